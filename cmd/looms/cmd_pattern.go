@@ -15,14 +15,18 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/patterns"
 	"github.com/teradata-labs/loom/pkg/tui/client"
 )
 
@@ -78,20 +82,96 @@ Press Ctrl+C to stop watching.`,
 	Run: runPatternWatch,
 }
 
+var patternLintCmd = &cobra.Command{
+	Use:   "lint [patterns-dir]",
+	Short: "Check patterns for matching problems",
+	Long: `Check a pattern library for problems that make patterns hard to match:
+short descriptions, missing use cases, use cases that only repeat the title,
+patterns with no keywords beyond their category, and patterns whose keywords
+overlap heavily with another pattern's.
+
+Exits with status 1 if any findings are reported.
+
+Examples:
+  # Lint a patterns directory
+  looms pattern lint ./patterns
+
+  # Emit findings as JSON
+  looms pattern lint ./patterns --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runPatternLint,
+}
+
+var patternCollisionsCmd = &cobra.Command{
+	Use:   "collisions [patterns-dir]",
+	Short: "Report patterns likely to be confused with each other",
+	Long: `Compare every pair of patterns in a library and report the pairs whose
+searchable terms overlap enough that keyword scoring cannot tell them apart,
+with the shared terms driving the overlap. Pairs at the top of the list rely
+on the LLM re-ranker to choose between them and need sharper descriptions
+and use cases.
+
+Examples:
+  # Report pairs sharing at least 30% of their terms
+  looms pattern collisions ./patterns
+
+  # Only report near-duplicates, as JSON
+  looms pattern collisions ./patterns --threshold 0.6 --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runPatternCollisions,
+}
+
+var patternServeCmd = &cobra.Command{
+	Use:   "serve [patterns-dir]",
+	Short: "Serve pattern recommendations as a JSON HTTP API",
+	Long: `Serve pattern selection for a pattern library over HTTP, so web frontends
+and clients in other languages can use it without embedding Go.
+
+Endpoints (POST, JSON body {"message": "...", "intent": "analytics"}):
+  /v1/patterns:recommend      Best pattern with confidence, reason, and explanation
+  /v1/patterns:recommendTopN  Up to "n" candidates, best first (default 5)
+  /v1/patterns:explain        Every scored candidate and the re-ranker's choice
+
+The intent is classified from the message when omitted. Ambiguous queries are
+re-ranked by the LLM provider from the looms config; --timeout bounds each
+request, including the re-ranker's LLM call, and a request may set a shorter
+"timeout_ms".
+
+Examples:
+  # Serve ./patterns on the default address
+  looms pattern serve ./patterns
+
+  # Keyword scoring only, without an LLM provider
+  looms pattern serve ./patterns --addr 0.0.0.0:5007 --no-llm
+
+  # Query it
+  curl -s localhost:5007/v1/patterns:recommend -d '{"message": "revenue by region"}'`,
+	Args: cobra.ExactArgs(1),
+	Run:  runPatternServe,
+}
+
 var (
-	patternAgentID     string
-	patternCategory    string
-	patternFile        string
-	patternStdin       bool
-	patternInteractive bool
-	patternServer      string
-	patternTimeout     int
+	patternAddr          string
+	patternNoLLM         bool
+	patternMinConfidence float64
+	patternLintJSON      bool
+	patternThreshold     float64
+	patternAgentID       string
+	patternCategory      string
+	patternFile          string
+	patternStdin         bool
+	patternInteractive   bool
+	patternServer        string
+	patternTimeout       int
 )
 
 func init() {
 	rootCmd.AddCommand(patternCmd)
 	patternCmd.AddCommand(patternCreateCmd)
 	patternCmd.AddCommand(patternWatchCmd)
+	patternCmd.AddCommand(patternLintCmd)
+	patternCmd.AddCommand(patternCollisionsCmd)
+	patternCmd.AddCommand(patternServeCmd)
 
 	// Create command flags
 	patternCreateCmd.Flags().StringVar(&patternAgentID, "thread", "", "Thread ID to create pattern for (required)")
@@ -107,6 +187,116 @@ func init() {
 	patternWatchCmd.Flags().StringVar(&patternAgentID, "thread", "", "Filter by thread ID (optional)")
 	patternWatchCmd.Flags().StringVar(&patternCategory, "category", "", "Filter by pattern category (optional)")
 	patternWatchCmd.Flags().StringVar(&patternServer, "server", "localhost:9090", "Loom server address")
+
+	// Lint command flags
+	patternLintCmd.Flags().BoolVar(&patternLintJSON, "json", false, "Output findings as JSON")
+
+	// Collisions command flags
+	patternCollisionsCmd.Flags().Float64Var(&patternThreshold, "threshold", 0.3, "Minimum term overlap (0-1) to report")
+	patternCollisionsCmd.Flags().BoolVar(&patternLintJSON, "json", false, "Output collisions as JSON")
+
+	// Serve command flags
+	patternServeCmd.Flags().StringVar(&patternAddr, "addr", "127.0.0.1:5007", "Address to listen on")
+	patternServeCmd.Flags().IntVar(&patternTimeout, "timeout", 30, "Request timeout in seconds, including LLM re-ranking (0 = no limit)")
+	patternServeCmd.Flags().BoolVar(&patternNoLLM, "no-llm", false, "Use keyword scoring only, without LLM re-ranking")
+	patternServeCmd.Flags().Float64Var(&patternMinConfidence, "min-confidence", 0, "Recommend no pattern below this confidence (0-1)")
+}
+
+func runPatternLint(cmd *cobra.Command, args []string) {
+	findings := patterns.LintLibrary(openPatternDir(args[0]))
+
+	if patternLintJSON {
+		if findings == nil {
+			findings = []patterns.LintFinding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to encode findings: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+		if len(findings) == 0 {
+			fmt.Println("✅ No lint findings")
+		} else {
+			fmt.Fprintf(os.Stderr, "\n%d finding(s)\n", len(findings))
+		}
+	}
+
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+func runPatternCollisions(cmd *cobra.Command, args []string) {
+	collisions := openPatternDir(args[0]).FindCollisions(patternThreshold)
+
+	if patternLintJSON {
+		if collisions == nil {
+			collisions = []patterns.Collision{}
+		}
+		data, err := json.MarshalIndent(collisions, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to encode collisions: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(collisions) == 0 {
+		fmt.Printf("✅ No pattern pairs overlap by %.0f%% or more\n", patternThreshold*100)
+		return
+	}
+	for _, c := range collisions {
+		fmt.Printf("%3.0f%%  %s <-> %s\n      shared: %s\n", c.Similarity*100, c.A, c.B, strings.Join(c.SharedTerms, ", "))
+	}
+}
+
+func runPatternServe(cmd *cobra.Command, args []string) {
+	orchestrator := patterns.NewOrchestrator(openPatternDir(args[0]))
+	orchestrator.SetMinConfidence(patternMinConfidence)
+	if !patternNoLLM {
+		llmProvider, providerName := createLLMProvider()
+		orchestrator.SetLLMProvider(llmProvider)
+		fmt.Printf("🤖 Re-ranking with %s (%s)\n", providerName, llmProvider.Model())
+	}
+
+	srv := &http.Server{
+		Addr:              patternAddr,
+		Handler:           patterns.NewHTTPHandler(orchestrator, time.Duration(patternTimeout)*time.Second),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt)
+		<-sigCh
+		fmt.Println("\nStopping pattern server...")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	fmt.Printf("📚 Serving %d patterns from %s on http://%s\n", len(orchestrator.GetLibrary().ListAll()), args[0], patternAddr)
+	fmt.Println("   POST " + patterns.HTTPPathRecommend + ", " + patterns.HTTPPathRecommendTopN + ", " + patterns.HTTPPathExplain)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// openPatternDir returns a library for a patterns directory, exiting if it does not exist.
+func openPatternDir(dir string) *patterns.Library {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Error: %s is not a patterns directory\n", dir)
+		os.Exit(1)
+	}
+	return patterns.NewLibrary(nil, dir)
 }
 
 func runPatternCreate(cmd *cobra.Command, args []string) {
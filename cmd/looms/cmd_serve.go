@@ -49,6 +49,7 @@ import (
 	"github.com/teradata-labs/loom/pkg/mcp/apps"
 	"github.com/teradata-labs/loom/pkg/mcp/manager"
 	"github.com/teradata-labs/loom/pkg/metaagent/learning"
+	"github.com/teradata-labs/loom/pkg/metrics"
 	"github.com/teradata-labs/loom/pkg/observability"
 	"github.com/teradata-labs/loom/pkg/orchestration"
 	"github.com/teradata-labs/loom/pkg/prompts"
@@ -386,6 +387,12 @@ func runServe(cmd *cobra.Command, args []string) {
 
 	logger.Info("Starting Loom Server", zap.String("version", rootCmd.Version))
 
+	// Redaction of logged content and trace attributes is on by default
+	if !config.Logging.Redact {
+		observability.SetRedactor(nil)
+		logger.Warn("Log and trace redaction disabled (logging.redact: false)")
+	}
+
 	// Show actual config file used (not just the --config flag)
 	configFileUsed := viper.ConfigFileUsed()
 	if configFileUsed != "" {
@@ -468,6 +475,14 @@ func runServe(cmd *cobra.Command, args []string) {
 		tracer = observability.NewNoOpTracer()
 	}
 
+	// Prometheus metrics (opt-in via server.admin_addr). Wrapping the tracer lets LLM
+	// call latency recorded by every agent's instrumented provider reach the histogram.
+	var promMetrics *metrics.Metrics
+	if config.Server.AdminAddr != "" {
+		promMetrics = metrics.New()
+		tracer = promMetrics.Tracer(tracer)
+	}
+
 	// Create session store
 	logger.Info("Database configuration",
 		zap.String("path", config.Database.Path),
@@ -477,6 +492,11 @@ func runServe(cmd *cobra.Command, args []string) {
 		logger.Fatal("Failed to create session store", zap.Error(err))
 	}
 	defer store.Close()
+	if config.Database.SessionTTLSeconds > 0 {
+		ttl := time.Duration(config.Database.SessionTTLSeconds) * time.Second
+		store.SetSessionTTL(ttl, time.Duration(config.Database.SessionSweepIntervalSeconds)*time.Second)
+		logger.Info("Session expiry enabled", zap.Duration("ttl", ttl))
+	}
 
 	// Create error store (uses same database for error submission channel)
 	errorStore, err := agent.NewSQLiteErrorStore(config.Database.Path, tracer)
@@ -813,6 +833,11 @@ func runServe(cmd *cobra.Command, args []string) {
 		logger.Info("MCP manager initialized successfully", zap.Int("servers_started", len(config.MCP.Servers)))
 	}
 
+	// Apply per-tool concurrency limits declared in tool metadata (shared by all agents)
+	if err := builtin.ApplyConcurrencyLimits(shuttle.DefaultConcurrencyLimiter()); err != nil {
+		logger.Warn("Failed to apply tool concurrency limits", zap.Error(err))
+	}
+
 	// Create tool registry for dynamic tool discovery
 	var toolRegistry *toolregistry.Registry
 	{
@@ -1049,6 +1074,7 @@ func runServe(cmd *cobra.Command, args []string) {
 					SystemPrompt:      cfg.SystemPrompt,
 					Rom:               cfg.Rom,      // ROM identifier for domain-specific knowledge
 					Metadata:          cfg.Metadata, // Metadata includes backend_path for ROM auto-detection
+					CanSpawn:          agent.SpawnAllowlist(cfg),
 					MaxTurns:          maxTurns,
 					MaxToolExecutions: maxToolExecutions,
 					EnableTracing:     config.Observability.Enabled,
@@ -1354,6 +1380,22 @@ func runServe(cmd *cobra.Command, args []string) {
 	loomService := server.NewMultiAgentServer(agents, store)
 	loomv1.RegisterLoomServiceServer(grpcServer, loomService)
 
+	// Share server sessions across instances when a networked backend is configured.
+	// Running spawned agents and their limits stay per instance (see SetSessionBackend).
+	if driver := config.Database.Driver; driver != "" && driver != "sqlite" {
+		backend, err := agent.NewSessionBackend(agent.SessionBackendConfig{
+			Driver: driver,
+			URL:    config.Database.URL,
+			TTL:    time.Duration(config.Database.SessionTTLSeconds) * time.Second,
+		}, tracer)
+		if err != nil {
+			logger.Fatal("Failed to create session backend", zap.String("driver", driver), zap.Error(err))
+		}
+		defer backend.Close()
+		loomService.SetSessionBackend(backend)
+		logger.Info("Shared session backend enabled", zap.String("driver", driver))
+	}
+
 	// Set logger for server operations
 	loomService.SetLogger(logger)
 
@@ -1367,6 +1409,13 @@ func runServe(cmd *cobra.Command, args []string) {
 		logger.Info("Agent registry configured on server for workflow execution")
 	}
 
+	// Wire custom tool factories (registered by custom builds) into agent sessions
+	toolFactories := builtin.DefaultToolFactories()
+	loomService.SetToolFactoryRegistry(toolFactories)
+	if names := toolFactories.Names(); len(names) > 0 {
+		logger.Info("Custom tool factories registered", zap.Strings("tools", names))
+	}
+
 	// Set clarification timeouts from config
 	if config.Server.Clarification.ChannelSendTimeoutMs > 0 {
 		loomService.SetClarificationConfig(config.Server.Clarification.ChannelSendTimeoutMs)
@@ -1384,6 +1433,42 @@ func runServe(cmd *cobra.Command, args []string) {
 		logger.Info("Observability tracer configured on server for workflow tracing")
 	}
 
+	if promMetrics != nil {
+		loomService.SetMetrics(promMetrics)
+	}
+
+	// Readiness (/readyz) reports the default LLM provider's reachability and the spawn limit
+	if llmProvider != nil {
+		loomService.SetHealthCheckLLM(llmProvider, 0)
+	}
+	if config.Server.MaxSpawnedAgents > 0 {
+		loomService.SetMaxSpawnedAgents(config.Server.MaxSpawnedAgents)
+		logger.Info("Global spawned agent limit configured", zap.Int("max_spawned_agents", config.Server.MaxSpawnedAgents))
+	}
+	if config.Server.SpawnRateLimit > 0 {
+		loomService.SetSpawnRateLimit(config.Server.SpawnRateLimit, config.Server.SpawnRateBurst)
+		logger.Info("Per-parent spawn rate limit configured",
+			zap.Float64("spawns_per_second", config.Server.SpawnRateLimit),
+			zap.Int("burst", config.Server.SpawnRateBurst))
+	}
+	if config.Server.MaxSpawnsPerWorkflow > 0 {
+		loomService.SetMaxSpawnsPerWorkflow(config.Server.MaxSpawnsPerWorkflow)
+		logger.Info("Per-workflow spawned agent limit configured", zap.Int("max_spawns_per_workflow", config.Server.MaxSpawnsPerWorkflow))
+	}
+	for _, wl := range config.Server.WorkflowSpawnLimits {
+		if wl.WorkflowID != "" {
+			loomService.SetWorkflowSpawnLimit(wl.WorkflowID, wl.MaxSpawns)
+		}
+	}
+	if config.Server.RequireSpawnAllowlist {
+		loomService.SetRequireSpawnAllowlist(true)
+		logger.Info("Spawning restricted to agents with a can_spawn allowlist")
+	}
+	if config.Server.SessionIDPrefix != "" {
+		loomService.SetSessionIDPrefix(config.Server.SessionIDPrefix)
+		logger.Info("Session ID prefix configured", zap.String("session_id_prefix", config.Server.SessionIDPrefix))
+	}
+
 	// Register embedded MCP UI apps for gRPC access (ListUIApps/GetUIApp RPCs)
 	uiRegistry := apps.NewUIResourceRegistry()
 	if err := apps.RegisterEmbeddedApps(uiRegistry); err != nil {
@@ -1952,6 +2037,7 @@ func runServe(cmd *cobra.Command, args []string) {
 				SystemPrompt:      agentConfig.SystemPrompt,
 				Rom:               agentConfig.Rom,      // ROM identifier for domain-specific knowledge
 				Metadata:          agentConfig.Metadata, // Metadata includes backend_path for ROM auto-detection
+				CanSpawn:          agent.SpawnAllowlist(agentConfig),
 				EnableTracing:     config.Observability.Enabled,
 			}
 
@@ -2248,6 +2334,23 @@ func runServe(cmd *cobra.Command, args []string) {
 			zap.String("health_endpoint", fmt.Sprintf("http://%s/health", httpAddr)))
 	}
 
+	// Start admin HTTP server (Prometheus /metrics and health probes) if configured
+	var adminSrv *server.AdminServer
+	if config.Server.AdminAddr != "" {
+		adminSrv = server.NewAdminServer(config.Server.AdminAddr, logger)
+		adminSrv.Handle("/metrics", promMetrics.Handler())
+		adminSrv.Handle("/healthz", server.LivenessHandler())
+		adminSrv.Handle("/readyz", loomService.ReadinessHandler())
+		go func() {
+			if err := adminSrv.Start(); err != nil {
+				logger.Error("Admin HTTP server failed", zap.Error(err))
+			}
+		}()
+		logger.Info("Admin endpoints available",
+			zap.String("metrics_endpoint", fmt.Sprintf("http://%s/metrics", config.Server.AdminAddr)),
+			zap.String("readiness_endpoint", fmt.Sprintf("http://%s/readyz", config.Server.AdminAddr)))
+	}
+
 	logger.Info("Ready to weave!")
 
 	// Start message queue monitor for event-driven workflow agent notifications
@@ -2284,6 +2387,26 @@ func runServe(cmd *cobra.Command, args []string) {
 			}
 		}
 
+		// Stop admin HTTP server
+		if adminSrv != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := adminSrv.Stop(ctx); err != nil {
+				logger.Warn("Error stopping admin HTTP server", zap.Error(err))
+			}
+		}
+
+		// Drain spawned agents and flush state while the bus and stores are still open
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 35*time.Second)
+		defer cancelShutdown()
+		if summary, err := loomService.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Error shutting down agent server", zap.Error(err))
+		} else {
+			logger.Info("Agent server stopped",
+				zap.Int("drained", len(summary.Drained)),
+				zap.Int("force_cancelled", len(summary.ForceCancelled)))
+		}
+
 		// Stop hot-reload watchers
 		if err := loomService.StopHotReload(); err != nil {
 			logger.Warn("Error stopping hot-reload", zap.Error(err))
@@ -154,13 +154,27 @@ type TUIConfig struct {
 
 // ServerConfig holds server-specific configuration.
 type ServerConfig struct {
-	Port             int                 `mapstructure:"port"`
-	Host             string              `mapstructure:"host"`
-	HTTPPort         int                 `mapstructure:"http_port"` // HTTP/REST+SSE port (default: 5006, 0=disabled)
-	EnableReflection bool                `mapstructure:"enable_reflection"`
-	TLS              TLSConfig           `mapstructure:"tls"`
-	Clarification    ClarificationConfig `mapstructure:"clarification"` // Clarification question timeouts
-	CORS             CORSServerConfig    `mapstructure:"cors"`          // CORS configuration for HTTP endpoints
+	Port                  int                  `mapstructure:"port"`
+	Host                  string               `mapstructure:"host"`
+	HTTPPort              int                  `mapstructure:"http_port"`               // HTTP/REST+SSE port (default: 5006, 0=disabled)
+	AdminAddr             string               `mapstructure:"admin_addr"`              // Admin listener for /metrics, /healthz, /readyz (e.g. "127.0.0.1:9090"; empty=disabled)
+	MaxSpawnedAgents      int                  `mapstructure:"max_spawned_agents"`      // Global cap on concurrently spawned sub-agents (0=unlimited)
+	SpawnRateLimit        float64              `mapstructure:"spawn_rate_limit"`        // Spawns per second allowed per parent session after the burst (0=unlimited)
+	SpawnRateBurst        int                  `mapstructure:"spawn_rate_burst"`        // Spawns a parent session may make at once before the rate applies
+	RequireSpawnAllowlist bool                 `mapstructure:"require_spawn_allowlist"` // Agents without can_spawn may not spawn (false=they may spawn any agent)
+	MaxSpawnsPerWorkflow  int                  `mapstructure:"max_spawns_per_workflow"` // Cap on concurrently spawned sub-agents per workflow ID (0=unlimited)
+	WorkflowSpawnLimits   []WorkflowSpawnLimit `mapstructure:"workflow_spawn_limits"`   // Per-workflow overrides of max_spawns_per_workflow
+	SessionIDPrefix       string               `mapstructure:"session_id_prefix"`       // Prefix of generated session IDs, e.g. "prod" for prod_1a2b3c4d (empty=sess)
+	EnableReflection      bool                 `mapstructure:"enable_reflection"`
+	TLS                   TLSConfig            `mapstructure:"tls"`
+	Clarification         ClarificationConfig  `mapstructure:"clarification"` // Clarification question timeouts
+	CORS                  CORSServerConfig     `mapstructure:"cors"`          // CORS configuration for HTTP endpoints
+}
+
+// WorkflowSpawnLimit overrides max_spawns_per_workflow for one workflow.
+type WorkflowSpawnLimit struct {
+	WorkflowID string `mapstructure:"workflow_id"`
+	MaxSpawns  int    `mapstructure:"max_spawns"` // 0=unlimited
 }
 
 // CORSServerConfig holds CORS configuration for HTTP endpoints.
@@ -278,7 +292,17 @@ type LLMConfig struct {
 // DatabaseConfig holds database configuration.
 type DatabaseConfig struct {
 	Path   string `mapstructure:"path"`
-	Driver string `mapstructure:"driver"` // sqlite, postgres (future)
+	Driver string `mapstructure:"driver"` // sqlite, redis, postgres
+
+	// Connection URL for the redis and postgres drivers. Sessions created by the server
+	// (including spawned agents) are stored there and shared by every instance pointing
+	// at it; path is still used for agent memory and the error store.
+	URL string `mapstructure:"url"`
+
+	// Session expiry: sessions not updated for this long are deleted (default: 0, disabled)
+	SessionTTLSeconds int `mapstructure:"session_ttl_seconds"`
+	// How often expired sessions are swept (default: 0, a tenth of the TTL)
+	SessionSweepIntervalSeconds int `mapstructure:"session_sweep_interval_seconds"`
 }
 
 // CommunicationConfig holds tiered communication configuration for inter-agent messaging.
@@ -351,6 +375,7 @@ type LoggingConfig struct {
 	Level  string `mapstructure:"level"`  // debug, info, warn, error
 	Format string `mapstructure:"format"` // text, json
 	File   string `mapstructure:"file"`   // File path for log output (optional, defaults to stdout/stderr)
+	Redact bool   `mapstructure:"redact"` // Mask PII/secrets in logged content and traces (default: true; disable for development)
 }
 
 // AgentsConfig holds configuration for multiple agents (for no-code deployment).
@@ -694,6 +719,11 @@ func fixMCPEnvCase(config *Config, configFile string) error {
 // 3. Environment variables
 // 4. Defaults (lowest priority)
 func LoadConfig(cfgFile string) (*Config, error) {
+	// Fail on an unusable data directory before anything reads or writes it
+	if err := loomconfig.ValidateDataDir(); err != nil {
+		return nil, err
+	}
+
 	// Set defaults
 	setDefaults()
 
@@ -759,6 +789,13 @@ func setDefaults() {
 	viper.SetDefault("server.port", 60051)
 	viper.SetDefault("server.host", "0.0.0.0")
 	viper.SetDefault("server.enable_reflection", true)
+	viper.SetDefault("server.admin_addr", "")        // Admin /metrics listener is opt-in
+	viper.SetDefault("server.max_spawned_agents", 0) // No global spawn cap by default
+	viper.SetDefault("server.spawn_rate_limit", 0)   // No per-parent spawn rate limit by default
+	viper.SetDefault("server.spawn_rate_burst", 5)
+	viper.SetDefault("server.require_spawn_allowlist", false) // Agents without can_spawn may spawn any agent
+	viper.SetDefault("server.max_spawns_per_workflow", 0)     // No per-workflow spawn cap by default
+	viper.SetDefault("server.session_id_prefix", "")          // Session IDs look like sess_1a2b3c4d
 
 	// Clarification defaults
 	viper.SetDefault("server.clarification.rpc_timeout_seconds", 5)
@@ -796,6 +833,7 @@ func setDefaults() {
 	defaultDBPath := filepath.Join(loomconfig.GetLoomDataDir(), "loom.db")
 	viper.SetDefault("database.path", defaultDBPath)
 	viper.SetDefault("database.driver", "sqlite")
+	viper.SetDefault("database.session_ttl_seconds", 0) // Sessions never expire
 
 	// Communication defaults (SQLite-backed, auto-promote enabled)
 	viper.SetDefault("communication.store.backend", "sqlite")
@@ -815,6 +853,7 @@ func setDefaults() {
 	// Logging defaults
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "text")
+	viper.SetDefault("logging.redact", true)
 
 	// Tool permissions defaults
 	viper.SetDefault("tools.permissions.require_approval", false)
@@ -1178,6 +1217,18 @@ func (c *Config) Validate() error {
 	if c.Database.Path == "" {
 		return fmt.Errorf("database.path is required")
 	}
+	switch c.Database.Driver {
+	case "", "sqlite":
+	case "redis", "postgres":
+		if c.Database.URL == "" {
+			return fmt.Errorf("database.url is required for the %s driver", c.Database.Driver)
+		}
+	default:
+		return fmt.Errorf("unsupported database driver: %s (must be sqlite, redis, or postgres)", c.Database.Driver)
+	}
+	if c.Database.SessionTTLSeconds < 0 || c.Database.SessionSweepIntervalSeconds < 0 {
+		return fmt.Errorf("database.session_ttl_seconds and database.session_sweep_interval_seconds cannot be negative")
+	}
 
 	// Validate observability config
 	if c.Observability.Enabled {
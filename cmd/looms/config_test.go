@@ -14,6 +14,8 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -43,6 +45,22 @@ func TestLoadConfig_WithAgents(t *testing.T) {
 	assert.False(t, sqliteAgent.EnableTracing)
 }
 
+func TestLoadConfig_WorkflowSpawnLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "looms.yaml")
+	content := `server:
+  max_spawns_per_workflow: 8
+  workflow_spawn_limits:
+    - workflow_id: Dungeon-Crawl
+      max_spawns: 20
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, 8, config.Server.MaxSpawnsPerWorkflow)
+	assert.Equal(t, []WorkflowSpawnLimit{{WorkflowID: "Dungeon-Crawl", MaxSpawns: 20}}, config.Server.WorkflowSpawnLimits)
+}
+
 func TestGenerateExampleConfig(t *testing.T) {
 	// Verify example config contains agent configuration
 	exampleConfig := GenerateExampleConfig()
@@ -0,0 +1,93 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pattern
+
+import (
+	"strings"
+
+	"github.com/teradata-labs/loom/internal/tui/styles"
+	"github.com/teradata-labs/loom/pkg/patterns"
+)
+
+// Card section headings, in display order
+const (
+	cardUseCasesHeading    = "Use Cases"
+	cardDescriptionHeading = "Description"
+)
+
+// cardBullet prefixes each use case in a pattern card
+const cardBullet = "• "
+
+// patternCard renders a loaded pattern as plain card lines (title, metadata, use
+// cases, description) and returns the highlighter that styles them. The text stays
+// unstyled so search, copy, and wrapping work on it like file content.
+func patternCard(p *patterns.Pattern) (string, syntaxHighlighter) {
+	title := p.Title
+	if title == "" {
+		title = p.Name
+	}
+
+	var meta []string
+	for _, field := range []struct{ label, value string }{
+		{"Category", p.Category},
+		{"Difficulty", p.Difficulty},
+		{"Backend", p.BackendType},
+	} {
+		if field.value != "" {
+			meta = append(meta, field.label+": "+field.value)
+		}
+	}
+	metaLine := strings.Join(meta, "  ·  ")
+
+	lines := []string{title}
+	if metaLine != "" {
+		lines = append(lines, metaLine)
+	}
+
+	if len(p.UseCases) > 0 {
+		lines = append(lines, "", cardUseCasesHeading)
+		for _, useCase := range p.UseCases {
+			lines = append(lines, cardBullet+strings.TrimSpace(useCase))
+		}
+	}
+
+	if description := strings.TrimSpace(p.Description); description != "" {
+		lines = append(lines, "", cardDescriptionHeading)
+		lines = append(lines, strings.Split(description, "\n")...)
+	}
+
+	return strings.Join(lines, "\n"), cardHighlighter{title: title, meta: metaLine}
+}
+
+// cardHighlighter styles the lines produced by patternCard
+type cardHighlighter struct {
+	title string
+	meta  string
+}
+
+func (h cardHighlighter) highlightLine(line string, t *styles.Theme) string {
+	switch {
+	case line == h.title:
+		return t.S().Base.Foreground(t.Primary).Bold(true).Render(line)
+	case h.meta != "" && line == h.meta:
+		return t.S().Base.Foreground(t.FgMuted).Render(line)
+	case line == cardUseCasesHeading || line == cardDescriptionHeading:
+		return t.S().Base.Foreground(t.Secondary).Bold(true).Render(line)
+	case strings.HasPrefix(line, cardBullet):
+		bullet := t.S().Base.Foreground(t.Secondary).Render(cardBullet)
+		return bullet + t.S().Base.Foreground(t.FgBase).Render(strings.TrimPrefix(line, cardBullet))
+	default:
+		return t.S().Base.Foreground(t.FgBase).Render(line)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pattern
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/teradata-labs/loom/internal/tui/styles"
+)
+
+// syntaxHighlighter styles one line of file content for the pattern viewer.
+// Highlighters work line by line so the viewer can style lines independently.
+type syntaxHighlighter interface {
+	highlightLine(line string, t *styles.Theme) string
+}
+
+// highlighterFor selects a highlighter from the file extension. Files without an
+// extension are treated as YAML (the pattern format); unknown extensions are plain.
+func highlighterFor(fileName string) syntaxHighlighter {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yaml", ".yml", "":
+		return yamlHighlighter{}
+	case ".json":
+		return jsonHighlighter{}
+	case ".md", ".markdown":
+		return markdownHighlighter{}
+	default:
+		return plainHighlighter{}
+	}
+}
+
+// plainHighlighter renders lines without syntax styling
+type plainHighlighter struct{}
+
+func (plainHighlighter) highlightLine(line string, t *styles.Theme) string {
+	return t.S().Base.Foreground(t.FgBase).Render(line)
+}
+
+// yamlHighlighter applies basic syntax highlighting for YAML content
+type yamlHighlighter struct{}
+
+func (yamlHighlighter) highlightLine(line string, t *styles.Theme) string {
+	trimmed := strings.TrimSpace(line)
+
+	// Comment
+	if strings.HasPrefix(trimmed, "#") {
+		return t.S().Base.Foreground(t.FgMuted).Render(line)
+	}
+
+	// Key-value pair
+	if idx := strings.Index(line, ":"); idx != -1 {
+		key := line[:idx+1]
+		value := line[idx+1:]
+
+		styledKey := t.S().Base.Foreground(t.Primary).Bold(true).Render(key)
+		styledValue := t.S().Base.Foreground(t.FgBase).Render(value)
+
+		return styledKey + styledValue
+	}
+
+	// List item
+	if strings.HasPrefix(trimmed, "-") {
+		return t.S().Base.Foreground(t.FgBase).Render(line)
+	}
+
+	// Default
+	return t.S().Base.Foreground(t.FgBase).Render(line)
+}
+
+// jsonHighlighter colors JSON keys, strings, numbers, and literals.
+// Strings spanning lines are not tracked; JSON does not allow them.
+type jsonHighlighter struct{}
+
+func (jsonHighlighter) highlightLine(line string, t *styles.Theme) string {
+	base := t.S().Base
+	keyStyle := base.Foreground(t.Primary).Bold(true)
+	stringStyle := base.Foreground(t.Success)
+	numberStyle := base.Foreground(t.Warning)
+	literalStyle := base.Foreground(t.Info)
+	punctStyle := base.Foreground(t.FgMuted)
+	textStyle := base.Foreground(t.FgBase)
+
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := jsonStringEnd(line, i)
+			token := line[i:end]
+			// A string followed by a colon is an object key
+			if strings.HasPrefix(strings.TrimLeft(line[end:], " \t"), ":") {
+				b.WriteString(keyStyle.Render(token))
+			} else {
+				b.WriteString(stringStyle.Render(token))
+			}
+			i = end
+
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(line) && strings.IndexByte("0123456789.eE+-", line[end]) >= 0 {
+				end++
+			}
+			b.WriteString(numberStyle.Render(line[i:end]))
+			i = end
+
+		case strings.IndexByte("{}[],:", c) >= 0:
+			b.WriteString(punctStyle.Render(string(c)))
+			i++
+
+		case isASCIILetter(c):
+			end := i + 1
+			for end < len(line) && isASCIILetter(line[end]) {
+				end++
+			}
+			word := line[i:end]
+			if word == "true" || word == "false" || word == "null" {
+				b.WriteString(literalStyle.Render(word))
+			} else {
+				b.WriteString(textStyle.Render(word))
+			}
+			i = end
+
+		default:
+			// Whitespace and anything unexpected pass through unstyled
+			end := i + 1
+			for end < len(line) && strings.IndexByte("\"-0123456789{}[],:", line[end]) < 0 && !isASCIILetter(line[end]) {
+				end++
+			}
+			b.WriteString(line[i:end])
+			i = end
+		}
+	}
+	return b.String()
+}
+
+// jsonStringEnd returns the index just past the string starting at line[start],
+// honoring backslash escapes. Unterminated strings run to the end of the line.
+func jsonStringEnd(line string, start int) int {
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(line)
+}
+
+// isASCIILetter reports whether c is an ASCII letter
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// markdownHighlighter styles Markdown block syntax (headings, lists, quotes, code
+// fences) and inline code spans. Lines inside fenced code blocks are styled as
+// Markdown too, since lines are highlighted independently.
+type markdownHighlighter struct{}
+
+func (markdownHighlighter) highlightLine(line string, t *styles.Theme) string {
+	base := t.S().Base
+	trimmed := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(trimmed)]
+
+	switch {
+	case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+		return base.Foreground(t.FgMuted).Render(line)
+
+	case strings.HasPrefix(trimmed, "#"):
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		if level <= 6 && (len(trimmed) == level || trimmed[level] == ' ') {
+			return base.Foreground(t.Primary).Bold(true).Render(line)
+		}
+
+	case strings.HasPrefix(trimmed, ">"):
+		return base.Foreground(t.FgMuted).Italic(true).Render(line)
+	}
+
+	// List markers: "-", "*", "+", or "1."
+	if marker := markdownListMarker(trimmed); marker != "" {
+		return indent +
+			base.Foreground(t.Secondary).Bold(true).Render(marker) +
+			markdownInline(trimmed[len(marker):], t)
+	}
+
+	return indent + markdownInline(trimmed, t)
+}
+
+// markdownListMarker returns the list marker (including its trailing space) that
+// starts s, or "" if s is not a list item.
+func markdownListMarker(s string) string {
+	if len(s) >= 2 && strings.IndexByte("-*+", s[0]) >= 0 && s[1] == ' ' {
+		return s[:2]
+	}
+	digits := 0
+	for digits < len(s) && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits+1 < len(s) && s[digits] == '.' && s[digits+1] == ' ' {
+		return s[:digits+2]
+	}
+	return ""
+}
+
+// markdownInline styles `code` spans within a line of Markdown text
+func markdownInline(s string, t *styles.Theme) string {
+	textStyle := t.S().Base.Foreground(t.FgBase)
+	codeStyle := t.S().Base.Foreground(t.Info)
+
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(s, '`')
+		if open == -1 {
+			break
+		}
+		closeIdx := strings.IndexByte(s[open+1:], '`')
+		if closeIdx == -1 {
+			break
+		}
+		closeIdx += open + 1
+		if open > 0 {
+			b.WriteString(textStyle.Render(s[:open]))
+		}
+		b.WriteString(codeStyle.Render(s[open : closeIdx+1]))
+		s = s[closeIdx+1:]
+	}
+	if s != "" {
+		b.WriteString(textStyle.Render(s))
+	}
+	return b.String()
+}
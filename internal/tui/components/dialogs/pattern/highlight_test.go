@@ -0,0 +1,74 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pattern
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/teradata-labs/loom/internal/tui/styles"
+)
+
+func TestHighlighterFor(t *testing.T) {
+	assert.IsType(t, yamlHighlighter{}, highlighterFor("patterns/sql/join.yaml"))
+	assert.IsType(t, yamlHighlighter{}, highlighterFor("agent.YML"))
+	assert.IsType(t, yamlHighlighter{}, highlighterFor("Patternfile"))
+	assert.IsType(t, jsonHighlighter{}, highlighterFor("examples/request.json"))
+	assert.IsType(t, markdownHighlighter{}, highlighterFor("README.md"))
+	assert.IsType(t, markdownHighlighter{}, highlighterFor("notes.markdown"))
+	assert.IsType(t, plainHighlighter{}, highlighterFor("query.sql"))
+}
+
+func TestHighlighters_PreserveText(t *testing.T) {
+	theme := styles.CurrentTheme()
+	lines := map[syntaxHighlighter][]string{
+		yamlHighlighter{}: {
+			"name: sql_join",
+			"  # comment",
+			"  - item",
+		},
+		jsonHighlighter{}: {
+			`{"name": "join", "weight": -1.5e3, "enabled": true, "tags": ["a", null]},`,
+			`  "escaped": "say \"hi\"",`,
+			`  "unterminated`,
+			`  "unicode": "héllo wörld"`,
+		},
+		markdownHighlighter{}: {
+			"## Use Cases",
+			"  - Run `SELECT 1` first",
+			"10. Tenth step",
+			"> quoted",
+			"```sql",
+			"#hashtag is not a heading",
+			"plain `unclosed code",
+		},
+		plainHighlighter{}: {"SELECT * FROM t;"},
+	}
+
+	for highlighter, input := range lines {
+		for _, line := range input {
+			assert.Equal(t, line, ansi.Strip(highlighter.highlightLine(line, theme)), "%T: %q", highlighter, line)
+		}
+	}
+}
+
+func TestMarkdownListMarker(t *testing.T) {
+	assert.Equal(t, "- ", markdownListMarker("- item"))
+	assert.Equal(t, "* ", markdownListMarker("* item"))
+	assert.Equal(t, "12. ", markdownListMarker("12. item"))
+	assert.Empty(t, markdownListMarker("-item"))
+	assert.Empty(t, markdownListMarker("12.5 percent"))
+	assert.Empty(t, markdownListMarker("plain"))
+}
@@ -17,23 +17,41 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/teradata-labs/loom/internal/tui/components/dialogs"
 	"github.com/teradata-labs/loom/internal/tui/styles"
 	"github.com/teradata-labs/loom/internal/tui/util"
+	"github.com/teradata-labs/loom/pkg/patterns"
 )
 
 const (
 	patternViewerDialogID dialogs.DialogID = "pattern-viewer"
+
+	// contentHeaderLines is the number of viewport lines above the file content
+	// (the source and a blank line)
+	contentHeaderLines = 2
+
+	// statusFlashDuration is how long a status message stays visible
+	statusFlashDuration = 2 * time.Second
+
+	// lazyRenderBuffer is the number of content lines styled above and below the
+	// viewport, so small scrolls don't re-render
+	lazyRenderBuffer = 50
 )
 
-// PatternViewerDialog shows pattern file content in a scrollable viewer
+// PatternViewerDialog shows a pattern in a scrollable viewer, either as a structured
+// card loaded from a pattern library or as the raw file
 type PatternViewerDialog interface {
 	dialogs.DialogModel
 }
@@ -45,6 +63,47 @@ type patternViewerDialogCmp struct {
 	filePath string
 	fileName string
 	content  string
+	lines    []string // content split into lines, set on load
+	loading  bool
+	loadErr  error
+
+	// library and patternName are set when viewing a pattern card; the raw file
+	// viewer reads filePath instead
+	library     *patterns.Library
+	patternName string
+
+	highlighter syntaxHighlighter
+	lineNumbers bool
+
+	// Search state: the input is open while searching; matches stay highlighted
+	// after it closes until the search is cleared
+	searching   bool
+	searchInput textinput.Model
+	searchQuery string
+	matches     []searchMatch
+	matchIndex  int
+
+	// wrap soft-wraps long lines to the content width instead of clipping them.
+	// rowOffsets maps each content line to its first rendered line (excluding the
+	// header), with a final entry for the total, as set by buildContent for
+	// rowOffsetsWidth (the wrap width, or 0 when not wrapping).
+	wrap            bool
+	rowOffsets      []int
+	rowOffsetsWidth int
+
+	// Lazy rendering: buildContent only styles content lines in [renderFrom,
+	// renderTo), around the viewport, and emits blank rows for the rest so the
+	// scroll height stays right. styled caches highlighter output per line for
+	// styledTheme.
+	renderFrom, renderTo int
+	styled               []string
+	styledTheme          *styles.Theme
+
+	// Transient status line (e.g., copy confirmation). statusID ties each flash to
+	// its clear timer so an older timer never clears a newer message.
+	status      string
+	statusIsErr bool
+	statusID    int
 
 	viewport viewport.Model
 	keys     PatternViewerKeyMap
@@ -56,12 +115,43 @@ type patternViewerDialogCmp struct {
 
 // PatternViewerKeyMap defines key bindings for pattern viewer dialog
 type PatternViewerKeyMap struct {
-	Close key.Binding
+	Search    key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	Copy      key.Binding
+	Wrap      key.Binding
+	Retry     key.Binding
+	Close     key.Binding
 }
 
 // DefaultPatternViewerKeyMap returns default key bindings
 func DefaultPatternViewerKeyMap() PatternViewerKeyMap {
 	return PatternViewerKeyMap{
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "prev match"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy file"),
+		),
+		Wrap: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "wrap lines"),
+		),
+		Retry: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "retry"),
+			key.WithDisabled(),
+		),
 		Close: key.NewBinding(
 			key.WithKeys("esc", "q"),
 			key.WithHelp("esc/q", "close"),
@@ -71,36 +161,83 @@ func DefaultPatternViewerKeyMap() PatternViewerKeyMap {
 
 // ShortHelp returns key bindings for the short help view
 func (k PatternViewerKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Close}
+	return []key.Binding{k.Search, k.NextMatch, k.PrevMatch, k.Copy, k.Wrap, k.Retry, k.Close}
 }
 
 // FullHelp returns key bindings for the full help view
 func (k PatternViewerKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Close},
+		{k.Search, k.NextMatch, k.PrevMatch},
+		{k.Copy, k.Wrap, k.Retry, k.Close},
 	}
 }
 
+// contentLoadedMsg carries the content read in the background, or the error that
+// prevented reading it. A non-nil highlighter replaces the viewer's, as pattern
+// cards style their own layout.
+type contentLoadedMsg struct {
+	filePath    string
+	content     string
+	highlighter syntaxHighlighter
+	err         error
+}
+
+// clipboardWrittenMsg reports the result of writing the file to the system clipboard
+type clipboardWrittenMsg struct {
+	err error
+}
+
+// clearStatusMsg clears the status line if it still shows flash id
+type clearStatusMsg struct {
+	id int
+}
+
+// writeClipboard writes to the system clipboard; a variable so tests can stub it
+var writeClipboard = clipboard.WriteAll
+
+// NewPatternViewerDialog creates a viewer for the raw pattern file, with line
+// numbers and syntax highlighting. The file is read asynchronously from Init so
+// large files don't block dialog creation.
 func NewPatternViewerDialog(filePath string) PatternViewerDialog {
+	m := newPatternViewer()
+	m.filePath = filePath
+	m.fileName = filepath.Base(filePath)
+	m.highlighter = highlighterFor(filePath)
+	m.lineNumbers = true
+	return m
+}
+
+// NewPatternViewerFromLibrary creates a viewer that loads the named pattern from lib
+// and shows it as a card with its title, category, use cases, and description.
+// Long lines wrap by default since the card is prose.
+func NewPatternViewerFromLibrary(lib *patterns.Library, name string) PatternViewerDialog {
+	m := newPatternViewer()
+	m.library = lib
+	m.patternName = name
+	m.fileName = name
+	m.highlighter = plainHighlighter{}
+	m.wrap = true
+	return m
+}
+
+func newPatternViewer() *patternViewerDialogCmp {
 	t := styles.CurrentTheme()
 	h := help.New()
 	h.Styles = t.S().Help
 
-	// Read file content
-	// #nosec G304 -- filePath comes from user selecting a pattern file in the sidebar
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		content = []byte("Error reading file: " + err.Error())
-	}
+	si := textinput.New()
+	si.Prompt = "/"
+	si.Placeholder = "search"
 
-	return &patternViewerDialogCmp{
-		filePath: filePath,
-		fileName: filepath.Base(filePath),
-		content:  string(content),
-		viewport: viewport.New(),
-		keys:     DefaultPatternViewerKeyMap(),
-		help:     h,
+	m := &patternViewerDialogCmp{
+		loading:     true,
+		searchInput: si,
+		viewport:    viewport.New(),
+		keys:        DefaultPatternViewerKeyMap(),
+		help:        h,
 	}
+	m.updateKeyStates()
+	return m
 }
 
 func (m *patternViewerDialogCmp) ID() dialogs.DialogID {
@@ -112,7 +249,33 @@ func (m *patternViewerDialogCmp) Position() (int, int) {
 }
 
 func (m *patternViewerDialogCmp) Init() tea.Cmd {
-	return m.viewport.Init()
+	return tea.Batch(m.viewport.Init(), m.loadContent())
+}
+
+// loadContent reads the file, or loads the pattern card, in the background and
+// posts a contentLoadedMsg
+func (m *patternViewerDialogCmp) loadContent() tea.Cmd {
+	if m.library != nil {
+		lib, name := m.library, m.patternName
+		return func() tea.Msg {
+			p, err := lib.Load(name)
+			if err != nil {
+				return contentLoadedMsg{err: err}
+			}
+			content, highlighter := patternCard(p)
+			return contentLoadedMsg{content: content, highlighter: highlighter}
+		}
+	}
+
+	filePath := m.filePath
+	return func() tea.Msg {
+		// #nosec G304 -- filePath comes from user selecting a pattern file in the sidebar
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return contentLoadedMsg{filePath: filePath, err: err}
+		}
+		return contentLoadedMsg{filePath: filePath, content: string(content)}
+	}
 }
 
 func (m *patternViewerDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
@@ -124,9 +287,80 @@ func (m *patternViewerDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		m.wWidth, m.wHeight = msg.Width, msg.Height
 		return m, m.resize()
 
+	case contentLoadedMsg:
+		if msg.filePath != m.filePath {
+			return m, nil
+		}
+		m.content = msg.content
+		m.lines = splitLines(msg.content)
+		m.loading = false
+		m.loadErr = msg.err
+		if msg.highlighter != nil {
+			m.highlighter = msg.highlighter
+		}
+		m.styled = nil
+		m.rowOffsets = nil
+		m.updateKeyStates()
+		m.matches = findMatches(m.lines, m.searchQuery)
+		m.matchIndex = 0
+		m.refreshContent()
+		return m, nil
+
+	case clipboardWrittenMsg:
+		if msg.err != nil {
+			return m, m.flashStatus("Could not access the system clipboard: "+msg.err.Error(), true)
+		}
+		return m, m.flashStatus(fmt.Sprintf("Copied %s to clipboard", m.fileName), false)
+
+	case clearStatusMsg:
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+		return m, nil
+
 	case tea.KeyPressMsg:
+		if m.searching {
+			return m, m.updateSearchInput(msg)
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.Search):
+			m.searching = true
+			m.searchInput.SetValue(m.searchQuery)
+			m.searchInput.CursorEnd()
+			return m, m.searchInput.Focus()
+
+		case key.Matches(msg, m.keys.NextMatch):
+			m.jumpToMatch(m.matchIndex + 1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.PrevMatch):
+			m.jumpToMatch(m.matchIndex - 1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Copy):
+			return m, m.copyContent()
+
+		case key.Matches(msg, m.keys.Retry):
+			m.loading = true
+			m.loadErr = nil
+			m.updateKeyStates()
+			m.refreshContent()
+			return m, m.loadContent()
+
+		case key.Matches(msg, m.keys.Wrap):
+			m.wrap = !m.wrap
+			m.viewport.SetXOffset(0)
+			m.refreshContent()
+			m.scrollToMatch()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Close):
+			// Esc clears an active search before closing the dialog
+			if msg.String() == "esc" && m.searchQuery != "" {
+				m.setSearchQuery("")
+				return m, nil
+			}
 			return m, util.CmdHandler(dialogs.CloseDialogMsg{})
 		}
 	}
@@ -134,13 +368,171 @@ func (m *patternViewerDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 	// Forward all other messages to viewport for scrolling
 	m.viewport, cmd = m.viewport.Update(msg)
 	cmds = append(cmds, cmd)
+	m.syncRenderWindow()
 
 	return m, tea.Batch(cmds...)
 }
 
-func (m *patternViewerDialogCmp) resize() tea.Cmd {
-	t := styles.CurrentTheme()
+// updateKeyStates enables the bindings that apply to the current state: content
+// keys once something is shown, retry only after a failed read
+func (m *patternViewerDialogCmp) updateKeyStates() {
+	hasContent := !m.loading && m.loadErr == nil && m.content != ""
+	m.keys.Search.SetEnabled(hasContent)
+	m.keys.NextMatch.SetEnabled(hasContent)
+	m.keys.PrevMatch.SetEnabled(hasContent)
+	m.keys.Copy.SetEnabled(hasContent)
+	m.keys.Wrap.SetEnabled(hasContent)
+	m.keys.Retry.SetEnabled(!m.loading && m.loadErr != nil)
+}
+
+// copyContent copies the full file to the clipboard. The content is sent both as an
+// OSC 52 sequence (for terminals, including over SSH) and to the native clipboard,
+// whose result is reported back as a clipboardWrittenMsg.
+func (m *patternViewerDialogCmp) copyContent() tea.Cmd {
+	if m.loading || m.loadErr != nil {
+		return nil
+	}
+	return tea.Sequence(tea.SetClipboard(m.content), writeClipboardCmd(m.content))
+}
+
+// writeClipboardCmd writes content to the native clipboard in the background
+func writeClipboardCmd(content string) tea.Cmd {
+	return func() tea.Msg {
+		return clipboardWrittenMsg{err: writeClipboard(content)}
+	}
+}
+
+// flashStatus shows a status message and schedules it to clear
+func (m *patternViewerDialogCmp) flashStatus(status string, isErr bool) tea.Cmd {
+	m.statusID++
+	m.status = status
+	m.statusIsErr = isErr
+	id := m.statusID
+	return tea.Tick(statusFlashDuration, func(time.Time) tea.Msg {
+		return clearStatusMsg{id: id}
+	})
+}
+
+// updateSearchInput handles keys while the search input is open. Matches update as
+// the query is typed; enter keeps them, esc restores the previous search.
+func (m *patternViewerDialogCmp) updateSearchInput(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.searchInput.Blur()
+		m.searchQuery = m.searchInput.Value()
+		return nil
+	case "esc":
+		m.searching = false
+		m.searchInput.Blur()
+		m.setSearchQuery(m.searchQuery)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.previewSearch(m.searchInput.Value())
+	return cmd
+}
+
+// previewSearch highlights matches for a query that is still being typed
+func (m *patternViewerDialogCmp) previewSearch(query string) {
+	m.matches = findMatches(m.lines, query)
+	m.matchIndex = m.firstVisibleMatch()
+	m.refreshContent()
+	m.scrollToMatch()
+}
+
+// setSearchQuery commits a search and jumps to the first match at or below the
+// current scroll position. An empty query clears the search.
+func (m *patternViewerDialogCmp) setSearchQuery(query string) {
+	m.searchQuery = query
+	m.previewSearch(query)
+}
+
+// firstVisibleMatch returns the index of the first match at or below the top of the
+// viewport, wrapping to the first match.
+func (m *patternViewerDialogCmp) firstVisibleMatch() int {
+	top := m.viewport.YOffset() - contentHeaderLines
+	for i, match := range m.matches {
+		if match.row >= top {
+			return i
+		}
+	}
+	return 0
+}
+
+// jumpToMatch selects match i (wrapping around) and scrolls it into view
+func (m *patternViewerDialogCmp) jumpToMatch(i int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIndex = (i%len(m.matches) + len(m.matches)) % len(m.matches)
+	m.refreshContent()
+	m.scrollToMatch()
+}
+
+// scrollToMatch scrolls the viewport so the current match is visible
+func (m *patternViewerDialogCmp) scrollToMatch() {
+	if len(m.matches) == 0 {
+		return
+	}
+	defer m.syncRenderWindow()
+
+	match := m.matches[m.matchIndex]
+	line := m.lines[match.row]
+	gutter := m.gutterWidth()
+	colStart := gutter + ansi.StringWidth(line[:match.col])
+	colEnd := gutter + ansi.StringWidth(line[:match.col+match.length])
+
+	if m.wrap && match.row+1 < len(m.rowOffsets) {
+		// Scroll to the wrapped segment holding the match (approximate, since word
+		// wrapping breaks lines early); no horizontal scrolling is needed
+		first, segments := m.rowOffsets[match.row], m.rowOffsets[match.row+1]-m.rowOffsets[match.row]
+		segment := min((colStart-gutter)/max(m.wrapWidth(), 1), segments-1)
+		m.viewport.EnsureVisible(contentHeaderLines+first+segment, 0, 0)
+		return
+	}
+	m.viewport.EnsureVisible(contentHeaderLines+match.row, colStart, colEnd)
+}
 
+// matchStatus returns the "match x of y" indicator, or "" when not searching
+func (m *patternViewerDialogCmp) matchStatus() string {
+	query := m.searchQuery
+	if m.searching {
+		query = m.searchInput.Value()
+	}
+	if query == "" {
+		return ""
+	}
+	if len(m.matches) == 0 {
+		return "no matches"
+	}
+	return fmt.Sprintf("match %d of %d", m.matchIndex+1, len(m.matches))
+}
+
+// splitLines splits content into lines; empty content has none
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+// gutterWidth returns the display width of the line-number gutter, if shown
+func (m *patternViewerDialogCmp) gutterWidth() int {
+	if !m.lineNumbers {
+		return 0
+	}
+	return len(fmt.Sprintf("%d", len(m.lines))) + ansi.StringWidth(" │ ")
+}
+
+// wrapWidth returns the width available to line content when wrapping
+func (m *patternViewerDialogCmp) wrapWidth() int {
+	return m.viewport.Width() - m.gutterWidth()
+}
+
+func (m *patternViewerDialogCmp) resize() tea.Cmd {
 	// Dialog should be 70% of screen width, 80% of height
 	m.width = int(float64(m.wWidth) * 0.7)
 	m.height = int(float64(m.wHeight) * 0.8)
@@ -160,10 +552,10 @@ func (m *patternViewerDialogCmp) resize() tea.Cmd {
 	// Set viewport size
 	m.viewport.SetWidth(contentWidth)
 	m.viewport.SetHeight(contentHeight)
+	m.searchInput.SetWidth(contentWidth - 20) // Leave room for the match indicator
 
 	// Build content
-	content := m.buildContent(t)
-	m.viewport.SetContent(content)
+	m.refreshContent()
 
 	// Set position (centered)
 	m.positionRow = m.wHeight/2 - m.height/2
@@ -173,59 +565,199 @@ func (m *patternViewerDialogCmp) resize() tea.Cmd {
 }
 
 func (m *patternViewerDialogCmp) buildContent(t *styles.Theme) string {
+	if m.loadErr != nil {
+		return m.buildErrorContent(t)
+	}
+
 	var parts []string
 
-	// File path
-	pathLabel := t.S().Base.Foreground(t.FgMuted).Render("File:")
-	pathValue := t.S().Base.Foreground(t.FgSubtle).Render(m.filePath)
+	// Source: the file path, or the library pattern name for cards
+	label, source := "File:", m.filePath
+	if m.library != nil {
+		label, source = "Library pattern:", m.patternName
+	}
+	pathLabel := t.S().Base.Foreground(t.FgMuted).Render(label)
+	pathValue := t.S().Base.Foreground(t.FgSubtle).Render(source)
 	parts = append(parts, pathLabel+" "+pathValue)
 	parts = append(parts, "")
 
-	// Content with line numbers and syntax highlighting for YAML
-	lines := strings.Split(m.content, "\n")
-	maxLineNum := len(lines)
-	lineNumWidth := len(fmt.Sprintf("%d", maxLineNum))
+	if m.loading {
+		parts = append(parts, t.S().Base.Foreground(t.FgMuted).Render("Loading..."))
+		return strings.Join(parts, "\n")
+	}
+
+	if m.content == "" {
+		m.rowOffsets = []int{0}
+		empty := "This file is empty."
+		if m.library != nil {
+			empty = "This pattern has no title, use cases, or description."
+		}
+		parts = append(parts, t.S().Base.Foreground(t.FgMuted).Italic(true).Render(empty))
+		return strings.Join(parts, "\n")
+	}
 
-	for i, line := range lines {
-		numStr := fmt.Sprintf("%d", i+1)
-		padding := strings.Repeat(" ", lineNumWidth-len(numStr))
-		lineNum := t.S().Base.Foreground(t.FgMuted).Render(padding + numStr + " │ ")
+	lines := m.lines
+	m.layoutRows()
+	m.renderFrom, m.renderTo = m.visibleLineRange()
+	m.renderFrom = max(m.renderFrom-lazyRenderBuffer, 0)
+	m.renderTo = min(m.renderTo+lazyRenderBuffer, len(lines))
+
+	// Group search matches in the render window by line
+	matchesByRow := make(map[int][]searchMatch)
+	for _, match := range m.matches {
+		if match.row >= m.renderFrom && match.row < m.renderTo {
+			matchesByRow[match.row] = append(matchesByRow[match.row], match)
+		}
+	}
+	var current searchMatch
+	if len(m.matches) > 0 {
+		current = m.matches[m.matchIndex]
+	}
 
-		// Basic YAML syntax highlighting
-		styledLine := m.highlightYAML(line, t)
-		parts = append(parts, lineNum+styledLine)
+	// Content with line numbers (for raw files) and syntax highlighting for the file
+	// type. The gutter is sized for the whole file so it doesn't shift on scroll.
+	lineNumWidth := len(fmt.Sprintf("%d", len(lines)))
+	gutterStyle := t.S().Base.Foreground(t.FgMuted)
+	// Wrapped continuations get a blank gutter so only logical lines are numbered
+	continuation := ""
+	if m.lineNumbers {
+		continuation = gutterStyle.Render(strings.Repeat(" ", lineNumWidth) + " │ ")
 	}
 
-	return strings.Join(parts, "\n")
+	// Rows outside the window stay blank until scrolled into view
+	rows := make([]string, m.rowOffsets[len(lines)])
+	for i := m.renderFrom; i < m.renderTo; i++ {
+		lineNum := ""
+		if m.lineNumbers {
+			numStr := fmt.Sprintf("%d", i+1)
+			padding := strings.Repeat(" ", lineNumWidth-len(numStr))
+			lineNum = gutterStyle.Render(padding + numStr + " │ ")
+		}
+
+		var styledLine string
+		if lineMatches, ok := matchesByRow[i]; ok {
+			styledLine = highlightMatches(lines[i], lineMatches, current, t)
+		} else {
+			styledLine = m.styledLine(i, t)
+		}
+
+		first, segments := m.rowOffsets[i], m.rowOffsets[i+1]-m.rowOffsets[i]
+		if segments == 1 {
+			rows[first] = lineNum + styledLine
+			continue
+		}
+		wrapped := strings.Split(ansi.Wrap(styledLine, m.rowOffsetsWidth, ""), "\n")
+		for j := 0; j < segments && j < len(wrapped); j++ {
+			if j == 0 {
+				rows[first] = lineNum + wrapped[j]
+			} else {
+				rows[first+j] = continuation + wrapped[j]
+			}
+		}
+	}
+
+	return strings.Join(append(parts, rows...), "\n")
+}
+
+// styledLine returns the highlighted content line i, styling it on first use
+func (m *patternViewerDialogCmp) styledLine(i int, t *styles.Theme) string {
+	if len(m.styled) != len(m.lines) || m.styledTheme != t {
+		m.styled = make([]string, len(m.lines))
+		m.styledTheme = t
+	}
+	if m.styled[i] == "" && m.lines[i] != "" {
+		m.styled[i] = m.highlighter.highlightLine(m.lines[i], t)
+	}
+	return m.styled[i]
 }
 
-// highlightYAML applies basic syntax highlighting for YAML content
-func (m *patternViewerDialogCmp) highlightYAML(line string, t *styles.Theme) string {
-	trimmed := strings.TrimSpace(line)
+// layoutRows sets rowOffsets for the current wrap width, unless already computed.
+// Lines are measured unstyled (with tabs expanded as lipgloss renders them), so
+// the layout is known without highlighting the whole file.
+func (m *patternViewerDialogCmp) layoutRows() {
+	width := 0
+	if m.wrap {
+		width = max(m.wrapWidth(), 0)
+	}
+	if len(m.rowOffsets) == len(m.lines)+1 && m.rowOffsetsWidth == width {
+		return
+	}
+
+	m.rowOffsetsWidth = width
+	m.rowOffsets = make([]int, 0, len(m.lines)+1)
+	rendered := 0
+	for _, line := range m.lines {
+		m.rowOffsets = append(m.rowOffsets, rendered)
+		rendered++
+		if width == 0 {
+			continue
+		}
+		if expanded := strings.ReplaceAll(line, "\t", "    "); ansi.StringWidth(expanded) > width {
+			rendered += strings.Count(ansi.Wrap(expanded, width, ""), "\n")
+		}
+	}
+	m.rowOffsets = append(m.rowOffsets, rendered)
+}
 
-	// Comment
-	if strings.HasPrefix(trimmed, "#") {
-		return t.S().Base.Foreground(t.FgMuted).Render(line)
+// visibleLineRange returns the content lines [from, to) shown in the viewport
+func (m *patternViewerDialogCmp) visibleLineRange() (int, int) {
+	if len(m.lines) == 0 {
+		return 0, 0
 	}
+	top := max(m.viewport.YOffset()-contentHeaderLines, 0)
+	bottom := top + max(m.viewport.Height(), 1)
+	return m.lineAtRow(top), min(m.lineAtRow(bottom-1)+1, len(m.lines))
+}
 
-	// Key-value pair
-	if idx := strings.Index(line, ":"); idx != -1 {
-		key := line[:idx+1]
-		value := line[idx+1:]
+// lineAtRow returns the content line rendered at row (relative to the content start)
+func (m *patternViewerDialogCmp) lineAtRow(row int) int {
+	n := len(m.lines)
+	i := sort.Search(n, func(i int) bool { return m.rowOffsets[i+1] > row })
+	return min(i, n-1)
+}
 
-		styledKey := t.S().Base.Foreground(t.Primary).Bold(true).Render(key)
-		styledValue := t.S().Base.Foreground(t.FgBase).Render(value)
+// refreshContent re-renders the viewport around the current scroll position
+func (m *patternViewerDialogCmp) refreshContent() {
+	t := styles.CurrentTheme()
+	m.viewport.SetContent(m.buildContent(t))
+	// Setting content can clamp the scroll position (e.g., after unwrapping);
+	// render again if that moved the viewport out of the window
+	if from, to := m.visibleLineRange(); from < m.renderFrom || to > m.renderTo {
+		m.viewport.SetContent(m.buildContent(t))
+	}
+}
 
-		return styledKey + styledValue
+// syncRenderWindow re-renders when scrolling has moved the viewport outside the
+// styled window
+func (m *patternViewerDialogCmp) syncRenderWindow() {
+	if m.loading || m.loadErr != nil || len(m.lines) == 0 {
+		return
+	}
+	if from, to := m.visibleLineRange(); from < m.renderFrom || to > m.renderTo {
+		m.refreshContent()
 	}
+}
+
+// buildErrorContent renders a failed read: what could not be read, why, and how to
+// retry. There is no file header or line numbering, since there is no content.
+func (m *patternViewerDialogCmp) buildErrorContent(t *styles.Theme) string {
+	m.rowOffsets = []int{0}
 
-	// List item
-	if strings.HasPrefix(trimmed, "-") {
-		return t.S().Base.Foreground(t.FgBase).Render(line)
+	headline := "Could not read " + m.filePath
+	if m.library != nil {
+		headline = "Could not load pattern " + m.patternName
 	}
+	// Wrap the details, since OS errors repeat the (possibly long) path
+	width := max(m.viewport.Width(), 1)
 
-	// Default
-	return t.S().Base.Foreground(t.FgBase).Render(line)
+	parts := []string{
+		t.S().Base.Foreground(t.Error).Bold(true).Render(ansi.Wrap("✗ "+headline, width, "")),
+		"",
+		t.S().Base.Foreground(t.Error).Render(ansi.Wrap(m.loadErr.Error(), width, "")),
+		"",
+		t.S().Base.Foreground(t.FgMuted).Render("Press r to retry or esc to close."),
+	}
+	return strings.Join(parts, "\n")
 }
 
 func (m *patternViewerDialogCmp) View() string {
@@ -240,8 +772,24 @@ func (m *patternViewerDialogCmp) View() string {
 	// Content (viewport)
 	content := m.viewport.View()
 
-	// Help
+	// Help, replaced by the search input while searching
 	helpView := m.help.View(m.keys)
+	if m.searching {
+		helpView = m.searchInput.View()
+	}
+	if status := m.matchStatus(); status != "" {
+		helpView += t.S().Base.Foreground(t.FgMuted).Render("  " + status)
+	}
+
+	// Status flash, shown in the spacer line above the help
+	statusLine := ""
+	if m.status != "" {
+		if m.statusIsErr {
+			statusLine = t.S().Base.Foreground(t.Error).Render("✗ " + m.status)
+		} else {
+			statusLine = t.S().Base.Foreground(t.Success).Render("✓ " + m.status)
+		}
+	}
 
 	// Assemble dialog
 	inner := lipgloss.JoinVertical(
@@ -249,7 +797,7 @@ func (m *patternViewerDialogCmp) View() string {
 		title,
 		"",
 		content,
-		"",
+		statusLine,
 		helpView,
 	)
 
@@ -0,0 +1,408 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pattern
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/internal/tui/components/dialogs"
+	"github.com/teradata-labs/loom/internal/tui/styles"
+	"github.com/teradata-labs/loom/pkg/patterns"
+)
+
+// newLoadedViewer returns a sized viewer showing content, skipping the file read
+func newLoadedViewer(t *testing.T, name, content string) *patternViewerDialogCmp {
+	t.Helper()
+	m := NewPatternViewerDialog(name).(*patternViewerDialogCmp)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.Update(contentLoadedMsg{filePath: name, content: content})
+	require.False(t, m.loading)
+	return m
+}
+
+func press(m *patternViewerDialogCmp, keys ...string) {
+	for _, k := range keys {
+		switch k {
+		case "enter":
+			m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		case "esc":
+			m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+		default:
+			for _, r := range k {
+				m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+			}
+		}
+	}
+}
+
+func TestPatternViewer_Search(t *testing.T) {
+	var lines []string
+	for i := 0; i < 200; i++ {
+		lines = append(lines, fmt.Sprintf("line_%d: value", i))
+	}
+	lines[50] = "target: first"
+	lines[150] = "target: second"
+	m := newLoadedViewer(t, "big.yaml", strings.Join(lines, "\n"))
+
+	press(m, "/", "target")
+	assert.True(t, m.searching)
+	assert.Len(t, m.matches, 2)
+	assert.Equal(t, "match 1 of 2", m.matchStatus())
+
+	press(m, "enter")
+	assert.False(t, m.searching)
+	assert.Equal(t, "target", m.searchQuery)
+	assertRowVisible(t, m, 50)
+
+	press(m, "n")
+	assert.Equal(t, "match 2 of 2", m.matchStatus())
+	assertRowVisible(t, m, 150)
+
+	// n wraps around, N goes back
+	press(m, "n")
+	assert.Equal(t, 0, m.matchIndex)
+	press(m, "N")
+	assert.Equal(t, 1, m.matchIndex)
+	assert.Contains(t, m.View(), "match 2 of 2")
+
+	// Esc clears the search before it closes the dialog
+	press(m, "esc")
+	assert.Empty(t, m.searchQuery)
+	assert.Empty(t, m.matches)
+	assert.Empty(t, m.matchStatus())
+}
+
+func TestPatternViewer_SearchCancelRestoresQuery(t *testing.T) {
+	m := newLoadedViewer(t, "p.yaml", "alpha\nbeta\nalpha beta")
+
+	press(m, "/", "beta", "enter")
+	assert.Len(t, m.matches, 2)
+
+	press(m, "/", "x", "esc")
+	assert.False(t, m.searching)
+	assert.Equal(t, "beta", m.searchQuery)
+	assert.Len(t, m.matches, 2)
+
+	press(m, "/", "zzz", "enter")
+	assert.Equal(t, "no matches", m.matchStatus())
+}
+
+func assertRowVisible(t *testing.T, m *patternViewerDialogCmp, row int) {
+	t.Helper()
+	line := contentHeaderLines + row
+	top := m.viewport.YOffset()
+	assert.True(t, line >= top && line < top+m.viewport.Height(),
+		"row %d (line %d) not within viewport [%d, %d)", row, line, top, top+m.viewport.Height())
+}
+
+func TestPatternViewer_CopyReportsResult(t *testing.T) {
+	var copied string
+	writeClipboard = func(s string) error {
+		copied = s
+		return nil
+	}
+	defer func() { writeClipboard = clipboard.WriteAll }()
+
+	m := newLoadedViewer(t, "p.yaml", "name: join\n")
+	press(m, "y")
+	m.Update(writeClipboardCmd(m.content)())
+	assert.Equal(t, "name: join\n", copied)
+	assert.Equal(t, "Copied p.yaml to clipboard", m.status)
+	assert.False(t, m.statusIsErr)
+	assert.Contains(t, m.View(), "Copied p.yaml to clipboard")
+
+	// Headless systems report an error line instead
+	m.Update(clipboardWrittenMsg{err: errors.New("no clipboard utilities available")})
+	assert.True(t, m.statusIsErr)
+	assert.Contains(t, m.View(), "Could not access the system clipboard")
+
+	// Only the latest flash is cleared by its timer
+	m.Update(clearStatusMsg{id: m.statusID - 1})
+	assert.NotEmpty(t, m.status)
+	m.Update(clearStatusMsg{id: m.statusID})
+	assert.Empty(t, m.status)
+}
+
+func TestPatternViewer_WrapToggle(t *testing.T) {
+	long := "description: " + strings.Repeat("prose about when to use this pattern ", 20)
+	m := newLoadedViewer(t, "p.yaml", "name: join\n"+long+"\nend: true")
+
+	// Truncated by default: one rendered line per content line
+	assert.Equal(t, []int{0, 1, 2, 3}, m.rowOffsets)
+
+	press(m, "w")
+	require.True(t, m.wrap)
+	assert.Greater(t, m.rowOffsets[2]-m.rowOffsets[1], 1, "long line wraps")
+
+	// Only logical lines are numbered
+	content := ansi.Strip(m.buildContent(styles.CurrentTheme()))
+	rendered := strings.Split(content, "\n")[contentHeaderLines:]
+	var numbered []string
+	for _, line := range rendered {
+		if num := strings.TrimSpace(strings.SplitN(line, "│", 2)[0]); num != "" {
+			numbered = append(numbered, num)
+		}
+		assert.LessOrEqual(t, ansi.StringWidth(line), m.viewport.Width())
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, numbered)
+	assert.Len(t, rendered, m.rowOffsets[3])
+
+	// The setting survives re-rendering until toggled off
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	assert.True(t, m.wrap)
+	press(m, "w")
+	assert.Equal(t, []int{0, 1, 2, 3}, m.rowOffsets)
+}
+
+func TestPatternViewer_FromLibrary(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sessionize.yaml"), []byte(`name: sessionize
+title: Sessionize Events
+category: analytics
+difficulty: intermediate
+backend_type: sql
+use_cases:
+  - Group clickstream events into sessions
+  - Measure session length
+description: |
+  Assigns session IDs to events separated by an idle timeout.
+parameters:
+  - name: timeout
+    type: integer
+`), 0600))
+
+	m := NewPatternViewerFromLibrary(patterns.NewLibrary(nil, dir), "sessionize").(*patternViewerDialogCmp)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.Update(m.loadContent()())
+	require.False(t, m.loading)
+
+	rendered := strings.Split(ansi.Strip(m.buildContent(styles.CurrentTheme())), "\n")
+	assert.Equal(t, "Library pattern: sessionize", rendered[0])
+	assert.Equal(t, []string{
+		"Sessionize Events",
+		"Category: analytics  ·  Difficulty: intermediate  ·  Backend: sql",
+		"",
+		"Use Cases",
+		"• Group clickstream events into sessions",
+		"• Measure session length",
+		"",
+		"Description",
+		"Assigns session IDs to events separated by an idle timeout.",
+	}, rendered[contentHeaderLines:])
+	assert.NotContains(t, m.content, "parameters:", "the card shows the structured pattern, not the YAML")
+	assert.True(t, m.wrap, "cards wrap prose by default")
+
+	// Search works on the card text
+	press(m, "/", "session", "enter")
+	assert.Equal(t, "match 1 of 4", m.matchStatus())
+}
+
+func TestPatternViewer_FromLibraryMissingPattern(t *testing.T) {
+	m := NewPatternViewerFromLibrary(patterns.NewLibrary(nil, t.TempDir()), "missing").(*patternViewerDialogCmp)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.Update(m.loadContent()())
+	require.Error(t, m.loadErr)
+
+	rendered := ansi.Strip(m.buildContent(styles.CurrentTheme()))
+	assert.Contains(t, rendered, "Could not load pattern missing")
+	assert.NotContains(t, rendered, "Library pattern:")
+}
+
+// runInit runs the viewer's Init commands off the update loop, as the program does,
+// and returns the messages they post
+func runInit(t *testing.T, m *patternViewerDialogCmp) []tea.Msg {
+	t.Helper()
+	cmd := m.Init()
+	require.NotNil(t, cmd)
+	cmds := []tea.Cmd{cmd}
+	var msgs []tea.Msg
+	for len(cmds) > 0 {
+		next := cmds[0]
+		cmds = cmds[1:]
+		if next == nil {
+			continue
+		}
+		done := make(chan tea.Msg, 1)
+		go func() { done <- next() }()
+		msg := <-done
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			cmds = append(cmds, batch...)
+			continue
+		}
+		if msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+func TestPatternViewer_AsyncLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "join.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: join\ncategory: sql\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sessionize.yaml"), []byte("name: sessionize\ntitle: Sessionize Events\ncategory: analytics\n"), 0600))
+
+	t.Run("file", func(t *testing.T) {
+		m := NewPatternViewerDialog(path).(*patternViewerDialogCmp)
+		m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+		// Nothing is read until Init's command runs
+		require.True(t, m.loading)
+		assert.Contains(t, ansi.Strip(m.View()), "Loading...")
+		assert.False(t, m.keys.Search.Enabled())
+
+		// A result for another file (a dialog reopened on a different pattern) is ignored
+		m.Update(contentLoadedMsg{filePath: "other.yaml", content: "name: other\n"})
+		require.True(t, m.loading)
+
+		for _, msg := range runInit(t, m) {
+			m.Update(msg)
+		}
+		require.False(t, m.loading)
+		require.NoError(t, m.loadErr)
+		view := ansi.Strip(m.View())
+		assert.NotContains(t, view, "Loading...")
+		assert.Contains(t, view, "name: join")
+		assert.Contains(t, view, "category: sql")
+		assert.True(t, m.keys.Search.Enabled())
+	})
+
+	t.Run("library", func(t *testing.T) {
+		m := NewPatternViewerFromLibrary(patterns.NewLibrary(nil, dir), "sessionize").(*patternViewerDialogCmp)
+		m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		require.True(t, m.loading)
+
+		for _, msg := range runInit(t, m) {
+			m.Update(msg)
+		}
+		require.False(t, m.loading)
+		require.NoError(t, m.loadErr)
+		view := ansi.Strip(m.View())
+		assert.Contains(t, view, "Sessionize Events")
+		assert.Contains(t, view, "Category: analytics")
+	})
+}
+
+func TestPatternViewer_UnreadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "later.yaml")
+	m := NewPatternViewerDialog(path).(*patternViewerDialogCmp)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.Update(m.loadContent()())
+	require.ErrorIs(t, m.loadErr, os.ErrNotExist)
+	assert.Empty(t, m.content)
+
+	// A distinct error state: no file header, no line numbers, and a retry hint
+	rendered := ansi.Strip(m.buildContent(styles.CurrentTheme()))
+	assert.True(t, strings.HasPrefix(rendered, "✗ Could not read "+path), rendered)
+	assert.NotContains(t, rendered, "File:")
+	assert.NotContains(t, rendered, "│")
+	assert.Contains(t, rendered, "Press r to retry")
+
+	// Content keys are disabled; retry is offered instead
+	assert.False(t, m.keys.Search.Enabled())
+	assert.False(t, m.keys.Copy.Enabled())
+	assert.True(t, m.keys.Retry.Enabled())
+	assert.Nil(t, m.copyContent())
+
+	// Retry reloads once the file exists
+	require.NoError(t, os.WriteFile(path, []byte("name: later\n"), 0600))
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	require.NotNil(t, cmd)
+	assert.True(t, m.loading)
+	m.Update(cmd())
+	require.NoError(t, m.loadErr)
+	assert.Equal(t, "name: later\n", m.content)
+	assert.False(t, m.keys.Retry.Enabled())
+	assert.True(t, m.keys.Search.Enabled())
+
+	// Close still works from the error state
+	m.loadErr = errors.New("permission denied")
+	m.updateKeyStates()
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.IsType(t, dialogs.CloseDialogMsg{}, cmd())
+}
+
+func TestPatternViewer_EmptyFile(t *testing.T) {
+	m := newLoadedViewer(t, "empty.yaml", "")
+
+	rendered := strings.Split(ansi.Strip(m.buildContent(styles.CurrentTheme())), "\n")
+	assert.Equal(t, []string{"File: empty.yaml", "", "This file is empty."}, rendered)
+	assert.False(t, m.keys.Copy.Enabled())
+
+	press(m, "/", "x")
+	assert.False(t, m.searching, "search is disabled without content")
+}
+
+// largePatternFile returns a YAML-like file with n lines
+func largePatternFile(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "step_%d: select value from table where id = %d\n", i, i)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func TestPatternViewer_LazyRendering(t *testing.T) {
+	m := newLoadedViewer(t, "big.yaml", largePatternFile(10000))
+
+	// Only the visible window plus a buffer is styled
+	styled := 0
+	for _, line := range m.styled {
+		if line != "" {
+			styled++
+		}
+	}
+	assert.LessOrEqual(t, styled, m.viewport.Height()+2*lazyRenderBuffer)
+	assert.Equal(t, contentHeaderLines+10000, m.viewport.TotalLineCount(), "the full scroll height is kept")
+
+	// The gutter is sized for the whole file
+	rendered := strings.Split(ansi.Strip(m.buildContent(styles.CurrentTheme())), "\n")
+	assert.Equal(t, "    1 │ step_0: select value from table where id = 0", rendered[contentHeaderLines])
+
+	// Scrolling past the window renders the newly visible lines
+	press(m, "/", "step_9990:", "enter")
+	assertRowVisible(t, m, 9990)
+	rendered = strings.Split(ansi.Strip(m.viewport.GetContent()), "\n")
+	assert.Equal(t, " 9991 │ step_9990: select value from table where id = 9990", rendered[contentHeaderLines+9990])
+	assert.Empty(t, rendered[contentHeaderLines], "lines far above the viewport are no longer rendered")
+
+	// Wrapping keeps the layout for all lines
+	press(m, "w")
+	assert.Len(t, m.rowOffsets, 10001)
+	assertRowVisible(t, m, 9990)
+}
+
+func BenchmarkPatternViewer_LargeFile(b *testing.B) {
+	content := largePatternFile(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m := NewPatternViewerDialog("big.yaml").(*patternViewerDialogCmp)
+		m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m.Update(contentLoadedMsg{filePath: "big.yaml", content: content})
+		for j := 0; j < 20; j++ {
+			m.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pattern
+
+import (
+	"strings"
+
+	"github.com/teradata-labs/loom/internal/tui/styles"
+)
+
+// searchMatch is one occurrence of the search query, at byte offset col of content
+// line row (both zero-based).
+type searchMatch struct {
+	row, col int
+	length   int
+}
+
+// findMatches returns every non-overlapping occurrence of query in lines, in reading
+// order. Matching is case-insensitive unless the query contains an upper-case letter.
+func findMatches(lines []string, query string) []searchMatch {
+	if query == "" {
+		return nil
+	}
+	caseSensitive := strings.ToLower(query) != query
+
+	var matches []searchMatch
+	for row, line := range lines {
+		haystack := line
+		if !caseSensitive {
+			// Lowering can change byte lengths for some non-ASCII text; fall back to an
+			// exact match there so offsets stay valid for the original line
+			if lowered := strings.ToLower(line); len(lowered) == len(line) {
+				haystack = lowered
+			}
+		}
+		for offset := 0; offset < len(haystack); {
+			idx := strings.Index(haystack[offset:], query)
+			if idx == -1 {
+				break
+			}
+			matches = append(matches, searchMatch{row: row, col: offset + idx, length: len(query)})
+			offset += idx + len(query)
+		}
+	}
+	return matches
+}
+
+// highlightMatches renders line with its matches styled, marking the current match.
+// Syntax highlighting is not applied to lines with matches so the match styling
+// stays legible.
+func highlightMatches(line string, lineMatches []searchMatch, current searchMatch, t *styles.Theme) string {
+	textStyle := t.S().Base.Foreground(t.FgBase)
+	matchStyle := t.S().Base.Foreground(t.BgBase).Background(t.Warning)
+	currentStyle := t.S().Base.Foreground(t.BgBase).Background(t.Primary).Bold(true)
+
+	var b strings.Builder
+	pos := 0
+	for _, match := range lineMatches {
+		if match.col > pos {
+			b.WriteString(textStyle.Render(line[pos:match.col]))
+		}
+		style := matchStyle
+		if match == current {
+			style = currentStyle
+		}
+		b.WriteString(style.Render(line[match.col : match.col+match.length]))
+		pos = match.col + match.length
+	}
+	if pos < len(line) {
+		b.WriteString(textStyle.Render(line[pos:]))
+	}
+	return b.String()
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pattern
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/teradata-labs/loom/internal/tui/styles"
+)
+
+func TestFindMatches(t *testing.T) {
+	lines := []string{
+		"name: sql_join",
+		"description: Join two SQL tables",
+		"",
+		"sql: SELECT * FROM a JOIN b; -- join join",
+	}
+
+	// Lower-case queries match any case
+	assert.Equal(t, []searchMatch{
+		{row: 0, col: 10, length: 4},
+		{row: 1, col: 13, length: 4},
+		{row: 3, col: 21, length: 4},
+		{row: 3, col: 32, length: 4},
+		{row: 3, col: 37, length: 4},
+	}, findMatches(lines, "join"))
+
+	// An upper-case letter makes the search case-sensitive
+	assert.Equal(t, []searchMatch{{row: 3, col: 21, length: 4}}, findMatches(lines, "JOIN"))
+
+	assert.Empty(t, findMatches(lines, ""))
+	assert.Empty(t, findMatches(lines, "missing"))
+}
+
+func TestHighlightMatches_PreservesText(t *testing.T) {
+	line := "join the join"
+	matches := findMatches([]string{line}, "join")
+	rendered := highlightMatches(line, matches, matches[1], styles.CurrentTheme())
+	assert.Equal(t, line, ansi.Strip(rendered))
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -106,7 +107,12 @@ func NewAgent(backend fabric.ExecutionBackend, llmProvider LLMProvider, opts ...
 
 	// Initialize pattern orchestrator
 	patternLibrary := patterns.NewLibrary(nil, a.config.PatternsDir)
+	if a.config.PatternConfig.IndexCache && a.config.PatternsDir != "" {
+		patternLibrary.EnableIndexCache(a.config.PatternConfig.IndexCacheDir)
+	}
 	a.orchestrator = patterns.NewOrchestrator(patternLibrary)
+	a.orchestrator.SetRequireIntentMatch(a.config.PatternConfig.RequireIntentMatch)
+	a.orchestrator.SetMinConfidence(a.config.PatternConfig.MinConfidence)
 
 	// Initialize LLM classifier if configured
 	if a.config.PatternConfig.UseLLMClassifier && llmProvider != nil {
@@ -332,6 +338,13 @@ func WithDescription(description string) Option {
 	}
 }
 
+// WithCanSpawn sets the agent IDs or glob patterns the agent may spawn (see Config.CanSpawn).
+func WithCanSpawn(patterns []string) Option {
+	return func(a *Agent) {
+		a.config.CanSpawn = patterns
+	}
+}
+
 // WithErrorStore enables error submission channel for storing full error details.
 // When set, tool execution errors are stored in SQLite with only summaries sent to LLM.
 // The get_error_details built-in tool is automatically registered.
@@ -384,6 +397,11 @@ func (a *Agent) UnregisterTool(name string) {
 	a.tools.Unregister(name)
 }
 
+// GetTool returns the registered tool with the given name.
+func (a *Agent) GetTool(name string) (shuttle.Tool, bool) {
+	return a.tools.Get(name)
+}
+
 // ToolCount returns the number of registered tools.
 func (a *Agent) ToolCount() int {
 	return a.tools.Count()
@@ -586,10 +604,33 @@ func formatWorkflowCommunicationInstructions(ctx *WorkflowCommunicationContext)
 		instructions.WriteString("🔔 WORKFLOW COMMUNICATION (PUB-SUB)\n")
 		instructions.WriteString(fmt.Sprintf("Subscribed topics: %s\n", strings.Join(ctx.SubscribedTopics, ", ")))
 		instructions.WriteString("→ To post: publish(topic=\"topic-name\", message=\"your message\")\n")
+		for _, topic := range ctx.SubscribedTopics {
+			if strings.ContainsAny(topic, "*>") {
+				instructions.WriteString("→ Wildcard topics (* and >) only receive; publish to a concrete topic name\n")
+				break
+			}
+		}
 		instructions.WriteString("→ Responses auto-inject as \"[BROADCAST FROM agent]: ...\"\n")
 		instructions.WriteString("→ Do NOT poll - you will be notified automatically\n\n")
 	}
 
+	// Spawn context (if spawned by another agent)
+	if ctx.ParentAgentID != "" || len(ctx.SpawnMetadata) > 0 {
+		instructions.WriteString("🔔 SPAWN CONTEXT\n")
+		if ctx.ParentAgentID != "" {
+			instructions.WriteString(fmt.Sprintf("Spawned by: %s\n", ctx.ParentAgentID))
+		}
+		keys := make([]string, 0, len(ctx.SpawnMetadata))
+		for key := range ctx.SpawnMetadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			instructions.WriteString(fmt.Sprintf("%s: %s\n", key, ctx.SpawnMetadata[key]))
+		}
+		instructions.WriteString("→ Act according to this context (e.g. your assigned role)\n\n")
+	}
+
 	// Point-to-point instructions (if available agents)
 	if len(ctx.AvailableAgents) > 0 {
 		instructions.WriteString("🔔 WORKFLOW COMMUNICATION (DIRECT MESSAGING)\n")
@@ -1130,7 +1171,7 @@ func (a *Agent) runConversationLoop(ctx Context) (*Response, error) {
 
 			// Step 2: Recommend pattern (if intent confidence sufficient)
 			if intent != patterns.IntentUnknown && intentConf > 0.3 {
-				patternName, patternConf := a.orchestrator.RecommendPattern(lastUserMessage, intent)
+				patternName, patternConf := a.orchestrator.RecommendPatternContext(ctx, lastUserMessage, intent)
 				patternConfidence = patternConf
 
 				if patternSpan != nil {
@@ -1665,11 +1706,29 @@ func (a *Agent) executeToolWithSelfCorrection(ctx Context, toolName string, inpu
 		val:     a.config.Name,
 	}
 
+	// Forward streaming tool progress as tool execution progress events
+	var execCtx context.Context = ctxWithAgent
+	if callback := ctx.ProgressCallback(); callback != nil {
+		execCtx = shuttle.ContextWithToolEventHandler(ctxWithAgent, func(name string, event shuttle.ToolEvent) {
+			progress := ProgressEvent{
+				Stage:     StageToolExecution,
+				Progress:  event.Progress,
+				Message:   event.Message,
+				ToolName:  name,
+				Timestamp: event.Timestamp,
+			}
+			if partial, ok := event.Data.(string); ok && event.Type == shuttle.ToolEventPartialOutput {
+				progress.PartialContent = partial
+			}
+			callback(progress)
+		})
+	}
+
 	// Execute with circuit breaker if enabled
 	if a.circuitBreakers != nil {
 		breaker := a.circuitBreakers.GetBreaker(toolName)
 		cbErr := breaker.Execute(func() error {
-			result, err = a.executor.Execute(ctxWithAgent, toolName, input)
+			result, err = a.executor.Execute(execCtx, toolName, input)
 			return err
 		})
 
@@ -1679,7 +1738,7 @@ func (a *Agent) executeToolWithSelfCorrection(ctx Context, toolName string, inpu
 		}
 	} else {
 		// No circuit breaker - execute directly
-		result, err = a.executor.Execute(ctxWithAgent, toolName, input)
+		result, err = a.executor.Execute(execCtx, toolName, input)
 	}
 
 	// If execution succeeded and guardrails enabled, clear error record
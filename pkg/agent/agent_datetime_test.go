@@ -132,3 +132,28 @@ func TestDatetimeFormatReadability(t *testing.T) {
 		t.Error("Expected month name not found in datetime format")
 	}
 }
+
+func TestFormatSystemPromptWithDatetime_SpawnContext(t *testing.T) {
+	result := formatSystemPromptWithDatetime("You are a fighter.", &WorkflowCommunicationContext{
+		ParentAgentID: "dungeon-master",
+		SpawnMetadata: map[string]string{"role": "tank", "party": "alpha"},
+	})
+
+	if !strings.Contains(result, "SPAWN CONTEXT") {
+		t.Fatal("Expected spawn context section not found")
+	}
+	if !strings.Contains(result, "Spawned by: dungeon-master") {
+		t.Error("Expected parent agent ID in spawn context")
+	}
+	// Keys are sorted so the prompt is stable across calls
+	if !strings.Contains(result, "party: alpha\nrole: tank\n") {
+		t.Errorf("Expected sorted spawn metadata, got:\n%s", result)
+	}
+	if !strings.HasSuffix(result, "You are a fighter.") {
+		t.Error("Expected original prompt at the end")
+	}
+
+	if strings.Contains(formatSystemPromptWithDatetime("p", &WorkflowCommunicationContext{WorkflowName: "wf"}), "SPAWN CONTEXT") {
+		t.Error("Spawn context should be omitted when there is nothing to show")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -43,6 +44,7 @@ type AgentConfigYAML struct {
 		Tools        ToolsConfigYAML        `yaml:"tools"`
 		Memory       MemoryConfigYAML       `yaml:"memory"`
 		Behavior     BehaviorConfigYAML     `yaml:"behavior"`
+		CanSpawn     []string               `yaml:"can_spawn"` // Agent IDs or glob patterns this agent may spawn
 		Metadata     map[string]interface{} `yaml:"metadata"`
 	} `yaml:"agent"`
 }
@@ -71,6 +73,7 @@ type K8sStyleAgentConfig struct {
 		ROM           string                 `yaml:"rom"` // ROM identifier: "TD", "teradata", "auto", or ""
 		Config        BehaviorConfigYAML     `yaml:"config"`
 		Memory        MemoryConfigYAML       `yaml:"memory"`
+		CanSpawn      []string               `yaml:"can_spawn"` // Agent IDs or glob patterns this agent may spawn
 		Observability map[string]interface{} `yaml:"observability"`
 	} `yaml:"spec"`
 }
@@ -297,6 +300,9 @@ func convertK8sToLegacy(k8s *K8sStyleAgentConfig) AgentConfigYAML {
 	// Memory
 	legacy.Agent.Memory = k8s.Spec.Memory
 
+	// Spawn allowlist
+	legacy.Agent.CanSpawn = k8s.Spec.CanSpawn
+
 	// Metadata - merge labels and other metadata fields
 	legacy.Agent.Metadata = make(map[string]interface{})
 	if k8s.Metadata.Version != "" {
@@ -323,6 +329,32 @@ func convertK8sToLegacy(k8s *K8sStyleAgentConfig) AgentConfigYAML {
 	return legacy
 }
 
+// CanSpawnMetadataKey is the AgentConfig metadata key holding the can_spawn allowlist,
+// a JSON array of agent IDs or glob patterns.
+const CanSpawnMetadataKey = "can_spawn"
+
+// SpawnAllowlist returns the agent IDs or glob patterns the agent may spawn, from its
+// can_spawn config. Returns nil if none are set. A comma-separated list (as set through
+// labels or metadata by hand) is also accepted.
+func SpawnAllowlist(config *loomv1.AgentConfig) []string {
+	raw := strings.TrimSpace(config.GetMetadata()[CanSpawnMetadataKey])
+	if raw == "" {
+		return nil
+	}
+	var patterns []string
+	if strings.HasPrefix(raw, "[") {
+		if err := json.Unmarshal([]byte(raw), &patterns); err == nil {
+			return patterns
+		}
+	}
+	for _, pattern := range strings.Split(raw, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
 // convertMetadata converts map[string]interface{} to map[string]string
 // Complex values (lists, maps) are JSON-encoded
 func convertMetadata(metadata map[string]interface{}) map[string]string {
@@ -367,6 +399,20 @@ func yamlToProto(yaml *AgentConfigYAML) (*loomv1.AgentConfig, error) {
 		metadata["backend_path"] = yaml.Agent.BackendPath
 	}
 
+	// The spawn allowlist has no proto field; carry it in metadata (see SpawnAllowlist)
+	if len(yaml.Agent.CanSpawn) > 0 {
+		for _, pattern := range yaml.Agent.CanSpawn {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid can_spawn pattern %q: %w", pattern, err)
+			}
+		}
+		canSpawn, err := json.Marshal(yaml.Agent.CanSpawn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid can_spawn: %w", err)
+		}
+		metadata[CanSpawnMetadataKey] = string(canSpawn)
+	}
+
 	config := &loomv1.AgentConfig{
 		Name:         yaml.Agent.Name,
 		Description:  yaml.Agent.Description,
@@ -693,6 +739,10 @@ func protoToYAML(config *loomv1.AgentConfig) *AgentConfigYAML {
 	yaml.Agent.Description = config.Description
 	yaml.Agent.SystemPrompt = config.SystemPrompt
 	yaml.Agent.Metadata = convertMetadataToInterface(config.Metadata)
+	if canSpawn := SpawnAllowlist(config); len(canSpawn) > 0 {
+		yaml.Agent.CanSpawn = canSpawn
+		delete(yaml.Agent.Metadata, CanSpawnMetadataKey)
+	}
 
 	// Convert LLM config
 	if config.Llm != nil {
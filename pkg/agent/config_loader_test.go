@@ -549,3 +549,40 @@ agent:
 		})
 	}
 }
+
+func TestLoadConfig_CanSpawn(t *testing.T) {
+	k8s := `apiVersion: loom/v1
+kind: Agent
+metadata:
+  name: triage
+spec:
+  can_spawn:
+    - sql-analyst
+    - "report-*"
+`
+	config, err := LoadConfigFromString(k8s)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sql-analyst", "report-*"}, SpawnAllowlist(config))
+
+	legacy := `agent:
+  name: triage
+  can_spawn: [sql-analyst]
+`
+	config, err = LoadConfigFromString(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sql-analyst"}, SpawnAllowlist(config))
+
+	// Saved configs keep the allowlist as a field rather than metadata
+	path := filepath.Join(t.TempDir(), "triage.yaml")
+	require.NoError(t, SaveAgentConfig(config, path))
+	reloaded, err := LoadAgentConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sql-analyst"}, SpawnAllowlist(reloaded))
+
+	_, err = LoadConfigFromString("agent:\n  name: triage\n  can_spawn: [\"report-[\"]\n")
+	assert.ErrorContains(t, err, "invalid can_spawn pattern")
+
+	// Hand-written metadata may use a comma-separated list
+	assert.Equal(t, []string{"a", "b-*"}, SpawnAllowlist(&loomv1.AgentConfig{Metadata: map[string]string{CanSpawnMetadataKey: "a, b-*"}}))
+	assert.Nil(t, SpawnAllowlist(&loomv1.AgentConfig{}))
+}
@@ -14,19 +14,38 @@
 package agent
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/teradata-labs/loom/pkg/llm"
 	"github.com/teradata-labs/loom/pkg/shuttle"
 	llmtypes "github.com/teradata-labs/loom/pkg/types"
 	"go.uber.org/zap"
 )
 
+// checkUsageBudget returns an error wrapping llm.ErrBudgetExceeded when the turn's
+// session has reached the configured UsageBudget.
+func (a *Agent) checkUsageBudget(ctx Context) error {
+	session := ctx.Session()
+	if session == nil {
+		return nil
+	}
+	costUSD, tokens := session.Usage()
+	return a.config.UsageBudget.Check(session.ID, costUSD, tokens)
+}
+
 // chatWithRetry wraps LLM Chat calls with exponential backoff retry logic.
 // If the provider supports streaming and a progress callback is configured,
 // it will use streaming with token buffering to emit real-time progress.
 func (a *Agent) chatWithRetry(ctx Context, messages []Message, tools []shuttle.Tool) (*LLMResponse, error) {
+	messages = a.trimToContextWindow(messages)
+
+	if err := a.checkUsageBudget(ctx); err != nil {
+		return nil, err
+	}
+
 	// Check if provider supports streaming and we have a progress callback
 	supportsStreaming := llmtypes.SupportsStreaming(a.llm)
 	progressCallback := ctx.ProgressCallback()
@@ -68,6 +87,11 @@ func (a *Agent) chatWithRetry(ctx Context, messages []Message, tools []shuttle.T
 				attempt+1, a.config.Retry.MaxRetries+1, err)
 		}
 
+		// An exhausted budget stays exhausted
+		if errors.Is(err, llm.ErrBudgetExceeded) {
+			return nil, err
+		}
+
 		// If this is the last attempt, don't sleep
 		if attempt >= a.config.Retry.MaxRetries {
 			break
@@ -107,6 +131,38 @@ func (a *Agent) chatWithRetry(ctx Context, messages []Message, tools []shuttle.T
 		a.config.Retry.MaxRetries+1, lastErr)
 }
 
+// trimToContextWindow drops the oldest conversation turns when the agent has an explicit
+// context window (MaxContextTokens), so long sessions degrade instead of failing with a
+// context-length error. System messages and the latest user turn are always kept.
+func (a *Agent) trimToContextWindow(messages []Message) []Message {
+	if a.config == nil || a.config.MaxContextTokens <= 0 {
+		return messages
+	}
+
+	maxContext := a.config.MaxContextTokens
+	reserved := a.config.ReservedOutputTokens
+	if reserved <= 0 {
+		reserved = maxContext / 10 // Same default as segmented memory
+	}
+
+	counter := llmtypes.EstimateMessageTokens
+	if a.tokenCounter != nil {
+		counter = func(msg Message) int {
+			return a.tokenCounter.EstimateMessagesTokens([]Message{msg})
+		}
+	}
+
+	trimmed := llmtypes.TrimMessagesWithCounter(messages, maxContext-reserved, true, counter)
+	if len(trimmed) < len(messages) {
+		zap.L().Info("trimmed conversation history to fit context window",
+			zap.Int("dropped_messages", len(messages)-len(trimmed)),
+			zap.Int("max_context_tokens", maxContext),
+			zap.Int("reserved_output_tokens", reserved),
+		)
+	}
+	return trimmed
+}
+
 // chatWithStreaming uses streaming API with token buffering and progress emission.
 func (a *Agent) chatWithStreaming(ctx Context, messages []Message, tools []shuttle.Tool, progressCallback ProgressCallback) (*LLMResponse, error) {
 	streamingProvider, ok := a.llm.(llmtypes.StreamingLLMProvider)
@@ -0,0 +1,79 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teradata-labs/loom/pkg/llm"
+)
+
+func TestAgent_TrimToContextWindow(t *testing.T) {
+	long := strings.Repeat("lorem ipsum dolor sit amet ", 200) // ~1000 tokens
+	messages := []Message{
+		{Role: "system", Content: "You are a SQL assistant."},
+		{Role: "user", Content: long},
+		{Role: "assistant", Content: long},
+		{Role: "user", Content: "What tables exist?"},
+	}
+
+	// Without an explicit context window the history is sent as-is
+	a := &Agent{config: &Config{}, tokenCounter: GetTokenCounter()}
+	assert.Len(t, a.trimToContextWindow(messages), 4)
+
+	// 1000-token window with 10% reserved for output cannot hold the long turn
+	a.config.MaxContextTokens = 1000
+	trimmed := a.trimToContextWindow(messages)
+	require.Len(t, trimmed, 2)
+	assert.Equal(t, "system", trimmed[0].Role)
+	assert.Equal(t, "What tables exist?", trimmed[1].Content)
+
+	// A generous window keeps everything
+	a.config.MaxContextTokens = 200000
+	assert.Len(t, a.trimToContextWindow(messages), 4)
+}
+
+func TestAgent_UsageBudget(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PatternConfig = &PatternConfig{Enabled: false}
+	cfg.UsageBudget = llm.UsageBudget{MaxTokens: 100}
+	// The factory's usage wrapper fills in the total the mock leaves out (75 per call)
+	provider := llm.NewUsageTrackingProvider(&mockSimpleLLM{}, llm.ModelPricing{})
+	ag := NewAgent(nil, provider, WithConfig(cfg))
+	ctx := context.Background()
+
+	_, err := ag.Chat(ctx, "sess-1", "first")
+	require.NoError(t, err)
+	session, ok := ag.GetSession("sess-1")
+	require.True(t, ok)
+	costUSD, tokens := session.Usage()
+	assert.Equal(t, 75, tokens, "response usage is added to the session totals")
+	assert.InDelta(t, 0.0037, costUSD, 1e-9)
+
+	_, err = ag.Chat(ctx, "sess-1", "second")
+	require.NoError(t, err, "the call that crosses the budget is allowed")
+
+	_, err = ag.Chat(ctx, "sess-1", "third")
+	require.ErrorIs(t, err, llm.ErrBudgetExceeded)
+	_, tokens = session.Usage()
+	assert.Equal(t, 150, tokens, "calls over budget do not reach the provider")
+
+	_, err = ag.Chat(ctx, "sess-2", "first")
+	assert.NoError(t, err, "budgets are per session")
+}
@@ -118,10 +118,10 @@ func TestMigrationFromV100(t *testing.T) {
 	require.Equal(t, 1, count, "session_id column should exist after migration")
 
 	// Verify we can still read the old data
-	sessions, err := store.ListSessions(ctx)
+	sessions, err := store.ListSessions(ctx, SessionFilter{})
 	require.NoError(t, err)
 	require.Len(t, sessions, 1)
-	require.Equal(t, "test-session", sessions[0])
+	require.Equal(t, "test-session", sessions[0].ID)
 
 	// Verify we can read messages
 	messages, err := store.LoadMessages(ctx, "test-session")
@@ -0,0 +1,66 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAgent_PatternIndexCache(t *testing.T) {
+	patternsDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(patternsDir, "sessionize.yaml"), []byte(`name: sessionize
+title: Sessionize Events
+category: analytics
+`), 0600))
+
+	newAgent := func(indexCache bool, cacheDir string) *Agent {
+		cfg := DefaultConfig()
+		cfg.PatternsDir = patternsDir
+		cfg.PatternConfig = DefaultPatternConfig()
+		cfg.PatternConfig.UseLLMClassifier = false
+		cfg.PatternConfig.IndexCache = indexCache
+		cfg.PatternConfig.IndexCacheDir = cacheDir
+		return NewAgent(nil, &mockSimpleLLM{}, WithConfig(cfg))
+	}
+	cacheFiles := func(dir string) []string {
+		matches, err := filepath.Glob(filepath.Join(dir, "pattern-index-*.json"))
+		require.NoError(t, err)
+		return matches
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		ag := newAgent(true, cacheDir)
+		summaries := ag.GetOrchestrator().GetLibrary().ListAll()
+		require.NotEmpty(t, summaries)
+		assert.Len(t, cacheFiles(cacheDir), 1, "indexing writes the cache")
+
+		// A second agent on the same directory reads the same index back
+		again := newAgent(true, cacheDir).GetOrchestrator().GetLibrary().ListAll()
+		assert.Equal(t, summaries, again)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		ag := newAgent(false, cacheDir)
+		require.NotEmpty(t, ag.GetOrchestrator().GetLibrary().ListAll())
+		assert.Empty(t, cacheFiles(cacheDir))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -37,6 +38,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrAgentNotFound is returned when no agent or agent config matches a name or GUID.
+var ErrAgentNotFound = errors.New("agent not found")
+
 // ReloadCallback is called when an agent config changes.
 // It receives the agent name, agent GUID, and new configuration.
 // The GUID is the stable identifier that should be used for agent registration.
@@ -493,6 +497,11 @@ func (r *Registry) buildAgent(ctx context.Context, config *loomv1.AgentConfig) (
 		opts = append(opts, WithConfig(agentConfig))
 	}
 
+	// Set spawn allowlist if provided (after WithConfig, which replaces the config)
+	if canSpawn := SpawnAllowlist(config); len(canSpawn) > 0 {
+		opts = append(opts, WithCanSpawn(canSpawn))
+	}
+
 	// Set tracer if provided
 	if r.tracer != nil {
 		opts = append(opts, WithTracer(r.tracer))
@@ -1027,11 +1036,11 @@ func (r *Registry) StartAgent(ctx context.Context, nameOrID string) error {
 		// Fall back to name lookup
 		agentID, nameExists := r.agentsByName[nameOrID]
 		if !nameExists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 		info, exists = r.agentInfo[agentID]
 		if !exists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 	}
 
@@ -1060,11 +1069,11 @@ func (r *Registry) StopAgent(ctx context.Context, nameOrID string) error {
 		// Fall back to name lookup
 		agentID, nameExists := r.agentsByName[nameOrID]
 		if !nameExists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 		info, exists = r.agentInfo[agentID]
 		if !exists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 	}
 
@@ -1114,7 +1123,7 @@ func (r *Registry) GetAgent(ctx context.Context, nameOrID string) (*Agent, error
 	r.mu.RUnlock()
 
 	if !guidExists {
-		return nil, fmt.Errorf("agent not found: %s", nameOrID)
+		return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 	}
 
 	// Found by GUID - try to get by name
@@ -1215,7 +1224,7 @@ func (r *Registry) GetAgentInfo(nameOrID string) (*AgentInstanceInfo, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("agent not found: %s", nameOrID)
+	return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 }
 
 // GetAgentByID returns information about an agent by GUID only.
@@ -1226,7 +1235,7 @@ func (r *Registry) GetAgentByID(id string) (*AgentInstanceInfo, error) {
 
 	info, exists := r.agentInfo[id]
 	if !exists {
-		return nil, fmt.Errorf("agent not found with ID: %s", id)
+		return nil, fmt.Errorf("%w with ID: %s", ErrAgentNotFound, id)
 	}
 
 	return info, nil
@@ -1276,11 +1285,11 @@ func (r *Registry) DeleteAgent(ctx context.Context, nameOrID string, force bool)
 		// Fall back to name lookup
 		agentID, nameExists := r.agentsByName[nameOrID]
 		if !nameExists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 		info, exists = r.agentInfo[agentID]
 		if !exists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 	}
 
@@ -1329,11 +1338,11 @@ func (r *Registry) ReloadAgent(ctx context.Context, nameOrID string) error {
 		// Fall back to name lookup
 		agentID, nameExists := r.agentsByName[nameOrID]
 		if !nameExists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 		info, exists = r.agentInfo[agentID]
 		if !exists {
-			return fmt.Errorf("agent not found: %s", nameOrID)
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, nameOrID)
 		}
 	}
 
@@ -1792,6 +1801,11 @@ func (r *Registry) loadAgentsFromDB() error {
 	return rows.Err()
 }
 
+// Ping verifies the registry database is reachable (used by health checks).
+func (r *Registry) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
 // Close closes the registry and cleans up resources
 func (r *Registry) Close() error {
 	r.watcher.Close()
@@ -1345,7 +1345,7 @@ func (sm *SegmentedMemory) SearchMessages(
 	ctx, span := sm.tracer.StartSpan(ctx, "memory.search_messages")
 	defer sm.tracer.EndSpan(span)
 
-	span.SetAttribute("query", query)
+	span.SetAttribute("query", observability.Redact(query))
 	span.SetAttribute("limit", fmt.Sprintf("%d", limit))
 
 	// Phase 1: BM25 retrieval (top-50 for reranking)
@@ -1403,7 +1403,7 @@ func (sm *SegmentedMemory) rerankByRelevance(
 	ctx, span := sm.tracer.StartSpan(ctx, "memory.rerank_search_results")
 	defer sm.tracer.EndSpan(span)
 
-	span.SetAttribute("query", query)
+	span.SetAttribute("query", observability.Redact(query))
 	span.SetAttribute("candidates_count", fmt.Sprintf("%d", len(candidates)))
 	span.SetAttribute("top_n", fmt.Sprintf("%d", topN))
 
@@ -0,0 +1,87 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// SessionBackend is session persistence that can be shared by several server instances.
+// SessionStore (SQLite, local), RedisSessionStore, and PostgresSessionStore implement it.
+// LoadSession returns an error wrapping ErrSessionNotFound for unknown IDs.
+type SessionBackend interface {
+	SaveSession(ctx context.Context, session *Session) error
+	LoadSession(ctx context.Context, sessionID string) (*Session, error)
+	DeleteSession(ctx context.Context, sessionID string) error
+	ListSessions(ctx context.Context, filter SessionFilter) ([]*Session, error)
+	SaveMessage(ctx context.Context, sessionID string, msg Message) error
+	LoadMessages(ctx context.Context, sessionID string) ([]Message, error)
+	ExportSession(ctx context.Context, sessionID string) ([]byte, error)
+	ImportSession(ctx context.Context, data []byte) (string, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+var (
+	_ SessionBackend = (*SessionStore)(nil)
+	_ SessionBackend = (*RedisSessionStore)(nil)
+	_ SessionBackend = (*PostgresSessionStore)(nil)
+)
+
+// SessionBackendConfig selects and configures a SessionBackend.
+type SessionBackendConfig struct {
+	// Backend driver: sqlite (default), redis, or postgres
+	Driver string
+
+	// SQLite database path
+	Path string
+
+	// Connection URL for redis (redis://host:6379/0) and postgres (postgres://user@host/db)
+	URL string
+
+	// Idle session expiry for redis, enforced with key expiry (0 = never)
+	TTL time.Duration
+}
+
+// NewSessionBackend creates the SessionBackend selected by cfg.Driver.
+func NewSessionBackend(cfg SessionBackendConfig, tracer observability.Tracer) (SessionBackend, error) {
+	switch cfg.Driver {
+	case "", "sqlite":
+		return NewSessionStore(cfg.Path, tracer)
+	case "redis":
+		return NewRedisSessionStore(RedisSessionStoreConfig{URL: cfg.URL, TTL: cfg.TTL}, tracer)
+	case "postgres":
+		return NewPostgresSessionStore(cfg.URL, tracer)
+	default:
+		return nil, fmt.Errorf("unknown session backend: %s (supported: sqlite, redis, postgres)", cfg.Driver)
+	}
+}
+
+// exportSession loads a session from any backend and encodes it for ImportSession.
+func exportSession(ctx context.Context, backend SessionBackend, sessionID string) ([]byte, error) {
+	session, err := backend.LoadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(newSessionExport(session), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session export: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,383 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// testSessionBackendConformance runs the behavior every SessionBackend must share.
+// newBackend returns an empty backend.
+func testSessionBackendConformance(t *testing.T, newBackend func(t *testing.T) SessionBackend) {
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		backend := newBackend(t)
+		session := &Session{
+			ID:           "sess-1",
+			AgentID:      "analyst",
+			Context:      map[string]interface{}{"table": "sales"},
+			CreatedAt:    base,
+			UpdatedAt:    base,
+			TotalCostUSD: 0.25,
+			TotalTokens:  120,
+		}
+		if err := backend.SaveSession(ctx, session); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		session.TotalTokens = 150
+		if err := backend.SaveSession(ctx, session); err != nil {
+			t.Fatalf("Failed to update session: %v", err)
+		}
+
+		loaded, err := backend.LoadSession(ctx, "sess-1")
+		if err != nil {
+			t.Fatalf("Failed to load session: %v", err)
+		}
+		if loaded.AgentID != "analyst" || loaded.TotalTokens != 150 || loaded.TotalCostUSD != 0.25 {
+			t.Errorf("Unexpected session: %+v", loaded)
+		}
+		if loaded.Context["table"] != "sales" {
+			t.Errorf("Expected context to round-trip, got %v", loaded.Context)
+		}
+		if !loaded.CreatedAt.Equal(base) {
+			t.Errorf("Expected created_at %v, got %v", base, loaded.CreatedAt)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		backend := newBackend(t)
+		if _, err := backend.LoadSession(ctx, "missing"); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("Expected ErrSessionNotFound, got %v", err)
+		}
+		if _, err := backend.ExportSession(ctx, "missing"); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("Expected ErrSessionNotFound from export, got %v", err)
+		}
+	})
+
+	t.Run("Messages", func(t *testing.T) {
+		backend := newBackend(t)
+		if err := backend.SaveSession(ctx, &Session{ID: "sess-1", CreatedAt: base, UpdatedAt: base}); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		// Same-timestamp messages keep save order
+		for _, content := range []string{"first", "second", "third"} {
+			if err := backend.SaveMessage(ctx, "sess-1", Message{Role: "user", Content: content, Timestamp: base}); err != nil {
+				t.Fatalf("Failed to save message: %v", err)
+			}
+		}
+
+		messages, err := backend.LoadMessages(ctx, "sess-1")
+		if err != nil {
+			t.Fatalf("Failed to load messages: %v", err)
+		}
+		if len(messages) != 3 {
+			t.Fatalf("Expected 3 messages, got %d", len(messages))
+		}
+		for i, want := range []string{"first", "second", "third"} {
+			if messages[i].Content != want {
+				t.Errorf("Message %d: expected %q, got %q", i, want, messages[i].Content)
+			}
+		}
+
+		loaded, err := backend.LoadSession(ctx, "sess-1")
+		if err != nil {
+			t.Fatalf("Failed to load session: %v", err)
+		}
+		if len(loaded.Messages) != 3 {
+			t.Errorf("Expected LoadSession to include 3 messages, got %d", len(loaded.Messages))
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		backend := newBackend(t)
+		if err := backend.SaveSession(ctx, &Session{ID: "sess-1", CreatedAt: base, UpdatedAt: base}); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		if err := backend.SaveMessage(ctx, "sess-1", Message{Role: "user", Content: "hi", Timestamp: base}); err != nil {
+			t.Fatalf("Failed to save message: %v", err)
+		}
+		if err := backend.DeleteSession(ctx, "sess-1"); err != nil {
+			t.Fatalf("Failed to delete session: %v", err)
+		}
+		if _, err := backend.LoadSession(ctx, "sess-1"); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("Expected ErrSessionNotFound after delete, got %v", err)
+		}
+		sessions, err := backend.ListSessions(ctx, SessionFilter{})
+		if err != nil {
+			t.Fatalf("Failed to list sessions: %v", err)
+		}
+		if len(sessions) != 0 {
+			t.Errorf("Expected no sessions after delete, got %d", len(sessions))
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		backend := newBackend(t)
+		for i, session := range []*Session{
+			{ID: "coordinator", AgentID: "coordinator"},
+			{ID: "child-a", AgentID: "analyst", ParentSessionID: "coordinator"},
+			{ID: "child-b", AgentID: "analyst", ParentSessionID: "coordinator"},
+			{ID: "other", AgentID: "writer"},
+		} {
+			session.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			session.UpdatedAt = session.CreatedAt
+			if err := backend.SaveSession(ctx, session); err != nil {
+				t.Fatalf("Failed to save session %s: %v", session.ID, err)
+			}
+		}
+
+		ids := func(sessions []*Session) []string {
+			out := make([]string, len(sessions))
+			for i, s := range sessions {
+				out[i] = s.ID
+			}
+			return out
+		}
+		list := func(filter SessionFilter) []*Session {
+			t.Helper()
+			sessions, err := backend.ListSessions(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to list sessions: %v", err)
+			}
+			return sessions
+		}
+		equal := func(got []*Session, want ...string) {
+			t.Helper()
+			gotIDs := ids(got)
+			if len(gotIDs) != len(want) {
+				t.Fatalf("Expected %v, got %v", want, gotIDs)
+			}
+			for i := range want {
+				if gotIDs[i] != want[i] {
+					t.Fatalf("Expected %v, got %v", want, gotIDs)
+				}
+			}
+		}
+
+		equal(list(SessionFilter{}), "other", "child-b", "child-a", "coordinator")
+		equal(list(SessionFilter{AgentID: "analyst"}), "child-b", "child-a")
+		equal(list(SessionFilter{ParentSessionID: "coordinator"}), "child-b", "child-a")
+		equal(list(SessionFilter{CreatedAfter: base.Add(time.Minute), CreatedBefore: base.Add(3 * time.Minute)}), "child-b", "child-a")
+
+		page := list(SessionFilter{Limit: 3})
+		equal(page, "other", "child-b", "child-a")
+		equal(list(SessionFilter{Limit: 3, Cursor: SessionCursor(page[2])}), "coordinator")
+
+		if _, err := backend.ListSessions(ctx, SessionFilter{Cursor: "not-a-cursor"}); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+
+	t.Run("ExportImport", func(t *testing.T) {
+		backend := newBackend(t)
+		if err := backend.SaveSession(ctx, &Session{ID: "parent", AgentID: "coordinator", CreatedAt: base, UpdatedAt: base}); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		if err := backend.SaveSession(ctx, &Session{ID: "child", AgentID: "analyst", ParentSessionID: "parent", CreatedAt: base, UpdatedAt: base}); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		if err := backend.SaveMessage(ctx, "child", Message{Role: "user", Content: "profile sales", Timestamp: base}); err != nil {
+			t.Fatalf("Failed to save message: %v", err)
+		}
+
+		parentData, err := backend.ExportSession(ctx, "parent")
+		if err != nil {
+			t.Fatalf("Failed to export parent: %v", err)
+		}
+		childData, err := backend.ExportSession(ctx, "child")
+		if err != nil {
+			t.Fatalf("Failed to export child: %v", err)
+		}
+
+		dst := newBackend(t)
+		newParent, err := dst.ImportSession(ctx, parentData)
+		if err != nil {
+			t.Fatalf("Failed to import parent: %v", err)
+		}
+		newChild, err := dst.ImportSession(ctx, childData)
+		if err != nil {
+			t.Fatalf("Failed to import child: %v", err)
+		}
+		if newParent == "parent" || newChild == "child" {
+			t.Errorf("Expected imported sessions to get new IDs, got %s and %s", newParent, newChild)
+		}
+
+		child, err := dst.LoadSession(ctx, newChild)
+		if err != nil {
+			t.Fatalf("Failed to load imported child: %v", err)
+		}
+		if child.ParentSessionID != newParent {
+			t.Errorf("Expected parent %s, got %s", newParent, child.ParentSessionID)
+		}
+		if len(child.Messages) != 1 || child.Messages[0].Content != "profile sales" {
+			t.Errorf("Expected imported message, got %+v", child.Messages)
+		}
+
+		if _, err := dst.ImportSession(ctx, []byte(`{"version": 99}`)); err == nil {
+			t.Error("Expected error importing unsupported version")
+		}
+	})
+}
+
+func TestSessionBackendConformance_SQLite(t *testing.T) {
+	testSessionBackendConformance(t, func(t *testing.T) SessionBackend {
+		store, err := NewSessionStore(t.TempDir()+"/test.db", observability.NewNoOpTracer())
+		if err != nil {
+			t.Fatalf("Failed to create store: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	})
+}
+
+func TestSessionBackendConformance_Redis(t *testing.T) {
+	testSessionBackendConformance(t, func(t *testing.T) SessionBackend {
+		return newTestRedisSessionStore(t, miniredis.RunT(t), 0)
+	})
+}
+
+func TestSessionBackendConformance_Postgres(t *testing.T) {
+	dsn := os.Getenv("LOOM_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("LOOM_TEST_POSTGRES_DSN not set")
+	}
+	testSessionBackendConformance(t, func(t *testing.T) SessionBackend {
+		return newTestPostgresSessionStore(t, dsn)
+	})
+}
+
+func newTestPostgresSessionStore(t *testing.T, dsn string) *PostgresSessionStore {
+	store, err := NewPostgresSessionStore(dsn, observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if _, err := store.db.Exec("TRUNCATE loom_sessions CASCADE"); err != nil {
+		t.Fatalf("Failed to reset tables: %v", err)
+	}
+	return store
+}
+
+func TestPostgresSessionStore_UnpersistedParent(t *testing.T) {
+	dsn := os.Getenv("LOOM_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("LOOM_TEST_POSTGRES_DSN not set")
+	}
+	ctx := context.Background()
+	store := newTestPostgresSessionStore(t, dsn)
+	now := time.Now()
+
+	// A spawned session may be saved before (or without) its parent
+	child := &Session{ID: "spawned", AgentID: "analyst", ParentSessionID: "coordinator", CreatedAt: now, UpdatedAt: now}
+	if err := store.SaveSession(ctx, child); err != nil {
+		t.Fatalf("Failed to save session with unpersisted parent: %v", err)
+	}
+	loaded, err := store.LoadSession(ctx, "spawned")
+	if err != nil {
+		t.Fatalf("Failed to load session: %v", err)
+	}
+	if loaded.ParentSessionID != "coordinator" {
+		t.Errorf("Expected parent coordinator, got %q", loaded.ParentSessionID)
+	}
+	children, err := store.ListSessions(ctx, SessionFilter{ParentSessionID: "coordinator"})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(children) != 1 || children[0].ID != "spawned" {
+		t.Errorf("Expected the spawned session under its parent, got %v", children)
+	}
+
+	// Deleting the parent once persisted still detaches its children
+	parent := &Session{ID: "coordinator", AgentID: "coordinator", CreatedAt: now, UpdatedAt: now}
+	if err := store.SaveSession(ctx, parent); err != nil {
+		t.Fatalf("Failed to save parent: %v", err)
+	}
+	if err := store.DeleteSession(ctx, "coordinator"); err != nil {
+		t.Fatalf("Failed to delete parent: %v", err)
+	}
+	loaded, err = store.LoadSession(ctx, "spawned")
+	if err != nil {
+		t.Fatalf("Failed to load session: %v", err)
+	}
+	if loaded.ParentSessionID != "" {
+		t.Errorf("Expected parent to be cleared, got %q", loaded.ParentSessionID)
+	}
+}
+
+func newTestRedisSessionStore(t *testing.T, mr *miniredis.Miniredis, ttl time.Duration) *RedisSessionStore {
+	store, err := NewRedisSessionStore(RedisSessionStoreConfig{URL: "redis://" + mr.Addr(), TTL: ttl}, observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestRedisSessionStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	store := newTestRedisSessionStore(t, mr, time.Hour)
+
+	now := time.Now()
+	for _, id := range []string{"idle", "active"} {
+		if err := store.SaveSession(ctx, &Session{ID: id, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+	}
+
+	mr.FastForward(40 * time.Minute)
+	if err := store.SaveMessage(ctx, "active", Message{Role: "user", Content: "still here", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Failed to save message: %v", err)
+	}
+	mr.FastForward(40 * time.Minute)
+
+	if _, err := store.LoadSession(ctx, "idle"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected idle session to expire, got %v", err)
+	}
+	active, err := store.LoadSession(ctx, "active")
+	if err != nil {
+		t.Fatalf("Expected active session to survive, got %v", err)
+	}
+	if len(active.Messages) != 1 {
+		t.Errorf("Expected messages to expire with the session, got %d", len(active.Messages))
+	}
+
+	sessions, err := store.ListSessions(ctx, SessionFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "active" {
+		t.Errorf("Expected only the active session to be listed, got %d", len(sessions))
+	}
+
+	// Disabling the TTL persists sessions on their next write
+	store.SetSessionTTL(0)
+	if err := store.SaveSession(ctx, active); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+	mr.FastForward(24 * time.Hour)
+	if _, err := store.LoadSession(ctx, "active"); err != nil {
+		t.Errorf("Expected session without TTL to persist, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// minSessionSweepInterval bounds the default sweep interval for short TTLs.
+const minSessionSweepInterval = time.Second
+
+// SetSessionTTL enables automatic expiry: every sweepInterval, sessions whose UpdatedAt
+// is older than ttl are deleted, with or without a parent session. Expiry hooks run
+// before each deletion and cleanup hooks after it (see RegisterExpiryHook).
+// A sweepInterval of 0 uses a tenth of the TTL, at least one second. A ttl of 0 stops
+// the sweeper. Calling it again replaces the previous settings.
+func (s *SessionStore) SetSessionTTL(ttl, sweepInterval time.Duration) {
+	s.ttlMu.Lock()
+	defer s.ttlMu.Unlock()
+
+	if s.stopSweeper != nil {
+		s.stopSweeper()
+		s.stopSweeper = nil
+	}
+	s.ttl = ttl
+	if ttl <= 0 {
+		return
+	}
+
+	if sweepInterval <= 0 {
+		sweepInterval = max(ttl/10, minSessionSweepInterval)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopSweeper = cancel
+	go s.runSweeper(ctx, sweepInterval)
+}
+
+// RegisterExpiryHook registers a callback invoked with the ID of each session the TTL
+// sweeper is about to delete, so dependent resources (bus subscriptions, spawned child
+// agents) can be released first. Thread-safe.
+func (s *SessionStore) RegisterExpiryHook(hook SessionCleanupHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expiryHooks = append(s.expiryHooks, hook)
+}
+
+// ExpireSessions deletes every session last updated more than the TTL ago and returns
+// how many were deleted. It is run by the sweeper and is a no-op when no TTL is set.
+// A session updated after its expiry hooks ran is kept.
+func (s *SessionStore) ExpireSessions(ctx context.Context) (int, error) {
+	s.ttlMu.Lock()
+	ttl := s.ttl
+	s.ttlMu.Unlock()
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	ctx, span := s.tracer.StartSpan(ctx, "session_store.expire_sessions")
+	defer s.tracer.EndSpan(span)
+
+	cutoff := time.Now().Add(-ttl)
+	expired, err := s.staleSessionIDs(ctx, cutoff)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	s.mu.RLock()
+	hooks := make([]SessionCleanupHook, len(s.expiryHooks))
+	copy(hooks, s.expiryHooks)
+	s.mu.RUnlock()
+
+	deleted := 0
+	for _, sessionID := range expired {
+		for _, hook := range hooks {
+			hook(ctx, sessionID)
+		}
+		ok, err := s.deleteSession(ctx, span, sessionID, cutoff)
+		if err != nil {
+			return deleted, err
+		}
+		if ok {
+			deleted++
+		}
+	}
+
+	span.SetAttribute("expired_count", fmt.Sprintf("%d", deleted))
+	return deleted, nil
+}
+
+// staleSessionIDs returns the IDs of sessions last updated before cutoff, oldest first.
+func (s *SessionStore) staleSessionIDs(ctx context.Context, cutoff time.Time) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, "SELECT id FROM sessions WHERE updated_at < ? ORDER BY updated_at", cutoff.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan session ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expired sessions: %w", err)
+	}
+	return ids, nil
+}
+
+// runSweeper calls ExpireSessions every interval until ctx is cancelled.
+func (s *SessionStore) runSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are recorded on the span; the next sweep retries
+			_, _ = s.ExpireSessions(ctx)
+		}
+	}
+}
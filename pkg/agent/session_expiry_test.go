@@ -0,0 +1,153 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+func TestSessionStore_ExpireSessions(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir()+"/test.db", observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	old := time.Now().Add(-2 * time.Hour)
+	for _, session := range []*Session{
+		{ID: "idle-parent", CreatedAt: old, UpdatedAt: old},
+		{ID: "idle-child", ParentSessionID: "idle-parent", CreatedAt: old, UpdatedAt: old},
+		{ID: "active", CreatedAt: old, UpdatedAt: time.Now()},
+	} {
+		session.Context = make(map[string]interface{})
+		if err := store.SaveSession(ctx, session); err != nil {
+			t.Fatalf("Failed to save session %s: %v", session.ID, err)
+		}
+	}
+
+	// Without a TTL nothing expires
+	if n, err := store.ExpireSessions(ctx); err != nil || n != 0 {
+		t.Fatalf("Expected no expiry without a TTL, got %d, %v", n, err)
+	}
+
+	var events []string
+	store.RegisterExpiryHook(func(ctx context.Context, sessionID string) {
+		// Expiry hooks run while the session still exists
+		if _, err := store.LoadSession(ctx, sessionID); err != nil {
+			t.Errorf("Expected %s to exist in expiry hook, got %v", sessionID, err)
+		}
+		events = append(events, "expire:"+sessionID)
+	})
+	store.RegisterCleanupHook(func(_ context.Context, sessionID string) {
+		events = append(events, "cleanup:"+sessionID)
+	})
+
+	store.SetSessionTTL(time.Hour, time.Hour)
+	n, err := store.ExpireSessions(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 expired sessions, got %d", n)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 hook calls, got %v", events)
+	}
+	for i := 0; i < len(events); i += 2 {
+		id := events[i][len("expire:"):]
+		if events[i] != "expire:"+id || events[i+1] != "cleanup:"+id {
+			t.Errorf("Expected the expiry hook before the cleanup hook for each session, got %v", events)
+		}
+	}
+
+	for _, id := range []string{"idle-parent", "idle-child"} {
+		if _, err := store.LoadSession(ctx, id); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("Expected %s to be deleted, got %v", id, err)
+		}
+	}
+	if _, err := store.LoadSession(ctx, "active"); err != nil {
+		t.Errorf("Expected active session to be kept, got %v", err)
+	}
+}
+
+func TestSessionStore_ExpiryRefreshedInHook(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir()+"/test.db", observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	old := time.Now().Add(-2 * time.Hour)
+	session := &Session{ID: "revived", CreatedAt: old, UpdatedAt: old, Context: make(map[string]interface{})}
+	if err := store.SaveSession(ctx, session); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	// A session updated while its expiry hooks run is kept
+	store.RegisterExpiryHook(func(ctx context.Context, _ string) {
+		session.UpdatedAt = time.Now()
+		_ = store.SaveSession(ctx, session)
+	})
+	store.SetSessionTTL(time.Hour, time.Hour)
+
+	n, err := store.ExpireSessions(ctx)
+	if err != nil || n != 0 {
+		t.Fatalf("Expected the refreshed session to be kept, got %d, %v", n, err)
+	}
+	if _, err := store.LoadSession(ctx, "revived"); err != nil {
+		t.Errorf("Expected session to exist, got %v", err)
+	}
+}
+
+func TestSessionStore_ExpirySweeper(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir()+"/test.db", observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	old := time.Now().Add(-time.Minute)
+	if err := store.SaveSession(ctx, &Session{ID: "stale", CreatedAt: old, UpdatedAt: old, Context: map[string]interface{}{}}); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	expired := make(chan string, 1)
+	store.RegisterCleanupHook(func(_ context.Context, sessionID string) {
+		expired <- sessionID
+	})
+	store.SetSessionTTL(10*time.Second, 20*time.Millisecond)
+
+	select {
+	case id := <-expired:
+		if id != "stale" {
+			t.Errorf("Expected stale session to expire, got %s", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sweeper did not expire the session")
+	}
+
+	// A zero TTL stops the sweeper
+	store.SetSessionTTL(0, 0)
+	if n, err := store.ExpireSessions(ctx); err != nil || n != 0 {
+		t.Errorf("Expected no expiry after disabling the TTL, got %d, %v", n, err)
+	}
+}
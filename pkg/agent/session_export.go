@@ -0,0 +1,249 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/types"
+)
+
+// sessionExportVersion is bumped when the export format changes incompatibly.
+const sessionExportVersion = 1
+
+// sessionExport is the portable form of a session written by ExportSession.
+type sessionExport struct {
+	Version         int                    `json:"version"`
+	ID              string                 `json:"id"`
+	AgentID         string                 `json:"agent_id,omitempty"`
+	ParentSessionID string                 `json:"parent_session_id,omitempty"`
+	Context         map[string]interface{} `json:"context,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	TotalCostUSD    float64                `json:"total_cost_usd"`
+	TotalTokens     int                    `json:"total_tokens"`
+	Messages        []exportedMessage      `json:"messages"`
+}
+
+// exportedMessage is one message of a sessionExport, in conversation order.
+type exportedMessage struct {
+	Role           string               `json:"role"`
+	Content        string               `json:"content,omitempty"`
+	ToolCalls      []types.ToolCall     `json:"tool_calls,omitempty"`
+	ToolUseID      string               `json:"tool_use_id,omitempty"`
+	ToolResult     *shuttle.Result      `json:"tool_result,omitempty"`
+	SessionContext types.SessionContext `json:"session_context,omitempty"`
+	AgentID        string               `json:"agent_id,omitempty"`
+	Timestamp      time.Time            `json:"timestamp"`
+	TokenCount     int                  `json:"token_count,omitempty"`
+	CostUSD        float64              `json:"cost_usd,omitempty"`
+}
+
+// ExportSession returns a session, its metadata, parent link, and messages as JSON that
+// ImportSession can load into another store.
+func (s *SessionStore) ExportSession(ctx context.Context, sessionID string) ([]byte, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "session_store.export_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	data, err := exportSession(ctx, s, sessionID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// ImportSession stores a session written by ExportSession under a new ID and returns it.
+// The parent link is remapped to the parent's new ID if the parent was imported into
+// this store earlier, and cleared otherwise. Messages keep their order and timestamps.
+func (s *SessionStore) ImportSession(ctx context.Context, data []byte) (string, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "session_store.import_session")
+	defer s.tracer.EndSpan(span)
+
+	export, err := parseSessionExport(data)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	contextJSON, err := json.Marshal(export.Context)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to marshal context: %w", err)
+	}
+
+	newID := newImportedSessionID()
+	span.SetAttribute("original_session_id", export.ID)
+	span.SetAttribute("session_id", newID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var parentSessionID interface{}
+	if export.ParentSessionID != "" {
+		var parent string
+		err := tx.QueryRowContext(ctx,
+			"SELECT session_id FROM session_imports WHERE original_id = ? ORDER BY imported_at DESC LIMIT 1",
+			export.ParentSessionID).Scan(&parent)
+		switch {
+		case err == nil:
+			parentSessionID = parent
+		case !errors.Is(err, sql.ErrNoRows):
+			span.RecordError(err)
+			return "", fmt.Errorf("failed to look up imported parent session: %w", err)
+		}
+	}
+	var agentID interface{}
+	if export.AgentID != "" {
+		agentID = export.AgentID
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sessions (id, agent_id, parent_session_id, context_json, created_at, updated_at, total_cost_usd, total_tokens)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		newID, agentID, parentSessionID, string(contextJSON),
+		export.CreatedAt.Unix(), export.UpdatedAt.Unix(), export.TotalCostUSD, export.TotalTokens)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to save session: %w", err)
+	}
+
+	// Inserted in export order, which LoadMessages preserves for equal timestamps
+	for _, m := range export.Messages {
+		if err := insertMessage(ctx, tx, newID, m.message()); err != nil {
+			span.RecordError(err)
+			return "", err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO session_imports (session_id, original_id, imported_at) VALUES (?, ?, ?)",
+		newID, export.ID, time.Now().UnixNano())
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to record session import: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	span.SetAttribute("message_count", fmt.Sprintf("%d", len(export.Messages)))
+	return newID, nil
+}
+
+// newSessionExport converts a loaded session, including its messages, to export form.
+func newSessionExport(session *Session) *sessionExport {
+	export := &sessionExport{
+		Version:         sessionExportVersion,
+		ID:              session.ID,
+		AgentID:         session.AgentID,
+		ParentSessionID: session.ParentSessionID,
+		Context:         session.Context,
+		CreatedAt:       session.CreatedAt,
+		UpdatedAt:       session.UpdatedAt,
+		TotalCostUSD:    session.TotalCostUSD,
+		TotalTokens:     session.TotalTokens,
+		Messages:        make([]exportedMessage, len(session.Messages)),
+	}
+	for i, msg := range session.Messages {
+		export.Messages[i] = newExportedMessage(msg)
+	}
+	return export
+}
+
+// parseSessionExport decodes and checks data written by ExportSession.
+func parseSessionExport(data []byte) (*sessionExport, error) {
+	var export sessionExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse session export: %w", err)
+	}
+	if export.Version != sessionExportVersion {
+		return nil, fmt.Errorf("unsupported session export version %d (expected %d)", export.Version, sessionExportVersion)
+	}
+	if export.ID == "" {
+		return nil, fmt.Errorf("session export has no session ID")
+	}
+	if export.Context == nil {
+		export.Context = make(map[string]interface{})
+	}
+	return &export, nil
+}
+
+// session returns the exported session's metadata, without messages, under id.
+func (e *sessionExport) session(id, parentSessionID string) *Session {
+	return &Session{
+		ID:              id,
+		AgentID:         e.AgentID,
+		ParentSessionID: parentSessionID,
+		Context:         e.Context,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+		TotalCostUSD:    e.TotalCostUSD,
+		TotalTokens:     e.TotalTokens,
+	}
+}
+
+// newExportedMessage converts a message to its portable form.
+func newExportedMessage(msg Message) exportedMessage {
+	return exportedMessage{
+		Role:           msg.Role,
+		Content:        msg.Content,
+		ToolCalls:      msg.ToolCalls,
+		ToolUseID:      msg.ToolUseID,
+		ToolResult:     msg.ToolResult,
+		SessionContext: msg.SessionContext,
+		AgentID:        msg.AgentID,
+		Timestamp:      msg.Timestamp,
+		TokenCount:     msg.TokenCount,
+		CostUSD:        msg.CostUSD,
+	}
+}
+
+// message converts a portable message back to a Message.
+func (m exportedMessage) message() Message {
+	return Message{
+		Role:           m.Role,
+		Content:        m.Content,
+		ToolCalls:      m.ToolCalls,
+		ToolUseID:      m.ToolUseID,
+		ToolResult:     m.ToolResult,
+		SessionContext: m.SessionContext,
+		AgentID:        m.AgentID,
+		Timestamp:      m.Timestamp,
+		TokenCount:     m.TokenCount,
+		CostUSD:        m.CostUSD,
+	}
+}
+
+// newImportedSessionID returns a fresh ID for an imported session.
+func newImportedSessionID() string {
+	return fmt.Sprintf("sess_%s", uuid.New().String()[:8])
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+func TestSessionStore_ExportImport(t *testing.T) {
+	tracer := observability.NewNoOpTracer()
+	src, err := NewSessionStore(t.TempDir()+"/src.db", tracer)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer src.Close()
+	dst, err := NewSessionStore(t.TempDir()+"/dst.db", tracer)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer dst.Close()
+
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, session := range []*Session{
+		{ID: "coordinator", AgentID: "coordinator", CreatedAt: created, UpdatedAt: created, Context: map[string]interface{}{"table": "sales"}},
+		{ID: "child", AgentID: "wf:analyst", ParentSessionID: "coordinator", CreatedAt: created, UpdatedAt: created, TotalTokens: 42, TotalCostUSD: 0.5},
+	} {
+		if err := src.SaveSession(ctx, session); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+	}
+
+	// Three messages in the same second must keep their order
+	messages := []Message{
+		{Role: "user", Content: "profile the table", Timestamp: created},
+		{Role: "assistant", ToolCalls: []ToolCall{{ID: "call-1", Name: "profile", Input: map[string]interface{}{"table": "sales"}}}, Timestamp: created},
+		{Role: "tool", ToolUseID: "call-1", ToolResult: &shuttle.Result{Success: true, Data: "3 columns"}, Timestamp: created},
+		{Role: "assistant", Content: "done", AgentID: "wf:analyst", Timestamp: created.Add(time.Minute), TokenCount: 7},
+	}
+	for _, msg := range messages {
+		if err := src.SaveMessage(ctx, "child", msg); err != nil {
+			t.Fatalf("Failed to save message: %v", err)
+		}
+	}
+
+	// A child imported without its parent loses the parent link
+	childData, err := src.ExportSession(ctx, "child")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	orphanID, err := dst.ImportSession(ctx, childData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	orphan, err := dst.LoadSession(ctx, orphanID)
+	if err != nil {
+		t.Fatalf("Expected imported session, got %v", err)
+	}
+	if orphan.ParentSessionID != "" {
+		t.Errorf("Expected parent link to be cleared, got %q", orphan.ParentSessionID)
+	}
+
+	// After the parent is imported, the child is linked to the parent's new ID
+	parentData, err := src.ExportSession(ctx, "coordinator")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	parentID, err := dst.ImportSession(ctx, parentData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	childID, err := dst.ImportSession(ctx, childData)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if childID == "child" || childID == orphanID || parentID == "coordinator" {
+		t.Errorf("Expected fresh IDs, got parent %s and child %s", parentID, childID)
+	}
+
+	parent, err := dst.LoadSession(ctx, parentID)
+	if err != nil {
+		t.Fatalf("Expected imported parent, got %v", err)
+	}
+	if parent.Context["table"] != "sales" || parent.AgentID != "coordinator" {
+		t.Errorf("Expected parent metadata to round-trip, got %+v", parent)
+	}
+
+	child, err := dst.LoadSession(ctx, childID)
+	if err != nil {
+		t.Fatalf("Expected imported child, got %v", err)
+	}
+	if child.ParentSessionID != parentID {
+		t.Errorf("Expected parent %s, got %q", parentID, child.ParentSessionID)
+	}
+	if child.AgentID != "wf:analyst" || child.TotalTokens != 42 || child.TotalCostUSD != 0.5 {
+		t.Errorf("Expected session metadata to round-trip, got %+v", child)
+	}
+	if !child.CreatedAt.Equal(created) || !child.UpdatedAt.Equal(created) {
+		t.Errorf("Expected timestamps %v, got created %v updated %v", created, child.CreatedAt, child.UpdatedAt)
+	}
+
+	if len(child.Messages) != len(messages) {
+		t.Fatalf("Expected %d messages, got %d", len(messages), len(child.Messages))
+	}
+	for i, want := range messages {
+		got := child.Messages[i]
+		if got.Role != want.Role || got.Content != want.Content || !got.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("Message %d: expected %s %q at %v, got %s %q at %v", i, want.Role, want.Content, want.Timestamp, got.Role, got.Content, got.Timestamp)
+		}
+	}
+	if calls := child.Messages[1].ToolCalls; len(calls) != 1 || calls[0].Name != "profile" {
+		t.Errorf("Expected tool call to round-trip, got %+v", calls)
+	}
+	if result := child.Messages[2].ToolResult; result == nil || !result.Success || child.Messages[2].ToolUseID != "call-1" {
+		t.Errorf("Expected tool result to round-trip, got %+v", child.Messages[2])
+	}
+	if child.Messages[3].AgentID != "wf:analyst" || child.Messages[3].TokenCount != 7 {
+		t.Errorf("Expected message metadata to round-trip, got %+v", child.Messages[3])
+	}
+}
+
+func TestSessionStore_ImportSession_Invalid(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir()+"/test.db", observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	for name, data := range map[string]string{
+		"not json":        "{",
+		"unknown version": `{"version": 99, "id": "s1"}`,
+		"missing id":      `{"version": 1}`,
+	} {
+		if _, err := store.ImportSession(ctx, []byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := store.ExportSession(ctx, "missing"); err == nil {
+		t.Error("Expected an error exporting a missing session")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Session listing limits for SessionStore.ListSessions.
+const (
+	DefaultSessionListLimit = 100
+	MaxSessionListLimit     = 1000
+)
+
+// ErrInvalidCursor is returned by ListSessions for a cursor not made by SessionCursor.
+var ErrInvalidCursor = errors.New("invalid session cursor")
+
+// SessionFilter selects sessions for SessionStore.ListSessions. Zero fields match all
+// sessions. Creation times are compared at second precision.
+type SessionFilter struct {
+	// Sessions of this agent only
+	AgentID string
+
+	// Children of this session only
+	ParentSessionID string
+
+	// Sessions created at or after / strictly before these times
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Page size (default: 100, max: 1000)
+	Limit int
+
+	// Resume after the session this cursor was made from (see SessionCursor)
+	Cursor string
+}
+
+// SessionCursor returns an opaque cursor that makes ListSessions resume after session,
+// normally the last session of the previous page.
+func SessionCursor(session *Session) string {
+	raw := strconv.FormatInt(session.CreatedAt.UnixNano(), 10) + ":" + session.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// parseSessionCursor decodes a cursor made by SessionCursor into the creation time and
+// ID of the session to resume after.
+func parseSessionCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+	}
+	createdAt, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+	}
+	nanos, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+	}
+	return time.Unix(0, nanos), id, nil
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,6 +30,9 @@ import (
 	"github.com/teradata-labs/loom/pkg/types"
 )
 
+// ErrSessionNotFound is returned by LoadSession when no session has the given ID.
+var ErrSessionNotFound = errors.New("session not found")
+
 // SessionCleanupHook is called when a session is deleted.
 // Used for cleanup tasks like releasing shared memory references.
 // The hook receives the session ID being deleted.
@@ -41,6 +45,12 @@ type SessionStore struct {
 	mu           sync.RWMutex
 	tracer       observability.Tracer
 	cleanupHooks []SessionCleanupHook
+	expiryHooks  []SessionCleanupHook
+
+	// Session expiry (see SetSessionTTL)
+	ttlMu       sync.Mutex
+	ttl         time.Duration
+	stopSweeper context.CancelFunc
 }
 
 // NewSessionStore creates a new SessionStore with SQLite persistence.
@@ -135,6 +145,15 @@ func (s *SessionStore) initSchema() error {
 		FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
 	);
 
+	-- Original IDs of imported sessions, so children imported later keep their parent link
+	CREATE TABLE IF NOT EXISTS session_imports (
+		session_id TEXT PRIMARY KEY,
+		original_id TEXT NOT NULL,
+		imported_at INTEGER NOT NULL,
+		FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_session_imports_original ON session_imports(original_id, imported_at);
+
 	-- FTS5 virtual table for semantic search (BM25 ranking)
 	CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts5 USING fts5(
 		message_id UNINDEXED,
@@ -370,7 +389,7 @@ func (s *SessionStore) SaveSession(ctx context.Context, session *Session) error
 	defer s.mu.Unlock()
 
 	// Serialize context
-	contextJSON, err := json.Marshal(session.Context)
+	contextJSON, err := session.MarshalContext()
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to marshal context: %w", err)
@@ -461,7 +480,7 @@ func (s *SessionStore) LoadSession(ctx context.Context, sessionID string) (*Sess
 
 	if err == sql.ErrNoRows {
 		span.SetAttribute("found", "false")
-		return nil, fmt.Errorf("session not found: %s", sessionID)
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
 	}
 	if err != nil {
 		span.RecordError(err)
@@ -500,12 +519,28 @@ func (s *SessionStore) SaveMessage(ctx context.Context, sessionID string, msg Me
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := insertMessage(ctx, s.db, sessionID, msg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttribute("tokens", fmt.Sprintf("%d", msg.TokenCount))
+	span.SetAttribute("cost_usd", fmt.Sprintf("%.4f", msg.CostUSD))
+	return nil
+}
+
+// sqlExecer is satisfied by *sql.DB and *sql.Tx.
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertMessage inserts a message row for a session.
+func insertMessage(ctx context.Context, db sqlExecer, sessionID string, msg Message) error {
 	// Serialize tool calls if present
 	var toolCallsJSON *string
 	if len(msg.ToolCalls) > 0 {
 		data, err := json.Marshal(msg.ToolCalls)
 		if err != nil {
-			span.RecordError(err)
 			return fmt.Errorf("failed to marshal tool calls: %w", err)
 		}
 		jsonStr := string(data)
@@ -523,7 +558,6 @@ func (s *SessionStore) SaveMessage(ctx context.Context, sessionID string, msg Me
 	if msg.ToolResult != nil {
 		data, err := json.Marshal(msg.ToolResult)
 		if err != nil {
-			span.RecordError(err)
 			return fmt.Errorf("failed to marshal tool result: %w", err)
 		}
 		jsonStr := string(data)
@@ -547,7 +581,7 @@ func (s *SessionStore) SaveMessage(ctx context.Context, sessionID string, msg Me
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := s.db.ExecContext(ctx, query,
+	_, err := db.ExecContext(ctx, query,
 		sessionID,
 		msg.Role,
 		msg.Content,
@@ -562,12 +596,8 @@ func (s *SessionStore) SaveMessage(ctx context.Context, sessionID string, msg Me
 	)
 
 	if err != nil {
-		span.RecordError(err)
 		return fmt.Errorf("failed to save message: %w", err)
 	}
-
-	span.SetAttribute("tokens", fmt.Sprintf("%d", msg.TokenCount))
-	span.SetAttribute("cost_usd", fmt.Sprintf("%.4f", msg.CostUSD))
 	return nil
 }
 
@@ -584,7 +614,7 @@ func (s *SessionStore) LoadMessages(ctx context.Context, sessionID string) ([]Me
 		SELECT id, role, content, tool_calls_json, tool_use_id, tool_result_json, session_context, agent_id, timestamp, token_count, cost_usd
 		FROM messages
 		WHERE session_id = ?
-		ORDER BY timestamp ASC
+		ORDER BY timestamp ASC, id ASC
 	`
 
 	rows, err := s.db.QueryContext(ctx, query, sessionID)
@@ -1035,13 +1065,33 @@ func (s *SessionStore) DeleteSession(ctx context.Context, sessionID string) erro
 	defer s.tracer.EndSpan(span)
 	span.SetAttribute("session_id", sessionID)
 
+	_, err := s.deleteSession(ctx, span, sessionID, time.Time{})
+	return err
+}
+
+// deleteSession deletes a session, its artifact directory, and runs the cleanup hooks.
+// A non-zero staleBefore deletes the session only if it was last updated before then,
+// and the result reports whether it was deleted.
+func (s *SessionStore) deleteSession(ctx context.Context, span *observability.Span, sessionID string, staleBefore time.Time) (bool, error) {
+	query, args := "DELETE FROM sessions WHERE id = ?", []interface{}{sessionID}
+	if !staleBefore.IsZero() {
+		query += " AND updated_at < ?"
+		args = append(args, staleBefore.Unix())
+	}
+
 	s.mu.Lock()
 	// CASCADE delete will remove messages, tool executions, and artifacts
-	_, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE id = ?", sessionID)
+	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		s.mu.Unlock()
 		span.RecordError(err)
-		return fmt.Errorf("failed to delete session: %w", err)
+		return false, fmt.Errorf("failed to delete session: %w", err)
+	}
+	if !staleBefore.IsZero() {
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			s.mu.Unlock()
+			return false, nil
+		}
 	}
 
 	// Copy hooks to avoid holding lock during callback execution
@@ -1068,32 +1118,94 @@ func (s *SessionStore) DeleteSession(ctx context.Context, sessionID string) erro
 		hook(ctx, sessionID)
 	}
 
-	return nil
+	return true, nil
 }
 
-// ListSessions returns all session IDs.
-func (s *SessionStore) ListSessions(ctx context.Context) ([]string, error) {
+// ListSessions returns sessions matching the filter, newest first (by creation time, then
+// ID). Returned sessions do not include messages; use LoadSession for those.
+// At most filter.Limit sessions are returned; pass SessionCursor of the last one as
+// filter.Cursor to get the next page. An empty page means there are no more sessions.
+func (s *SessionStore) ListSessions(ctx context.Context, filter SessionFilter) ([]*Session, error) {
 	ctx, span := s.tracer.StartSpan(ctx, "session_store.list_sessions")
 	defer s.tracer.EndSpan(span)
 
+	query := `
+		SELECT id, agent_id, parent_session_id, context_json, created_at, updated_at, total_cost_usd, total_tokens
+		FROM sessions
+		WHERE 1 = 1`
+	var args []interface{}
+	if filter.AgentID != "" {
+		query += " AND agent_id = ?"
+		args = append(args, filter.AgentID)
+	}
+	if filter.ParentSessionID != "" {
+		query += " AND parent_session_id = ?"
+		args = append(args, filter.ParentSessionID)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query += " AND created_at >= ?"
+		args = append(args, filter.CreatedAfter.Unix())
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query += " AND created_at < ?"
+		args = append(args, filter.CreatedBefore.Unix())
+	}
+	if filter.Cursor != "" {
+		createdAt, id, err := parseSessionCursor(filter.Cursor)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		query += " AND (created_at < ? OR (created_at = ? AND id < ?))"
+		args = append(args, createdAt.Unix(), createdAt.Unix(), id)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultSessionListLimit
+	}
+	limit = min(limit, MaxSessionListLimit)
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+	args = append(args, limit)
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rows, err := s.db.QueryContext(ctx, "SELECT id FROM sessions ORDER BY updated_at DESC")
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to query sessions: %w", err)
 	}
 	defer rows.Close()
 
-	var sessionIDs []string
+	sessions := make([]*Session, 0)
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var session Session
+		var contextJSON string
+		var createdAt, updatedAt int64
+		var agentID, parentSessionID sql.NullString
+		if err := rows.Scan(
+			&session.ID,
+			&agentID,
+			&parentSessionID,
+			&contextJSON,
+			&createdAt,
+			&updatedAt,
+			&session.TotalCostUSD,
+			&session.TotalTokens,
+		); err != nil {
 			span.RecordError(err)
-			return nil, fmt.Errorf("failed to scan session ID: %w", err)
+			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
-		sessionIDs = append(sessionIDs, id)
+		session.AgentID = agentID.String
+		session.ParentSessionID = parentSessionID.String
+		if err := json.Unmarshal([]byte(contextJSON), &session.Context); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to unmarshal context for session %s: %w", session.ID, err)
+		}
+		session.CreatedAt = time.Unix(createdAt, 0)
+		session.UpdatedAt = time.Unix(updatedAt, 0)
+		sessions = append(sessions, &session)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -1101,8 +1213,8 @@ func (s *SessionStore) ListSessions(ctx context.Context) ([]string, error) {
 		return nil, fmt.Errorf("error iterating sessions: %w", err)
 	}
 
-	span.SetAttribute("session_count", fmt.Sprintf("%d", len(sessionIDs)))
-	return sessionIDs, nil
+	span.SetAttribute("session_count", fmt.Sprintf("%d", len(sessions)))
+	return sessions, nil
 }
 
 // SaveMemorySnapshot persists a memory snapshot (L2 summary) to the database.
@@ -1211,8 +1323,14 @@ func (s *SessionStore) LoadMemorySnapshots(ctx context.Context, sessionID string
 	return snapshots, nil
 }
 
-// Close closes the database connection.
+// Ping verifies the database connection is alive (used by health checks).
+func (s *SessionStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// Close stops the expiry sweeper and closes the database connection.
 func (s *SessionStore) Close() error {
+	s.SetSessionTTL(0, 0)
 	return s.db.Close()
 }
 
@@ -1332,7 +1450,7 @@ func (s *SessionStore) SearchFTS5(ctx context.Context, sessionID, query string,
 	defer s.tracer.EndSpan(span)
 
 	span.SetAttribute("session_id", sessionID)
-	span.SetAttribute("query", query)
+	span.SetAttribute("query", observability.Redact(query))
 	span.SetAttribute("limit", fmt.Sprintf("%d", limit))
 
 	// Validate query - return empty results for invalid/empty queries
@@ -1455,7 +1573,7 @@ func (s *SessionStore) SearchFTS5ByAgent(ctx context.Context, agentID, query str
 	defer s.tracer.EndSpan(span)
 
 	span.SetAttribute("agent_id", agentID)
-	span.SetAttribute("query", query)
+	span.SetAttribute("query", observability.Redact(query))
 	span.SetAttribute("limit", fmt.Sprintf("%d", limit))
 
 	// Validate query - return empty results for invalid/empty queries
@@ -0,0 +1,421 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq" // postgres driver
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// postgresSessionSchema creates the PostgresSessionStore tables. Tables are prefixed so
+// they can live in a database shared with other applications.
+const postgresSessionSchema = `
+	CREATE TABLE IF NOT EXISTS loom_sessions (
+		id TEXT PRIMARY KEY,
+		agent_id TEXT,
+		parent_session_id TEXT,
+		context JSONB NOT NULL DEFAULT '{}'::jsonb,
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		total_cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+		total_tokens INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX IF NOT EXISTS idx_loom_sessions_created ON loom_sessions(created_at DESC, id DESC);
+	CREATE INDEX IF NOT EXISTS idx_loom_sessions_agent ON loom_sessions(agent_id);
+	CREATE INDEX IF NOT EXISTS idx_loom_sessions_parent ON loom_sessions(parent_session_id);
+	-- parent_session_id is not a foreign key: spawned sessions are saved before their
+	-- parent is; drop the constraint earlier schemas created
+	ALTER TABLE loom_sessions DROP CONSTRAINT IF EXISTS loom_sessions_parent_session_id_fkey;
+
+	CREATE TABLE IF NOT EXISTS loom_session_messages (
+		id BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES loom_sessions(id) ON DELETE CASCADE,
+		message JSONB NOT NULL,
+		timestamp TIMESTAMPTZ NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_loom_session_messages_session ON loom_session_messages(session_id, timestamp, id);
+
+	CREATE TABLE IF NOT EXISTS loom_session_imports (
+		session_id TEXT PRIMARY KEY REFERENCES loom_sessions(id) ON DELETE CASCADE,
+		original_id TEXT NOT NULL,
+		imported_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS idx_loom_session_imports_original ON loom_session_imports(original_id, imported_at);
+`
+
+// PostgresSessionStore is a SessionBackend in PostgreSQL, shared by every server instance
+// using the same database. Session context and messages are stored as JSONB.
+type PostgresSessionStore struct {
+	db     *sql.DB
+	tracer observability.Tracer
+}
+
+// NewPostgresSessionStore connects to PostgreSQL and creates the session tables if needed.
+func NewPostgresSessionStore(dsn string, tracer observability.Tracer) (*PostgresSessionStore, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("postgres connection URL is required")
+	}
+	if tracer == nil {
+		tracer = observability.NewNoOpTracer()
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+	if _, err := db.Exec(postgresSessionSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+	return &PostgresSessionStore{db: db, tracer: tracer}, nil
+}
+
+// SaveSession creates or updates a session's metadata. Messages are stored separately
+// with SaveMessage and are not written from session.Messages.
+func (s *PostgresSessionStore) SaveSession(ctx context.Context, session *Session) error {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.save_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", session.ID)
+
+	if err := upsertPostgresSession(ctx, s.db, session); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// upsertPostgresSession writes session metadata with db (a *sql.DB or *sql.Tx).
+func upsertPostgresSession(ctx context.Context, db sqlExecer, session *Session) error {
+	contextJSON, err := session.MarshalContext()
+	if err != nil {
+		return fmt.Errorf("failed to marshal context: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO loom_sessions (id, agent_id, parent_session_id, context, created_at, updated_at, total_cost_usd, total_tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			agent_id = EXCLUDED.agent_id,
+			parent_session_id = EXCLUDED.parent_session_id,
+			context = EXCLUDED.context,
+			updated_at = EXCLUDED.updated_at,
+			total_cost_usd = EXCLUDED.total_cost_usd,
+			total_tokens = EXCLUDED.total_tokens`,
+		session.ID,
+		sql.NullString{String: session.AgentID, Valid: session.AgentID != ""},
+		sql.NullString{String: session.ParentSessionID, Valid: session.ParentSessionID != ""},
+		string(contextJSON),
+		session.CreatedAt,
+		session.UpdatedAt,
+		session.TotalCostUSD,
+		session.TotalTokens,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession loads a session and its messages.
+func (s *PostgresSessionStore) LoadSession(ctx context.Context, sessionID string) (*Session, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.load_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, agent_id, parent_session_id, context, created_at, updated_at, total_cost_usd, total_tokens
+		FROM loom_sessions WHERE id = $1`, sessionID)
+	session, err := scanPostgresSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		span.SetAttribute("found", "false")
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if session.Messages, err = s.LoadMessages(ctx, sessionID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	span.SetAttribute("found", "true")
+	return session, nil
+}
+
+// scanPostgresSession scans one loom_sessions row.
+func scanPostgresSession(row interface{ Scan(...interface{}) error }) (*Session, error) {
+	var session Session
+	var agentID, parentSessionID sql.NullString
+	var contextJSON []byte
+	err := row.Scan(
+		&session.ID,
+		&agentID,
+		&parentSessionID,
+		&contextJSON,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+		&session.TotalCostUSD,
+		&session.TotalTokens,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan session: %w", err)
+	}
+	session.AgentID = agentID.String
+	session.ParentSessionID = parentSessionID.String
+	if err := json.Unmarshal(contextJSON, &session.Context); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal context for session %s: %w", session.ID, err)
+	}
+	return &session, nil
+}
+
+// DeleteSession removes a session and its messages. Child sessions are kept with their
+// parent link cleared.
+func (s *PostgresSessionStore) DeleteSession(ctx context.Context, sessionID string) error {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.delete_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	// Detach children in the same statement; parent_session_id has no ON DELETE SET NULL
+	const query = `
+		WITH detached AS (
+			UPDATE loom_sessions SET parent_session_id = NULL WHERE parent_session_id = $1
+		)
+		DELETE FROM loom_sessions WHERE id = $1`
+	if _, err := s.db.ExecContext(ctx, query, sessionID); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// ListSessions returns sessions matching the filter, newest first, without messages.
+// See SessionStore.ListSessions for paging.
+func (s *PostgresSessionStore) ListSessions(ctx context.Context, filter SessionFilter) ([]*Session, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.list_sessions")
+	defer s.tracer.EndSpan(span)
+
+	query := `
+		SELECT id, agent_id, parent_session_id, context, created_at, updated_at, total_cost_usd, total_tokens
+		FROM loom_sessions
+		WHERE TRUE`
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if filter.AgentID != "" {
+		query += " AND agent_id = " + arg(filter.AgentID)
+	}
+	if filter.ParentSessionID != "" {
+		query += " AND parent_session_id = " + arg(filter.ParentSessionID)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query += " AND date_trunc('second', created_at) >= " + arg(filter.CreatedAfter.Truncate(time.Second))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query += " AND date_trunc('second', created_at) < " + arg(filter.CreatedBefore.Truncate(time.Second))
+	}
+	if filter.Cursor != "" {
+		createdAt, id, err := parseSessionCursor(filter.Cursor)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		at := arg(createdAt)
+		query += " AND (created_at < " + at + " OR (created_at = " + at + " AND id < " + arg(id) + "))"
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultSessionListLimit
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT " + arg(min(limit, MaxSessionListLimit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*Session, 0)
+	for rows.Next() {
+		session, err := scanPostgresSession(rows)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	span.SetAttribute("session_count", fmt.Sprintf("%d", len(sessions)))
+	return sessions, nil
+}
+
+// SaveMessage appends a message to a session.
+func (s *PostgresSessionStore) SaveMessage(ctx context.Context, sessionID string, msg Message) error {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.save_message")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+	span.SetAttribute("role", msg.Role)
+
+	if err := insertPostgresMessage(ctx, s.db, sessionID, msg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// insertPostgresMessage inserts a message row with db (a *sql.DB or *sql.Tx).
+func insertPostgresMessage(ctx context.Context, db sqlExecer, sessionID string, msg Message) error {
+	data, err := json.Marshal(newExportedMessage(msg))
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO loom_session_messages (session_id, message, timestamp) VALUES ($1, $2, $3)",
+		sessionID, string(data), msg.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to save message: %w", err)
+	}
+	return nil
+}
+
+// LoadMessages loads a session's messages in timestamp order, then save order.
+func (s *PostgresSessionStore) LoadMessages(ctx context.Context, sessionID string) ([]Message, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.load_messages")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, message FROM loom_session_messages WHERE session_id = $1 ORDER BY timestamp, id", sessionID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []Message
+	for rows.Next() {
+		var id int64
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+		var m exportedMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+		}
+		msg := m.message()
+		msg.ID = strconv.FormatInt(id, 10)
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error iterating messages: %w", err)
+	}
+
+	span.SetAttribute("message_count", fmt.Sprintf("%d", len(messages)))
+	return messages, nil
+}
+
+// ExportSession returns a session as JSON for ImportSession.
+func (s *PostgresSessionStore) ExportSession(ctx context.Context, sessionID string) ([]byte, error) {
+	return exportSession(ctx, s, sessionID)
+}
+
+// ImportSession stores an exported session under a new ID; see SessionStore.ImportSession.
+func (s *PostgresSessionStore) ImportSession(ctx context.Context, data []byte) (string, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "postgres_session_store.import_session")
+	defer s.tracer.EndSpan(span)
+
+	export, err := parseSessionExport(data)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	newID := newImportedSessionID()
+	span.SetAttribute("original_session_id", export.ID)
+	span.SetAttribute("session_id", newID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	parentSessionID := ""
+	if export.ParentSessionID != "" {
+		err := tx.QueryRowContext(ctx,
+			"SELECT session_id FROM loom_session_imports WHERE original_id = $1 ORDER BY imported_at DESC LIMIT 1",
+			export.ParentSessionID).Scan(&parentSessionID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			return "", fmt.Errorf("failed to look up imported parent session: %w", err)
+		}
+	}
+
+	if err := upsertPostgresSession(ctx, tx, export.session(newID, parentSessionID)); err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	for _, m := range export.Messages {
+		if err := insertPostgresMessage(ctx, tx, newID, m.message()); err != nil {
+			span.RecordError(err)
+			return "", err
+		}
+	}
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO loom_session_imports (session_id, original_id, imported_at) VALUES ($1, $2, clock_timestamp())",
+		newID, export.ID); err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to record session import: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to commit import: %w", err)
+	}
+	return newID, nil
+}
+
+// Ping verifies the database connection is alive (used by health checks).
+func (s *PostgresSessionStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// Close closes the database connection.
+func (s *PostgresSessionStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,441 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// DefaultRedisKeyPrefix prefixes every key written by RedisSessionStore.
+const DefaultRedisKeyPrefix = "loom:"
+
+// redisListBatch is how many index entries ListSessions reads per round trip.
+const redisListBatch = 200
+
+// RedisSessionStoreConfig configures a RedisSessionStore.
+type RedisSessionStoreConfig struct {
+	// Redis URL, e.g. redis://:password@localhost:6379/0
+	URL string
+
+	// Prefix for all keys (default: "loom:")
+	KeyPrefix string
+
+	// Sessions expire this long after their last save or message (default: 0, never)
+	TTL time.Duration
+}
+
+// RedisSessionStore is a SessionBackend shared by every server instance using the same
+// Redis database. Each session is a JSON value with its messages in a list, and a sorted
+// set indexes sessions by creation time for ListSessions. Expiry uses Redis key TTLs
+// (see SetSessionTTL), so no sweeper runs and no hooks fire on expiry.
+type RedisSessionStore struct {
+	client *redis.Client
+	prefix string
+	tracer observability.Tracer
+
+	mu  sync.RWMutex
+	ttl time.Duration
+}
+
+// NewRedisSessionStore connects to Redis and checks the connection.
+func NewRedisSessionStore(cfg RedisSessionStoreConfig, tracer observability.Tracer) (*RedisSessionStore, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("redis URL is required")
+	}
+	opts, err := redis.ParseURL(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if tracer == nil {
+		tracer = observability.NewNoOpTracer()
+	}
+	prefix := cfg.KeyPrefix
+	if prefix == "" {
+		prefix = DefaultRedisKeyPrefix
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return &RedisSessionStore{client: client, prefix: prefix, tracer: tracer, ttl: cfg.TTL}, nil
+}
+
+// SetSessionTTL sets how long sessions live after their last save or message.
+// It applies from each session's next write; 0 disables expiry for later writes.
+func (s *RedisSessionStore) SetSessionTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ttl = ttl
+}
+
+func (s *RedisSessionStore) sessionKey(id string) string { return s.prefix + "session:" + id }
+func (s *RedisSessionStore) messagesKey(id string) string {
+	return s.prefix + "session:" + id + ":messages"
+}
+func (s *RedisSessionStore) importKey(id string) string { return s.prefix + "import:" + id }
+func (s *RedisSessionStore) indexKey() string           { return s.prefix + "sessions" }
+
+func (s *RedisSessionStore) sessionTTL() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ttl
+}
+
+// SaveSession creates or replaces a session's metadata. Messages are stored separately
+// with SaveMessage and are not written from session.Messages.
+func (s *RedisSessionStore) SaveSession(ctx context.Context, session *Session) error {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.save_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", session.ID)
+
+	metadata := *newSessionExport(&Session{
+		ID:              session.ID,
+		AgentID:         session.AgentID,
+		ParentSessionID: session.ParentSessionID,
+		Context:         session.Context,
+		CreatedAt:       session.CreatedAt,
+		UpdatedAt:       session.UpdatedAt,
+		TotalCostUSD:    session.TotalCostUSD,
+		TotalTokens:     session.TotalTokens,
+	})
+	if err := s.writeSession(ctx, &metadata, nil); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// writeSession stores session metadata, appends messages, and updates the index in one
+// MULTI/EXEC transaction.
+func (s *RedisSessionStore) writeSession(ctx context.Context, metadata *sessionExport, messages []exportedMessage, extra ...func(redis.Pipeliner)) error {
+	metadata.Messages = nil
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	encoded := make([]interface{}, len(messages))
+	for i, msg := range messages {
+		if encoded[i], err = json.Marshal(msg); err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+	}
+
+	ttl := s.sessionTTL()
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.sessionKey(metadata.ID), data, ttl)
+		if len(encoded) > 0 {
+			pipe.RPush(ctx, s.messagesKey(metadata.ID), encoded...)
+		}
+		if ttl > 0 {
+			pipe.Expire(ctx, s.messagesKey(metadata.ID), ttl)
+		} else {
+			pipe.Persist(ctx, s.messagesKey(metadata.ID))
+		}
+		pipe.ZAdd(ctx, s.indexKey(), redis.Z{Score: float64(metadata.CreatedAt.UnixMicro()), Member: metadata.ID})
+		for _, fn := range extra {
+			fn(pipe)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession loads a session and its messages.
+func (s *RedisSessionStore) LoadSession(ctx context.Context, sessionID string) (*Session, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.load_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	data, err := s.client.Get(ctx, s.sessionKey(sessionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		span.SetAttribute("found", "false")
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	session, err := decodeRedisSession(data)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if session.Messages, err = s.LoadMessages(ctx, sessionID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	span.SetAttribute("found", "true")
+	return session, nil
+}
+
+// decodeRedisSession decodes session metadata written by writeSession.
+func decodeRedisSession(data []byte) (*Session, error) {
+	var metadata sessionExport
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	if metadata.Context == nil {
+		metadata.Context = make(map[string]interface{})
+	}
+	return metadata.session(metadata.ID, metadata.ParentSessionID), nil
+}
+
+// DeleteSession removes a session and its messages.
+func (s *RedisSessionStore) DeleteSession(ctx context.Context, sessionID string) error {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.delete_session")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, s.sessionKey(sessionID), s.messagesKey(sessionID))
+		pipe.ZRem(ctx, s.indexKey(), sessionID)
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// ListSessions returns sessions matching the filter, newest first, without messages.
+// See SessionStore.ListSessions for paging. Index entries of expired sessions are
+// removed as they are found.
+func (s *RedisSessionStore) ListSessions(ctx context.Context, filter SessionFilter) ([]*Session, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.list_sessions")
+	defer s.tracer.EndSpan(span)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultSessionListLimit
+	}
+	limit = min(limit, MaxSessionListLimit)
+
+	minScore, maxScore := "-inf", "+inf"
+	if !filter.CreatedAfter.IsZero() {
+		minScore = strconv.FormatInt(filter.CreatedAfter.Truncate(time.Second).UnixMicro(), 10)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		maxScore = "(" + strconv.FormatInt(filter.CreatedBefore.Truncate(time.Second).UnixMicro(), 10)
+	}
+	var cursorScore float64
+	var cursorID string
+	if filter.Cursor != "" {
+		createdAt, id, err := parseSessionCursor(filter.Cursor)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		cursorScore, cursorID = float64(createdAt.UnixMicro()), id
+		// Resume at the cursor's score unless CreatedBefore is already tighter
+		if filter.CreatedBefore.IsZero() || createdAt.Before(filter.CreatedBefore.Truncate(time.Second)) {
+			maxScore = strconv.FormatInt(createdAt.UnixMicro(), 10)
+		}
+	}
+
+	sessions := make([]*Session, 0)
+	for offset := int64(0); len(sessions) < limit; offset += redisListBatch {
+		entries, err := s.client.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{
+			Key:     s.indexKey(),
+			Start:   minScore, // go-redis swaps the bounds for Rev
+			Stop:    maxScore,
+			ByScore: true,
+			Rev:     true,
+			Offset:  offset,
+			Count:   redisListBatch,
+		}).Result()
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to query sessions: %w", err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			keys[i] = s.sessionKey(entry.Member.(string))
+		}
+		values, err := s.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to load sessions: %w", err)
+		}
+
+		for i, value := range values {
+			id := entries[i].Member.(string)
+			if cursorID != "" && entries[i].Score == cursorScore && id >= cursorID {
+				continue
+			}
+			data, ok := value.(string)
+			if !ok {
+				// Expired: drop the stale index entry
+				s.client.ZRem(ctx, s.indexKey(), id)
+				continue
+			}
+			session, err := decodeRedisSession([]byte(data))
+			if err != nil {
+				span.RecordError(err)
+				return nil, err
+			}
+			if (filter.AgentID != "" && session.AgentID != filter.AgentID) ||
+				(filter.ParentSessionID != "" && session.ParentSessionID != filter.ParentSessionID) {
+				continue
+			}
+			sessions = append(sessions, session)
+			if len(sessions) == limit {
+				break
+			}
+		}
+	}
+
+	span.SetAttribute("session_count", fmt.Sprintf("%d", len(sessions)))
+	return sessions, nil
+}
+
+// SaveMessage appends a message to a session and refreshes the session's TTL.
+func (s *RedisSessionStore) SaveMessage(ctx context.Context, sessionID string, msg Message) error {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.save_message")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+	span.SetAttribute("role", msg.Role)
+
+	data, err := json.Marshal(newExportedMessage(msg))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	exists, err := s.client.Exists(ctx, s.sessionKey(sessionID)).Result()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to save message: %w", err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("failed to save message: %w: %s", ErrSessionNotFound, sessionID)
+	}
+
+	ttl := s.sessionTTL()
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, s.messagesKey(sessionID), data)
+		if ttl > 0 {
+			pipe.Expire(ctx, s.sessionKey(sessionID), ttl)
+			pipe.Expire(ctx, s.messagesKey(sessionID), ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to save message: %w", err)
+	}
+	return nil
+}
+
+// LoadMessages loads a session's messages in the order they were saved.
+func (s *RedisSessionStore) LoadMessages(ctx context.Context, sessionID string) ([]Message, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.load_messages")
+	defer s.tracer.EndSpan(span)
+	span.SetAttribute("session_id", sessionID)
+
+	values, err := s.client.LRange(ctx, s.messagesKey(sessionID), 0, -1).Result()
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query messages: %w", err)
+	}
+
+	var messages []Message
+	for i, value := range values {
+		var m exportedMessage
+		if err := json.Unmarshal([]byte(value), &m); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+		}
+		msg := m.message()
+		msg.ID = strconv.Itoa(i + 1)
+		messages = append(messages, msg)
+	}
+	span.SetAttribute("message_count", fmt.Sprintf("%d", len(messages)))
+	return messages, nil
+}
+
+// ExportSession returns a session as JSON for ImportSession.
+func (s *RedisSessionStore) ExportSession(ctx context.Context, sessionID string) ([]byte, error) {
+	return exportSession(ctx, s, sessionID)
+}
+
+// ImportSession stores an exported session under a new ID; see SessionStore.ImportSession.
+func (s *RedisSessionStore) ImportSession(ctx context.Context, data []byte) (string, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "redis_session_store.import_session")
+	defer s.tracer.EndSpan(span)
+
+	export, err := parseSessionExport(data)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	parentSessionID := ""
+	if export.ParentSessionID != "" {
+		parent, err := s.client.Get(ctx, s.importKey(export.ParentSessionID)).Result()
+		switch {
+		case err == nil:
+			parentSessionID = parent
+		case !errors.Is(err, redis.Nil):
+			span.RecordError(err)
+			return "", fmt.Errorf("failed to look up imported parent session: %w", err)
+		}
+	}
+
+	newID := newImportedSessionID()
+	span.SetAttribute("original_session_id", export.ID)
+	span.SetAttribute("session_id", newID)
+
+	metadata := *export
+	metadata.ID = newID
+	metadata.ParentSessionID = parentSessionID
+	err = s.writeSession(ctx, &metadata, export.Messages, func(pipe redis.Pipeliner) {
+		pipe.Set(ctx, s.importKey(export.ID), newID, 0)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	return newID, nil
+}
+
+// Ping verifies the Redis connection is alive (used by health checks).
+func (s *RedisSessionStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Close closes the Redis connection.
+func (s *RedisSessionStore) Close() error {
+	return s.client.Close()
+}
@@ -15,7 +15,9 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -381,7 +383,7 @@ func TestSessionStore_ListSessions(t *testing.T) {
 		_ = store.SaveSession(ctx, session)
 	}
 
-	sessions, err := store.ListSessions(ctx)
+	sessions, err := store.ListSessions(ctx, SessionFilter{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -391,6 +393,85 @@ func TestSessionStore_ListSessions(t *testing.T) {
 	}
 }
 
+func TestSessionStore_ListSessions_Filter(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir()+"/test.db", observability.NewNoOpTracer())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	sessions := []*Session{
+		{ID: "coordinator", AgentID: "coordinator", CreatedAt: base},
+		{ID: "child-a", AgentID: "wf:analyst", ParentSessionID: "coordinator", CreatedAt: base.Add(time.Minute)},
+		{ID: "child-b", AgentID: "wf:writer", ParentSessionID: "coordinator", CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "child-c", AgentID: "wf:analyst", ParentSessionID: "coordinator", CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "other", AgentID: "wf:analyst", CreatedAt: base.Add(3 * time.Minute)},
+	}
+	for _, session := range sessions {
+		session.UpdatedAt = session.CreatedAt
+		session.Context = map[string]interface{}{"k": "v"}
+		if err := store.SaveSession(ctx, session); err != nil {
+			t.Fatalf("Failed to save session %s: %v", session.ID, err)
+		}
+	}
+
+	ids := func(filter SessionFilter) []string {
+		t.Helper()
+		list, err := store.ListSessions(ctx, filter)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		out := make([]string, len(list))
+		for i, session := range list {
+			out[i] = session.ID
+		}
+		return out
+	}
+	assertIDs := func(got []string, want ...string) {
+		t.Helper()
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	}
+
+	assertIDs(ids(SessionFilter{}), "other", "child-c", "child-b", "child-a", "coordinator")
+	assertIDs(ids(SessionFilter{AgentID: "wf:analyst"}), "other", "child-c", "child-a")
+	assertIDs(ids(SessionFilter{ParentSessionID: "coordinator"}), "child-c", "child-b", "child-a")
+	assertIDs(ids(SessionFilter{CreatedAfter: base.Add(time.Minute), CreatedBefore: base.Add(3 * time.Minute)}), "child-c", "child-b", "child-a")
+
+	list, err := store.ListSessions(ctx, SessionFilter{AgentID: "wf:writer"})
+	if err != nil || len(list) != 1 {
+		t.Fatalf("Expected one session, got %v, %v", list, err)
+	}
+	if got := list[0]; got.ParentSessionID != "coordinator" || got.Context["k"] != "v" || !got.CreatedAt.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("Expected session fields to be loaded, got %+v", got)
+	}
+
+	// Pages of two, including a page boundary between sessions created in the same second
+	var paged []string
+	filter := SessionFilter{Limit: 2}
+	for {
+		page, err := store.ListSessions(ctx, filter)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, session := range page {
+			paged = append(paged, session.ID)
+		}
+		filter.Cursor = SessionCursor(page[len(page)-1])
+	}
+	assertIDs(paged, "other", "child-c", "child-b", "child-a", "coordinator")
+
+	if _, err := store.ListSessions(ctx, SessionFilter{Cursor: "not a cursor"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
 func TestSessionStore_GetStats(t *testing.T) {
 	tmpfile := t.TempDir() + "/test.db"
 	defer os.Remove(tmpfile)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/teradata-labs/loom/pkg/communication"
 	"github.com/teradata-labs/loom/pkg/fabric"
+	"github.com/teradata-labs/loom/pkg/llm"
 	"github.com/teradata-labs/loom/pkg/observability"
 	"github.com/teradata-labs/loom/pkg/patterns"
 	"github.com/teradata-labs/loom/pkg/prompts"
@@ -126,6 +127,12 @@ type WorkflowCommunicationContext struct {
 
 	// Workflow name (for constructing agent IDs)
 	WorkflowName string
+
+	// ID of the agent that spawned this one (spawned sub-agents only)
+	ParentAgentID string
+
+	// Metadata passed by the parent at spawn time (e.g. {"role": "tank"})
+	SpawnMetadata map[string]string
 }
 
 // Config holds agent configuration.
@@ -154,6 +161,10 @@ type Config struct {
 	// Metadata for agent configuration (includes backend_path for ROM auto-detection)
 	Metadata map[string]string
 
+	// CanSpawn lists the agent IDs this agent may spawn as sub-agents. Entries may be
+	// glob patterns ("analyst-*"). When empty, the server's spawn policy decides.
+	CanSpawn []string
+
 	// EnableTracing enables observability tracing
 	EnableTracing bool
 
@@ -166,6 +177,10 @@ type Config struct {
 	// Retry configuration for LLM calls
 	Retry RetryConfig
 
+	// UsageBudget caps each session's LLM spend. It is checked against the session's
+	// TotalCostUSD and TotalTokens before every LLM call (zero fields are unlimited).
+	UsageBudget llm.UsageBudget
+
 	// MaxContextTokens is the model's context window size (0 = use defaults/auto-detect)
 	MaxContextTokens int
 
@@ -197,6 +212,17 @@ type PatternConfig struct {
 
 	// UseLLMClassifier enables LLM-based intent classification (default: false, uses keyword-based)
 	UseLLMClassifier bool
+
+	// RequireIntentMatch restricts pattern candidates to those matching the classified intent
+	// (default: false, intent only boosts keyword scores)
+	RequireIntentMatch bool
+
+	// IndexCache persists the PatternsDir index on disk so restarts skip re-parsing
+	// every pattern file (default: false)
+	IndexCache bool
+
+	// IndexCacheDir is where the index cache is written (default: the loom cache directory)
+	IndexCacheDir string
 }
 
 // RetryConfig configures exponential backoff retry logic for LLM calls
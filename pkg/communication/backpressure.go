@@ -0,0 +1,196 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+// BackpressurePolicy controls what Publish does when a subscription's buffer is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureDropNewest drops the message being published (the default)
+	BackpressureDropNewest BackpressurePolicy = iota
+
+	// BackpressureDropOldest discards the oldest buffered message to make room
+	BackpressureDropOldest
+
+	// BackpressureBlock waits for buffer space up to the subscription's block timeout
+	BackpressureBlock
+)
+
+// DefaultBlockTimeout is how long Publish waits on a BackpressureBlock subscription
+// without its own block timeout.
+const DefaultBlockTimeout = 5 * time.Second
+
+// dropOldestAttempts bounds how often a DropOldest delivery evicts to make room when
+// other publishers refill the buffer concurrently.
+const dropOldestAttempts = 3
+
+// ErrPublishTimeout is returned by Publish when a BackpressureBlock subscription did not
+// accept the message within its block timeout.
+var ErrPublishTimeout = errors.New("publish timed out waiting for subscriber")
+
+// String returns the policy name.
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressureDropNewest:
+		return "drop_newest"
+	case BackpressureDropOldest:
+		return "drop_oldest"
+	case BackpressureBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("BackpressurePolicy(%d)", int(p))
+	}
+}
+
+// SetBackpressurePolicy sets what Publish does when the subscription's buffer is full.
+// blockTimeout applies to BackpressureBlock; zero uses DefaultBlockTimeout.
+func (b *MessageBus) SetBackpressurePolicy(subscriptionID string, policy BackpressurePolicy, blockTimeout time.Duration) error {
+	if policy < BackpressureDropNewest || policy > BackpressureBlock {
+		return fmt.Errorf("unknown backpressure policy: %d", int(policy))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub, exists := b.subscriptions[subscriptionID]
+	if !exists {
+		return fmt.Errorf("subscription not found: %s", subscriptionID)
+	}
+	sub.Backpressure = policy
+	sub.BlockTimeout = max(blockTimeout, 0)
+	return nil
+}
+
+// Dropped returns the number of messages dropped for this subscription because its
+// buffer was full, including messages evicted by BackpressureDropOldest.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// deliver sends msg according to the subscription's backpressure policy. It reports whether
+// msg was buffered and how many messages were dropped, and returns an error when a
+// BackpressureBlock send timed out or ctx was cancelled. A closed subscription takes no
+// messages and counts no drops.
+// Callers need not hold the bus lock: a blocked send gives up when the subscription closes.
+func (s *Subscription) deliver(ctx context.Context, msg *loomv1.BusMessage) (bool, int, error) {
+	s.sendMu.RLock()
+	defer s.sendMu.RUnlock()
+	if s.closed {
+		return false, 0, nil
+	}
+
+	if s.trySendLocked(msg) {
+		return true, 0, nil
+	}
+
+	switch s.Backpressure {
+	case BackpressureDropOldest:
+		evicted := 0
+		for i := 0; i < dropOldestAttempts; i++ {
+			select {
+			case <-s.channel:
+				evicted++
+			default:
+			}
+			if s.trySendLocked(msg) {
+				s.dropped.Add(int64(evicted))
+				return true, evicted, nil
+			}
+		}
+		s.dropped.Add(int64(evicted + 1))
+		return false, evicted + 1, nil
+
+	case BackpressureBlock:
+		timeout := s.BlockTimeout
+		if timeout <= 0 {
+			timeout = DefaultBlockTimeout
+		}
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case s.channel <- msg:
+			s.notify()
+			return true, 0, nil
+		case <-s.done:
+			return false, 0, nil
+		case <-timer.C:
+			s.dropped.Add(1)
+			return false, 1, fmt.Errorf("%w: subscription %s did not accept the message within %s", ErrPublishTimeout, s.ID, timeout)
+		case <-ctx.Done():
+			s.dropped.Add(1)
+			return false, 1, ctx.Err()
+		}
+
+	default:
+		s.dropped.Add(1)
+		return false, 1, nil
+	}
+}
+
+// trySend buffers msg without blocking and reports whether there was room.
+// A closed subscription never has room.
+func (s *Subscription) trySend(msg *loomv1.BusMessage) bool {
+	s.sendMu.RLock()
+	defer s.sendMu.RUnlock()
+	if s.closed {
+		return false
+	}
+	return s.trySendLocked(msg)
+}
+
+// trySendLocked is trySend for callers holding sendMu.
+func (s *Subscription) trySendLocked(msg *loomv1.BusMessage) bool {
+	select {
+	case s.channel <- msg:
+		s.notify()
+		return true
+	default:
+		return false
+	}
+}
+
+// close closes the subscription's channel once no send is in flight. Blocked
+// BackpressureBlock sends are woken first so close does not wait out their timeout.
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.sendMu.Lock()
+		defer s.sendMu.Unlock()
+		s.closed = true
+		close(s.channel)
+	})
+}
+
+// notify wakes an event-driven consumer, if one registered a notification channel.
+func (s *Subscription) notify() {
+	if s.notifyChannel == nil {
+		return
+	}
+	select {
+	case s.notifyChannel <- struct{}{}:
+		// Notification sent
+	default:
+		// Notification channel full, agent already has pending notification
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+func TestBusBackpressure(t *testing.T) {
+	ctx := context.Background()
+	publish := func(bus *MessageBus, id string) (int, int, error) {
+		return bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: id})
+	}
+
+	t.Run("drop newest", func(t *testing.T) {
+		bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+		defer bus.Close()
+		sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 2)
+		require.NoError(t, err)
+
+		for _, id := range []string{"m1", "m2", "m3"} {
+			_, _, err := publish(bus, id)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, "m1", (<-sub.Channel).Id)
+		assert.Equal(t, "m2", (<-sub.Channel).Id)
+		assert.Equal(t, int64(1), sub.Dropped())
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+		defer bus.Close()
+		sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 2)
+		require.NoError(t, err)
+		require.NoError(t, bus.SetBackpressurePolicy(sub.ID, BackpressureDropOldest, 0))
+
+		for _, id := range []string{"m1", "m2"} {
+			_, _, err := publish(bus, id)
+			require.NoError(t, err)
+		}
+		delivered, dropped, err := publish(bus, "m3")
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, 1, dropped)
+
+		assert.Equal(t, "m2", (<-sub.Channel).Id)
+		assert.Equal(t, "m3", (<-sub.Channel).Id)
+
+		// The counter is visible to operators through the agent's subscriptions
+		subs := bus.GetSubscriptionsByAgent("agent1")
+		require.Len(t, subs, 1)
+		assert.Equal(t, int64(1), subs[0].Dropped())
+	})
+
+	t.Run("block", func(t *testing.T) {
+		bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+		defer bus.Close()
+		slow, err := bus.Subscribe(ctx, "slow", "party.chat", nil, 1)
+		require.NoError(t, err)
+		require.NoError(t, bus.SetBackpressurePolicy(slow.ID, BackpressureBlock, 20*time.Millisecond))
+		fast, err := bus.Subscribe(ctx, "fast", "party.chat", nil, 10)
+		require.NoError(t, err)
+
+		_, _, err = publish(bus, "m1")
+		require.NoError(t, err)
+
+		// Full buffer: Publish waits, then reports a timeout while still delivering to others
+		start := time.Now()
+		delivered, dropped, err := publish(bus, "m2")
+		assert.ErrorIs(t, err, ErrPublishTimeout)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, 1, dropped)
+		assert.Len(t, fast.Channel, 2)
+		assert.Equal(t, int64(1), slow.Dropped())
+
+		// A consumer freeing space unblocks the publisher
+		require.NoError(t, bus.SetBackpressurePolicy(slow.ID, BackpressureBlock, time.Second))
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			<-slow.Channel
+		}()
+		_, _, err = publish(bus, "m3")
+		require.NoError(t, err)
+		assert.Equal(t, "m3", (<-slow.Channel).Id)
+	})
+
+	t.Run("blocked subscriber does not stall other topics", func(t *testing.T) {
+		bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+		defer bus.Close()
+		slow, err := bus.Subscribe(ctx, "slow", "party.chat", nil, 1)
+		require.NoError(t, err)
+		require.NoError(t, bus.SetBackpressurePolicy(slow.ID, BackpressureBlock, 5*time.Second))
+		other, err := bus.Subscribe(ctx, "other", "party.news", nil, 1)
+		require.NoError(t, err)
+
+		_, _, err = publish(bus, "m1")
+		require.NoError(t, err)
+
+		blocked := make(chan error, 1)
+		go func() {
+			_, _, err := publish(bus, "m2")
+			blocked <- err
+		}()
+		time.Sleep(20 * time.Millisecond) // let the publish reach the blocking send
+
+		// While that publish waits on the slow subscriber, other topics, subscribes and
+		// unsubscribes go through
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _, err := bus.Publish(ctx, "party.news", &loomv1.BusMessage{Id: "n1"})
+			assert.NoError(t, err)
+			extra, err := bus.Subscribe(ctx, "late", "party.news", nil, 1)
+			if assert.NoError(t, err) {
+				assert.NoError(t, bus.Unsubscribe(ctx, extra.ID))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("publish to another topic stalled behind a blocked subscriber")
+		}
+		assert.Equal(t, "n1", (<-other.Channel).Id)
+
+		// Unsubscribing the slow subscriber releases the blocked publish
+		require.NoError(t, bus.Unsubscribe(ctx, slow.ID))
+		select {
+		case err := <-blocked:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("blocked publish not released by unsubscribe")
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+		defer bus.Close()
+		sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 2)
+		require.NoError(t, err)
+
+		assert.Error(t, bus.SetBackpressurePolicy(sub.ID, BackpressurePolicy(99), 0))
+		assert.Error(t, bus.SetBackpressurePolicy("missing", BackpressureBlock, 0))
+		assert.Equal(t, "drop_oldest", BackpressureDropOldest.String())
+	})
+}
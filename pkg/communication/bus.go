@@ -15,8 +15,10 @@ package communication
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +27,7 @@ import (
 
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
 	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/session"
 )
 
 // Hawk span constants for bus operations
@@ -36,6 +39,10 @@ const (
 	SpanBusUnsubscribe = "bus.unsubscribe"
 )
 
+// ErrReservedTopic is returned by Publish for a message to a reserved topic from
+// anyone but its publisher (see ReserveTopic).
+var ErrReservedTopic = errors.New("topic is reserved")
+
 // Default configuration values
 const (
 	// DefaultMessageBufferSize is the default buffer size for message channels
@@ -53,16 +60,33 @@ type MessageBus struct {
 	// Subscriber ID → subscription (for unsubscribe)
 	subscriptions map[string]*Subscription
 
+	// Publish index: exact topic → subscriber ID → subscription, plus every
+	// wildcard subscription (matched against each published topic)
+	exactSubscriptions    map[string]map[string]*Subscription
+	wildcardSubscriptions map[string]*Subscription
+
+	// Reserved topic → the only FromAgent allowed to publish to it (see ReserveTopic)
+	reservedTopics map[string]string
+
 	// Dependencies
 	refStore ReferenceStore
 	policy   *PolicyManager
 	tracer   observability.Tracer
 	logger   *zap.Logger
 
+	// Reliable delivery: subscription ID → message ID → delivery awaiting Ack
+	reliableMu     sync.Mutex
+	reliableCfg    ReliableDeliveryConfig
+	pending        map[string]map[string]*pendingDelivery
+	redeliveryOnce sync.Once
+	redeliveryDone chan struct{}
+
 	// Metrics (atomic counters)
-	totalPublished atomic.Int64
-	totalDelivered atomic.Int64
-	totalDropped   atomic.Int64
+	totalPublished    atomic.Int64
+	totalDelivered    atomic.Int64
+	totalDropped      atomic.Int64
+	totalRedelivered  atomic.Int64
+	totalDeadLettered atomic.Int64
 
 	// Lifecycle
 	closed atomic.Bool
@@ -83,6 +107,11 @@ type TopicBroadcaster struct {
 	totalDropped   atomic.Int64
 	createdAt      time.Time
 	lastPublishAt  atomic.Value // time.Time
+
+	// Replay buffer for late subscribers (see ConfigureTopic)
+	retainN   int
+	retainFor time.Duration
+	retained  []retainedMessage
 }
 
 // Subscriber represents an agent subscribed to a topic.
@@ -105,6 +134,27 @@ type Subscription struct {
 	channel       chan *loomv1.BusMessage    // Internal writable reference
 	notifyChannel chan struct{}              // For event-driven notifications (internal)
 	Created       time.Time
+
+	// Time to Ack a PublishReliable message before redelivery (0: bus default; see SetAckTimeout)
+	AckTimeout time.Duration
+
+	// What Publish does when the buffer is full, and how long BackpressureBlock waits
+	// (see SetBackpressurePolicy)
+	Backpressure BackpressurePolicy
+	BlockTimeout time.Duration
+
+	// Messages dropped for this subscription (see Dropped)
+	dropped atomic.Int64
+
+	// Publish delivers after releasing the bus lock, so sends and close are ordered by
+	// sendMu instead; done wakes a blocked send when the subscription closes
+	sendMu    sync.RWMutex
+	closed    bool
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// Pattern tokens for wildcard subscriptions (nil for exact topics)
+	pattern []string
 }
 
 // NewMessageBus creates a new message bus.
@@ -116,19 +166,33 @@ func NewMessageBus(refStore ReferenceStore, policy *PolicyManager, tracer observ
 		logger = zap.NewNop()
 	}
 
-	return &MessageBus{
-		topics:        make(map[string]*TopicBroadcaster),
-		subscriptions: make(map[string]*Subscription),
-		refStore:      refStore,
-		policy:        policy,
-		tracer:        tracer,
-		logger:        logger,
-	}
+	b := &MessageBus{
+		topics:                make(map[string]*TopicBroadcaster),
+		subscriptions:         make(map[string]*Subscription),
+		exactSubscriptions:    make(map[string]map[string]*Subscription),
+		wildcardSubscriptions: make(map[string]*Subscription),
+		reservedTopics:        make(map[string]string),
+		refStore:              refStore,
+		policy:                policy,
+		tracer:                tracer,
+		logger:                logger,
+		pending:               make(map[string]map[string]*pendingDelivery),
+		redeliveryDone:        make(chan struct{}),
+	}
+	b.SetReliableDelivery(ReliableDeliveryConfig{})
+	return b
 }
 
-// Publish sends a message to all subscribers of a topic.
+// Publish sends a message to all subscribers of a topic, including wildcard
+// subscriptions whose pattern matches it (see Subscribe for precedence).
 // Returns (delivered, dropped, error).
-// Does NOT block on slow subscribers - messages are dropped if subscriber buffers are full.
+// What happens when a subscriber's buffer is full depends on its BackpressurePolicy: by
+// default the message is dropped for that subscriber. Subscribers with BackpressureBlock
+// make Publish wait up to their block timeout, after which it still delivers to the
+// remaining subscribers and returns an error wrapping ErrPublishTimeout.
+// The trace context of ctx (or, when ctx has none, the one already in the message
+// metadata) is written into msg.Metadata so subscribers continue the publisher's trace
+// (see observability.ExtractTraceContext).
 func (b *MessageBus) Publish(ctx context.Context, topic string, msg *loomv1.BusMessage) (int, int, error) {
 	if b.closed.Load() {
 		return 0, 0, fmt.Errorf("message bus is closed")
@@ -140,66 +204,69 @@ func (b *MessageBus) Publish(ctx context.Context, topic string, msg *loomv1.BusM
 	if msg == nil {
 		return 0, 0, fmt.Errorf("message cannot be nil")
 	}
+	if isWildcardPattern(topic) {
+		return 0, 0, fmt.Errorf("cannot publish to wildcard topic: %s", topic)
+	}
+	if err := b.checkReservedTopic(topic, msg.FromAgent); err != nil {
+		return 0, 0, err
+	}
+
+	// A republished message keeps the trace it started in
+	if observability.SpanFromContext(ctx) == nil {
+		ctx = observability.ExtractTraceContext(ctx, msg.Metadata)
+	}
 
 	// Instrument with Hawk
 	var span *observability.Span
+	spanCtx := ctx
 	if b.tracer != nil {
-		_, span = b.tracer.StartSpan(ctx, SpanBusPublish)
+		spanCtx, span = b.tracer.StartSpan(ctx, SpanBusPublish)
 		defer b.tracer.EndSpan(span)
 		span.SetAttribute("topic", topic)
 		span.SetAttribute("from_agent", msg.FromAgent)
 		span.SetAttribute("message_id", msg.Id)
+		span.SetAttribute(observability.AttrAgentID, msg.FromAgent)
+		if sessionID := session.SessionIDFromContext(ctx); sessionID != "" {
+			span.SetAttribute(observability.AttrSessionID, sessionID)
+		}
 	}
 
+	// Carry the trace to subscribers; done before delivery so they never see the map change
+	msg.Metadata = observability.InjectTraceContext(spanCtx, msg.Metadata)
+
 	start := time.Now()
 
 	// Broadcast to pattern-matched and filtered subscribers
 	delivered := 0
 	dropped := 0
 
+	var publishErr error
+
+	// Snapshot the recipients and deliver without the bus lock, so a subscriber blocking
+	// under BackpressureBlock does not stall publishes, subscribes and unsubscribes
 	b.mu.RLock()
-	for _, subscription := range b.subscriptions {
-		// Check if topic matches subscription pattern
-		if !matchesTopicPattern(subscription.Topic, topic) {
-			continue
-		}
+	b.retain(topic, msg)
+	recipients := b.matchingSubscriptions(topic)
+	b.mu.RUnlock()
 
+	for _, subscription := range recipients {
 		// Check if message matches subscription filter
 		if !matchesFilter(subscription.Filter, msg) {
 			continue
 		}
 
-		// Try to deliver message (non-blocking)
-		select {
-		case subscription.channel <- msg:
+		// Deliver according to the subscription's backpressure policy
+		ok, lost, err := subscription.deliver(ctx, msg)
+		if ok {
 			delivered++
-			// Send event-driven notification if registered
-			if subscription.notifyChannel != nil {
-				select {
-				case subscription.notifyChannel <- struct{}{}:
-					// Notification sent
-				default:
-					// Notification channel full, agent already has pending notification
-				}
-			}
-		default:
-			// Channel full - drop message to avoid blocking publisher
-			dropped++
+		}
+		dropped += lost
+		if err != nil && publishErr == nil {
+			publishErr = err
 		}
 	}
-	b.mu.RUnlock()
-
-	// Update MessageBus metrics
-	b.totalPublished.Add(1)
-	b.totalDelivered.Add(int64(delivered))
-	b.totalDropped.Add(int64(dropped))
 
-	// Update TopicBroadcaster metrics
-	broadcaster := b.getOrCreateTopic(topic)
-	broadcaster.totalPublished.Add(1)
-	broadcaster.totalDelivered.Add(int64(delivered))
-	broadcaster.totalDropped.Add(int64(dropped))
-	broadcaster.lastPublishAt.Store(time.Now())
+	b.recordPublish(topic, delivered, dropped)
 
 	latency := time.Since(start)
 
@@ -218,11 +285,76 @@ func (b *MessageBus) Publish(ctx context.Context, topic string, msg *loomv1.BusM
 		zap.Int("dropped", dropped),
 		zap.Duration("latency", latency))
 
-	return delivered, dropped, nil
+	return delivered, dropped, publishErr
+}
+
+// ReserveTopic restricts publishing to topic to messages from publisher. Publish rejects
+// messages from any other FromAgent with an error wrapping ErrReservedTopic, so agents
+// cannot forge events on topics the server owns.
+func (b *MessageBus) ReserveTopic(topic, publisher string) error {
+	if topic == "" {
+		return fmt.Errorf("topic cannot be empty")
+	}
+	if isWildcardPattern(topic) {
+		return fmt.Errorf("cannot reserve wildcard topic: %s", topic)
+	}
+	if publisher == "" {
+		return fmt.Errorf("publisher cannot be empty")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reservedTopics[topic] = publisher
+	return nil
+}
+
+// IsReservedTopic reports whether topic was reserved with ReserveTopic.
+func (b *MessageBus) IsReservedTopic(topic string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.reservedTopics[topic]
+	return ok
+}
+
+// checkReservedTopic returns an error if topic is reserved for a publisher other than fromAgent.
+func (b *MessageBus) checkReservedTopic(topic, fromAgent string) error {
+	b.mu.RLock()
+	publisher, ok := b.reservedTopics[topic]
+	b.mu.RUnlock()
+	if ok && fromAgent != publisher {
+		return fmt.Errorf("%w: %s is reserved for %s", ErrReservedTopic, topic, publisher)
+	}
+	return nil
+}
+
+// recordPublish updates bus and topic metrics after a publish.
+func (b *MessageBus) recordPublish(topic string, delivered, dropped int) {
+	// Update MessageBus metrics
+	b.totalPublished.Add(1)
+	b.totalDelivered.Add(int64(delivered))
+	b.totalDropped.Add(int64(dropped))
+
+	// Update TopicBroadcaster metrics
+	broadcaster := b.getOrCreateTopic(topic)
+	broadcaster.totalPublished.Add(1)
+	broadcaster.totalDelivered.Add(int64(delivered))
+	broadcaster.totalDropped.Add(int64(dropped))
+	broadcaster.lastPublishAt.Store(time.Now())
 }
 
 // Subscribe creates a new subscription to a topic pattern.
-// Topic patterns support wildcards: "workflow.*" matches "workflow.started", "workflow.completed"
+// Messages retained by matching topics (see ConfigureTopic) are replayed to the new
+// subscription, oldest first, before any newly published message.
+// Topics are dot-separated. A "*" token matches exactly one token ("party.*" matches
+// "party.chat" but not "party.chat.dm"), and a trailing ">" token matches one or more
+// tokens ("workflow.dungeon-crawl.>" matches "workflow.dungeon-crawl.room.1"). Other
+// tokens may use path.Match globs within the token ("party-*").
+//
+// An agent receives each published message at most once per topic pattern kind:
+// its exact-topic subscriptions take precedence over its wildcard subscriptions,
+// and of several matching wildcard subscriptions only the most specific one (most
+// literal tokens, then "*" over ">", then the oldest) receives it. Subscription
+// filters are applied after this choice.
 // Returns a Subscription that contains a channel for receiving messages.
 func (b *MessageBus) Subscribe(ctx context.Context, agentID string, topicPattern string, filter *loomv1.SubscriptionFilter, bufferSize int) (*Subscription, error) {
 	if b.closed.Load() {
@@ -235,6 +367,13 @@ func (b *MessageBus) Subscribe(ctx context.Context, agentID string, topicPattern
 	if topicPattern == "" {
 		return nil, fmt.Errorf("topic pattern cannot be empty")
 	}
+	var pattern []string
+	if isWildcardPattern(topicPattern) {
+		var err error
+		if pattern, err = parseTopicPattern(topicPattern); err != nil {
+			return nil, err
+		}
+	}
 
 	if bufferSize <= 0 {
 		bufferSize = DefaultMessageBufferSize
@@ -275,18 +414,32 @@ func (b *MessageBus) Subscribe(ctx context.Context, agentID string, topicPattern
 		Channel: channel, // Read-only view for external consumers
 		channel: channel, // Writable reference for internal publish
 		Created: subscriber.created,
+		pattern: pattern,
+		done:    make(chan struct{}),
 	}
 
 	// Store subscription for later unsubscribe
 	b.mu.Lock()
 	b.subscriptions[subID] = subscription
+	if pattern != nil {
+		b.wildcardSubscriptions[subID] = subscription
+	} else {
+		if b.exactSubscriptions[topicPattern] == nil {
+			b.exactSubscriptions[topicPattern] = make(map[string]*Subscription)
+		}
+		b.exactSubscriptions[topicPattern][subID] = subscription
+	}
+	// Replay retained messages under the same lock so no live message is missed,
+	// duplicated, or delivered ahead of them
+	replayed := b.replayRetained(subscription)
 	b.mu.Unlock()
 
 	b.logger.Info("bus subscribe",
 		zap.String("subscription_id", subID),
 		zap.String("agent_id", agentID),
 		zap.String("topic_pattern", topicPattern),
-		zap.Int("buffer_size", bufferSize))
+		zap.Int("buffer_size", bufferSize),
+		zap.Int("replayed", replayed))
 
 	return subscription, nil
 }
@@ -312,14 +465,10 @@ func (b *MessageBus) Unsubscribe(ctx context.Context, subscriptionID string) err
 		b.mu.Unlock()
 		return fmt.Errorf("subscription not found: %s", subscriptionID)
 	}
-	delete(b.subscriptions, subscriptionID)
+	b.removeSubscriptionLocked(subscription)
 	b.mu.Unlock()
 
-	// Remove from topic broadcaster
-	broadcaster := b.getTopic(subscription.Topic)
-	if broadcaster != nil {
-		broadcaster.removeSubscriber(subscriptionID)
-	}
+	b.closeSubscription(subscription)
 
 	b.logger.Info("bus unsubscribe",
 		zap.String("subscription_id", subscriptionID),
@@ -329,6 +478,78 @@ func (b *MessageBus) Unsubscribe(ctx context.Context, subscriptionID string) err
 	return nil
 }
 
+// UnsubscribeAll removes every subscription held by an agent and closes their channels.
+// The subscriptions are removed under a single lock, so no publish delivers to some of
+// them but not others and no subscription is missed by a concurrent Subscribe.
+// Returns how many subscriptions were removed.
+func (b *MessageBus) UnsubscribeAll(ctx context.Context, agentID string) (int, error) {
+	if agentID == "" {
+		return 0, fmt.Errorf("agent ID cannot be empty")
+	}
+
+	// Instrument with Hawk
+	var span *observability.Span
+	if b.tracer != nil {
+		_, span = b.tracer.StartSpan(ctx, SpanBusUnsubscribe)
+		defer b.tracer.EndSpan(span)
+		span.SetAttribute("agent_id", agentID)
+	}
+
+	b.mu.Lock()
+	var removed []*Subscription
+	for _, subscription := range b.subscriptions {
+		if subscription.AgentID == agentID {
+			removed = append(removed, subscription)
+		}
+	}
+	for _, subscription := range removed {
+		b.removeSubscriptionLocked(subscription)
+	}
+	b.mu.Unlock()
+
+	for _, subscription := range removed {
+		b.closeSubscription(subscription)
+	}
+
+	if span != nil {
+		span.SetAttribute("removed", len(removed))
+	}
+
+	b.logger.Info("bus unsubscribe all",
+		zap.String("agent_id", agentID),
+		zap.Int("removed", len(removed)))
+
+	return len(removed), nil
+}
+
+// removeSubscriptionLocked removes a subscription from the bus indexes so publishes
+// no longer reach it. Caller must hold b.mu for writing.
+func (b *MessageBus) removeSubscriptionLocked(subscription *Subscription) {
+	delete(b.subscriptions, subscription.ID)
+	delete(b.wildcardSubscriptions, subscription.ID)
+	if exact := b.exactSubscriptions[subscription.Topic]; exact != nil {
+		delete(exact, subscription.ID)
+		if len(exact) == 0 {
+			delete(b.exactSubscriptions, subscription.Topic)
+		}
+	}
+}
+
+// closeSubscription discards a removed subscription's unacked messages and closes its channel.
+func (b *MessageBus) closeSubscription(subscription *Subscription) {
+	// Unacked reliable messages have nowhere to go once the subscription is gone
+	b.reliableMu.Lock()
+	delete(b.pending, subscription.ID)
+	b.reliableMu.Unlock()
+
+	// Remove from topic broadcaster
+	broadcaster := b.getTopic(subscription.Topic)
+	if broadcaster != nil {
+		broadcaster.removeSubscriber(subscription.ID)
+	}
+	subscription.close()
+}
+
 // ListTopics returns all active topics.
 func (b *MessageBus) ListTopics(ctx context.Context) ([]string, error) {
 	b.mu.RLock()
@@ -352,11 +573,17 @@ func (b *MessageBus) GetTopicStats(ctx context.Context, topic string) (*loomv1.T
 	return broadcaster.stats(), nil
 }
 
+// IsClosed reports whether Close has been called.
+func (b *MessageBus) IsClosed() bool {
+	return b.closed.Load()
+}
+
 // Close shuts down the message bus and closes all subscriber channels.
 func (b *MessageBus) Close() error {
 	if !b.closed.CompareAndSwap(false, true) {
 		return nil // Already closed
 	}
+	close(b.redeliveryDone)
 
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -365,11 +592,16 @@ func (b *MessageBus) Close() error {
 	for _, broadcaster := range b.topics {
 		broadcaster.closeAll()
 	}
+	for _, subscription := range b.subscriptions {
+		subscription.close()
+	}
 
 	b.logger.Info("message bus closed",
 		zap.Int64("total_published", b.totalPublished.Load()),
 		zap.Int64("total_delivered", b.totalDelivered.Load()),
-		zap.Int64("total_dropped", b.totalDropped.Load()))
+		zap.Int64("total_dropped", b.totalDropped.Load()),
+		zap.Int64("total_redelivered", b.totalRedelivered.Load()),
+		zap.Int64("total_dead_lettered", b.totalDeadLettered.Load()))
 
 	return nil
 }
@@ -408,25 +640,18 @@ func (tb *TopicBroadcaster) addSubscriber(sub *Subscriber) {
 	tb.subscribers[sub.id] = sub
 }
 
-// removeSubscriber removes a subscriber and closes their channel.
+// removeSubscriber removes a subscriber. Its channel is closed by the Subscription
+// that owns it.
 func (tb *TopicBroadcaster) removeSubscriber(subID string) {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
-
-	if sub, found := tb.subscribers[subID]; found {
-		close(sub.channel)
-		delete(tb.subscribers, subID)
-	}
+	delete(tb.subscribers, subID)
 }
 
-// closeAll closes all subscriber channels.
+// closeAll removes all subscribers.
 func (tb *TopicBroadcaster) closeAll() {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
-
-	for _, sub := range tb.subscribers {
-		close(sub.channel)
-	}
 	tb.subscribers = make(map[string]*Subscriber)
 }
 
@@ -455,22 +680,120 @@ func (tb *TopicBroadcaster) stats() *loomv1.TopicStats {
 
 // Subscriber methods
 
-// matchesTopicPattern checks if a topic matches a subscription pattern.
-// Supports wildcards: "workflow.*" matches "workflow.started", "workflow.completed"
-func matchesTopicPattern(pattern, topic string) bool {
-	// Exact match
-	if pattern == topic {
-		return true
+// matchingSubscriptions returns the subscriptions that receive a message published
+// to topic, applying the precedence rules documented on Subscribe.
+// Caller must hold b.mu.
+func (b *MessageBus) matchingSubscriptions(topic string) []*Subscription {
+	exact := b.exactSubscriptions[topic]
+	matched := make([]*Subscription, 0, len(exact))
+	for _, sub := range exact {
+		matched = append(matched, sub)
+	}
+	if len(b.wildcardSubscriptions) == 0 {
+		return matched
 	}
 
-	// Wildcard match using filepath.Match semantics
-	matched, err := path.Match(pattern, topic)
-	if err != nil {
-		return false
+	exactAgents := make(map[string]bool, len(exact))
+	for _, sub := range exact {
+		exactAgents[sub.AgentID] = true
+	}
+
+	var tokens []string
+	best := make(map[string]*Subscription)
+	for _, sub := range b.wildcardSubscriptions {
+		if exactAgents[sub.AgentID] {
+			continue
+		}
+		if tokens == nil {
+			tokens = strings.Split(topic, ".")
+		}
+		if !matchTopicTokens(sub.pattern, tokens) {
+			continue
+		}
+		if current, ok := best[sub.AgentID]; !ok || moreSpecific(sub, current) {
+			best[sub.AgentID] = sub
+		}
+	}
+	for _, sub := range best {
+		matched = append(matched, sub)
 	}
 	return matched
 }
 
+// isWildcardPattern reports whether a topic contains wildcard or glob syntax.
+func isWildcardPattern(topic string) bool {
+	return strings.ContainsAny(topic, "*?[>")
+}
+
+// parseTopicPattern splits a wildcard pattern into tokens and validates it.
+func parseTopicPattern(pattern string) ([]string, error) {
+	tokens := strings.Split(pattern, ".")
+	for i, tok := range tokens {
+		switch {
+		case tok == "":
+			return nil, fmt.Errorf("invalid topic pattern %q: empty token", pattern)
+		case tok == ">":
+			if i != len(tokens)-1 {
+				return nil, fmt.Errorf("invalid topic pattern %q: '>' must be the last token", pattern)
+			}
+		case strings.Contains(tok, ">"):
+			return nil, fmt.Errorf("invalid topic pattern %q: '>' must be a whole token", pattern)
+		default:
+			if _, err := path.Match(tok, ""); err != nil {
+				return nil, fmt.Errorf("invalid topic pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// matchTopicTokens matches topic tokens against pattern tokens.
+func matchTopicTokens(pattern, topic []string) bool {
+	for i, tok := range pattern {
+		if tok == ">" {
+			return len(topic) > i
+		}
+		if i >= len(topic) {
+			return false
+		}
+		if tok == "*" || tok == topic[i] {
+			continue
+		}
+		if matched, err := path.Match(tok, topic[i]); err != nil || !matched {
+			return false
+		}
+	}
+	return len(pattern) == len(topic)
+}
+
+// moreSpecific reports whether wildcard subscription a takes precedence over b.
+func moreSpecific(a, b *Subscription) bool {
+	if sa, sb := patternSpecificity(a.pattern), patternSpecificity(b.pattern); sa != sb {
+		return sa > sb
+	}
+	if !a.Created.Equal(b.Created) {
+		return a.Created.Before(b.Created)
+	}
+	return a.ID < b.ID
+}
+
+// patternSpecificity scores a pattern: literal tokens count most, then "*" tokens.
+func patternSpecificity(pattern []string) int {
+	score := 0
+	for _, tok := range pattern {
+		switch {
+		case tok == ">":
+		case tok == "*":
+			score++
+		case isWildcardPattern(tok):
+			score += 2
+		default:
+			score += 3
+		}
+	}
+	return score
+}
+
 // matchesFilter checks if a message matches a subscription filter.
 // Returns true if the message passes all filter criteria.
 func matchesFilter(filter *loomv1.SubscriptionFilter, msg *loomv1.BusMessage) bool {
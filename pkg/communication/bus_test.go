@@ -16,6 +16,7 @@ package communication
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"go.uber.org/zap/zaptest"
 
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/observability"
 )
 
 func TestBusPublishSubscribe(t *testing.T) {
@@ -397,6 +399,49 @@ func TestBusUnsubscribe(t *testing.T) {
 	}
 }
 
+func TestBusUnsubscribeAll(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	chat, err := bus.Subscribe(ctx, "rogue", "party.chat", nil, 10)
+	require.NoError(t, err)
+	loot, err := bus.Subscribe(ctx, "rogue", "loot.*", nil, 10)
+	require.NoError(t, err)
+	err = bus.SetAckTimeout(loot.ID, time.Minute)
+	require.NoError(t, err)
+	other, err := bus.Subscribe(ctx, "cleric", "party.chat", nil, 10)
+	require.NoError(t, err)
+
+	_, _, err = bus.PublishReliable(ctx, "loot.gold", &loomv1.BusMessage{Id: "gold"})
+	require.NoError(t, err)
+	require.Equal(t, 1, bus.PendingAcks(loot.ID))
+
+	removed, err := bus.UnsubscribeAll(ctx, "rogue")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.Empty(t, bus.GetSubscriptionsByAgent("rogue"))
+	assert.Equal(t, 0, bus.PendingAcks(loot.ID))
+
+	delivered, _, err := bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered, "only the other agent's subscription remains")
+	assert.Len(t, other.Channel, 1)
+
+	_, ok := <-chat.Channel
+	assert.False(t, ok, "channel should be closed")
+	<-loot.Channel // the buffered reliable message
+	_, ok = <-loot.Channel
+	assert.False(t, ok, "channel should be closed")
+
+	removed, err = bus.UnsubscribeAll(ctx, "rogue")
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	_, err = bus.UnsubscribeAll(ctx, "")
+	assert.Error(t, err)
+}
+
 func TestBusListTopics(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	bus := NewMessageBus(nil, nil, nil, logger)
@@ -522,3 +567,166 @@ func TestBusValidation(t *testing.T) {
 	_, _, err = bus.Publish(ctx, "topic", nil)
 	assert.Error(t, err)
 }
+
+func TestBusReservedTopic(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	require.NoError(t, bus.ReserveTopic("loom.agents.lifecycle", "loom-server"))
+	assert.True(t, bus.IsReservedTopic("loom.agents.lifecycle"))
+	assert.False(t, bus.IsReservedTopic("party.chat"))
+	assert.Error(t, bus.ReserveTopic("loom.>", "loom-server"))
+	assert.Error(t, bus.ReserveTopic("loom.other", ""))
+
+	sub, err := bus.Subscribe(ctx, "supervisor", "loom.agents.lifecycle", nil, 10)
+	require.NoError(t, err)
+
+	// Other publishers are rejected before anything is delivered
+	for _, from := range []string{"agent1", ""} {
+		delivered, _, err := bus.Publish(ctx, "loom.agents.lifecycle", &loomv1.BusMessage{Id: "forged", FromAgent: from})
+		assert.ErrorIs(t, err, ErrReservedTopic)
+		assert.Equal(t, 0, delivered)
+	}
+	assert.Empty(t, sub.Channel)
+
+	delivered, _, err := bus.Publish(ctx, "loom.agents.lifecycle", &loomv1.BusMessage{Id: "event", FromAgent: "loom-server"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, "event", (<-sub.Channel).Id)
+}
+
+func TestMatchTopicTokens(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{"party.*", "party.chat", true},
+		{"party.*", "party.chat.dm", false},
+		{"party.*", "party", false},
+		{"*.chat", "party.chat", true},
+		{"workflow.dungeon-crawl.>", "workflow.dungeon-crawl.room", true},
+		{"workflow.dungeon-crawl.>", "workflow.dungeon-crawl.room.1", true},
+		{"workflow.dungeon-crawl.>", "workflow.dungeon-crawl", false},
+		{">", "anything.at.all", true},
+		{"party-*", "party-chat", true},
+		{"party-*", "party-chat.dm", false},
+	}
+	for _, tt := range tests {
+		tokens, err := parseTopicPattern(tt.pattern)
+		require.NoError(t, err, tt.pattern)
+		assert.Equal(t, tt.want, matchTopicTokens(tokens, strings.Split(tt.topic, ".")), "%s vs %s", tt.pattern, tt.topic)
+	}
+
+	for _, invalid := range []string{"party.>.chat", "party..*", "party.a>", "party.[a"} {
+		_, err := parseTopicPattern(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestBusWildcardSubscriptions(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	single, err := bus.Subscribe(ctx, "bard", "party.*", nil, 10)
+	require.NoError(t, err)
+	multi, err := bus.Subscribe(ctx, "dm", "party.>", nil, 10)
+	require.NoError(t, err)
+
+	_, err = bus.Subscribe(ctx, "rogue", "party.>.dm", nil, 10)
+	assert.Error(t, err)
+
+	publish := func(topic string) int {
+		delivered, _, err := bus.Publish(ctx, topic, &loomv1.BusMessage{Id: topic, Topic: topic, FromAgent: "fighter"})
+		require.NoError(t, err)
+		return delivered
+	}
+
+	assert.Equal(t, 2, publish("party.chat"))
+	assert.Equal(t, 1, publish("party.chat.whisper"))
+	assert.Equal(t, 0, publish("guild.chat"))
+	assert.Len(t, single.Channel, 1)
+	assert.Len(t, multi.Channel, 2)
+
+	_, _, err = bus.Publish(ctx, "party.*", &loomv1.BusMessage{Id: "wild"})
+	assert.Error(t, err, "wildcards are only valid in subscriptions")
+
+	// Unsubscribed wildcard subscriptions stop matching
+	require.NoError(t, bus.Unsubscribe(ctx, multi.ID))
+	assert.Equal(t, 0, publish("party.chat.whisper"))
+}
+
+func TestBusWildcardPrecedence(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	exact, err := bus.Subscribe(ctx, "dm", "party.chat", nil, 10)
+	require.NoError(t, err)
+	dmWildcard, err := bus.Subscribe(ctx, "dm", "party.*", nil, 10)
+	require.NoError(t, err)
+	broad, err := bus.Subscribe(ctx, "bard", "party.>", nil, 10)
+	require.NoError(t, err)
+	narrow, err := bus.Subscribe(ctx, "bard", "party.*", nil, 10)
+	require.NoError(t, err)
+
+	delivered, _, err := bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "m1", FromAgent: "fighter"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered, "each agent receives the message once")
+
+	assert.Len(t, exact.Channel, 1, "exact subscription wins over the agent's wildcard")
+	assert.Len(t, dmWildcard.Channel, 0)
+	assert.Len(t, narrow.Channel, 1, "most specific wildcard wins")
+	assert.Len(t, broad.Channel, 0)
+
+	// Without an exact or narrower match, the broader pattern still delivers
+	delivered, _, err = bus.Publish(ctx, "party.chat.whisper", &loomv1.BusMessage{Id: "m2", FromAgent: "fighter"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, broad.Channel, 1)
+}
+
+func TestBusPublishPropagatesTraceContext(t *testing.T) {
+	tracer := observability.NewMockTracer()
+	bus := NewMessageBus(nil, nil, tracer, zaptest.NewLogger(t))
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, err := bus.Subscribe(ctx, "agent1", "test.topic", nil, 10)
+	require.NoError(t, err)
+
+	receive := func() *loomv1.BusMessage {
+		select {
+		case received := <-sub.Channel:
+			return received
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+			return nil
+		}
+	}
+
+	// The publish span joins the caller's trace and is handed to subscribers
+	parentCtx, parent := tracer.StartSpan(ctx, "caller")
+	_, _, err = bus.Publish(parentCtx, "test.topic", &loomv1.BusMessage{Id: "msg1", FromAgent: "agent0"})
+	require.NoError(t, err)
+
+	received := receive()
+	publish := tracer.GetSpanByName(SpanBusPublish)
+	require.NotNil(t, publish)
+	assert.Equal(t, parent.TraceID, publish.TraceID)
+	assert.Equal(t, parent.SpanID, publish.ParentID)
+	assert.Equal(t, "agent0", publish.Attributes[observability.AttrAgentID])
+	assert.Equal(t, parent.TraceID, received.Metadata[observability.MetadataTraceID])
+	assert.Equal(t, publish.SpanID, received.Metadata[observability.MetadataSpanID])
+
+	// Republishing without a span in ctx continues the trace in the metadata
+	tracer.Reset()
+	_, _, err = bus.Publish(ctx, "test.topic", received)
+	require.NoError(t, err)
+	republished := receive()
+	assert.Equal(t, parent.TraceID, republished.Metadata[observability.MetadataTraceID])
+	require.NotNil(t, tracer.GetSpanByName(SpanBusPublish))
+	assert.Equal(t, publish.SpanID, tracer.GetSpanByName(SpanBusPublish).ParentID)
+}
@@ -0,0 +1,359 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// SpanBusPublishReliable is the Hawk span for PublishReliable.
+const SpanBusPublishReliable = "bus.publish_reliable"
+
+// Reliable delivery defaults
+const (
+	// DefaultAckTimeout is how long a subscriber has to Ack a reliable message before it is redelivered
+	DefaultAckTimeout = 30 * time.Second
+
+	// DefaultMaxRedeliveries is how many times an unacked message is redelivered before dead-lettering
+	DefaultMaxRedeliveries = 3
+
+	// DefaultDeadLetterTopic prefixes the topic that exhausted messages are published to
+	DefaultDeadLetterTopic = "dead-letter"
+
+	defaultRedeliveryInterval = time.Second
+)
+
+// Metadata keys set on dead-lettered messages.
+const (
+	DeadLetterTopicKey        = "dead_letter_topic"        // Topic the message was originally published to
+	DeadLetterAgentKey        = "dead_letter_agent"        // Agent that never acknowledged it
+	DeadLetterSubscriptionKey = "dead_letter_subscription" // Subscription that never acknowledged it
+	DeliveryAttemptsKey       = "delivery_attempts"        // Delivery attempts made
+)
+
+// ReliableDeliveryConfig configures acknowledgements and redelivery for PublishReliable.
+type ReliableDeliveryConfig struct {
+	// Ack timeout for subscriptions without their own (default: 30s)
+	AckTimeout time.Duration
+
+	// Redeliveries after the first attempt before a message is dead-lettered (default: 3)
+	MaxRedeliveries int
+
+	// Exhausted messages are published to "<DeadLetterTopic>.<original topic>" (default: "dead-letter")
+	DeadLetterTopic string
+
+	// How often unacked messages are checked (default: 1s; read on the first PublishReliable)
+	CheckInterval time.Duration
+}
+
+// pendingDelivery is a reliable message awaiting acknowledgement by one subscription.
+type pendingDelivery struct {
+	msg      *loomv1.BusMessage
+	topic    string
+	attempts int       // Delivery attempts so far, including ones that found the buffer full
+	deadline time.Time // When the message is next redelivered (one ack timeout after each attempt)
+}
+
+// deadLetter is a message that exhausted its redeliveries.
+type deadLetter struct {
+	pending *pendingDelivery
+	sub     *Subscription
+}
+
+// SetReliableDelivery configures acknowledgement timeouts, redelivery, and dead-lettering
+// for messages sent with PublishReliable. Zero fields use the defaults.
+func (b *MessageBus) SetReliableDelivery(cfg ReliableDeliveryConfig) {
+	if cfg.AckTimeout <= 0 {
+		cfg.AckTimeout = DefaultAckTimeout
+	}
+	if cfg.MaxRedeliveries < 0 {
+		cfg.MaxRedeliveries = 0
+	} else if cfg.MaxRedeliveries == 0 {
+		cfg.MaxRedeliveries = DefaultMaxRedeliveries
+	}
+	if cfg.DeadLetterTopic == "" {
+		cfg.DeadLetterTopic = DefaultDeadLetterTopic
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultRedeliveryInterval
+	}
+
+	b.reliableMu.Lock()
+	defer b.reliableMu.Unlock()
+	b.reliableCfg = cfg
+}
+
+// SetAckTimeout sets how long a subscription has to Ack a reliable message before it is
+// redelivered. Zero uses the bus default.
+func (b *MessageBus) SetAckTimeout(subscriptionID string, timeout time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub, exists := b.subscriptions[subscriptionID]
+	if !exists {
+		return fmt.Errorf("subscription not found: %s", subscriptionID)
+	}
+	sub.AckTimeout = max(timeout, 0)
+	return nil
+}
+
+// PublishReliable publishes a message with at-least-once delivery. Each matching
+// subscription must Ack the message within its ack timeout; otherwise, or if its buffer
+// was full, the message is redelivered after each ack timeout up to MaxRedeliveries
+// times and then published to the dead-letter topic. Subscribers may receive a message more than once and should
+// deduplicate by message ID, which is therefore required.
+// Returns (delivered, dropped, error) as Publish does; dropped messages are queued for
+// redelivery.
+func (b *MessageBus) PublishReliable(ctx context.Context, topic string, msg *loomv1.BusMessage) (int, int, error) {
+	if b.closed.Load() {
+		return 0, 0, fmt.Errorf("message bus is closed")
+	}
+	if topic == "" {
+		return 0, 0, fmt.Errorf("topic cannot be empty")
+	}
+	if msg == nil {
+		return 0, 0, fmt.Errorf("message cannot be nil")
+	}
+	if msg.Id == "" {
+		return 0, 0, fmt.Errorf("message ID is required for reliable delivery")
+	}
+	if isWildcardPattern(topic) {
+		return 0, 0, fmt.Errorf("cannot publish to wildcard topic: %s", topic)
+	}
+
+	// Instrument with Hawk
+	var span *observability.Span
+	if b.tracer != nil {
+		_, span = b.tracer.StartSpan(ctx, SpanBusPublishReliable)
+		defer b.tracer.EndSpan(span)
+		span.SetAttribute("topic", topic)
+		span.SetAttribute("from_agent", msg.FromAgent)
+		span.SetAttribute("message_id", msg.Id)
+	}
+
+	b.redeliveryOnce.Do(func() { go b.runRedelivery() })
+
+	delivered := 0
+	dropped := 0
+	var publishErr error
+
+	// As in Publish, deliver outside the bus lock so a blocked subscriber stalls only
+	// this publish
+	b.mu.RLock()
+	b.retain(topic, msg)
+	recipients := b.matchingSubscriptions(topic)
+	b.mu.RUnlock()
+
+	for _, subscription := range recipients {
+		if !matchesFilter(subscription.Filter, msg) {
+			continue
+		}
+
+		// Track the delivery before sending so an immediate Ack finds it. reliableMu is
+		// not held while sending: a blocking send must not stall the subscriber's Ack.
+		b.reliableMu.Lock()
+		if b.pending[subscription.ID] == nil {
+			b.pending[subscription.ID] = make(map[string]*pendingDelivery)
+		}
+		b.pending[subscription.ID][msg.Id] = &pendingDelivery{
+			msg:      msg,
+			topic:    topic,
+			attempts: 1,
+			deadline: time.Now().Add(b.ackTimeout(subscription)),
+		}
+		b.reliableMu.Unlock()
+
+		ok, lost, err := subscription.deliver(ctx, msg)
+		if ok {
+			delivered++
+		}
+		dropped += lost
+		if err != nil && publishErr == nil {
+			publishErr = err
+		}
+	}
+
+	b.recordPublish(topic, delivered, dropped)
+
+	if span != nil {
+		span.SetAttribute("delivered", delivered)
+		span.SetAttribute("dropped", dropped)
+	}
+
+	b.logger.Debug("bus publish reliable",
+		zap.String("topic", topic),
+		zap.String("from_agent", msg.FromAgent),
+		zap.String("message_id", msg.Id),
+		zap.Int("delivered", delivered),
+		zap.Int("dropped", dropped))
+
+	return delivered, dropped, publishErr
+}
+
+// Ack marks a reliable message as processed by a subscription so it is not redelivered.
+// Acknowledging a message that is not pending (already acked, or not sent with
+// PublishReliable) is a no-op.
+func (b *MessageBus) Ack(subscriptionID, messageID string) error {
+	if subscriptionID == "" || messageID == "" {
+		return fmt.Errorf("subscription ID and message ID are required")
+	}
+
+	b.mu.RLock()
+	_, exists := b.subscriptions[subscriptionID]
+	b.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("subscription not found: %s", subscriptionID)
+	}
+
+	b.reliableMu.Lock()
+	defer b.reliableMu.Unlock()
+	if msgs := b.pending[subscriptionID]; msgs != nil {
+		delete(msgs, messageID)
+		if len(msgs) == 0 {
+			delete(b.pending, subscriptionID)
+		}
+	}
+	return nil
+}
+
+// PendingAcks returns the number of reliable messages a subscription has not acknowledged.
+func (b *MessageBus) PendingAcks(subscriptionID string) int {
+	b.reliableMu.Lock()
+	defer b.reliableMu.Unlock()
+	return len(b.pending[subscriptionID])
+}
+
+// ackTimeout returns the subscription's ack timeout, or the bus default.
+// Caller must hold b.mu and b.reliableMu.
+func (b *MessageBus) ackTimeout(sub *Subscription) time.Duration {
+	if sub.AckTimeout > 0 {
+		return sub.AckTimeout
+	}
+	return b.reliableCfg.AckTimeout
+}
+
+// runRedelivery periodically redelivers unacked messages until the bus is closed.
+func (b *MessageBus) runRedelivery() {
+	b.reliableMu.Lock()
+	interval := b.reliableCfg.CheckInterval
+	b.reliableMu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.redeliveryDone:
+			return
+		case now := <-ticker.C:
+			b.redeliverExpired(now)
+		}
+	}
+}
+
+// redeliverExpired redelivers messages whose ack deadline has passed and dead-letters
+// those that have used up their redeliveries.
+func (b *MessageBus) redeliverExpired(now time.Time) {
+	var exhausted []deadLetter
+	redelivered := 0
+
+	b.mu.RLock()
+	// Close closes subscriber channels under b.mu, so check it while holding the lock
+	if b.closed.Load() {
+		b.mu.RUnlock()
+		return
+	}
+	b.reliableMu.Lock()
+	maxAttempts := 1 + b.reliableCfg.MaxRedeliveries
+	for subID, msgs := range b.pending {
+		sub, exists := b.subscriptions[subID]
+		if !exists {
+			delete(b.pending, subID)
+			continue
+		}
+		for msgID, pending := range msgs {
+			if now.Before(pending.deadline) {
+				continue
+			}
+			if pending.attempts >= maxAttempts {
+				delete(msgs, msgID)
+				exhausted = append(exhausted, deadLetter{pending: pending, sub: sub})
+				continue
+			}
+
+			pending.attempts++
+			pending.deadline = now.Add(b.ackTimeout(sub))
+			// Redelivery never blocks, whatever the subscription's backpressure policy
+			if sub.trySend(pending.msg) {
+				redelivered++
+			} else {
+				sub.dropped.Add(1)
+			}
+		}
+		if len(msgs) == 0 {
+			delete(b.pending, subID)
+		}
+	}
+	deadLetterTopic := b.reliableCfg.DeadLetterTopic
+	b.reliableMu.Unlock()
+	b.mu.RUnlock()
+
+	b.totalRedelivered.Add(int64(redelivered))
+
+	// Publish outside the locks: Publish takes b.mu itself
+	for _, dl := range exhausted {
+		b.publishDeadLetter(deadLetterTopic, dl)
+	}
+}
+
+// publishDeadLetter publishes an exhausted message to "<prefix>.<original topic>".
+func (b *MessageBus) publishDeadLetter(prefix string, dl deadLetter) {
+	msg, ok := proto.Clone(dl.pending.msg).(*loomv1.BusMessage)
+	if !ok {
+		return
+	}
+	topic := prefix + "." + dl.pending.topic
+	msg.Topic = topic
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]string)
+	}
+	msg.Metadata[DeadLetterTopicKey] = dl.pending.topic
+	msg.Metadata[DeadLetterAgentKey] = dl.sub.AgentID
+	msg.Metadata[DeadLetterSubscriptionKey] = dl.sub.ID
+	msg.Metadata[DeliveryAttemptsKey] = strconv.Itoa(dl.pending.attempts)
+
+	b.totalDeadLettered.Add(1)
+	b.logger.Warn("bus message dead-lettered",
+		zap.String("message_id", msg.Id),
+		zap.String("topic", dl.pending.topic),
+		zap.String("agent_id", dl.sub.AgentID),
+		zap.String("subscription_id", dl.sub.ID),
+		zap.Int("attempts", dl.pending.attempts))
+
+	if _, _, err := b.Publish(context.Background(), topic, msg); err != nil {
+		b.logger.Warn("Failed to publish dead letter",
+			zap.String("message_id", msg.Id),
+			zap.String("topic", topic),
+			zap.Error(err))
+	}
+}
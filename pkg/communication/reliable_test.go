@@ -0,0 +1,150 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+func newReliableTestBus(t *testing.T, maxRedeliveries int) *MessageBus {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	bus.SetReliableDelivery(ReliableDeliveryConfig{
+		AckTimeout:      20 * time.Millisecond,
+		MaxRedeliveries: maxRedeliveries,
+		CheckInterval:   5 * time.Millisecond,
+	})
+	t.Cleanup(func() { _ = bus.Close() })
+	return bus
+}
+
+func receive(t *testing.T, sub *Subscription) *loomv1.BusMessage {
+	t.Helper()
+	select {
+	case msg := <-sub.Channel:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+		return nil
+	}
+}
+
+func TestBusPublishReliable_Ack(t *testing.T) {
+	bus := newReliableTestBus(t, 3)
+	ctx := context.Background()
+
+	sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 10)
+	require.NoError(t, err)
+	require.NoError(t, bus.SetAckTimeout(sub.ID, time.Minute))
+	assert.Error(t, bus.SetAckTimeout("missing", time.Minute))
+
+	delivered, dropped, err := bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "msg1", FromAgent: "agent0"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, 1, bus.PendingAcks(sub.ID))
+
+	assert.Equal(t, "msg1", receive(t, sub).Id)
+	require.NoError(t, bus.Ack(sub.ID, "msg1"))
+	assert.Equal(t, 0, bus.PendingAcks(sub.ID))
+	require.NoError(t, bus.Ack(sub.ID, "msg1"), "duplicate acks are harmless")
+
+	// Acked messages are not redelivered
+	time.Sleep(60 * time.Millisecond)
+	assert.Len(t, sub.Channel, 0)
+
+	// Unacked messages wait for the subscription's own ack timeout
+	_, _, err = bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "msg2"})
+	require.NoError(t, err)
+	assert.Equal(t, "msg2", receive(t, sub).Id)
+	time.Sleep(60 * time.Millisecond)
+	assert.Len(t, sub.Channel, 0)
+	assert.Equal(t, 1, bus.PendingAcks(sub.ID))
+
+	assert.Error(t, bus.Ack("missing", "msg1"))
+	_, _, err = bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{})
+	assert.Error(t, err, "message ID is required")
+}
+
+func TestBusPublishReliable_RedeliveryAndDeadLetter(t *testing.T) {
+	bus := newReliableTestBus(t, 2)
+	ctx := context.Background()
+
+	sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 10)
+	require.NoError(t, err)
+	deadLetters, err := bus.Subscribe(ctx, "operator", DefaultDeadLetterTopic+".>", nil, 10)
+	require.NoError(t, err)
+
+	_, _, err = bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "msg1", FromAgent: "agent0"})
+	require.NoError(t, err)
+
+	// First delivery plus two redeliveries, none acknowledged
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "msg1", receive(t, sub).Id)
+	}
+
+	dead := receive(t, deadLetters)
+	assert.Equal(t, "msg1", dead.Id)
+	assert.Equal(t, DefaultDeadLetterTopic+".party.chat", dead.Topic)
+	assert.Equal(t, "party.chat", dead.Metadata[DeadLetterTopicKey])
+	assert.Equal(t, "agent1", dead.Metadata[DeadLetterAgentKey])
+	assert.Equal(t, "3", dead.Metadata[DeliveryAttemptsKey])
+	assert.Equal(t, 0, bus.PendingAcks(sub.ID))
+	assert.Len(t, sub.Channel, 0)
+}
+
+func TestBusPublishReliable_FullBufferIsRetried(t *testing.T) {
+	bus := newReliableTestBus(t, 3)
+	ctx := context.Background()
+
+	sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 1)
+	require.NoError(t, err)
+
+	_, _, err = bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "msg1"})
+	require.NoError(t, err)
+	delivered, dropped, err := bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "msg2"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, 1, dropped)
+
+	// Freeing the buffer lets the dropped message through after the ack timeout
+	assert.Equal(t, "msg1", receive(t, sub).Id)
+	require.NoError(t, bus.Ack(sub.ID, "msg1"))
+	assert.Equal(t, "msg2", receive(t, sub).Id)
+	require.NoError(t, bus.Ack(sub.ID, "msg2"))
+	assert.Equal(t, 0, bus.PendingAcks(sub.ID))
+}
+
+func TestBusPublishReliable_Unsubscribe(t *testing.T) {
+	bus := newReliableTestBus(t, 3)
+	ctx := context.Background()
+
+	sub, err := bus.Subscribe(ctx, "agent1", "party.chat", nil, 10)
+	require.NoError(t, err)
+	_, _, err = bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "msg1"})
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Unsubscribe(ctx, sub.ID))
+	assert.Equal(t, 0, bus.PendingAcks(sub.ID))
+
+	// The redelivery loop must not touch the closed channel
+	time.Sleep(60 * time.Millisecond)
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+// Metadata keys set on request messages and their replies.
+const (
+	CorrelationIDKey = "correlation_id" // Ties a reply to its request
+	ReplyToKey       = "reply_to"       // Topic the requester waits on for the reply
+)
+
+// ReplyTopicPrefix prefixes the temporary topics Request waits on.
+const ReplyTopicPrefix = "_reply"
+
+var (
+	// ErrNoResponders is returned by Request when no subscriber received the request.
+	ErrNoResponders = errors.New("no subscribers for request topic")
+
+	// ErrNotRequest is returned by Reply for messages not sent with Request.
+	ErrNotRequest = errors.New("message is not a request")
+)
+
+// Request publishes msg to topic and waits for the first reply sent with Reply.
+// The published copy carries a new correlation ID and a temporary reply topic in its
+// metadata (CorrelationIDKey, ReplyToKey); the temporary subscription is removed
+// before Request returns. Replies with another correlation ID are ignored.
+// Returns an error wrapping ErrNoResponders if no subscriber received the request,
+// or ctx.Err() if ctx is done before a reply arrives.
+func (b *MessageBus) Request(ctx context.Context, topic string, msg *loomv1.BusMessage) (*loomv1.BusMessage, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+
+	correlationID := uuid.New().String()
+	replyTopic := ReplyTopicPrefix + "." + correlationID
+
+	requester := msg.FromAgent
+	if requester == "" {
+		requester = "request"
+	}
+	sub, err := b.Subscribe(ctx, requester, replyTopic, nil, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to reply topic: %w", err)
+	}
+	defer b.removeReplyTopic(ctx, sub)
+
+	request, ok := proto.Clone(msg).(*loomv1.BusMessage)
+	if !ok {
+		return nil, fmt.Errorf("failed to copy request message")
+	}
+	if request.Metadata == nil {
+		request.Metadata = make(map[string]string)
+	}
+	request.Metadata[CorrelationIDKey] = correlationID
+	request.Metadata[ReplyToKey] = replyTopic
+
+	delivered, _, err := b.Publish(ctx, topic, request)
+	if err != nil {
+		return nil, err
+	}
+	if delivered == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoResponders, topic)
+	}
+
+	for {
+		select {
+		case reply, ok := <-sub.Channel:
+			if !ok {
+				return nil, fmt.Errorf("message bus is closed")
+			}
+			if reply.Metadata[CorrelationIDKey] == correlationID {
+				return reply, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Reply publishes reply to the topic a Request is waiting on, tagged with the
+// request's correlation ID. Returns an error wrapping ErrNotRequest if request was not
+// sent with Request, and an error if the requester is no longer waiting.
+func (b *MessageBus) Reply(ctx context.Context, request, reply *loomv1.BusMessage) error {
+	if request == nil || reply == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+	correlationID := request.Metadata[CorrelationIDKey]
+	replyTopic := request.Metadata[ReplyToKey]
+	if correlationID == "" || replyTopic == "" {
+		return fmt.Errorf("%w: %s", ErrNotRequest, request.Id)
+	}
+
+	msg, ok := proto.Clone(reply).(*loomv1.BusMessage)
+	if !ok {
+		return fmt.Errorf("failed to copy reply message")
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]string)
+	}
+	msg.Metadata[CorrelationIDKey] = correlationID
+
+	delivered, _, err := b.Publish(ctx, replyTopic, msg)
+	if err != nil {
+		return err
+	}
+	if delivered == 0 {
+		return fmt.Errorf("requester is no longer waiting for a reply to %s", request.Id)
+	}
+	return nil
+}
+
+// removeReplyTopic unsubscribes a Request's reply subscription and drops its topic,
+// so finished requests do not accumulate in ListTopics.
+func (b *MessageBus) removeReplyTopic(ctx context.Context, sub *Subscription) {
+	if err := b.Unsubscribe(ctx, sub.ID); err != nil {
+		b.logger.Debug("failed to remove reply subscription", zap.String("subscription_id", sub.ID), zap.Error(err))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if tb := b.topics[sub.Topic]; tb != nil {
+		tb.mu.RLock()
+		unused := len(tb.subscribers) == 0
+		tb.mu.RUnlock()
+		if unused {
+			delete(b.topics, sub.Topic)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+func TestBusRequestReply(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	wizard, err := bus.Subscribe(ctx, "wizard", "identify", nil, 10)
+	require.NoError(t, err)
+	go func() {
+		for req := range wizard.Channel {
+			// A stray reply with the wrong correlation ID is ignored by the requester
+			stray := &loomv1.BusMessage{Id: "stray", Metadata: map[string]string{CorrelationIDKey: "other"}}
+			_, _, _ = bus.Publish(ctx, req.Metadata[ReplyToKey], stray)
+
+			answer := &loomv1.BusMessage{
+				Id:        "answer",
+				FromAgent: "wizard",
+				Payload:   &loomv1.MessagePayload{Data: &loomv1.MessagePayload_Value{Value: []byte("cursed sword")}},
+			}
+			assert.NoError(t, bus.Reply(ctx, req, answer))
+		}
+	}()
+
+	reply, err := bus.Request(ctx, "identify", &loomv1.BusMessage{Id: "q1", FromAgent: "fighter"})
+	require.NoError(t, err)
+	assert.Equal(t, "answer", reply.Id)
+	assert.Equal(t, "cursed sword", string(reply.Payload.GetValue()))
+	assert.NotEmpty(t, reply.Metadata[CorrelationIDKey])
+
+	// The temporary reply subscription and topic are gone
+	assert.Empty(t, bus.GetSubscriptionsByAgent("fighter"))
+	topics, err := bus.ListTopics(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"identify"}, topics)
+}
+
+func TestBusRequestErrors(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	_, err := bus.Request(ctx, "nobody.listens", &loomv1.BusMessage{Id: "q1", FromAgent: "fighter"})
+	require.ErrorIs(t, err, ErrNoResponders)
+
+	_, err = bus.Subscribe(ctx, "wizard", "identify", nil, 10)
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = bus.Request(timeoutCtx, "identify", &loomv1.BusMessage{Id: "q2", FromAgent: "fighter"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, bus.GetSubscriptionsByAgent("fighter"))
+
+	err = bus.Reply(ctx, &loomv1.BusMessage{Id: "plain"}, &loomv1.BusMessage{Id: "r"})
+	require.ErrorIs(t, err, ErrNotRequest)
+
+	// Replying after the requester gave up reports it
+	expired := &loomv1.BusMessage{Id: "q2", Metadata: map[string]string{CorrelationIDKey: "c", ReplyToKey: ReplyTopicPrefix + ".c"}}
+	assert.Error(t, bus.Reply(ctx, expired, &loomv1.BusMessage{Id: "r"}))
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+// maxRetainedMessages caps a topic's replay buffer when only RetainFor is set.
+const maxRetainedMessages = 1000
+
+// ReplayedKey is set to "true" in the metadata of retained messages replayed to a new subscriber.
+const ReplayedKey = "replayed"
+
+// retainedMessage is a published message kept for late subscribers.
+type retainedMessage struct {
+	msg *loomv1.BusMessage
+	at  time.Time
+}
+
+// ConfigureTopic sets how many recent messages a topic retains for replay to new
+// subscribers: the last retainN messages, those published within retainFor, or both
+// limits when both are set. Only retainFor keeps at most 1000 messages. Zero for both
+// disables retention and discards retained messages.
+func (b *MessageBus) ConfigureTopic(name string, retainN int, retainFor time.Duration) error {
+	if name == "" {
+		return fmt.Errorf("topic cannot be empty")
+	}
+	if isWildcardPattern(name) {
+		return fmt.Errorf("cannot configure wildcard topic: %s", name)
+	}
+	if retainN < 0 || retainFor < 0 {
+		return fmt.Errorf("retention limits cannot be negative")
+	}
+
+	tb := b.getOrCreateTopic(name)
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.retainN = retainN
+	tb.retainFor = retainFor
+	tb.retained = tb.trimRetained(tb.retained, time.Now())
+	return nil
+}
+
+// retain adds msg to the topic's replay buffer, if it has one. Caller must hold b.mu.
+func (b *MessageBus) retain(topic string, msg *loomv1.BusMessage) {
+	if tb := b.topics[topic]; tb != nil {
+		tb.retain(msg, time.Now())
+	}
+}
+
+// replayRetained sends the retained messages of every topic matching the subscription,
+// oldest first, and returns how many were buffered. Replay never blocks; messages that
+// do not fit the buffer are dropped. Caller must hold b.mu for writing.
+func (b *MessageBus) replayRetained(sub *Subscription) int {
+	var history []retainedMessage
+	now := time.Now()
+	for name, tb := range b.topics {
+		if sub.pattern != nil {
+			if isWildcardPattern(name) || !matchTopicTokens(sub.pattern, strings.Split(name, ".")) {
+				continue
+			}
+		} else if name != sub.Topic {
+			continue
+		}
+		history = append(history, tb.retainedSince(now)...)
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].at.Before(history[j].at) })
+
+	replayed := 0
+	for _, r := range history {
+		if !matchesFilter(sub.Filter, r.msg) {
+			continue
+		}
+		msg, ok := proto.Clone(r.msg).(*loomv1.BusMessage)
+		if !ok {
+			continue
+		}
+		if msg.Metadata == nil {
+			msg.Metadata = make(map[string]string)
+		}
+		msg.Metadata[ReplayedKey] = "true"
+		if !sub.trySend(msg) {
+			sub.dropped.Add(1)
+			continue
+		}
+		replayed++
+	}
+	return replayed
+}
+
+// retain appends msg to the replay buffer and trims it to the configured limits.
+func (tb *TopicBroadcaster) retain(msg *loomv1.BusMessage, now time.Time) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if tb.retainN == 0 && tb.retainFor == 0 {
+		return
+	}
+	tb.retained = tb.trimRetained(append(tb.retained, retainedMessage{msg: msg, at: now}), now)
+}
+
+// retainedSince returns the retained messages still within the retention window.
+func (tb *TopicBroadcaster) retainedSince(now time.Time) []retainedMessage {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.retained = tb.trimRetained(tb.retained, now)
+	return append([]retainedMessage(nil), tb.retained...)
+}
+
+// trimRetained drops messages beyond the count limit or older than the age limit.
+// Caller must hold tb.mu.
+func (tb *TopicBroadcaster) trimRetained(retained []retainedMessage, now time.Time) []retainedMessage {
+	if tb.retainN == 0 && tb.retainFor == 0 {
+		return nil
+	}
+
+	limit := tb.retainN
+	if limit == 0 {
+		limit = maxRetainedMessages
+	}
+	if len(retained) > limit {
+		retained = append(retained[:0:0], retained[len(retained)-limit:]...)
+	}
+
+	if tb.retainFor > 0 {
+		cutoff := now.Add(-tb.retainFor)
+		i := sort.Search(len(retained), func(i int) bool { return retained[i].at.After(cutoff) })
+		retained = retained[i:]
+	}
+	return retained
+}
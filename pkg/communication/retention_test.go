@@ -0,0 +1,115 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+func drain(sub *Subscription) []*loomv1.BusMessage {
+	var msgs []*loomv1.BusMessage
+	for {
+		select {
+		case msg := <-sub.Channel:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+func TestBusRetention_LastN(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	require.NoError(t, bus.ConfigureTopic("party-chat", 2, 0))
+	for i := 1; i <= 3; i++ {
+		_, _, err := bus.Publish(ctx, "party-chat", &loomv1.BusMessage{Id: fmt.Sprintf("m%d", i)})
+		require.NoError(t, err)
+	}
+
+	late, err := bus.Subscribe(ctx, "bard", "party-chat", nil, 10)
+	require.NoError(t, err)
+
+	history := drain(late)
+	require.Len(t, history, 2)
+	assert.Equal(t, "m2", history[0].Id)
+	assert.Equal(t, "m3", history[1].Id)
+	assert.Equal(t, "true", history[0].Metadata[ReplayedKey])
+
+	// Live messages follow the scrollback and are not marked as replayed
+	_, _, err = bus.Publish(ctx, "party-chat", &loomv1.BusMessage{Id: "m4"})
+	require.NoError(t, err)
+	live := drain(late)
+	require.Len(t, live, 1)
+	assert.Empty(t, live[0].Metadata[ReplayedKey])
+}
+
+func TestBusRetention_RetainFor(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	require.NoError(t, bus.ConfigureTopic("party.chat", 0, 30*time.Millisecond))
+	_, _, err := bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "old"})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	_, _, err = bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "recent"})
+	require.NoError(t, err)
+	_, _, err = bus.Publish(ctx, "party.other", &loomv1.BusMessage{Id: "unretained"})
+	require.NoError(t, err)
+
+	// Wildcard subscribers replay every matching retained topic
+	late, err := bus.Subscribe(ctx, "bard", "party.*", nil, 10)
+	require.NoError(t, err)
+	history := drain(late)
+	require.Len(t, history, 1)
+	assert.Equal(t, "recent", history[0].Id)
+}
+
+func TestBusRetention_Configure(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	assert.Error(t, bus.ConfigureTopic("", 1, 0))
+	assert.Error(t, bus.ConfigureTopic("party.*", 1, 0))
+	assert.Error(t, bus.ConfigureTopic("party.chat", -1, 0))
+
+	// Topics are not retained unless configured
+	_, _, err := bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "m1"})
+	require.NoError(t, err)
+	sub, err := bus.Subscribe(ctx, "bard", "party.chat", nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, drain(sub))
+
+	// Disabling retention discards the buffer
+	require.NoError(t, bus.ConfigureTopic("party.chat", 5, 0))
+	_, _, err = bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "m2"})
+	require.NoError(t, err)
+	require.NoError(t, bus.ConfigureTopic("party.chat", 0, 0))
+	sub, err = bus.Subscribe(ctx, "rogue", "party.chat", nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, drain(sub))
+}
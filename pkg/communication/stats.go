@@ -0,0 +1,95 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"sort"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+// BusStats is a point-in-time snapshot of message bus activity.
+type BusStats struct {
+	TotalPublished    int64
+	TotalDelivered    int64
+	TotalDropped      int64
+	TotalRedelivered  int64
+	TotalDeadLettered int64
+
+	// Per-topic counters, sorted by topic. Wildcard subscriptions appear under their pattern.
+	Topics []*loomv1.TopicStats
+
+	// Per-subscription buffer state, sorted by agent ID, then topic
+	Subscriptions []SubscriptionStats
+}
+
+// SubscriptionStats describes one subscription's buffer and delivery state.
+type SubscriptionStats struct {
+	ID           string
+	AgentID      string
+	Topic        string
+	Buffered     int   // Messages waiting in the buffer
+	Capacity     int   // Buffer size
+	Dropped      int64 // Messages dropped because the buffer was full
+	PendingAcks  int   // PublishReliable messages not yet acknowledged
+	Backpressure BackpressurePolicy
+}
+
+// Stats returns a snapshot of bus-wide counters, per-topic statistics, and the buffer
+// occupancy of every subscription.
+func (b *MessageBus) Stats() BusStats {
+	stats := BusStats{
+		TotalPublished:    b.totalPublished.Load(),
+		TotalDelivered:    b.totalDelivered.Load(),
+		TotalDropped:      b.totalDropped.Load(),
+		TotalRedelivered:  b.totalRedelivered.Load(),
+		TotalDeadLettered: b.totalDeadLettered.Load(),
+	}
+
+	b.mu.RLock()
+	stats.Topics = make([]*loomv1.TopicStats, 0, len(b.topics))
+	for _, broadcaster := range b.topics {
+		stats.Topics = append(stats.Topics, broadcaster.stats())
+	}
+
+	stats.Subscriptions = make([]SubscriptionStats, 0, len(b.subscriptions))
+	b.reliableMu.Lock()
+	for _, sub := range b.subscriptions {
+		stats.Subscriptions = append(stats.Subscriptions, SubscriptionStats{
+			ID:           sub.ID,
+			AgentID:      sub.AgentID,
+			Topic:        sub.Topic,
+			Buffered:     len(sub.channel),
+			Capacity:     cap(sub.channel),
+			Dropped:      sub.dropped.Load(),
+			PendingAcks:  len(b.pending[sub.ID]),
+			Backpressure: sub.Backpressure,
+		})
+	}
+	b.reliableMu.Unlock()
+	b.mu.RUnlock()
+
+	sort.Slice(stats.Topics, func(i, j int) bool { return stats.Topics[i].Topic < stats.Topics[j].Topic })
+	sort.Slice(stats.Subscriptions, func(i, j int) bool {
+		a, c := stats.Subscriptions[i], stats.Subscriptions[j]
+		if a.AgentID != c.AgentID {
+			return a.AgentID < c.AgentID
+		}
+		if a.Topic != c.Topic {
+			return a.Topic < c.Topic
+		}
+		return a.ID < c.ID
+	})
+	return stats
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package communication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+)
+
+func TestBusStats(t *testing.T) {
+	bus := NewMessageBus(nil, nil, nil, zaptest.NewLogger(t))
+	defer bus.Close()
+	ctx := context.Background()
+
+	slow, err := bus.Subscribe(ctx, "slow", "party.chat", nil, 1)
+	require.NoError(t, err)
+	_, err = bus.Subscribe(ctx, "fast", "party.*", nil, 10)
+	require.NoError(t, err)
+
+	for _, id := range []string{"m1", "m2"} {
+		_, _, err := bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: id})
+		require.NoError(t, err)
+	}
+	_, _, err = bus.PublishReliable(ctx, "party.chat", &loomv1.BusMessage{Id: "m3"})
+	require.NoError(t, err)
+
+	stats := bus.Stats()
+	assert.Equal(t, int64(3), stats.TotalPublished)
+	assert.Equal(t, int64(4), stats.TotalDelivered)
+	assert.Equal(t, int64(2), stats.TotalDropped)
+
+	require.Len(t, stats.Topics, 2)
+	assert.Equal(t, "party.*", stats.Topics[0].Topic)
+	assert.Equal(t, "party.chat", stats.Topics[1].Topic)
+	assert.Equal(t, int32(1), stats.Topics[1].ActiveSubscribers)
+	assert.Equal(t, int64(3), stats.Topics[1].TotalPublished)
+
+	require.Len(t, stats.Subscriptions, 2)
+	fast, slowStats := stats.Subscriptions[0], stats.Subscriptions[1]
+	assert.Equal(t, "fast", fast.AgentID)
+	assert.Equal(t, 3, fast.Buffered)
+	assert.Equal(t, 10, fast.Capacity)
+	assert.Equal(t, 1, fast.PendingAcks)
+	assert.Equal(t, slow.ID, slowStats.ID)
+	assert.Equal(t, 1, slowStats.Buffered)
+	assert.Equal(t, int64(2), slowStats.Dropped)
+	assert.Equal(t, BackpressureDropNewest, slowStats.Backpressure)
+}
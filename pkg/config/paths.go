@@ -6,45 +6,160 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+)
+
+// userHomeDir resolves the user's home directory. Overridden in tests.
+var userHomeDir = os.UserHomeDir
+
+// fallbackWarnOnce limits the temp-dir fallback warning to once per process.
+var fallbackWarnOnce sync.Once
+
+// The data directory is resolved once and cached, see GetLoomDataDirE.
+// dataDirMu guards replacing dataDirOnce in ResetDataDirCache.
+var (
+	dataDirMu        sync.RWMutex
+	dataDirOnce      sync.Once
+	cachedDataDir    string
+	cachedDataDirErr error
 )
 
 // GetLoomDataDir returns the Loom data directory.
 //
 // Priority:
 // 1. LOOM_DATA_DIR environment variable (if set and non-empty)
-// 2. ~/.loom (default)
+// 2. $XDG_DATA_HOME/loom or ~/.local/share/loom (Linux, when LOOM_USE_XDG is true; see UsesXDGDataDir)
+// 3. ~/.loom (default)
+// 4. <os.TempDir()>/loom (if the home directory cannot be determined)
 //
-// The returned path is always absolute. Tilde (~) in LOOM_DATA_DIR is expanded to the user's home directory.
-// Relative paths in LOOM_DATA_DIR are converted to absolute paths.
+// The returned path is always absolute. Environment variables ($VAR or ${VAR}) and a leading
+// tilde (~) in LOOM_DATA_DIR are expanded. Relative paths in LOOM_DATA_DIR are converted to absolute paths.
 //
 // This function is called during bootstrap (before config file is loaded) to locate the config file itself.
 // After config is loaded, use config.DataDir for consistency.
 //
 // Examples:
 //
-//	LOOM_DATA_DIR=/custom/loom        -> /custom/loom
-//	LOOM_DATA_DIR=~/my-loom           -> /home/user/my-loom
-//	LOOM_DATA_DIR=relative/path       -> /current/dir/relative/path
-//	LOOM_DATA_DIR not set             -> /home/user/.loom
+//	LOOM_DATA_DIR=/custom/loom            -> /custom/loom
+//	LOOM_DATA_DIR=~/my-loom               -> /home/user/my-loom
+//	LOOM_DATA_DIR=$HOME/loom              -> /home/user/loom
+//	LOOM_DATA_DIR=${XDG_DATA_HOME}/loom   -> /home/user/.local/share/loom
+//	LOOM_DATA_DIR=relative/path           -> /current/dir/relative/path
+//	LOOM_DATA_DIR not set                 -> /home/user/.loom
+//	LOOM_DATA_DIR not set, LOOM_USE_XDG=1 -> /home/user/.local/share/loom (Linux only)
+//	LOOM_DATA_DIR not set, no $HOME       -> /tmp/loom (warning printed to stderr)
+//
+// Callers that must not silently use the temp fallback (e.g. daemons) should use GetLoomDataDirE,
+// or ValidateDataDir at startup.
+//
+// The directory is resolved on first use and cached for the life of the process, so all
+// callers agree on one path. Changing LOOM_DATA_DIR (or HOME, LOOM_USE_XDG, XDG_DATA_HOME)
+// after that has no effect unless ResetDataDirCache is called.
 //
 // Note: This function reads directly from os.Getenv(), not from viper, to avoid
 // circular dependency during config initialization.
 func GetLoomDataDir() string {
+	dataDir, err := GetLoomDataDirE()
+	if err != nil {
+		fallback := filepath.Join(os.TempDir(), "loom")
+		fallbackWarnOnce.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: %v; using %s as Loom data directory (set LOOM_DATA_DIR to override)\n", err, fallback)
+		})
+		return fallback
+	}
+	return dataDir
+}
+
+// GetLoomDataDirE returns the Loom data directory, or an error if LOOM_DATA_DIR is
+// unset and the user's home directory cannot be determined.
+// Resolution rules and caching are otherwise identical to GetLoomDataDir.
+func GetLoomDataDirE() (string, error) {
+	dataDirMu.RLock()
+	defer dataDirMu.RUnlock()
+	dataDirOnce.Do(func() {
+		cachedDataDir, cachedDataDirErr = resolveDataDir()
+	})
+	return cachedDataDir, cachedDataDirErr
+}
+
+// ResetDataDirCache discards the cached data directory so the next call resolves it
+// from the current environment. Intended for tests that change LOOM_DATA_DIR.
+func ResetDataDirCache() {
+	dataDirMu.Lock()
+	defer dataDirMu.Unlock()
+	dataDirOnce = sync.Once{}
+	cachedDataDir, cachedDataDirErr = "", nil
+}
+
+// resolveDataDir resolves the data directory from the environment, uncached.
+func resolveDataDir() (string, error) {
 	// Check environment variable first
 	if dataDir := os.Getenv("LOOM_DATA_DIR"); dataDir != "" {
-		return expandPath(dataDir)
+		return expandPath(dataDir), nil
+	}
+
+	if UsesXDGDataDir() {
+		return xdgDataDir()
 	}
 
 	// Fall back to ~/.loom
-	homeDir, err := os.UserHomeDir()
+	return legacyDataDir()
+}
+
+// legacyDataDir returns ~/.loom, the default data directory.
+func legacyDataDir() (string, error) {
+	homeDir, err := userHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory for Loom data directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".loom"), nil
+}
+
+// ValidateDataDir checks that the Loom data directory resolves to an absolute path,
+// exists (creating it if missing), is a directory, and is writable. Errors name the
+// offending path and whether it came from LOOM_DATA_DIR.
+//
+// Bootstrap calls this before loading config so misconfiguration fails loudly instead
+// of scattering data into the temp fallback or a relative path.
+func ValidateDataDir() error {
+	source := "default data directory; set LOOM_DATA_DIR to override"
+	if env := os.Getenv("LOOM_DATA_DIR"); env != "" {
+		source = fmt.Sprintf("LOOM_DATA_DIR=%q", env)
+	}
+
+	dataDir, err := GetLoomDataDirE()
 	if err != nil {
-		// Fallback to current directory if home dir cannot be determined
-		return ".loom"
+		return fmt.Errorf("invalid Loom data directory (%s): %w", source, err)
 	}
-	return filepath.Join(homeDir, ".loom")
+	if !filepath.IsAbs(dataDir) {
+		return fmt.Errorf("invalid Loom data directory %s (%s): cannot resolve to an absolute path", dataDir, source)
+	}
+
+	info, err := os.Stat(dataDir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := os.MkdirAll(dataDir, 0750); err != nil {
+			return fmt.Errorf("invalid Loom data directory %s (%s): cannot create: %w", dataDir, source, err)
+		}
+	case err != nil:
+		return fmt.Errorf("invalid Loom data directory %s (%s): %w", dataDir, source, err)
+	case !info.IsDir():
+		return fmt.Errorf("invalid Loom data directory %s (%s): not a directory", dataDir, source)
+	}
+
+	// Probe writability with a temp file; permission bits alone miss ACLs and read-only mounts
+	probe, err := os.CreateTemp(dataDir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("invalid Loom data directory %s (%s): not writable: %w", dataDir, source, err)
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+	return nil
 }
 
 // GetLoomSandboxDir returns the agent execution sandbox directory.
@@ -56,7 +171,8 @@ func GetLoomDataDir() string {
 // This directory is where shell_execute runs commands by default.
 // It is separate from LOOM_DATA_DIR (which stores internal loom data like databases, artifacts, and configs).
 //
-// The returned path is always absolute. Tilde (~) in LOOM_SANDBOX_DIR is expanded to the user's home directory.
+// The returned path is always absolute. Environment variables and a leading tilde (~) in
+// LOOM_SANDBOX_DIR are expanded as for LOOM_DATA_DIR.
 //
 // Examples:
 //
@@ -83,14 +199,17 @@ func GetLoomSubDir(subdir string) string {
 	return filepath.Join(GetLoomDataDir(), subdir)
 }
 
-// expandPath expands ~ and resolves to absolute path
+// expandPath expands $VAR and ${VAR} references, then a leading ~ (alone or
+// followed by /), and resolves to an absolute path. Unset variables expand to "".
 func expandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		homeDir, err := os.UserHomeDir()
+	path = os.ExpandEnv(path)
+
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := userHomeDir()
 		if err != nil {
 			return path // Return as-is if we can't get home dir
 		}
-		return filepath.Join(homeDir, path[2:])
+		return filepath.Join(homeDir, strings.TrimPrefix(path[1:], "/"))
 	}
 
 	// Make path absolute
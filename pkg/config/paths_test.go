@@ -6,9 +6,11 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,13 +23,16 @@ func TestGetLoomDataDir(t *testing.T) {
 	defer func() {
 		if originalEnv != "" {
 			os.Setenv("LOOM_DATA_DIR", originalEnv)
+			ResetDataDirCache()
 		} else {
 			os.Unsetenv("LOOM_DATA_DIR")
+			ResetDataDirCache()
 		}
 	}()
 
 	t.Run("default to ~/.loom", func(t *testing.T) {
 		os.Unsetenv("LOOM_DATA_DIR")
+		ResetDataDirCache()
 
 		dataDir := GetLoomDataDir()
 
@@ -40,6 +45,7 @@ func TestGetLoomDataDir(t *testing.T) {
 	t.Run("use LOOM_DATA_DIR when set", func(t *testing.T) {
 		customDir := "/custom/loom/data"
 		os.Setenv("LOOM_DATA_DIR", customDir)
+		ResetDataDirCache()
 
 		dataDir := GetLoomDataDir()
 
@@ -48,6 +54,7 @@ func TestGetLoomDataDir(t *testing.T) {
 
 	t.Run("expand ~ in LOOM_DATA_DIR", func(t *testing.T) {
 		os.Setenv("LOOM_DATA_DIR", "~/custom/.loom")
+		ResetDataDirCache()
 
 		dataDir := GetLoomDataDir()
 
@@ -59,6 +66,7 @@ func TestGetLoomDataDir(t *testing.T) {
 
 	t.Run("make relative path absolute in LOOM_DATA_DIR", func(t *testing.T) {
 		os.Setenv("LOOM_DATA_DIR", "relative/path")
+		ResetDataDirCache()
 
 		dataDir := GetLoomDataDir()
 
@@ -68,19 +76,48 @@ func TestGetLoomDataDir(t *testing.T) {
 	})
 }
 
+func TestGetLoomDataDirE_NoHomeDir(t *testing.T) {
+	originalHome := userHomeDir
+	defer func() { userHomeDir = originalHome }()
+	userHomeDir = func() (string, error) {
+		return "", errors.New("$HOME is not defined")
+	}
+	t.Setenv("LOOM_DATA_DIR", "")
+	ResetDataDirCache()
+	defer ResetDataDirCache()
+
+	_, err := GetLoomDataDirE()
+	assert.Error(t, err)
+
+	// GetLoomDataDir falls back to an absolute temp location, never a CWD-relative path
+	dataDir := GetLoomDataDir()
+	assert.Equal(t, filepath.Join(os.TempDir(), "loom"), dataDir)
+	assert.True(t, filepath.IsAbs(dataDir))
+
+	// LOOM_DATA_DIR still wins when set
+	t.Setenv("LOOM_DATA_DIR", "/custom/loom/data")
+	ResetDataDirCache()
+	dataDir, err = GetLoomDataDirE()
+	require.NoError(t, err)
+	assert.Equal(t, "/custom/loom/data", dataDir)
+}
+
 func TestGetLoomSubDir(t *testing.T) {
 	// Save original env var
 	originalEnv := os.Getenv("LOOM_DATA_DIR")
 	defer func() {
 		if originalEnv != "" {
 			os.Setenv("LOOM_DATA_DIR", originalEnv)
+			ResetDataDirCache()
 		} else {
 			os.Unsetenv("LOOM_DATA_DIR")
+			ResetDataDirCache()
 		}
 	}()
 
 	t.Run("return subdirectory path", func(t *testing.T) {
 		os.Unsetenv("LOOM_DATA_DIR")
+		ResetDataDirCache()
 
 		agentsDir := GetLoomSubDir("agents")
 
@@ -93,6 +130,7 @@ func TestGetLoomSubDir(t *testing.T) {
 	t.Run("respect LOOM_DATA_DIR for subdirectories", func(t *testing.T) {
 		customDir := "/custom/loom"
 		os.Setenv("LOOM_DATA_DIR", customDir)
+		ResetDataDirCache()
 
 		patternsDir := GetLoomSubDir("patterns")
 
@@ -115,6 +153,36 @@ func TestExpandPath(t *testing.T) {
 			input:    "~/test/path",
 			expected: filepath.Join(homeDir, "test", "path"),
 		},
+		{
+			name:     "expand bare tilde",
+			input:    "~",
+			expected: homeDir,
+		},
+		{
+			name:     "tilde not followed by slash is a name",
+			input:    "/data/~user",
+			expected: "/data/~user",
+		},
+		{
+			name:     "expand $VAR",
+			input:    "$LOOM_TEST_ROOT/loom",
+			expected: "/srv/data/loom",
+		},
+		{
+			name:     "expand ${VAR}",
+			input:    "${LOOM_TEST_ROOT}/loom",
+			expected: "/srv/data/loom",
+		},
+		{
+			name:     "expand variable holding a tilde path",
+			input:    "${LOOM_TEST_TILDE}/loom",
+			expected: filepath.Join(homeDir, "shared", "loom"),
+		},
+		{
+			name:     "expand $HOME",
+			input:    "$HOME/loom",
+			expected: filepath.Join(os.Getenv("HOME"), "loom"),
+		},
 		{
 			name:     "absolute path unchanged",
 			input:    "/absolute/path",
@@ -127,6 +195,9 @@ func TestExpandPath(t *testing.T) {
 		},
 	}
 
+	t.Setenv("LOOM_TEST_ROOT", "/srv/data")
+	t.Setenv("LOOM_TEST_TILDE", "~/shared")
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := expandPath(tt.input)
@@ -140,3 +211,122 @@ func TestExpandPath(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLoomDataDir_ExpandsEnvironment(t *testing.T) {
+	xdgDataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", xdgDataHome)
+	t.Setenv("LOOM_DATA_DIR", "${XDG_DATA_HOME}/loom")
+	ResetDataDirCache()
+
+	assert.Equal(t, filepath.Join(xdgDataHome, "loom"), GetLoomDataDir())
+}
+
+func TestValidateDataDir(t *testing.T) {
+	t.Run("creates missing directory", func(t *testing.T) {
+		dataDir := filepath.Join(t.TempDir(), "nested", "loom")
+		t.Setenv("LOOM_DATA_DIR", dataDir)
+		ResetDataDirCache()
+
+		require.NoError(t, ValidateDataDir())
+		info, err := os.Stat(dataDir)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+
+		// The writability probe leaves nothing behind
+		entries, err := os.ReadDir(dataDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("rejects a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "loom")
+		require.NoError(t, os.WriteFile(file, nil, 0600))
+		t.Setenv("LOOM_DATA_DIR", file)
+		ResetDataDirCache()
+
+		err := ValidateDataDir()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), file)
+		assert.Contains(t, err.Error(), "LOOM_DATA_DIR=")
+		assert.Contains(t, err.Error(), "not a directory")
+	})
+
+	t.Run("rejects a path under a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "loom")
+		require.NoError(t, os.WriteFile(file, nil, 0600))
+		t.Setenv("LOOM_DATA_DIR", filepath.Join(file, "data"))
+		ResetDataDirCache()
+
+		err := ValidateDataDir()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), filepath.Join(file, "data"))
+	})
+
+	t.Run("rejects unresolvable home directory", func(t *testing.T) {
+		originalHome := userHomeDir
+		defer func() {
+			userHomeDir = originalHome
+			ResetDataDirCache()
+		}()
+		userHomeDir = func() (string, error) {
+			return "", errors.New("$HOME is not defined")
+		}
+
+		// Unset: the default needs the home directory
+		t.Setenv("LOOM_DATA_DIR", "")
+		ResetDataDirCache()
+		err := ValidateDataDir()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "set LOOM_DATA_DIR to override")
+
+		// Set with a tilde that cannot be expanded
+		t.Setenv("LOOM_DATA_DIR", "~/loom")
+		ResetDataDirCache()
+		err = ValidateDataDir()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `LOOM_DATA_DIR="~/loom"`)
+		assert.Contains(t, err.Error(), "absolute path")
+	})
+}
+
+func TestGetLoomDataDir_Cached(t *testing.T) {
+	first := t.TempDir()
+	t.Setenv("LOOM_DATA_DIR", first)
+	ResetDataDirCache()
+	defer ResetDataDirCache()
+
+	assert.Equal(t, first, GetLoomDataDir())
+
+	// Later environment changes are ignored until the cache is reset
+	second := t.TempDir()
+	t.Setenv("LOOM_DATA_DIR", second)
+	assert.Equal(t, first, GetLoomDataDir())
+	assert.Equal(t, filepath.Join(first, "agents"), GetLoomSubDir("agents"))
+
+	ResetDataDirCache()
+	assert.Equal(t, second, GetLoomDataDir())
+}
+
+func TestGetLoomDataDir_Concurrent(t *testing.T) {
+	t.Setenv("LOOM_DATA_DIR", t.TempDir())
+	ResetDataDirCache()
+	defer ResetDataDirCache()
+
+	var wg sync.WaitGroup
+	results := make([]string, 16)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%4 == 0 {
+				ResetDataDirCache()
+			}
+			results[i] = GetLoomDataDir()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, dataDir := range results {
+		assert.Equal(t, results[0], dataDir)
+	}
+}
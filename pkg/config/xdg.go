@@ -0,0 +1,92 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// goos is the operating system used to pick the default data directory. Overridden in tests.
+var goos = runtime.GOOS
+
+// UsesXDGDataDir reports whether the default data directory follows the XDG Base
+// Directory spec. It requires Linux and opting in with LOOM_USE_XDG=true (or 1), so
+// existing ~/.loom installs keep working until they migrate with MigrateToXDGDataDir.
+// macOS and Windows always use ~/.loom. LOOM_DATA_DIR, when set, overrides both.
+func UsesXDGDataDir() bool {
+	if goos != "linux" {
+		return false
+	}
+	optIn, err := strconv.ParseBool(os.Getenv("LOOM_USE_XDG"))
+	return err == nil && optIn
+}
+
+// xdgDataDir returns $XDG_DATA_HOME/loom, or ~/.local/share/loom when XDG_DATA_HOME
+// is unset. Relative XDG_DATA_HOME values are ignored, as the spec requires.
+func xdgDataDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" && filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "loom"), nil
+	}
+	homeDir, err := userHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory for Loom data directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".local", "share", "loom"), nil
+}
+
+// MigrateToXDGDataDir moves an existing ~/.loom to the XDG data directory
+// ($XDG_DATA_HOME/loom or ~/.local/share/loom) and returns the new location.
+// It is a no-op returning "" when there is no ~/.loom, so it is safe to run more
+// than once. It requires UsesXDGDataDir, so migrated data is where Loom looks for it,
+// and fails if the XDG directory already has content rather than merging the two.
+//
+// The move is a rename, so both locations must be on the same filesystem.
+func MigrateToXDGDataDir() (string, error) {
+	if !UsesXDGDataDir() {
+		return "", fmt.Errorf("XDG data directory is not enabled (requires Linux and LOOM_USE_XDG=true)")
+	}
+
+	legacy, err := legacyDataDir()
+	if err != nil {
+		return "", err
+	}
+	target, err := xdgDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(legacy); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("cannot read legacy data directory %s: %w", legacy, err)
+	}
+
+	// An empty target (e.g. created by a run after opting in) is replaced
+	entries, err := os.ReadDir(target)
+	switch {
+	case err == nil && len(entries) > 0:
+		return "", fmt.Errorf("cannot migrate %s: %s already exists and is not empty; merge them manually", legacy, target)
+	case err == nil:
+		if err := os.Remove(target); err != nil {
+			return "", fmt.Errorf("cannot replace empty data directory %s: %w", target, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return "", fmt.Errorf("cannot read data directory %s: %w", target, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return "", fmt.Errorf("cannot create %s: %w", filepath.Dir(target), err)
+	}
+	if err := os.Rename(legacy, target); err != nil {
+		return "", fmt.Errorf("cannot move %s to %s (move it manually if they are on different filesystems): %w", legacy, target, err)
+	}
+	return target, nil
+}
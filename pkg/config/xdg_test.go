@@ -0,0 +1,147 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withXDGEnv points the home directory at a temp dir and sets the OS for data-dir
+// resolution, restoring both afterwards. It returns the fake home directory.
+func withXDGEnv(t *testing.T, targetOS string) string {
+	t.Helper()
+	home := t.TempDir()
+	originalHome, originalGOOS := userHomeDir, goos
+	t.Cleanup(func() { userHomeDir, goos = originalHome, originalGOOS })
+	userHomeDir = func() (string, error) { return home, nil }
+	goos = targetOS
+
+	t.Setenv("LOOM_DATA_DIR", "")
+	t.Setenv("LOOM_USE_XDG", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	return home
+}
+
+func TestResolveDataDir_XDG(t *testing.T) {
+	t.Run("opt-in required", func(t *testing.T) {
+		home := withXDGEnv(t, "linux")
+
+		dataDir, err := resolveDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".loom"), dataDir)
+	})
+
+	t.Run("XDG_DATA_HOME", func(t *testing.T) {
+		withXDGEnv(t, "linux")
+		t.Setenv("LOOM_USE_XDG", "true")
+		t.Setenv("XDG_DATA_HOME", "/xdg/data")
+
+		dataDir, err := resolveDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, "/xdg/data/loom", dataDir)
+	})
+
+	t.Run("default XDG location", func(t *testing.T) {
+		home := withXDGEnv(t, "linux")
+		t.Setenv("LOOM_USE_XDG", "1")
+
+		dataDir, err := resolveDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".local", "share", "loom"), dataDir)
+
+		// Relative XDG_DATA_HOME is invalid per the spec and ignored
+		t.Setenv("XDG_DATA_HOME", "relative/data")
+		dataDir, err = resolveDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".local", "share", "loom"), dataDir)
+	})
+
+	t.Run("macOS and Windows keep ~/.loom", func(t *testing.T) {
+		for _, targetOS := range []string{"darwin", "windows"} {
+			home := withXDGEnv(t, targetOS)
+			t.Setenv("LOOM_USE_XDG", "true")
+			t.Setenv("XDG_DATA_HOME", "/xdg/data")
+
+			dataDir, err := resolveDataDir()
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(home, ".loom"), dataDir, targetOS)
+		}
+	})
+
+	t.Run("LOOM_DATA_DIR wins", func(t *testing.T) {
+		withXDGEnv(t, "linux")
+		t.Setenv("LOOM_USE_XDG", "true")
+		t.Setenv("LOOM_DATA_DIR", "/custom/loom")
+
+		dataDir, err := resolveDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, "/custom/loom", dataDir)
+	})
+}
+
+func TestMigrateToXDGDataDir(t *testing.T) {
+	t.Run("moves ~/.loom", func(t *testing.T) {
+		home := withXDGEnv(t, "linux")
+		t.Setenv("LOOM_USE_XDG", "true")
+		legacy := filepath.Join(home, ".loom")
+		require.NoError(t, os.MkdirAll(filepath.Join(legacy, "agents"), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(legacy, "looms.yaml"), []byte("server: {}\n"), 0600))
+
+		target, err := MigrateToXDGDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".local", "share", "loom"), target)
+		assert.FileExists(t, filepath.Join(target, "looms.yaml"))
+		assert.DirExists(t, filepath.Join(target, "agents"))
+		assert.NoDirExists(t, legacy)
+
+		// Running again is a no-op
+		target, err = MigrateToXDGDataDir()
+		require.NoError(t, err)
+		assert.Empty(t, target)
+	})
+
+	t.Run("replaces an empty XDG directory", func(t *testing.T) {
+		home := withXDGEnv(t, "linux")
+		t.Setenv("LOOM_USE_XDG", "true")
+		xdgHome := filepath.Join(home, "xdg")
+		t.Setenv("XDG_DATA_HOME", xdgHome)
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".loom"), 0750))
+		require.NoError(t, os.MkdirAll(filepath.Join(xdgHome, "loom"), 0750))
+
+		target, err := MigrateToXDGDataDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(xdgHome, "loom"), target)
+	})
+
+	t.Run("refuses to merge", func(t *testing.T) {
+		home := withXDGEnv(t, "linux")
+		t.Setenv("LOOM_USE_XDG", "true")
+		target := filepath.Join(home, ".local", "share", "loom")
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".loom"), 0750))
+		require.NoError(t, os.MkdirAll(target, 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(target, "loom.db"), nil, 0600))
+
+		_, err := MigrateToXDGDataDir()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not empty")
+		assert.DirExists(t, filepath.Join(home, ".loom"), "nothing is moved")
+	})
+
+	t.Run("requires opt-in", func(t *testing.T) {
+		home := withXDGEnv(t, "linux")
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".loom"), 0750))
+
+		_, err := MigrateToXDGDataDir()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "LOOM_USE_XDG")
+		assert.DirExists(t, filepath.Join(home, ".loom"))
+	})
+}
@@ -20,15 +20,18 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
-	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 	"github.com/teradata-labs/loom/pkg/llm"
 	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
 	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/types"
+	"go.uber.org/zap"
 )
 
 // Global rate limiter shared across all Bedrock clients.
@@ -52,6 +55,17 @@ type Client struct {
 	toolNameMap map[string]string
 	// rateLimiter handles request rate limiting to prevent AWS throttling
 	rateLimiter *llm.RateLimiter
+	// useConverse routes Chat through ChatConverse
+	useConverse bool
+	// systemPrompt and stopSequences are the request defaults from Config
+	systemPrompt  string
+	stopSequences []string
+	// maxRetries and retryBackoff control retries of transient AWS errors (see call)
+	maxRetries   int
+	retryBackoff time.Duration
+	logger       *zap.Logger
+	// contextWindow bounds the history sent per request (see trimHistory)
+	contextWindow int
 }
 
 // getOrCreateGlobalRateLimiter returns the singleton rate limiter for all Bedrock clients.
@@ -78,9 +92,23 @@ type Config struct {
 	Profile         string // Optional: AWS profile name from ~/.aws/config
 
 	// Model Configuration
-	ModelID     string  // Default: anthropic.claude-3-5-sonnet-20241022-v2:0
-	MaxTokens   int     // Default: 4096
-	Temperature float64 // Default: 1.0
+	ModelID             string  // Default: DefaultBedrockModelID unless InferenceProfileARN is set
+	InferenceProfileARN string  // Optional: inference profile ARN used in place of ModelID; set at most one
+	MaxTokens           int     // Default: 4096
+	Temperature         float64 // Default: 1.0
+	ContextWindow       int     // Default: 200000; the oldest turns are trimmed to fit, negative disables trimming
+
+	// Request Defaults (overridable per call with ChatWithOptions)
+	SystemPrompt  string   // Optional: system prompt sent ahead of the conversation's system messages
+	StopSequences []string // Optional: sequences that end generation
+
+	// API Selection
+	UseConverseAPI bool // Optional: send Chat through the Converse API instead of InvokeModel (streaming is unaffected)
+
+	// Retry Configuration (throttling, model timeouts, and transient service errors)
+	MaxRetries   int           // Default: 3; negative disables retries
+	RetryBackoff time.Duration // Default: 500ms; doubles per attempt with jitter
+	Logger       *zap.Logger   // Optional: logs each retry at debug level
 
 	// Rate Limiting Configuration
 	RateLimiterConfig llm.RateLimiterConfig // Optional: rate limiting config (enables automatic throttle handling)
@@ -96,10 +124,38 @@ const (
 	DefaultBedrockRegion      = "us-west-2"
 	DefaultBedrockMaxTokens   = 4096
 	DefaultBedrockTemperature = 1.0
+	// DefaultBedrockContextWindow is the context window of the Claude models on Bedrock
+	DefaultBedrockContextWindow = 200000
 )
 
-// NewClient creates a new Bedrock client.
-func NewClient(cfg Config) (*Client, error) {
+// resolveModel applies model and region defaults and validates InferenceProfileARN.
+//
+// ModelID and InferenceProfileARN are mutually exclusive. When the ARN is set it becomes
+// the model identifier sent to Bedrock, and Region defaults to the ARN's region (a
+// different explicit Region is an error, since profiles must be invoked from their own
+// region). When neither is set, ModelID falls back to the environment and then to
+// DefaultBedrockModelID, which is itself a cross-region system inference profile ID
+// (us.* prefix) and needs no ARN.
+func resolveModel(cfg *Config) error {
+	if cfg.InferenceProfileARN != "" {
+		if cfg.ModelID != "" {
+			return fmt.Errorf("set either ModelID or InferenceProfileARN, not both")
+		}
+		profile, err := arn.Parse(cfg.InferenceProfileARN)
+		if err != nil {
+			return fmt.Errorf("invalid inference profile ARN %q: %w", cfg.InferenceProfileARN, err)
+		}
+		if profile.Service != "bedrock" || !strings.Contains(profile.Resource, "inference-profile/") {
+			return fmt.Errorf("invalid inference profile ARN %q: expected a bedrock inference-profile or application-inference-profile resource", cfg.InferenceProfileARN)
+		}
+		if cfg.Region == "" {
+			cfg.Region = profile.Region
+		} else if profile.Region != "" && profile.Region != cfg.Region {
+			return fmt.Errorf("inference profile ARN is in region %s but Region is %s", profile.Region, cfg.Region)
+		}
+		cfg.ModelID = cfg.InferenceProfileARN
+	}
+
 	// Set defaults - check environment variables first
 	if cfg.ModelID == "" {
 		if envModel := os.Getenv("AWS_BEDROCK_MODEL_ID"); envModel != "" {
@@ -119,12 +175,31 @@ func NewClient(cfg Config) (*Client, error) {
 			cfg.Region = DefaultBedrockRegion
 		}
 	}
+	return nil
+}
+
+// NewClient creates a new Bedrock client.
+func NewClient(cfg Config) (*Client, error) {
+	if err := resolveModel(&cfg); err != nil {
+		return nil, err
+	}
 	if cfg.MaxTokens == 0 {
 		cfg.MaxTokens = DefaultBedrockMaxTokens
 	}
 	if cfg.Temperature == 0 {
 		cfg.Temperature = DefaultBedrockTemperature
 	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultBedrockMaxRetries
+	} else if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultBedrockRetryBackoff
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
 
 	// Build AWS config
 	var awsCfg aws.Config
@@ -194,13 +269,20 @@ func NewClient(cfg Config) (*Client, error) {
 	}
 
 	return &Client{
-		client:      bedrockruntime.NewFromConfig(awsCfg),
-		modelID:     cfg.ModelID,
-		region:      cfg.Region,
-		maxTokens:   cfg.MaxTokens,
-		temperature: cfg.Temperature,
-		toolNameMap: make(map[string]string),
-		rateLimiter: rateLimiter,
+		client:        bedrockruntime.NewFromConfig(awsCfg),
+		modelID:       cfg.ModelID,
+		region:        cfg.Region,
+		maxTokens:     cfg.MaxTokens,
+		temperature:   cfg.Temperature,
+		toolNameMap:   make(map[string]string),
+		rateLimiter:   rateLimiter,
+		useConverse:   cfg.UseConverseAPI,
+		systemPrompt:  cfg.SystemPrompt,
+		stopSequences: cfg.StopSequences,
+		maxRetries:    cfg.MaxRetries,
+		retryBackoff:  cfg.RetryBackoff,
+		logger:        cfg.Logger,
+		contextWindow: contextWindowOrDefault(cfg.ContextWindow),
 	}, nil
 }
 
@@ -214,39 +296,42 @@ func (c *Client) Model() string {
 	return c.modelID
 }
 
-// Chat sends a conversation to Bedrock and returns the response.
-func (c *Client) Chat(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (*llmtypes.LLMResponse, error) {
-	// Extract system messages and convert to Bedrock format
-	systemPrompt, apiMessages := c.convertMessages(messages)
+// ChatOptions are per-call request settings for ChatWithOptions.
+// Unset fields fall back to the client's Config.
+type ChatOptions struct {
+	// SystemPrompt is sent ahead of any system messages in the conversation
+	SystemPrompt string
+	// StopSequences end generation when the model produces one (nil keeps the Config default)
+	StopSequences []string
+}
 
-	// Validate that we have at least one message (Bedrock requires non-empty messages array)
-	if len(apiMessages) == 0 {
-		return nil, fmt.Errorf("no valid messages to send (messages may be empty)")
+// withDefaults fills unset options from the client's Config.
+func (c *Client) withDefaults(opts ChatOptions) ChatOptions {
+	if opts.SystemPrompt == "" {
+		opts.SystemPrompt = c.systemPrompt
 	}
-
-	// Build request (Bedrock uses Anthropic's message format)
-	// AWS docs: anthropic_version MUST be "bedrock-2023-05-31" for all Claude models
-	request := map[string]interface{}{
-		"anthropic_version": "bedrock-2023-05-31",
-		"max_tokens":        c.maxTokens,
-		"temperature":       c.temperature,
-		"messages":          apiMessages,
+	if opts.StopSequences == nil {
+		opts.StopSequences = c.stopSequences
 	}
+	return opts
+}
 
-	// Add system prompt if present (Anthropic Messages API requires separate system field)
-	if systemPrompt != "" {
-		request["system"] = systemPrompt
-	}
+// Chat sends a conversation to Bedrock and returns the response.
+// It uses InvokeModel unless the client was configured with UseConverseAPI.
+func (c *Client) Chat(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (*llmtypes.LLMResponse, error) {
+	return c.ChatWithOptions(ctx, messages, tools, ChatOptions{})
+}
 
-	// Add tools if provided
-	if len(tools) > 0 {
-		request["tools"] = c.convertTools(tools)
+// ChatWithOptions is Chat with a per-call system prompt and stop sequences.
+func (c *Client) ChatWithOptions(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool, opts ChatOptions) (*llmtypes.LLMResponse, error) {
+	opts = c.withDefaults(opts)
+	if c.useConverse {
+		return c.chatConverse(ctx, messages, tools, opts)
 	}
 
-	// Marshal request
-	body, err := json.Marshal(request)
+	body, err := c.buildRequestBody(messages, tools, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, err
 	}
 
 	// Debug logging if LOOM_DEBUG_BEDROCK is set
@@ -260,32 +345,18 @@ func (c *Client) Chat(ctx context.Context, messages []llmtypes.Message, tools []
 		fmt.Printf("=== END REQUEST ===\n\n")
 	}
 
-	// Call Bedrock with rate limiting if configured
-	var output *bedrockruntime.InvokeModelOutput
-	if c.rateLimiter != nil {
-		// Use rate limiter with automatic retry on throttling
-		result, err := c.rateLimiter.Do(ctx, func(ctx context.Context) (interface{}, error) {
-			return c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
-				ModelId:     aws.String(c.modelID),
-				Body:        body,
-				ContentType: aws.String("application/json"),
-			})
-		})
-		if err != nil {
-			return nil, fmt.Errorf("bedrock invocation failed: %w", err)
-		}
-		output = result.(*bedrockruntime.InvokeModelOutput)
-	} else {
-		// Direct call without rate limiting
-		output, err = c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+	// Call Bedrock (rate limited if configured, retrying transient errors)
+	result, err := c.call(ctx, "InvokeModel", func(ctx context.Context) (interface{}, error) {
+		return c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
 			ModelId:     aws.String(c.modelID),
 			Body:        body,
 			ContentType: aws.String("application/json"),
 		})
-		if err != nil {
-			return nil, fmt.Errorf("bedrock invocation failed: %w", err)
-		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock invocation failed: %w", err)
 	}
+	output := result.(*bedrockruntime.InvokeModelOutput)
 
 	// Debug logging if LOOM_DEBUG_BEDROCK is set
 	if os.Getenv("LOOM_DEBUG_BEDROCK") == "1" {
@@ -315,17 +386,19 @@ func (c *Client) Chat(ctx context.Context, messages []llmtypes.Message, tools []
 	return llmResp, nil
 }
 
-// chatStreamDisabled is the streaming implementation, currently disabled due to
-// a bug in Bedrock's InvokeModelWithResponseStream API where tool input parameters
-// are not streamed correctly (empty input_json_delta events).
-// Use non-streaming Chat() method instead until this is resolved.
-//
-//nolint:unused // Intentionally disabled until AWS fixes InvokeModelWithResponseStream bug
-func (c *Client) chatStreamDisabled(ctx context.Context, messages []llmtypes.Message,
-	tools []shuttle.Tool, tokenCallback llmtypes.TokenCallback) (*llmtypes.LLMResponse, error) {
+// buildRequestBody marshals messages, tools, and options into an InvokeModel request body.
+// Bedrock uses Anthropic's message format for Claude models.
+func (c *Client) buildRequestBody(messages []llmtypes.Message, tools []shuttle.Tool, opts ChatOptions) ([]byte, error) {
+	messages = trimHistory(messages, c.contextWindow, c.maxTokens, opts.SystemPrompt)
 
-	// 1. Build request body (extract system messages and convert to Bedrock format)
+	// Extract system messages and convert to Bedrock format
 	systemPrompt, apiMessages := c.convertMessages(messages)
+	systemPrompt = joinSystemPrompts(opts.SystemPrompt, systemPrompt)
+
+	// Validate that we have at least one message (Bedrock requires non-empty messages array)
+	if len(apiMessages) == 0 {
+		return nil, fmt.Errorf("no valid messages to send (messages may be empty)")
+	}
 
 	// AWS docs: anthropic_version MUST be "bedrock-2023-05-31" for all Claude models
 	request := map[string]interface{}{
@@ -340,171 +413,58 @@ func (c *Client) chatStreamDisabled(ctx context.Context, messages []llmtypes.Mes
 		request["system"] = systemPrompt
 	}
 
+	if len(opts.StopSequences) > 0 {
+		request["stop_sequences"] = opts.StopSequences
+	}
+
 	// Add tools if provided
 	if len(tools) > 0 {
 		request["tools"] = c.convertTools(tools)
 	}
 
-	// Marshal request
 	body, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
+	return body, nil
+}
 
-	// 2. Use InvokeModelWithResponseStream instead of InvokeModel
-	var output *bedrockruntime.InvokeModelWithResponseStreamOutput
-	if c.rateLimiter != nil {
-		// Use rate limiter with automatic retry on throttling
-		result, err := c.rateLimiter.Do(ctx, func(ctx context.Context) (interface{}, error) {
-			return c.client.InvokeModelWithResponseStream(ctx,
-				&bedrockruntime.InvokeModelWithResponseStreamInput{
-					ModelId:     aws.String(c.modelID),
-					Body:        body,
-					ContentType: aws.String("application/json"),
-				})
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to start stream: %w", err)
-		}
-		output = result.(*bedrockruntime.InvokeModelWithResponseStreamOutput)
-	} else {
-		// Direct call without rate limiting
-		output, err = c.client.InvokeModelWithResponseStream(ctx,
-			&bedrockruntime.InvokeModelWithResponseStreamInput{
-				ModelId:     aws.String(c.modelID),
-				Body:        body,
-				ContentType: aws.String("application/json"),
-			})
-		if err != nil {
-			return nil, fmt.Errorf("failed to start stream: %w", err)
-		}
+// joinSystemPrompts combines the configured system prompt with the conversation's
+// system messages, configured prompt first.
+func joinSystemPrompts(configured, fromMessages string) string {
+	switch {
+	case configured == "":
+		return fromMessages
+	case fromMessages == "":
+		return configured
+	default:
+		return configured + "\n\n" + fromMessages
 	}
+}
 
-	// 3. Process event stream
-	buffer := strings.Builder{}
-	usage := llmtypes.Usage{}
-	var stopReason string
-	tokenCount := 0
-	var toolCalls []llmtypes.ToolCall
-	// Track tool input JSON as it streams in (indexed by content block index)
-	toolInputBuffers := make(map[int]*strings.Builder)
-
-	for event := range output.GetStream().Events() {
-		switch e := event.(type) {
-		case *bedrocktypes.ResponseStreamMemberChunk:
-			// Parse chunk (Bedrock sends JSON chunks)
-			var chunk bedrockStreamChunk
-			if err := json.Unmarshal(e.Value.Bytes, &chunk); err != nil {
-				// Skip malformed chunks but continue processing
-				continue
-			}
-
-			// Handle content delta (text streaming)
-			if chunk.Type == "content_block_delta" && chunk.Delta.Text != "" {
-				token := chunk.Delta.Text
-				buffer.WriteString(token)
-				tokenCount++
-
-				// Call token callback (non-blocking)
-				if tokenCallback != nil {
-					tokenCallback(token)
-				}
-
-				// Check context cancellation
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				default:
-				}
-			}
-
-			// Handle content block start (for tool use)
-			if chunk.Type == "content_block_start" && chunk.ContentBlock.Type == "tool_use" {
-				// Start tracking a new tool call with empty input (will be populated from deltas)
-				toolCall := llmtypes.ToolCall{
-					ID:    chunk.ContentBlock.ID,
-					Name:  chunk.ContentBlock.Name,
-					Input: make(map[string]interface{}), // Initialize to empty map (never nil)
-				}
-				toolCalls = append(toolCalls, toolCall)
-				// Initialize buffer for this tool's input JSON
-				toolInputBuffers[chunk.Index] = &strings.Builder{}
-			}
-
-			// Handle tool input delta (accumulate JSON for tool parameters)
-			if chunk.Type == "content_block_delta" && chunk.Delta.Type == "input_json_delta" {
-				// Accumulate the JSON delta
-				if buf, exists := toolInputBuffers[chunk.Index]; exists {
-					buf.WriteString(chunk.Delta.Text)
-				}
-			}
-
-			// Handle content block stop (finalize tool input)
-			if chunk.Type == "content_block_stop" {
-				// If we have accumulated input JSON for this block, parse it
-				if buf, exists := toolInputBuffers[chunk.Index]; exists && buf.Len() > 0 {
-					var input map[string]interface{}
-					if err := json.Unmarshal([]byte(buf.String()), &input); err == nil {
-						// Update the tool call with parsed input
-						if chunk.Index < len(toolCalls) {
-							toolCalls[chunk.Index].Input = input
-						}
-					}
-					// Clean up buffer
-					delete(toolInputBuffers, chunk.Index)
-				}
-			}
-
-			// Handle message stop
-			if chunk.Type == "message_stop" {
-				stopReason = chunk.StopReason
-			}
-
-			// Handle usage data (comes in message_delta events)
-			if chunk.Type == "message_delta" && chunk.Usage != nil {
-				usage.OutputTokens = chunk.Usage.OutputTokens
-			}
-
-			// Handle usage data (alternative: in message_stop)
-			if chunk.Type == "message_stop" && chunk.Usage != nil {
-				usage.InputTokens = chunk.Usage.InputTokens
-				usage.OutputTokens = chunk.Usage.OutputTokens
-			}
-		}
+// contextWindowOrDefault applies DefaultBedrockContextWindow to an unset ContextWindow.
+func contextWindowOrDefault(contextWindow int) int {
+	if contextWindow == 0 {
+		return DefaultBedrockContextWindow
 	}
+	return contextWindow
+}
 
-	// 4. Build final response
-	// If output tokens not set by stream, use our token count
-	if usage.OutputTokens == 0 {
-		usage.OutputTokens = tokenCount
+// trimHistory drops the oldest conversation turns so the messages, the configured
+// system prompt, and maxTokens of output fit in contextWindow. System messages and
+// the latest user turn are always kept. A non-positive contextWindow disables trimming.
+func trimHistory(messages []llmtypes.Message, contextWindow, maxTokens int, systemPrompt string) []llmtypes.Message {
+	if contextWindow <= 0 {
+		return messages
 	}
-	usage.TotalTokens = usage.InputTokens + usage.OutputTokens
-	usage.CostUSD = c.calculateCost(usage.InputTokens, usage.OutputTokens)
-
-	// Record token usage for rate limiter metrics
-	if c.rateLimiter != nil {
-		totalTokens := int64(usage.InputTokens + usage.OutputTokens)
-		c.rateLimiter.RecordTokenUsage(totalTokens)
+	budget := contextWindow - maxTokens
+	if systemPrompt != "" {
+		budget -= types.EstimateMessageTokens(types.Message{Role: "system", Content: systemPrompt})
 	}
-
-	// Map sanitized tool names back to original names
-	for i := range toolCalls {
-		if originalName, exists := c.toolNameMap[toolCalls[i].Name]; exists {
-			toolCalls[i].Name = originalName
-		}
+	if budget <= 0 {
+		return messages
 	}
-
-	return &llmtypes.LLMResponse{
-		Content:    buffer.String(),
-		StopReason: stopReason,
-		Usage:      usage,
-		ToolCalls:  toolCalls,
-		Metadata: map[string]interface{}{
-			"model":       c.modelID,
-			"stop_reason": stopReason,
-			"streaming":   true,
-		},
-	}, nil
+	return types.TrimMessages(messages, budget, true)
 }
 
 // convertMessages converts agent messages to Bedrock/Anthropic format.
@@ -818,18 +778,16 @@ type bedrockStreamChunk struct {
 	Type  string `json:"type"` // message_start, content_block_start, content_block_delta, content_block_stop, message_delta, message_stop
 	Index int    `json:"index,omitempty"`
 
+	// For message_start events (carries input token usage)
+	Message *struct {
+		Usage bedrockUsage `json:"usage"`
+	} `json:"message,omitempty"`
+
 	// For content_block_start events
-	ContentBlock struct {
-		Type string `json:"type"` // text, tool_use
-		ID   string `json:"id,omitempty"`
-		Name string `json:"name,omitempty"`
-	} `json:"content_block,omitempty"`
-
-	// For content_block_delta events
-	Delta struct {
-		Type string `json:"type"`           // text_delta, input_json_delta
-		Text string `json:"text,omitempty"` // For text_delta and input_json_delta (JSON string chunks)
-	} `json:"delta,omitempty"`
+	ContentBlock bedrockStreamContentBlock `json:"content_block,omitempty"`
+
+	// For content_block_delta and message_delta events
+	Delta bedrockStreamDelta `json:"delta,omitempty"`
 
 	// For message_stop events
 	StopReason string `json:"stop_reason,omitempty"`
@@ -839,12 +797,32 @@ type bedrockStreamChunk struct {
 		InputTokens  int `json:"input_tokens"`
 		OutputTokens int `json:"output_tokens"`
 	} `json:"usage,omitempty"`
+
+	// Bedrock appends invocation metrics to the message_stop event
+	InvocationMetrics *struct {
+		InputTokenCount  int `json:"inputTokenCount"`
+		OutputTokenCount int `json:"outputTokenCount"`
+	} `json:"amazon-bedrock-invocationMetrics,omitempty"`
 }
 
-// Bedrock Client implements LLMProvider (non-streaming only).
-// Streaming is DISABLED due to bugs in ConverseStream API where tool schemas
-// are not properly serialized via document.NewLazyDocument (all tool inputs are empty {}).
-// The legacy InvokeModel API works correctly, so we use that until AWS fixes the SDK.
-var _ llmtypes.LLMProvider = (*Client)(nil)
+// bedrockStreamContentBlock is the content block announced by content_block_start.
+type bedrockStreamContentBlock struct {
+	Type string `json:"type"` // text, tool_use
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
 
-// var _ llmtypes.StreamingLLMProvider = (*Client)(nil)  // DISABLED
+// bedrockStreamDelta is the delta of a content_block_delta or message_delta event.
+type bedrockStreamDelta struct {
+	Type        string `json:"type"`                   // text_delta, input_json_delta
+	Text        string `json:"text,omitempty"`         // For text_delta
+	PartialJSON string `json:"partial_json,omitempty"` // For input_json_delta (JSON string chunks)
+	StopReason  string `json:"stop_reason,omitempty"`  // For message_delta
+}
+
+// Bedrock Client implements LLMProvider and StreamingLLMProvider.
+// Both use the InvokeModel API; streaming goes through InvokeModelWithResponseStream.
+var (
+	_ llmtypes.LLMProvider          = (*Client)(nil)
+	_ llmtypes.StreamingLLMProvider = (*Client)(nil)
+)
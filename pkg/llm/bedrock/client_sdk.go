@@ -18,7 +18,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 
 	anthropic "github.com/anthropics/anthropic-sdk-go"
@@ -41,28 +40,17 @@ type SDKClient struct {
 	temperature float64
 	rateLimiter *llm.RateLimiter
 	toolNameMap map[string]string // sanitized name → original name
+	// systemPrompt and stopSequences are the request defaults from Config
+	systemPrompt  string
+	stopSequences []string
+	// contextWindow bounds the history sent per request (see trimHistory)
+	contextWindow int
 }
 
 // NewSDKClient creates a new Bedrock client using the Anthropic SDK.
 func NewSDKClient(cfg Config) (*SDKClient, error) {
-	// Set defaults - check environment variables first
-	if cfg.ModelID == "" {
-		if envModel := os.Getenv("AWS_BEDROCK_MODEL_ID"); envModel != "" {
-			cfg.ModelID = envModel
-		} else if envModel := os.Getenv("LOOM_LLM_BEDROCK_MODEL_ID"); envModel != "" {
-			cfg.ModelID = envModel
-		} else {
-			cfg.ModelID = DefaultBedrockModelID
-		}
-	}
-	if cfg.Region == "" {
-		if envRegion := os.Getenv("AWS_DEFAULT_REGION"); envRegion != "" {
-			cfg.Region = envRegion
-		} else if envRegion := os.Getenv("LOOM_LLM_BEDROCK_REGION"); envRegion != "" {
-			cfg.Region = envRegion
-		} else {
-			cfg.Region = DefaultBedrockRegion
-		}
+	if err := resolveModel(&cfg); err != nil {
+		return nil, err
 	}
 	if cfg.MaxTokens == 0 {
 		cfg.MaxTokens = DefaultBedrockMaxTokens
@@ -142,12 +130,15 @@ func NewSDKClient(cfg Config) (*SDKClient, error) {
 	)
 
 	return &SDKClient{
-		client:      client,
-		modelID:     cfg.ModelID,
-		region:      cfg.Region,
-		maxTokens:   int64(cfg.MaxTokens),
-		temperature: cfg.Temperature,
-		rateLimiter: rateLimiter,
+		client:        client,
+		modelID:       cfg.ModelID,
+		region:        cfg.Region,
+		maxTokens:     int64(cfg.MaxTokens),
+		temperature:   cfg.Temperature,
+		rateLimiter:   rateLimiter,
+		systemPrompt:  cfg.SystemPrompt,
+		stopSequences: cfg.StopSequences,
+		contextWindow: contextWindowOrDefault(cfg.ContextWindow),
 	}, nil
 }
 
@@ -164,6 +155,7 @@ func (c *SDKClient) Model() string {
 // Chat sends a conversation to Bedrock using the Anthropic SDK and returns the response.
 func (c *SDKClient) Chat(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (*llmtypes.LLMResponse, error) {
 	// Convert messages to Anthropic SDK format
+	messages = trimHistory(messages, c.contextWindow, int(c.maxTokens), c.systemPrompt)
 	systemPrompt, sdkMessages := c.convertMessagesToSDK(messages)
 
 	// Validate that we have at least one message
@@ -180,11 +172,14 @@ func (c *SDKClient) Chat(ctx context.Context, messages []llmtypes.Message, tools
 	}
 
 	// Add system prompt if present
-	if systemPrompt != "" {
+	if systemPrompt = joinSystemPrompts(c.systemPrompt, systemPrompt); systemPrompt != "" {
 		params.System = []anthropic.TextBlockParam{
 			{Text: systemPrompt},
 		}
 	}
+	if len(c.stopSequences) > 0 {
+		params.StopSequences = c.stopSequences
+	}
 
 	// Add tools if provided
 	if len(tools) > 0 {
@@ -424,6 +419,7 @@ func (c *SDKClient) ChatStream(ctx context.Context, messages []llmtypes.Message,
 	tokenCallback llmtypes.TokenCallback) (*llmtypes.LLMResponse, error) {
 
 	// Convert messages to SDK format
+	messages = trimHistory(messages, c.contextWindow, int(c.maxTokens), c.systemPrompt)
 	systemPrompt, sdkMessages := c.convertMessagesToSDK(messages)
 
 	// Validate that we have at least one message
@@ -440,11 +436,14 @@ func (c *SDKClient) ChatStream(ctx context.Context, messages []llmtypes.Message,
 	}
 
 	// Add system prompt if present
-	if systemPrompt != "" {
+	if systemPrompt = joinSystemPrompts(c.systemPrompt, systemPrompt); systemPrompt != "" {
 		params.System = []anthropic.TextBlockParam{
 			{Text: systemPrompt},
 		}
 	}
+	if len(c.stopSequences) > 0 {
+		params.StopSequences = c.stopSequences
+	}
 
 	// Add tools if provided
 	if len(tools) > 0 {
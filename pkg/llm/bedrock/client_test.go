@@ -16,6 +16,7 @@ package bedrock
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -682,9 +683,77 @@ func TestConfig_Defaults(t *testing.T) {
 	}
 }
 
+func TestResolveModel_InferenceProfileARN(t *testing.T) {
+	for _, env := range []string{"AWS_BEDROCK_MODEL_ID", "LOOM_LLM_BEDROCK_MODEL_ID", "AWS_DEFAULT_REGION", "LOOM_LLM_BEDROCK_REGION"} {
+		t.Setenv(env, "")
+	}
+	const profileARN = "arn:aws:bedrock:us-east-1:123456789012:inference-profile/us.anthropic.claude-opus-4-1-20250805-v1:0"
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		wantModel  string
+		wantRegion string
+		wantErr    string
+	}{
+		{
+			name:       "defaults",
+			cfg:        Config{},
+			wantModel:  DefaultBedrockModelID,
+			wantRegion: DefaultBedrockRegion,
+		},
+		{
+			name:       "profile ARN replaces model and sets region",
+			cfg:        Config{InferenceProfileARN: profileARN},
+			wantModel:  profileARN,
+			wantRegion: "us-east-1",
+		},
+		{
+			name:       "application inference profile in matching region",
+			cfg:        Config{Region: "eu-west-1", InferenceProfileARN: "arn:aws:bedrock:eu-west-1:123456789012:application-inference-profile/abc123"},
+			wantModel:  "arn:aws:bedrock:eu-west-1:123456789012:application-inference-profile/abc123",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name:    "both model and profile",
+			cfg:     Config{ModelID: "anthropic.claude-3-5-sonnet-20241022-v2:0", InferenceProfileARN: profileARN},
+			wantErr: "not both",
+		},
+		{
+			name:    "region mismatch",
+			cfg:     Config{Region: "us-west-2", InferenceProfileARN: profileARN},
+			wantErr: "region us-east-1 but Region is us-west-2",
+		},
+		{
+			name:    "not an ARN",
+			cfg:     Config{InferenceProfileARN: "us.anthropic.claude-opus-4-1-20250805-v1:0"},
+			wantErr: "invalid inference profile ARN",
+		},
+		{
+			name:    "not an inference profile",
+			cfg:     Config{InferenceProfileARN: "arn:aws:bedrock:us-east-1::foundation-model/anthropic.claude-3-5-sonnet-20241022-v2:0"},
+			wantErr: "expected a bedrock inference-profile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			err := resolveModel(&cfg)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantModel, cfg.ModelID)
+			assert.Equal(t, tt.wantRegion, cfg.Region)
+		})
+	}
+}
+
 func TestClient_ImplementsLLMProviderInterface(t *testing.T) {
 	// Verify that Client implements both LLMProvider and StreamingLLMProvider interfaces
-	// Streaming now uses ConverseStream API (fixes InvokeModelWithResponseStream tool bugs)
 	client := &Client{
 		modelID:     "anthropic.claude-3-5-sonnet-20241022-v2:0",
 		region:      "us-east-1",
@@ -695,7 +764,7 @@ func TestClient_ImplementsLLMProviderInterface(t *testing.T) {
 
 	// Type assertion to verify interface implementation
 	var _ types.LLMProvider = client
-	assert.True(t, types.SupportsStreaming(client), "Bedrock client should support streaming via ConverseStream")
+	assert.True(t, types.SupportsStreaming(client), "Bedrock client should support streaming via InvokeModelWithResponseStream")
 }
 
 func TestBedrockStreamChunk_Unmarshal(t *testing.T) {
@@ -710,10 +779,7 @@ func TestBedrockStreamChunk_Unmarshal(t *testing.T) {
 			expected: bedrockStreamChunk{
 				Type:  "content_block_delta",
 				Index: 0,
-				Delta: struct {
-					Type string `json:"type"`
-					Text string `json:"text,omitempty"`
-				}{
+				Delta: bedrockStreamDelta{
 					Type: "text_delta",
 					Text: "Hello",
 				},
@@ -740,11 +806,7 @@ func TestBedrockStreamChunk_Unmarshal(t *testing.T) {
 			expected: bedrockStreamChunk{
 				Type:  "content_block_start",
 				Index: 0,
-				ContentBlock: struct {
-					Type string `json:"type"`
-					ID   string `json:"id,omitempty"`
-					Name string `json:"name,omitempty"`
-				}{
+				ContentBlock: bedrockStreamContentBlock{
 					Type: "tool_use",
 					ID:   "toolu_123",
 					Name: "get_weather",
@@ -837,3 +899,71 @@ func TestBedrockStreamChunk_ToolInputDelta_Empty(t *testing.T) {
 	assert.NotNil(t, input)
 	assert.Empty(t, input)
 }
+
+func TestClient_BuildRequestBody_Options(t *testing.T) {
+	client := &Client{
+		modelID:       "test-model",
+		maxTokens:     1024,
+		temperature:   0.5,
+		toolNameMap:   make(map[string]string),
+		systemPrompt:  "Config prompt",
+		stopSequences: []string{"</answer>"},
+	}
+	messages := []types.Message{
+		{Role: "system", Content: "Conversation prompt"},
+		{Role: "user", Content: "Classify this"},
+	}
+
+	decode := func(opts ChatOptions) map[string]interface{} {
+		body, err := client.buildRequestBody(messages, nil, client.withDefaults(opts))
+		require.NoError(t, err)
+		var request map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		return request
+	}
+
+	// Config defaults come first and apply when the call sets nothing
+	request := decode(ChatOptions{})
+	assert.Equal(t, "Config prompt\n\nConversation prompt", request["system"])
+	assert.Equal(t, []interface{}{"</answer>"}, request["stop_sequences"])
+
+	// Per-call options override the defaults; an empty slice disables stop sequences
+	request = decode(ChatOptions{SystemPrompt: "Respond with JSON only", StopSequences: []string{}})
+	assert.Equal(t, "Respond with JSON only\n\nConversation prompt", request["system"])
+	assert.NotContains(t, request, "stop_sequences")
+}
+
+func TestClient_BuildRequestBody_TrimsHistory(t *testing.T) {
+	client := &Client{modelID: "test-model", maxTokens: 100, toolNameMap: make(map[string]string), contextWindow: 400}
+	long := strings.Repeat("x", 800) // ~200 tokens
+	messages := []types.Message{
+		{Role: "system", Content: "You are helpful."},
+		{Role: "user", Content: long},
+		{Role: "assistant", Content: long},
+		{Role: "user", Content: "Latest question"},
+	}
+
+	body, err := client.buildRequestBody(messages, nil, client.withDefaults(ChatOptions{}))
+	require.NoError(t, err)
+	var request map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &request))
+
+	// The oldest turn no longer fits; the system prompt and latest question remain
+	assert.Equal(t, "You are helpful.", request["system"])
+	apiMessages := request["messages"].([]interface{})
+	require.Len(t, apiMessages, 1)
+	assert.Equal(t, "user", apiMessages[0].(map[string]interface{})["role"])
+
+	// Trimming is disabled with a non-positive context window
+	client.contextWindow = -1
+	body, err = client.buildRequestBody(messages, nil, client.withDefaults(ChatOptions{}))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Len(t, request["messages"], 3)
+}
+
+func TestContextWindowOrDefault(t *testing.T) {
+	assert.Equal(t, DefaultBedrockContextWindow, contextWindowOrDefault(0))
+	assert.Equal(t, 32000, contextWindowOrDefault(32000))
+	assert.Equal(t, -1, contextWindowOrDefault(-1))
+}
@@ -31,32 +31,25 @@ import (
 // This is the modern, unified API that properly handles tool use.
 // This method is used when streaming is not needed or disabled.
 func (c *Client) ChatConverse(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (*llmtypes.LLMResponse, error) {
-	startTime := time.Now()
-
-	// Convert messages and tools to Converse API format (reuses same converter as streaming)
-	systemBlocks, converseMessages := c.convertMessagesToConverse(messages)
+	return c.chatConverse(ctx, messages, tools, c.withDefaults(ChatOptions{}))
+}
 
-	// Validate that we have at least one message
-	if len(converseMessages) == 0 {
-		return nil, fmt.Errorf("no valid messages to send (messages may be empty)")
-	}
+// chatConverse sends a Converse request, mapping opts to the system and stopSequences fields.
+func (c *Client) chatConverse(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool, opts ChatOptions) (*llmtypes.LLMResponse, error) {
+	startTime := time.Now()
 
-	// Build Converse input
-	input := &bedrockruntime.ConverseInput{
-		ModelId:  aws.String(c.modelID),
-		Messages: converseMessages,
-		InferenceConfig: &bedrocktypes.InferenceConfiguration{
-			MaxTokens:   aws.Int32(int32(c.maxTokens)),
-			Temperature: aws.Float32(float32(c.temperature)),
-		},
+	input, err := c.buildConverseInput(messages, tools, opts)
+	if err != nil {
+		return nil, err
 	}
+	converseMessages := input.Messages
 
 	// Debug logging if LOOM_DEBUG_BEDROCK is set
 	if os.Getenv("LOOM_DEBUG_BEDROCK") == "1" {
 		debugJSON, _ := json.MarshalIndent(map[string]interface{}{
 			"model_id":         c.modelID,
 			"num_messages":     len(converseMessages),
-			"num_system":       len(systemBlocks),
+			"num_system":       len(input.System),
 			"has_tools":        len(tools) > 0,
 			"inference_config": input.InferenceConfig,
 		}, "", "  ")
@@ -92,34 +85,14 @@ func (c *Client) ChatConverse(ctx context.Context, messages []llmtypes.Message,
 		fmt.Printf("=== END REQUEST ===\n\n")
 	}
 
-	// Add system prompts if present
-	if len(systemBlocks) > 0 {
-		input.System = systemBlocks
-	}
-
-	// Add tools if provided
-	if len(tools) > 0 {
-		input.ToolConfig = c.convertToolsToConverse(tools)
-	}
-
-	// Execute Converse with rate limiting if configured
-	var output *bedrockruntime.ConverseOutput
-	var err error
-
-	if c.rateLimiter != nil {
-		result, err := c.rateLimiter.Do(ctx, func(ctx context.Context) (interface{}, error) {
-			return c.client.Converse(ctx, input)
-		})
-		if err != nil {
-			return nil, fmt.Errorf("bedrock converse failed: %w", err)
-		}
-		output = result.(*bedrockruntime.ConverseOutput)
-	} else {
-		output, err = c.client.Converse(ctx, input)
-		if err != nil {
-			return nil, fmt.Errorf("bedrock converse failed: %w", err)
-		}
+	// Execute Converse (rate limited if configured, retrying transient errors)
+	result, err := c.call(ctx, "Converse", func(ctx context.Context) (interface{}, error) {
+		return c.client.Converse(ctx, input)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock converse failed: %w", err)
 	}
+	output := result.(*bedrockruntime.ConverseOutput)
 
 	// Debug logging
 	if os.Getenv("LOOM_DEBUG_BEDROCK") == "1" {
@@ -148,26 +121,10 @@ func (c *Client) ChatConverse(ctx context.Context, messages []llmtypes.Message,
 					contentText += b.Value
 
 				case *bedrocktypes.ContentBlockMemberToolUse:
-					// Extract tool call
-					toolCall := llmtypes.ToolCall{
-						ID:    aws.ToString(b.Value.ToolUseId),
-						Name:  aws.ToString(b.Value.Name),
-						Input: make(map[string]interface{}),
-					}
-
-					// Map sanitized name back to original name
-					if originalName, found := c.toolNameMap[toolCall.Name]; found {
-						toolCall.Name = originalName
-					}
-
-					// Convert document.Interface to map[string]interface{}
-					if b.Value.Input != nil {
-						inputBytes, err := json.Marshal(b.Value.Input)
-						if err == nil {
-							_ = json.Unmarshal(inputBytes, &toolCall.Input)
-						}
+					toolCall, err := c.convertConverseToolUse(b.Value)
+					if err != nil {
+						return nil, err
 					}
-
 					toolCalls = append(toolCalls, toolCall)
 				}
 			}
@@ -203,3 +160,75 @@ func (c *Client) ChatConverse(ctx context.Context, messages []llmtypes.Message,
 
 	return response, nil
 }
+
+// buildConverseInput converts messages, tools, and options into a Converse request.
+func (c *Client) buildConverseInput(messages []llmtypes.Message, tools []shuttle.Tool, opts ChatOptions) (*bedrockruntime.ConverseInput, error) {
+	messages = trimHistory(messages, c.contextWindow, c.maxTokens, opts.SystemPrompt)
+
+	// Convert messages and tools to Converse API format (reuses same converter as streaming)
+	systemBlocks, converseMessages := c.convertMessagesToConverse(messages)
+	if opts.SystemPrompt != "" {
+		systemBlocks = append([]bedrocktypes.SystemContentBlock{
+			&bedrocktypes.SystemContentBlockMemberText{Value: opts.SystemPrompt},
+		}, systemBlocks...)
+	}
+
+	// Validate that we have at least one message
+	if len(converseMessages) == 0 {
+		return nil, fmt.Errorf("no valid messages to send (messages may be empty)")
+	}
+
+	// Build Converse input
+	input := &bedrockruntime.ConverseInput{
+		ModelId:  aws.String(c.modelID),
+		Messages: converseMessages,
+		InferenceConfig: &bedrocktypes.InferenceConfiguration{
+			MaxTokens:     aws.Int32(int32(c.maxTokens)),
+			Temperature:   aws.Float32(float32(c.temperature)),
+			StopSequences: opts.StopSequences,
+		},
+	}
+
+	// Add system prompts if present
+	if len(systemBlocks) > 0 {
+		input.System = systemBlocks
+	}
+
+	// Add tools if provided
+	if len(tools) > 0 {
+		input.ToolConfig = c.convertToolsToConverse(tools)
+	}
+
+	return input, nil
+}
+
+// convertConverseToolUse converts a Converse toolUse block into a tool call.
+// Tool input arrives as a smithy document, which must be encoded with
+// MarshalSmithyDocument; json.Marshal on the document yields {} and drops every parameter.
+func (c *Client) convertConverseToolUse(block bedrocktypes.ToolUseBlock) (llmtypes.ToolCall, error) {
+	toolCall := llmtypes.ToolCall{
+		ID:    aws.ToString(block.ToolUseId),
+		Name:  aws.ToString(block.Name),
+		Input: make(map[string]interface{}),
+	}
+
+	// Map sanitized name back to original name
+	if originalName, found := c.toolNameMap[toolCall.Name]; found {
+		toolCall.Name = originalName
+	}
+
+	if block.Input != nil {
+		raw, err := block.Input.MarshalSmithyDocument()
+		if err != nil {
+			return llmtypes.ToolCall{}, fmt.Errorf("failed to decode input for tool %s: %w", toolCall.Name, err)
+		}
+		var input map[string]interface{}
+		if err := json.Unmarshal(raw, &input); err != nil {
+			return llmtypes.ToolCall{}, fmt.Errorf("failed to decode input for tool %s: %w", toolCall.Name, err)
+		}
+		if input != nil {
+			toolCall.Input = input
+		}
+	}
+	return toolCall, nil
+}
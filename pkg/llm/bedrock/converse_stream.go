@@ -14,7 +14,6 @@
 package bedrock
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -27,15 +26,6 @@ import (
 	"github.com/teradata-labs/loom/pkg/shuttle"
 )
 
-// ChatStream is DISABLED due to bugs with document.NewLazyDocument causing empty tool inputs.
-// The ConverseStream API cannot properly serialize tool schemas, resulting in all tool calls
-// having empty parameters ({}). Use the non-streaming Chat() method instead.
-func (c *Client) ChatStream(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool, tokenCallback llmtypes.TokenCallback) (*llmtypes.LLMResponse, error) {
-	// DISABLED: Fall back to non-streaming
-	// The ConverseStream API has issues with tool parameter serialization
-	return c.Chat(ctx, messages, tools)
-}
-
 // convertMessagesToConverse converts internal messages to Bedrock Converse API format.
 // CRITICAL: AWS Bedrock requires all tool results from the same turn to be in a single user message.
 // We aggregate consecutive tool messages into one message with multiple tool_result blocks.
@@ -162,10 +152,11 @@ func (c *Client) convertMessagesToConverse(messages []llmtypes.Message) ([]bedro
 			// Add this tool result to pending results (will be flushed when we see next non-tool message)
 			var toolResultContent bedrocktypes.ToolResultContentBlock
 
-			// Try to parse content as JSON for structured results
-			var contentData interface{}
-			if err := json.Unmarshal([]byte(msg.Content), &contentData); err == nil {
-				// Content is valid JSON - use JSON block
+			// Try to parse content as a JSON object for structured results
+			// (Converse only accepts objects in JSON tool result blocks)
+			var contentData map[string]interface{}
+			if err := json.Unmarshal([]byte(msg.Content), &contentData); err == nil && contentData != nil {
+				// Content is a JSON object - use JSON block
 				toolResultDoc := document.NewLazyDocument(contentData)
 				toolResultContent = &bedrocktypes.ToolResultContentBlockMemberJson{
 					Value: toolResultDoc,
@@ -177,14 +168,20 @@ func (c *Client) convertMessagesToConverse(messages []llmtypes.Message) ([]bedro
 				}
 			}
 
+			resultBlock := bedrocktypes.ToolResultBlock{
+				ToolUseId: aws.String(msg.ToolUseID),
+				Content: []bedrocktypes.ToolResultContentBlock{
+					toolResultContent,
+				},
+			}
+			// Tell the model the tool failed so it can recover instead of trusting the output
+			if msg.ToolResult != nil && !msg.ToolResult.Success {
+				resultBlock.Status = bedrocktypes.ToolResultStatusError
+			}
+
 			// Add to pending tool results (will be combined into one message)
 			pendingToolResults = append(pendingToolResults, &bedrocktypes.ContentBlockMemberToolResult{
-				Value: bedrocktypes.ToolResultBlock{
-					ToolUseId: aws.String(msg.ToolUseID),
-					Content: []bedrocktypes.ToolResultContentBlock{
-						toolResultContent,
-					},
-				},
+				Value: resultBlock,
 			})
 		}
 	}
@@ -209,32 +206,29 @@ func (c *Client) convertToolsToConverse(tools []shuttle.Tool) *bedrocktypes.Tool
 		// Store mapping for later conversion back
 		c.toolNameMap[sanitizedName] = originalName
 
-		// Convert input schema
-		schema := tool.InputSchema()
-		var inputSchema bedrocktypes.ToolInputSchema
-
-		if schema != nil {
-			// Build JSON schema document
-			schemaMap := map[string]interface{}{
-				"type":       "object",
-				"properties": convertSchemaProperties(schema.Properties),
-			}
+		// Convert input schema (Converse requires one, so tools without parameters
+		// get an empty object schema)
+		schemaMap := map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		}
+		if schema := tool.InputSchema(); schema != nil {
+			schemaMap["properties"] = convertSchemaProperties(schema.Properties)
 			if len(schema.Required) > 0 {
 				schemaMap["required"] = schema.Required
 			}
+		}
 
-			// Debug: Log the schema map before converting to document
-			if os.Getenv("LOOM_DEBUG_BEDROCK") == "1" {
-				schemaJSON, _ := json.MarshalIndent(schemaMap, "", "  ")
-				fmt.Printf("DEBUG: Schema for tool %s:\n%s\n", sanitizedName, schemaJSON)
-			}
+		// Debug: Log the schema map before converting to document
+		if os.Getenv("LOOM_DEBUG_BEDROCK") == "1" {
+			schemaJSON, _ := json.MarshalIndent(schemaMap, "", "  ")
+			fmt.Printf("DEBUG: Schema for tool %s:\n%s\n", sanitizedName, schemaJSON)
+		}
 
-			// Create document from the schema map
-			// NOTE: Pass the map value, not a pointer to it
-			doc := document.NewLazyDocument(schemaMap)
-			inputSchema = &bedrocktypes.ToolInputSchemaMemberJson{
-				Value: doc,
-			}
+		// Create document from the schema map
+		// NOTE: Pass the map value, not a pointer to it
+		inputSchema := &bedrocktypes.ToolInputSchemaMemberJson{
+			Value: document.NewLazyDocument(schemaMap),
 		}
 
 		// Create tool specification
@@ -0,0 +1,174 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bedrock
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/types"
+)
+
+func TestClient_ConvertToolsToConverse(t *testing.T) {
+	client := &Client{toolNameMap: make(map[string]string)}
+
+	tools := []shuttle.Tool{
+		&mockTool{
+			name:        "spawn_agent",
+			description: "Spawn a sub-agent",
+			schema: shuttle.NewObjectSchema("params", map[string]*shuttle.JSONSchema{
+				"agent_id": shuttle.NewStringSchema("Agent to spawn"),
+				"topics":   shuttle.NewArraySchema("Topics", shuttle.NewStringSchema("topic")),
+			}, []string{"agent_id"}),
+		},
+		&mockTool{name: "filesystem:list", description: "List files"},
+	}
+
+	cfg := client.convertToolsToConverse(tools)
+	require.Len(t, cfg.Tools, 2)
+
+	spec := cfg.Tools[0].(*bedrocktypes.ToolMemberToolSpec).Value
+	assert.Equal(t, "spawn_agent", aws.ToString(spec.Name))
+	assert.Equal(t, "Spawn a sub-agent", aws.ToString(spec.Description))
+
+	raw, err := spec.InputSchema.(*bedrocktypes.ToolInputSchemaMemberJson).Value.MarshalSmithyDocument()
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &schema))
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []interface{}{"agent_id"}, schema["required"])
+	props := schema["properties"].(map[string]interface{})
+	assert.Equal(t, "string", props["agent_id"].(map[string]interface{})["type"])
+	assert.Equal(t, "array", props["topics"].(map[string]interface{})["type"])
+
+	// Tools without a schema still get an object schema, and names are sanitized
+	noParams := cfg.Tools[1].(*bedrocktypes.ToolMemberToolSpec).Value
+	assert.Equal(t, "filesystem_list", aws.ToString(noParams.Name))
+	raw, err = noParams.InputSchema.(*bedrocktypes.ToolInputSchemaMemberJson).Value.MarshalSmithyDocument()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{}}`, string(raw))
+	assert.Equal(t, "filesystem:list", client.toolNameMap["filesystem_list"])
+}
+
+func TestClient_ConvertConverseToolUse(t *testing.T) {
+	client := &Client{toolNameMap: map[string]string{"filesystem_read_file": "filesystem:read_file"}}
+
+	call, err := client.convertConverseToolUse(bedrocktypes.ToolUseBlock{
+		ToolUseId: aws.String("tooluse_1"),
+		Name:      aws.String("filesystem_read_file"),
+		Input:     document.NewLazyDocument(map[string]interface{}{"path": "/tmp/a.txt", "limit": 10}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tooluse_1", call.ID)
+	assert.Equal(t, "filesystem:read_file", call.Name)
+	assert.Equal(t, "/tmp/a.txt", call.Input["path"])
+	assert.NotNil(t, call.Input["limit"])
+
+	// Missing input yields an empty (non-nil) map
+	call, err = client.convertConverseToolUse(bedrocktypes.ToolUseBlock{
+		ToolUseId: aws.String("tooluse_2"),
+		Name:      aws.String("get_time"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "get_time", call.Name)
+	assert.NotNil(t, call.Input)
+	assert.Empty(t, call.Input)
+}
+
+func TestClient_ConvertMessagesToConverse_ToolRoundTrip(t *testing.T) {
+	client := &Client{toolNameMap: make(map[string]string)}
+
+	messages := []types.Message{
+		{Role: "system", Content: "You are a coordinator."},
+		{Role: "user", Content: "Spawn two helpers"},
+		{
+			Role: "assistant",
+			ToolCalls: []types.ToolCall{
+				{ID: "tu_1", Name: "spawn_agent", Input: map[string]interface{}{"agent_id": "a"}},
+				{ID: "tu_2", Name: "spawn_agent", Input: map[string]interface{}{"agent_id": "b"}},
+			},
+		},
+		{Role: "tool", ToolUseID: "tu_1", Content: `{"session_id":"sess-a"}`, ToolResult: &shuttle.Result{Success: true}},
+		{Role: "tool", ToolUseID: "tu_2", Content: "agent not found", ToolResult: &shuttle.Result{Success: false}},
+	}
+
+	system, converse := client.convertMessagesToConverse(messages)
+	require.Len(t, system, 1)
+	require.Len(t, converse, 3)
+
+	assistant := converse[1]
+	assert.Equal(t, bedrocktypes.ConversationRoleAssistant, assistant.Role)
+	require.Len(t, assistant.Content, 2)
+	toolUse := assistant.Content[0].(*bedrocktypes.ContentBlockMemberToolUse).Value
+	assert.Equal(t, "tu_1", aws.ToString(toolUse.ToolUseId))
+	raw, err := toolUse.Input.MarshalSmithyDocument()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"agent_id":"a"}`, string(raw))
+
+	// Both tool results are aggregated into one user message
+	results := converse[2]
+	assert.Equal(t, bedrocktypes.ConversationRoleUser, results.Role)
+	require.Len(t, results.Content, 2)
+
+	ok := results.Content[0].(*bedrocktypes.ContentBlockMemberToolResult).Value
+	assert.Equal(t, "tu_1", aws.ToString(ok.ToolUseId))
+	assert.Empty(t, ok.Status)
+	_, isJSON := ok.Content[0].(*bedrocktypes.ToolResultContentBlockMemberJson)
+	assert.True(t, isJSON)
+
+	failed := results.Content[1].(*bedrocktypes.ContentBlockMemberToolResult).Value
+	assert.Equal(t, "tu_2", aws.ToString(failed.ToolUseId))
+	assert.Equal(t, bedrocktypes.ToolResultStatusError, failed.Status)
+	text, isText := failed.Content[0].(*bedrocktypes.ToolResultContentBlockMemberText)
+	require.True(t, isText)
+	assert.Equal(t, "agent not found", text.Value)
+}
+
+func TestClient_ConvertMessagesToConverse_NonObjectJSONResult(t *testing.T) {
+	client := &Client{toolNameMap: make(map[string]string)}
+
+	_, converse := client.convertMessagesToConverse([]types.Message{
+		{Role: "user", Content: "List"},
+		{Role: "assistant", ToolCalls: []types.ToolCall{{ID: "tu_1", Name: "list"}}},
+		{Role: "tool", ToolUseID: "tu_1", Content: `["a","b"]`},
+	})
+	require.Len(t, converse, 3)
+
+	result := converse[2].Content[0].(*bedrocktypes.ContentBlockMemberToolResult).Value
+	text, isText := result.Content[0].(*bedrocktypes.ToolResultContentBlockMemberText)
+	require.True(t, isText, "arrays are sent as text because Converse only accepts JSON objects")
+	assert.Equal(t, `["a","b"]`, text.Value)
+}
+
+func TestClient_BuildConverseInput_Options(t *testing.T) {
+	client := &Client{modelID: "test-model", maxTokens: 1024, temperature: 0.5, toolNameMap: make(map[string]string)}
+
+	input, err := client.buildConverseInput([]types.Message{
+		{Role: "system", Content: "Conversation prompt"},
+		{Role: "user", Content: "Classify this"},
+	}, nil, ChatOptions{SystemPrompt: "Respond with JSON only", StopSequences: []string{"\n\n"}})
+	require.NoError(t, err)
+
+	require.Len(t, input.System, 2)
+	assert.Equal(t, "Respond with JSON only", input.System[0].(*bedrocktypes.SystemContentBlockMemberText).Value)
+	assert.Equal(t, "Conversation prompt", input.System[1].(*bedrocktypes.SystemContentBlockMemberText).Value)
+	assert.Equal(t, []string{"\n\n"}, input.InferenceConfig.StopSequences)
+	assert.Nil(t, input.ToolConfig)
+}
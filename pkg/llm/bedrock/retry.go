@@ -0,0 +1,120 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go"
+	"go.uber.org/zap"
+)
+
+// Retry defaults for throttling and model timeouts.
+const (
+	DefaultBedrockMaxRetries   = 3
+	DefaultBedrockRetryBackoff = 500 * time.Millisecond
+
+	// maxRetryBackoff caps the exponential backoff between attempts
+	maxRetryBackoff = 20 * time.Second
+)
+
+// retryableErrorCodes are the AWS error codes worth retrying: throttling, model
+// timeouts, and transient service failures. Everything else (validation, access
+// denied, missing models) fails fast.
+var retryableErrorCodes = map[string]bool{
+	"ThrottlingException":         true,
+	"TooManyRequestsException":    true,
+	"ModelTimeoutException":       true,
+	"ModelNotReadyException":      true,
+	"ServiceUnavailableException": true,
+	"InternalServerException":     true,
+}
+
+// isRetryableError reports whether a Bedrock error is transient.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var throttling *bedrocktypes.ThrottlingException
+	var timeout *bedrocktypes.ModelTimeoutException
+	if errors.As(err, &throttling) || errors.As(err, &timeout) {
+		return true
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return retryableErrorCodes[apiErr.ErrorCode()]
+	}
+	return false
+}
+
+// call runs an AWS call through the rate limiter when one is configured, retrying
+// retryable errors with exponential backoff and jitter. Retries stop when ctx is done
+// or when the next backoff would run past ctx's deadline; the last error is returned.
+func (c *Client) call(ctx context.Context, operation string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	invoke := fn
+	if c.rateLimiter != nil {
+		invoke = func(ctx context.Context) (interface{}, error) {
+			return c.rateLimiter.Do(ctx, fn)
+		}
+	}
+
+	logger := c.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	backoff := c.retryBackoff
+	if backoff <= 0 {
+		backoff = DefaultBedrockRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		result, err := invoke(ctx)
+		if err == nil || attempt >= c.maxRetries || !isRetryableError(err) {
+			return result, err
+		}
+
+		// Equal jitter: wait between half and all of the current backoff
+		wait := backoff/2 + rand.N(backoff/2+1) // #nosec G404 -- jitter does not need crypto randomness
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, err
+		}
+
+		logger.Debug("Retrying Bedrock request",
+			zap.String("operation", operation),
+			zap.String("model", c.modelID),
+			zap.Int("attempt", attempt+1),
+			zap.Int("max_retries", c.maxRetries),
+			zap.Duration("backoff", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"throttling", &bedrocktypes.ThrottlingException{Message: aws.String("slow down")}, true},
+		{"model timeout", &bedrocktypes.ModelTimeoutException{}, true},
+		{"service unavailable", &bedrocktypes.ServiceUnavailableException{}, true},
+		{"wrapped throttling", fmt.Errorf("operation error: %w", &bedrocktypes.ThrottlingException{}), true},
+		{"generic api error", &smithy.GenericAPIError{Code: "ThrottlingException"}, true},
+		{"validation", &bedrocktypes.ValidationException{}, false},
+		{"access denied", &bedrocktypes.AccessDeniedException{}, false},
+		{"resource not found", &bedrocktypes.ResourceNotFoundException{}, false},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryableError(tt.err))
+		})
+	}
+}
+
+func TestClient_Call_RetriesThrottling(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	client := &Client{modelID: "test-model", maxRetries: 3, retryBackoff: time.Millisecond, logger: zap.New(core)}
+
+	attempts := 0
+	result, err := client.call(context.Background(), "InvokeModel", func(context.Context) (interface{}, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, &bedrocktypes.ThrottlingException{Message: aws.String("Too many requests")}
+		}
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 3, attempts)
+
+	retries := logs.FilterMessage("Retrying Bedrock request").All()
+	require.Len(t, retries, 2)
+	assert.Equal(t, zap.DebugLevel, retries[0].Level)
+	assert.Equal(t, "InvokeModel", retries[0].ContextMap()["operation"])
+}
+
+func TestClient_Call_GivesUpAfterMaxRetries(t *testing.T) {
+	client := &Client{maxRetries: 2, retryBackoff: time.Millisecond}
+
+	attempts := 0
+	_, err := client.call(context.Background(), "InvokeModel", func(context.Context) (interface{}, error) {
+		attempts++
+		return nil, &bedrocktypes.ModelTimeoutException{Message: aws.String("timed out")}
+	})
+	var timeout *bedrocktypes.ModelTimeoutException
+	require.True(t, errors.As(err, &timeout))
+	assert.Equal(t, 3, attempts, "initial attempt plus two retries")
+}
+
+func TestClient_Call_FailsFastOnNonRetryable(t *testing.T) {
+	client := &Client{maxRetries: 3, retryBackoff: time.Millisecond}
+
+	attempts := 0
+	_, err := client.call(context.Background(), "InvokeModel", func(context.Context) (interface{}, error) {
+		attempts++
+		return nil, &bedrocktypes.AccessDeniedException{Message: aws.String("no access")}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_Call_RetriesDisabled(t *testing.T) {
+	client := &Client{maxRetries: 0, retryBackoff: time.Millisecond}
+
+	attempts := 0
+	_, err := client.call(context.Background(), "InvokeModel", func(context.Context) (interface{}, error) {
+		attempts++
+		return nil, &bedrocktypes.ThrottlingException{}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_Call_HonorsContext(t *testing.T) {
+	client := &Client{maxRetries: 5, retryBackoff: time.Hour}
+
+	// The backoff would run past the deadline, so the throttle is returned immediately
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	attempts := 0
+	start := time.Now()
+	_, err := client.call(ctx, "InvokeModel", func(context.Context) (interface{}, error) {
+		attempts++
+		return nil, &bedrocktypes.ThrottlingException{}
+	})
+	var throttling *bedrocktypes.ThrottlingException
+	require.True(t, errors.As(err, &throttling))
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Without a deadline, cancellation interrupts the backoff
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = client.call(ctx, "InvokeModel", func(context.Context) (interface{}, error) {
+		return nil, &bedrocktypes.ThrottlingException{}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// streamChunkBuffer is the capacity of the channel returned by StreamChat. It lets the
+// final chunk be delivered after cancellation without blocking on the consumer.
+const streamChunkBuffer = 16
+
+// StreamChat sends a conversation to Bedrock with InvokeModelWithResponseStream and
+// returns a channel of chunks. Text deltas are sent as they arrive; the final chunk has
+// Done set and carries the complete response (content, tool calls, stop reason, and
+// token usage) or the error that ended the stream. The channel is closed after the
+// final chunk.
+//
+// Errors starting the stream are returned directly. Cancelling ctx closes the
+// underlying stream and ends the channel with ctx.Err().
+func (c *Client) StreamChat(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (<-chan llmtypes.ChatChunk, error) {
+	body, err := c.buildRequestBody(messages, tools, c.withDefaults(ChatOptions{}))
+	if err != nil {
+		return nil, err
+	}
+
+	input := &bedrockruntime.InvokeModelWithResponseStreamInput{
+		ModelId:     aws.String(c.modelID),
+		Body:        body,
+		ContentType: aws.String("application/json"),
+	}
+
+	// Start the stream (rate limited if configured, retrying transient errors).
+	// Errors raised mid-stream are not retried, since deltas were already sent.
+	result, err := c.call(ctx, "InvokeModelWithResponseStream", func(ctx context.Context) (interface{}, error) {
+		return c.client.InvokeModelWithResponseStream(ctx, input)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start stream: %w", err)
+	}
+	output := result.(*bedrockruntime.InvokeModelWithResponseStreamOutput)
+
+	chunks := make(chan llmtypes.ChatChunk, streamChunkBuffer)
+	go c.consumeStream(ctx, output.GetStream(), chunks)
+	return chunks, nil
+}
+
+// ChatStream streams a conversation to Bedrock, calling tokenCallback for each text
+// delta, and returns the complete response once the stream finishes.
+func (c *Client) ChatStream(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool, tokenCallback llmtypes.TokenCallback) (*llmtypes.LLMResponse, error) {
+	chunks, err := c.StreamChat(ctx, messages, tools)
+	if err != nil {
+		return nil, err
+	}
+
+	for chunk := range chunks {
+		if chunk.Delta != "" && tokenCallback != nil {
+			tokenCallback(chunk.Delta)
+		}
+		if chunk.Done {
+			return chunk.Response, chunk.Err
+		}
+	}
+
+	// Unreachable while the channel is drained, but never return (nil, nil)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("bedrock stream closed without a final response")
+}
+
+// consumeStream reads events from stream and forwards them to chunks, ending with
+// exactly one Done chunk (unless the consumer stopped reading after cancellation).
+func (c *Client) consumeStream(ctx context.Context, stream bedrockruntime.ResponseStreamReader, chunks chan<- llmtypes.ChatChunk) {
+	defer close(chunks)
+	defer func() { _ = stream.Close() }()
+
+	acc := newStreamAccumulator()
+	events := stream.Events()
+	for {
+		select {
+		case <-ctx.Done():
+			sendFinalChunk(ctx, chunks, llmtypes.ChatChunk{Done: true, Err: ctx.Err()})
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				sendFinalChunk(ctx, chunks, c.finishStream(acc, stream.Err()))
+				return
+			}
+
+			chunk, isChunk := event.(*bedrocktypes.ResponseStreamMemberChunk)
+			if !isChunk {
+				continue
+			}
+			delta := acc.add(chunk.Value.Bytes)
+			if delta == "" {
+				continue
+			}
+			select {
+			case chunks <- llmtypes.ChatChunk{Delta: delta}:
+			case <-ctx.Done():
+				sendFinalChunk(ctx, chunks, llmtypes.ChatChunk{Done: true, Err: ctx.Err()})
+				return
+			}
+		}
+	}
+}
+
+// finishStream builds the final chunk once the event channel closes.
+func (c *Client) finishStream(acc *streamAccumulator, streamErr error) llmtypes.ChatChunk {
+	// Exceptions raised mid-stream (throttling, model errors) surface through Err
+	if streamErr != nil {
+		return llmtypes.ChatChunk{Done: true, Err: fmt.Errorf("bedrock stream failed: %w", streamErr)}
+	}
+	if !acc.stopped {
+		return llmtypes.ChatChunk{Done: true, Err: fmt.Errorf("bedrock stream ended before message_stop")}
+	}
+
+	usage := acc.usage
+	// If output tokens not set by stream, use the number of text deltas
+	if usage.OutputTokens == 0 {
+		usage.OutputTokens = acc.textDeltas
+	}
+	usage.TotalTokens = usage.InputTokens + usage.OutputTokens
+	usage.CostUSD = c.calculateCost(usage.InputTokens, usage.OutputTokens)
+
+	// Record token usage for rate limiter metrics
+	if c.rateLimiter != nil {
+		c.rateLimiter.RecordTokenUsage(int64(usage.TotalTokens))
+	}
+
+	// Map sanitized tool names back to original names
+	toolCalls := acc.toolCalls
+	for i := range toolCalls {
+		if originalName, exists := c.toolNameMap[toolCalls[i].Name]; exists {
+			toolCalls[i].Name = originalName
+		}
+	}
+
+	return llmtypes.ChatChunk{
+		Done: true,
+		Response: &llmtypes.LLMResponse{
+			Content:    acc.content.String(),
+			StopReason: acc.stopReason,
+			Usage:      usage,
+			ToolCalls:  toolCalls,
+			Metadata: map[string]interface{}{
+				"model":       c.modelID,
+				"stop_reason": acc.stopReason,
+				"streaming":   true,
+			},
+		},
+	}
+}
+
+// sendFinalChunk delivers the final chunk. After cancellation it only uses free buffer
+// space, so a consumer that stopped reading cannot block the stream goroutine.
+func sendFinalChunk(ctx context.Context, chunks chan<- llmtypes.ChatChunk, chunk llmtypes.ChatChunk) {
+	select {
+	case chunks <- chunk:
+		return
+	case <-ctx.Done():
+	}
+	select {
+	case chunks <- chunk:
+	default:
+	}
+}
+
+// streamAccumulator assembles a response from Anthropic-format stream chunks.
+type streamAccumulator struct {
+	content    strings.Builder
+	textDeltas int
+	toolCalls  []llmtypes.ToolCall
+	// toolBlocks maps content block index to position in toolCalls; text blocks share
+	// the index space, so the two differ whenever text precedes a tool call
+	toolBlocks map[int]int
+	toolInput  map[int]*strings.Builder
+	stopReason string
+	usage      llmtypes.Usage
+	stopped    bool
+}
+
+func newStreamAccumulator() *streamAccumulator {
+	return &streamAccumulator{
+		toolBlocks: make(map[int]int),
+		toolInput:  make(map[int]*strings.Builder),
+	}
+}
+
+// add applies one chunk and returns its text delta, if any. Malformed chunks are skipped.
+func (a *streamAccumulator) add(data []byte) string {
+	var chunk bedrockStreamChunk
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return ""
+	}
+
+	switch chunk.Type {
+	case "message_start":
+		if chunk.Message != nil {
+			a.usage.InputTokens = chunk.Message.Usage.InputTokens
+		}
+
+	case "content_block_start":
+		if chunk.ContentBlock.Type == "tool_use" {
+			a.toolBlocks[chunk.Index] = len(a.toolCalls)
+			a.toolCalls = append(a.toolCalls, llmtypes.ToolCall{
+				ID:    chunk.ContentBlock.ID,
+				Name:  chunk.ContentBlock.Name,
+				Input: make(map[string]interface{}), // Never nil, even for tools without parameters
+			})
+			a.toolInput[chunk.Index] = &strings.Builder{}
+		}
+
+	case "content_block_delta":
+		if chunk.Delta.Type == "input_json_delta" {
+			if buf, exists := a.toolInput[chunk.Index]; exists {
+				// Anthropic sends partial_json; older payloads used text
+				if chunk.Delta.PartialJSON != "" {
+					buf.WriteString(chunk.Delta.PartialJSON)
+				} else {
+					buf.WriteString(chunk.Delta.Text)
+				}
+			}
+			return ""
+		}
+		if chunk.Delta.Text != "" {
+			a.content.WriteString(chunk.Delta.Text)
+			a.textDeltas++
+			return chunk.Delta.Text
+		}
+
+	case "content_block_stop":
+		buf, exists := a.toolInput[chunk.Index]
+		if !exists {
+			break
+		}
+		if buf.Len() > 0 {
+			var input map[string]interface{}
+			if err := json.Unmarshal([]byte(buf.String()), &input); err == nil && input != nil {
+				a.toolCalls[a.toolBlocks[chunk.Index]].Input = input
+			}
+		}
+		delete(a.toolInput, chunk.Index)
+
+	case "message_delta":
+		if chunk.Delta.StopReason != "" {
+			a.stopReason = chunk.Delta.StopReason
+		}
+		if chunk.Usage != nil {
+			a.usage.OutputTokens = chunk.Usage.OutputTokens
+		}
+
+	case "message_stop":
+		a.stopped = true
+		if chunk.StopReason != "" {
+			a.stopReason = chunk.StopReason
+		}
+		if chunk.Usage != nil {
+			a.usage.InputTokens = chunk.Usage.InputTokens
+			a.usage.OutputTokens = chunk.Usage.OutputTokens
+		}
+		// Bedrock's invocation metrics are authoritative when present
+		if m := chunk.InvocationMetrics; m != nil {
+			a.usage.InputTokens = m.InputTokenCount
+			a.usage.OutputTokens = m.OutputTokenCount
+		}
+	}
+	return ""
+}
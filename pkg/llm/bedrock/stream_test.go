@@ -0,0 +1,172 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+)
+
+// fakeStreamReader replays chunks and then closes, or stays open when hang is set.
+type fakeStreamReader struct {
+	events chan bedrocktypes.ResponseStream
+	err    error
+	closed atomic.Bool
+}
+
+func newFakeStreamReader(err error, hang bool, chunks ...string) *fakeStreamReader {
+	r := &fakeStreamReader{events: make(chan bedrocktypes.ResponseStream, len(chunks)), err: err}
+	for _, c := range chunks {
+		r.events <- &bedrocktypes.ResponseStreamMemberChunk{Value: bedrocktypes.PayloadPart{Bytes: []byte(c)}}
+	}
+	if !hang {
+		close(r.events)
+	}
+	return r
+}
+
+func (r *fakeStreamReader) Events() <-chan bedrocktypes.ResponseStream { return r.events }
+func (r *fakeStreamReader) Close() error                               { r.closed.Store(true); return nil }
+func (r *fakeStreamReader) Err() error                                 { return r.err }
+
+func collectChunks(chunks <-chan llmtypes.ChatChunk) (deltas []string, final llmtypes.ChatChunk) {
+	for chunk := range chunks {
+		if chunk.Done {
+			final = chunk
+			continue
+		}
+		deltas = append(deltas, chunk.Delta)
+	}
+	return deltas, final
+}
+
+func TestConsumeStream_TextAndToolUse(t *testing.T) {
+	client := &Client{
+		modelID:     "anthropic.claude-sonnet-4-5-20250929-v1:0",
+		toolNameMap: map[string]string{"filesystem_read_file": "filesystem:read_file"},
+	}
+	reader := newFakeStreamReader(nil, false,
+		`{"type":"message_start","message":{"id":"msg_1","usage":{"input_tokens":25,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Let me "}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"check."}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"filesystem_read_file","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"path\": \"/tm"}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"p/a.txt\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":40}}`,
+		`{"type":"message_stop","amazon-bedrock-invocationMetrics":{"inputTokenCount":25,"outputTokenCount":42}}`,
+	)
+
+	chunks := make(chan llmtypes.ChatChunk, streamChunkBuffer)
+	go client.consumeStream(context.Background(), reader, chunks)
+	deltas, final := collectChunks(chunks)
+
+	assert.Equal(t, []string{"Let me ", "check."}, deltas)
+	require.NoError(t, final.Err)
+	require.NotNil(t, final.Response)
+	resp := final.Response
+	assert.Equal(t, "Let me check.", resp.Content)
+	assert.Equal(t, "tool_use", resp.StopReason)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, "toolu_1", resp.ToolCalls[0].ID)
+	assert.Equal(t, "filesystem:read_file", resp.ToolCalls[0].Name)
+	assert.Equal(t, map[string]interface{}{"path": "/tmp/a.txt"}, resp.ToolCalls[0].Input)
+	assert.Equal(t, 25, resp.Usage.InputTokens)
+	assert.Equal(t, 42, resp.Usage.OutputTokens)
+	assert.Equal(t, 67, resp.Usage.TotalTokens)
+	assert.Greater(t, resp.Usage.CostUSD, 0.0)
+	assert.Equal(t, true, resp.Metadata["streaming"])
+	assert.True(t, reader.closed.Load())
+}
+
+func TestConsumeStream_ToolWithoutInput(t *testing.T) {
+	client := &Client{toolNameMap: map[string]string{}}
+	reader := newFakeStreamReader(nil, false,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"tool_use","id":"toolu_1","name":"get_time"}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":""}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":5}}`,
+		`{"type":"message_stop"}`,
+	)
+
+	chunks := make(chan llmtypes.ChatChunk, streamChunkBuffer)
+	go client.consumeStream(context.Background(), reader, chunks)
+	_, final := collectChunks(chunks)
+
+	require.NoError(t, final.Err)
+	require.Len(t, final.Response.ToolCalls, 1)
+	assert.NotNil(t, final.Response.ToolCalls[0].Input)
+	assert.Empty(t, final.Response.ToolCalls[0].Input)
+}
+
+func TestConsumeStream_MidStreamError(t *testing.T) {
+	client := &Client{toolNameMap: map[string]string{}}
+	streamErr := &bedrocktypes.ThrottlingException{Message: aws.String("Too many tokens")}
+	reader := newFakeStreamReader(streamErr, false,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+	)
+
+	chunks := make(chan llmtypes.ChatChunk, streamChunkBuffer)
+	go client.consumeStream(context.Background(), reader, chunks)
+	deltas, final := collectChunks(chunks)
+
+	assert.Equal(t, []string{"Hel"}, deltas)
+	assert.Nil(t, final.Response)
+	var throttled *bedrocktypes.ThrottlingException
+	assert.True(t, errors.As(final.Err, &throttled))
+}
+
+func TestConsumeStream_EndsWithoutMessageStop(t *testing.T) {
+	client := &Client{toolNameMap: map[string]string{}}
+	reader := newFakeStreamReader(nil, false,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+	)
+
+	chunks := make(chan llmtypes.ChatChunk, streamChunkBuffer)
+	go client.consumeStream(context.Background(), reader, chunks)
+	_, final := collectChunks(chunks)
+
+	require.Error(t, final.Err)
+	assert.Contains(t, final.Err.Error(), "message_stop")
+}
+
+func TestConsumeStream_ContextCancelled(t *testing.T) {
+	client := &Client{toolNameMap: map[string]string{}}
+	reader := newFakeStreamReader(nil, true,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan llmtypes.ChatChunk, streamChunkBuffer)
+	go client.consumeStream(ctx, reader, chunks)
+
+	first := <-chunks
+	assert.Equal(t, "Hel", first.Delta)
+	cancel()
+
+	_, final := collectChunks(chunks)
+	assert.True(t, final.Done)
+	assert.ErrorIs(t, final.Err, context.Canceled)
+	assert.True(t, reader.closed.Load())
+}
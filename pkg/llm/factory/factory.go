@@ -16,8 +16,10 @@ package factory
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/teradata-labs/loom/pkg/llm"
 	"github.com/teradata-labs/loom/pkg/llm/anthropic"
 	"github.com/teradata-labs/loom/pkg/llm/azureopenai"
 	"github.com/teradata-labs/loom/pkg/llm/bedrock"
@@ -26,6 +28,7 @@ import (
 	"github.com/teradata-labs/loom/pkg/llm/mistral"
 	"github.com/teradata-labs/loom/pkg/llm/ollama"
 	"github.com/teradata-labs/loom/pkg/llm/openai"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
 )
 
 // ProviderFactory creates LLM providers dynamically based on configuration.
@@ -104,6 +107,8 @@ func NewProviderFactory(config FactoryConfig) *ProviderFactory {
 
 // CreateProvider creates an LLM provider for the specified provider type and model.
 // Returns interface{} to avoid import cycles (caller should type assert to agent.LLMProvider).
+// The provider is wrapped with llm.NewUsageTrackingProvider so responses report total
+// tokens and, for models in the ModelRegistry, an estimated cost when the API reports none.
 func (f *ProviderFactory) CreateProvider(provider, model string) (interface{}, error) {
 	// Use defaults if not specified
 	if provider == "" {
@@ -113,6 +118,19 @@ func (f *ProviderFactory) CreateProvider(provider, model string) (interface{}, e
 		model = f.config.DefaultModel
 	}
 
+	created, err := f.createProvider(provider, model)
+	if err != nil {
+		return nil, err
+	}
+	client, ok := created.(llmtypes.LLMProvider)
+	if !ok {
+		return created, nil
+	}
+	return llm.NewUsageTrackingProvider(client, defaultModelRegistry.Pricing(provider, client.Model())), nil
+}
+
+// createProvider creates the client for provider without wrapping it.
+func (f *ProviderFactory) createProvider(provider, model string) (interface{}, error) {
 	switch provider {
 	case "anthropic":
 		return f.createAnthropicProvider(model)
@@ -131,7 +149,7 @@ func (f *ProviderFactory) CreateProvider(provider, model string) (interface{}, e
 	case "huggingface":
 		return f.createHuggingFaceProvider(model)
 	default:
-		return nil, fmt.Errorf("unsupported provider: %s", provider)
+		return nil, fmt.Errorf("unsupported provider: %q (supported: %s)", provider, strings.Join(builtinProviders, ", "))
 	}
 }
 
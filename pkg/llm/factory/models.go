@@ -22,6 +22,7 @@ import (
 	"time"
 
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/llm"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -236,6 +237,23 @@ func (r *ModelRegistry) GetModelsForProvider(provider string) []*loomv1.ModelInf
 	return result
 }
 
+// defaultModelRegistry prices the providers CreateProvider creates.
+var defaultModelRegistry = NewModelRegistry()
+
+// Pricing returns the listed price of a provider's model, or zero pricing if the model
+// is not listed.
+func (r *ModelRegistry) Pricing(provider, model string) llm.ModelPricing {
+	if provider == "azureopenai" {
+		provider = "azure-openai"
+	}
+	for _, m := range r.models[provider] {
+		if m.Id == model {
+			return llm.ModelPricing{InputPerMillion: m.CostPer_1MInputUsd, OutputPerMillion: m.CostPer_1MOutputUsd}
+		}
+	}
+	return llm.ModelPricing{}
+}
+
 // GetAllModels returns all models from all providers.
 func (r *ModelRegistry) GetAllModels() []*loomv1.ModelInfo {
 	var all []*loomv1.ModelInfo
@@ -0,0 +1,103 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package factory
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/teradata-labs/loom/pkg/llm"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+)
+
+// builtinProviders lists the provider names CreateProvider understands, in the order
+// they are reported in errors. "azureopenai" is accepted as an alias of "azure-openai".
+var builtinProviders = []string{
+	"anthropic",
+	"bedrock",
+	"ollama",
+	"openai",
+	"azure-openai",
+	"mistral",
+	"gemini",
+	"huggingface",
+}
+
+// Importing this package registers every built-in provider with llm.NewProvider.
+func init() {
+	for _, name := range append(builtinProviders, "azureopenai") {
+		llm.RegisterProvider(name, newRegisteredProvider(name))
+	}
+}
+
+// newRegisteredProvider adapts CreateProvider to llm.ProviderFactoryFunc.
+func newRegisteredProvider(name string) llm.ProviderFactoryFunc {
+	return func(cfg llm.ProviderConfig) (llmtypes.LLMProvider, error) {
+		created, err := NewProviderFactory(factoryConfigFrom(cfg)).CreateProvider(name, cfg.Model)
+		if err != nil {
+			return nil, err
+		}
+		provider, ok := created.(llmtypes.LLMProvider)
+		if !ok {
+			return nil, fmt.Errorf("%s client does not implement LLMProvider", name)
+		}
+		return provider, nil
+	}
+}
+
+// factoryConfigFrom maps the provider-neutral configuration onto the per-provider
+// fields of FactoryConfig. Only the fields of the selected provider are read.
+func factoryConfigFrom(cfg llm.ProviderConfig) FactoryConfig {
+	opts := cfg.Options
+	if opts == nil {
+		opts = map[string]string{}
+	}
+
+	timeout := 0
+	if cfg.Timeout > 0 {
+		timeout = int(math.Ceil(cfg.Timeout.Seconds()))
+	}
+
+	return FactoryConfig{
+		DefaultProvider: cfg.Provider,
+		DefaultModel:    cfg.Model,
+
+		AnthropicAPIKey: cfg.APIKey,
+
+		BedrockRegion:          cfg.Region,
+		BedrockAccessKeyID:     opts["access_key_id"],
+		BedrockSecretAccessKey: opts["secret_access_key"],
+		BedrockSessionToken:    opts["session_token"],
+		BedrockProfile:         opts["profile"],
+
+		OllamaEndpoint: cfg.Endpoint,
+
+		OpenAIAPIKey: cfg.APIKey,
+
+		AzureOpenAIEndpoint:     cfg.Endpoint,
+		AzureOpenAIDeploymentID: opts["deployment_id"],
+		AzureOpenAIAPIKey:       cfg.APIKey,
+		AzureOpenAIEntraToken:   opts["entra_token"],
+
+		MistralAPIKey: cfg.APIKey,
+
+		GeminiAPIKey: cfg.APIKey,
+
+		HuggingFaceToken: cfg.APIKey,
+
+		MaxTokens:   cfg.MaxTokens,
+		Temperature: cfg.Temperature,
+		Timeout:     timeout,
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package factory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/llm"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+)
+
+func TestRegistry_BuiltinProvidersRegistered(t *testing.T) {
+	registered := llm.Providers()
+	for _, name := range append(builtinProviders, "azureopenai") {
+		assert.Contains(t, registered, name)
+	}
+}
+
+func TestRegistry_NewProvider(t *testing.T) {
+	provider, err := llm.NewProvider(llm.ProviderConfig{
+		Provider: "ollama",
+		Model:    "qwen3:8b",
+		Endpoint: "http://localhost:11434",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ollama", provider.Name())
+	assert.Equal(t, "qwen3:8b", provider.Model())
+
+	provider, err = llm.NewProvider(llm.ProviderConfig{Provider: "openai", APIKey: "sk-test"})
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4o", provider.Model())
+}
+
+func TestRegistry_NewProvider_UnknownListsSupported(t *testing.T) {
+	_, err := llm.NewProvider(llm.ProviderConfig{Provider: "bedrok"})
+	require.ErrorIs(t, err, llm.ErrUnknownProvider)
+	assert.Contains(t, err.Error(), "bedrock")
+	assert.Contains(t, err.Error(), "ollama")
+}
+
+func TestFactoryConfigFrom(t *testing.T) {
+	cfg := factoryConfigFrom(llm.ProviderConfig{
+		Provider: "bedrock",
+		Region:   "us-west-2",
+		Timeout:  1500 * time.Millisecond,
+		Options:  map[string]string{"profile": "dev", "deployment_id": "gpt4"},
+	})
+	assert.Equal(t, "us-west-2", cfg.BedrockRegion)
+	assert.Equal(t, "dev", cfg.BedrockProfile)
+	assert.Equal(t, "gpt4", cfg.AzureOpenAIDeploymentID)
+	assert.Equal(t, 2, cfg.Timeout, "timeouts round up to whole seconds")
+}
+
+func TestCreateProvider_UnsupportedListsSupported(t *testing.T) {
+	_, err := NewProviderFactory(FactoryConfig{}).CreateProvider("nope", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "supported: anthropic, bedrock")
+}
+
+func TestCreateProvider_WrapsForUsage(t *testing.T) {
+	assert.Equal(t, llm.ModelPricing{InputPerMillion: 3, OutputPerMillion: 15},
+		defaultModelRegistry.Pricing("anthropic", "claude-sonnet-4-5-20250929"))
+	assert.Equal(t, llm.ModelPricing{}, defaultModelRegistry.Pricing("anthropic", "unlisted"))
+
+	created, err := NewProviderFactory(FactoryConfig{AnthropicAPIKey: "sk-test"}).CreateProvider("anthropic", "claude-sonnet-4-5-20250929")
+	require.NoError(t, err)
+	provider, ok := created.(llmtypes.StreamingLLMProvider)
+	require.True(t, ok, "the usage wrapper keeps streaming")
+	assert.Equal(t, "claude-sonnet-4-5-20250929", provider.Model())
+	assert.Contains(t, fmt.Sprintf("%T", provider), "UsageTrackingProvider")
+}
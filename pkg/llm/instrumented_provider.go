@@ -20,6 +20,7 @@ import (
 
 	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
 	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/session"
 	"github.com/teradata-labs/loom/pkg/shuttle"
 )
 
@@ -69,6 +70,7 @@ func (p *InstrumentedProvider) Chat(ctx context.Context, messages []llmtypes.Mes
 	// Set span attributes - basic info
 	span.SetAttribute(observability.AttrLLMProvider, p.provider.Name())
 	span.SetAttribute(observability.AttrLLMModel, p.provider.Model())
+	setCallerAttributes(ctx, span)
 
 	// Capture request details
 	span.SetAttribute("llm.messages.count", len(messages))
@@ -211,6 +213,7 @@ func (p *InstrumentedProvider) ChatStream(ctx context.Context, messages []llmtyp
 	// Set span attributes - basic info
 	span.SetAttribute(observability.AttrLLMProvider, p.provider.Name())
 	span.SetAttribute(observability.AttrLLMModel, p.provider.Model())
+	setCallerAttributes(ctx, span)
 	span.SetAttribute("llm.streaming", true)
 
 	// Capture request details
@@ -386,3 +389,14 @@ var _ llmtypes.LLMProvider = (*InstrumentedProvider)(nil)
 
 // Ensure InstrumentedProvider implements StreamingLLMProvider interface
 var _ llmtypes.StreamingLLMProvider = (*InstrumentedProvider)(nil)
+
+// setCallerAttributes tags an LLM span with the session and agent making the call,
+// when the caller put them in ctx (see session.WithSessionID and session.WithAgentID).
+func setCallerAttributes(ctx context.Context, span *observability.Span) {
+	if sessionID := session.SessionIDFromContext(ctx); sessionID != "" {
+		span.SetAttribute(observability.AttrSessionID, sessionID)
+	}
+	if agentID := session.AgentIDFromContext(ctx); agentID != "" {
+		span.SetAttribute(observability.AttrAgentID, agentID)
+	}
+}
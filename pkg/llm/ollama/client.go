@@ -359,10 +359,25 @@ func (c *Client) convertMessages(messages []llmtypes.Message) []ollamaMessage {
 			}
 
 		case "assistant":
-			apiMessages = append(apiMessages, ollamaMessage{
+			apiMsg := ollamaMessage{
 				Role:    msg.Role,
 				Content: msg.Content,
-			})
+			}
+			// Replay tool calls so native tool results can be matched to them
+			if len(msg.ToolCalls) > 0 && c.supportsNativeTools() {
+				apiMsg.ToolCalls = make([]ollamaToolCall, len(msg.ToolCalls))
+				for i, tc := range msg.ToolCalls {
+					apiMsg.ToolCalls[i] = ollamaToolCall{
+						ID:   tc.ID,
+						Type: "function",
+						Function: ollamaFunctionCall{
+							Name:      llm.SanitizeToolName(tc.Name),
+							Arguments: tc.Input,
+						},
+					}
+				}
+			}
+			apiMessages = append(apiMessages, apiMsg)
 
 		case "tool":
 			if c.supportsNativeTools() {
@@ -381,6 +381,55 @@ func TestClient_ConvertMessages(t *testing.T) {
 	assert.Equal(t, "Third message", converted[3].Content)
 }
 
+func TestClient_ConvertMessages_AssistantToolCalls(t *testing.T) {
+	messages := []llmtypes.Message{
+		{Role: "user", Content: "Read the file"},
+		{
+			Role: "assistant",
+			ToolCalls: []llmtypes.ToolCall{
+				{ID: "call_1", Name: "filesystem:read_file", Input: map[string]interface{}{"path": "/tmp/a.txt"}},
+			},
+		},
+		{Role: "tool", ToolUseID: "call_1", Content: "contents"},
+	}
+
+	client := NewClient(Config{ToolMode: ToolModeNative})
+	converted := client.convertMessages(messages)
+	require.Len(t, converted, 3)
+	require.Len(t, converted[1].ToolCalls, 1)
+	call := converted[1].ToolCalls[0]
+	assert.Equal(t, "call_1", call.ID)
+	assert.Equal(t, "function", call.Type)
+	assert.Equal(t, "filesystem_read_file", call.Function.Name)
+	assert.Equal(t, map[string]interface{}{"path": "/tmp/a.txt"}, call.Function.Arguments)
+	assert.Equal(t, "tool", converted[2].Role)
+
+	// Prompt mode has no native tool calls to replay
+	client = NewClient(Config{ToolMode: ToolModePrompt})
+	converted = client.convertMessages(messages)
+	assert.Empty(t, converted[1].ToolCalls)
+	assert.Equal(t, "user", converted[2].Role)
+}
+
+func TestClient_Chat_HonorsContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(Config{Endpoint: server.URL, ToolMode: ToolModePrompt})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Chat(ctx, []llmtypes.Message{{Role: "user", Content: "Hello"}}, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
 func TestClient_ImplementsInterface(t *testing.T) {
 	var _ llmtypes.LLMProvider = (*Client)(nil)
 }
@@ -0,0 +1,107 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+)
+
+// ErrUnknownProvider is returned by NewProvider, wrapped with the supported provider
+// names, when no factory is registered for the requested provider.
+var ErrUnknownProvider = errors.New("unknown LLM provider")
+
+// ProviderConfig selects and configures an LLM provider for NewProvider.
+// Fields a provider does not use are ignored; empty fields use provider defaults
+// (which usually fall back to environment variables).
+type ProviderConfig struct {
+	Provider    string        // Required: registered provider name (e.g., "bedrock", "openai", "ollama")
+	Model       string        // Optional: model identifier
+	APIKey      string        // Optional: API key or token for hosted providers
+	Endpoint    string        // Optional: service URL (e.g., Ollama or Azure OpenAI endpoint)
+	Region      string        // Optional: cloud region (e.g., Bedrock)
+	MaxTokens   int           // Optional: maximum output tokens
+	Temperature float64       // Optional: sampling temperature
+	Timeout     time.Duration // Optional: request timeout
+
+	// Options holds provider-specific settings, such as "profile" for Bedrock or
+	// "deployment_id" for Azure OpenAI.
+	Options map[string]string
+}
+
+// ProviderFactoryFunc creates a provider from configuration.
+type ProviderFactoryFunc func(cfg ProviderConfig) (llmtypes.LLMProvider, error)
+
+var (
+	providerFactoriesMu sync.RWMutex
+	providerFactories   = make(map[string]ProviderFactoryFunc)
+)
+
+// RegisterProvider registers a provider factory under name. Registering an existing
+// name replaces its factory. The built-in providers are registered by importing
+// github.com/teradata-labs/loom/pkg/llm/factory.
+func RegisterProvider(name string, factory ProviderFactoryFunc) {
+	providerFactoriesMu.Lock()
+	defer providerFactoriesMu.Unlock()
+	providerFactories[name] = factory
+}
+
+// UnregisterProvider removes a provider factory.
+func UnregisterProvider(name string) {
+	providerFactoriesMu.Lock()
+	defer providerFactoriesMu.Unlock()
+	delete(providerFactories, name)
+}
+
+// Providers returns the registered provider names, sorted.
+func Providers() []string {
+	providerFactoriesMu.RLock()
+	defer providerFactoriesMu.RUnlock()
+
+	names := make([]string, 0, len(providerFactories))
+	for name := range providerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProvider creates the provider named by cfg.Provider. Unknown providers return an
+// error wrapping ErrUnknownProvider that lists the supported names.
+func NewProvider(cfg ProviderConfig) (llmtypes.LLMProvider, error) {
+	providerFactoriesMu.RLock()
+	factory, ok := providerFactories[cfg.Provider]
+	providerFactoriesMu.RUnlock()
+
+	if !ok {
+		supported := Providers()
+		if len(supported) == 0 {
+			return nil, fmt.Errorf("%w: %q (no providers registered; import github.com/teradata-labs/loom/pkg/llm/factory)",
+				ErrUnknownProvider, cfg.Provider)
+		}
+		return nil, fmt.Errorf("%w: %q (supported: %s)", ErrUnknownProvider, cfg.Provider, strings.Join(supported, ", "))
+	}
+
+	provider, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s provider: %w", cfg.Provider, err)
+	}
+	return provider, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package llm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+)
+
+func TestNewProvider_RegisteredFactory(t *testing.T) {
+	RegisterProvider("test-provider", func(cfg ProviderConfig) (llmtypes.LLMProvider, error) {
+		return &mockLLMProvider{name: cfg.Provider, model: cfg.Model}, nil
+	})
+	defer UnregisterProvider("test-provider")
+
+	provider, err := NewProvider(ProviderConfig{Provider: "test-provider", Model: "test-model"})
+	require.NoError(t, err)
+	assert.Equal(t, "test-provider", provider.Name())
+	assert.Equal(t, "test-model", provider.Model())
+	assert.Contains(t, Providers(), "test-provider")
+}
+
+func TestNewProvider_UnknownProvider(t *testing.T) {
+	RegisterProvider("test-b", func(ProviderConfig) (llmtypes.LLMProvider, error) { return &mockLLMProvider{}, nil })
+	RegisterProvider("test-a", func(ProviderConfig) (llmtypes.LLMProvider, error) { return &mockLLMProvider{}, nil })
+	defer UnregisterProvider("test-a")
+	defer UnregisterProvider("test-b")
+
+	_, err := NewProvider(ProviderConfig{Provider: "nope"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnknownProvider))
+	assert.Contains(t, err.Error(), `"nope"`)
+	assert.Contains(t, err.Error(), "supported: test-a, test-b")
+}
+
+func TestNewProvider_NoProvidersRegistered(t *testing.T) {
+	_, err := NewProvider(ProviderConfig{Provider: "bedrock"})
+	require.ErrorIs(t, err, ErrUnknownProvider)
+	assert.Contains(t, err.Error(), "pkg/llm/factory")
+}
+
+func TestNewProvider_FactoryError(t *testing.T) {
+	RegisterProvider("test-failing", func(ProviderConfig) (llmtypes.LLMProvider, error) {
+		return nil, errors.New("missing credentials")
+	})
+	defer UnregisterProvider("test-failing")
+
+	_, err := NewProvider(ProviderConfig{Provider: "test-failing"})
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrUnknownProvider))
+	assert.Contains(t, err.Error(), "failed to create test-failing provider: missing credentials")
+}
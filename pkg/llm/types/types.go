@@ -20,4 +20,5 @@ type Usage = types.Usage
 type LLMResponse = types.LLMResponse
 type LLMProvider = types.LLMProvider
 type TokenCallback = types.TokenCallback
+type ChatChunk = types.ChatChunk
 type StreamingLLMProvider = types.StreamingLLMProvider
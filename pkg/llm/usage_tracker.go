@@ -0,0 +1,131 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// ErrBudgetExceeded is returned, wrapped with details, when a session has reached its
+// UsageBudget. The call is not sent to the provider.
+var ErrBudgetExceeded = errors.New("LLM budget exceeded")
+
+// UsageBudget caps what a session may spend. Zero fields are unlimited.
+type UsageBudget struct {
+	MaxCostUSD float64
+	MaxTokens  int
+}
+
+// Check returns an error wrapping ErrBudgetExceeded if a session that has spent
+// costUSD and used tokens has reached the budget. Calls already in flight finish, so
+// a session can overshoot the budget by its last call.
+func (b UsageBudget) Check(sessionID string, costUSD float64, tokens int) error {
+	if b.MaxCostUSD > 0 && costUSD >= b.MaxCostUSD {
+		return fmt.Errorf("%w: session %q spent $%.4f of $%.4f", ErrBudgetExceeded, sessionID, costUSD, b.MaxCostUSD)
+	}
+	if b.MaxTokens > 0 && tokens >= b.MaxTokens {
+		return fmt.Errorf("%w: session %q used %d of %d tokens", ErrBudgetExceeded, sessionID, tokens, b.MaxTokens)
+	}
+	return nil
+}
+
+// ModelPricing is a model's price in USD per million tokens, used to estimate the cost
+// of calls whose provider does not report one.
+type ModelPricing struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+// IsZero reports whether no price is set.
+func (p ModelPricing) IsZero() bool {
+	return p.InputPerMillion == 0 && p.OutputPerMillion == 0
+}
+
+// completeUsage fills in the total token count and, when the provider reported no
+// cost, the cost estimated from pricing.
+func completeUsage(usage *llmtypes.Usage, pricing ModelPricing) {
+	if usage.TotalTokens == 0 {
+		usage.TotalTokens = usage.InputTokens + usage.OutputTokens
+	}
+	if usage.CostUSD == 0 && !pricing.IsZero() {
+		usage.CostUSD = (float64(usage.InputTokens)*pricing.InputPerMillion + float64(usage.OutputTokens)*pricing.OutputPerMillion) / 1_000_000
+	}
+}
+
+// UsageTrackingProvider wraps an LLMProvider so every response reports complete usage:
+// a total token count and, for providers that report no cost, a cost estimated from
+// the model's pricing. The agent adds each response's usage to the session it belongs
+// to (Session.TotalCostUSD and Session.TotalTokens) and checks those totals against its
+// UsageBudget, so wrapped providers need no store of their own.
+//
+// The provider factory wraps every provider it creates; the wrapper streams only when
+// the underlying provider does.
+type UsageTrackingProvider struct {
+	provider llmtypes.LLMProvider
+	pricing  ModelPricing
+}
+
+// streamingUsageTrackingProvider is a UsageTrackingProvider over a streaming provider.
+type streamingUsageTrackingProvider struct {
+	*UsageTrackingProvider
+	streaming llmtypes.StreamingLLMProvider
+}
+
+// NewUsageTrackingProvider wraps provider so its responses carry complete usage, with
+// costs estimated from pricing when the provider reports none. The result implements
+// StreamingLLMProvider if provider does.
+//
+// Example:
+//
+//	provider = llm.NewUsageTrackingProvider(provider, llm.ModelPricing{InputPerMillion: 3, OutputPerMillion: 15})
+func NewUsageTrackingProvider(provider llmtypes.LLMProvider, pricing ModelPricing) llmtypes.LLMProvider {
+	p := &UsageTrackingProvider{provider: provider, pricing: pricing}
+	if streaming, ok := provider.(llmtypes.StreamingLLMProvider); ok {
+		return &streamingUsageTrackingProvider{UsageTrackingProvider: p, streaming: streaming}
+	}
+	return p
+}
+
+// Name returns the underlying provider name.
+func (p *UsageTrackingProvider) Name() string {
+	return p.provider.Name()
+}
+
+// Model returns the underlying model identifier.
+func (p *UsageTrackingProvider) Model() string {
+	return p.provider.Model()
+}
+
+// Chat calls the provider and completes the response's usage.
+func (p *UsageTrackingProvider) Chat(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (*llmtypes.LLMResponse, error) {
+	resp, err := p.provider.Chat(ctx, messages, tools)
+	if resp != nil {
+		completeUsage(&resp.Usage, p.pricing)
+	}
+	return resp, err
+}
+
+// ChatStream is Chat for streaming providers.
+func (p *streamingUsageTrackingProvider) ChatStream(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool, tokenCallback llmtypes.TokenCallback) (*llmtypes.LLMResponse, error) {
+	resp, err := p.streaming.ChatStream(ctx, messages, tools, tokenCallback)
+	if resp != nil {
+		completeUsage(&resp.Usage, p.pricing)
+	}
+	return resp, err
+}
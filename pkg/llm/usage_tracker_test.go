@@ -0,0 +1,95 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// mockStreamingLLMProvider adds ChatStream to mockLLMProvider.
+type mockStreamingLLMProvider struct {
+	*mockLLMProvider
+}
+
+func (m *mockStreamingLLMProvider) ChatStream(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool, tokenCallback llmtypes.TokenCallback) (*llmtypes.LLMResponse, error) {
+	return m.Chat(ctx, messages, tools)
+}
+
+func TestUsageTrackingProvider(t *testing.T) {
+	pricing := ModelPricing{InputPerMillion: 1, OutputPerMillion: 2}
+
+	t.Run("estimates missing cost and totals", func(t *testing.T) {
+		mock := &mockLLMProvider{
+			name:     "ollama",
+			model:    "llama3",
+			response: &llmtypes.LLMResponse{Usage: llmtypes.Usage{InputTokens: 1_000_000, OutputTokens: 500_000}},
+		}
+		provider := NewUsageTrackingProvider(mock, pricing)
+		assert.Equal(t, "ollama", provider.Name())
+		assert.Equal(t, "llama3", provider.Model())
+
+		resp, err := provider.Chat(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1_500_000, resp.Usage.TotalTokens)
+		assert.InDelta(t, 2.0, resp.Usage.CostUSD, 1e-9)
+	})
+
+	t.Run("keeps reported cost", func(t *testing.T) {
+		mock := &mockLLMProvider{
+			response: &llmtypes.LLMResponse{Usage: llmtypes.Usage{InputTokens: 100, OutputTokens: 20, TotalTokens: 120, CostUSD: 0.5}},
+		}
+		resp, err := NewUsageTrackingProvider(mock, pricing).Chat(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 120, resp.Usage.TotalTokens)
+		assert.InDelta(t, 0.5, resp.Usage.CostUSD, 1e-9)
+	})
+
+	t.Run("unpriced models report no cost", func(t *testing.T) {
+		mock := &mockLLMProvider{response: &llmtypes.LLMResponse{Usage: llmtypes.Usage{InputTokens: 10}}}
+		resp, err := NewUsageTrackingProvider(mock, ModelPricing{}).Chat(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Zero(t, resp.Usage.CostUSD)
+		assert.Equal(t, 10, resp.Usage.TotalTokens)
+	})
+
+	t.Run("streams only when the provider does", func(t *testing.T) {
+		mock := &mockLLMProvider{response: &llmtypes.LLMResponse{Usage: llmtypes.Usage{InputTokens: 1_000_000}}}
+		_, streams := NewUsageTrackingProvider(mock, pricing).(llmtypes.StreamingLLMProvider)
+		assert.False(t, streams)
+
+		streaming, streams := NewUsageTrackingProvider(&mockStreamingLLMProvider{mock}, pricing).(llmtypes.StreamingLLMProvider)
+		require.True(t, streams)
+		resp, err := streaming.ChatStream(context.Background(), nil, nil, nil)
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, resp.Usage.CostUSD, 1e-9)
+	})
+}
+
+func TestUsageBudget_Check(t *testing.T) {
+	assert.NoError(t, UsageBudget{}.Check("sess-1", 100, 1_000_000), "zero budget is unlimited")
+
+	budget := UsageBudget{MaxCostUSD: 1, MaxTokens: 200}
+	assert.NoError(t, budget.Check("sess-1", 0.99, 199))
+	assert.ErrorIs(t, budget.Check("sess-1", 1, 0), ErrBudgetExceeded)
+	err := budget.Check("sess-1", 0, 200)
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	assert.Contains(t, err.Error(), `session "sess-1" used 200 of 200 tokens`)
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exposes Loom server metrics as Prometheus collectors.
+//
+// Metrics are opt-in. Nothing is registered with the global Prometheus registry,
+// and a nil *Metrics is a valid recorder that does nothing, so embedders that
+// never call New pay nothing and are not forced into an HTTP listener. Serve
+// Handler on an admin listener to expose the metrics for scraping.
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/teradata-labs/loom/pkg/communication"
+	"github.com/teradata-labs/loom/pkg/patterns"
+)
+
+// namespace prefixes every Loom metric name.
+const namespace = "loom"
+
+// llmLatencyBuckets spans fast cached responses to long tool-heavy completions (seconds).
+var llmLatencyBuckets = []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32, 64, 128}
+
+// Snapshot is the server state read on every scrape.
+type Snapshot struct {
+	SpawnedAgents int                       // Spawned sub-agents currently tracked
+	Sessions      int                       // Sessions held in agent memory
+	Bus           *communication.BusStats   // Message bus counters (nil when no bus is configured)
+	ReRankCache   patterns.ReRankCacheStats // Re-ranker cache counters, summed over agents
+}
+
+// Metrics holds the Prometheus collectors for a Loom server.
+// All methods are safe for concurrent use and no-ops on a nil receiver.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	spawns     *prometheus.CounterVec
+	cleanups   *prometheus.CounterVec
+	llmLatency *prometheus.HistogramVec
+	llmErrors  *prometheus.CounterVec
+
+	sourceMu sync.RWMutex
+	source   func() Snapshot
+}
+
+// New creates metrics registered on a private registry, together with the standard
+// Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		spawns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "spawns_total",
+			Help:      "Sub-agent spawn requests by outcome.",
+		}, []string{"status"}),
+		cleanups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "spawn_cleanups_total",
+			Help:      "Spawned sub-agents cleaned up, by reason.",
+		}, []string{"reason"}),
+		llmLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "llm_call_duration_seconds",
+			Help:      "Duration of successful LLM calls.",
+			Buckets:   llmLatencyBuckets,
+		}, []string{"provider", "model"}),
+		llmErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_call_errors_total",
+			Help:      "LLM calls that returned an error.",
+		}, []string{"provider", "model"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.spawns,
+		m.cleanups,
+		m.llmLatency,
+		m.llmErrors,
+		&snapshotCollector{metrics: m},
+	)
+	return m
+}
+
+// Registry returns the registry holding every Loom collector, for embedders that
+// serve metrics themselves or add their own collectors.
+func (m *Metrics) Registry() *prometheus.Registry {
+	if m == nil {
+		return nil
+	}
+	return m.registry
+}
+
+// Handler returns an HTTP handler serving the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// SetSource sets the function that reports server state on each scrape. It is called
+// from the scraping goroutine and must be safe for concurrent use. Passing nil stops
+// the snapshot metrics from being exported.
+func (m *Metrics) SetSource(source func() Snapshot) {
+	if m == nil {
+		return
+	}
+	m.sourceMu.Lock()
+	defer m.sourceMu.Unlock()
+	m.source = source
+}
+
+// RecordSpawn counts a spawn request. status should come from a small fixed set
+// (e.g. "spawned", "limit_reached") to keep label cardinality bounded.
+func (m *Metrics) RecordSpawn(status string) {
+	if m == nil {
+		return
+	}
+	m.spawns.WithLabelValues(status).Inc()
+}
+
+// RecordCleanup counts a spawned agent cleanup. Like RecordSpawn, reason should come
+// from a small fixed set.
+func (m *Metrics) RecordCleanup(reason string) {
+	if m == nil {
+		return
+	}
+	m.cleanups.WithLabelValues(reason).Inc()
+}
+
+// ObserveLLMCall records one LLM call: its duration when it succeeded, or an error.
+func (m *Metrics) ObserveLLMCall(provider, model string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.llmErrors.WithLabelValues(provider, model).Inc()
+		return
+	}
+	m.llmLatency.WithLabelValues(provider, model).Observe(duration.Seconds())
+}
+
+func (m *Metrics) snapshot() (Snapshot, bool) {
+	m.sourceMu.RLock()
+	source := m.source
+	m.sourceMu.RUnlock()
+	if source == nil {
+		return Snapshot{}, false
+	}
+	return source(), true
+}
+
+// snapshotCollector exports the Snapshot reported by the metrics source.
+type snapshotCollector struct {
+	metrics *Metrics
+}
+
+var (
+	spawnedAgentsDesc = prometheus.NewDesc(namespace+"_spawned_agents",
+		"Spawned sub-agents currently running.", nil, nil)
+	sessionsDesc = prometheus.NewDesc(namespace+"_active_sessions",
+		"Sessions currently held in agent memory.", nil, nil)
+	busPublishedDesc = prometheus.NewDesc(namespace+"_bus_messages_published_total",
+		"Messages published to the message bus.", nil, nil)
+	busDeliveredDesc = prometheus.NewDesc(namespace+"_bus_messages_delivered_total",
+		"Message deliveries to bus subscribers.", nil, nil)
+	busDroppedDesc = prometheus.NewDesc(namespace+"_bus_messages_dropped_total",
+		"Message deliveries dropped because a subscriber buffer was full.", nil, nil)
+	reRankHitsDesc = prometheus.NewDesc(namespace+"_reranker_cache_hits_total",
+		"Pattern re-ranker selections served from the cache.", nil, nil)
+	reRankMissesDesc = prometheus.NewDesc(namespace+"_reranker_cache_misses_total",
+		"Pattern re-ranker selections that required an LLM call.", nil, nil)
+)
+
+// Describe implements prometheus.Collector.
+func (c *snapshotCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- spawnedAgentsDesc
+	ch <- sessionsDesc
+	ch <- busPublishedDesc
+	ch <- busDeliveredDesc
+	ch <- busDroppedDesc
+	ch <- reRankHitsDesc
+	ch <- reRankMissesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *snapshotCollector) Collect(ch chan<- prometheus.Metric) {
+	snap, ok := c.metrics.snapshot()
+	if !ok {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(spawnedAgentsDesc, prometheus.GaugeValue, float64(snap.SpawnedAgents))
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(snap.Sessions))
+	if snap.Bus != nil {
+		ch <- prometheus.MustNewConstMetric(busPublishedDesc, prometheus.CounterValue, float64(snap.Bus.TotalPublished))
+		ch <- prometheus.MustNewConstMetric(busDeliveredDesc, prometheus.CounterValue, float64(snap.Bus.TotalDelivered))
+		ch <- prometheus.MustNewConstMetric(busDroppedDesc, prometheus.CounterValue, float64(snap.Bus.TotalDropped))
+	}
+	ch <- prometheus.MustNewConstMetric(reRankHitsDesc, prometheus.CounterValue, float64(snap.ReRankCache.Hits))
+	ch <- prometheus.MustNewConstMetric(reRankMissesDesc, prometheus.CounterValue, float64(snap.ReRankCache.Misses))
+}
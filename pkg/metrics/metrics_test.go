@@ -0,0 +1,116 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/communication"
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/patterns"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_NilReceiver(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.RecordSpawn("spawned")
+		m.RecordCleanup("despawned")
+		m.ObserveLLMCall("anthropic", "claude", time.Second, nil)
+		m.SetSource(func() Snapshot { return Snapshot{} })
+	})
+	assert.Nil(t, m.Registry())
+
+	next := observability.NewNoOpTracer()
+	assert.Same(t, next, m.Tracer(next))
+}
+
+func TestMetrics_Counters(t *testing.T) {
+	m := New()
+	m.RecordSpawn("spawned")
+	m.RecordSpawn("spawned")
+	m.RecordSpawn("limit_reached")
+	m.RecordCleanup("idle_timeout")
+	m.ObserveLLMCall("anthropic", "claude", 1500*time.Millisecond, nil)
+	m.ObserveLLMCall("anthropic", "claude", 0, errors.New("boom"))
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `loom_spawns_total{status="spawned"} 2`)
+	assert.Contains(t, out, `loom_spawns_total{status="limit_reached"} 1`)
+	assert.Contains(t, out, `loom_spawn_cleanups_total{reason="idle_timeout"} 1`)
+	assert.Contains(t, out, `loom_llm_call_duration_seconds_count{model="claude",provider="anthropic"} 1`)
+	assert.Contains(t, out, `loom_llm_call_duration_seconds_sum{model="claude",provider="anthropic"} 1.5`)
+	assert.Contains(t, out, `loom_llm_call_errors_total{model="claude",provider="anthropic"} 1`)
+	assert.Contains(t, out, "go_goroutines")
+}
+
+func TestMetrics_Snapshot(t *testing.T) {
+	m := New()
+	assert.NotContains(t, scrape(t, m), "loom_spawned_agents", "no source, no snapshot metrics")
+
+	m.SetSource(func() Snapshot {
+		return Snapshot{
+			SpawnedAgents: 3,
+			Sessions:      7,
+			Bus:           &communication.BusStats{TotalPublished: 10, TotalDelivered: 20, TotalDropped: 1},
+			ReRankCache:   patterns.ReRankCacheStats{Hits: 4, Misses: 2},
+		}
+	})
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "loom_spawned_agents 3")
+	assert.Contains(t, out, "loom_active_sessions 7")
+	assert.Contains(t, out, "loom_bus_messages_published_total 10")
+	assert.Contains(t, out, "loom_bus_messages_delivered_total 20")
+	assert.Contains(t, out, "loom_bus_messages_dropped_total 1")
+	assert.Contains(t, out, "loom_reranker_cache_hits_total 4")
+	assert.Contains(t, out, "loom_reranker_cache_misses_total 2")
+
+	m.SetSource(func() Snapshot { return Snapshot{} })
+	assert.NotContains(t, scrape(t, m), "loom_bus_messages_published_total", "bus metrics omitted without a bus")
+}
+
+func TestMetrics_Tracer(t *testing.T) {
+	m := New()
+	tracer := m.Tracer(nil)
+	labels := map[string]string{
+		observability.AttrLLMProvider: "openai",
+		observability.AttrLLMModel:    "gpt",
+	}
+
+	tracer.RecordMetric(observability.MetricLLMLatency, 250, labels)
+	tracer.RecordMetric(observability.MetricLLMErrors, 1, labels)
+	tracer.RecordMetric("unrelated.metric", 1, labels)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `loom_llm_call_duration_seconds_count{model="gpt",provider="openai"} 1`)
+	assert.Contains(t, out, `loom_llm_call_duration_seconds_sum{model="gpt",provider="openai"} 0.25`)
+	assert.Contains(t, out, `loom_llm_call_errors_total{model="gpt",provider="openai"} 1`)
+}
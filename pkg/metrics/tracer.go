@@ -0,0 +1,57 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// errLLMCall marks LLM error metrics forwarded to ObserveLLMCall.
+var errLLMCall = errors.New("llm call failed")
+
+// Tracer wraps next so the LLM metrics that llm.InstrumentedProvider records through
+// it (latency and errors) also feed the Prometheus LLM collectors. Everything is still
+// forwarded to next. Wrap the tracer given to the agent registry to cover every
+// agent's LLM calls. A nil receiver returns next unchanged.
+func (m *Metrics) Tracer(next observability.Tracer) observability.Tracer {
+	if m == nil {
+		return next
+	}
+	if next == nil {
+		next = observability.NewNoOpTracer()
+	}
+	return &metricsTracer{Tracer: next, metrics: m}
+}
+
+// metricsTracer forwards to the wrapped tracer and taps its LLM metrics.
+type metricsTracer struct {
+	observability.Tracer
+	metrics *Metrics
+}
+
+// RecordMetric implements observability.Tracer.
+func (t *metricsTracer) RecordMetric(name string, value float64, labels map[string]string) {
+	switch name {
+	case observability.MetricLLMLatency:
+		// InstrumentedProvider reports latency in milliseconds
+		t.metrics.ObserveLLMCall(labels[observability.AttrLLMProvider], labels[observability.AttrLLMModel],
+			time.Duration(value*float64(time.Millisecond)), nil)
+	case observability.MetricLLMErrors:
+		t.metrics.ObserveLLMCall(labels[observability.AttrLLMProvider], labels[observability.AttrLLMModel], 0, errLLMCall)
+	}
+	t.Tracer.RecordMetric(name, value, labels)
+}
@@ -21,6 +21,8 @@ const (
 	SpanAgentToolSelection  = "agent.tool_selection"
 	SpanAgentPatternMatch   = "agent.pattern_match"
 	SpanAgentSelfCorrection = "agent.self_correction"
+	SpanAgentSpawn          = "agent.spawn"
+	SpanAgentSpawnMessage   = "agent.spawn.message"
 
 	// LLM spans
 	SpanLLMCompletion = "llm.completion"
@@ -136,6 +138,7 @@ const (
 const (
 	// Session/User context
 	AttrSessionID = "session.id"
+	AttrAgentID   = "agent.id"
 	AttrUserID    = "user.id"
 	AttrTraceID   = "trace.id"
 	AttrSpanID    = "span.id"
@@ -0,0 +1,55 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package observability
+
+import "context"
+
+// Metadata keys carrying a span context across process or goroutine boundaries,
+// e.g. in message bus metadata, so the consumer continues the publisher's trace.
+const (
+	MetadataTraceID = "trace.id"
+	MetadataSpanID  = "trace.parent_span_id"
+)
+
+// InjectTraceContext writes the trace and span IDs of the span in ctx into metadata
+// and returns it, allocating the map if needed. Metadata is returned unchanged when
+// ctx carries no span.
+func InjectTraceContext(ctx context.Context, metadata map[string]string) map[string]string {
+	span := SpanFromContext(ctx)
+	if span == nil || span.TraceID == "" {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]string, 2)
+	}
+	metadata[MetadataTraceID] = span.TraceID
+	metadata[MetadataSpanID] = span.SpanID
+	return metadata
+}
+
+// ExtractTraceContext returns ctx with the span context stored in metadata (see
+// InjectTraceContext) as the parent for the next StartSpan, so spans started from it
+// join the originating trace. The parent is a reference only: it is never ended or
+// exported. Returns ctx unchanged when metadata carries no trace context.
+func ExtractTraceContext(ctx context.Context, metadata map[string]string) context.Context {
+	traceID := metadata[MetadataTraceID]
+	if traceID == "" {
+		return ctx
+	}
+	return ContextWithSpan(ctx, &Span{
+		TraceID: traceID,
+		SpanID:  metadata[MetadataSpanID],
+		Name:    "remote",
+	})
+}
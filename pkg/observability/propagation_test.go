@@ -0,0 +1,49 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package observability
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	tracer := NewMockTracer()
+
+	t.Run("no span leaves metadata untouched", func(t *testing.T) {
+		if md := InjectTraceContext(context.Background(), nil); md != nil {
+			t.Errorf("Expected nil metadata, got %v", md)
+		}
+		ctx := context.Background()
+		if ExtractTraceContext(ctx, map[string]string{"other": "value"}) != ctx {
+			t.Error("Expected context to be returned unchanged")
+		}
+	})
+
+	t.Run("round trip continues the trace", func(t *testing.T) {
+		ctx, publisher := tracer.StartSpan(context.Background(), "publish")
+		md := InjectTraceContext(ctx, map[string]string{"in_reply_to": "msg-1"})
+		if md["in_reply_to"] != "msg-1" {
+			t.Error("Expected existing metadata to be kept")
+		}
+
+		_, consumer := tracer.StartSpan(ExtractTraceContext(context.Background(), md), "consume")
+		if consumer.TraceID != publisher.TraceID {
+			t.Errorf("Expected trace %q, got %q", publisher.TraceID, consumer.TraceID)
+		}
+		if consumer.ParentID != publisher.SpanID {
+			t.Errorf("Expected parent %q, got %q", publisher.SpanID, consumer.ParentID)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package observability
+
+import (
+	"regexp"
+	"sync"
+)
+
+// Redactor masks sensitive content (PII, secrets) before it is written to logs or traces.
+// Implementations must be safe for concurrent use.
+type Redactor interface {
+	Redact(s string) string
+}
+
+// RedactorFunc adapts a function to the Redactor interface.
+type RedactorFunc func(s string) string
+
+// Redact implements Redactor.
+func (f RedactorFunc) Redact(s string) string {
+	return f(s)
+}
+
+// RedactionRule replaces every match of Pattern with Replacement.
+// Replacement may reference capture groups (e.g. "${1}[REDACTED]").
+type RedactionRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// RegexRedactor applies redaction rules in order.
+type RegexRedactor struct {
+	rules []RedactionRule
+}
+
+// NewRegexRedactor creates a redactor from the given rules.
+func NewRegexRedactor(rules ...RedactionRule) *RegexRedactor {
+	return &RegexRedactor{rules: rules}
+}
+
+// Redact implements Redactor.
+func (r *RegexRedactor) Redact(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+	return s
+}
+
+// DefaultRedactionRules returns the conservative rule set used by DefaultRedactor:
+// credential assignments, bearer tokens, common API key formats, emails, SSNs,
+// card numbers, and phone numbers. Secrets run first so their values are masked whole.
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{
+		{
+			Pattern:     regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|api[_-]?key|access[_-]?key|token|auth)(["']?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,;&]+)`),
+			Replacement: "${1}${2}[SECRET_REDACTED]",
+		},
+		{
+			Pattern:     regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9._~+/=-]+`),
+			Replacement: "Bearer [SECRET_REDACTED]",
+		},
+		{
+			Pattern:     regexp.MustCompile(`\b(sk-[A-Za-z0-9_-]{16,}|AKIA[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{30,}|xox[baprs]-[A-Za-z0-9-]{10,})\b`),
+			Replacement: "[SECRET_REDACTED]",
+		},
+		{
+			Pattern:     regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`),
+			Replacement: "[EMAIL_REDACTED]",
+		},
+		{
+			Pattern:     regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+			Replacement: "[SSN_REDACTED]",
+		},
+		{
+			Pattern:     regexp.MustCompile(`\b\d{4}[-\s]?\d{4}[-\s]?\d{4}[-\s]?\d{4}\b`),
+			Replacement: "[CARD_REDACTED]",
+		},
+		{
+			Pattern:     regexp.MustCompile(`\b\d{3}[-.]\d{3}[-.]\d{4}\b`),
+			Replacement: "[PHONE_REDACTED]",
+		},
+	}
+}
+
+// DefaultRedactor returns a RegexRedactor using DefaultRedactionRules.
+func DefaultRedactor() *RegexRedactor {
+	return NewRegexRedactor(DefaultRedactionRules()...)
+}
+
+// NoopRedactor returns content unchanged. Use it to disable redaction in development.
+type NoopRedactor struct{}
+
+// Redact implements Redactor.
+func (NoopRedactor) Redact(s string) string {
+	return s
+}
+
+var (
+	redactorMu     sync.RWMutex
+	activeRedactor Redactor = DefaultRedactor()
+)
+
+// SetRedactor replaces the process-wide redactor used by Redact.
+// Passing nil disables redaction (equivalent to NoopRedactor).
+func SetRedactor(r Redactor) {
+	if r == nil {
+		r = NoopRedactor{}
+	}
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+	activeRedactor = r
+}
+
+// GetRedactor returns the process-wide redactor.
+func GetRedactor() Redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+	return activeRedactor
+}
+
+// Redact masks sensitive content using the process-wide redactor.
+// Call it on user-supplied text (messages, prompts, queries, LLM responses)
+// before passing it to a logger or span attribute.
+func Redact(s string) string {
+	return GetRedactor().Redact(s)
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/config"
+	"github.com/teradata-labs/loom/pkg/observability"
+	"go.uber.org/zap"
+)
+
+// RecommendationEvent describes one pattern recommendation for offline analysis.
+type RecommendationEvent struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Query     string         `json:"query"` // Redacted with observability.Redact
+	Intent    IntentCategory `json:"intent"`
+
+	// KeywordWinner is the top keyword-scored pattern; FinalWinner is the pattern
+	// returned. They differ when the re-ranker overrode the keyword ranking.
+	// FinalWinner is empty when the best match was below the minimum confidence.
+	KeywordWinner string           `json:"keyword_winner"`
+	FinalWinner   string           `json:"final_winner"`
+	Confidence    float64          `json:"confidence"`
+	Reason        ConfidenceReason `json:"reason,omitempty"`
+
+	// LLMInvoked is true if the re-ranker was called, whether or not it succeeded
+	LLMInvoked bool    `json:"llm_invoked"`
+	LatencyMs  float64 `json:"latency_ms"`
+}
+
+// RecommendationSink receives an event after every recommendation.
+// Record is called on the recommendation path, so implementations must not block;
+// buffer and drop events instead. Implementations must be safe for concurrent use.
+type RecommendationSink interface {
+	Record(event RecommendationEvent)
+}
+
+// SetRecommendationSink sets the sink that receives an event after each recommendation
+// (Recommend, RecommendPattern, RecommendPatternWithExplanation, and RecommendMulti).
+// Passing nil disables recording.
+func (o *Orchestrator) SetRecommendationSink(sink RecommendationSink) {
+	o.sink = sink
+}
+
+// RecommendationSink returns the sink set by SetRecommendationSink, or nil.
+func (o *Orchestrator) RecommendationSink() RecommendationSink {
+	return o.sink
+}
+
+// recordRecommendation sends the outcome of a selection to the sink, if any.
+func (o *Orchestrator) recordRecommendation(userMessage string, intent IntentCategory, selection patternSelection, startTime time.Time) {
+	if o.sink == nil {
+		return
+	}
+
+	event := RecommendationEvent{
+		Timestamp:     startTime,
+		Query:         observability.Redact(userMessage),
+		Intent:        intent,
+		KeywordWinner: selection.keywordWinner,
+		Reason:        selection.reason,
+		LLMInvoked:    selection.llmInvoked,
+		LatencyMs:     time.Since(startTime).Seconds() * 1000,
+	}
+	if len(selection.ranked) > 0 {
+		event.Confidence = selection.ranked[0].Confidence
+		if selection.belowMinConfidence {
+			event.Reason = ReasonBelowMinConfidence
+		} else {
+			event.FinalWinner = selection.ranked[0].Name
+		}
+	}
+	o.sink.Record(event)
+}
+
+const (
+	defaultAnalyticsMaxFileBytes = 10 * 1024 * 1024
+	defaultAnalyticsBufferSize   = 1024
+	analyticsFileName            = "recommendations.jsonl"
+)
+
+// JSONLSinkConfig configures a JSONLRecommendationSink.
+type JSONLSinkConfig struct {
+	// Directory for event files (default: config.GetLoomSubDir("analytics"))
+	Dir string
+
+	// Size at which the active file is rotated (default: 10MB)
+	MaxFileBytes int64
+
+	// Events buffered before Record starts dropping (default: 1024)
+	BufferSize int
+
+	// Logger for write failures (default: no-op)
+	Logger *zap.Logger
+}
+
+// JSONLRecommendationSink appends recommendation events to recommendations.jsonl,
+// one JSON object per line. When the file reaches MaxFileBytes it is renamed to
+// recommendations-<timestamp>.jsonl and a new file is started.
+// Events are written by a background goroutine; Record never blocks and drops
+// events when the buffer is full (see Dropped). Call Close to flush on shutdown.
+type JSONLRecommendationSink struct {
+	dir      string
+	maxBytes int64
+	logger   *zap.Logger
+
+	events  chan RecommendationEvent
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	closeOnce sync.Once
+	closeMu   sync.RWMutex
+	closed    bool
+
+	// Owned by the writer goroutine
+	file *os.File
+	size int64
+}
+
+// NewJSONLRecommendationSink creates the analytics directory and starts the writer.
+func NewJSONLRecommendationSink(cfg JSONLSinkConfig) (*JSONLRecommendationSink, error) {
+	if cfg.Dir == "" {
+		cfg.Dir = config.GetLoomSubDir("analytics")
+	}
+	if cfg.MaxFileBytes <= 0 {
+		cfg.MaxFileBytes = defaultAnalyticsMaxFileBytes
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultAnalyticsBufferSize
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create analytics directory: %w", err)
+	}
+
+	s := &JSONLRecommendationSink{
+		dir:      cfg.Dir,
+		maxBytes: cfg.MaxFileBytes,
+		logger:   cfg.Logger,
+		events:   make(chan RecommendationEvent, cfg.BufferSize),
+		done:     make(chan struct{}),
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	go s.run()
+	return s, nil
+}
+
+// Record implements RecommendationSink. It drops the event if the buffer is full
+// or the sink is closed.
+func (s *JSONLRecommendationSink) Record(event RecommendationEvent) {
+	s.closeMu.RLock()
+	defer s.closeMu.RUnlock()
+
+	if s.closed {
+		s.dropped.Add(1)
+		return
+	}
+	select {
+	case s.events <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped because the buffer was full or the
+// sink was closed.
+func (s *JSONLRecommendationSink) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close writes buffered events and closes the file.
+func (s *JSONLRecommendationSink) Close() error {
+	s.closeOnce.Do(func() {
+		s.closeMu.Lock()
+		s.closed = true
+		close(s.events)
+		s.closeMu.Unlock()
+	})
+	<-s.done
+	return nil
+}
+
+// run writes events until the channel is closed.
+func (s *JSONLRecommendationSink) run() {
+	defer close(s.done)
+	defer func() {
+		if s.file != nil {
+			_ = s.file.Close()
+		}
+	}()
+
+	for event := range s.events {
+		if err := s.write(event); err != nil {
+			s.logger.Warn("Failed to write recommendation event", zap.Error(err))
+		}
+	}
+}
+
+// write appends one event, rotating first if it would overflow the active file.
+func (s *JSONLRecommendationSink) write(event RecommendationEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recommendation event: %w", err)
+	}
+	line = append(line, '\n')
+
+	if s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write recommendation event: %w", err)
+	}
+	return nil
+}
+
+// open opens the active file for appending.
+func (s *JSONLRecommendationSink) open() error {
+	path := filepath.Join(s.dir, analyticsFileName)
+	// #nosec G304 -- path is built from the configured analytics directory
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open analytics file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat analytics file: %w", err)
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+// rotate renames the active file to a timestamped name. The next write reopens it.
+func (s *JSONLRecommendationSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		s.logger.Warn("Failed to close analytics file", zap.Error(err))
+	}
+	s.file, s.size = nil, 0
+
+	active := filepath.Join(s.dir, analyticsFileName)
+	rotated := filepath.Join(s.dir, fmt.Sprintf("recommendations-%s.jsonl", time.Now().UTC().Format("20060102T150405.000000000")))
+	if err := os.Rename(active, rotated); err != nil {
+		return fmt.Errorf("failed to rotate analytics file: %w", err)
+	}
+	return nil
+}
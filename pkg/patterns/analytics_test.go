@@ -0,0 +1,156 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySink collects events in memory.
+type memorySink struct {
+	mu     sync.Mutex
+	events []RecommendationEvent
+}
+
+func (s *memorySink) Record(event RecommendationEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func TestOrchestrator_RecommendationSink(t *testing.T) {
+	tmpDir := t.TempDir()
+	patterns := map[string]string{
+		"churn_analysis": `name: churn_analysis
+title: Churn Analysis
+description: Analyze customer churn
+category: analytics
+`,
+		"churn_report": `name: churn_report
+title: Churn Report
+description: Customer report
+category: reporting
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	sink := &memorySink{}
+	orch.SetRecommendationSink(sink)
+
+	name, confidence := orch.RecommendPattern("analyze customer churn", IntentAnalytics)
+	orch.RecommendPattern("zzzz", IntentUnknown)
+
+	require.Len(t, sink.events, 2)
+	event := sink.events[0]
+	assert.Equal(t, "analyze customer churn", event.Query)
+	assert.Equal(t, IntentAnalytics, event.Intent)
+	assert.Equal(t, "churn_analysis", name)
+	assert.Equal(t, name, event.FinalWinner)
+	assert.Equal(t, name, event.KeywordWinner)
+	assert.Equal(t, confidence, event.Confidence)
+	assert.False(t, event.LLMInvoked)
+	assert.False(t, event.Timestamp.IsZero())
+
+	assert.Empty(t, sink.events[1].FinalWinner)
+
+	// A re-ranker overriding the keyword winner is visible in the event
+	sink.events = nil
+	orch.SetReRanker(NewFunctionReRanker(func(_ string, summary PatternSummary, _ float64) float64 {
+		if summary.Name == "churn_report" {
+			return 1
+		}
+		return 0
+	}))
+	orch.RecommendPattern("analyze customer churn", IntentUnknown)
+	require.Len(t, sink.events, 1)
+	assert.True(t, sink.events[0].LLMInvoked)
+	assert.Equal(t, "churn_analysis", sink.events[0].KeywordWinner)
+	assert.Equal(t, "churn_report", sink.events[0].FinalWinner)
+
+	// Cache warm-up is not recorded
+	sink.events = nil
+	require.NoError(t, orch.WarmCache(context.Background(), []string{"analyze customer churn"}))
+	assert.Empty(t, sink.events)
+}
+
+func TestJSONLRecommendationSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewJSONLRecommendationSink(JSONLSinkConfig{Dir: dir})
+	require.NoError(t, err)
+
+	sink.Record(RecommendationEvent{Query: "q1", FinalWinner: "a"})
+	sink.Record(RecommendationEvent{Query: "q2", FinalWinner: "b", LLMInvoked: true})
+	require.NoError(t, sink.Close())
+
+	f, err := os.Open(filepath.Join(dir, analyticsFileName))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []RecommendationEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event RecommendationEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, "q1", events[0].Query)
+	assert.True(t, events[1].LLMInvoked)
+
+	// Records after Close are dropped, not panics
+	sink.Record(RecommendationEvent{Query: "late"})
+	assert.Equal(t, uint64(1), sink.Dropped())
+}
+
+func TestJSONLRecommendationSink_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewJSONLRecommendationSink(JSONLSinkConfig{Dir: dir, MaxFileBytes: 300})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		sink.Record(RecommendationEvent{Query: "rotation test query", FinalWinner: "pattern"})
+	}
+	require.NoError(t, sink.Close())
+
+	rotated, err := filepath.Glob(filepath.Join(dir, "recommendations-*.jsonl"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, rotated)
+
+	for _, path := range append(rotated, filepath.Join(dir, analyticsFileName)) {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(300), path)
+	}
+}
+
+func TestJSONLRecommendationSink_DropsWhenFull(t *testing.T) {
+	sink := &JSONLRecommendationSink{events: make(chan RecommendationEvent, 1), done: make(chan struct{})}
+
+	// No writer is running, so the second event cannot be buffered
+	sink.Record(RecommendationEvent{Query: "buffered"})
+	sink.Record(RecommendationEvent{Query: "dropped"})
+	assert.Equal(t, uint64(1), sink.Dropped())
+}
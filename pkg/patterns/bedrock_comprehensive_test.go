@@ -9,57 +9,24 @@
 package patterns
 
 import (
-	"context"
 	"os"
 	"strings"
 	"testing"
 	"time"
-
-	"github.com/teradata-labs/loom/pkg/llm/bedrock"
-	"github.com/teradata-labs/loom/pkg/types"
 )
 
 // TestComprehensivePatternSelectionWithBedrock tests hybrid pattern selection with diverse use cases.
 // This validates that both keyword scoring and LLM re-ranking work correctly across different domains.
 //
 // Prerequisites:
-// - AWS credentials configured
+// - AWS credentials configured (or LOOM_TEST_LLM_PROVIDER=ollama with a local Ollama server)
 // - Run with: go test -tags integration,fts5 -run TestComprehensivePatternSelectionWithBedrock ./pkg/patterns
 func TestComprehensivePatternSelectionWithBedrock(t *testing.T) {
 	if os.Getenv("SKIP_INTEGRATION_TESTS") == "true" {
 		t.Skip("Skipping integration test")
 	}
 
-	// Set up Bedrock client
-	region := os.Getenv("AWS_REGION")
-	if region == "" {
-		region = "us-west-2"
-	}
-
-	t.Logf("🔧 Setting up Bedrock LLM (region: %s)", region)
-
-	bedrockCfg := bedrock.Config{
-		Region:      region,
-		ModelID:     bedrock.DefaultBedrockModelID,
-		MaxTokens:   1000,
-		Temperature: 0.7,
-	}
-
-	provider, err := bedrock.NewClient(bedrockCfg)
-	if err != nil {
-		t.Skipf("Skipping test - Bedrock client creation failed (credentials may be unavailable): %v", err)
-	}
-
-	// Test if credentials are valid with a simple call
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	testMsg := []types.Message{{Role: "user", Content: "test"}}
-	_, err = provider.Chat(ctx, testMsg, nil)
-	if err != nil {
-		t.Skipf("Skipping test - Bedrock credentials invalid or expired: %v", err)
-	}
-
-	t.Log("✅ Bedrock client created and credentials verified")
+	provider := newIntegrationLLM(t)
 
 	// Set up pattern library and orchestrator
 	lib := NewLibrary(nil, "../../patterns")
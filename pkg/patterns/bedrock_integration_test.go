@@ -9,13 +9,9 @@
 package patterns
 
 import (
-	"context"
 	"os"
 	"testing"
 	"time"
-
-	"github.com/teradata-labs/loom/pkg/llm/bedrock"
-	"github.com/teradata-labs/loom/pkg/types"
 )
 
 // TestPatternSelectionWithBedrockLLM tests the full pattern selection flow with real Bedrock LLM.
@@ -24,6 +20,7 @@ import (
 // Prerequisites:
 // - AWS credentials configured (IAM role, profile, or env vars)
 // - Bedrock model access enabled in your AWS account
+// - Or, without AWS: LOOM_TEST_LLM_PROVIDER=ollama with a local Ollama server
 // - Run with: go test -tags integration,fts5 -run TestPatternSelectionWithBedrockLLM ./pkg/patterns
 func TestPatternSelectionWithBedrockLLM(t *testing.T) {
 	// Skip if not in integration test mode
@@ -31,36 +28,7 @@ func TestPatternSelectionWithBedrockLLM(t *testing.T) {
 		t.Skip("Skipping integration test")
 	}
 
-	// Set up Bedrock client
-	region := os.Getenv("AWS_REGION")
-	if region == "" {
-		region = "us-west-2" // Default
-	}
-
-	t.Logf("🔧 Setting up Bedrock LLM (region: %s)", region)
-
-	bedrockCfg := bedrock.Config{
-		Region:      region,
-		ModelID:     bedrock.DefaultBedrockModelID, // Claude Sonnet 4.5
-		MaxTokens:   1000,                          // Small for intent classification
-		Temperature: 0.7,
-	}
-
-	provider, err := bedrock.NewClient(bedrockCfg)
-	if err != nil {
-		t.Skipf("Skipping test - Bedrock client creation failed (credentials may be unavailable): %v", err)
-	}
-
-	// Test if credentials are valid with a simple call
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	testMsg := []types.Message{{Role: "user", Content: "test"}}
-	_, err = provider.Chat(ctx, testMsg, nil)
-	if err != nil {
-		t.Skipf("Skipping test - Bedrock credentials invalid or expired: %v", err)
-	}
-
-	t.Log("✅ Bedrock client created and credentials verified")
+	provider := newIntegrationLLM(t)
 
 	// Set up pattern library and orchestrator
 	lib := NewLibrary(nil, "../../patterns")
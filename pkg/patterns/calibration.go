@@ -0,0 +1,280 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Calibration curve types.
+const (
+	CalibrationIsotonic = "isotonic"
+	CalibrationLogistic = "logistic"
+)
+
+// minCalibrationSamples is the number of feedback samples a pattern needs before
+// FitCalibration gives it its own curve instead of relying on the global one.
+const minCalibrationSamples = 20
+
+// CalibrationCurve maps a raw keyword score to a calibrated confidence.
+//
+// Isotonic curves interpolate linearly between Points ([raw, calibrated] pairs sorted by
+// raw score) and clamp outside them. Logistic curves compute 1 / (1 + exp(-(A*raw + B))).
+type CalibrationCurve struct {
+	Type   string       `json:"type"`
+	Points [][2]float64 `json:"points,omitempty"`
+	A      float64      `json:"a,omitempty"`
+	B      float64      `json:"b,omitempty"`
+}
+
+// Calibration holds per-pattern curves and an optional global fallback.
+//
+// Example file:
+//
+//	{
+//	  "global": {"type": "logistic", "a": 6.0, "b": -3.5},
+//	  "patterns": {
+//	    "churn_analysis": {"type": "isotonic", "points": [[0.2, 0.1], [0.7, 0.55], [1.0, 0.9]]}
+//	  }
+//	}
+type Calibration struct {
+	Global   *CalibrationCurve            `json:"global,omitempty"`
+	Patterns map[string]*CalibrationCurve `json:"patterns,omitempty"`
+}
+
+// CalibrationSample is one recorded outcome used to fit a calibration: the raw keyword
+// score a pattern received and whether the user accepted it.
+type CalibrationSample struct {
+	Pattern  string  `json:"pattern"`
+	Score    float64 `json:"score"`
+	Positive bool    `json:"positive"`
+}
+
+// validate checks that a curve can be applied.
+func (c *CalibrationCurve) validate() error {
+	switch c.Type {
+	case CalibrationIsotonic:
+		if len(c.Points) == 0 {
+			return fmt.Errorf("isotonic curve has no points")
+		}
+		for i := 1; i < len(c.Points); i++ {
+			if c.Points[i][0] < c.Points[i-1][0] {
+				return fmt.Errorf("isotonic points must be sorted by raw score")
+			}
+			if c.Points[i][1] < c.Points[i-1][1] {
+				return fmt.Errorf("isotonic points must be non-decreasing")
+			}
+		}
+	case CalibrationLogistic:
+		if c.A <= 0 {
+			return fmt.Errorf("logistic curve slope must be positive, got %v", c.A)
+		}
+	default:
+		return fmt.Errorf("unknown calibration type %q", c.Type)
+	}
+	return nil
+}
+
+// apply maps a raw score through the curve.
+func (c *CalibrationCurve) apply(raw float64) float64 {
+	if c.Type == CalibrationLogistic {
+		return 1 / (1 + math.Exp(-(c.A*raw + c.B)))
+	}
+
+	points := c.Points
+	if raw <= points[0][0] {
+		return points[0][1]
+	}
+	last := points[len(points)-1]
+	if raw >= last[0] {
+		return last[1]
+	}
+	i := sort.Search(len(points), func(i int) bool { return points[i][0] >= raw })
+	lo, hi := points[i-1], points[i]
+	if hi[0] == lo[0] {
+		return hi[1]
+	}
+	return lo[1] + (hi[1]-lo[1])*(raw-lo[0])/(hi[0]-lo[0])
+}
+
+// Validate checks every curve in the calibration.
+func (c *Calibration) Validate() error {
+	if c.Global != nil {
+		if err := c.Global.validate(); err != nil {
+			return fmt.Errorf("global calibration: %w", err)
+		}
+	}
+	for name, curve := range c.Patterns {
+		if curve == nil {
+			return fmt.Errorf("calibration for pattern %s is empty", name)
+		}
+		if err := curve.validate(); err != nil {
+			return fmt.Errorf("calibration for pattern %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Apply returns the calibrated confidence for a pattern's raw score, using the pattern's
+// curve, then the global curve. Scores without a curve are returned unchanged.
+func (c *Calibration) Apply(pattern string, raw float64) float64 {
+	if curve, ok := c.Patterns[pattern]; ok {
+		return curve.apply(raw)
+	}
+	if c.Global != nil {
+		return c.Global.apply(raw)
+	}
+	return raw
+}
+
+// Save writes the calibration as JSON, creating parent directories as needed.
+func (c *Calibration) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create calibration directory: %w", err)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal calibration: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write calibration file %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadCalibrationFile reads and validates a calibration file.
+func LoadCalibrationFile(path string) (*Calibration, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is caller-configured
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calibration file %s: %w", path, err)
+	}
+	var c Calibration
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse calibration file %s: %w", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid calibration file %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// FitCalibration fits isotonic curves from recorded feedback: a global curve from all
+// samples, and a per-pattern curve for each pattern with at least 20 samples.
+// Returns an error if there are no samples.
+func FitCalibration(samples []CalibrationSample) (*Calibration, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no calibration samples")
+	}
+
+	byPattern := make(map[string][]CalibrationSample)
+	for _, s := range samples {
+		byPattern[s.Pattern] = append(byPattern[s.Pattern], s)
+	}
+
+	c := &Calibration{
+		Global:   fitIsotonic(samples),
+		Patterns: make(map[string]*CalibrationCurve),
+	}
+	for name, patternSamples := range byPattern {
+		if name != "" && len(patternSamples) >= minCalibrationSamples {
+			c.Patterns[name] = fitIsotonic(patternSamples)
+		}
+	}
+	return c, nil
+}
+
+// fitIsotonic runs pool-adjacent-violators over samples sorted by score, producing one
+// point per pooled block at the block's mean score and acceptance rate.
+func fitIsotonic(samples []CalibrationSample) *CalibrationCurve {
+	sorted := append([]CalibrationSample(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Score < sorted[j].Score })
+
+	type block struct {
+		sumX, sumY, n float64
+	}
+	blocks := make([]block, 0, len(sorted))
+	for _, s := range sorted {
+		y := 0.0
+		if s.Positive {
+			y = 1
+		}
+		blocks = append(blocks, block{sumX: s.Score, sumY: y, n: 1})
+		// Merge backwards while the acceptance rate decreases
+		for len(blocks) > 1 {
+			last, prev := blocks[len(blocks)-1], blocks[len(blocks)-2]
+			if prev.sumY/prev.n <= last.sumY/last.n {
+				break
+			}
+			blocks = blocks[:len(blocks)-2]
+			blocks = append(blocks, block{sumX: prev.sumX + last.sumX, sumY: prev.sumY + last.sumY, n: prev.n + last.n})
+		}
+	}
+
+	points := make([][2]float64, len(blocks))
+	for i, b := range blocks {
+		points[i] = [2]float64{b.sumX / b.n, b.sumY / b.n}
+	}
+	return &CalibrationCurve{Type: CalibrationIsotonic, Points: points}
+}
+
+// LoadCalibration loads a calibration file and applies it to keyword scores before the
+// re-rank trigger thresholds are evaluated, so those thresholds (and the returned
+// keyword confidences) operate on calibrated values. See Calibration for the format.
+func (o *Orchestrator) LoadCalibration(path string) error {
+	c, err := LoadCalibrationFile(path)
+	if err != nil {
+		return err
+	}
+	o.calibration = c
+	return nil
+}
+
+// CalibrateFromFeedback fits a calibration from the votes recorded with RecordFeedback
+// (see FitCalibration) and applies it. Call it periodically, or after a batch of
+// feedback, to keep confidences in line with how often users accept each pattern; save
+// the result with Calibration.Save to reuse it through LoadCalibration. Returns an error,
+// and keeps the current calibration, if no scored votes have been recorded.
+func (o *Orchestrator) CalibrateFromFeedback() (*Calibration, error) {
+	store, err := o.feedbackStore()
+	if err != nil {
+		return nil, err
+	}
+	c, err := FitCalibration(store.CalibrationSamples())
+	if err != nil {
+		return nil, fmt.Errorf("failed to calibrate from feedback: %w", err)
+	}
+	o.calibration = c
+	return c, nil
+}
+
+// SetCalibration sets the calibration directly. Passing nil restores raw keyword scores.
+func (o *Orchestrator) SetCalibration(c *Calibration) {
+	o.calibration = c
+}
+
+// applyCalibration maps scores through the calibration and restores score order.
+func (o *Orchestrator) applyCalibration(scored []scoredPattern) {
+	if o.calibration == nil || len(scored) == 0 {
+		return
+	}
+	for i := range scored {
+		scored[i].score = o.calibration.Apply(scored[i].name, scored[i].score)
+	}
+	sortScoredPatterns(scored)
+}
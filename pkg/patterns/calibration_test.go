@@ -0,0 +1,181 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalibrationCurve_Apply(t *testing.T) {
+	isotonic := &CalibrationCurve{Type: CalibrationIsotonic, Points: [][2]float64{{0.2, 0.1}, {0.6, 0.5}, {1.0, 0.9}}}
+	require.NoError(t, isotonic.validate())
+	assert.InDelta(t, 0.1, isotonic.apply(0.0), 1e-9)
+	assert.InDelta(t, 0.3, isotonic.apply(0.4), 1e-9)
+	assert.InDelta(t, 0.5, isotonic.apply(0.6), 1e-9)
+	assert.InDelta(t, 0.9, isotonic.apply(1.3), 1e-9)
+
+	logistic := &CalibrationCurve{Type: CalibrationLogistic, A: 10, B: -5}
+	require.NoError(t, logistic.validate())
+	assert.InDelta(t, 0.5, logistic.apply(0.5), 1e-9)
+	assert.Greater(t, logistic.apply(0.9), logistic.apply(0.6))
+}
+
+func TestCalibration_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		curve *CalibrationCurve
+	}{
+		{"unknown type", &CalibrationCurve{Type: "spline"}},
+		{"no points", &CalibrationCurve{Type: CalibrationIsotonic}},
+		{"unsorted", &CalibrationCurve{Type: CalibrationIsotonic, Points: [][2]float64{{0.5, 0.2}, {0.1, 0.3}}}},
+		{"decreasing", &CalibrationCurve{Type: CalibrationIsotonic, Points: [][2]float64{{0.1, 0.5}, {0.5, 0.2}}}},
+		{"flat logistic", &CalibrationCurve{Type: CalibrationLogistic}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Calibration{Patterns: map[string]*CalibrationCurve{"p": tt.curve}}
+			assert.Error(t, c.Validate())
+		})
+	}
+}
+
+func TestFitCalibration(t *testing.T) {
+	_, err := FitCalibration(nil)
+	assert.Error(t, err)
+
+	var samples []CalibrationSample
+	for i := 0; i < 40; i++ {
+		score := float64(i) / 40
+		// Accepted more often at higher scores, with noise
+		positive := score > 0.5 || i%7 == 0
+		samples = append(samples, CalibrationSample{Pattern: "frequent", Score: score, Positive: positive})
+	}
+	samples = append(samples, CalibrationSample{Pattern: "rare", Score: 0.9, Positive: false})
+
+	c, err := FitCalibration(samples)
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	require.NotNil(t, c.Global)
+	assert.Contains(t, c.Patterns, "frequent")
+	assert.NotContains(t, c.Patterns, "rare")
+
+	// Fitted curves are monotone
+	prev := -1.0
+	for x := 0.0; x <= 1.0; x += 0.05 {
+		y := c.Apply("frequent", x)
+		assert.GreaterOrEqual(t, y, prev)
+		prev = y
+	}
+
+	// Round-trips through a file
+	path := filepath.Join(t.TempDir(), "calibration", "patterns.json")
+	require.NoError(t, c.Save(path))
+	loaded, err := LoadCalibrationFile(path)
+	require.NoError(t, err)
+	assert.InDelta(t, c.Apply("rare", 0.8), loaded.Apply("rare", 0.8), 1e-9)
+}
+
+func TestOrchestrator_LoadCalibration(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "churn_analysis.yaml"), []byte(`name: churn_analysis
+title: Churn Analysis
+description: Analyze customer churn
+category: analytics
+`), 0644))
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	invoked := 0
+	orch.SetReRanker(NewFunctionReRanker(func(_ string, _ PatternSummary, keywordScore float64) float64 {
+		invoked++
+		return keywordScore
+	}))
+
+	// Raw scores make this a clear keyword winner
+	rec := orch.Recommend("churn analysis", IntentAnalytics)
+	assert.Equal(t, ReasonClearKeywordWinner, rec.Reason)
+	assert.Equal(t, 0, invoked)
+
+	// Calibrated down below the trigger threshold, the re-ranker runs
+	calibrationPath := filepath.Join(t.TempDir(), "calibration.json")
+	require.NoError(t, os.WriteFile(calibrationPath, []byte(`{
+		"patterns": {"churn_analysis": {"type": "isotonic", "points": [[0, 0], [2, 0.5]]}}
+	}`), 0600))
+	require.NoError(t, orch.LoadCalibration(calibrationPath))
+
+	ranked, err := orch.RecommendTopN("churn analysis", IntentAnalytics, 1)
+	require.NoError(t, err)
+	require.Len(t, ranked, 1)
+	assert.Less(t, ranked[0].KeywordScore, 0.7)
+	assert.Equal(t, 1, invoked)
+
+	// Invalid files are rejected and leave the calibration in place
+	require.NoError(t, os.WriteFile(calibrationPath, []byte(`{"global": {"type": "spline"}}`), 0600))
+	assert.Error(t, orch.LoadCalibration(calibrationPath))
+	assert.Error(t, orch.LoadCalibration(filepath.Join(t.TempDir(), "missing.json")))
+
+	orch.SetCalibration(nil)
+	invoked = 0
+	assert.Equal(t, ReasonClearKeywordWinner, orch.Recommend("churn analysis", IntentAnalytics).Reason)
+	assert.Equal(t, 0, invoked)
+}
+
+func TestOrchestrator_CalibrateFromFeedback(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "churn_analysis.yaml"), []byte(`name: churn_analysis
+title: Churn Analysis
+description: Analyze customer churn
+category: analytics
+`), 0644))
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	feedbackPath := filepath.Join(t.TempDir(), "fb.json")
+	store, err := NewFeedbackStore(feedbackPath)
+	require.NoError(t, err)
+	orch.SetFeedbackStore(store)
+
+	_, err = orch.CalibrateFromFeedback()
+	assert.Error(t, err, "no votes recorded yet")
+
+	raw, err := orch.RecommendTopN("churn analysis", IntentUnknown, 1)
+	require.NoError(t, err)
+	require.Len(t, raw, 1)
+
+	// Each vote keeps the raw score the pattern had for the query
+	for i := 0; i < minCalibrationSamples; i++ {
+		require.NoError(t, orch.RecordFeedback("churn analysis", "churn_analysis", i%4 == 0))
+	}
+	require.NoError(t, orch.RecordFeedback("churn analysis", "unknown_pattern", true))
+	samples := store.CalibrationSamples()
+	require.Len(t, samples, minCalibrationSamples)
+	assert.Equal(t, "churn_analysis", samples[0].Pattern)
+	assert.InDelta(t, raw[0].KeywordScore, samples[0].Score, 1e-9)
+
+	reopened, err := NewFeedbackStore(feedbackPath)
+	require.NoError(t, err)
+	assert.Equal(t, samples, reopened.CalibrationSamples())
+
+	// The fitted curve maps the score to the observed acceptance rate
+	c, err := orch.CalibrateFromFeedback()
+	require.NoError(t, err)
+	assert.Contains(t, c.Patterns, "churn_analysis")
+	calibrated, err := orch.RecommendTopN("churn analysis", IntentUnknown, 1)
+	require.NoError(t, err)
+	require.Len(t, calibrated, 1)
+	assert.InDelta(t, 0.25, calibrated[0].KeywordScore, 1e-9)
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Category is a canonical pattern category. Pattern files may spell categories
+// loosely ("Data Quality", "data-quality"); ParseCategory maps them to the
+// registered lowercase snake_case form ("data_quality").
+type Category string
+
+// Core pattern categories. RegisteredCategories lists the full set, including
+// categories registered by applications with RegisterCategory.
+const (
+	CategoryAnalytics     Category = "analytics"
+	CategoryAggregation   Category = "aggregation"
+	CategoryReporting     Category = "reporting"
+	CategoryDataQuality   Category = "data_quality"
+	CategoryValidation    Category = "validation"
+	CategoryETL           Category = "etl"
+	CategoryDataTransform Category = "data_transform"
+	CategoryDataImport    Category = "data_import"
+	CategoryDataLoading   Category = "data_loading"
+	CategoryDataDiscovery Category = "data_discovery"
+	CategorySchema        Category = "schema"
+	CategoryMetadata      Category = "metadata"
+	CategoryDataModeling  Category = "data_modeling"
+	CategoryTimeseries    Category = "timeseries"
+	CategoryML            Category = "ml"
+	CategoryPerformance   Category = "performance"
+	CategoryText          Category = "text"
+	CategoryDocument      Category = "document"
+	CategoryRESTAPI       Category = "rest_api"
+	CategoryLearning      Category = "learning"
+	CategoryReasoning     Category = "reasoning"
+	CategoryTesting       Category = "testing"
+)
+
+// ErrUnknownCategory is returned by ParseCategory, wrapped with the offending value,
+// for categories that are not registered.
+var ErrUnknownCategory = errors.New("unknown pattern category")
+
+var (
+	categoriesMu sync.RWMutex
+	// categories maps each registered category and alias to its canonical category
+	categories = make(map[Category]Category)
+)
+
+func init() {
+	for _, c := range []Category{
+		CategoryAnalytics, CategoryAggregation, CategoryReporting,
+		CategoryDataQuality, CategoryValidation,
+		CategoryETL, CategoryDataTransform, CategoryDataImport, CategoryDataLoading,
+		CategoryDataDiscovery, CategorySchema, CategoryMetadata, CategoryDataModeling,
+		CategoryTimeseries, CategoryML, CategoryPerformance,
+		CategoryText, CategoryDocument, CategoryRESTAPI,
+		CategoryLearning, CategoryReasoning, CategoryTesting,
+		// Categories used by the bundled pattern library
+		"analysis", "classification", "code_migration", "debugging", "document_extraction",
+		"documentation", "extraction", "formatting", "fun", "investigation",
+		"quality_assurance", "question_answering", "reliability", "spreadsheet_analysis",
+		"summarization", "troubleshooting", "workflow", "workflow_orchestration",
+	} {
+		RegisterCategory(c)
+	}
+	RegisterCategory(CategoryML, "machine_learning")
+	RegisterCategory(CategoryTimeseries, "time_series")
+	RegisterCategory(CategoryRESTAPI, "api")
+	RegisterCategory(CategoryTesting, "test")
+}
+
+// normalizeCategory lowercases a category and joins its words with underscores,
+// so "Data Quality", "data-quality", and "data_quality" compare equal.
+func normalizeCategory(s string) Category {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	})
+	return Category(strings.Join(words, "_"))
+}
+
+// RegisterCategory adds a category, plus optional aliases that parse to it.
+// Registering an existing category is a no-op; an alias that is already registered
+// is remapped to c. Names are normalized as by ParseCategory.
+func RegisterCategory(c Category, aliases ...string) {
+	canonical := normalizeCategory(string(c))
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+	categories[canonical] = canonical
+	for _, alias := range aliases {
+		categories[normalizeCategory(alias)] = canonical
+	}
+}
+
+// RegisteredCategories returns the canonical registered categories, sorted.
+// Aliases are not included.
+func RegisteredCategories() []Category {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+
+	result := make([]Category, 0, len(categories))
+	for name, canonical := range categories {
+		if name == canonical {
+			result = append(result, canonical)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// ParseCategory returns the canonical category for s. Case, surrounding space, and
+// word separators (space, hyphen, underscore) are ignored, and aliases such as
+// "Machine Learning" resolve to their category ("ml"). Unregistered or empty
+// categories return an error wrapping ErrUnknownCategory.
+func ParseCategory(s string) (Category, error) {
+	normalized := normalizeCategory(s)
+	categoriesMu.RLock()
+	canonical, ok := categories[normalized]
+	categoriesMu.RUnlock()
+	if !ok || normalized == "" {
+		known := RegisteredCategories()
+		names := make([]string, len(known))
+		for i, c := range known {
+			names[i] = string(c)
+		}
+		return "", fmt.Errorf("%w: %q (known: %s)", ErrUnknownCategory, s, strings.Join(names, ", "))
+	}
+	return canonical, nil
+}
+
+// canonicalCategory resolves s like ParseCategory, but returns unregistered
+// categories normalized instead of failing. Used for lookups, where an unknown
+// category should simply match nothing.
+func canonicalCategory(s string) Category {
+	if c, err := ParseCategory(s); err == nil {
+		return c
+	}
+	return normalizeCategory(s)
+}
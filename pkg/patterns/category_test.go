@@ -0,0 +1,104 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCategory(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Category
+	}{
+		{"analytics", CategoryAnalytics},
+		{"Analytics", CategoryAnalytics},
+		{"  Data Quality ", CategoryDataQuality},
+		{"data-quality", CategoryDataQuality},
+		{"data-import", CategoryDataImport},
+		{"Machine Learning", CategoryML},
+		{"ML", CategoryML},
+		{"test", CategoryTesting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCategory(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, invalid := range []string{"", "  ", "analytcs", "machine learnin"} {
+		_, err := ParseCategory(invalid)
+		assert.ErrorIs(t, err, ErrUnknownCategory, invalid)
+	}
+}
+
+func TestRegisterCategory(t *testing.T) {
+	RegisterCategory("Geo Spatial", "gis")
+	defer func() {
+		categoriesMu.Lock()
+		delete(categories, "geo_spatial")
+		delete(categories, "gis")
+		categoriesMu.Unlock()
+	}()
+
+	got, err := ParseCategory("GIS")
+	require.NoError(t, err)
+	assert.Equal(t, Category("geo_spatial"), got)
+	assert.Contains(t, RegisteredCategories(), Category("geo_spatial"))
+	assert.NotContains(t, RegisteredCategories(), Category("gis"), "aliases are not listed")
+}
+
+func TestLibrary_Categories(t *testing.T) {
+	dir := t.TempDir()
+	for name, category := range map[string]string{
+		"revenue":  "Analytics",
+		"churn":    "machine-learning",
+		"nulls":    "Data Quality",
+		"growth":   "analytics",
+		"misspelt": "analytcs",
+	} {
+		content := "name: " + name + "\ntitle: " + name + "\ncategory: " + category + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0600))
+	}
+	lib := NewLibrary(nil, dir)
+
+	assert.Equal(t, []Category{CategoryAnalytics, CategoryDataQuality, CategoryML}, lib.Categories())
+
+	// Loaded patterns carry the canonical category
+	p, err := lib.Load("churn")
+	require.NoError(t, err)
+	assert.Equal(t, "ml", p.Category)
+
+	// Unknown categories are rejected at load
+	_, err = lib.Load("misspelt")
+	assert.ErrorIs(t, err, ErrUnknownCategory)
+
+	names := func(summaries []PatternSummary) []string {
+		var result []string
+		for _, s := range summaries {
+			result = append(result, s.Name)
+		}
+		return result
+	}
+	assert.ElementsMatch(t, []string{"revenue", "growth"}, names(lib.ByCategory(CategoryAnalytics)))
+	assert.ElementsMatch(t, []string{"nulls"}, names(lib.FilterByCategory("data-quality")))
+	assert.Empty(t, lib.ByCategory(CategoryTimeseries))
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Collection is a named set of related patterns recommended as a unit when a
+// query maps to the collection's theme (e.g. "customer-360" grouping churn,
+// cohort, and health-scoring patterns).
+type Collection struct {
+	Name        string   `yaml:"name" json:"name"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description" json:"description"`
+	Keywords    []string `yaml:"keywords" json:"keywords"` // Theme keywords matched against queries
+	Patterns    []string `yaml:"patterns" json:"patterns"` // Member pattern names
+}
+
+// CollectionsFileYAML is the on-disk format for collection definitions.
+//
+// Example:
+//
+//	collections:
+//	  - name: customer-360
+//	    title: Customer 360
+//	    description: Understand and retain customers
+//	    keywords: [customer, retention, loyalty]
+//	    patterns: [churn_analysis, cohort_analysis, customer_health_scoring]
+type CollectionsFileYAML struct {
+	Collections []*Collection `yaml:"collections"`
+}
+
+// collectionMinConfidence is the minimum score for RecommendCollection to return a collection.
+const collectionMinConfidence = 0.4
+
+// validate checks that a collection is usable.
+func (c *Collection) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("collection name is required")
+	}
+	if len(c.Patterns) < 2 {
+		return fmt.Errorf("collection %s must contain at least 2 patterns", c.Name)
+	}
+	return nil
+}
+
+// LoadCollections reads collection definitions from a YAML file and adds them to the library.
+// Collections with a name that is already registered replace the existing definition.
+func (lib *Library) LoadCollections(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read collections file %s: %w", path, err)
+	}
+
+	var file CollectionsFileYAML
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse collections file %s: %w", path, err)
+	}
+
+	for i, c := range file.Collections {
+		if c == nil {
+			return fmt.Errorf("collection %d in %s is empty", i, path)
+		}
+		if err := lib.AddCollection(c); err != nil {
+			return fmt.Errorf("invalid collection %d in %s: %w", i, path, err)
+		}
+	}
+	return nil
+}
+
+// AddCollection registers a collection, replacing any existing collection with the same name.
+func (lib *Library) AddCollection(c *Collection) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if lib.collections == nil {
+		lib.collections = make(map[string]*Collection)
+	}
+	lib.collections[c.Name] = c
+	return nil
+}
+
+// Collections returns all registered collections sorted by name.
+func (lib *Library) Collections() []*Collection {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	result := make([]*Collection, 0, len(lib.collections))
+	for _, c := range lib.collections {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// RecommendCollection suggests a pattern collection for broad queries.
+// Each collection is scored on two signals: how many query keywords match its theme
+// (name, title, description, keywords), and how many of its member patterns the query
+// also matches. Queries touching several members score higher than queries aimed at one
+// pattern, so callers should prefer the returned collection over RecommendPattern's
+// single pick when its confidence is at least as high.
+// Returns nil and 0.0 if no collection reaches the minimum confidence.
+func (o *Orchestrator) RecommendCollection(userMessage string) (*Collection, float64) {
+	startTime := time.Now()
+	_, span := o.tracer.StartSpan(context.Background(), "patterns.orchestrator.recommend_collection")
+	defer o.tracer.EndSpan(span)
+
+	collections := o.library.Collections()
+	keywords := extractQueryKeywords(userMessage)
+	if len(collections) == 0 || len(keywords) == 0 {
+		return nil, 0.0
+	}
+
+	// Patterns the query matches individually
+	matched := make(map[string]bool)
+	for _, summary := range o.library.Search(userMessage) {
+		matched[summary.Name] = true
+	}
+
+	var best *Collection
+	bestScore := 0.0
+	for _, c := range collections {
+		themeWords := strings.Fields(strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(
+			c.Name + " " + c.Title + " " + c.Description + " " + strings.Join(c.Keywords, " "))))
+
+		themeMatches := 0
+		for _, kw := range keywords {
+			if matchesThemeWord(kw, themeWords) {
+				themeMatches++
+			}
+		}
+		if themeMatches == 0 {
+			continue
+		}
+
+		memberMatches := 0
+		for _, name := range c.Patterns {
+			if matched[name] {
+				memberMatches++
+			}
+		}
+
+		themeScore := float64(themeMatches) / float64(len(keywords))
+		coverage := float64(memberMatches) / float64(len(c.Patterns))
+		score := themeScore*0.6 + coverage*0.4
+
+		// Collections are sorted by name, so ties go to the first name
+		if score > bestScore {
+			best = c
+			bestScore = score
+		}
+	}
+
+	if best == nil || bestScore < collectionMinConfidence {
+		best, bestScore = nil, 0.0
+	}
+
+	if span != nil {
+		span.SetAttribute("collections.total", fmt.Sprintf("%d", len(collections)))
+		if best != nil {
+			span.SetAttribute("recommendation.collection", best.Name)
+		}
+		span.SetAttribute("recommendation.confidence", fmt.Sprintf("%.2f", bestScore))
+		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", time.Since(startTime).Seconds()*1000))
+	}
+
+	return best, bestScore
+}
+
+// matchesThemeWord reports whether a query keyword matches any theme word, allowing
+// simple inflections ("customers" matches "customer").
+func matchesThemeWord(keyword string, themeWords []string) bool {
+	for _, w := range themeWords {
+		if w == keyword {
+			return true
+		}
+		if len(w) >= 4 && len(keyword) >= 4 && (strings.HasPrefix(keyword, w) || strings.HasPrefix(w, keyword)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCollectionLibrary(t *testing.T) *Library {
+	t.Helper()
+	patternsDir := t.TempDir()
+
+	patterns := map[string]string{
+		"churn_analysis": `name: churn_analysis
+title: Churn Analysis
+description: Analyze which customers are likely to churn
+category: analytics
+`,
+		"cohort_analysis": `name: cohort_analysis
+title: Cohort Analysis
+description: Analyze customer cohorts over time
+category: analytics
+`,
+		"customer_health_scoring": `name: customer_health_scoring
+title: Customer Health Scoring
+description: Score customer health from usage signals
+category: analytics
+`,
+		"data_validation": `name: data_validation
+title: Data Validation
+description: Validate data quality
+category: data_quality
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(patternsDir, name+".yaml"), []byte(content), 0644))
+	}
+
+	lib := NewLibrary(nil, patternsDir)
+
+	collectionsFile := filepath.Join(t.TempDir(), "collections.yaml")
+	require.NoError(t, os.WriteFile(collectionsFile, []byte(`collections:
+  - name: customer-360
+    title: Customer 360
+    description: Understand and retain customers
+    keywords: [retention, loyalty]
+    patterns: [churn_analysis, cohort_analysis, customer_health_scoring]
+`), 0644))
+	require.NoError(t, lib.LoadCollections(collectionsFile))
+	return lib
+}
+
+func TestLibrary_LoadCollections(t *testing.T) {
+	lib := setupCollectionLibrary(t)
+
+	collections := lib.Collections()
+	require.Len(t, collections, 1)
+	assert.Equal(t, "customer-360", collections[0].Name)
+	assert.Len(t, collections[0].Patterns, 3)
+
+	assert.Error(t, lib.AddCollection(&Collection{Name: "solo", Patterns: []string{"churn_analysis"}}))
+	assert.Error(t, lib.AddCollection(&Collection{Patterns: []string{"a", "b"}}))
+	assert.Error(t, lib.LoadCollections(filepath.Join(t.TempDir(), "missing.yaml")))
+}
+
+func TestOrchestrator_RecommendCollection(t *testing.T) {
+	orch := NewOrchestrator(setupCollectionLibrary(t))
+
+	t.Run("broad query matches collection", func(t *testing.T) {
+		collection, conf := orch.RecommendCollection("I need to analyze my customers")
+		require.NotNil(t, collection)
+		assert.Equal(t, "customer-360", collection.Name)
+		assert.GreaterOrEqual(t, conf, collectionMinConfidence)
+	})
+
+	t.Run("unrelated query", func(t *testing.T) {
+		collection, conf := orch.RecommendCollection("validate data quality")
+		assert.Nil(t, collection)
+		assert.Equal(t, 0.0, conf)
+	})
+
+	t.Run("no collections", func(t *testing.T) {
+		collection, _ := NewOrchestrator(NewLibrary(nil, "")).RecommendCollection("analyze customers")
+		assert.Nil(t, collection)
+	})
+}
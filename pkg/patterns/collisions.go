@@ -0,0 +1,74 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"sort"
+)
+
+// Collision is a pair of patterns whose searchable terms overlap enough that the keyword
+// scorer ranks them close together for the same queries, leaving the choice to the
+// re-ranker.
+type Collision struct {
+	A           string   `json:"a"`            // Pattern name, A < B
+	B           string   `json:"b"`            // Pattern name
+	Similarity  float64  `json:"similarity"`   // Jaccard similarity of the term sets, 0-1
+	SharedTerms []string `json:"shared_terms"` // Terms in both patterns, sorted
+}
+
+// FindCollisions compares every pair of patterns in the library and returns the pairs
+// whose term overlap is at least threshold, most similar first. Terms are the words the
+// scorer matches in the name, title, backend function, description, and use cases,
+// lowercased, without stop words and words shorter than three characters. Pairs with no
+// shared terms are never reported.
+func (l *Library) FindCollisions(threshold float64) []Collision {
+	var names []string
+	terms := make(map[string]map[string]bool)
+	for _, summary := range l.ListAll() {
+		if _, ok := terms[summary.Name]; ok {
+			continue // Same pattern for another backend
+		}
+		names = append(names, summary.Name)
+		terms[summary.Name] = summaryTerms(summary)
+	}
+	sort.Strings(names)
+
+	var collisions []Collision
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			similarity := jaccard(terms[a], terms[b])
+			if similarity == 0 || similarity < threshold {
+				continue
+			}
+			var shared []string
+			for term := range terms[a] {
+				if terms[b][term] {
+					shared = append(shared, term)
+				}
+			}
+			sort.Strings(shared)
+			collisions = append(collisions, Collision{A: a, B: b, Similarity: similarity, SharedTerms: shared})
+		}
+	}
+
+	sort.SliceStable(collisions, func(i, j int) bool {
+		return collisions[i].Similarity > collisions[j].Similarity
+	})
+	return collisions
+}
+
+// summaryTerms returns the terms of every field the scorer matches keywords against.
+func summaryTerms(s PatternSummary) map[string]bool {
+	return keywordSet(append([]string{s.Name, s.Title, s.BackendFunction, s.Description}, s.UseCases...)...)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFindCollisions(t *testing.T) {
+	lib := NewLibraryFromSource(NewFSSource(fstest.MapFS{
+		"analytics/customer_segments.yaml": {Data: []byte("name: customer_segments\ntitle: Customer Segments\ndescription: Group customers by purchase behavior\ncategory: analytics\nuse_cases:\n  - segment customers\n")},
+		"analytics/customer_value.yaml":    {Data: []byte("name: customer_value\ntitle: Customer Value\ndescription: Rank customers by purchase value\ncategory: analytics\nuse_cases:\n  - value customers\n")},
+		"ml/forecast.yaml":                 {Data: []byte("name: forecast\ntitle: Forecast\ndescription: Project revenue for future periods\ncategory: ml\n")},
+		"postgres/forecast.yaml":           {Data: []byte("name: forecast\ntitle: Forecast\ndescription: Project revenue for future periods\ncategory: ml\n")},
+	}))
+
+	collisions := lib.FindCollisions(0.3)
+	if len(collisions) != 1 {
+		t.Fatalf("FindCollisions(0.3) = %v, want one collision", collisions)
+	}
+	c := collisions[0]
+	if c.A != "customer_segments" || c.B != "customer_value" {
+		t.Errorf("collision = %s <-> %s", c.A, c.B)
+	}
+	if want := []string{"customer", "customers", "purchase"}; !reflect.DeepEqual(c.SharedTerms, want) {
+		t.Errorf("SharedTerms = %v, want %v", c.SharedTerms, want)
+	}
+	if c.Similarity < 0.3 || c.Similarity > 1 {
+		t.Errorf("Similarity = %v", c.Similarity)
+	}
+
+	if collisions := lib.FindCollisions(0.9); len(collisions) != 0 {
+		t.Errorf("FindCollisions(0.9) = %v, want none", collisions)
+	}
+	// Unrelated patterns are never reported, even at threshold 0
+	for _, c := range lib.FindCollisions(0) {
+		if len(c.SharedTerms) == 0 {
+			t.Errorf("reported %s <-> %s with no shared terms", c.A, c.B)
+		}
+	}
+}
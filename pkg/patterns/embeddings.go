@@ -0,0 +1,296 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Embedder converts texts into embedding vectors for semantic pattern retrieval.
+// Implementations must return one vector per input text, in input order.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// semanticMinSimilarity is the cosine similarity a pattern needs to become a
+// candidate when it did not match any query keyword.
+const semanticMinSimilarity = 0.3
+
+// patternEmbedding is a cached vector and the content hash it was computed from.
+type patternEmbedding struct {
+	Pattern string    `json:"pattern"`
+	Hash    string    `json:"hash"`
+	Vector  []float32 `json:"vector"`
+}
+
+// embeddingIndex holds the embedder and pattern vectors for a Library.
+// It has its own lock so embedding calls never block pattern loading.
+type embeddingIndex struct {
+	mu       sync.Mutex
+	embedder Embedder
+	cacheDir string
+	vectors  map[string]patternEmbedding // pattern name -> embedding
+}
+
+// SetEmbedder enables semantic retrieval using the given embedder.
+// Vectors are persisted in cacheDir (normally config.GetLoomSubDir("embeddings")),
+// one file per pattern content hash, so unchanged patterns are not re-embedded on
+// startup. Use a separate cacheDir per embedding model since vectors from different
+// models are not comparable. An empty cacheDir keeps vectors in memory only.
+// Passing a nil embedder disables semantic retrieval.
+func (lib *Library) SetEmbedder(embedder Embedder, cacheDir string) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if embedder == nil {
+		lib.embeddings = nil
+		return
+	}
+	lib.embeddings = &embeddingIndex{
+		embedder: embedder,
+		cacheDir: cacheDir,
+		vectors:  make(map[string]patternEmbedding),
+	}
+}
+
+// HasEmbedder reports whether semantic retrieval is enabled.
+func (lib *Library) HasEmbedder() bool {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+	return lib.embeddings != nil
+}
+
+// BuildEmbeddings embeds every indexed pattern whose content changed since it was
+// last embedded, loading persisted vectors from the cache directory where possible.
+// Calling it at startup avoids paying the embedding cost on the first query.
+func (lib *Library) BuildEmbeddings(ctx context.Context) error {
+	lib.mu.RLock()
+	idx := lib.embeddings
+	lib.mu.RUnlock()
+	if idx == nil {
+		return fmt.Errorf("no embedder configured")
+	}
+
+	startTime := time.Now()
+	_, span := lib.tracer.StartSpan(ctx, "patterns.library.build_embeddings")
+	defer lib.tracer.EndSpan(span)
+
+	computed, err := idx.refresh(ctx, lib.ListAll())
+
+	if span != nil {
+		span.SetAttribute("embeddings.computed", fmt.Sprintf("%d", computed))
+		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", time.Since(startTime).Seconds()*1000))
+	}
+	lib.tracer.RecordMetric("patterns.library.build_embeddings", 1.0, map[string]string{
+		"computed": fmt.Sprintf("%d", computed),
+		"success":  fmt.Sprintf("%t", err == nil),
+	})
+	return err
+}
+
+// SemanticScores returns the cosine similarity between the query and each embedded
+// pattern, keyed by pattern name. Stale or missing pattern vectors are refreshed first.
+func (lib *Library) SemanticScores(ctx context.Context, query string) (map[string]float64, error) {
+	lib.mu.RLock()
+	idx := lib.embeddings
+	lib.mu.RUnlock()
+	if idx == nil {
+		return nil, fmt.Errorf("no embedder configured")
+	}
+
+	if _, err := idx.refresh(ctx, lib.ListAll()); err != nil {
+		return nil, err
+	}
+
+	vectors, err := idx.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 query", len(vectors))
+	}
+	queryVec := vectors[0]
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	scores := make(map[string]float64, len(idx.vectors))
+	for name, emb := range idx.vectors {
+		scores[name] = cosineSimilarity(queryVec, emb.Vector)
+	}
+	return scores, nil
+}
+
+// refresh ensures every summary has a vector matching its current content.
+// Returns the number of vectors computed by the embedder.
+// The embedder runs without idx.mu held, so queries are scored against the existing
+// vectors while a slow embedding call is in flight. Concurrent refreshes may embed the
+// same pattern twice; either vector is valid for its content hash.
+func (idx *embeddingIndex) refresh(ctx context.Context, summaries []PatternSummary) (int, error) {
+	missing, texts := idx.pending(summaries)
+	if len(texts) == 0 {
+		return 0, nil
+	}
+
+	vectors, err := idx.embedder.Embed(ctx, texts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed %d patterns: %w", len(texts), err)
+	}
+	if len(vectors) != len(texts) {
+		return 0, fmt.Errorf("embedder returned %d vectors for %d patterns", len(vectors), len(texts))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for i, emb := range missing {
+		emb.Vector = vectors[i]
+		if current, ok := idx.vectors[emb.Pattern]; ok && current.Hash == emb.Hash {
+			continue // Installed by a concurrent refresh
+		}
+		idx.vectors[emb.Pattern] = emb
+		if err := idx.saveCached(emb); err != nil {
+			return len(texts), err
+		}
+	}
+	return len(texts), nil
+}
+
+// pending loads cached vectors for summaries whose content changed, drops vectors of
+// removed patterns, and returns the patterns still to embed with their texts.
+func (idx *embeddingIndex) pending(summaries []PatternSummary) ([]patternEmbedding, []string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	current := make(map[string]bool, len(summaries))
+	var missing []patternEmbedding
+	var texts []string
+
+	for _, summary := range summaries {
+		current[summary.Name] = true
+		text := embeddingText(summary)
+		hash := contentHash(text)
+
+		if emb, ok := idx.vectors[summary.Name]; ok && emb.Hash == hash {
+			continue
+		}
+		if emb, ok := idx.loadCached(hash); ok {
+			emb.Pattern = summary.Name
+			idx.vectors[summary.Name] = emb
+			continue
+		}
+		missing = append(missing, patternEmbedding{Pattern: summary.Name, Hash: hash})
+		texts = append(texts, text)
+	}
+
+	// Drop vectors for patterns that were removed from the library
+	for name := range idx.vectors {
+		if !current[name] {
+			delete(idx.vectors, name)
+		}
+	}
+	return missing, texts
+}
+
+// loadCached reads a persisted vector by content hash.
+func (idx *embeddingIndex) loadCached(hash string) (patternEmbedding, bool) {
+	if idx.cacheDir == "" {
+		return patternEmbedding{}, false
+	}
+	data, err := os.ReadFile(filepath.Join(idx.cacheDir, hash+".json"))
+	if err != nil {
+		return patternEmbedding{}, false
+	}
+	var emb patternEmbedding
+	if err := json.Unmarshal(data, &emb); err != nil || emb.Hash != hash || len(emb.Vector) == 0 {
+		return patternEmbedding{}, false
+	}
+	return emb, true
+}
+
+// saveCached persists a vector under its content hash.
+// Writes go through a temp file so a crash never leaves a truncated vector behind.
+func (idx *embeddingIndex) saveCached(emb patternEmbedding) error {
+	if idx.cacheDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(idx.cacheDir, 0750); err != nil {
+		return fmt.Errorf("failed to create embeddings directory: %w", err)
+	}
+
+	data, err := json.Marshal(emb)
+	if err != nil {
+		return fmt.Errorf("failed to marshal embedding for %s: %w", emb.Pattern, err)
+	}
+
+	path := filepath.Join(idx.cacheDir, emb.Hash+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write embedding for %s: %w", emb.Pattern, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write embedding for %s: %w", emb.Pattern, err)
+	}
+	return nil
+}
+
+// embeddingText builds the text embedded for a pattern from its title,
+// description, and use cases.
+func embeddingText(summary PatternSummary) string {
+	var b strings.Builder
+	b.WriteString(summary.Title)
+	if summary.Description != "" {
+		b.WriteString("\n")
+		b.WriteString(summary.Description)
+	}
+	for _, useCase := range summary.UseCases {
+		b.WriteString("\n- ")
+		b.WriteString(useCase)
+	}
+	return b.String()
+}
+
+// contentHash returns the hex SHA-256 of the embedded text.
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b,
+// or 0 if the vectors are empty, zero, or of different dimensions.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
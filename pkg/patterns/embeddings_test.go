@@ -0,0 +1,233 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conceptEmbedder maps texts onto fixed concept dimensions so paraphrases land
+// close together without a real model.
+type conceptEmbedder struct {
+	mu       sync.Mutex
+	embedded int // number of texts embedded
+	err      error
+}
+
+var embedderConcepts = [][]string{
+	{"churn", "leaving", "attrition", "retain"},
+	{"quality", "validate", "null", "duplicate"},
+}
+
+func (e *conceptEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	e.mu.Lock()
+	e.embedded += len(texts)
+	e.mu.Unlock()
+
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		lower := strings.ToLower(text)
+		vec := make([]float32, len(embedderConcepts))
+		for dim, words := range embedderConcepts {
+			for _, w := range words {
+				if strings.Contains(lower, w) {
+					vec[dim]++
+				}
+			}
+		}
+		vectors[i] = vec
+	}
+	return vectors, nil
+}
+
+func (e *conceptEmbedder) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.embedded
+}
+
+func writeSemanticPatterns(t *testing.T, dir string) {
+	t.Helper()
+	patterns := map[string]string{
+		"churn_prediction": `name: churn_prediction
+title: Churn Prediction
+description: Predict attrition risk for each account
+category: ml
+`,
+		"data_validation": `name: data_validation
+title: Data Validation
+description: Validate null and duplicate values
+category: data_quality
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644))
+	}
+}
+
+func TestOrchestrator_SemanticRetrieval(t *testing.T) {
+	patternsDir := t.TempDir()
+	writeSemanticPatterns(t, patternsDir)
+
+	lib := NewLibrary(nil, patternsDir)
+	orch := NewOrchestrator(lib)
+
+	// Keyword scoring alone has no overlap with the paraphrase
+	name, _ := orch.RecommendPattern("stop customers leaving", IntentUnknown)
+	assert.Empty(t, name)
+
+	lib.SetEmbedder(&conceptEmbedder{}, t.TempDir())
+	orch.SetSemanticWeight(0.5)
+
+	name, conf := orch.RecommendPattern("stop customers leaving", IntentUnknown)
+	assert.Equal(t, "churn_prediction", name)
+	assert.Greater(t, conf, 0.0)
+
+	// Weight 0 disables semantic retrieval
+	orch.SetSemanticWeight(0)
+	name, _ = orch.RecommendPattern("stop customers leaving", IntentUnknown)
+	assert.Empty(t, name)
+}
+
+func TestOrchestrator_SemanticRetrievalEmbedderError(t *testing.T) {
+	patternsDir := t.TempDir()
+	writeSemanticPatterns(t, patternsDir)
+
+	lib := NewLibrary(nil, patternsDir)
+	lib.SetEmbedder(&conceptEmbedder{err: fmt.Errorf("embedding service unavailable")}, "")
+	orch := NewOrchestrator(lib)
+	orch.SetSemanticWeight(0.5)
+
+	// Falls back to keyword scoring
+	name, _ := orch.RecommendPattern("validate data", IntentUnknown)
+	assert.Equal(t, "data_validation", name)
+}
+
+func TestLibrary_EmbeddingsPersisted(t *testing.T) {
+	patternsDir := t.TempDir()
+	cacheDir := t.TempDir()
+	writeSemanticPatterns(t, patternsDir)
+
+	first := &conceptEmbedder{}
+	lib := NewLibrary(nil, patternsDir)
+	lib.SetEmbedder(first, cacheDir)
+	require.NoError(t, lib.BuildEmbeddings(context.Background()))
+	assert.Equal(t, 2, first.count())
+
+	files, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// A new library with the same cache directory reuses persisted vectors
+	second := &conceptEmbedder{}
+	lib2 := NewLibrary(nil, patternsDir)
+	lib2.SetEmbedder(second, cacheDir)
+	require.NoError(t, lib2.BuildEmbeddings(context.Background()))
+	assert.Equal(t, 0, second.count())
+
+	scores, err := lib2.SemanticScores(context.Background(), "customer attrition")
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.count(), "only the query should be embedded")
+	assert.InDelta(t, 1.0, scores["churn_prediction"], 0.001)
+	assert.InDelta(t, 0.0, scores["data_validation"], 0.001)
+
+	// Changing a pattern's content re-embeds only that pattern
+	require.NoError(t, os.WriteFile(filepath.Join(patternsDir, "churn_prediction.yaml"), []byte(`name: churn_prediction
+title: Churn Prediction
+description: Predict which accounts will churn next quarter
+category: ml
+`), 0644))
+	lib2.ClearCache()
+	require.NoError(t, lib2.BuildEmbeddings(context.Background()))
+	assert.Equal(t, 2, second.count())
+}
+
+// stallingEmbedder blocks its first call until release is closed.
+type stallingEmbedder struct {
+	conceptEmbedder
+	once    sync.Once
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (e *stallingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	first := false
+	e.once.Do(func() { first = true })
+	if first {
+		close(e.entered)
+		<-e.release
+	}
+	return e.conceptEmbedder.Embed(ctx, texts)
+}
+
+func TestLibrary_EmbeddingsRefreshUnlocked(t *testing.T) {
+	patternsDir := t.TempDir()
+	writeSemanticPatterns(t, patternsDir)
+
+	embedder := &stallingEmbedder{entered: make(chan struct{}), release: make(chan struct{})}
+	lib := NewLibrary(nil, patternsDir)
+	lib.SetEmbedder(embedder, t.TempDir())
+
+	built := make(chan error, 1)
+	go func() { built <- lib.BuildEmbeddings(context.Background()) }()
+	<-embedder.entered
+
+	// A query is not held up by the stalled embedding call
+	scored := make(chan map[string]float64, 1)
+	go func() {
+		scores, err := lib.SemanticScores(context.Background(), "customer attrition")
+		assert.NoError(t, err)
+		scored <- scores
+	}()
+	select {
+	case scores := <-scored:
+		assert.InDelta(t, 1.0, scores["churn_prediction"], 0.001)
+	case <-time.After(time.Second):
+		t.Fatal("SemanticScores blocked behind an in-flight embedding call")
+	}
+
+	close(embedder.release)
+	require.NoError(t, <-built)
+	scores, err := lib.SemanticScores(context.Background(), "validate duplicates")
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, scores["data_validation"], 0.001)
+}
+
+func TestLibrary_EmbeddingsRequireEmbedder(t *testing.T) {
+	lib := NewLibrary(nil, t.TempDir())
+	assert.False(t, lib.HasEmbedder())
+	assert.Error(t, lib.BuildEmbeddings(context.Background()))
+	_, err := lib.SemanticScores(context.Background(), "query")
+	assert.Error(t, err)
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, cosineSimilarity([]float32{1, 2}, []float32{2, 4}), 0.0001)
+	assert.InDelta(t, 0.0, cosineSimilarity([]float32{1, 0}, []float32{0, 1}), 0.0001)
+	assert.Equal(t, 0.0, cosineSimilarity([]float32{1}, []float32{1, 2}))
+	assert.Equal(t, 0.0, cosineSimilarity([]float32{0, 0}, []float32{1, 2}))
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// CandidateTrace is one scored candidate in a RankingTrace.
+type CandidateTrace struct {
+	Name         string  `json:"name"`
+	Category     string  `json:"category"`
+	KeywordScore float64 `json:"keyword_score"` // Score used for ranking (blended with SemanticScore when enabled)
+
+	// SemanticScore is the embedding similarity, present only when semantic retrieval is enabled
+	SemanticScore *float64 `json:"semantic_score,omitempty"`
+}
+
+// RankingTrace records every step of a pattern selection for debugging.
+type RankingTrace struct {
+	Query  string         `json:"query"`
+	Intent IntentCategory `json:"intent"`
+
+	// Candidates are all scored patterns, highest score first
+	Candidates []CandidateTrace `json:"candidates"`
+
+	// Trigger names the re-rank trigger that fired ("unknown_intent", "low_score",
+	// "close_race", "strong_candidates"), or "" for a clear keyword winner
+	Trigger string `json:"trigger,omitempty"`
+
+	// ReRankApplied reports whether Recommend would use the re-ranker's pick
+	// (a trigger fired, a re-ranker is set, and it succeeded)
+	ReRankApplied bool `json:"rerank_applied"`
+
+	// Re-ranker step. Explain always runs the re-ranker when one is set, even for
+	// clear keyword winners, so its opinion can be compared with the keyword pick.
+	// LLMCalled reports whether the LLM re-ranker ran (its answer may come from cache).
+	ReRanker        string  `json:"reranker,omitempty"`
+	LLMCalled       bool    `json:"llm_called"`
+	LLMPattern      string  `json:"llm_pattern,omitempty"`
+	LLMConfidence   float64 `json:"llm_confidence,omitempty"`
+	LLMReasoning    string  `json:"llm_reasoning,omitempty"`
+	LLMProvider     string  `json:"llm_provider,omitempty"`
+	ReRankError     string  `json:"rerank_error,omitempty"`
+	SelectedPattern string  `json:"selected_pattern"` // What Recommend would return
+}
+
+// Explain scores userMessage like Recommend and returns the full ranking: every
+// candidate with its score, the re-rank trigger, and the re-ranker's choice and
+// reasoning. It is a diagnostics API: it always invokes the re-ranker (when one is
+// set) so results may cost an LLM call even for unambiguous queries.
+// Returns an error only if userMessage is empty.
+func (o *Orchestrator) Explain(userMessage string, intent IntentCategory) (*RankingTrace, error) {
+	return o.ExplainContext(context.Background(), userMessage, intent)
+}
+
+// ExplainContext is Explain with a caller context. The context is passed to the
+// re-ranker, so cancelling it or reaching its deadline aborts an in-flight LLM call,
+// which is reported in ReRankError.
+func (o *Orchestrator) ExplainContext(ctx context.Context, userMessage string, intent IntentCategory) (*RankingTrace, error) {
+	if userMessage == "" {
+		return nil, fmt.Errorf("user message is required")
+	}
+
+	ctx, span := o.tracer.StartSpan(ctx, "patterns.orchestrator.explain")
+	defer o.tracer.EndSpan(span)
+
+	trace := &RankingTrace{Query: userMessage, Intent: intent}
+
+	scoring := o.scoreCandidates(userMessage, []IntentCategory{intent}, "", span)
+	if len(scoring.scored) == 0 {
+		return trace, nil
+	}
+
+	categories := make(map[string]string, len(scoring.searchResults))
+	for _, summary := range scoring.searchResults {
+		categories[summary.Name] = summary.Category
+	}
+	for _, c := range scoring.scored {
+		ct := CandidateTrace{Name: c.name, Category: categories[c.name], KeywordScore: c.score}
+		if scoring.semantic != nil {
+			sim := scoring.semantic[c.name]
+			ct.SemanticScore = &sim
+		}
+		trace.Candidates = append(trace.Candidates, ct)
+	}
+	sort.SliceStable(trace.Candidates, func(i, j int) bool {
+		if trace.Candidates[i].KeywordScore != trace.Candidates[j].KeywordScore {
+			return trace.Candidates[i].KeywordScore > trace.Candidates[j].KeywordScore
+		}
+		return trace.Candidates[i].Name < trace.Candidates[j].Name
+	})
+
+	trigger := detectReRankTrigger(scoring.scored, intent)
+	trace.Trigger = trigger.String()
+	trace.SelectedPattern = scoring.scored[0].name
+
+	if o.reRanker != nil {
+		trace.ReRanker = o.reRanker.Name()
+		_, trace.LLMCalled = o.reRanker.(*LLMReRanker)
+		summaries := candidateSummaries(scoring.scored, scoring.searchResults)
+
+		var err error
+		if explainer, ok := o.reRanker.(explainingReRanker); ok {
+			var result *reRankingResult
+			result, err = explainer.reRankWithReasoning(ctx, userMessage, scoring.scored, summaries)
+			if result != nil {
+				trace.LLMPattern = result.SelectedPattern
+				trace.LLMConfidence = result.Confidence
+				trace.LLMReasoning = result.Reasoning
+				trace.LLMProvider = result.Provider
+			}
+		} else {
+			trace.LLMPattern, trace.LLMConfidence, err = o.reRanker.ReRank(ctx, userMessage, scoring.scored, summaries)
+		}
+
+		if err != nil {
+			trace.ReRankError = err.Error()
+		} else if trigger != reRankTriggerNone {
+			trace.ReRankApplied = true
+			trace.SelectedPattern = trace.LLMPattern
+		}
+	}
+
+	if span != nil {
+		span.SetAttribute("candidates.count", fmt.Sprintf("%d", len(trace.Candidates)))
+		span.SetAttribute("rerank.trigger", trace.Trigger)
+		span.SetAttribute("recommendation.pattern", trace.SelectedPattern)
+	}
+
+	return trace, nil
+}
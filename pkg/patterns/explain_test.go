@@ -0,0 +1,121 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupExplainOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	dir := t.TempDir()
+	patterns := map[string]string{
+		"revenue_report": `name: revenue_report
+title: Revenue Report
+description: Aggregate revenue totals by region
+category: analytics
+`,
+		"revenue_forecast": `name: revenue_forecast
+title: Revenue Forecast
+description: Forecast revenue
+category: timeseries
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644))
+	}
+	return NewOrchestrator(NewLibrary(nil, dir))
+}
+
+func TestOrchestrator_Explain(t *testing.T) {
+	t.Run("keyword only", func(t *testing.T) {
+		orch := setupExplainOrchestrator(t)
+
+		trace, err := orch.Explain("revenue by region", IntentAnalytics)
+		require.NoError(t, err)
+		require.Len(t, trace.Candidates, 2)
+		assert.Equal(t, "revenue_report", trace.Candidates[0].Name)
+		assert.Equal(t, "analytics", trace.Candidates[0].Category)
+		assert.Greater(t, trace.Candidates[0].KeywordScore, trace.Candidates[1].KeywordScore)
+		assert.Nil(t, trace.Candidates[0].SemanticScore)
+		assert.False(t, trace.LLMCalled)
+		assert.False(t, trace.ReRankApplied)
+		assert.Empty(t, trace.ReRanker)
+
+		name, _ := orch.RecommendPattern("revenue by region", IntentAnalytics)
+		assert.Equal(t, name, trace.SelectedPattern)
+	})
+
+	t.Run("LLM runs even without a trigger", func(t *testing.T) {
+		orch := setupExplainOrchestrator(t)
+		llm := &mockLLMProvider{
+			defaultResponse: `{"selected_pattern": "revenue_forecast", "confidence": 0.7, "reasoning": "user wants projections"}`,
+		}
+		orch.SetLLMProvider(llm)
+
+		trace, err := orch.Explain("revenue report by region", IntentAnalytics)
+		require.NoError(t, err)
+		assert.Empty(t, trace.Trigger, "clear keyword winner")
+		assert.True(t, trace.LLMCalled)
+		assert.Equal(t, "llm", trace.ReRanker)
+		assert.Equal(t, "revenue_forecast", trace.LLMPattern)
+		assert.Equal(t, 0.7, trace.LLMConfidence)
+		assert.Equal(t, "user wants projections", trace.LLMReasoning)
+		assert.Equal(t, "mock/mock-model", trace.LLMProvider)
+		assert.False(t, trace.ReRankApplied)
+		assert.Equal(t, "revenue_report", trace.SelectedPattern)
+		assert.Equal(t, 1, llm.callCount)
+	})
+
+	t.Run("trigger applies re-ranker pick", func(t *testing.T) {
+		orch := setupExplainOrchestrator(t)
+		orch.SetLLMProvider(&mockLLMProvider{
+			defaultResponse: `{"selected_pattern": "revenue_forecast", "confidence": 0.7, "reasoning": "projections"}`,
+		})
+
+		trace, err := orch.Explain("revenue by region", IntentUnknown)
+		require.NoError(t, err)
+		assert.Equal(t, "unknown_intent", trace.Trigger)
+		assert.True(t, trace.ReRankApplied)
+		assert.Equal(t, "revenue_forecast", trace.SelectedPattern)
+	})
+
+	t.Run("re-ranker error is reported", func(t *testing.T) {
+		orch := setupExplainOrchestrator(t)
+		orch.SetReRanker(&failingReRanker{})
+
+		trace, err := orch.Explain("revenue by region", IntentUnknown)
+		require.NoError(t, err)
+		assert.Equal(t, "failing", trace.ReRanker)
+		assert.Contains(t, trace.ReRankError, "unavailable")
+		assert.False(t, trace.ReRankApplied)
+	})
+
+	t.Run("no match and empty message", func(t *testing.T) {
+		orch := setupExplainOrchestrator(t)
+
+		trace, err := orch.Explain("xyzzy plugh", IntentUnknown)
+		require.NoError(t, err)
+		assert.Empty(t, trace.Candidates)
+		assert.Empty(t, trace.SelectedPattern)
+
+		_, err = orch.Explain("", IntentUnknown)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,340 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/teradata-labs/loom/pkg/config"
+)
+
+const (
+	// maxFeedbackBoost caps SetFeedbackBoost so feedback can only nudge keyword scores.
+	maxFeedbackBoost = 0.3
+
+	// feedbackPriorVotes smooths the feedback ratio: a pattern needs many more votes
+	// than this before its adjustment approaches the full boost.
+	feedbackPriorVotes = 5.0
+
+	feedbackFileName = "pattern_feedback.json"
+
+	// maxFeedbackSamples caps the scored outcomes kept per pattern for calibration;
+	// the oldest are dropped first.
+	maxFeedbackSamples = 1000
+)
+
+// FeedbackCounts holds thumbs-up and thumbs-down totals.
+type FeedbackCounts struct {
+	Positive int `json:"positive"`
+	Negative int `json:"negative"`
+}
+
+// patternFeedback is the persisted feedback for one pattern. Keywords holds counts
+// per query keyword so feedback applies to similar queries rather than all queries.
+// Samples holds the raw score of each scored vote, for FitCalibration.
+type patternFeedback struct {
+	Total    FeedbackCounts            `json:"total"`
+	Keywords map[string]FeedbackCounts `json:"keywords"`
+	Samples  []feedbackSample          `json:"samples,omitempty"`
+}
+
+// feedbackSample is a vote and the raw score the pattern had for its query.
+type feedbackSample struct {
+	Score    float64 `json:"score"`
+	Positive bool    `json:"positive"`
+}
+
+// FeedbackStore persists per-pattern user feedback as a JSON file.
+// It is safe for concurrent use.
+type FeedbackStore struct {
+	mu       sync.RWMutex
+	path     string
+	patterns map[string]*patternFeedback
+}
+
+// NewFeedbackStore opens the feedback file at path, creating it on first write.
+// An empty path uses pattern_feedback.json in config.GetLoomSubDir("feedback").
+func NewFeedbackStore(path string) (*FeedbackStore, error) {
+	if path == "" {
+		path = filepath.Join(config.GetLoomSubDir("feedback"), feedbackFileName)
+	}
+
+	store := &FeedbackStore{
+		path:     path,
+		patterns: make(map[string]*patternFeedback),
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is caller-configured
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feedback file %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &store.patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse feedback file %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Record adds one vote for the pattern against the query's keywords and saves the file.
+func (s *FeedbackStore) Record(query, pattern string, positive bool) error {
+	return s.record(query, pattern, positive, nil)
+}
+
+// RecordScore is Record for a vote whose raw keyword score is known: the score and the
+// outcome are also kept as a calibration sample (see CalibrationSamples).
+func (s *FeedbackStore) RecordScore(query, pattern string, score float64, positive bool) error {
+	return s.record(query, pattern, positive, &feedbackSample{Score: score, Positive: positive})
+}
+
+func (s *FeedbackStore) record(query, pattern string, positive bool, sample *feedbackSample) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fb, ok := s.patterns[pattern]
+	if !ok {
+		fb = &patternFeedback{Keywords: make(map[string]FeedbackCounts)}
+		s.patterns[pattern] = fb
+	}
+	if fb.Keywords == nil {
+		fb.Keywords = make(map[string]FeedbackCounts)
+	}
+
+	fb.Total = fb.Total.add(positive)
+	for _, kw := range extractQueryKeywords(query) {
+		fb.Keywords[kw] = fb.Keywords[kw].add(positive)
+	}
+	if sample != nil {
+		fb.Samples = append(fb.Samples, *sample)
+		if len(fb.Samples) > maxFeedbackSamples {
+			fb.Samples = fb.Samples[len(fb.Samples)-maxFeedbackSamples:]
+		}
+	}
+
+	return s.save()
+}
+
+// CalibrationSamples returns the scored votes of every pattern, ordered by pattern
+// name and then recording order, ready for FitCalibration.
+func (s *FeedbackStore) CalibrationSamples() []CalibrationSample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.patterns))
+	for name := range s.patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var samples []CalibrationSample
+	for _, name := range names {
+		for _, sample := range s.patterns[name].Samples {
+			samples = append(samples, CalibrationSample{Pattern: name, Score: sample.Score, Positive: sample.Positive})
+		}
+	}
+	return samples
+}
+
+// Counts returns the total feedback recorded for a pattern.
+func (s *FeedbackStore) Counts(pattern string) FeedbackCounts {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if fb, ok := s.patterns[pattern]; ok {
+		return fb.Total
+	}
+	return FeedbackCounts{}
+}
+
+// rating returns the pattern's smoothed feedback for queries sharing the given keywords,
+// in (-1, 1). Votes are averaged over the matching keywords, and feedbackPriorVotes
+// neutral votes are added so a handful of votes moves the rating only slightly.
+// Returns 0 when no recorded query shares a keyword.
+func (s *FeedbackStore) rating(pattern string, keywords []string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fb, ok := s.patterns[pattern]
+	if !ok {
+		return 0
+	}
+
+	// Average over matching keywords so one vote on a long query counts once
+	var positive, negative, matched int
+	for _, kw := range keywords {
+		counts, ok := fb.Keywords[kw]
+		if !ok {
+			continue
+		}
+		positive += counts.Positive
+		negative += counts.Negative
+		matched++
+	}
+	if matched == 0 {
+		return 0
+	}
+	p, n := float64(positive)/float64(matched), float64(negative)/float64(matched)
+	return (p - n) / (p + n + feedbackPriorVotes)
+}
+
+// save writes the store through a temp file. Caller must hold s.mu.
+func (s *FeedbackStore) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return fmt.Errorf("failed to create feedback directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.patterns, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write feedback file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write feedback file: %w", err)
+	}
+	return nil
+}
+
+func (c FeedbackCounts) add(positive bool) FeedbackCounts {
+	if positive {
+		c.Positive++
+	} else {
+		c.Negative++
+	}
+	return c
+}
+
+// SetFeedbackStore sets the store used by RecordFeedback and the feedback boost.
+// When unset, the default store under the Loom data directory is used.
+func (o *Orchestrator) SetFeedbackStore(store *FeedbackStore) {
+	o.feedbackMu.Lock()
+	defer o.feedbackMu.Unlock()
+	o.feedback = store
+}
+
+// SetFeedbackBoost enables feedback-adjusted ranking. A pattern's keyword score moves by
+// up to ±boost according to its feedback on queries sharing keywords with the current
+// one, and equal scores are broken in favor of the better-rated pattern. Negative
+// adjustments never remove more than half of a pattern's keyword score, so a few bad
+// votes cannot bury an otherwise relevant pattern. The boost is clamped to [0, 0.3];
+// 0 (the default) disables the adjustment.
+func (o *Orchestrator) SetFeedbackBoost(boost float64) {
+	if boost < 0 {
+		boost = 0
+	} else if boost > maxFeedbackBoost {
+		boost = maxFeedbackBoost
+	}
+	o.feedbackBoost = boost
+}
+
+// RecordFeedback records a thumbs-up (positive) or thumbs-down for a pattern recommended
+// for query. If no store was set with SetFeedbackStore, the default store under the
+// Loom data directory is opened on first use.
+//
+// The vote also records the pattern's raw score for the query, as Recommend computes
+// it for the classified intent before calibration and feedback, so CalibrateFromFeedback
+// can fit calibration curves from the outcomes.
+func (o *Orchestrator) RecordFeedback(query, pattern string, positive bool) error {
+	store, err := o.feedbackStore()
+	if err != nil {
+		return err
+	}
+	if score, ok := o.rawScore(query, pattern); ok {
+		return store.RecordScore(query, pattern, score, positive)
+	}
+	return store.Record(query, pattern, positive)
+}
+
+// rawScore returns the pattern's score for query before calibration and feedback are
+// applied. Returns false if the pattern is not in the library.
+func (o *Orchestrator) rawScore(query, pattern string) (float64, bool) {
+	for _, summary := range o.library.ListAll() {
+		if summary.Name != pattern {
+			continue
+		}
+		intent, _ := o.ClassifyIntent(query, nil)
+		scored := o.scorePatterns([]PatternSummary{summary}, []IntentCategory{intent},
+			extractQueryKeywords(query), strings.ToLower(query), o.semanticScores(query, nil), 1)
+		if len(scored) == 0 {
+			return 0, true
+		}
+		return scored[0].score, true
+	}
+	return 0, false
+}
+
+// feedbackStore returns the configured store, opening the default one if needed.
+func (o *Orchestrator) feedbackStore() (*FeedbackStore, error) {
+	o.feedbackMu.Lock()
+	defer o.feedbackMu.Unlock()
+
+	if o.feedback == nil {
+		store, err := NewFeedbackStore("")
+		if err != nil {
+			return nil, err
+		}
+		o.feedback = store
+	}
+	return o.feedback, nil
+}
+
+// applyFeedback adjusts scores by feedback rating and re-sorts candidates by score,
+// breaking ties by rating. No-op when the boost is 0 or the store cannot be opened.
+func (o *Orchestrator) applyFeedback(scored []scoredPattern, keywords []string) {
+	if o.feedbackBoost == 0 || len(scored) == 0 {
+		return
+	}
+	store, err := o.feedbackStore()
+	if err != nil {
+		return
+	}
+
+	ratings := make(map[string]float64, len(scored))
+	for i := range scored {
+		rating := store.rating(scored[i].name, keywords)
+		ratings[scored[i].name] = rating
+
+		adjustment := rating * o.feedbackBoost
+		if floor := -scored[i].score / 2; adjustment < floor {
+			adjustment = floor
+		}
+		scored[i].score += adjustment
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		if ratings[scored[i].name] != ratings[scored[j].name] {
+			return ratings[scored[i].name] > ratings[scored[j].name]
+		}
+		return scored[i].name < scored[j].name
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFeedbackTestOrchestrator returns an orchestrator over two patterns that tie on
+// keyword score for "revenue summary".
+func newFeedbackTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	tmpDir := t.TempDir()
+	patterns := map[string]string{
+		"revenue_summary_a": `name: revenue_summary_a
+title: Revenue Summary A
+description: Summarize revenue
+category: analytics
+`,
+		"revenue_summary_b": `name: revenue_summary_b
+title: Revenue Summary B
+description: Summarize revenue
+category: analytics
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+	return NewOrchestrator(NewLibrary(nil, tmpDir))
+}
+
+func TestFeedbackStore_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback", "fb.json")
+	store, err := NewFeedbackStore(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Record("revenue summary", "p1", true))
+	require.NoError(t, store.Record("revenue summary", "p1", false))
+	require.NoError(t, store.Record("revenue summary", "p1", true))
+	assert.Error(t, store.Record("revenue summary", "", true))
+
+	reopened, err := NewFeedbackStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, FeedbackCounts{Positive: 2, Negative: 1}, reopened.Counts("p1"))
+	assert.Equal(t, FeedbackCounts{}, reopened.Counts("missing"))
+
+	// Only queries sharing keywords are affected
+	assert.Greater(t, reopened.rating("p1", []string{"revenue"}), 0.0)
+	assert.Equal(t, 0.0, reopened.rating("p1", []string{"tables"}))
+}
+
+func TestFeedbackStore_RatingBounded(t *testing.T) {
+	store, err := NewFeedbackStore(filepath.Join(t.TempDir(), "fb.json"))
+	require.NoError(t, err)
+
+	// Two down votes on a multi-keyword query count once per vote, not per keyword
+	require.NoError(t, store.Record("monthly revenue summary report", "p1", false))
+	require.NoError(t, store.Record("monthly revenue summary report", "p1", false))
+	rating := store.rating("p1", extractQueryKeywords("monthly revenue summary report"))
+	assert.InDelta(t, -2.0/7.0, rating, 1e-9)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, store.Record("revenue", "p2", true))
+	}
+	assert.Less(t, store.rating("p2", []string{"revenue"}), 1.0)
+}
+
+func TestOrchestrator_FeedbackBoost(t *testing.T) {
+	orch := newFeedbackTestOrchestrator(t)
+	store, err := NewFeedbackStore(filepath.Join(t.TempDir(), "fb.json"))
+	require.NoError(t, err)
+	orch.SetFeedbackStore(store)
+
+	baseline, _ := orch.RecommendPattern("revenue summary", IntentAnalytics)
+	other := "revenue_summary_a"
+	if baseline == other {
+		other = "revenue_summary_b"
+	}
+
+	require.NoError(t, orch.RecordFeedback("revenue summary", other, true))
+
+	// Opt-in: feedback has no effect until a boost is set
+	name, _ := orch.RecommendPattern("revenue summary", IntentAnalytics)
+	assert.Equal(t, baseline, name)
+
+	// Tied keyword scores are decided in favor of the better-rated pattern
+	orch.SetFeedbackBoost(0.1)
+	name, _ = orch.RecommendPattern("revenue summary", IntentAnalytics)
+	assert.Equal(t, other, name)
+
+	// Unrelated queries are unaffected
+	ranked, err := orch.RecommendTopN("summarize", IntentAnalytics, 2)
+	require.NoError(t, err)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, ranked[0].KeywordScore, ranked[1].KeywordScore)
+}
+
+func TestOrchestrator_FeedbackBoostBounded(t *testing.T) {
+	orch := newFeedbackTestOrchestrator(t)
+	store, err := NewFeedbackStore(filepath.Join(t.TempDir(), "fb.json"))
+	require.NoError(t, err)
+	orch.SetFeedbackStore(store)
+
+	before, err := orch.RecommendTopN("revenue summary", IntentAnalytics, 2)
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, orch.RecordFeedback("revenue summary", "revenue_summary_a", false))
+	}
+
+	orch.SetFeedbackBoost(5) // clamped to maxFeedbackBoost
+	after, err := orch.RecommendTopN("revenue summary", IntentAnalytics, 2)
+	require.NoError(t, err)
+	require.Len(t, after, 2)
+
+	var downvoted RankedPattern
+	for _, r := range after {
+		if r.Name == "revenue_summary_a" {
+			downvoted = r
+		}
+	}
+	assert.Equal(t, "revenue_summary_b", after[0].Name)
+	assert.GreaterOrEqual(t, downvoted.KeywordScore, before[0].KeywordScore-maxFeedbackBoost)
+	assert.Greater(t, downvoted.KeywordScore, 0.0)
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// errFullTextUnavailable is returned by the full-text index when the binary was
+// built without the fts5 tag.
+var errFullTextUnavailable = errors.New("full-text search requires build with -tags fts5")
+
+// ftsDocument is the text indexed for one pattern.
+type ftsDocument struct {
+	Name        string
+	Title       string
+	Description string
+	UseCases    string
+}
+
+// FullTextSearch runs a ranked full-text query over pattern names, titles,
+// descriptions, and use cases and returns at most limit summaries (limit <= 0
+// means no limit). With the fts5 build tag this is an in-memory SQLite FTS5 index
+// ranked by BM25 with prefix matching on each query word, suitable for
+// search-as-you-type. Without the tag it degrades to the keyword scorer used by Search.
+// The FTS5 index is rebuilt lazily whenever the pattern index changes.
+func (lib *Library) FullTextSearch(query string, limit int) ([]PatternSummary, error) {
+	startTime := time.Now()
+	_, span := lib.tracer.StartSpan(context.Background(), "patterns.library.full_text_search")
+	defer lib.tracer.EndSpan(span)
+
+	if span != nil {
+		span.SetAttribute("search.query", observability.Redact(query))
+		span.SetAttribute("search.limit", fmt.Sprintf("%d", limit))
+	}
+
+	terms := ftsTerms(query)
+	if len(terms) == 0 {
+		return truncateSummaries(lib.ListAll(), limit), nil
+	}
+
+	engine := "fts5"
+	results, err := lib.ftsSearch(terms, limit)
+	if errors.Is(err, errFullTextUnavailable) {
+		engine = "keyword"
+		results, err = truncateSummaries(lib.Search(strings.Join(terms, " ")), limit), nil
+	}
+
+	duration := time.Since(startTime)
+	if span != nil {
+		span.SetAttribute("search.engine", engine)
+		span.SetAttribute("result.count", fmt.Sprintf("%d", len(results)))
+		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
+		if err != nil {
+			span.RecordError(err)
+		}
+	}
+	lib.tracer.RecordMetric("patterns.library.full_text_search", 1.0, map[string]string{
+		"engine":  engine,
+		"success": fmt.Sprintf("%t", err == nil),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ftsSearch queries the FTS5 index, rebuilding it first if the pattern index changed.
+func (lib *Library) ftsSearch(terms []string, limit int) ([]PatternSummary, error) {
+	summaries := lib.ListAll()
+
+	lib.mu.RLock()
+	version := lib.indexVersion
+	lib.mu.RUnlock()
+
+	lib.ftsMu.Lock()
+	defer lib.ftsMu.Unlock()
+
+	if lib.fts == nil {
+		fts, err := newFTSIndex()
+		if err != nil {
+			return nil, err
+		}
+		lib.fts = fts
+	}
+
+	// indexVersion starts at 1 once ListAll has built the index, so a new index is always populated
+	if lib.ftsVersion != version {
+		docs := make([]ftsDocument, 0, len(summaries))
+		for _, summary := range summaries {
+			doc := ftsDocument{
+				Name:        summary.Name,
+				Title:       summary.Title,
+				Description: summary.Description,
+				UseCases:    strings.Join(summary.UseCases, "\n"),
+			}
+			// Index the full description rather than the truncated summary text
+			lib.mu.RLock()
+			if pattern, ok := lib.patternCache[summary.Name]; ok {
+				doc.Description = pattern.Description
+			}
+			lib.mu.RUnlock()
+			docs = append(docs, doc)
+		}
+		if err := lib.fts.rebuild(docs); err != nil {
+			return nil, fmt.Errorf("failed to build full-text index: %w", err)
+		}
+		lib.ftsVersion = version
+	}
+
+	names, err := lib.fts.search(ftsMatchQuery(terms), limit)
+	if err != nil {
+		return nil, fmt.Errorf("full-text search failed: %w", err)
+	}
+
+	byName := make(map[string]PatternSummary, len(summaries))
+	for _, summary := range summaries {
+		byName[summary.Name] = summary
+	}
+	results := make([]PatternSummary, 0, len(names))
+	for _, name := range names {
+		if summary, ok := byName[name]; ok {
+			results = append(results, summary)
+		}
+	}
+	return results, nil
+}
+
+// ftsTerms splits a query into lowercase words, dropping punctuation so user
+// input can never produce FTS5 syntax errors.
+func ftsTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// ftsMatchQuery builds an FTS5 MATCH expression that ORs quoted prefix terms,
+// so BM25 ranks patterns matching more words higher.
+func ftsMatchQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + term + `"*`
+	}
+	return strings.Join(quoted, " OR ")
+}
+
+// truncateSummaries returns at most limit summaries (limit <= 0 means all).
+func truncateSummaries(summaries []PatternSummary, limit int) []PatternSummary {
+	if limit > 0 && len(summaries) > limit {
+		return summaries[:limit]
+	}
+	return summaries
+}
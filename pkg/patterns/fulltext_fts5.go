@@ -0,0 +1,103 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build fts5
+
+package patterns
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mutecomm/go-sqlcipher/v4" // SQLite driver with FTS5 support
+)
+
+// ftsIndex is an in-memory SQLite FTS5 index of pattern text.
+type ftsIndex struct {
+	db *sql.DB
+}
+
+// newFTSIndex opens an in-memory FTS5 index.
+func newFTSIndex() (*ftsIndex, error) {
+	db, err := sql.Open("sqlite3", ":memory:?_fts5=1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open full-text index: %w", err)
+	}
+	// Each connection to :memory: is a separate database, so pin to one
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE VIRTUAL TABLE patterns_fts USING fts5(
+		name,
+		title,
+		description,
+		use_cases,
+		tokenize='porter unicode61'
+	)`)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create full-text index: %w", err)
+	}
+	return &ftsIndex{db: db}, nil
+}
+
+// rebuild replaces the indexed documents.
+func (f *ftsIndex) rebuild(docs []ftsDocument) error {
+	tx, err := f.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`DELETE FROM patterns_fts`); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT INTO patterns_fts(name, title, description, use_cases) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, doc := range docs {
+		if _, err := stmt.Exec(doc.Name, doc.Title, doc.Description, doc.UseCases); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// search returns pattern names ordered by BM25 rank (title and name weighted highest).
+func (f *ftsIndex) search(match string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	rows, err := f.db.Query(`
+		SELECT name FROM patterns_fts
+		WHERE patterns_fts MATCH ?
+		ORDER BY bm25(patterns_fts, 5.0, 10.0, 2.0, 3.0)
+		LIMIT ?`, match, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
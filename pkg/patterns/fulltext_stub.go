@@ -0,0 +1,33 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !fts5
+
+package patterns
+
+// ftsIndex is a stub when built without fts5; FullTextSearch falls back to keyword search.
+type ftsIndex struct{}
+
+// newFTSIndex reports that full-text search is unavailable.
+func newFTSIndex() (*ftsIndex, error) {
+	return nil, errFullTextUnavailable
+}
+
+func (f *ftsIndex) rebuild(docs []ftsDocument) error {
+	return errFullTextUnavailable
+}
+
+func (f *ftsIndex) search(match string, limit int) ([]string, error) {
+	return nil, errFullTextUnavailable
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFullTextLibrary(t *testing.T) (*Library, string) {
+	t.Helper()
+	dir := t.TempDir()
+	patterns := map[string]string{
+		"churn_analysis": `name: churn_analysis
+title: Churn Analysis
+description: Identify customers likely to cancel their subscription
+category: analytics
+use_cases:
+  - Retention campaigns
+`,
+		"sessionize": `name: sessionize
+title: Sessionize Events
+description: Group clickstream events into sessions
+category: timeseries
+`,
+		"data_profiling": `name: data_profiling
+title: Data Profiling
+description: Profile column distributions and customer data quality
+category: data_quality
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644))
+	}
+	return NewLibrary(nil, dir), dir
+}
+
+func TestLibrary_FullTextSearch(t *testing.T) {
+	lib, dir := setupFullTextLibrary(t)
+
+	t.Run("ranks title matches first", func(t *testing.T) {
+		results, err := lib.FullTextSearch("churn customers", 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "churn_analysis", results[0].Name)
+	})
+
+	t.Run("use cases are searchable", func(t *testing.T) {
+		results, err := lib.FullTextSearch("retention", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "churn_analysis", results[0].Name)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		results, err := lib.FullTextSearch("customer", 1)
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("punctuation does not break the query", func(t *testing.T) {
+		results, err := lib.FullTextSearch(`"sessionize" AND (events*`, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "sessionize", results[0].Name)
+	})
+
+	t.Run("empty query lists all", func(t *testing.T) {
+		results, err := lib.FullTextSearch("  ", 0)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	t.Run("index follows library changes", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "forecast.yaml"), []byte(`name: forecast
+title: Demand Forecast
+description: Predict next month's demand
+category: timeseries
+`), 0644))
+		lib.ClearCache()
+
+		results, err := lib.FullTextSearch("forecast", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "forecast", results[0].Name)
+	})
+}
+
+func TestFTSTerms(t *testing.T) {
+	assert.Equal(t, []string{"churn", "analysis"}, ftsTerms(`Churn-analysis "churn"`))
+	assert.Empty(t, ftsTerms("*()"))
+	assert.Equal(t, `"churn"* OR "risk"*`, ftsMatchQuery([]string{"churn", "risk"}))
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ReRankScoreFunc scores a single candidate for a user message.
+// keywordScore is the score assigned by keyword matching. Higher return values win.
+type ReRankScoreFunc func(userMessage string, summary PatternSummary, keywordScore float64) float64
+
+// FunctionReRanker is a deterministic re-ranker that applies a scoring function
+// to each candidate instead of calling an LLM. It is intended for tests and CI,
+// where the full recommend pipeline must run offline with reproducible results.
+//
+// Example usage:
+//
+//	orchestrator.SetReRanker(NewFunctionReRanker(UseCaseCoverageScore))
+type FunctionReRanker struct {
+	scoreFunc ReRankScoreFunc
+}
+
+// NewFunctionReRanker creates a re-ranker that selects the candidate with the highest score.
+// If scoreFunc is nil, UseCaseCoverageScore is used.
+func NewFunctionReRanker(scoreFunc ReRankScoreFunc) *FunctionReRanker {
+	if scoreFunc == nil {
+		scoreFunc = UseCaseCoverageScore
+	}
+	return &FunctionReRanker{scoreFunc: scoreFunc}
+}
+
+// ReRank implements ReRanker. Ties are broken by keyword rank (earlier candidates win),
+// and the winning score is clamped to [0.0, 1.0] and returned as the confidence.
+// Returns ctx.Err() without scoring if ctx is already done.
+func (r *FunctionReRanker) ReRank(ctx context.Context, userMessage string, candidates []scoredPattern, summaries map[string]PatternSummary) (string, float64, error) {
+	if err := ctx.Err(); err != nil {
+		return "", 0.0, err
+	}
+	if len(candidates) == 0 {
+		return "", 0.0, fmt.Errorf("no candidates to re-rank")
+	}
+
+	bestName := ""
+	bestScore := 0.0
+	for i, candidate := range candidates {
+		score := r.scoreFunc(userMessage, summaries[candidate.name], candidate.score)
+		if i == 0 || score > bestScore {
+			bestName = candidate.name
+			bestScore = score
+		}
+	}
+
+	if bestScore < 0.0 {
+		bestScore = 0.0
+	}
+	if bestScore > 1.0 {
+		bestScore = 1.0
+	}
+
+	return bestName, bestScore, nil
+}
+
+// Name implements ReRanker.
+func (r *FunctionReRanker) Name() string {
+	return "function"
+}
+
+// UseCaseCoverageScore scores a candidate by the fraction of query keywords that
+// appear in its use cases. Returns 0.0 when the query has no usable keywords.
+func UseCaseCoverageScore(userMessage string, summary PatternSummary, keywordScore float64) float64 {
+	keywords := extractQueryKeywords(userMessage)
+	if len(keywords) == 0 {
+		return 0.0
+	}
+
+	useCaseText := strings.ToLower(strings.Join(summary.UseCases, " "))
+	matches := 0
+	for _, keyword := range keywords {
+		if strings.Contains(useCaseText, keyword) {
+			matches++
+		}
+	}
+
+	return float64(matches) / float64(len(keywords))
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionReRanker_UseCaseCoverage(t *testing.T) {
+	reRanker := NewFunctionReRanker(nil)
+
+	candidates := []scoredPattern{
+		{name: "generic_analytics", score: 0.8},
+		{name: "churn_forecast", score: 0.7},
+	}
+	summaries := map[string]PatternSummary{
+		"generic_analytics": {Name: "generic_analytics", UseCases: []string{"reporting"}},
+		"churn_forecast":    {Name: "churn_forecast", UseCases: []string{"customer churn", "forecasting"}},
+	}
+
+	name, confidence, err := reRanker.ReRank(context.Background(), "forecast customer churn", candidates, summaries)
+	require.NoError(t, err)
+	assert.Equal(t, "churn_forecast", name)
+	assert.InDelta(t, 1.0, confidence, 0.001)
+	assert.Equal(t, "function", reRanker.Name())
+}
+
+func TestFunctionReRanker_TieKeepsKeywordOrder(t *testing.T) {
+	reRanker := NewFunctionReRanker(func(string, PatternSummary, float64) float64 { return 0.5 })
+
+	candidates := []scoredPattern{
+		{name: "first", score: 0.6},
+		{name: "second", score: 0.6},
+	}
+
+	name, confidence, err := reRanker.ReRank(context.Background(), "anything", candidates, map[string]PatternSummary{})
+	require.NoError(t, err)
+	assert.Equal(t, "first", name)
+	assert.Equal(t, 0.5, confidence)
+}
+
+func TestFunctionReRanker_ClampsConfidence(t *testing.T) {
+	reRanker := NewFunctionReRanker(func(string, PatternSummary, float64) float64 { return 3.0 })
+
+	_, confidence, err := reRanker.ReRank(context.Background(), "anything", []scoredPattern{{name: "only", score: 0.1}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, confidence)
+}
+
+func TestFunctionReRanker_NoCandidates(t *testing.T) {
+	reRanker := NewFunctionReRanker(nil)
+
+	_, _, err := reRanker.ReRank(context.Background(), "anything", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestOrchestrator_RecommendPattern_FunctionReRanker(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"sales_report": `name: sales_report
+title: Sales Forecast Report
+description: Sales forecast reporting
+category: analytics
+use_cases:
+  - monthly summary
+`,
+		"revenue_projection": `name: revenue_projection
+title: Revenue Projection
+description: Projects revenue
+category: analytics
+use_cases:
+  - sales forecast
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+
+	lib := NewLibrary(nil, tmpDir)
+	orch := NewOrchestrator(lib)
+	orch.SetReRanker(NewFunctionReRanker(UseCaseCoverageScore))
+
+	// Unknown intent always triggers re-ranking; keyword scoring favors sales_report,
+	// but only revenue_projection covers the query in its use cases.
+	pattern, confidence := orch.RecommendPattern("sales forecast", IntentUnknown)
+	assert.Equal(t, "revenue_projection", pattern)
+	assert.InDelta(t, 1.0, confidence, 0.001)
+
+	// Repeated runs are deterministic.
+	for i := 0; i < 5; i++ {
+		again, againConf := orch.RecommendPattern("sales forecast", IntentUnknown)
+		assert.Equal(t, pattern, again)
+		assert.Equal(t, confidence, againConf)
+	}
+}
+
+func TestOrchestrator_WithReRanker(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, useCase := range map[string]string{"sales_report": "sales report", "revenue_projection": "revenue report"} {
+		content := "name: " + name + "\ncategory: analytics\nuse_cases:\n  - " + useCase + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+
+	pickProjection := NewFunctionReRanker(func(_ string, summary PatternSummary, _ float64) float64 {
+		if summary.Name == "revenue_projection" {
+			return 1.0
+		}
+		return 0.1
+	})
+	llm := &mockLLMProvider{}
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir)).WithReRanker(pickProjection)
+	orch.SetLLMProvider(llm)
+
+	// The configured re-ranker survives SetLLMProvider and decides ambiguous results
+	pattern, confidence := orch.RecommendPattern("quarterly report", IntentUnknown)
+	assert.Equal(t, "revenue_projection", pattern)
+	assert.InDelta(t, 1.0, confidence, 0.001)
+	assert.Equal(t, 0, llm.callCount, "default LLM re-ranker must not be installed over a custom one")
+
+	// Clearing it lets the provider's default LLM re-ranker take over
+	orch.SetReRanker(nil)
+	orch.SetLLMProvider(llm)
+	orch.RecommendPattern("quarterly report", IntentUnknown)
+	assert.Positive(t, llm.callCount)
+}
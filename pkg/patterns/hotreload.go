@@ -269,8 +269,9 @@ func (hr *HotReloader) handleModify(patternName, filePath string) {
 		span.SetAttribute("pattern.file", filePath)
 	}
 
-	// Validate pattern before clearing cache
-	if err := hr.validatePattern(filePath); err != nil {
+	// Validate pattern before swapping it in; on failure the last good version stays
+	pattern, err := hr.validatePattern(filePath)
+	if err != nil {
 		duration := time.Since(startTime)
 		if span != nil {
 			span.SetAttribute("validation.failed", "true")
@@ -294,12 +295,8 @@ func (hr *HotReloader) handleModify(patternName, filePath string) {
 		return
 	}
 
-	// Remove from cache - will be lazy-loaded on next access
-	hr.library.mu.Lock()
-	delete(hr.library.patternCache, patternName)
-	// Clear index to force re-indexing
-	hr.library.indexInitialized = false
-	hr.library.mu.Unlock()
+	// Swap in the new version without re-indexing the whole directory
+	hr.library.upsertPattern(patternName, pattern)
 
 	duration := time.Since(startTime)
 	if span != nil {
@@ -319,6 +316,7 @@ func (hr *HotReloader) handleModify(patternName, filePath string) {
 	if hr.config.OnUpdate != nil {
 		hr.config.OnUpdate("modify", patternName, filePath, nil)
 	}
+	hr.library.notifyReload([]string{patternName})
 }
 
 // handleCreate adds a new pattern to the library.
@@ -333,7 +331,8 @@ func (hr *HotReloader) handleCreate(patternName, filePath string) {
 	}
 
 	// Validate new pattern
-	if err := hr.validatePattern(filePath); err != nil {
+	pattern, err := hr.validatePattern(filePath)
+	if err != nil {
 		duration := time.Since(startTime)
 		if span != nil {
 			span.SetAttribute("validation.failed", "true")
@@ -357,10 +356,8 @@ func (hr *HotReloader) handleCreate(patternName, filePath string) {
 		return
 	}
 
-	// Clear index to include new pattern
-	hr.library.mu.Lock()
-	hr.library.indexInitialized = false
-	hr.library.mu.Unlock()
+	// Add the new pattern to the index
+	hr.library.upsertPattern(patternName, pattern)
 
 	duration := time.Since(startTime)
 	if span != nil {
@@ -380,6 +377,7 @@ func (hr *HotReloader) handleCreate(patternName, filePath string) {
 	if hr.config.OnUpdate != nil {
 		hr.config.OnUpdate("create", patternName, filePath, nil)
 	}
+	hr.library.notifyReload([]string{patternName})
 }
 
 // handleDelete removes a pattern from cache.
@@ -393,15 +391,11 @@ func (hr *HotReloader) handleDelete(patternName, filePath string) {
 		span.SetAttribute("pattern.file", filePath)
 	}
 
-	hr.library.mu.Lock()
-	_, existed := hr.library.patternCache[patternName]
-	delete(hr.library.patternCache, patternName)
-	hr.library.indexInitialized = false
-	hr.library.mu.Unlock()
+	existed := hr.library.removePattern(patternName)
 
 	duration := time.Since(startTime)
 	if span != nil {
-		span.SetAttribute("pattern.existed_in_cache", fmt.Sprintf("%t", existed))
+		span.SetAttribute("pattern.existed", fmt.Sprintf("%t", existed))
 		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
 	}
 
@@ -409,17 +403,21 @@ func (hr *HotReloader) handleDelete(patternName, filePath string) {
 		zap.String("pattern", patternName))
 
 	hr.tracer.RecordMetric("patterns.hotreload.delete", 1.0, map[string]string{
-		"existed_in_cache": fmt.Sprintf("%t", existed),
+		"existed": fmt.Sprintf("%t", existed),
 	})
 
 	// Notify callback of deletion
 	if hr.config.OnUpdate != nil {
 		hr.config.OnUpdate("delete", patternName, filePath, nil)
 	}
+	if existed {
+		hr.library.notifyReload([]string{patternName})
+	}
 }
 
-// validatePattern validates a pattern file before reload.
-func (hr *HotReloader) validatePattern(filePath string) error {
+// validatePattern loads and validates a pattern file before reload.
+// Returns the parsed pattern so callers can install it without reading the file again.
+func (hr *HotReloader) validatePattern(filePath string) (*Pattern, error) {
 	startTime := time.Now()
 	_, span := hr.tracer.StartSpan(context.Background(), "patterns.hotreload.validate")
 	defer hr.tracer.EndSpan(span)
@@ -443,7 +441,7 @@ func (hr *HotReloader) validatePattern(filePath string) error {
 		hr.tracer.RecordMetric("patterns.hotreload.validate", 1.0, map[string]string{
 			"result": "load_failed",
 		})
-		return fmt.Errorf("failed to load pattern: %w", err)
+		return nil, fmt.Errorf("failed to load pattern: %w", err)
 	}
 
 	// Validate required fields
@@ -458,7 +456,7 @@ func (hr *HotReloader) validatePattern(filePath string) error {
 		hr.tracer.RecordMetric("patterns.hotreload.validate", 1.0, map[string]string{
 			"result": "missing_name",
 		})
-		return err
+		return nil, err
 	}
 	if pattern.Category == "" {
 		err := fmt.Errorf("pattern.category is required")
@@ -471,7 +469,7 @@ func (hr *HotReloader) validatePattern(filePath string) error {
 		hr.tracer.RecordMetric("patterns.hotreload.validate", 1.0, map[string]string{
 			"result": "missing_category",
 		})
-		return err
+		return nil, err
 	}
 
 	// Additional validation based on pattern type
@@ -498,7 +496,7 @@ func (hr *HotReloader) validatePattern(filePath string) error {
 		"has_warnings": fmt.Sprintf("%t", hasWarnings),
 	})
 
-	return nil
+	return pattern, nil
 }
 
 // extractPatternName extracts the pattern name from file path.
@@ -589,7 +587,8 @@ func (hr *HotReloader) ManualReload(patternName string) error {
 	}
 
 	// Validate and reload
-	if err := hr.validatePattern(filePath); err != nil {
+	pattern, err := hr.validatePattern(filePath)
+	if err != nil {
 		duration := time.Since(startTime)
 		if span != nil {
 			span.SetAttribute("result", "validation_failed")
@@ -602,10 +601,7 @@ func (hr *HotReloader) ManualReload(patternName string) error {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	hr.library.mu.Lock()
-	delete(hr.library.patternCache, patternName)
-	hr.library.indexInitialized = false
-	hr.library.mu.Unlock()
+	hr.library.upsertPattern(patternName, pattern)
 
 	duration := time.Since(startTime)
 	if span != nil {
@@ -619,6 +615,66 @@ func (hr *HotReloader) ManualReload(patternName string) error {
 	hr.tracer.RecordMetric("patterns.hotreload.manual_reload", 1.0, map[string]string{
 		"result": "success",
 	})
+	hr.library.notifyReload([]string{patternName})
+
+	return nil
+}
+
+// OnReload registers a callback invoked after watched pattern files are added,
+// modified, or removed, with the names of the affected patterns. Files that fail
+// validation do not trigger the callback. The callback runs on the watcher's
+// goroutine, so it should return quickly (e.g. signal a UI refresh).
+func (lib *Library) OnReload(callback func(changed []string)) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+	lib.onReload = callback
+}
+
+// notifyReload invokes the OnReload callback, if any.
+func (lib *Library) notifyReload(changed []string) {
+	lib.mu.RLock()
+	callback := lib.onReload
+	lib.mu.RUnlock()
+	if callback != nil {
+		callback(changed)
+	}
+}
+
+// Watch starts watching the library's patterns directory and applies changes to
+// .yaml files incrementally: only the changed file is re-parsed and its index entry
+// swapped in. Malformed files are logged and the last good version is kept.
+// Watching stops when ctx is cancelled. Returns an error if the library has no
+// filesystem patterns directory or is already being watched.
+func (lib *Library) Watch(ctx context.Context) error {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if lib.watcher != nil {
+		return fmt.Errorf("pattern library is already being watched")
+	}
+
+	hr, err := NewHotReloader(lib, HotReloadConfig{
+		Enabled: true,
+		Logger:  lib.logger,
+	})
+	if err != nil {
+		return err
+	}
+	hr.WithTracer(lib.tracer)
+
+	if err := hr.Start(ctx); err != nil {
+		_ = hr.watcher.Close()
+		return err
+	}
+	lib.watcher = hr
+
+	go func() {
+		<-ctx.Done()
+		_ = hr.Stop()
+		lib.mu.Lock()
+		lib.watcher = nil
+		lib.mu.Unlock()
+	}()
 
 	return nil
 }
@@ -15,6 +15,7 @@ package patterns
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -251,11 +252,8 @@ description: Missing name
 	cached, inCache := library.patternCache["valid_pattern"]
 	library.mu.RUnlock()
 
-	// NOTE: Invalid patterns cause cache eviction, so this test verifies
-	// that the library doesn't crash on invalid patterns
-	if inCache {
-		assert.Equal(t, "Valid Pattern", cached.Title)
-	}
+	require.True(t, inCache, "Last good version should be kept")
+	assert.Equal(t, "Valid Pattern", cached.Title)
 }
 
 func TestHotReloader_Debouncing(t *testing.T) {
@@ -552,3 +550,84 @@ func TestHotReloader_StopTimeout(t *testing.T) {
 	err = hr.Stop()
 	require.NoError(t, err)
 }
+
+func TestLibrary_Watch(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writePattern := func(name, title string) {
+		content := fmt.Sprintf("name: %s\ntitle: %s\ndescription: Watched pattern\ncategory: analytics\n", name, title)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+	writePattern("watched_a", "Original A")
+	writePattern("watched_b", "Original B")
+
+	library := NewLibrary(nil, tmpDir)
+	require.Len(t, library.ListAll(), 2)
+
+	changes := make(chan []string, 10)
+	library.OnReload(func(changed []string) {
+		changes <- changed
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, library.Watch(ctx))
+	assert.Error(t, library.Watch(ctx), "second Watch should fail")
+
+	time.Sleep(200 * time.Millisecond)
+
+	waitForChange := func() []string {
+		select {
+		case changed := <-changes:
+			return changed
+		case <-time.After(3 * time.Second):
+			t.Fatal("timed out waiting for reload")
+			return nil
+		}
+	}
+
+	titles := func() map[string]string {
+		result := make(map[string]string)
+		for _, s := range library.ListAll() {
+			result[s.Name] = s.Title
+		}
+		return result
+	}
+
+	// Modify: only the changed entry is swapped in
+	writePattern("watched_a", "Updated A")
+	assert.Equal(t, []string{"watched_a"}, waitForChange())
+	assert.Equal(t, map[string]string{"watched_a": "Updated A", "watched_b": "Original B"}, titles())
+	pattern, err := library.Load("watched_a")
+	require.NoError(t, err)
+	assert.Equal(t, "Updated A", pattern.Title)
+
+	// Malformed file: last good version is kept and no reload fires
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "watched_b.yaml"), []byte("name: [unterminated\n"), 0644))
+	time.Sleep(1 * time.Second)
+	assert.Empty(t, changes)
+	assert.Equal(t, "Original B", titles()["watched_b"])
+
+	// Create
+	writePattern("watched_c", "New C")
+	assert.Equal(t, []string{"watched_c"}, waitForChange())
+	assert.Equal(t, "New C", titles()["watched_c"])
+
+	// Delete
+	require.NoError(t, os.Remove(filepath.Join(tmpDir, "watched_c.yaml")))
+	assert.Equal(t, []string{"watched_c"}, waitForChange())
+	assert.NotContains(t, titles(), "watched_c")
+
+	// Cancelling the context stops the watcher so Watch can be called again
+	cancel()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	assert.Eventually(t, func() bool {
+		return library.Watch(ctx2) == nil
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestLibrary_WatchRequiresPatternsDir(t *testing.T) {
+	library := NewLibrary(nil, "")
+	assert.Error(t, library.Watch(context.Background()))
+}
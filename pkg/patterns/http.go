@@ -0,0 +1,185 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTP API paths served by NewHTTPHandler.
+const (
+	HTTPPathRecommend     = "/v1/patterns:recommend"
+	HTTPPathRecommendTopN = "/v1/patterns:recommendTopN"
+	HTTPPathExplain       = "/v1/patterns:explain"
+)
+
+const (
+	// defaultHTTPTopN is the number of candidates returned when a RecommendTopN request
+	// does not set n.
+	defaultHTTPTopN = 5
+
+	// maxHTTPRequestBytes caps request bodies.
+	maxHTTPRequestBytes = 1 << 20
+)
+
+// errInvalidHTTPRequest marks serve errors caused by the request, which get a 400.
+var errInvalidHTTPRequest = errors.New("invalid request")
+
+// RecommendHTTPRequest is the JSON body of every pattern API request.
+type RecommendHTTPRequest struct {
+	Message string         `json:"message"`
+	Intent  IntentCategory `json:"intent,omitempty"` // Classified from the message when empty
+	N       int            `json:"n,omitempty"`      // RecommendTopN only (default: 5)
+
+	// TimeoutMs shortens the server's request timeout for this request; it cannot
+	// extend it. The timeout bounds the re-ranker, so an LLM call that runs out of time
+	// falls back to keyword scoring (Explain reports it in rerank_error).
+	TimeoutMs int64 `json:"timeout_ms,omitempty"`
+}
+
+// RecommendHTTPResponse is the response to HTTPPathRecommend: the Recommendation, plus
+// the intent it was made for.
+type RecommendHTTPResponse struct {
+	Intent           IntentCategory `json:"intent"`
+	IntentConfidence float64        `json:"intent_confidence,omitempty"` // Set when the intent was classified
+	Recommendation
+}
+
+// RecommendTopNHTTPResponse is the response to HTTPPathRecommendTopN.
+type RecommendTopNHTTPResponse struct {
+	Intent           IntentCategory  `json:"intent"`
+	IntentConfidence float64         `json:"intent_confidence,omitempty"` // Set when the intent was classified
+	Patterns         []RankedPattern `json:"patterns"`
+}
+
+// NewHTTPHandler serves o's pattern selection as a JSON API, so clients in other
+// languages can use it without embedding Go. Each endpoint takes a POST with a
+// RecommendHTTPRequest body:
+//
+//   - HTTPPathRecommend returns a RecommendHTTPResponse (see Orchestrator.Recommend)
+//   - HTTPPathRecommendTopN returns a RecommendTopNHTTPResponse (see RecommendTopN)
+//   - HTTPPathExplain returns a RankingTrace, classifying the intent first if it is
+//     not given (see Explain)
+//
+// timeout bounds each request, including intent classification and any LLM re-ranking
+// (0 = no limit). Errors have a JSON {"error": "..."} body: 400 for invalid requests, 504
+// when the request times out before a result is available, and 500 otherwise.
+func NewHTTPHandler(o *Orchestrator, timeout time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(path string, serve func(ctx context.Context, req RecommendHTTPRequest, intent IntentCategory, intentConfidence float64) (any, error)) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				writeHTTPError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+				return
+			}
+
+			var req RecommendHTTPRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPRequestBytes)).Decode(&req); err != nil {
+				writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+				return
+			}
+			if req.Message == "" {
+				writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("message is required"))
+				return
+			}
+
+			ctx := r.Context()
+			requestTimeout := timeout
+			if req.TimeoutMs > 0 {
+				if t := time.Duration(req.TimeoutMs) * time.Millisecond; requestTimeout == 0 || t < requestTimeout {
+					requestTimeout = t
+				}
+			}
+			if requestTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, requestTimeout)
+				defer cancel()
+			}
+
+			intent, intentConfidence := req.Intent, 0.0
+			if intent == "" {
+				var err error
+				intent, intentConfidence, err = o.ClassifyIntentContext(ctx, req.Message, nil)
+				if err != nil {
+					writeHTTPError(w, httpErrorStatus(err), fmt.Errorf("intent classification failed: %w", err))
+					return
+				}
+			}
+
+			resp, err := serve(ctx, req, intent, intentConfidence)
+			if err != nil {
+				writeHTTPError(w, httpErrorStatus(err), err)
+				return
+			}
+			writeHTTPJSON(w, http.StatusOK, resp)
+		})
+	}
+
+	handle(HTTPPathRecommend, func(ctx context.Context, req RecommendHTTPRequest, intent IntentCategory, intentConfidence float64) (any, error) {
+		return RecommendHTTPResponse{
+			Intent:           intent,
+			IntentConfidence: intentConfidence,
+			Recommendation:   o.RecommendContext(ctx, req.Message, intent),
+		}, nil
+	})
+	handle(HTTPPathRecommendTopN, func(ctx context.Context, req RecommendHTTPRequest, intent IntentCategory, intentConfidence float64) (any, error) {
+		n := req.N
+		if n < 0 {
+			return nil, fmt.Errorf("%w: n must be positive, got %d", errInvalidHTTPRequest, n)
+		}
+		if n == 0 {
+			n = defaultHTTPTopN
+		}
+		ranked, err := o.RecommendTopNContext(ctx, req.Message, intent, n)
+		if err != nil {
+			return nil, err
+		}
+		if ranked == nil {
+			ranked = []RankedPattern{}
+		}
+		return RecommendTopNHTTPResponse{Intent: intent, IntentConfidence: intentConfidence, Patterns: ranked}, nil
+	})
+	handle(HTTPPathExplain, func(ctx context.Context, req RecommendHTTPRequest, intent IntentCategory, _ float64) (any, error) {
+		return o.ExplainContext(ctx, req.Message, intent)
+	})
+	return mux
+}
+
+// httpErrorStatus returns the status code for an error from serving a request.
+func httpErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errInvalidHTTPRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeHTTPJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeHTTPError(w http.ResponseWriter, code int, err error) {
+	writeHTTPJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingReRanker waits for its context to end.
+type blockingReRanker struct{}
+
+func (blockingReRanker) ReRank(ctx context.Context, _ string, _ []scoredPattern, _ map[string]PatternSummary) (string, float64, error) {
+	<-ctx.Done()
+	return "", 0, ctx.Err()
+}
+
+func (blockingReRanker) Name() string { return "blocking" }
+
+func postPatternAPI(t *testing.T, h http.Handler, path, body string, out any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	if out != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out), rec.Body.String())
+	}
+	return rec.Code
+}
+
+func TestHTTPHandler(t *testing.T) {
+	h := NewHTTPHandler(setupExplainOrchestrator(t), time.Second)
+
+	var rec RecommendHTTPResponse
+	require.Equal(t, http.StatusOK, postPatternAPI(t, h, HTTPPathRecommend, `{"message": "revenue by region", "intent": "analytics"}`, &rec))
+	assert.Equal(t, IntentAnalytics, rec.Intent)
+	assert.Equal(t, "revenue_report", rec.PatternName)
+	assert.Greater(t, rec.Confidence, 0.0)
+	assert.NotEmpty(t, rec.Reason)
+
+	var topN RecommendTopNHTTPResponse
+	require.Equal(t, http.StatusOK, postPatternAPI(t, h, HTTPPathRecommendTopN, `{"message": "revenue by region", "intent": "analytics", "n": 1}`, &topN))
+	require.Len(t, topN.Patterns, 1)
+	assert.Equal(t, "revenue_report", topN.Patterns[0].Name)
+
+	var trace RankingTrace
+	require.Equal(t, http.StatusOK, postPatternAPI(t, h, HTTPPathExplain, `{"message": "revenue by region", "intent": "analytics"}`, &trace))
+	assert.Len(t, trace.Candidates, 2)
+	assert.Equal(t, "revenue_report", trace.SelectedPattern)
+
+	// The intent is classified when not given
+	require.Equal(t, http.StatusOK, postPatternAPI(t, h, HTTPPathRecommend, `{"message": "revenue by region"}`, &rec))
+	assert.NotEmpty(t, rec.Intent)
+
+	var apiErr map[string]string
+	assert.Equal(t, http.StatusBadRequest, postPatternAPI(t, h, HTTPPathRecommend, `{"intent": "analytics"}`, &apiErr))
+	assert.Equal(t, "message is required", apiErr["error"])
+	assert.Equal(t, http.StatusBadRequest, postPatternAPI(t, h, HTTPPathRecommendTopN, `{"message": "revenue", "n": -1}`, &apiErr))
+	assert.Contains(t, apiErr["error"], "n must be positive")
+	assert.Equal(t, http.StatusBadRequest, postPatternAPI(t, h, HTTPPathRecommend, `not json`, &apiErr))
+
+	get := httptest.NewRecorder()
+	h.ServeHTTP(get, httptest.NewRequest(http.MethodGet, HTTPPathRecommend, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, get.Code)
+}
+
+func TestHTTPHandler_Timeout(t *testing.T) {
+	orch := setupExplainOrchestrator(t)
+	orch.SetReRanker(blockingReRanker{})
+	h := NewHTTPHandler(orch, time.Minute)
+
+	// The request timeout bounds the re-ranker
+	start := time.Now()
+	var trace RankingTrace
+	require.Equal(t, http.StatusOK, postPatternAPI(t, h, HTTPPathExplain, `{"message": "revenue by region", "intent": "analytics", "timeout_ms": 20}`, &trace))
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Equal(t, "blocking", trace.ReRanker)
+	assert.Contains(t, trace.ReRankError, context.DeadlineExceeded.Error())
+	assert.Equal(t, "revenue_report", trace.SelectedPattern)
+}
+
+func TestHTTPHandler_ClassifyTimeout(t *testing.T) {
+	orch := setupExplainOrchestrator(t)
+	release := make(chan struct{})
+	defer close(release)
+	orch.SetIntentClassifier(func(string, map[string]interface{}) (IntentCategory, float64) {
+		<-release
+		return IntentAnalytics, 0.9
+	})
+	h := NewHTTPHandler(orch, time.Minute)
+
+	// Classification runs under the request timeout and reports it as a gateway timeout
+	start := time.Now()
+	var apiErr map[string]string
+	assert.Equal(t, http.StatusGatewayTimeout, postPatternAPI(t, h, HTTPPathRecommend, `{"message": "revenue by region", "timeout_ms": 20}`, &apiErr))
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Contains(t, apiErr["error"], context.DeadlineExceeded.Error())
+
+	// A given intent skips classification
+	assert.Equal(t, http.StatusOK, postPatternAPI(t, h, HTTPPathRecommend, `{"message": "revenue by region", "intent": "analytics", "timeout_ms": 20}`, nil))
+}
+
+func TestHTTPErrorStatus(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, httpErrorStatus(fmt.Errorf("%w: bad n", errInvalidHTTPRequest)))
+	assert.Equal(t, http.StatusGatewayTimeout, httpErrorStatus(fmt.Errorf("re-ranking: %w", context.DeadlineExceeded)))
+	assert.Equal(t, http.StatusInternalServerError, httpErrorStatus(fmt.Errorf("library unavailable")))
+}
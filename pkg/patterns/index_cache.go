@@ -0,0 +1,229 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/teradata-labs/loom/pkg/config"
+	"github.com/teradata-labs/loom/pkg/observability"
+	"go.uber.org/zap"
+)
+
+// indexCacheVersion is bumped whenever PatternSummary or the cache layout changes,
+// so caches written by older binaries are rebuilt rather than misread.
+const indexCacheVersion = 3
+
+// indexCacheFile is the on-disk form of a filesystem pattern index.
+type indexCacheFile struct {
+	Version   int               `json:"version"`
+	Dir       string            `json:"dir"`
+	Key       string            `json:"key"`
+	Summaries []PatternSummary  `json:"summaries"`
+	Paths     map[string]string `json:"paths"` // pattern name -> path relative to Dir
+}
+
+// EnableIndexCache persists the filesystem pattern index in dir so later processes can
+// skip re-parsing every pattern YAML file at startup. An empty dir uses
+// config.GetLoomSubDir("cache"). The cache is keyed by the path, size, and modification
+// time of every pattern file, so adding, removing, or editing any file invalidates it.
+// Embedded patterns are always indexed from the binary.
+func (lib *Library) EnableIndexCache(dir string) {
+	if dir == "" {
+		dir = config.GetLoomSubDir("cache")
+	}
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+	lib.indexCacheDir = dir
+}
+
+// RebuildIndex discards the in-memory index and pattern cache, re-parses every pattern,
+// and rewrites the on-disk index cache when one is enabled.
+func (lib *Library) RebuildIndex() error {
+	lib.mu.RLock()
+	cacheDir, patternsDir := lib.indexCacheDir, lib.patternsDir
+	lib.mu.RUnlock()
+
+	if cacheDir != "" && patternsDir != "" {
+		path, err := indexCachePath(cacheDir, patternsDir)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove index cache: %w", err)
+		}
+	}
+
+	lib.ClearCache()
+	lib.ListAll()
+	return nil
+}
+
+// indexFilesystemCached returns the filesystem index from the on-disk cache when it is
+// still valid, and otherwise re-indexes the directory and rewrites the cache.
+func (lib *Library) indexFilesystemCached(span *observability.Span) []PatternSummary {
+	lib.mu.RLock()
+	cacheDir, patternsDir := lib.indexCacheDir, lib.patternsDir
+	lib.mu.RUnlock()
+
+	if cacheDir == "" {
+		return lib.indexFilesystem()
+	}
+
+	logger := lib.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	path, err := indexCachePath(cacheDir, patternsDir)
+	if err != nil {
+		logger.Warn("Pattern index cache disabled", zap.Error(err))
+		return lib.indexFilesystem()
+	}
+	key, err := directoryIndexKey(patternsDir)
+	if err != nil {
+		logger.Warn("Failed to fingerprint patterns directory", zap.String("dir", patternsDir), zap.Error(err))
+		return lib.indexFilesystem()
+	}
+
+	if cached, ok := loadIndexCache(path, patternsDir, key); ok {
+		lib.mu.Lock()
+		for name, relPath := range cached.Paths {
+			lib.pathCache[name] = relPath
+		}
+		lib.mu.Unlock()
+		if span != nil {
+			span.SetAttribute("index.disk_cache", "hit")
+		}
+		return cached.Summaries
+	}
+
+	summaries := lib.indexFilesystem()
+	if span != nil {
+		span.SetAttribute("index.disk_cache", "miss")
+	}
+
+	paths := make(map[string]string, len(summaries))
+	lib.mu.RLock()
+	for _, summary := range summaries {
+		if relPath, ok := lib.pathCache[summary.Name]; ok {
+			paths[summary.Name] = relPath
+		}
+	}
+	lib.mu.RUnlock()
+
+	// Re-fingerprint so files changed while indexing are not cached under a stale key
+	if after, err := directoryIndexKey(patternsDir); err != nil || after != key {
+		return summaries
+	}
+
+	if err := saveIndexCache(path, indexCacheFile{
+		Version:   indexCacheVersion,
+		Dir:       patternsDir,
+		Key:       key,
+		Summaries: summaries,
+		Paths:     paths,
+	}); err != nil {
+		logger.Warn("Failed to write pattern index cache", zap.String("path", path), zap.Error(err))
+	}
+	return summaries
+}
+
+// indexCachePath returns the cache file for a patterns directory. Each directory gets
+// its own file so libraries over different directories do not evict each other.
+func indexCachePath(cacheDir, patternsDir string) (string, error) {
+	abs, err := filepath.Abs(patternsDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve patterns directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(cacheDir, "pattern-index-"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// directoryIndexKey fingerprints every pattern file under dir by relative path, size,
+// and modification time. Walk order is lexical, so the key is deterministic.
+func directoryIndexKey(dir string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d\n", indexCacheVersion)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".yaml") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", rel, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// loadIndexCache reads a cache file and reports whether it matches dir and key.
+func loadIndexCache(path, dir, key string) (indexCacheFile, bool) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built from the configured cache directory
+	if err != nil {
+		return indexCacheFile{}, false
+	}
+	var cached indexCacheFile
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return indexCacheFile{}, false
+	}
+	if cached.Version != indexCacheVersion || cached.Dir != dir || cached.Key != key {
+		return indexCacheFile{}, false
+	}
+	if cached.Summaries == nil {
+		cached.Summaries = make([]PatternSummary, 0)
+	}
+	return cached, true
+}
+
+// saveIndexCache writes a cache file through a temp file so readers never see a partial index.
+func saveIndexCache(path string, cached indexCacheFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pattern index: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pattern index: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write pattern index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIndexCacheTestPattern(t *testing.T, dir, name, title string) {
+	t.Helper()
+	content := "name: " + name + "\ntitle: " + title + "\ndescription: test\ncategory: analytics\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644))
+}
+
+// tamperIndexCache rewrites every cached title so tests can tell cache hits from re-indexing.
+func tamperIndexCache(t *testing.T, path string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var cached indexCacheFile
+	require.NoError(t, json.Unmarshal(data, &cached))
+	for i := range cached.Summaries {
+		cached.Summaries[i].Title = "from cache"
+	}
+	data, err = json.Marshal(cached)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func summaryTitles(summaries []PatternSummary) map[string]string {
+	result := make(map[string]string, len(summaries))
+	for _, s := range summaries {
+		result[s.Name] = s.Title
+	}
+	return result
+}
+
+func TestLibrary_IndexCache(t *testing.T) {
+	patternsDir := t.TempDir()
+	cacheDir := t.TempDir()
+	writeIndexCacheTestPattern(t, patternsDir, "alpha", "Alpha")
+	require.NoError(t, os.MkdirAll(filepath.Join(patternsDir, "analytics"), 0755))
+	writeIndexCacheTestPattern(t, filepath.Join(patternsDir, "analytics"), "beta", "Beta")
+
+	first := NewLibrary(nil, patternsDir)
+	first.EnableIndexCache(cacheDir)
+	assert.Equal(t, map[string]string{"alpha": "Alpha", "beta": "Beta"}, summaryTitles(first.ListAll()))
+
+	cachePath, err := indexCachePath(cacheDir, patternsDir)
+	require.NoError(t, err)
+	require.FileExists(t, cachePath)
+
+	// A new library over the unchanged directory is served from the cache
+	tamperIndexCache(t, cachePath)
+	second := NewLibrary(nil, patternsDir)
+	second.EnableIndexCache(cacheDir)
+	assert.Equal(t, map[string]string{"alpha": "from cache", "beta": "from cache"}, summaryTitles(second.ListAll()))
+
+	// Patterns in subdirectories still load through the cached paths
+	pattern, err := second.Load("beta")
+	require.NoError(t, err)
+	assert.Equal(t, "Beta", pattern.Title)
+
+	// Editing any file invalidates the cache
+	writeIndexCacheTestPattern(t, patternsDir, "alpha", "Alpha v2")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(patternsDir, "alpha.yaml"), future, future))
+
+	third := NewLibrary(nil, patternsDir)
+	third.EnableIndexCache(cacheDir)
+	assert.Equal(t, map[string]string{"alpha": "Alpha v2", "beta": "Beta"}, summaryTitles(third.ListAll()))
+
+	// Adding a file invalidates it too
+	writeIndexCacheTestPattern(t, patternsDir, "gamma", "Gamma")
+	fourth := NewLibrary(nil, patternsDir)
+	fourth.EnableIndexCache(cacheDir)
+	assert.Len(t, fourth.ListAll(), 3)
+}
+
+func TestLibrary_RebuildIndex(t *testing.T) {
+	patternsDir := t.TempDir()
+	cacheDir := t.TempDir()
+	writeIndexCacheTestPattern(t, patternsDir, "alpha", "Alpha")
+
+	lib := NewLibrary(nil, patternsDir)
+	lib.EnableIndexCache(cacheDir)
+	lib.ListAll()
+
+	cachePath, err := indexCachePath(cacheDir, patternsDir)
+	require.NoError(t, err)
+	tamperIndexCache(t, cachePath)
+
+	lib = NewLibrary(nil, patternsDir)
+	lib.EnableIndexCache(cacheDir)
+	assert.Equal(t, "from cache", lib.ListAll()[0].Title)
+
+	require.NoError(t, lib.RebuildIndex())
+	assert.Equal(t, "Alpha", lib.ListAll()[0].Title)
+
+	// The rewritten cache holds the fresh index
+	fresh := NewLibrary(nil, patternsDir)
+	fresh.EnableIndexCache(cacheDir)
+	assert.Equal(t, "Alpha", fresh.ListAll()[0].Title)
+}
+
+func TestLibrary_IndexCacheDisabledByDefault(t *testing.T) {
+	patternsDir := t.TempDir()
+	writeIndexCacheTestPattern(t, patternsDir, "alpha", "Alpha")
+
+	lib := NewLibrary(nil, patternsDir)
+	assert.Len(t, lib.ListAll(), 1)
+	require.NoError(t, lib.RebuildIndex())
+	assert.Len(t, lib.ListAll(), 1)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+//go:build integration
+// +build integration
+
+package patterns
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/llm/bedrock"
+	"github.com/teradata-labs/loom/pkg/llm/ollama"
+	"github.com/teradata-labs/loom/pkg/types"
+)
+
+// newIntegrationLLM returns the LLM provider for the integration tests, skipping the
+// test when it is unreachable. Bedrock is used by default; set
+// LOOM_TEST_LLM_PROVIDER=ollama to run against a local Ollama server instead
+// (OLLAMA_ENDPOINT and OLLAMA_MODEL override the endpoint and model).
+func newIntegrationLLM(t *testing.T) types.LLMProvider {
+	t.Helper()
+
+	var provider types.LLMProvider
+	switch name := os.Getenv("LOOM_TEST_LLM_PROVIDER"); name {
+	case "", "bedrock":
+		region := os.Getenv("AWS_REGION")
+		if region == "" {
+			region = "us-west-2" // Default
+		}
+		t.Logf("🔧 Setting up Bedrock LLM (region: %s)", region)
+
+		client, err := bedrock.NewClient(bedrock.Config{
+			Region:      region,
+			ModelID:     bedrock.DefaultBedrockModelID, // Claude Sonnet 4.5
+			MaxTokens:   1000,                          // Small for intent classification
+			Temperature: 0.7,
+		})
+		if err != nil {
+			t.Skipf("Skipping test - Bedrock client creation failed (credentials may be unavailable): %v", err)
+		}
+		provider = client
+
+	case "ollama":
+		model := os.Getenv("OLLAMA_MODEL")
+		if model == "" {
+			model = "llama3.1"
+		}
+		t.Logf("🔧 Setting up Ollama LLM (model: %s)", model)
+
+		provider = ollama.NewClient(ollama.Config{
+			Endpoint:    os.Getenv("OLLAMA_ENDPOINT"), // Default: http://localhost:11434
+			Model:       model,
+			MaxTokens:   1000,
+			Temperature: 0.7,
+			ToolMode:    ollama.ToolModePrompt, // Classification and re-ranking never use tools
+		})
+
+	default:
+		t.Fatalf("Unsupported LOOM_TEST_LLM_PROVIDER %q (supported: bedrock, ollama)", name)
+	}
+
+	// Test that the provider is reachable with a simple call. Local models can be
+	// slow to load on first use.
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	testMsg := []types.Message{{Role: "user", Content: "test"}}
+	if _, err := provider.Chat(ctx, testMsg, nil); err != nil {
+		t.Skipf("Skipping test - %s provider unavailable: %v", provider.Name(), err)
+	}
+
+	t.Logf("✅ %s client created and verified (model: %s)", provider.Name(), provider.Model())
+	return provider
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import "sort"
+
+// intentMismatchPenalty is the fraction of its score a pattern keeps when the
+// classified intent maps to categories and the pattern's category is not one of them.
+const intentMismatchPenalty = 0.5
+
+// DefaultIntentCategories returns the default mapping from intents to the pattern
+// categories that serve them. Intents without an entry (such as IntentQueryGeneration)
+// only match a category of the same name and never down-weight other patterns.
+// The returned map is a fresh copy and may be modified before passing it to
+// WithIntentCategories.
+func DefaultIntentCategories() map[IntentCategory][]Category {
+	return map[IntentCategory][]Category{
+		IntentAnalytics:         {CategoryAnalytics, CategoryAggregation, CategoryReporting},
+		IntentDataQuality:       {CategoryDataQuality, CategoryValidation},
+		IntentDataTransform:     {CategoryETL, CategoryDataTransform},
+		IntentSchemaDiscovery:   {CategorySchema, CategoryMetadata, CategoryDataDiscovery},
+		IntentRelationshipQuery: {CategoryDataModeling},
+		IntentDocumentSearch:    {CategoryDocument, CategoryText},
+		IntentAPICall:           {CategoryRESTAPI},
+	}
+}
+
+// intentCategoryIndex is the lookup form of an intent-to-category mapping.
+// Categories are stored canonicalized so aliases in the mapping match loaded patterns.
+type intentCategoryIndex map[IntentCategory]map[Category]bool
+
+func newIntentCategoryIndex(mapping map[IntentCategory][]Category) intentCategoryIndex {
+	index := make(intentCategoryIndex, len(mapping))
+	for intent, cats := range mapping {
+		if len(cats) == 0 {
+			continue
+		}
+		set := make(map[Category]bool, len(cats))
+		for _, c := range cats {
+			set[canonicalCategory(string(c))] = true
+		}
+		index[intent] = set
+	}
+	return index
+}
+
+// mapping returns the index as a map of sorted category lists.
+func (idx intentCategoryIndex) mapping() map[IntentCategory][]Category {
+	mapping := make(map[IntentCategory][]Category, len(idx))
+	for intent, set := range idx {
+		cats := make([]Category, 0, len(set))
+		for c := range set {
+			cats = append(cats, c)
+		}
+		sort.Slice(cats, func(i, j int) bool { return cats[i] < cats[j] })
+		mapping[intent] = cats
+	}
+	return mapping
+}
+
+// matches reports whether a pattern category serves intent: it is in the intent's
+// mapped categories, or, for an unmapped intent, has the intent's name.
+func (idx intentCategoryIndex) matches(category string, intent IntentCategory) bool {
+	c := normalizeCategory(category)
+	if set, mapped := idx[intent]; mapped {
+		return set[c]
+	}
+	return c == normalizeCategory(string(intent))
+}
+
+// mismatched reports whether a category falls outside every mapped intent in
+// intents. It is false when no intent is mapped, so unmapped and unknown intents
+// never down-weight a pattern.
+func (idx intentCategoryIndex) mismatched(category string, intents []IntentCategory) bool {
+	mapped := false
+	for _, intent := range intents {
+		if idx.matches(category, intent) {
+			return false
+		}
+		if _, ok := idx[intent]; ok {
+			mapped = true
+		}
+	}
+	return mapped
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func intentCategoryTestLibrary(t *testing.T) *Library {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"revenue_report": `name: revenue_report
+title: Revenue Report
+description: Aggregate revenue totals
+category: analytics
+use_cases:
+  - revenue totals
+`,
+		"revenue_query_tuning": `name: revenue_query_tuning
+title: Revenue Query Tuning
+description: Speed up slow revenue queries
+category: performance
+use_cases:
+  - slow revenue queries
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+	return NewLibrary(nil, tmpDir)
+}
+
+func keywordScores(t *testing.T, orch *Orchestrator, query string, intent IntentCategory) map[string]float64 {
+	t.Helper()
+	ranked, err := orch.RecommendTopN(query, intent, 10)
+	if err != nil {
+		t.Fatalf("RecommendTopN: %v", err)
+	}
+	scores := make(map[string]float64, len(ranked))
+	for _, r := range ranked {
+		scores[r.Name] = r.KeywordScore
+	}
+	return scores
+}
+
+func TestOrchestrator_IntentCategoriesDownWeightUnmapped(t *testing.T) {
+	orch := NewOrchestrator(intentCategoryTestLibrary(t))
+	query := "revenue queries"
+
+	// Query generation has no mapping, so nothing is boosted or penalized
+	unmapped := keywordScores(t, orch, query, IntentQueryGeneration)
+
+	// Analytics maps to analytics patterns; the performance pattern is kept but halved
+	mapped := keywordScores(t, orch, query, IntentAnalytics)
+	got, ok := mapped["revenue_query_tuning"]
+	if !ok {
+		t.Fatalf("down-weighted pattern should remain a candidate, got %v", mapped)
+	}
+	if want := unmapped["revenue_query_tuning"] * intentMismatchPenalty; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected down-weighted score %.3f, got %.3f", want, got)
+	}
+	if name, _ := orch.RecommendPattern(query, IntentAnalytics); name != "revenue_report" {
+		t.Errorf("expected revenue_report for analytics intent, got %q", name)
+	}
+
+	// Unknown intent never down-weights
+	unknown := keywordScores(t, orch, query, IntentUnknown)
+	if math.Abs(unknown["revenue_query_tuning"]-unmapped["revenue_query_tuning"]) > 1e-9 {
+		t.Errorf("unknown intent changed the performance score: %.3f vs %.3f",
+			unknown["revenue_query_tuning"], unmapped["revenue_query_tuning"])
+	}
+}
+
+func TestOrchestrator_WithIntentCategories(t *testing.T) {
+	orch := NewOrchestrator(intentCategoryTestLibrary(t)).WithIntentCategories(map[IntentCategory][]Category{
+		IntentAnalytics: {CategoryPerformance},
+	})
+	query := "revenue queries"
+
+	// The override replaces the default: performance now serves analytics
+	if name, _ := orch.RecommendPattern(query, IntentAnalytics); name != "revenue_query_tuning" {
+		t.Errorf("expected revenue_query_tuning with the override, got %q", name)
+	}
+	if !orch.matchesIntent("performance", IntentAnalytics) || orch.matchesIntent("analytics", IntentAnalytics) {
+		t.Error("override should fully replace the analytics mapping")
+	}
+
+	// Intents dropped from the mapping fall back to same-name matching
+	if !orch.matchesIntent("data_quality", IntentDataQuality) || orch.matchesIntent("validation", IntentDataQuality) {
+		t.Error("unmapped intent should only match its own category name")
+	}
+
+	// Aliases are canonicalized
+	orch.WithIntentCategories(map[IntentCategory][]Category{IntentAnalytics: {"Machine Learning", "timeseries"}})
+	want := map[IntentCategory][]Category{IntentAnalytics: {CategoryML, CategoryTimeseries}}
+	if got := orch.IntentCategories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("IntentCategories() = %v, expected %v", got, want)
+	}
+
+	// nil restores the default mapping
+	orch.WithIntentCategories(nil)
+	if got := orch.IntentCategories(); len(got) != len(DefaultIntentCategories()) {
+		t.Errorf("expected the default mapping after reset, got %v", got)
+	}
+	if name, _ := orch.RecommendPattern(query, IntentAnalytics); name != "revenue_report" {
+		t.Errorf("expected revenue_report with the default mapping, got %q", name)
+	}
+}
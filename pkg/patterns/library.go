@@ -16,9 +16,10 @@ package patterns
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
-	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	"time"
 
 	"github.com/teradata-labs/loom/pkg/observability"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -36,6 +38,7 @@ type Library struct {
 	patternCache     map[string]*Pattern
 	patternIndex     []PatternSummary
 	indexInitialized bool
+	indexVersion     uint64 // Incremented whenever patternIndex changes
 
 	// Embedded patterns (optional)
 	embeddedFS *embed.FS
@@ -43,20 +46,48 @@ type Library struct {
 	// Filesystem patterns (optional)
 	patternsDir string
 
+	// Patterns from a PatternSource (optional, see NewLibraryFromSource)
+	source PatternSource
+
 	// Pattern search paths within embedded FS or filesystem
 	searchPaths []string
 
 	// Path cache: pattern name -> relative path (populated during indexing)
 	pathCache map[string]string
 
+	// Named pattern collections (see collections.go)
+	collections map[string]*Collection
+
+	// Semantic retrieval (optional, see embeddings.go)
+	embeddings *embeddingIndex
+
+	// Full-text index (see fulltext.go), rebuilt when ftsVersion lags indexVersion
+	ftsMu      sync.Mutex
+	fts        *ftsIndex
+	ftsVersion uint64
+
+	// On-disk filesystem index cache directory (optional, see index_cache.go)
+	indexCacheDir string
+
+	// File watching (optional, see Watch in hotreload.go)
+	watcher  *HotReloader
+	onReload func(changed []string)
+	logger   *zap.Logger
+
 	// Observability
 	tracer observability.Tracer
 }
 
+// errPatternNotFound is wrapped by load errors for patterns with no file.
+var errPatternNotFound = errors.New("pattern not found")
+
 // NewLibrary creates a new pattern library.
 // If embeddedFS is provided, patterns will be loaded from embedded filesystem.
 // If patternsDir is provided, patterns will be loaded from filesystem.
 // Both can be provided - embedded patterns are checked first, then filesystem.
+// The filesystem directory is read through NewDirSource, so NewLibrary(nil, dir) serves
+// the same patterns as NewLibraryFromSource(NewDirSource(dir)) and additionally supports
+// SetPatternsDir, Watch, and EnableIndexCache.
 func NewLibrary(embeddedFS *embed.FS, patternsDir string) *Library {
 	return &Library{
 		patternCache: make(map[string]*Pattern),
@@ -97,6 +128,14 @@ func NewLibrary(embeddedFS *embed.FS, patternsDir string) *Library {
 	}
 }
 
+// NewLibraryFromSource creates a pattern library that loads patterns from source, such
+// as an embed.FS (NewFSSource) or a remote catalog (NewHTTPSource).
+func NewLibraryFromSource(source PatternSource) *Library {
+	lib := NewLibrary(nil, "")
+	lib.source = source
+	return lib
+}
+
 // SetPatternsDir updates the filesystem patterns directory.
 // This is used by LoadPatterns RPC to dynamically set the patterns source.
 // When the directory changes, the pattern index is invalidated so the next
@@ -116,6 +155,12 @@ func (lib *Library) WithTracer(tracer observability.Tracer) *Library {
 	return lib
 }
 
+// WithLogger sets the logger used for library events such as file watching.
+func (lib *Library) WithLogger(logger *zap.Logger) *Library {
+	lib.logger = logger
+	return lib
+}
+
 // Load reads a pattern by name.
 // Patterns are cached after first load for performance.
 // Searches in order: cache → embedded FS → filesystem.
@@ -148,12 +193,21 @@ func (lib *Library) Load(name string) (*Pattern, error) {
 		return cached, nil
 	}
 
+	// loadErr keeps the first parse failure (e.g. an unknown category) for the result
+	var loadErr error
+	recordLoadErr := func(err error) {
+		if loadErr == nil && err != nil && !errors.Is(err, errPatternNotFound) {
+			loadErr = err
+		}
+	}
+
 	// Try path cache first (populated during indexing)
 	if cachedPath != "" {
 		if lib.embeddedFS != nil {
 			data, err := lib.embeddedFS.ReadFile(cachedPath)
 			if err == nil {
 				pattern, err := lib.parsePattern(data, name, cachedPath)
+				recordLoadErr(err)
 				if err == nil {
 					lib.cachePattern(name, pattern)
 					duration := time.Since(startTime)
@@ -171,17 +225,13 @@ func (lib *Library) Load(name string) (*Pattern, error) {
 			}
 		}
 		if lib.patternsDir != "" {
-			fullPath := filepath.Join(lib.patternsDir, cachedPath)
-			// Validate path is within patternsDir (prevent path traversal)
-			cleanPath := filepath.Clean(fullPath)
-			cleanBase := filepath.Clean(lib.patternsDir)
-			if !strings.HasPrefix(cleanPath, cleanBase) {
+			data, err := readSourceFile(lib.dirSource(), filepath.ToSlash(cachedPath))
+			if errors.Is(err, fs.ErrInvalid) {
 				return nil, fmt.Errorf("pattern path outside patterns directory: %s", name)
 			}
-			// #nosec G304 -- Path validated to be within patternsDir
-			data, err := os.ReadFile(cleanPath)
 			if err == nil {
 				pattern, err := lib.parsePattern(data, name, cachedPath)
+				recordLoadErr(err)
 				if err == nil {
 					lib.cachePattern(name, pattern)
 					duration := time.Since(startTime)
@@ -203,6 +253,7 @@ func (lib *Library) Load(name string) (*Pattern, error) {
 	// Try loading from embedded FS first
 	if lib.embeddedFS != nil {
 		pattern, err := lib.loadFromEmbedded(name)
+		recordLoadErr(err)
 		if err == nil {
 			lib.cachePattern(name, pattern)
 			duration := time.Since(startTime)
@@ -222,6 +273,7 @@ func (lib *Library) Load(name string) (*Pattern, error) {
 	// Fall back to filesystem
 	if lib.patternsDir != "" {
 		pattern, err := lib.loadFromFilesystem(name)
+		recordLoadErr(err)
 		if err == nil {
 			lib.cachePattern(name, pattern)
 			duration := time.Since(startTime)
@@ -238,6 +290,26 @@ func (lib *Library) Load(name string) (*Pattern, error) {
 		}
 	}
 
+	// Fall back to the pattern source
+	if lib.source != nil {
+		pattern, err := lib.loadFromSource(lib.source, name, cachedPath)
+		recordLoadErr(err)
+		if err == nil {
+			lib.cachePattern(name, pattern)
+			duration := time.Since(startTime)
+			if span != nil {
+				span.SetAttribute("cache.hit", "false")
+				span.SetAttribute("source", "source")
+				span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
+			}
+			lib.tracer.RecordMetric("patterns.library.load", 1.0, map[string]string{
+				"cache_hit": "false",
+				"source":    "source",
+			})
+			return pattern, nil
+		}
+	}
+
 	duration := time.Since(startTime)
 	if span != nil {
 		span.SetAttribute("cache.hit", "false")
@@ -251,7 +323,11 @@ func (lib *Library) Load(name string) (*Pattern, error) {
 		"error":     "true",
 	})
 
-	return nil, fmt.Errorf("pattern not found: %s", name)
+	// A pattern file that exists but fails to parse is reported as such, not as missing
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	return nil, fmt.Errorf("%w: %s", errPatternNotFound, name)
 }
 
 // loadFromEmbedded loads a pattern from embedded filesystem.
@@ -306,72 +382,72 @@ func (lib *Library) loadFromEmbedded(name string) (*Pattern, error) {
 		"success": "false",
 	})
 
-	return nil, fmt.Errorf("pattern not found in embedded FS: %s", name)
+	return nil, fmt.Errorf("%w in embedded FS: %s", errPatternNotFound, name)
 }
 
-// loadFromFilesystem loads a pattern from filesystem.
+// loadFromFilesystem loads a pattern from the patterns directory. The directory is read
+// through a DirSource, so it resolves patterns exactly as NewLibraryFromSource would.
 func (lib *Library) loadFromFilesystem(name string) (*Pattern, error) {
-	startTime := time.Now()
-	_, span := lib.tracer.StartSpan(context.Background(), "patterns.library.load_filesystem")
+	return lib.loadFromSource(lib.dirSource(), name, "")
+}
+
+// dirSource returns a source reading the patterns directory.
+func (lib *Library) dirSource() PatternSource {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+	return NewDirSource(lib.patternsDir)
+}
+
+// loadFromSource loads a pattern from source, trying the path found while indexing
+// before the search paths.
+func (lib *Library) loadFromSource(source PatternSource, name, cachedPath string) (*Pattern, error) {
+	_, span := lib.tracer.StartSpan(context.Background(), "patterns.library.load_source")
 	defer lib.tracer.EndSpan(span)
 
 	if span != nil {
 		span.SetAttribute("pattern.name", name)
 	}
 
-	// Try direct path first, then search in subdirectories
-	possiblePaths := []string{filepath.Join(lib.patternsDir, name+".yaml")}
-	for _, searchPath := range lib.searchPaths {
-		possiblePaths = append(possiblePaths, filepath.Join(lib.patternsDir, searchPath, name+".yaml"))
+	var possiblePaths []string
+	if cachedPath != "" {
+		possiblePaths = append(possiblePaths, filepath.ToSlash(cachedPath))
 	}
-
-	if span != nil {
-		span.SetAttribute("search.paths_checked", fmt.Sprintf("%d", len(possiblePaths)))
+	possiblePaths = append(possiblePaths, name+".yaml")
+	lib.mu.RLock()
+	for _, searchPath := range lib.searchPaths {
+		possiblePaths = append(possiblePaths, path.Join(searchPath, name+".yaml"))
 	}
+	lib.mu.RUnlock()
 
-	for _, path := range possiblePaths {
-		cleanPath := filepath.Clean(path)
-		// Prevent directory traversal: resolved path must stay within patternsDir
-		if lib.patternsDir != "" && !strings.HasPrefix(cleanPath, filepath.Clean(lib.patternsDir)+string(filepath.Separator)) {
+	for _, p := range possiblePaths {
+		data, err := readSourceFile(source, p)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
 			continue
 		}
-		data, err := os.ReadFile(cleanPath) // #nosec G304 -- path validated above
-		if err == nil {
-			relPath := path
-			if lib.patternsDir != "" && strings.HasPrefix(path, lib.patternsDir) {
-				relPath = strings.TrimPrefix(path, lib.patternsDir)
-				relPath = strings.TrimPrefix(relPath, string(filepath.Separator))
-			}
-			pattern, err := lib.parsePattern(data, name, relPath)
-			if err != nil {
-				if span != nil {
-					span.RecordError(err)
-				}
-				return nil, err
+		if err != nil {
+			if span != nil {
+				span.RecordError(err)
 			}
-			duration := time.Since(startTime)
+			return nil, fmt.Errorf("failed to read pattern %s: %w", name, err)
+		}
+		pattern, err := lib.parsePattern(data, name, p)
+		if err != nil {
 			if span != nil {
-				span.SetAttribute("pattern.path", path)
-				span.SetAttribute("pattern.size_bytes", fmt.Sprintf("%d", len(data)))
-				span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
+				span.RecordError(err)
 			}
-			lib.tracer.RecordMetric("patterns.library.load_filesystem", 1.0, map[string]string{
-				"success": "true",
-			})
-			return pattern, nil
+			return nil, err
 		}
+		if span != nil {
+			span.SetAttribute("pattern.path", p)
+			span.SetAttribute("pattern.size_bytes", fmt.Sprintf("%d", len(data)))
+		}
+		return pattern, nil
 	}
 
-	duration := time.Since(startTime)
 	if span != nil {
-		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
-		span.RecordError(fmt.Errorf("pattern not found in filesystem: %s", name))
+		span.RecordError(fmt.Errorf("pattern not found in source: %s", name))
 	}
-	lib.tracer.RecordMetric("patterns.library.load_filesystem", 1.0, map[string]string{
-		"success": "false",
-	})
-
-	return nil, fmt.Errorf("pattern not found in filesystem: %s", name)
+	return nil, fmt.Errorf("%w in source: %s", errPatternNotFound, name)
 }
 
 // parsePattern parses a pattern from YAML data and caches its path.
@@ -381,6 +457,16 @@ func (lib *Library) parsePattern(data []byte, name string, relPath string) (*Pat
 		return nil, fmt.Errorf("failed to parse pattern %s: %w", name, err)
 	}
 
+	// Reject unknown categories and store the canonical spelling, so filtering and
+	// intent matching see one form. An empty category leaves the pattern uncategorized.
+	if pattern.Category != "" {
+		category, err := ParseCategory(pattern.Category)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", name, err)
+		}
+		pattern.Category = string(category)
+	}
+
 	// Cache the path for future loads
 	lib.mu.Lock()
 	lib.pathCache[name] = relPath
@@ -396,6 +482,63 @@ func (lib *Library) cachePattern(name string, pattern *Pattern) {
 	lib.patternCache[name] = pattern
 }
 
+// upsertPattern installs a freshly loaded pattern in the cache and, if the index is
+// built, replaces or appends its summary. The index slice is copied rather than
+// mutated so callers still holding the previous ListAll result are unaffected.
+func (lib *Library) upsertPattern(name string, pattern *Pattern) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	lib.patternCache[name] = pattern
+	if !lib.indexInitialized {
+		return
+	}
+
+	summary := lib.createSummary(pattern)
+	index := make([]PatternSummary, 0, len(lib.patternIndex)+1)
+	replaced := false
+	for _, existing := range lib.patternIndex {
+		if existing.Name == name || existing.Name == pattern.Name {
+			if !replaced {
+				index = append(index, summary)
+				replaced = true
+			}
+			continue
+		}
+		index = append(index, existing)
+	}
+	if !replaced {
+		index = append(index, summary)
+	}
+	lib.patternIndex = index
+	lib.indexVersion++
+}
+
+// removePattern drops a pattern from the cache and index.
+// Returns true if the pattern was cached or indexed.
+func (lib *Library) removePattern(name string) bool {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	_, existed := lib.patternCache[name]
+	delete(lib.patternCache, name)
+	delete(lib.pathCache, name)
+
+	if lib.indexInitialized {
+		index := make([]PatternSummary, 0, len(lib.patternIndex))
+		for _, existing := range lib.patternIndex {
+			if existing.Name == name {
+				existed = true
+				continue
+			}
+			index = append(index, existing)
+		}
+		lib.patternIndex = index
+		lib.indexVersion++
+	}
+	return existed
+}
+
 // ListAll returns metadata for all available patterns.
 // Results are cached for performance.
 func (lib *Library) ListAll() []PatternSummary {
@@ -437,17 +580,27 @@ func (lib *Library) ListAll() []PatternSummary {
 
 	// Load from filesystem
 	if lib.patternsDir != "" {
-		fsSummaries := lib.indexFilesystem()
+		fsSummaries := lib.indexFilesystemCached(span)
 		summaries = append(summaries, fsSummaries...)
 		if span != nil {
 			span.SetAttribute("index.filesystem_count", fmt.Sprintf("%d", len(fsSummaries)))
 		}
 	}
 
+	// Load from the pattern source
+	if lib.source != nil {
+		sourceSummaries := lib.indexSource(lib.source)
+		summaries = append(summaries, sourceSummaries...)
+		if span != nil {
+			span.SetAttribute("index.source_count", fmt.Sprintf("%d", len(sourceSummaries)))
+		}
+	}
+
 	// Cache the index
 	lib.mu.Lock()
 	lib.patternIndex = summaries
 	lib.indexInitialized = true
+	lib.indexVersion++
 	lib.mu.Unlock()
 
 	duration := time.Since(startTime)
@@ -485,7 +638,8 @@ func (lib *Library) indexEmbedded() []PatternSummary {
 
 		pattern, loadErr := lib.Load(name)
 		if loadErr != nil {
-			return nil // Skip patterns that fail to load
+			lib.warnSkippedPattern(path, loadErr)
+			return nil
 		}
 
 		summaries = append(summaries, lib.createSummary(pattern))
@@ -500,50 +654,59 @@ func (lib *Library) indexEmbedded() []PatternSummary {
 	return summaries
 }
 
-// indexFilesystem indexes all patterns in filesystem directory.
+// indexFilesystem indexes all patterns in the patterns directory.
 func (lib *Library) indexFilesystem() []PatternSummary {
-	summaries := make([]PatternSummary, 0)
+	return lib.indexSource(lib.dirSource())
+}
 
-	err := filepath.WalkDir(lib.patternsDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return nil // Skip errors, continue walking
-		}
+// indexSource indexes all patterns in source.
+func (lib *Library) indexSource(source PatternSource) []PatternSummary {
+	summaries := make([]PatternSummary, 0)
 
-		if d.IsDir() || !strings.HasSuffix(path, ".yaml") {
-			return nil
+	names, err := source.List()
+	if err != nil {
+		if lib.logger != nil {
+			lib.logger.Warn("Failed to list pattern source", zap.Error(err))
 		}
+		return summaries
+	}
 
-		name := strings.TrimSuffix(filepath.Base(path), ".yaml")
-
-		// Compute relative path from patternsDir
-		relPath := path
-		if lib.patternsDir != "" && strings.HasPrefix(path, lib.patternsDir) {
-			relPath = strings.TrimPrefix(path, lib.patternsDir)
-			relPath = strings.TrimPrefix(relPath, string(filepath.Separator))
+	for _, file := range names {
+		if !strings.HasSuffix(file, ".yaml") {
+			continue
 		}
+		name := sourcePatternName(file)
 
 		// Cache the path for this pattern
 		lib.mu.Lock()
-		lib.pathCache[name] = relPath
+		lib.pathCache[name] = file
 		lib.mu.Unlock()
 
 		pattern, loadErr := lib.Load(name)
 		if loadErr != nil {
-			return nil // Skip patterns that fail to load
+			lib.warnSkippedPattern(file, loadErr)
+			continue
 		}
 
 		summaries = append(summaries, lib.createSummary(pattern))
-		return nil
-	})
-
-	if err != nil {
-		// Log error but continue
-		return summaries
 	}
 
 	return summaries
 }
 
+// warnSkippedPattern logs a pattern file that indexing could not load, so a pattern
+// with a mistyped category does not silently drop out of the index.
+func (lib *Library) warnSkippedPattern(file string, err error) {
+	if lib.logger == nil || errors.Is(err, errPatternNotFound) {
+		return
+	}
+	if errors.Is(err, ErrUnknownCategory) {
+		lib.logger.Warn("Skipping pattern with unknown category", zap.String("file", file), zap.Error(err))
+		return
+	}
+	lib.logger.Warn("Skipping pattern that failed to load", zap.String("file", file), zap.Error(err))
+}
+
 // createSummary creates a PatternSummary from a full Pattern.
 func (lib *Library) createSummary(pattern *Pattern) PatternSummary {
 	return PatternSummary{
@@ -553,12 +716,37 @@ func (lib *Library) createSummary(pattern *Pattern) PatternSummary {
 		Category:        pattern.Category,
 		Difficulty:      pattern.Difficulty,
 		BackendType:     pattern.BackendType,
+		Backend:         pattern.Backend,
 		UseCases:        pattern.UseCases,
+		ExcludeKeywords: pattern.ExcludeKeywords,
 		BackendFunction: pattern.BackendFunction,
 	}
 }
 
-// FilterByCategory returns patterns matching the specified category.
+// Categories returns the distinct categories of the indexed patterns, sorted.
+// Uncategorized patterns are not represented.
+func (lib *Library) Categories() []Category {
+	seen := make(map[Category]bool)
+	var result []Category
+	for _, p := range lib.ListAll() {
+		c := Category(p.Category)
+		if c != "" && !seen[c] {
+			seen[c] = true
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// ByCategory returns the patterns in category c.
+func (lib *Library) ByCategory(c Category) []PatternSummary {
+	return lib.FilterByCategory(string(c))
+}
+
+// FilterByCategory returns patterns matching the specified category. The category
+// is matched as by ParseCategory, so "Data Quality" finds data_quality patterns.
+// An empty category returns all patterns.
 func (lib *Library) FilterByCategory(category string) []PatternSummary {
 	startTime := time.Now()
 	_, span := lib.tracer.StartSpan(context.Background(), "patterns.library.filter_by_category")
@@ -582,11 +770,11 @@ func (lib *Library) FilterByCategory(category string) []PatternSummary {
 		return all
 	}
 
+	// Loaded categories are canonical, so compare against the parsed filter
+	target := canonicalCategory(category)
 	filtered := make([]PatternSummary, 0)
-	categoryLower := strings.ToLower(category)
-
 	for _, p := range all {
-		if strings.ToLower(p.Category) == categoryLower {
+		if p.Category == string(target) {
 			filtered = append(filtered, p)
 		}
 	}
@@ -713,7 +901,7 @@ func (lib *Library) Search(query string) []PatternSummary {
 	defer lib.tracer.EndSpan(span)
 
 	if span != nil {
-		span.SetAttribute("search.query", query)
+		span.SetAttribute("search.query", observability.Redact(query))
 		span.SetAttribute("search.query_length", fmt.Sprintf("%d", len(query)))
 	}
 
@@ -817,12 +1005,15 @@ func (lib *Library) Search(query string) []PatternSummary {
 		}
 	}
 
-	// Sort by score (descending), then by match count
-	sort.Slice(scoredResults, func(i, j int) bool {
+	// Sort by score (descending), then by match count, then by name
+	sort.SliceStable(scoredResults, func(i, j int) bool {
 		if scoredResults[i].score != scoredResults[j].score {
 			return scoredResults[i].score > scoredResults[j].score
 		}
-		return scoredResults[i].matchCount > scoredResults[j].matchCount
+		if scoredResults[i].matchCount != scoredResults[j].matchCount {
+			return scoredResults[i].matchCount > scoredResults[j].matchCount
+		}
+		return scoredResults[i].pattern.Name < scoredResults[j].pattern.Name
 	})
 
 	// Extract sorted patterns
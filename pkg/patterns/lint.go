@@ -0,0 +1,222 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Lint checks reported in LintFinding.Check.
+const (
+	LintShortDescription         = "short_description"          // Description too short to match on
+	LintMissingUseCases          = "missing_use_cases"          // No use cases to match on
+	LintUseCaseDuplicatesTitle   = "use_case_duplicates_title"  // Use case adds no keywords beyond the name and title
+	LintNoDistinguishingKeywords = "no_distinguishing_keywords" // Keywords do not set the pattern apart within its category
+	LintKeywordOverlap           = "keyword_overlap"            // Keywords largely shared with another pattern
+)
+
+const (
+	// lintMinDescriptionWords is the fewest description words before a description is
+	// flagged as short.
+	lintMinDescriptionWords = 8
+
+	// lintOverlapThreshold is the Jaccard similarity of two patterns' keyword sets at or
+	// above which they are flagged as colliding.
+	lintOverlapThreshold = 0.6
+
+	// lintMinOverlapKeywords is the smallest keyword set compared for overlap; smaller
+	// sets are already flagged by the other checks.
+	lintMinOverlapKeywords = 3
+)
+
+// LintFinding is a likely authoring problem that makes a pattern hard for the keyword
+// scorer to match or to tell apart from other patterns.
+type LintFinding struct {
+	Pattern string `json:"pattern"`           // Pattern name
+	Check   string `json:"check"`             // LintShortDescription, LintMissingUseCases, ...
+	Message string `json:"message"`           // What is wrong and how to fix it
+	Related string `json:"related,omitempty"` // The other pattern, for LintKeywordOverlap
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: [%s] %s", f.Pattern, f.Check, f.Message)
+}
+
+// Lint checks a single pattern for text the keyword scorer cannot use: a short
+// description, missing use cases, use cases that only repeat the title, and keywords
+// that say nothing beyond the pattern's category. Use LintAll to also compare patterns
+// with each other.
+func Lint(p Pattern) []LintFinding {
+	var findings []LintFinding
+	add := func(check, format string, args ...any) {
+		findings = append(findings, LintFinding{Pattern: p.Name, Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if words := len(strings.Fields(p.Description)); words < lintMinDescriptionWords {
+		add(LintShortDescription, "description has %d words; describe what the pattern does in at least %d, using the terms users search for", words, lintMinDescriptionWords)
+	}
+
+	var useCases []string
+	for _, uc := range p.UseCases {
+		if strings.TrimSpace(uc) != "" {
+			useCases = append(useCases, uc)
+		}
+	}
+	if len(useCases) == 0 {
+		add(LintMissingUseCases, "no use cases; list the tasks and phrasings users ask for")
+	}
+
+	titleKeywords := keywordSet(p.Name, p.Title)
+	for _, uc := range useCases {
+		if isSubset(keywordSet(uc), titleKeywords) {
+			add(LintUseCaseDuplicatesTitle, "use case %q only repeats the name and title; use the words users type instead", uc)
+		}
+	}
+
+	if p.Category != "" {
+		categoryWords := keywordSet(strings.ReplaceAll(string(canonicalCategory(p.Category)), "_", " "), p.Category)
+		distinct := 0
+		for kw := range patternKeywords(p) {
+			if !categoryWords[kw] {
+				distinct++
+			}
+		}
+		if distinct == 0 {
+			add(LintNoDistinguishingKeywords, "name, title, and use cases only repeat the category %q; add keywords specific to this pattern", p.Category)
+		}
+	}
+
+	return findings
+}
+
+// LintAll lints each pattern (see Lint) and compares them: patterns whose keyword sets
+// overlap heavily are flagged as collision risks, and patterns whose keywords all appear
+// in other patterns of the same category are flagged as indistinguishable within it.
+// Keyword sets are the name, title, use cases, and backend function, lowercased, without
+// stop words and words shorter than three characters, as the keyword scorer sees them.
+// Patterns are compared only with patterns of other names. Findings are ordered by
+// pattern name.
+func LintAll(patterns []Pattern) []LintFinding {
+	sorted := make([]Pattern, len(patterns))
+	copy(sorted, patterns)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	keywords := make([]map[string]bool, len(sorted))
+	for i, p := range sorted {
+		keywords[i] = patternKeywords(p)
+	}
+
+	var findings []LintFinding
+	for i, p := range sorted {
+		findings = append(findings, Lint(p)...)
+
+		if len(keywords[i]) > 0 && p.Category != "" {
+			siblings := make(map[string]bool)
+			hasSiblings := false
+			for j, other := range sorted {
+				if other.Name != p.Name && canonicalCategory(other.Category) == canonicalCategory(p.Category) {
+					hasSiblings = true
+					for kw := range keywords[j] {
+						siblings[kw] = true
+					}
+				}
+			}
+			if hasSiblings && isSubset(keywords[i], siblings) {
+				findings = append(findings, LintFinding{
+					Pattern: p.Name,
+					Check:   LintNoDistinguishingKeywords,
+					Message: fmt.Sprintf("every keyword also appears in other %q patterns; add keywords only this pattern matches", p.Category),
+				})
+			}
+		}
+
+		for j, other := range sorted {
+			if other.Name == p.Name || len(keywords[i]) < lintMinOverlapKeywords || len(keywords[j]) < lintMinOverlapKeywords {
+				continue
+			}
+			if similarity := jaccard(keywords[i], keywords[j]); similarity >= lintOverlapThreshold {
+				findings = append(findings, LintFinding{
+					Pattern: p.Name,
+					Check:   LintKeywordOverlap,
+					Message: fmt.Sprintf("%.0f%% of keywords are shared with %q; queries will rank them interchangeably", similarity*100, other.Name),
+					Related: other.Name,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// LintLibrary loads every pattern in lib and lints them with LintAll. Patterns that fail
+// to load are skipped, as by ListAll.
+func LintLibrary(lib *Library) []LintFinding {
+	summaries := lib.ListAll()
+	loaded := make([]Pattern, 0, len(summaries))
+	seen := make(map[string]bool, len(summaries))
+	for _, summary := range summaries {
+		if seen[summary.Name] {
+			continue // Load resolves a name to one file
+		}
+		seen[summary.Name] = true
+		if p, err := lib.Load(summary.Name); err == nil {
+			loaded = append(loaded, *p)
+		}
+	}
+	return LintAll(loaded)
+}
+
+// patternKeywords returns the keywords the scorer matches most strongly for p.
+func patternKeywords(p Pattern) map[string]bool {
+	return keywordSet(append([]string{p.Name, p.Title, p.BackendFunction}, p.UseCases...)...)
+}
+
+// keywordSet tokenizes texts as query keywords are tokenized for scoring.
+func keywordSet(texts ...string) map[string]bool {
+	set := make(map[string]bool)
+	for _, text := range texts {
+		for _, word := range normalizedWords(text) {
+			if !queryStopWords[word] && len(word) > 2 {
+				set[word] = true
+			}
+		}
+	}
+	return set
+}
+
+// isSubset reports whether every element of a is in b. An empty a is a subset.
+func isSubset(a, b map[string]bool) bool {
+	for k := range a {
+		if !b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b|.
+func jaccard(a, b map[string]bool) float64 {
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
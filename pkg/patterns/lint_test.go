@@ -0,0 +1,114 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func lintChecks(findings []LintFinding, pattern string) map[string]int {
+	checks := make(map[string]int)
+	for _, f := range findings {
+		if f.Pattern == pattern {
+			checks[f.Check]++
+		}
+	}
+	return checks
+}
+
+func TestLint(t *testing.T) {
+	good := Pattern{
+		Name:        "funnel_analysis",
+		Title:       "Funnel Analysis",
+		Description: "Measure drop-off between ordered steps of a user conversion journey",
+		Category:    "analytics",
+		UseCases:    []string{"conversion rate by signup step", "checkout abandonment"},
+	}
+	if findings := Lint(good); len(findings) != 0 {
+		t.Errorf("Lint(good) = %v, want no findings", findings)
+	}
+
+	bad := Pattern{
+		Name:        "analytics",
+		Title:       "Analytics",
+		Description: "Does analytics",
+		Category:    "analytics",
+		UseCases:    []string{"", "  "},
+	}
+	checks := lintChecks(Lint(bad), "analytics")
+	for _, check := range []string{LintShortDescription, LintMissingUseCases, LintNoDistinguishingKeywords} {
+		if checks[check] != 1 {
+			t.Errorf("Lint(bad) reported %s %d times, want 1", check, checks[check])
+		}
+	}
+
+	dupe := good
+	dupe.UseCases = []string{"Funnel analysis", "checkout abandonment"}
+	if checks := lintChecks(Lint(dupe), dupe.Name); checks[LintUseCaseDuplicatesTitle] != 1 {
+		t.Errorf("Lint(dupe) = %v, want one %s", checks, LintUseCaseDuplicatesTitle)
+	}
+}
+
+func TestLintAll(t *testing.T) {
+	description := "A description long enough to pass the short description check"
+	all := []Pattern{
+		{Name: "sessionize_events", Title: "Sessionize Events", Description: description, Category: "analytics",
+			UseCases: []string{"group clickstream events into sessions"}},
+		{Name: "event_sessions", Title: "Event Sessions", Description: description, Category: "analytics",
+			UseCases: []string{"group clickstream events into sessionize windows"}},
+		{Name: "churn_model", Title: "Churn Model", Description: description, Category: "ml",
+			UseCases: []string{"predict customer churn"}},
+	}
+
+	findings := LintAll(all)
+	for _, name := range []string{"sessionize_events", "event_sessions"} {
+		if checks := lintChecks(findings, name); checks[LintKeywordOverlap] != 1 {
+			t.Errorf("%s: checks = %v, want one %s", name, checks, LintKeywordOverlap)
+		}
+	}
+	if checks := lintChecks(findings, "churn_model"); len(checks) != 0 {
+		t.Errorf("churn_model: checks = %v, want none", checks)
+	}
+	for _, f := range findings {
+		if f.Pattern == "event_sessions" && f.Check == LintKeywordOverlap && f.Related != "sessionize_events" {
+			t.Errorf("Related = %q, want sessionize_events", f.Related)
+		}
+	}
+	for i := 1; i < len(findings); i++ {
+		if findings[i-1].Pattern > findings[i].Pattern {
+			t.Fatalf("findings not ordered by pattern: %v", findings)
+		}
+	}
+
+	// A pattern whose keywords all appear in its category siblings
+	all = append(all, Pattern{Name: "churn", Title: "Customer Churn", Description: description, Category: "ml",
+		UseCases: []string{"predict churn"}})
+	if checks := lintChecks(LintAll(all), "churn"); checks[LintNoDistinguishingKeywords] != 1 {
+		t.Errorf("churn: checks = %v, want one %s", checks, LintNoDistinguishingKeywords)
+	}
+}
+
+func TestLintLibrary(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "terse.yaml"), []byte("name: terse\ntitle: Terse\ndescription: Too short\ncategory: analytics\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := lintChecks(LintLibrary(NewLibrary(nil, dir)), "terse")
+	if checks[LintShortDescription] != 1 || checks[LintMissingUseCases] != 1 {
+		t.Errorf("checks = %v, want short description and missing use cases", checks)
+	}
+}
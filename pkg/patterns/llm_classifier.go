@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -34,19 +35,34 @@ type LLMClassifierConfig struct {
 
 	// Cache TTL (default: 15 minutes)
 	CacheTTL time.Duration
+
+	// Minimum confidence for a secondary intent in multi-intent classification (default: 0.3).
+	// The top intent is always returned.
+	MultiIntentThreshold float64
+
+	// Intents within this margin of the top intent's confidence are returned even
+	// when they fall below MultiIntentThreshold, so near-ties are never dropped (default: 0.1)
+	MultiIntentTieMargin float64
 }
 
 // DefaultLLMClassifierConfig returns sensible defaults.
-// Note: The LLM provider should be pre-configured with a fast model (e.g., claude-haiku-3-5)
-// for low-latency classification.
+// Note: The LLM provider should be pre-configured with a fast model (e.g., claude-haiku-3-5
+// on Bedrock, or a small local model via the ollama provider) for low-latency classification.
 func DefaultLLMClassifierConfig(llm types.LLMProvider) *LLMClassifierConfig {
 	return &LLMClassifierConfig{
-		LLMProvider: llm,
-		EnableCache: true,
-		CacheTTL:    15 * time.Minute,
+		LLMProvider:          llm,
+		EnableCache:          true,
+		CacheTTL:             15 * time.Minute,
+		MultiIntentThreshold: defaultMultiIntentThreshold,
+		MultiIntentTieMargin: defaultMultiIntentTieMargin,
 	}
 }
 
+const (
+	defaultMultiIntentThreshold = 0.3
+	defaultMultiIntentTieMargin = 0.1
+)
+
 // NewLLMIntentClassifier creates an LLM-based intent classifier.
 // Returns an IntentClassifierFunc that can be plugged into the orchestrator.
 //
@@ -122,7 +138,28 @@ func buildClassificationPrompt(userMessage string, contextData map[string]any) s
 
 	prompt := fmt.Sprintf(`Classify the user's intent for a %s backend system.
 
-Available intent categories:
+%s
+
+User message: "%s"
+
+Classify this intent. Respond ONLY with valid JSON (no markdown, no code blocks):
+{
+  "intent": "<category_name>",
+  "confidence": <0.0-1.0>,
+  "reasoning": "<brief explanation>"
+}
+
+Guidelines:
+- Be conservative with confidence scores. Only use >0.9 for very clear, unambiguous intents.
+- Use 0.7-0.9 for probable intents with some ambiguity.
+- Use <0.7 for uncertain or multi-intent queries.
+- If the message is greeting/chitchat/off-topic, use "unknown" with low confidence.`, backendType, intentCategoryDescriptions, userMessage)
+
+	return prompt
+}
+
+// intentCategoryDescriptions lists the intent categories offered to the LLM.
+const intentCategoryDescriptions = `Available intent categories:
 1. schema_discovery - User wants to explore database structure, tables, columns, metadata
    Examples: "show me all tables", "what columns are in orders table", "describe the schema"
 
@@ -147,25 +184,7 @@ Available intent categories:
 8. api_call - User wants to make HTTP/REST API calls
    Examples: "call the user API", "make a GET request to X", "post data to the endpoint"
 
-9. unknown - Intent doesn't clearly match any category
-
-User message: "%s"
-
-Classify this intent. Respond ONLY with valid JSON (no markdown, no code blocks):
-{
-  "intent": "<category_name>",
-  "confidence": <0.0-1.0>,
-  "reasoning": "<brief explanation>"
-}
-
-Guidelines:
-- Be conservative with confidence scores. Only use >0.9 for very clear, unambiguous intents.
-- Use 0.7-0.9 for probable intents with some ambiguity.
-- Use <0.7 for uncertain or multi-intent queries.
-- If the message is greeting/chitchat/off-topic, use "unknown" with low confidence.`, backendType, userMessage)
-
-	return prompt
-}
+9. unknown - Intent doesn't clearly match any category`
 
 // classificationResult holds parsed LLM response
 type classificationResult struct {
@@ -189,31 +208,220 @@ func parseClassificationResponse(content string) *classificationResult {
 	}
 
 	// Validate intent category
-	validIntents := map[IntentCategory]bool{
-		IntentSchemaDiscovery:   true,
-		IntentDataQuality:       true,
-		IntentDataTransform:     true,
-		IntentAnalytics:         true,
-		IntentRelationshipQuery: true,
-		IntentQueryGeneration:   true,
-		IntentDocumentSearch:    true,
-		IntentAPICall:           true,
-		IntentUnknown:           true,
+	if !validIntents[result.Intent] {
+		return nil
 	}
 
-	if !validIntents[result.Intent] {
+	result.Confidence = clampConfidence(result.Confidence)
+
+	return &result
+}
+
+// validIntents are the intent categories the LLM may return.
+var validIntents = map[IntentCategory]bool{
+	IntentSchemaDiscovery:   true,
+	IntentDataQuality:       true,
+	IntentDataTransform:     true,
+	IntentAnalytics:         true,
+	IntentRelationshipQuery: true,
+	IntentQueryGeneration:   true,
+	IntentDocumentSearch:    true,
+	IntentAPICall:           true,
+	IntentUnknown:           true,
+}
+
+// clampConfidence clamps confidence to [0.0, 1.0]
+func clampConfidence(confidence float64) float64 {
+	if confidence < 0.0 {
+		return 0.0
+	}
+	if confidence > 1.0 {
+		return 1.0
+	}
+	return confidence
+}
+
+// NewLLMMultiIntentClassifier creates an LLM-based classifier that can return more
+// than one intent. The top intent is always returned; other intents are kept when
+// their confidence reaches MultiIntentThreshold or is within MultiIntentTieMargin of
+// the top intent, so a message with two nearly equal intents yields both.
+// LLM and parse failures fall back to the keyword classifier, like NewLLMIntentClassifier.
+//
+// Example usage:
+//
+//	orchestrator.SetMultiIntentClassifier(NewLLMMultiIntentClassifier(config))
+//	intents, err := orchestrator.ClassifyMulti(userMessage)
+func NewLLMMultiIntentClassifier(config *LLMClassifierConfig) MultiIntentClassifierFunc {
+	var cache *classificationCache
+	if config.EnableCache {
+		cache = newClassificationCache(5000, config.CacheTTL)
+	}
+
+	return func(userMessage string, contextData map[string]any) ([]ScoredIntent, error) {
+		if config.LLMProvider == nil {
+			return nil, fmt.Errorf("no LLM provider configured")
+		}
+
+		threshold, tieMargin := config.MultiIntentThreshold, config.MultiIntentTieMargin
+		if threshold <= 0 {
+			threshold = defaultMultiIntentThreshold
+		}
+		if tieMargin <= 0 {
+			tieMargin = defaultMultiIntentTieMargin
+		}
+
+		if cache != nil {
+			if result := cache.Get(userMessage); result != nil && result.Intents != nil {
+				return append([]ScoredIntent(nil), result.Intents...), nil
+			}
+		}
+
+		intents := selectIntents(classifyMultiWithLLM(config, userMessage, contextData), threshold, tieMargin)
+
+		if cache != nil {
+			cache.SetIntents(userMessage, intents)
+		}
+
+		return append([]ScoredIntent(nil), intents...), nil
+	}
+}
+
+// classifyMultiWithLLM asks the LLM for every plausible intent.
+func classifyMultiWithLLM(config *LLMClassifierConfig, userMessage string, contextData map[string]any) []ScoredIntent {
+	messages := []types.Message{
+		{
+			Role:    "user",
+			Content: buildMultiClassificationPrompt(userMessage, contextData),
+		},
+	}
+
+	resp, err := config.LLMProvider.Chat(context.Background(), messages, nil)
+	if err == nil {
+		if intents := parseMultiClassificationResponse(resp.Content); len(intents) > 0 {
+			return intents
+		}
+	}
+
+	// Fallback to keyword classifier on error
+	intent, confidence := defaultIntentClassifier(userMessage, contextData)
+	return []ScoredIntent{{Intent: intent, Confidence: confidence}}
+}
+
+// buildMultiClassificationPrompt constructs the LLM prompt for multi-intent classification
+func buildMultiClassificationPrompt(userMessage string, contextData map[string]any) string {
+	backendType := "unknown"
+	if bt, ok := contextData["backend_type"].(string); ok {
+		backendType = bt
+	}
+
+	return fmt.Sprintf(`Classify the user's intents for a %s backend system. A message may express more than one intent.
+
+%s
+
+User message: "%s"
+
+List every intent the message plausibly expresses. Respond ONLY with valid JSON (no markdown, no code blocks):
+{
+  "intents": [
+    {"intent": "<category_name>", "confidence": <0.0-1.0>}
+  ],
+  "reasoning": "<brief explanation>"
+}
+
+Guidelines:
+- Score each intent independently. Two intents that are equally likely should get equal confidence.
+- Be conservative with confidence scores. Only use >0.9 for very clear, unambiguous intents.
+- Omit intents that do not apply rather than listing them with very low confidence.
+- If the message is greeting/chitchat/off-topic, return only "unknown" with low confidence.`, backendType, intentCategoryDescriptions, userMessage)
+}
+
+// multiClassificationResult holds parsed multi-intent LLM response
+type multiClassificationResult struct {
+	Intents   []ScoredIntent `json:"intents"`
+	Reasoning string         `json:"reasoning"`
+}
+
+// parseMultiClassificationResponse parses the multi-intent LLM JSON response.
+// A single-intent response ({"intent": ..., "confidence": ...}) is also accepted.
+// Unknown categories are dropped; returns nil if nothing valid remains.
+func parseMultiClassificationResponse(content string) []ScoredIntent {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	content = strings.TrimSpace(content)
+
+	var result multiClassificationResult
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil
+	}
+
+	if len(result.Intents) == 0 {
+		if single := parseClassificationResponse(content); single != nil {
+			return []ScoredIntent{{Intent: single.Intent, Confidence: single.Confidence}}
+		}
+		return nil
+	}
+
+	intents := make([]ScoredIntent, 0, len(result.Intents))
+	for _, si := range result.Intents {
+		if !validIntents[si.Intent] {
+			continue
+		}
+		intents = append(intents, ScoredIntent{Intent: si.Intent, Confidence: clampConfidence(si.Confidence)})
+	}
+	if len(intents) == 0 {
 		return nil
 	}
+	return intents
+}
 
-	// Clamp confidence to [0.0, 1.0]
-	if result.Confidence < 0.0 {
-		result.Confidence = 0.0
+// selectIntents orders intents by confidence and keeps the top intent plus every
+// other intent at or above threshold or within tieMargin of the top. Duplicate
+// intents keep their highest confidence, and IntentUnknown is dropped whenever a
+// real intent is kept.
+func selectIntents(intents []ScoredIntent, threshold, tieMargin float64) []ScoredIntent {
+	best := make(map[IntentCategory]float64, len(intents))
+	order := make([]IntentCategory, 0, len(intents))
+	for _, si := range intents {
+		conf, seen := best[si.Intent]
+		if !seen {
+			order = append(order, si.Intent)
+		}
+		if !seen || si.Confidence > conf {
+			best[si.Intent] = si.Confidence
+		}
 	}
-	if result.Confidence > 1.0 {
-		result.Confidence = 1.0
+
+	sorted := make([]ScoredIntent, 0, len(order))
+	for _, intent := range order {
+		sorted = append(sorted, ScoredIntent{Intent: intent, Confidence: best[intent]})
 	}
+	// Stable so equal confidences keep the LLM's order
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Confidence > sorted[j].Confidence })
 
-	return &result
+	if len(sorted) == 0 {
+		return []ScoredIntent{{Intent: IntentUnknown, Confidence: 0}}
+	}
+
+	top := sorted[0].Confidence
+	selected := sorted[:1]
+	for _, si := range sorted[1:] {
+		if si.Confidence >= threshold || top-si.Confidence <= tieMargin {
+			selected = append(selected, si)
+		}
+	}
+
+	if len(selected) > 1 {
+		known := selected[:0]
+		for _, si := range selected {
+			if si.Intent != IntentUnknown {
+				known = append(known, si)
+			}
+		}
+		selected = known
+	}
+	return selected
 }
 
 // classificationCache provides LRU caching for classifications
@@ -227,6 +435,7 @@ type classificationCache struct {
 type cacheEntry struct {
 	Intent     IntentCategory
 	Confidence float64
+	Intents    []ScoredIntent // Set by multi-intent classification
 	Timestamp  time.Time
 }
 
@@ -271,6 +480,24 @@ func (c *classificationCache) Set(message string, intent IntentCategory, confide
 	}
 }
 
+func (c *classificationCache) SetIntents(message string, intents []ScoredIntent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxSize {
+		c.evictOldest(c.maxSize / 5)
+	}
+
+	entry := &cacheEntry{
+		Intents:   intents,
+		Timestamp: time.Now(),
+	}
+	if len(intents) > 0 {
+		entry.Intent, entry.Confidence = intents[0].Intent, intents[0].Confidence
+	}
+	c.entries[message] = entry
+}
+
 func (c *classificationCache) evictOldest(count int) {
 	// Simple eviction: remove first N entries (could be optimized with heap)
 	// Note: This is called while holding the lock
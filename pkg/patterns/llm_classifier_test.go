@@ -373,6 +373,103 @@ func TestBuildClassificationPrompt(t *testing.T) {
 	}
 }
 
+func TestLLMMultiIntentClassifier_NearTie(t *testing.T) {
+	mock := &mockLLMProvider{
+		defaultResponse: `{
+			"intents": [
+				{"intent": "data_quality", "confidence": 0.46},
+				{"intent": "analytics", "confidence": 0.48},
+				{"intent": "api_call", "confidence": 0.05}
+			],
+			"reasoning": "Validate then aggregate"
+		}`,
+	}
+
+	classifier := NewLLMMultiIntentClassifier(DefaultLLMClassifierConfig(mock))
+	intents, err := classifier("check sales for nulls and compute monthly totals", nil)
+	require.NoError(t, err)
+
+	// Both near-equal intents are returned, best first; the weak one is dropped
+	require.Len(t, intents, 2)
+	assert.Equal(t, IntentAnalytics, intents[0].Intent)
+	assert.Equal(t, IntentDataQuality, intents[1].Intent)
+	assert.Contains(t, mock.lastCall[0].Content, `"intents"`)
+
+	// Cached
+	_, err = classifier("check sales for nulls and compute monthly totals", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mock.callCount)
+}
+
+func TestLLMMultiIntentClassifier_Fallbacks(t *testing.T) {
+	t.Run("single intent response", func(t *testing.T) {
+		mock := &mockLLMProvider{defaultResponse: `{"intent": "schema_discovery", "confidence": 0.9}`}
+		intents, err := NewLLMMultiIntentClassifier(DefaultLLMClassifierConfig(mock))("show me all tables", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []ScoredIntent{{Intent: IntentSchemaDiscovery, Confidence: 0.9}}, intents)
+	})
+
+	t.Run("LLM error uses keyword classifier", func(t *testing.T) {
+		intents, err := NewLLMMultiIntentClassifier(DefaultLLMClassifierConfig(&errorLLMProvider{}))("show tables in database", nil)
+		require.NoError(t, err)
+		require.Len(t, intents, 1)
+		assert.Equal(t, IntentSchemaDiscovery, intents[0].Intent)
+	})
+
+	t.Run("no provider", func(t *testing.T) {
+		_, err := NewLLMMultiIntentClassifier(&LLMClassifierConfig{})("show me all tables", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestSelectIntents(t *testing.T) {
+	tests := []struct {
+		name    string
+		intents []ScoredIntent
+		want    []IntentCategory
+	}{
+		{
+			name:    "above threshold",
+			intents: []ScoredIntent{{IntentAnalytics, 0.8}, {IntentDataQuality, 0.4}, {IntentAPICall, 0.1}},
+			want:    []IntentCategory{IntentAnalytics, IntentDataQuality},
+		},
+		{
+			name:    "near tie below threshold",
+			intents: []ScoredIntent{{IntentAnalytics, 0.25}, {IntentDataQuality, 0.2}},
+			want:    []IntentCategory{IntentAnalytics, IntentDataQuality},
+		},
+		{
+			name:    "exact tie keeps response order",
+			intents: []ScoredIntent{{IntentDataQuality, 0.5}, {IntentAnalytics, 0.5}},
+			want:    []IntentCategory{IntentDataQuality, IntentAnalytics},
+		},
+		{
+			name:    "duplicates keep highest",
+			intents: []ScoredIntent{{IntentAnalytics, 0.2}, {IntentDataQuality, 0.7}, {IntentAnalytics, 0.9}},
+			want:    []IntentCategory{IntentAnalytics, IntentDataQuality},
+		},
+		{
+			name:    "unknown dropped next to real intent",
+			intents: []ScoredIntent{{IntentUnknown, 0.5}, {IntentAnalytics, 0.45}},
+			want:    []IntentCategory{IntentAnalytics},
+		},
+		{
+			name: "empty",
+			want: []IntentCategory{IntentUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []IntentCategory
+			for _, si := range selectIntents(tt.intents, defaultMultiIntentThreshold, defaultMultiIntentTieMargin) {
+				got = append(got, si.Intent)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // errorLLMProvider always returns errors
 type errorLLMProvider struct{}
 
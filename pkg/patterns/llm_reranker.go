@@ -6,13 +6,20 @@
 package patterns
 
 import (
+	"container/list"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/teradata-labs/loom/pkg/observability"
 	"github.com/teradata-labs/loom/pkg/types"
+	"go.uber.org/zap"
 )
 
 // LLMReRankerConfig configures the LLM-based pattern re-ranker
@@ -20,20 +27,243 @@ type LLMReRankerConfig struct {
 	// LLM provider to use for re-ranking
 	LLMProvider types.LLMProvider
 
+	// Providers tried in order when the primary provider's Chat call fails or its
+	// response cannot be parsed (optional)
+	FallbackProviders []types.LLMProvider
+
+	// Timeout applied to each provider attempt separately (default: 30 seconds)
+	Timeout time.Duration
+
+	// Overall budget for one re-rank across all providers (default: 30 seconds). Each
+	// attempt gets at most an equal share of what is left, so a primary that hangs
+	// leaves time for its fallbacks. The caller's context deadline applies too;
+	// whichever is sooner wins.
+	ReRankTimeout time.Duration
+
+	// Maximum candidates included in the prompt; only the top-scoring ones are kept (default: 8)
+	MaxCandidates int
+
+	// Maximum characters of each candidate's description included in the prompt (default: 200)
+	DescriptionCharBudget int
+
+	// Logger for re-ranking diagnostics such as JSON repair (default: no-op)
+	Logger *zap.Logger
+
 	// Enable caching of re-ranking results
 	EnableCache bool
 
 	// Cache TTL (default: 30 minutes - longer than intent classification)
 	CacheTTL time.Duration
+
+	// Maximum cached selections before least-recently-used entries are evicted (default: 1000)
+	CacheMaxSize int
 }
 
+const (
+	defaultReRankMaxCandidates     = 8
+	defaultReRankDescriptionBudget = 200
+	defaultReRankProviderTimeout   = 30 * time.Second
+	defaultReRankTimeout           = 30 * time.Second
+)
+
 // DefaultLLMReRankerConfig returns sensible defaults for re-ranking
 func DefaultLLMReRankerConfig(llm types.LLMProvider) *LLMReRankerConfig {
 	return &LLMReRankerConfig{
-		LLMProvider: llm,
-		EnableCache: true,
-		CacheTTL:    30 * time.Minute,
+		LLMProvider:           llm,
+		Timeout:               defaultReRankProviderTimeout,
+		ReRankTimeout:         defaultReRankTimeout,
+		MaxCandidates:         defaultReRankMaxCandidates,
+		DescriptionCharBudget: defaultReRankDescriptionBudget,
+		Logger:                zap.NewNop(),
+		EnableCache:           true,
+		CacheTTL:              30 * time.Minute,
+		CacheMaxSize:          1000,
+	}
+}
+
+// ReRanker selects the most relevant pattern among keyword-ranked candidates.
+// Candidates are ordered by descending keyword score and summaries is keyed by pattern name.
+// The orchestrator passes at most the top 8 candidates, or LLMReRankerConfig.MaxCandidates
+// for an LLMReRanker.
+// Implementations return the selected pattern name and a confidence in [0.0, 1.0].
+type ReRanker interface {
+	// ReRank selects a pattern from candidates for the user message. Implementations
+	// that call out to a service must stop when ctx is done.
+	ReRank(ctx context.Context, userMessage string, candidates []scoredPattern, summaries map[string]PatternSummary) (string, float64, error)
+
+	// Name identifies the re-ranker in traces and metrics (e.g., "llm", "function").
+	Name() string
+}
+
+// explainingReRanker is implemented by re-rankers that can report why they chose a pattern.
+type explainingReRanker interface {
+	reRankWithReasoning(ctx context.Context, userMessage string, candidates []scoredPattern, summaries map[string]PatternSummary) (*reRankingResult, error)
+}
+
+// LLMReRanker re-ranks candidates by asking an LLM to pick the best semantic match.
+// This is the default re-ranker used when an LLM provider is set on the orchestrator.
+type LLMReRanker struct {
+	config *LLMReRankerConfig
+	cache  *reRankCache
+
+	// In-flight LLM calls keyed by cache key, so concurrent identical requests share one call
+	inflightMu sync.Mutex
+	inflight   map[string]*reRankCall
+}
+
+// reRankCall is an LLM re-rank call that other callers with the same key can wait on.
+type reRankCall struct {
+	done   chan struct{}
+	result *reRankingResult
+	err    error
+}
+
+// NewLLMReRanker creates an LLM-based re-ranker from the given config.
+func NewLLMReRanker(config *LLMReRankerConfig) *LLMReRanker {
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	r := &LLMReRanker{config: config, inflight: make(map[string]*reRankCall)}
+	if config.EnableCache {
+		ttl := config.CacheTTL
+		if ttl <= 0 {
+			ttl = 30 * time.Minute
+		}
+		maxSize := config.CacheMaxSize
+		if maxSize <= 0 {
+			maxSize = 1000
+		}
+		r.cache = newReRankCache(maxSize, ttl)
+	}
+	return r
+}
+
+// ReRank implements ReRanker.
+// When caching is enabled, a selection for the same normalized message and candidate
+// set is served from the cache without calling the LLM.
+func (r *LLMReRanker) ReRank(ctx context.Context, userMessage string, candidates []scoredPattern, summaries map[string]PatternSummary) (string, float64, error) {
+	result, err := r.reRankWithReasoning(ctx, userMessage, candidates, summaries)
+	if result == nil {
+		return "", 0.0, err
+	}
+	return result.SelectedPattern, result.Confidence, err
+}
+
+// maxCandidates returns the configured MaxCandidates, or the default of 8 when unset.
+func (r *LLMReRanker) maxCandidates() int {
+	if r.config.MaxCandidates <= 0 {
+		return defaultReRankMaxCandidates
+	}
+	return r.config.MaxCandidates
+}
+
+// reRankWithReasoning implements explainingReRanker. The model's reasoning is kept
+// even when the selection falls back to the keyword winner.
+//
+// The LLM calls are bounded by ctx and the re-rank budget (see reRankBudget). A caller joining an identical
+// in-flight call stops waiting when its own ctx is done; the shared call keeps the
+// context of the caller that started it.
+func (r *LLMReRanker) reRankWithReasoning(ctx context.Context, userMessage string, candidates []scoredPattern, summaries map[string]PatternSummary) (*reRankingResult, error) {
+	candidates = topScoredCandidates(candidates, r.maxCandidates())
+
+	key := reRankCacheKey(userMessage, candidates)
+	if r.cache != nil {
+		if cached := r.cache.Get(key); cached != nil {
+			return cached, nil
+		}
+	}
+
+	// Join an identical call that is already in flight
+	r.inflightMu.Lock()
+	if call, ok := r.inflight[key]; ok {
+		r.inflightMu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("re-ranking cancelled: %w", ctx.Err())
+		}
+		if call.result == nil {
+			return nil, call.err
+		}
+		result := *call.result
+		return &result, call.err
+	}
+	call := &reRankCall{done: make(chan struct{})}
+	r.inflight[key] = call
+	r.inflightMu.Unlock()
+
+	call.result, call.err = r.callProviders(ctx, key, userMessage, candidates, summaries)
+
+	r.inflightMu.Lock()
+	delete(r.inflight, key)
+	r.inflightMu.Unlock()
+	close(call.done)
+
+	if call.result == nil {
+		return nil, call.err
+	}
+	result := *call.result
+	return &result, call.err
+}
+
+// callProviders runs the provider chain and caches successful selections.
+func (r *LLMReRanker) callProviders(ctx context.Context, key, userMessage string, candidates []scoredPattern, summaries map[string]PatternSummary) (*reRankingResult, error) {
+	providers := make([]types.LLMProvider, 0, 1+len(r.config.FallbackProviders))
+	if r.config.LLMProvider != nil {
+		providers = append(providers, r.config.LLMProvider)
+	}
+	for _, p := range r.config.FallbackProviders {
+		if p != nil {
+			providers = append(providers, p)
+		}
+	}
+
+	opts := reRankCallOptions{
+		timeout:           r.providerTimeout(),
+		descriptionBudget: r.config.DescriptionCharBudget,
+		logger:            r.config.Logger,
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.reRankBudget())
+	defer cancel()
+
+	result, err := reRankPatternsWithProviders(ctx, providers, opts, userMessage, candidates, summaries)
+	if err != nil {
+		return result, err
+	}
+
+	if r.cache != nil {
+		r.cache.Set(key, result)
+	}
+	return result, nil
+}
+
+// providerTimeout returns the timeout of a single provider attempt.
+func (r *LLMReRanker) providerTimeout() time.Duration {
+	if r.config.Timeout > 0 {
+		return r.config.Timeout
+	}
+	return defaultReRankProviderTimeout
+}
+
+// reRankBudget returns the overall budget for a re-rank across all providers.
+func (r *LLMReRanker) reRankBudget() time.Duration {
+	if r.config.ReRankTimeout > 0 {
+		return r.config.ReRankTimeout
 	}
+	return defaultReRankTimeout
+}
+
+// CacheStats returns re-ranker cache counters. All values are zero when caching is disabled.
+func (r *LLMReRanker) CacheStats() ReRankCacheStats {
+	if r.cache == nil {
+		return ReRankCacheStats{}
+	}
+	return r.cache.Stats()
+}
+
+// Name implements ReRanker.
+func (r *LLMReRanker) Name() string {
+	return "llm"
 }
 
 // reRankingResult represents the result of LLM-based re-ranking
@@ -41,23 +271,192 @@ type reRankingResult struct {
 	SelectedPattern string  `json:"selected_pattern"`
 	Confidence      float64 `json:"confidence"`
 	Reasoning       string  `json:"reasoning"`
+
+	// Provider that produced the result (set by the re-ranker, not parsed from the response)
+	Provider string `json:"-"`
+}
+
+// reRankPatternsWithProviders tries each provider in order and returns the first
+// result that was parsed successfully. A Chat failure or unparseable response moves
+// on to the next provider, each with its own timeout; a parsed selection outside the
+// candidate set is returned as-is (with its error) since retrying would not fix the candidates.
+// When ctx has a deadline, each attempt is also limited to an equal share of the time
+// left, so the providers after it still get a turn. Remaining providers are skipped
+// once ctx is done.
+func reRankPatternsWithProviders(
+	ctx context.Context,
+	providers []types.LLMProvider,
+	opts reRankCallOptions,
+	userMessage string,
+	candidates []scoredPattern,
+	summaries map[string]PatternSummary,
+) (*reRankingResult, error) {
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("LLM provider is nil")
+	}
+
+	var errs []string
+	for i, provider := range providers {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("re-ranking aborted before provider %d (%s): %w", i, providerLabel(provider), ctx.Err())
+		}
+		attempt := opts
+		if deadline, ok := ctx.Deadline(); ok {
+			if share := time.Until(deadline) / time.Duration(len(providers)-i); share < attempt.timeout {
+				attempt.timeout = share
+			}
+		}
+		result, err := reRankPatternsWithLLM(ctx, provider, attempt, userMessage, candidates, summaries)
+		if result != nil {
+			result.Provider = providerLabel(provider)
+			return result, err
+		}
+		errs = append(errs, fmt.Sprintf("provider %d (%s): %v", i, providerLabel(provider), err))
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("re-ranking aborted: %s: %w", strings.Join(errs, "; "), ctx.Err())
+	}
+	return nil, fmt.Errorf("all %d re-ranking providers failed: %s", len(providers), strings.Join(errs, "; "))
+}
+
+// topScoredCandidates returns the n highest-scoring candidates in descending score
+// order. Equal scores are ordered by name.
+func topScoredCandidates(candidates []scoredPattern, n int) []scoredPattern {
+	sorted := make([]scoredPattern, len(candidates))
+	copy(sorted, candidates)
+	sortScoredPatterns(sorted)
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// truncateRunes shortens s to at most n runes without splitting a multi-byte character.
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// reRankCallOptions are the per-call settings derived from LLMReRankerConfig.
+type reRankCallOptions struct {
+	timeout           time.Duration // Per-provider timeout
+	descriptionBudget int           // Max description runes per candidate
+	logger            *zap.Logger
+}
+
+// withDefaults fills unset options.
+func (o reRankCallOptions) withDefaults() reRankCallOptions {
+	if o.timeout <= 0 {
+		o.timeout = 30 * time.Second
+	}
+	if o.descriptionBudget <= 0 {
+		o.descriptionBudget = defaultReRankDescriptionBudget
+	}
+	if o.logger == nil {
+		o.logger = zap.NewNop()
+	}
+	return o
+}
+
+// repairJSONObject extracts the first balanced {...} object from text, removing
+// // and /* */ comments and trailing commas before a closing brace or bracket.
+// String literals are copied verbatim. Returns false if no balanced object exists.
+func repairJSONObject(text string) (string, bool) {
+	start := strings.IndexByte(text, '{')
+	if start < 0 {
+		return "", false
+	}
+
+	var out strings.Builder
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+
+		if inString {
+			out.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(text) && text[i+1] == '/':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(text) && text[i+1] == '*':
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return "", false
+			}
+			i += end + 3
+		case c == '{' || c == '[':
+			depth++
+			out.WriteByte(c)
+		case c == '}' || c == ']':
+			// Drop a trailing comma (and the whitespace after it) before the closer
+			trimmed := strings.TrimRight(out.String(), " \t\r\n")
+			if strings.HasSuffix(trimmed, ",") {
+				out.Reset()
+				out.WriteString(strings.TrimSuffix(trimmed, ","))
+			}
+			out.WriteByte(c)
+			depth--
+			if depth == 0 {
+				return out.String(), true
+			}
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return "", false
+}
+
+// providerLabel identifies a provider in results and errors.
+func providerLabel(provider types.LLMProvider) string {
+	if model := provider.Model(); model != "" {
+		return provider.Name() + "/" + model
+	}
+	return provider.Name()
 }
 
 // reRankPatternsWithLLM uses LLM to re-rank a set of candidate patterns based on user query.
 // This provides semantic understanding beyond keyword matching.
+// If the LLM selects a pattern outside the candidate set, the result falls back to the
+// top keyword candidate and is returned together with a non-nil error.
 func reRankPatternsWithLLM(
+	ctx context.Context,
 	llmProvider types.LLMProvider,
+	opts reRankCallOptions,
 	userMessage string,
 	candidates []scoredPattern,
 	summaries map[string]PatternSummary,
-) (string, float64, error) {
+) (*reRankingResult, error) {
 	if llmProvider == nil {
-		return "", 0.0, fmt.Errorf("LLM provider is nil")
+		return nil, fmt.Errorf("LLM provider is nil")
 	}
 
 	if len(candidates) == 0 {
-		return "", 0.0, fmt.Errorf("no candidates to re-rank")
+		return nil, fmt.Errorf("no candidates to re-rank")
 	}
+	opts = opts.withDefaults()
 
 	// Build prompt with pattern candidates
 	var promptBuilder strings.Builder
@@ -71,7 +470,7 @@ func reRankPatternsWithLLM(
 		promptBuilder.WriteString(fmt.Sprintf("%d. %s\n", i+1, candidate.name))
 		promptBuilder.WriteString(fmt.Sprintf("   Title: %s\n", summary.Title))
 		promptBuilder.WriteString(fmt.Sprintf("   Category: %s\n", summary.Category))
-		promptBuilder.WriteString(fmt.Sprintf("   Description: %s\n", summary.Description[:min(200, len(summary.Description))]))
+		promptBuilder.WriteString(fmt.Sprintf("   Description: %s\n", truncateRunes(summary.Description, opts.descriptionBudget)))
 
 		if len(summary.UseCases) > 0 {
 			promptBuilder.WriteString(fmt.Sprintf("   Use Cases: %s\n",
@@ -96,7 +495,7 @@ func reRankPatternsWithLLM(
 	prompt := promptBuilder.String()
 
 	// Call LLM
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
 	defer cancel()
 
 	messages := []types.Message{
@@ -108,7 +507,7 @@ func reRankPatternsWithLLM(
 
 	response, err := llmProvider.Chat(ctx, messages, nil)
 	if err != nil {
-		return "", 0.0, fmt.Errorf("LLM generation failed: %w", err)
+		return nil, fmt.Errorf("LLM generation failed: %w", err)
 	}
 
 	// Parse JSON response
@@ -133,7 +532,20 @@ func reRankPatternsWithLLM(
 
 	var result reRankingResult
 	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
-		return "", 0.0, fmt.Errorf("failed to parse LLM response: %w\nResponse: %s", err, responseText)
+		// Models sometimes add prose, comments, or trailing commas; try the first object alone
+		repaired, ok := repairJSONObject(response.Content)
+		repairErr := fmt.Errorf("no JSON object found")
+		if ok {
+			result = reRankingResult{}
+			repairErr = json.Unmarshal([]byte(repaired), &result)
+		}
+		opts.logger.Debug("Repairing malformed re-ranker response",
+			zap.String("raw_response", observability.Redact(response.Content)),
+			zap.Bool("repaired", repairErr == nil),
+			zap.Error(err))
+		if repairErr != nil {
+			return nil, fmt.Errorf("failed to parse LLM response: %w\nResponse: %s", err, observability.Redact(responseText))
+		}
 	}
 
 	// Validate selected pattern is in candidates
@@ -147,8 +559,10 @@ func reRankPatternsWithLLM(
 
 	if !found {
 		// Fallback to highest keyword score
-		return candidates[0].name, candidates[0].score,
-			fmt.Errorf("LLM selected pattern not in candidates: %s", result.SelectedPattern)
+		selected := result.SelectedPattern
+		result.SelectedPattern = candidates[0].name
+		result.Confidence = candidates[0].score
+		return &result, fmt.Errorf("LLM selected pattern not in candidates: %s", selected)
 	}
 
 	// Ensure confidence is in valid range
@@ -159,43 +573,75 @@ func reRankPatternsWithLLM(
 		result.Confidence = 1.0
 	}
 
-	return result.SelectedPattern, result.Confidence, nil
+	return &result, nil
 }
 
-// shouldInvokeLLMReRanker determines if LLM re-ranking should be used.
-// With accuracy preference, we invoke LLM more aggressively.
-func shouldInvokeLLMReRanker(
-	scored []scoredPattern,
-	intent IntentCategory,
-	llmProvider types.LLMProvider,
-) bool {
-	// No LLM provider available
-	if llmProvider == nil {
-		return false
+// reRankTrigger identifies why keyword results are too ambiguous to trust on their own.
+type reRankTrigger int
+
+const (
+	reRankTriggerNone reRankTrigger = iota
+	reRankTriggerUnknownIntent
+	reRankTriggerLowScore
+	reRankTriggerCloseRace
+	reRankTriggerStrongCandidates
+)
+
+// String returns the trigger name used in traces and diagnostics ("" for none).
+func (t reRankTrigger) String() string {
+	switch t {
+	case reRankTriggerUnknownIntent:
+		return "unknown_intent"
+	case reRankTriggerLowScore:
+		return "low_score"
+	case reRankTriggerCloseRace:
+		return "close_race"
+	case reRankTriggerStrongCandidates:
+		return "strong_candidates"
+	default:
+		return ""
 	}
+}
+
+// resolvedReason maps a trigger to the reason code reported when the re-ranker resolved it.
+func (t reRankTrigger) resolvedReason() ConfidenceReason {
+	switch t {
+	case reRankTriggerUnknownIntent:
+		return ReasonLLMResolvedUnknownIntent
+	case reRankTriggerCloseRace, reRankTriggerStrongCandidates:
+		return ReasonLLMResolvedCloseRace
+	case reRankTriggerLowScore:
+		return ReasonLowConfidenceBestGuess
+	default:
+		return ReasonClearKeywordWinner
+	}
+}
 
+// detectReRankTrigger evaluates keyword scores and returns the first re-ranking trigger that applies.
+// With accuracy preference, the thresholds are aggressive.
+func detectReRankTrigger(scored []scoredPattern, intent IntentCategory) reRankTrigger {
 	// No patterns to re-rank
 	if len(scored) == 0 {
-		return false
+		return reRankTriggerNone
 	}
 
 	// AGGRESSIVE TRIGGERS (accuracy over speed)
 
 	// 1. Always use LLM for unknown intent
 	if intent == IntentUnknown {
-		return true
+		return reRankTriggerUnknownIntent
 	}
 
 	// 2. Use LLM when top score is uncertain (< 0.70)
 	// Raised threshold from 0.60 to 0.70 for better accuracy
 	if scored[0].score < 0.70 {
-		return true
+		return reRankTriggerLowScore
 	}
 
 	// 3. Use LLM when there's a close race (top 2 within 0.20)
 	// Increased from 0.15 to 0.20 to catch more ambiguous cases
 	if len(scored) >= 2 && (scored[0].score-scored[1].score) < 0.20 {
-		return true
+		return reRankTriggerCloseRace
 	}
 
 	// 4. Use LLM when there are multiple strong candidates (3+ patterns > 0.60)
@@ -206,11 +652,26 @@ func shouldInvokeLLMReRanker(
 		}
 	}
 	if strongCandidates >= 3 {
-		return true
+		return reRankTriggerStrongCandidates
 	}
 
 	// Clear winner - use fast path
-	return false
+	return reRankTriggerNone
+}
+
+// shouldInvokeLLMReRanker determines if LLM re-ranking should be used.
+// With accuracy preference, we invoke LLM more aggressively.
+func shouldInvokeLLMReRanker(
+	scored []scoredPattern,
+	intent IntentCategory,
+	reRanker ReRanker,
+) bool {
+	// No re-ranker available
+	if reRanker == nil {
+		return false
+	}
+
+	return detectReRankTrigger(scored, intent) != reRankTriggerNone
 }
 
 func min(a, b int) int {
@@ -219,3 +680,107 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// ReRankCacheStats reports re-ranker cache effectiveness.
+type ReRankCacheStats struct {
+	Hits   int64
+	Misses int64
+	Size   int
+}
+
+// reRankCache is an LRU cache of LLM re-ranking selections with per-entry expiry.
+// Expired entries are evicted lazily on lookup.
+type reRankCache struct {
+	mu      sync.Mutex
+	maxSize int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+	hits    int64
+	misses  int64
+}
+
+type reRankCacheEntry struct {
+	key       string
+	result    reRankingResult
+	expiresAt time.Time
+}
+
+func newReRankCache(maxSize int, ttl time.Duration) *reRankCache {
+	return &reRankCache{
+		maxSize: maxSize,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// reRankCacheKey hashes the normalized user message together with the sorted
+// candidate names, so the same query over the same candidates hits the cache
+// regardless of keyword-score ordering or whitespace/case differences.
+func reRankCacheKey(userMessage string, candidates []scoredPattern) string {
+	names := make([]string, len(candidates))
+	for i, c := range candidates {
+		names[i] = c.name
+	}
+	sort.Strings(names)
+
+	normalized := strings.Join(strings.Fields(strings.ToLower(userMessage)), " ")
+	sum := sha256.Sum256([]byte(normalized + "\x00" + strings.Join(names, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *reRankCache) Get(key string) *reRankingResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil
+	}
+
+	entry := elem.Value.(*reRankCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		c.misses++
+		return nil
+	}
+
+	c.order.MoveToFront(elem)
+	c.hits++
+	result := entry.result
+	return &result
+}
+
+func (c *reRankCache) Set(key string, result *reRankingResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*reRankCacheEntry)
+		entry.result = *result
+		entry.expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&reRankCacheEntry{
+		key:       key,
+		result:    *result,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+
+	for c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*reRankCacheEntry).key)
+	}
+}
+
+func (c *reRankCache) Stats() ReRankCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ReRankCacheStats{Hits: c.hits, Misses: c.misses, Size: c.order.Len()}
+}
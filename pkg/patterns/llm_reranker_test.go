@@ -0,0 +1,587 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/types"
+)
+
+// failingReRanker always returns an error
+type failingReRanker struct{}
+
+func (f *failingReRanker) ReRank(context.Context, string, []scoredPattern, map[string]PatternSummary) (string, float64, error) {
+	return "", 0.0, fmt.Errorf("re-ranker unavailable")
+}
+
+func (f *failingReRanker) Name() string {
+	return "failing"
+}
+
+func TestDetectReRankTrigger(t *testing.T) {
+	tests := []struct {
+		name     string
+		scored   []scoredPattern
+		intent   IntentCategory
+		expected reRankTrigger
+	}{
+		{
+			name:     "no candidates",
+			scored:   nil,
+			intent:   IntentAnalytics,
+			expected: reRankTriggerNone,
+		},
+		{
+			name:     "unknown intent",
+			scored:   []scoredPattern{{name: "a", score: 0.95}},
+			intent:   IntentUnknown,
+			expected: reRankTriggerUnknownIntent,
+		},
+		{
+			name:     "low top score",
+			scored:   []scoredPattern{{name: "a", score: 0.5}},
+			intent:   IntentAnalytics,
+			expected: reRankTriggerLowScore,
+		},
+		{
+			name:     "close race",
+			scored:   []scoredPattern{{name: "a", score: 0.9}, {name: "b", score: 0.8}},
+			intent:   IntentAnalytics,
+			expected: reRankTriggerCloseRace,
+		},
+		{
+			name:     "clear winner",
+			scored:   []scoredPattern{{name: "a", score: 0.95}, {name: "b", score: 0.3}},
+			intent:   IntentAnalytics,
+			expected: reRankTriggerNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectReRankTrigger(tt.scored, tt.intent))
+		})
+	}
+}
+
+func TestOrchestrator_Recommend_ReasonCodes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"time_series": `name: time_series
+title: Time Series Analysis
+description: Pattern for analyzing time series data
+category: analytics
+use_cases:
+  - time series
+`,
+		"data_validation": `name: data_validation
+title: Data Quality Validation
+description: Pattern for validating data quality
+category: data_quality
+use_cases:
+  - validation
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+
+	lib := NewLibrary(nil, tmpDir)
+
+	t.Run("clear keyword winner", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		rec := orch.Recommend("time series", IntentAnalytics)
+		assert.Equal(t, "time_series", rec.PatternName)
+		assert.Equal(t, ReasonClearKeywordWinner, rec.Reason)
+	})
+
+	t.Run("unknown intent resolved by re-ranker", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		orch.SetReRanker(NewFunctionReRanker(nil))
+		rec := orch.Recommend("time series", IntentUnknown)
+		assert.Equal(t, "time_series", rec.PatternName)
+		assert.Equal(t, ReasonLLMResolvedUnknownIntent, rec.Reason)
+	})
+
+	t.Run("ambiguous without re-ranker", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		rec := orch.Recommend("time series", IntentUnknown)
+		assert.Equal(t, ReasonLowConfidenceBestGuess, rec.Reason)
+	})
+
+	t.Run("fallback after re-ranker error", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		orch.SetReRanker(&failingReRanker{})
+		rec := orch.Recommend("time series", IntentUnknown)
+		assert.Equal(t, "time_series", rec.PatternName)
+		assert.Equal(t, ReasonKeywordFallbackAfterLLMError, rec.Reason)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		rec := orch.Recommend("xyz random words", IntentUnknown)
+		assert.Equal(t, Recommendation{}, rec)
+	})
+}
+
+func TestLLMReRanker_Cache(t *testing.T) {
+	llm := &mockLLMProvider{
+		defaultResponse: `{"selected_pattern": "b", "confidence": 0.8, "reasoning": "better fit"}`,
+	}
+	reRanker := NewLLMReRanker(DefaultLLMReRankerConfig(llm))
+
+	candidates := []scoredPattern{{name: "a", score: 0.7}, {name: "b", score: 0.65}}
+	summaries := map[string]PatternSummary{"a": {Name: "a"}, "b": {Name: "b"}}
+
+	name, conf, err := reRanker.ReRank(context.Background(), "Top revenue by region", candidates, summaries)
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+	assert.Equal(t, 0.8, conf)
+
+	// Same normalized query and candidate set (different order) hits the cache
+	reordered := []scoredPattern{candidates[1], candidates[0]}
+	name, conf, err = reRanker.ReRank(context.Background(), "  top revenue   BY region ", reordered, summaries)
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+	assert.Equal(t, 0.8, conf)
+	assert.Equal(t, 1, llm.callCount, "cached selection should not call the LLM")
+
+	// A different candidate set misses
+	_, _, err = reRanker.ReRank(context.Background(), "top revenue by region", append(candidates, scoredPattern{name: "c", score: 0.5}), summaries)
+	require.NoError(t, err)
+	assert.Equal(t, 2, llm.callCount)
+
+	assert.Equal(t, ReRankCacheStats{Hits: 1, Misses: 2, Size: 2}, reRanker.CacheStats())
+}
+
+func TestLLMReRanker_CacheDisabledOnError(t *testing.T) {
+	llm := &mockLLMProvider{
+		defaultResponse: `{"selected_pattern": "zzz", "confidence": 0.9, "reasoning": "hallucinated"}`,
+	}
+	reRanker := NewLLMReRanker(DefaultLLMReRankerConfig(llm))
+	candidates := []scoredPattern{{name: "a", score: 0.7}}
+
+	for i := 0; i < 2; i++ {
+		name, _, err := reRanker.ReRank(context.Background(), "query", candidates, map[string]PatternSummary{})
+		assert.Error(t, err)
+		assert.Equal(t, "a", name, "falls back to the keyword winner")
+	}
+	assert.Equal(t, 2, llm.callCount, "failed selections are not cached")
+}
+
+// scriptedLLMProvider returns a fixed error, or blocks until the context is done.
+type scriptedLLMProvider struct {
+	name     string
+	err      error
+	block    bool
+	response string
+	calls    int
+}
+
+func (p *scriptedLLMProvider) Chat(ctx context.Context, _ []types.Message, _ []shuttle.Tool) (*types.LLMResponse, error) {
+	p.calls++
+	if p.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &types.LLMResponse{Content: p.response}, nil
+}
+
+func (p *scriptedLLMProvider) Name() string  { return p.name }
+func (p *scriptedLLMProvider) Model() string { return "" }
+
+func TestLLMReRanker_FallbackProviders(t *testing.T) {
+	candidates := []scoredPattern{{name: "a", score: 0.7}, {name: "b", score: 0.65}}
+	summaries := map[string]PatternSummary{"a": {Name: "a"}, "b": {Name: "b"}}
+	good := `{"selected_pattern": "b", "confidence": 0.8, "reasoning": "better fit"}`
+
+	t.Run("chat error moves to next provider", func(t *testing.T) {
+		primary := &scriptedLLMProvider{name: "primary", err: fmt.Errorf("throttled")}
+		secondary := &scriptedLLMProvider{name: "secondary", response: good}
+		config := DefaultLLMReRankerConfig(primary)
+		config.FallbackProviders = []types.LLMProvider{secondary}
+
+		result, err := NewLLMReRanker(config).reRankWithReasoning(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "b", result.SelectedPattern)
+		assert.Equal(t, "secondary", result.Provider)
+		assert.Equal(t, 1, primary.calls)
+	})
+
+	t.Run("unparseable response moves to next provider", func(t *testing.T) {
+		primary := &scriptedLLMProvider{name: "primary", response: "I think b is best"}
+		secondary := &scriptedLLMProvider{name: "secondary", response: good}
+		config := DefaultLLMReRankerConfig(primary)
+		config.FallbackProviders = []types.LLMProvider{secondary}
+
+		result, err := NewLLMReRanker(config).reRankWithReasoning(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "secondary", result.Provider)
+	})
+
+	t.Run("primary result records provider", func(t *testing.T) {
+		primary := &mockLLMProvider{defaultResponse: good}
+		secondary := &scriptedLLMProvider{name: "secondary", response: good}
+		config := DefaultLLMReRankerConfig(primary)
+		config.FallbackProviders = []types.LLMProvider{secondary}
+
+		result, err := NewLLMReRanker(config).reRankWithReasoning(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "mock/mock-model", result.Provider)
+		assert.Equal(t, 0, secondary.calls)
+	})
+
+	t.Run("timeouts are per provider", func(t *testing.T) {
+		primary := &scriptedLLMProvider{name: "primary", block: true}
+		secondary := &scriptedLLMProvider{name: "secondary", block: true}
+		tertiary := &scriptedLLMProvider{name: "tertiary", response: good}
+		config := DefaultLLMReRankerConfig(primary)
+		config.FallbackProviders = []types.LLMProvider{secondary, tertiary}
+		config.Timeout = 50 * time.Millisecond
+
+		result, err := NewLLMReRanker(config).reRankWithReasoning(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "tertiary", result.Provider)
+		assert.Equal(t, 1, secondary.calls)
+	})
+
+	t.Run("all providers fail", func(t *testing.T) {
+		primary := &scriptedLLMProvider{name: "primary", err: fmt.Errorf("throttled")}
+		secondary := &scriptedLLMProvider{name: "secondary", response: "not json"}
+		config := DefaultLLMReRankerConfig(primary)
+		config.FallbackProviders = []types.LLMProvider{secondary}
+
+		result, err := NewLLMReRanker(config).reRankWithReasoning(context.Background(), "query", candidates, summaries)
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "primary")
+		assert.Contains(t, err.Error(), "secondary")
+	})
+}
+
+func TestLLMReRanker_PromptBudget(t *testing.T) {
+	llm := &mockLLMProvider{
+		defaultResponse: `{"selected_pattern": "p11", "confidence": 0.8, "reasoning": "best"}`,
+	}
+	config := DefaultLLMReRankerConfig(llm)
+	config.MaxCandidates = 3
+	config.DescriptionCharBudget = 10
+	reRanker := NewLLMReRanker(config)
+
+	candidates := make([]scoredPattern, 12)
+	summaries := make(map[string]PatternSummary)
+	for i := range candidates {
+		name := fmt.Sprintf("p%d", i)
+		candidates[i] = scoredPattern{name: name, score: float64(i) / 20}
+		summaries[name] = PatternSummary{Name: name, Description: "abcdefghijklmnopqrstuvwxyz"}
+	}
+
+	name, _, err := reRanker.ReRank(context.Background(), "query", candidates, summaries)
+	require.NoError(t, err)
+	assert.Equal(t, "p11", name)
+
+	require.Len(t, llm.lastCall, 1)
+	prompt := llm.lastCall[0].Content
+	for _, included := range []string{"1. p11\n", "2. p10\n", "3. p9\n"} {
+		assert.Contains(t, prompt, included)
+	}
+	assert.NotContains(t, prompt, "4. ")
+	assert.Contains(t, prompt, "Description: abcdefghij\n")
+
+	// Defaults apply when unset
+	defaults := DefaultLLMReRankerConfig(llm)
+	assert.Equal(t, 8, defaults.MaxCandidates)
+	assert.Equal(t, 200, defaults.DescriptionCharBudget)
+	assert.Len(t, topScoredCandidates(candidates, defaults.MaxCandidates), 8)
+}
+
+func TestOrchestrator_ReRankCandidateCap(t *testing.T) {
+	tmpDir := t.TempDir()
+	for i := 0; i < 12; i++ {
+		content := fmt.Sprintf("name: p%d\ncategory: analytics\nuse_cases:\n  - revenue report\n", i)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, fmt.Sprintf("p%d.yaml", i)), []byte(content), 0644))
+	}
+	lib := NewLibrary(nil, tmpDir)
+
+	// Re-rankers without their own limit are given the default top 8
+	seen := 0
+	orch := NewOrchestrator(lib).WithReRanker(NewFunctionReRanker(func(string, PatternSummary, float64) float64 {
+		seen++
+		return 0.5
+	}))
+	orch.RecommendPattern("revenue report", IntentUnknown)
+	assert.Equal(t, 8, seen)
+
+	// An LLM re-ranker's MaxCandidates sets the limit, and summaries cover only those sent
+	llm := &mockLLMProvider{defaultResponse: `{"selected_pattern": "p0", "confidence": 0.8, "reasoning": "best"}`}
+	config := DefaultLLMReRankerConfig(llm)
+	config.MaxCandidates = 3
+	reRanker := NewLLMReRanker(config)
+	assert.Equal(t, 3, reRankCandidateLimit(reRanker))
+	orch.SetReRanker(reRanker)
+	orch.RecommendPattern("revenue report", IntentUnknown)
+	require.Len(t, llm.lastCall, 1)
+	assert.Contains(t, llm.lastCall[0].Content, "3. ")
+	assert.NotContains(t, llm.lastCall[0].Content, "4. ")
+}
+
+func TestRepairJSONObject(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{"prose after object", `{"a": 1} I hope this helps!`, `{"a": 1}`, true},
+		{"prose before object", `Sure: {"a": {"b": 2}}`, `{"a": {"b": 2}}`, true},
+		{"trailing commas", "{\"a\": [1, 2,],\n \"b\": 3,\n}", "{\"a\": [1, 2],\n \"b\": 3}", true},
+		{"comments", "{\"a\": 1, // pick a\n /* note */ \"b\": 2}", `{"a": 1,   "b": 2}`, true},
+		{"braces and slashes in strings", `{"r": "use } and // here, \"x\""}`, `{"r": "use } and // here, \"x\""}`, true},
+		{"unbalanced", `{"a": 1`, "", false},
+		{"no object", "no json here", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := repairJSONObject(tt.in)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLLMReRanker_RepairsMalformedResponse(t *testing.T) {
+	candidates := []scoredPattern{{name: "a", score: 0.7}, {name: "b", score: 0.65}}
+	summaries := map[string]PatternSummary{"a": {Name: "a"}, "b": {Name: "b"}}
+
+	llm := &mockLLMProvider{defaultResponse: "```json\n{\n  \"selected_pattern\": \"b\", // best match\n  \"confidence\": 0.8,\n  \"reasoning\": \"fits\",\n}\n```\nLet me know if you need more."}
+	name, conf, err := NewLLMReRanker(DefaultLLMReRankerConfig(llm)).ReRank(context.Background(), "query", candidates, summaries)
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+	assert.Equal(t, 0.8, conf)
+
+	// Irreparable responses still fail
+	llm = &mockLLMProvider{defaultResponse: "I would choose b"}
+	_, _, err = NewLLMReRanker(DefaultLLMReRankerConfig(llm)).ReRank(context.Background(), "query", candidates, summaries)
+	assert.Error(t, err)
+
+	// A config without a logger is usable, and the orchestrator's logger reaches the re-ranker
+	config := DefaultLLMReRankerConfig(llm)
+	config.Logger = nil
+	_, _, err = NewLLMReRanker(config).ReRank(context.Background(), "query", candidates, summaries)
+	assert.Error(t, err)
+
+	core, logs := observer.New(zap.DebugLevel)
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		content := "name: " + name + "\ncategory: analytics\nuse_cases:\n  - revenue report\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir)).WithLogger(zap.New(core))
+	orch.SetLLMProvider(llm)
+	orch.RecommendPattern("revenue report", IntentUnknown)
+	assert.Equal(t, 1, logs.FilterMessage("Repairing malformed re-ranker response").Len())
+}
+
+func TestReRankCache_ExpiryAndLRU(t *testing.T) {
+	cache := newReRankCache(2, time.Hour)
+	cache.Set("a", &reRankingResult{SelectedPattern: "a"})
+	cache.Set("b", &reRankingResult{SelectedPattern: "b"})
+
+	// Touch "a" so "b" becomes least recently used
+	require.NotNil(t, cache.Get("a"))
+	cache.Set("c", &reRankingResult{SelectedPattern: "c"})
+
+	assert.Nil(t, cache.Get("b"), "least recently used entry is evicted")
+	assert.NotNil(t, cache.Get("a"))
+	assert.NotNil(t, cache.Get("c"))
+
+	expiring := newReRankCache(10, time.Millisecond)
+	expiring.Set("x", &reRankingResult{SelectedPattern: "x"})
+	time.Sleep(5 * time.Millisecond)
+	assert.Nil(t, expiring.Get("x"))
+	assert.Equal(t, 0, expiring.Stats().Size, "expired entries are evicted on lookup")
+}
+
+func TestOrchestrator_RecommendPatternWithExplanation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"time_series": `name: time_series
+title: Time Series Analysis
+description: Pattern for analyzing time series data
+category: analytics
+use_cases:
+  - time series
+`,
+		"trend_analysis": `name: trend_analysis
+title: Trend Analysis
+description: Pattern for analyzing trends in series data
+category: analytics
+use_cases:
+  - trends
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+	lib := NewLibrary(nil, tmpDir)
+
+	t.Run("llm reasoning", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		orch.SetLLMProvider(&mockLLMProvider{
+			defaultResponse: `{"selected_pattern": "trend_analysis", "confidence": 0.88, "reasoning": "User asks about trends"}`,
+		})
+
+		name, conf, explanation := orch.RecommendPatternWithExplanation("analyze series trends", IntentUnknown)
+		assert.Equal(t, "trend_analysis", name)
+		assert.Equal(t, 0.88, conf)
+		assert.Equal(t, "User asks about trends", explanation)
+	})
+
+	t.Run("llm reasoning kept when pick is outside candidates", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		orch.SetLLMProvider(&mockLLMProvider{
+			defaultResponse: `{"selected_pattern": "not_a_pattern", "confidence": 0.9, "reasoning": "Hallucinated pick"}`,
+		})
+
+		rec := orch.Recommend("analyze series trends", IntentUnknown)
+		assert.Equal(t, ReasonKeywordFallbackAfterLLMError, rec.Reason)
+		assert.Equal(t, "Hallucinated pick", rec.Explanation)
+	})
+
+	t.Run("keyword explanation", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		name, _, explanation := orch.RecommendPatternWithExplanation("time series", IntentAnalytics)
+		assert.Equal(t, "time_series", name)
+		assert.Contains(t, explanation, "keyword matching")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		orch := NewOrchestrator(lib)
+		name, _, explanation := orch.RecommendPatternWithExplanation("xyz random words", IntentUnknown)
+		assert.Empty(t, name)
+		assert.Empty(t, explanation)
+	})
+}
+
+func TestLLMReRanker_Cancellation(t *testing.T) {
+	candidates := []scoredPattern{{name: "a", score: 0.7}, {name: "b", score: 0.65}}
+	summaries := map[string]PatternSummary{"a": {Name: "a"}, "b": {Name: "b"}}
+	response := `{"selected_pattern": "b", "confidence": 0.8, "reasoning": "fits"}`
+
+	t.Run("cancel aborts in-flight call", func(t *testing.T) {
+		llm := &slowLLMProvider{delay: 10 * time.Second, response: response}
+		config := DefaultLLMReRankerConfig(llm)
+		config.FallbackProviders = []types.LLMProvider{&mockLLMProvider{defaultResponse: response}}
+		reRanker := NewLLMReRanker(config)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, _, err := reRanker.ReRank(ctx, "query", candidates, summaries)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, 1, llm.callCount())
+		assert.Equal(t, 0, config.FallbackProviders[0].(*mockLLMProvider).callCount, "fallback skipped after cancellation")
+	})
+
+	t.Run("caller deadline shorter than budget", func(t *testing.T) {
+		llm := &slowLLMProvider{delay: 10 * time.Second, response: response}
+		reRanker := NewLLMReRanker(DefaultLLMReRankerConfig(llm))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, _, err := reRanker.ReRank(ctx, "query", candidates, summaries)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("budget shorter than caller deadline", func(t *testing.T) {
+		llm := &slowLLMProvider{delay: 10 * time.Second, response: response}
+		config := DefaultLLMReRankerConfig(llm)
+		config.ReRankTimeout = 50 * time.Millisecond
+
+		start := time.Now()
+		_, _, err := NewLLMReRanker(config).ReRank(context.Background(), "query", candidates, summaries)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("budget is shared with the fallback chain", func(t *testing.T) {
+		defaults := DefaultLLMReRankerConfig(&mockLLMProvider{defaultResponse: response})
+		assert.Equal(t, 30*time.Second, defaults.ReRankTimeout)
+		assert.Equal(t, 30*time.Second, NewLLMReRanker(defaults).reRankBudget())
+		defaults.ReRankTimeout = 0
+		assert.Equal(t, 30*time.Second, NewLLMReRanker(defaults).reRankBudget(), "unset budget uses the default")
+
+		// A primary that hangs past its share of the budget leaves the fallback time to answer
+		llm := &slowLLMProvider{delay: 10 * time.Second, response: `{"selected_pattern": "a", "confidence": 0.9, "reasoning": "slow"}`}
+		fallback := &mockLLMProvider{defaultResponse: response}
+		config := DefaultLLMReRankerConfig(llm)
+		config.FallbackProviders = []types.LLMProvider{fallback}
+		config.ReRankTimeout = 200 * time.Millisecond
+
+		start := time.Now()
+		name, _, err := NewLLMReRanker(config).ReRank(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "b", name)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, 1, fallback.callCount)
+	})
+
+	t.Run("fallback runs after primary times out", func(t *testing.T) {
+		llm := &slowLLMProvider{delay: 10 * time.Second, response: `{"selected_pattern": "a", "confidence": 0.9, "reasoning": "slow"}`}
+		fallback := &mockLLMProvider{defaultResponse: response}
+		config := DefaultLLMReRankerConfig(llm)
+		config.FallbackProviders = []types.LLMProvider{fallback}
+		config.Timeout = 50 * time.Millisecond // The primary's attempt uses up one provider timeout
+
+		name, _, err := NewLLMReRanker(config).ReRank(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "b", name)
+		assert.Equal(t, 1, llm.callCount())
+		assert.Equal(t, 1, fallback.callCount)
+	})
+
+	t.Run("cancelled context is not cached", func(t *testing.T) {
+		llm := &mockLLMProvider{defaultResponse: response}
+		reRanker := NewLLMReRanker(DefaultLLMReRankerConfig(llm))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := reRanker.ReRank(ctx, "query", candidates, summaries)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, llm.callCount)
+
+		name, _, err := reRanker.ReRank(context.Background(), "query", candidates, summaries)
+		require.NoError(t, err)
+		assert.Equal(t, "b", name)
+	})
+}
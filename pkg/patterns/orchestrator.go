@@ -16,8 +16,14 @@ package patterns
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+
+	"go.uber.org/zap"
 
 	"github.com/teradata-labs/loom/pkg/metaagent/learning"
 	"github.com/teradata-labs/loom/pkg/observability"
@@ -29,16 +35,55 @@ import (
 type Orchestrator struct {
 	library *Library
 	tracer  observability.Tracer
+	logger  *zap.Logger
 	tracker *learning.PatternEffectivenessTracker
 
 	// Pluggable intent classifier (backend-specific)
 	intentClassifier IntentClassifierFunc
 
+	// Pluggable multi-intent classifier (nil wraps intentClassifier)
+	multiIntentClassifier MultiIntentClassifierFunc
+
 	// Pluggable execution planner (backend-specific)
 	executionPlanner ExecutionPlannerFunc
 
 	// LLM provider for re-ranking (optional, enables hybrid approach)
 	llmProvider types.LLMProvider
+
+	// Re-ranker applied to ambiguous keyword results (nil disables re-ranking)
+	reRanker ReRanker
+
+	// reRanker was set with WithReRanker or SetReRanker, so SetLLMProvider keeps it
+	customReRanker bool
+
+	// Restrict candidates to patterns whose category matches the classified intent
+	requireIntentMatch bool
+
+	// Final confidence below which no pattern is recommended (0 disables)
+	minConfidence float64
+
+	// Pattern categories that serve each intent (see WithIntentCategories)
+	intentCategories intentCategoryIndex
+
+	// Weight of embedding similarity in the blended score (0 = keyword only)
+	semanticWeight float64
+
+	// Per-field keyword scoring weights (zero value means DefaultScoringWeights)
+	scoringWeights ScoringWeights
+
+	// Ignore pattern ExcludeKeywords when scoring (exclusions apply by default)
+	ignoreExcludeKeywords bool
+
+	// Maps raw keyword scores to calibrated confidences (nil leaves scores raw)
+	calibration *Calibration
+
+	// Receives an event after each recommendation (nil disables recording)
+	sink RecommendationSink
+
+	// User feedback store and the score adjustment it may apply (0 disables)
+	feedbackMu    sync.Mutex
+	feedback      *FeedbackStore
+	feedbackBoost float64
 }
 
 // NewOrchestrator creates a new orchestrator with the given library.
@@ -46,8 +91,10 @@ func NewOrchestrator(library *Library) *Orchestrator {
 	return &Orchestrator{
 		library:          library,
 		tracer:           observability.NewNoOpTracer(),
+		logger:           zap.NewNop(),
 		intentClassifier: defaultIntentClassifier,
 		executionPlanner: defaultExecutionPlanner,
+		intentCategories: newIntentCategoryIndex(DefaultIntentCategories()),
 	}
 }
 
@@ -57,6 +104,16 @@ func (o *Orchestrator) WithTracer(tracer observability.Tracer) *Orchestrator {
 	return o
 }
 
+// WithLogger sets the logger for orchestrator diagnostics. The LLM re-ranker installed
+// by SetLLMProvider logs through it, so call WithLogger first. A nil logger discards logs.
+func (o *Orchestrator) WithLogger(logger *zap.Logger) *Orchestrator {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	o.logger = logger
+	return o
+}
+
 // WithTracker sets the pattern effectiveness tracker for the orchestrator.
 // When set, the orchestrator will record pattern usage metrics after execution.
 func (o *Orchestrator) WithTracker(tracker *learning.PatternEffectivenessTracker) *Orchestrator {
@@ -64,6 +121,26 @@ func (o *Orchestrator) WithTracker(tracker *learning.PatternEffectivenessTracker
 	return o
 }
 
+// WithIntentCategories replaces the mapping from intents to the pattern categories
+// that serve them (default: DefaultIntentCategories). For a mapped intent, patterns in
+// the mapped categories get the intent boost, and all other patterns keep only half
+// their score so unrelated categories rank behind them without being excluded.
+// Intents missing from the mapping only match a category of the same name and
+// down-weight nothing. A nil mapping restores the default.
+func (o *Orchestrator) WithIntentCategories(mapping map[IntentCategory][]Category) *Orchestrator {
+	if mapping == nil {
+		mapping = DefaultIntentCategories()
+	}
+	o.intentCategories = newIntentCategoryIndex(mapping)
+	return o
+}
+
+// IntentCategories returns the intent-to-category mapping in effect, with
+// categories canonicalized and sorted.
+func (o *Orchestrator) IntentCategories() map[IntentCategory][]Category {
+	return o.intentCategories.mapping()
+}
+
 // GetLibrary returns the pattern library.
 func (o *Orchestrator) GetLibrary() *Library {
 	return o.library
@@ -75,6 +152,12 @@ func (o *Orchestrator) SetIntentClassifier(classifier IntentClassifierFunc) {
 	o.intentClassifier = classifier
 }
 
+// SetMultiIntentClassifier sets the classifier used by ClassifyMulti.
+// When unset, ClassifyMulti wraps the single-intent classifier and returns one intent.
+func (o *Orchestrator) SetMultiIntentClassifier(classifier MultiIntentClassifierFunc) {
+	o.multiIntentClassifier = classifier
+}
+
 // SetExecutionPlanner sets a custom execution planner function.
 // Backends can provide domain-specific planners for optimized execution.
 func (o *Orchestrator) SetExecutionPlanner(planner ExecutionPlannerFunc) {
@@ -83,16 +166,126 @@ func (o *Orchestrator) SetExecutionPlanner(planner ExecutionPlannerFunc) {
 
 // SetLLMProvider sets the LLM provider for pattern re-ranking.
 // When set, enables hybrid approach: fast keyword matching + LLM re-ranking for ambiguous cases.
+// The provider gets an LLMReRanker with DefaultLLMReRankerConfig unless a re-ranker was
+// configured with WithReRanker or SetReRanker, which is kept.
 func (o *Orchestrator) SetLLMProvider(provider types.LLMProvider) {
 	o.llmProvider = provider
+	if o.customReRanker {
+		return
+	}
+	if provider == nil {
+		o.reRanker = nil
+		return
+	}
+	config := DefaultLLMReRankerConfig(provider)
+	config.Logger = o.logger
+	o.reRanker = NewLLMReRanker(config)
+}
+
+// WithReRanker sets the re-ranker used for ambiguous keyword results, e.g. an
+// LLMReRanker with a custom LLMReRankerConfig (see SetReRanker).
+func (o *Orchestrator) WithReRanker(reRanker ReRanker) *Orchestrator {
+	o.SetReRanker(reRanker)
+	return o
+}
+
+// SetReRanker sets the re-ranker used for ambiguous keyword results.
+// Use a FunctionReRanker for deterministic, offline re-ranking (e.g., in CI).
+// Passing nil disables re-ranking so the keyword winner is always used, until
+// SetLLMProvider installs the default LLM re-ranker again.
+func (o *Orchestrator) SetReRanker(reRanker ReRanker) {
+	o.reRanker = reRanker
+	o.customReRanker = reRanker != nil
+}
+
+// ReRankCacheStats returns the re-ranker's cache counters, or zero values when the
+// re-ranker does not cache (or none is set).
+func (o *Orchestrator) ReRankCacheStats() ReRankCacheStats {
+	if cached, ok := o.reRanker.(interface{ CacheStats() ReRankCacheStats }); ok {
+		return cached.CacheStats()
+	}
+	return ReRankCacheStats{}
+}
+
+// SetRequireIntentMatch controls whether candidates must match the classified intent.
+// When enabled, only patterns whose category matches the intent (directly or via a
+// mapped category, see WithIntentCategories) are scored, and the re-ranker chooses among
+// them. The filter is bypassed for IntentUnknown. Default: false (intent only boosts scores).
+func (o *Orchestrator) SetRequireIntentMatch(require bool) {
+	o.requireIntentMatch = require
+}
+
+// SetMinConfidence sets the confidence a recommendation must reach to be returned.
+// Below it, Recommend returns an empty PatternName with Reason ReasonBelowMinConfidence
+// and the best candidate's Confidence, and Recommendation.Err returns
+// ErrNoConfidentMatch, so callers can ask the user to rephrase instead of acting on an
+// irrelevant pattern. The threshold applies to the final confidence, after calibration
+// and re-ranking. RecommendTopN is unaffected, so near misses can still be offered.
+// Values are clamped to [0, 1]; 0 (the default) always recommends the best candidate.
+func (o *Orchestrator) SetMinConfidence(min float64) {
+	if min < 0 {
+		min = 0
+	} else if min > 1 {
+		min = 1
+	}
+	o.minConfidence = min
+}
+
+// SetSemanticWeight sets how much embedding similarity contributes to pattern scores.
+// The final score is (1-weight)*keyword + weight*cosine, and patterns with no keyword
+// overlap become candidates when their similarity is high enough. Requires an embedder
+// on the library (Library.SetEmbedder); the weight is clamped to [0, 1].
+func (o *Orchestrator) SetSemanticWeight(weight float64) {
+	if weight < 0 {
+		weight = 0
+	} else if weight > 1 {
+		weight = 1
+	}
+	o.semanticWeight = weight
+}
+
+// SetScoringWeights sets per-field weights for keyword scoring. Negative weights are
+// treated as zero, and a zero weight disables that field's contribution entirely; if
+// every weight is zero, DefaultScoringWeights is used. See ScoringWeights.
+func (o *Orchestrator) SetScoringWeights(weights ScoringWeights) {
+	o.scoringWeights = weights
+}
+
+// SetExcludeKeywords controls whether pattern ExcludeKeywords suppress scores.
+// When enabled (the default), a pattern whose exclude keyword appears in the query as a
+// whole word or phrase keeps only a quarter of its score, so it falls behind
+// patterns that match the same keywords without the exclusion.
+func (o *Orchestrator) SetExcludeKeywords(enabled bool) {
+	o.ignoreExcludeKeywords = !enabled
+}
+
+// ScoringWeights returns the keyword scoring weights in effect.
+func (o *Orchestrator) ScoringWeights() ScoringWeights {
+	if o.scoringWeights.isZero() {
+		return DefaultScoringWeights()
+	}
+	return o.scoringWeights
 }
 
 // ClassifyIntent analyzes user message and determines intent category.
 // Returns intent category and confidence score (0.0-1.0).
 // Uses pluggable classifier if set, otherwise uses default keyword-based classifier.
 func (o *Orchestrator) ClassifyIntent(userMessage string, ctxData map[string]interface{}) (IntentCategory, float64) {
+	intent, confidence, _ := o.ClassifyIntentContext(context.Background(), userMessage, ctxData)
+	return intent, confidence
+}
+
+// ClassifyIntentContext is ClassifyIntent bounded by ctx: when ctx is done before the
+// classifier returns, it returns IntentUnknown and ctx.Err(). Classifiers do not take a
+// context, so a slow one (such as an LLM classifier) finishes in the background and its
+// result is discarded.
+func (o *Orchestrator) ClassifyIntentContext(ctx context.Context, userMessage string, ctxData map[string]interface{}) (IntentCategory, float64, error) {
+	if err := ctx.Err(); err != nil {
+		return IntentUnknown, 0.0, err
+	}
+
 	startTime := time.Now()
-	_, span := o.tracer.StartSpan(context.Background(), "patterns.orchestrator.classify_intent")
+	_, span := o.tracer.StartSpan(ctx, "patterns.orchestrator.classify_intent")
 	defer o.tracer.EndSpan(span)
 
 	if span != nil {
@@ -100,7 +293,28 @@ func (o *Orchestrator) ClassifyIntent(userMessage string, ctxData map[string]int
 		span.SetAttribute("context.keys", fmt.Sprintf("%d", len(ctxData)))
 	}
 
-	intent, confidence := o.intentClassifier(userMessage, ctxData)
+	type classification struct {
+		intent     IntentCategory
+		confidence float64
+	}
+	done := make(chan classification, 1)
+	classifier := o.intentClassifier
+	go func() {
+		intent, confidence := classifier(userMessage, ctxData)
+		done <- classification{intent, confidence}
+	}()
+
+	var intent IntentCategory
+	var confidence float64
+	select {
+	case c := <-done:
+		intent, confidence = c.intent, c.confidence
+	case <-ctx.Done():
+		if span != nil {
+			span.RecordError(fmt.Errorf("intent classification aborted: %w", ctx.Err()))
+		}
+		return IntentUnknown, 0.0, ctx.Err()
+	}
 
 	duration := time.Since(startTime)
 	if span != nil {
@@ -114,7 +328,53 @@ func (o *Orchestrator) ClassifyIntent(userMessage string, ctxData map[string]int
 		"confidence": fmt.Sprintf("%.1f", confidence*100),
 	})
 
-	return intent, confidence
+	return intent, confidence, nil
+}
+
+// ClassifyMulti determines every plausible intent for the user message, best first.
+// Unlike ClassifyIntent it can return several intents, so a message whose two intents
+// have nearly equal confidence yields both; pass the result to RecommendMulti.
+// Always returns at least one intent on success.
+func (o *Orchestrator) ClassifyMulti(userMessage string) ([]ScoredIntent, error) {
+	startTime := time.Now()
+	_, span := o.tracer.StartSpan(context.Background(), "patterns.orchestrator.classify_multi")
+	defer o.tracer.EndSpan(span)
+
+	if strings.TrimSpace(userMessage) == "" {
+		return nil, fmt.Errorf("user message is empty")
+	}
+
+	var intents []ScoredIntent
+	if o.multiIntentClassifier != nil {
+		var err error
+		intents, err = o.multiIntentClassifier(userMessage, nil)
+		if err != nil {
+			if span != nil {
+				span.RecordError(err)
+			}
+			return nil, fmt.Errorf("multi-intent classification failed: %w", err)
+		}
+	} else {
+		intent, confidence := o.intentClassifier(userMessage, nil)
+		intents = []ScoredIntent{{Intent: intent, Confidence: confidence}}
+	}
+	if len(intents) == 0 {
+		intents = []ScoredIntent{{Intent: IntentUnknown, Confidence: 0}}
+	}
+
+	if span != nil {
+		span.SetAttribute("intent.category", string(intents[0].Intent))
+		span.SetAttribute("intent.confidence", fmt.Sprintf("%.2f", intents[0].Confidence))
+		span.SetAttribute("intent.count", fmt.Sprintf("%d", len(intents)))
+		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", time.Since(startTime).Seconds()*1000))
+	}
+
+	o.tracer.RecordMetric("patterns.orchestrator.classify_multi", 1.0, map[string]string{
+		"intent": string(intents[0].Intent),
+		"count":  fmt.Sprintf("%d", len(intents)),
+	})
+
+	return intents, nil
 }
 
 // PlanExecution creates an execution plan based on classified intent.
@@ -198,121 +458,383 @@ func (o *Orchestrator) GetRoutingRecommendation(intent IntentCategory) string {
 }
 
 // RecommendPattern suggests a pattern from the library based on user message and intent.
-// Returns pattern name and confidence score (0.0-1.0).
+// Returns pattern name and confidence score (0.0-1.0). The name is empty when nothing
+// matched or, with SetMinConfidence, when the best match is below the threshold.
 func (o *Orchestrator) RecommendPattern(userMessage string, intent IntentCategory) (string, float64) {
+	return o.RecommendPatternContext(context.Background(), userMessage, intent)
+}
+
+// RecommendPatternContext is RecommendPattern with a caller context. The context is
+// passed to the re-ranker, so cancelling it or reaching its deadline aborts an
+// in-flight LLM call, and the keyword winner is returned instead.
+func (o *Orchestrator) RecommendPatternContext(ctx context.Context, userMessage string, intent IntentCategory) (string, float64) {
+	rec := o.RecommendContext(ctx, userMessage, intent)
+	return rec.PatternName, rec.Confidence
+}
+
+// Recommend suggests a pattern from the library based on user message and intent.
+// Unlike RecommendPattern, it also reports a ConfidenceReason describing how the
+// confidence was reached, so callers can branch on it deterministically.
+func (o *Orchestrator) Recommend(userMessage string, intent IntentCategory) Recommendation {
+	return o.RecommendContext(context.Background(), userMessage, intent)
+}
+
+// RecommendContext is Recommend with a caller context (see RecommendPatternContext).
+func (o *Orchestrator) RecommendContext(ctx context.Context, userMessage string, intent IntentCategory) Recommendation {
+	rec, _ := o.recommend(ctx, userMessage, []IntentCategory{intent}, "", "patterns.orchestrator.recommend_pattern", nil)
+	return rec
+}
+
+// RecommendMulti is Recommend for a multi-intent classification (see ClassifyMulti).
+// A pattern counts as matching intent if its category matches any of the intents,
+// which also applies to the SetRequireIntentMatch filter. The first intent is treated
+// as primary for re-rank decisions. An empty slice behaves like IntentUnknown.
+func (o *Orchestrator) RecommendMulti(userMessage string, intents []ScoredIntent) Recommendation {
+	categories := make([]IntentCategory, 0, len(intents))
+	for _, si := range intents {
+		categories = append(categories, si.Intent)
+	}
+	if len(categories) == 0 {
+		categories = append(categories, IntentUnknown)
+	}
+	rec, _ := o.recommend(context.Background(), userMessage, categories, "", "patterns.orchestrator.recommend_multi", nil)
+	return rec
+}
+
+// NoPatternForBackendError is returned by RecommendPatternForBackend when patterns match
+// the query but none of them runs on the requested backend.
+type NoPatternForBackendError struct {
+	Backend string
+
+	// Filtered is the number of matching patterns excluded for targeting other backends
+	Filtered int
+}
+
+func (e *NoPatternForBackendError) Error() string {
+	return fmt.Sprintf("no pattern supports backend %q (%d matching patterns target other backends)", e.Backend, e.Filtered)
+}
+
+// RecommendPatternForBackend is RecommendPattern restricted to patterns that run on the
+// given backend: patterns whose Backend matches it (case-insensitively) or is empty
+// (backend-agnostic). Other patterns are removed before scoring, so the re-ranker never
+// sees them. Returns a *NoPatternForBackendError if the query matched patterns but all
+// of them target other backends, ErrNoConfidentMatch with the best confidence if the
+// best match is below SetMinConfidence, and empty values with a nil error if nothing
+// matched. An empty backend disables the filter.
+func (o *Orchestrator) RecommendPatternForBackend(userMessage string, intent IntentCategory, backend string) (string, float64, error) {
+	rec, selection := o.recommend(context.Background(), userMessage, []IntentCategory{intent}, backend, "patterns.orchestrator.recommend_for_backend", nil)
+	if len(selection.ranked) == 0 && selection.backendFiltered > 0 {
+		return "", 0.0, &NoPatternForBackendError{Backend: backend, Filtered: selection.backendFiltered}
+	}
+	return rec.PatternName, rec.Confidence, rec.Err()
+}
+
+// recommend runs the selection pipeline and returns its head as a Recommendation,
+// along with the full selection. backend restricts candidates to that backend
+// ("" for no restriction). progress, if non-nil, receives pipeline stages.
+func (o *Orchestrator) recommend(ctx context.Context, userMessage string, intents []IntentCategory, backend, spanName string, progress progressFunc) (Recommendation, patternSelection) {
 	startTime := time.Now()
-	_, span := o.tracer.StartSpan(context.Background(), "patterns.orchestrator.recommend_pattern")
-	defer o.tracer.EndSpan(span)
+	selection := o.selectPattern(ctx, userMessage, intents, backend, spanName, progress)
+	o.recordRecommendation(userMessage, intents[0], selection, startTime)
+	if len(selection.ranked) == 0 {
+		return Recommendation{}, selection
+	}
+	if selection.belowMinConfidence {
+		return Recommendation{
+			Confidence:  selection.ranked[0].Confidence,
+			Reason:      ReasonBelowMinConfidence,
+			Explanation: fmt.Sprintf("No pattern matched with enough confidence: the best candidate, %s, scored %.2f, below the minimum of %.2f.", selection.ranked[0].Name, selection.ranked[0].Confidence, o.minConfidence),
+		}, selection
+	}
 
-	if span != nil {
-		span.SetAttribute("intent.category", string(intent))
-		span.SetAttribute("message.length", fmt.Sprintf("%d", len(userMessage)))
+	return Recommendation{
+		PatternName: selection.ranked[0].Name,
+		Confidence:  selection.ranked[0].Confidence,
+		Reason:      selection.reason,
+		Explanation: selection.explanation,
+	}, selection
+}
+
+// RecommendPatternWithExplanation is RecommendPattern plus a human-readable explanation
+// of the choice, suitable for showing end users "why this pattern".
+// The explanation is the LLM re-ranker's reasoning when the LLM path was taken (including
+// when its pick was outside the candidates and keyword scoring was used instead), and a
+// synthesized keyword explanation otherwise. Returns empty values if nothing matched.
+func (o *Orchestrator) RecommendPatternWithExplanation(userMessage string, intent IntentCategory) (string, float64, string) {
+	rec := o.Recommend(userMessage, intent)
+	return rec.PatternName, rec.Confidence, rec.Explanation
+}
+
+// RankedPattern is a candidate pattern in a RecommendTopN result.
+type RankedPattern struct {
+	// Name is the pattern name
+	Name string `json:"name"`
+
+	// Confidence is the selection confidence (0.0-1.0). For the head it matches
+	// Recommend; for runners-up it is the capped keyword score.
+	Confidence float64 `json:"confidence"`
+
+	// KeywordScore is the raw keyword/intent score before any re-ranking
+	KeywordScore float64 `json:"keyword_score"`
+
+	// ReRanked is true if the re-ranker selected this pattern
+	ReRanked bool `json:"re_ranked"`
+}
+
+// RecommendTopN returns up to n candidate patterns for the user message, best first.
+// The ordering follows keyword ranking, with the re-ranker's selection (if re-ranking
+// ran) moved to the head, so the first entry always matches Recommend.
+// Returns an empty slice if no pattern matches.
+func (o *Orchestrator) RecommendTopN(userMessage string, intent IntentCategory, n int) ([]RankedPattern, error) {
+	return o.RecommendTopNContext(context.Background(), userMessage, intent, n)
+}
+
+// RecommendTopNContext is RecommendTopN with a caller context for the re-ranker (see
+// RecommendPatternContext).
+func (o *Orchestrator) RecommendTopNContext(ctx context.Context, userMessage string, intent IntentCategory, n int) ([]RankedPattern, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive, got %d", n)
 	}
 
+	selection := o.selectPattern(ctx, userMessage, []IntentCategory{intent}, "", "patterns.orchestrator.recommend_top_n", nil)
+	if len(selection.ranked) > n {
+		return selection.ranked[:n], nil
+	}
+	return selection.ranked, nil
+}
+
+// patternSelection is the outcome of the selection pipeline shared by Recommend and RecommendTopN.
+type patternSelection struct {
+	ranked        []RankedPattern // best first; empty when nothing matched
+	reason        ConfidenceReason
+	explanation   string
+	keywordWinner string // top keyword-scored candidate before re-ranking
+	llmInvoked    bool   // re-ranker was called
+
+	belowMinConfidence bool // head is below SetMinConfidence, so nothing is recommended
+
+	backendFiltered int // candidates removed for targeting another backend
+}
+
+// candidateScoring is the keyword (and optional semantic) scoring of a query's candidates.
+type candidateScoring struct {
+	searchResults []PatternSummary   // candidate summaries from search
+	scored        []scoredPattern    // candidates with a positive score, best first
+	semantic      map[string]float64 // embedding similarity by name (nil when disabled)
+
+	backendFiltered int // candidates removed for targeting another backend
+}
+
+// scoreCandidates searches the library and scores each candidate for the query.
+// Each candidate is scored against every intent and keeps its best score.
+// A non-empty backend removes candidates that target a different backend.
+func (o *Orchestrator) scoreCandidates(userMessage string, intents []IntentCategory, backend string, span *observability.Span) candidateScoring {
 	messageLower := strings.ToLower(userMessage)
 
 	// Search for patterns matching the user's keywords
 	searchResults := o.library.Search(userMessage)
 
-	if span != nil {
-		span.SetAttribute("search.result_count", fmt.Sprintf("%d", len(searchResults)))
+	// Add patterns that match semantically but share no keywords with the query
+	semanticScores := o.semanticScores(userMessage, span)
+	if len(semanticScores) > 0 {
+		matched := make(map[string]bool, len(searchResults))
+		for _, summary := range searchResults {
+			matched[summary.Name] = true
+		}
+		for _, summary := range o.library.ListAll() {
+			if !matched[summary.Name] && semanticScores[summary.Name] >= semanticMinSimilarity {
+				searchResults = append(searchResults, summary)
+			}
+		}
 	}
 
-	if len(searchResults) == 0 {
-		duration := time.Since(startTime)
+	// Drop patterns that cannot run on the requested backend
+	backendFiltered := 0
+	if backend != "" {
+		supported := searchResults[:0:0]
+		for _, summary := range searchResults {
+			if supportsBackend(summary, backend) {
+				supported = append(supported, summary)
+			}
+		}
+		backendFiltered = len(searchResults) - len(supported)
+		searchResults = supported
+
 		if span != nil {
-			span.SetAttribute("recommendation.result", "no_match")
-			span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
+			span.SetAttribute("backend", backend)
+			span.SetAttribute("backend.filtered", fmt.Sprintf("%d", backendFiltered))
 		}
-		o.tracer.RecordMetric("patterns.orchestrator.recommend_pattern", 1.0, map[string]string{
-			"intent": string(intent),
-			"result": "no_match",
-		})
-		return "", 0.0
 	}
 
 	// Score patterns based on intent match and keyword relevance
-	scored := make([]scoredPattern, 0)
-
-	// Tokenize user message for better matching
-	keywords := strings.FieldsFunc(messageLower, func(r rune) bool {
-		return r == ' ' || r == ',' || r == ';' || r == '-' || r == '_'
-	})
+	filteredKeywords := extractQueryKeywords(userMessage)
+	scored := o.scorePatterns(searchResults, intents, filteredKeywords, messageLower, semanticScores, runtime.GOMAXPROCS(0))
 
-	// Filter stop words
-	stopWords := map[string]bool{
-		"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
-		"be": true, "by": true, "for": true, "from": true, "has": true, "in": true,
-		"is": true, "it": true, "of": true, "on": true, "that": true, "the": true,
-		"to": true, "was": true, "will": true, "with": true,
-	}
+	o.applyCalibration(scored)
+	o.applyFeedback(scored, filteredKeywords)
 
-	filteredKeywords := make([]string, 0, len(keywords))
-	for _, kw := range keywords {
-		if !stopWords[kw] && len(kw) > 2 {
-			filteredKeywords = append(filteredKeywords, kw)
-		}
+	return candidateScoring{
+		searchResults:   searchResults,
+		scored:          scored,
+		semantic:        semanticScores,
+		backendFiltered: backendFiltered,
 	}
+}
 
-	for _, summary := range searchResults {
-		score := 0.0
+// excludeKeywordPenalty is the fraction of its score a pattern keeps when the query
+// contains one of its ExcludeKeywords.
+const excludeKeywordPenalty = 0.25
+
+// parallelScoringThreshold is the candidate count below which scoring stays on the
+// calling goroutine; smaller sets score faster than a worker pool can start.
+const parallelScoringThreshold = 64
+
+// scorePatterns scores each summary against every intent, keeping its best score, and
+// blends in semantic similarity when enabled. Large candidate sets are split across
+// workers goroutines. Returns candidates with a positive score, sorted by score
+// (descending) then name so the order does not depend on scheduling.
+func (o *Orchestrator) scorePatterns(summaries []PatternSummary, intents []IntentCategory, keywords []string, messageLower string, semantic map[string]float64, workers int) []scoredPattern {
+	weights := o.scoringWeights.normalized()
+	queryWords := " " + strings.Join(normalizedWords(messageLower), " ") + " "
+
+	// Each index is written by exactly one worker; inputs are only read
+	scores := make([]float64, len(summaries))
+	scoreRange := func(start, end int) {
+		for i := start; i < end; i++ {
+			summary := summaries[i]
+			if o.requireIntentMatch && !o.matchesAnyIntent(summary.Category, intents) {
+				continue
+			}
 
-		// Build searchable text
-		searchText := strings.ToLower(fmt.Sprintf("%s %s %s %s",
-			summary.Name, summary.Title, summary.Description, summary.BackendFunction))
+			score := 0.0
+			for _, intent := range intents {
+				matched := o.intentCategories.matches(summary.Category, intent)
+				if s := weights.score(summary, intent, matched, keywords, messageLower); s > score {
+					score = s
+				}
+			}
 
-		for _, useCase := range summary.UseCases {
-			searchText += " " + strings.ToLower(useCase)
-		}
+			// Blend keyword and embedding scores
+			if semantic != nil {
+				score = (1-o.semanticWeight)*score + o.semanticWeight*semantic[summary.Name]
+			}
 
-		// Boost if category matches intent (strong signal)
-		if matchesIntent(summary.Category, intent) {
-			score += 0.5
-		} else if intent == IntentUnknown {
-			// When intent is unknown, give partial boost to relevant categories
-			// This helps ML, analytics, and data patterns rank higher
-			categoryLower := strings.ToLower(summary.Category)
-			switch categoryLower {
-			case "ml", "analytics", "timeseries":
-				score += 0.4 // High relevance
-			case "data_quality", "etl", "data_transform":
-				score += 0.3 // Medium relevance
-			case "data-import", "learning", "reasoning":
-				score += 0.2 // Lower relevance
+			if o.intentCategories.mismatched(summary.Category, intents) {
+				score *= intentMismatchPenalty
+			}
+			if !o.ignoreExcludeKeywords && containsExcludedKeyword(queryWords, summary.ExcludeKeywords) {
+				score *= excludeKeywordPenalty
 			}
+			scores[i] = score
 		}
+	}
 
-		// Count keyword matches in searchable text
-		keywordMatches := 0
-		for _, keyword := range filteredKeywords {
-			if strings.Contains(searchText, keyword) {
-				keywordMatches++
+	if workers <= 1 || len(summaries) < parallelScoringThreshold {
+		scoreRange(0, len(summaries))
+	} else {
+		chunk := (len(summaries) + workers - 1) / workers
+		var wg sync.WaitGroup
+		for start := 0; start < len(summaries); start += chunk {
+			end := start + chunk
+			if end > len(summaries) {
+				end = len(summaries)
 			}
+			wg.Add(1)
+			go func(start, end int) {
+				defer wg.Done()
+				scoreRange(start, end)
+			}(start, end)
 		}
+		wg.Wait()
+	}
 
-		// Score based on percentage of keywords matched
-		if len(filteredKeywords) > 0 {
-			matchRate := float64(keywordMatches) / float64(len(filteredKeywords))
-			score += matchRate * 0.5 // Up to 0.5 points for keyword matching
+	scored := make([]scoredPattern, 0, len(summaries))
+	for i, score := range scores {
+		if score > 0 {
+			scored = append(scored, scoredPattern{name: summaries[i].Name, score: score})
 		}
+	}
+	sortScoredPatterns(scored)
+	return scored
+}
 
-		// Bonus for exact name match
-		if strings.Contains(summary.Name, messageLower) {
-			score += 0.2
+// sortScoredPatterns orders candidates by score (descending), breaking ties by name
+// (ascending) so rankings never depend on map iteration or scheduling order.
+func sortScoredPatterns(scored []scoredPattern) {
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
 		}
+		return scored[i].name < scored[j].name
+	})
+}
 
-		// Bonus for title match
-		titleLower := strings.ToLower(summary.Title)
-		for _, keyword := range filteredKeywords {
-			if strings.Contains(titleLower, keyword) {
-				score += 0.1
+// candidateSummaries builds the summary map passed to re-rankers.
+func candidateSummaries(candidates []scoredPattern, searchResults []PatternSummary) map[string]PatternSummary {
+	summaries := make(map[string]PatternSummary, len(candidates))
+	for _, c := range candidates {
+		for _, summary := range searchResults {
+			if summary.Name == c.name {
+				summaries[c.name] = summary
 				break
 			}
 		}
+	}
+	return summaries
+}
 
-		if score > 0 {
-			scored = append(scored, scoredPattern{name: summary.Name, score: score})
+// reRankCandidateLimit returns how many of the top keyword candidates reRanker is given.
+func reRankCandidateLimit(reRanker ReRanker) int {
+	if limited, ok := reRanker.(interface{ maxCandidates() int }); ok {
+		return limited.maxCandidates()
+	}
+	return defaultReRankMaxCandidates
+}
+
+// selectPattern runs keyword scoring and, for ambiguous results, re-ranking.
+// intents must not be empty; the first is the primary intent. backend restricts
+// candidates to that backend ("" for no restriction).
+// spanName names the trace span and metric so each public entry point is observable.
+// progress, if non-nil, is called synchronously as each stage completes.
+// ctx is passed to the re-ranker.
+func (o *Orchestrator) selectPattern(ctx context.Context, userMessage string, intents []IntentCategory, backend, spanName string, progress progressFunc) patternSelection {
+	startTime := time.Now()
+	ctx, span := o.tracer.StartSpan(ctx, spanName)
+	defer o.tracer.EndSpan(span)
+
+	intent := intents[0]
+	if span != nil {
+		span.SetAttribute("intent.category", string(intent))
+		if len(intents) > 1 {
+			span.SetAttribute("intent.count", fmt.Sprintf("%d", len(intents)))
 		}
+		span.SetAttribute("message.length", fmt.Sprintf("%d", len(userMessage)))
+		span.SetAttribute("require_intent_match", fmt.Sprintf("%t", o.requireIntentMatch))
+	}
+
+	scoring := o.scoreCandidates(userMessage, intents, backend, span)
+	searchResults, scored := scoring.searchResults, scoring.scored
+	if len(scored) > 0 {
+		progress.emit(StageScoringDone, scored[0].name, scored[0].score, len(scored), nil)
+	} else {
+		progress.emit(StageScoringDone, "", 0, 0, nil)
+	}
+
+	if span != nil {
+		span.SetAttribute("search.result_count", fmt.Sprintf("%d", len(searchResults)))
+	}
+
+	if len(searchResults) == 0 {
+		duration := time.Since(startTime)
+		if span != nil {
+			span.SetAttribute("recommendation.result", "no_match")
+			span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
+		}
+		o.tracer.RecordMetric(spanName, 1.0, map[string]string{
+			"intent": string(intent),
+			"result": "no_match",
+		})
+		return patternSelection{backendFiltered: scoring.backendFiltered}
 	}
 
 	if len(scored) == 0 {
@@ -321,15 +843,16 @@ func (o *Orchestrator) RecommendPattern(userMessage string, intent IntentCategor
 			span.SetAttribute("recommendation.result", "no_scored_match")
 			span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
 		}
-		o.tracer.RecordMetric("patterns.orchestrator.recommend_pattern", 1.0, map[string]string{
+		o.tracer.RecordMetric(spanName, 1.0, map[string]string{
 			"intent": string(intent),
 			"result": "no_scored_match",
 		})
-		return "", 0.0
+		return patternSelection{backendFiltered: scoring.backendFiltered}
 	}
 
 	// === HYBRID APPROACH: Decide if we need LLM re-ranking ===
-	useLLM := shouldInvokeLLMReRanker(scored, intent, o.llmProvider)
+	trigger := detectReRankTrigger(scored, intent)
+	useLLM := shouldInvokeLLMReRanker(scored, intent, o.reRanker)
 
 	if span != nil {
 		span.SetAttribute("llm_reranking.triggered", fmt.Sprintf("%t", useLLM))
@@ -337,44 +860,58 @@ func (o *Orchestrator) RecommendPattern(userMessage string, intent IntentCategor
 
 	var finalPattern string
 	var finalConfidence float64
+	var reason ConfidenceReason
+	var explanation string
+	reRanked := false
 
 	if useLLM {
-		// Use LLM to re-rank top candidates for better accuracy
-		topN := min(5, len(scored))
-		topCandidates := scored[:topN]
+		// Only the top candidates are re-ranked; an LLMReRanker sets the cap with
+		// LLMReRankerConfig.MaxCandidates, other re-rankers get the default of 8
+		topCandidates := topScoredCandidates(scored, reRankCandidateLimit(o.reRanker))
+		topN := len(topCandidates)
 
 		if span != nil {
 			span.SetAttribute("llm_reranking.candidates", fmt.Sprintf("%d", topN))
 		}
 
-		// Build summary map for LLM re-ranker
-		summaries := make(map[string]PatternSummary)
-		for _, s := range topCandidates {
-			// Find summary in search results
-			for _, summary := range searchResults {
-				if summary.Name == s.name {
-					summaries[s.name] = summary
-					break
+		summaries := candidateSummaries(topCandidates, searchResults)
+		progress.emit(StageLLMInvoked, scored[0].name, scored[0].score, topN, nil)
+
+		var llmPattern string
+		var llmConf float64
+		var err error
+		if explainer, ok := o.reRanker.(explainingReRanker); ok {
+			var result *reRankingResult
+			result, err = explainer.reRankWithReasoning(ctx, userMessage, topCandidates, summaries)
+			if result != nil {
+				llmPattern, llmConf, explanation = result.SelectedPattern, result.Confidence, result.Reasoning
+				if span != nil && result.Provider != "" {
+					span.SetAttribute("llm_reranking.provider", result.Provider)
 				}
 			}
+		} else {
+			llmPattern, llmConf, err = o.reRanker.ReRank(ctx, userMessage, topCandidates, summaries)
 		}
 
-		llmPattern, llmConf, err := reRankPatternsWithLLM(o.llmProvider, userMessage, topCandidates, summaries)
 		if err != nil {
 			// Fallback to keyword scoring on error
 			if span != nil {
-				span.RecordError(fmt.Errorf("LLM re-ranking failed, using keyword fallback: %w", err))
+				span.RecordError(fmt.Errorf("re-ranking failed, using keyword fallback: %w", err))
 				span.SetAttribute("llm_reranking.fallback", "true")
 			}
 			finalPattern = scored[0].name
 			finalConfidence = scored[0].score
+			reason = ReasonKeywordFallbackAfterLLMError
 		} else {
 			finalPattern = llmPattern
 			finalConfidence = llmConf
+			reason = trigger.resolvedReason()
+			reRanked = true
 			if span != nil {
 				span.SetAttribute("llm_reranking.success", "true")
 			}
 		}
+		progress.emit(StageLLMResponded, finalPattern, finalConfidence, topN, err)
 	} else {
 		// Use keyword-based scoring (fast path)
 		finalPattern = scored[0].name
@@ -384,27 +921,411 @@ func (o *Orchestrator) RecommendPattern(userMessage string, intent IntentCategor
 		if finalConfidence > 0.9 {
 			finalConfidence = 0.9
 		}
+
+		// Ambiguous results without a re-ranker are only a best guess
+		reason = ReasonClearKeywordWinner
+		if trigger != reRankTriggerNone {
+			reason = ReasonLowConfidenceBestGuess
+		}
+	}
+
+	if explanation == "" {
+		explanation = keywordExplanation(scored, finalPattern, intent)
+	}
+
+	method := "keyword"
+	if useLLM {
+		method = o.reRanker.Name()
+	}
+
+	result := "success"
+	belowMinConfidence := o.minConfidence > 0 && finalConfidence < o.minConfidence
+	if belowMinConfidence {
+		result = "below_min_confidence"
 	}
 
 	duration := time.Since(startTime)
 	if span != nil {
-		span.SetAttribute("recommendation.result", "success")
+		span.SetAttribute("recommendation.result", result)
 		span.SetAttribute("recommendation.pattern", finalPattern)
 		span.SetAttribute("recommendation.confidence", fmt.Sprintf("%.2f", finalConfidence))
 		span.SetAttribute("recommendation.candidates", fmt.Sprintf("%d", len(scored)))
-		span.SetAttribute("recommendation.method", map[bool]string{true: "llm", false: "keyword"}[useLLM])
+		span.SetAttribute("recommendation.method", method)
+		span.SetAttribute("recommendation.reason", string(reason))
 		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
 	}
 
-	o.tracer.RecordMetric("patterns.orchestrator.recommend_pattern", 1.0, map[string]string{
+	o.tracer.RecordMetric(spanName, 1.0, map[string]string{
 		"intent":     string(intent),
-		"result":     "success",
+		"result":     result,
 		"pattern":    finalPattern,
-		"method":     map[bool]string{true: "llm", false: "keyword"}[useLLM],
+		"method":     method,
+		"reason":     string(reason),
 		"confidence": fmt.Sprintf("%.1f", finalConfidence*100),
 	})
 
-	return finalPattern, finalConfidence
+	// Head is the final selection; runners-up keep keyword order
+	ranked := make([]RankedPattern, 0, len(scored))
+	for _, sp := range scored {
+		if sp.name == finalPattern {
+			ranked = append([]RankedPattern{{
+				Name:         sp.name,
+				Confidence:   finalConfidence,
+				KeywordScore: sp.score,
+				ReRanked:     reRanked,
+			}}, ranked...)
+			continue
+		}
+		confidence := sp.score
+		if confidence > 0.9 {
+			confidence = 0.9
+		}
+		ranked = append(ranked, RankedPattern{
+			Name:         sp.name,
+			Confidence:   confidence,
+			KeywordScore: sp.score,
+		})
+	}
+
+	if len(ranked) == 0 || ranked[0].Name != finalPattern {
+		// Custom re-rankers may select outside the keyword candidates
+		ranked = append([]RankedPattern{{Name: finalPattern, Confidence: finalConfidence, ReRanked: reRanked}}, ranked...)
+	}
+
+	return patternSelection{
+		ranked:             ranked,
+		reason:             reason,
+		explanation:        explanation,
+		keywordWinner:      scored[0].name,
+		llmInvoked:         useLLM,
+		belowMinConfidence: belowMinConfidence,
+		backendFiltered:    scoring.backendFiltered,
+	}
+}
+
+// ScoringWeights controls how much each pattern field contributes to keyword scores.
+//
+//   - Title covers the pattern name, title, and backend function, plus the name and title match bonuses
+//   - Description covers the description text
+//   - UseCases covers the use case list
+//   - Category scales the boost for a category matching the classified intent
+//
+// A query keyword earns credit for the highest-weighted field it appears in, so
+// raising UseCases makes use-case matches count more than title-only matches.
+// Weights are relative: they are divided by the largest weight before scoring, so
+// custom weights never push scores above what DefaultScoringWeights can produce
+// and the re-rank trigger thresholds (detectReRankTrigger) keep their meaning.
+// Scores are clamped to [0, 1] whatever the weights.
+// A zero weight removes the field from scoring.
+type ScoringWeights struct {
+	Title       float64
+	Description float64
+	UseCases    float64
+	Category    float64
+}
+
+// DefaultScoringWeights weights every field equally, which reproduces the
+// original keyword scorer.
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{Title: 1, Description: 1, UseCases: 1, Category: 1}
+}
+
+func (w ScoringWeights) isZero() bool {
+	return w.Title <= 0 && w.Description <= 0 && w.UseCases <= 0 && w.Category <= 0
+}
+
+// normalized clamps negative weights to zero and scales so the largest weight is 1.
+func (w ScoringWeights) normalized() ScoringWeights {
+	if w.isZero() {
+		return DefaultScoringWeights()
+	}
+	fields := []*float64{&w.Title, &w.Description, &w.UseCases, &w.Category}
+	maxWeight := 0.0
+	for _, f := range fields {
+		if *f < 0 {
+			*f = 0
+		}
+		if *f > maxWeight {
+			maxWeight = *f
+		}
+	}
+	for _, f := range fields {
+		*f /= maxWeight
+	}
+	return w
+}
+
+// score computes the keyword score of a pattern using normalized weights:
+// up to 0.5 for the category/intent match, up to 0.5 for query keyword coverage,
+// plus 0.2 for a name match and 0.1 for a title keyword match, clamped to [0, 1] so
+// it blends with semantic similarity and reads as a confidence.
+// intentMatch reports whether the pattern's category serves intent.
+func (w ScoringWeights) score(summary PatternSummary, intent IntentCategory, intentMatch bool, keywords []string, messageLower string) float64 {
+	score := 0.0
+
+	// Boost if category matches intent (strong signal)
+	if intentMatch {
+		score += 0.5 * w.Category
+	} else if intent == IntentUnknown {
+		// When intent is unknown, give partial boost to relevant categories
+		// This helps ML, analytics, and data patterns rank higher
+		switch Category(summary.Category) {
+		case CategoryML, CategoryAnalytics, CategoryTimeseries:
+			score += 0.4 * w.Category // High relevance
+		case CategoryDataQuality, CategoryETL, CategoryDataTransform:
+			score += 0.3 * w.Category // Medium relevance
+		case CategoryDataImport, CategoryLearning, CategoryReasoning:
+			score += 0.2 * w.Category // Lower relevance
+		}
+	}
+
+	// Searchable text per field
+	titleText := strings.ToLower(fmt.Sprintf("%s %s %s", summary.Name, summary.Title, summary.BackendFunction))
+	descriptionText := strings.ToLower(summary.Description)
+	useCaseText := strings.ToLower(strings.Join(summary.UseCases, " "))
+
+	// Each keyword earns the weight of the best field it matches
+	if len(keywords) > 0 {
+		credit := 0.0
+		for _, keyword := range keywords {
+			best := 0.0
+			if w.Title > best && strings.Contains(titleText, keyword) {
+				best = w.Title
+			}
+			if w.Description > best && strings.Contains(descriptionText, keyword) {
+				best = w.Description
+			}
+			if w.UseCases > best && strings.Contains(useCaseText, keyword) {
+				best = w.UseCases
+			}
+			credit += best
+		}
+		score += credit / float64(len(keywords)) * 0.5 // Up to 0.5 points for keyword matching
+	}
+
+	// Bonus for exact name match
+	if strings.Contains(summary.Name, messageLower) {
+		score += 0.2 * w.Title
+	}
+
+	// Bonus for title match
+	titleLower := strings.ToLower(summary.Title)
+	for _, keyword := range keywords {
+		if strings.Contains(titleLower, keyword) {
+			score += 0.1 * w.Title
+			break
+		}
+	}
+
+	if score > 1.0 {
+		score = 1.0
+	}
+	return score
+}
+
+// semanticScores returns embedding similarities for the query, or nil when semantic
+// retrieval is disabled or the embedder fails (scoring then falls back to keywords).
+func (o *Orchestrator) semanticScores(userMessage string, span *observability.Span) map[string]float64 {
+	if o.semanticWeight == 0 || !o.library.HasEmbedder() {
+		return nil
+	}
+
+	scores, err := o.library.SemanticScores(context.Background(), userMessage)
+	if err != nil {
+		if span != nil {
+			span.SetAttribute("semantic.error", err.Error())
+		}
+		return nil
+	}
+
+	if span != nil {
+		span.SetAttribute("semantic.weight", fmt.Sprintf("%.2f", o.semanticWeight))
+		span.SetAttribute("semantic.scored_patterns", fmt.Sprintf("%d", len(scores)))
+	}
+	return scores
+}
+
+// keywordExplanation summarizes a keyword-based selection for RecommendPatternWithExplanation.
+func keywordExplanation(scored []scoredPattern, selected string, intent IntentCategory) string {
+	for i, sp := range scored {
+		if sp.name != selected {
+			continue
+		}
+		explanation := fmt.Sprintf("Selected by keyword matching with score %.2f for intent %s", sp.score, intent)
+		if i == 0 && len(scored) > 1 {
+			explanation += fmt.Sprintf(" (next best: %s at %.2f)", scored[1].name, scored[1].score)
+		}
+		return explanation
+	}
+	return ""
+}
+
+// warmCacheConcurrency bounds how many queries WarmCache runs in parallel.
+const warmCacheConcurrency = 4
+
+// WarmCache replays queries through the full selection pipeline (intent classification,
+// keyword search, re-ranking) so that caching classifiers and re-rankers are populated
+// before interactive traffic arrives. Results are discarded.
+//
+// Queries run concurrently; blank and duplicate queries are skipped. Returns ctx.Err()
+// if the context is cancelled before all queries have been dispatched. In-flight
+// queries finish before WarmCache returns.
+func (o *Orchestrator) WarmCache(ctx context.Context, queries []string) error {
+	_, span := o.tracer.StartSpan(ctx, "patterns.orchestrator.warm_cache")
+	defer o.tracer.EndSpan(span)
+
+	seen := make(map[string]bool, len(queries))
+	sem := make(chan struct{}, warmCacheConcurrency)
+	var wg sync.WaitGroup
+	var err error
+	warmed := 0
+
+dispatch:
+	for _, query := range queries {
+		query = strings.TrimSpace(query)
+		if query == "" || seen[query] {
+			continue
+		}
+		seen[query] = true
+
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		}
+
+		warmed++
+		wg.Add(1)
+		go func(q string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			intent, _ := o.ClassifyIntent(q, nil)
+			// Bypass the recommendation sink so warm-up queries are not recorded as traffic
+			o.selectPattern(ctx, q, []IntentCategory{intent}, "", "patterns.orchestrator.recommend_pattern", nil)
+		}(query)
+	}
+	wg.Wait()
+
+	if span != nil {
+		span.SetAttribute("queries.total", fmt.Sprintf("%d", len(queries)))
+		span.SetAttribute("queries.warmed", fmt.Sprintf("%d", warmed))
+	}
+
+	return err
+}
+
+// defaultBatchConcurrency is used by RecommendBatch when concurrency is not positive.
+const defaultBatchConcurrency = 8
+
+// BatchResult is the outcome of one query in RecommendBatch.
+type BatchResult struct {
+	Query          string
+	Intent         IntentCategory
+	Recommendation Recommendation
+
+	// Err is set when the query could not be processed (blank query, or the batch
+	// context was cancelled before it ran). Recommendation is empty in that case.
+	Err error
+}
+
+// RecommendBatch classifies intent and recommends a pattern for each query, running
+// up to concurrency queries in parallel (defaults to 8 when concurrency <= 0).
+// Results are returned in input order. Identical queries are processed once, and
+// concurrent re-rank requests for the same query and candidate set share one LLM
+// call (see LLMReRanker), so the re-ranker cache absorbs repeated questions.
+//
+// A cancelled context stops dispatching new queries; queries that did not run get
+// ctx.Err() in their BatchResult and RecommendBatch returns the partial results
+// together with ctx.Err(). In-flight queries finish before RecommendBatch returns.
+func (o *Orchestrator) RecommendBatch(ctx context.Context, queries []string, concurrency int) ([]BatchResult, error) {
+	startTime := time.Now()
+	_, span := o.tracer.StartSpan(ctx, "patterns.orchestrator.recommend_batch")
+	defer o.tracer.EndSpan(span)
+
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	results := make([]BatchResult, len(queries))
+	firstIndex := make(map[string]int, len(queries)) // trimmed query -> index that computes it
+	sem := make(chan struct{}, concurrency)
+	dispatched := make([]bool, len(queries))
+	var wg sync.WaitGroup
+	var err error
+	processed := 0
+
+dispatch:
+	for i, query := range queries {
+		trimmed := strings.TrimSpace(query)
+		if trimmed == "" {
+			continue
+		}
+		if _, dup := firstIndex[trimmed]; dup {
+			continue
+		}
+		firstIndex[trimmed] = i
+
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		}
+
+		processed++
+		dispatched[i] = true
+		wg.Add(1)
+		go func(idx int, q string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			intent, _ := o.ClassifyIntent(q, nil)
+			results[idx].Intent = intent
+			results[idx].Recommendation = o.RecommendContext(ctx, q, intent)
+		}(i, trimmed)
+	}
+	wg.Wait()
+
+	// Copy results to duplicate queries and mark queries that never ran
+	for i, query := range queries {
+		results[i].Query = query
+		trimmed := strings.TrimSpace(query)
+		if trimmed == "" {
+			results[i].Err = fmt.Errorf("query is empty")
+			continue
+		}
+		first, ok := firstIndex[trimmed]
+		if !ok || !dispatched[first] {
+			results[i].Err = err
+			continue
+		}
+		if first != i {
+			results[i].Intent = results[first].Intent
+			results[i].Recommendation = results[first].Recommendation
+		}
+	}
+
+	duration := time.Since(startTime)
+	if span != nil {
+		span.SetAttribute("queries.total", fmt.Sprintf("%d", len(queries)))
+		span.SetAttribute("queries.processed", fmt.Sprintf("%d", processed))
+		span.SetAttribute("concurrency", fmt.Sprintf("%d", concurrency))
+		span.SetAttribute("duration_ms", fmt.Sprintf("%.2f", duration.Seconds()*1000))
+		if err != nil {
+			span.RecordError(err)
+		}
+	}
+	o.tracer.RecordMetric("patterns.orchestrator.recommend_batch", float64(len(queries)), map[string]string{
+		"cancelled": fmt.Sprintf("%t", err != nil),
+	})
+
+	return results, err
 }
 
 // RecordPatternUsage records pattern usage metrics to the effectiveness tracker.
@@ -636,29 +1557,80 @@ func defaultExecutionPlanner(intent IntentCategory, userMessage string, context
 	return plan, nil
 }
 
-// matchesIntent checks if a pattern category matches an intent.
-func matchesIntent(category string, intent IntentCategory) bool {
-	categoryLower := strings.ToLower(category)
-	intentStr := strings.ToLower(string(intent))
+// matchesIntent checks if a pattern category serves an intent under the
+// orchestrator's intent-to-category mapping.
+func (o *Orchestrator) matchesIntent(category string, intent IntentCategory) bool {
+	return o.intentCategories.matches(category, intent)
+}
+
+// normalizedWords splits text into lowercase words on anything that is not a letter
+// or digit, so "real-time" and "real time" compare equal.
+func normalizedWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
 
-	// Direct match
-	if categoryLower == intentStr {
-		return true
+// containsExcludedKeyword reports whether any exclude keyword occurs in queryWords as
+// a whole word or phrase. queryWords is the query's normalized words joined by single
+// spaces, with a leading and trailing space.
+func containsExcludedKeyword(queryWords string, excludeKeywords []string) bool {
+	for _, kw := range excludeKeywords {
+		words := normalizedWords(kw)
+		if len(words) == 0 {
+			continue
+		}
+		if strings.Contains(queryWords, " "+strings.Join(words, " ")+" ") {
+			return true
+		}
 	}
+	return false
+}
 
-	// Fuzzy matches
-	switch intent {
-	case IntentAnalytics:
-		return categoryLower == "analytics" || categoryLower == "aggregation" || categoryLower == "reporting"
-	case IntentDataQuality:
-		return categoryLower == "data_quality" || categoryLower == "validation" || categoryLower == "quality"
-	case IntentDataTransform:
-		return categoryLower == "etl" || categoryLower == "transform" || categoryLower == "data_transform"
-	case IntentSchemaDiscovery:
-		return categoryLower == "schema" || categoryLower == "metadata" || categoryLower == "discovery"
+// supportsBackend reports whether a pattern runs on backend: it is backend-agnostic
+// or targets that backend.
+func supportsBackend(summary PatternSummary, backend string) bool {
+	return summary.Backend == "" || strings.EqualFold(summary.Backend, backend)
+}
+
+// matchesAnyIntent reports whether a category matches any of the intents. An intent
+// list of only IntentUnknown matches everything, mirroring the single-intent filter.
+func (o *Orchestrator) matchesAnyIntent(category string, intents []IntentCategory) bool {
+	known := false
+	for _, intent := range intents {
+		if intent == IntentUnknown {
+			continue
+		}
+		known = true
+		if o.matchesIntent(category, intent) {
+			return true
+		}
 	}
+	return !known
+}
 
-	return false
+// queryStopWords are filtered out of user messages before keyword matching.
+var queryStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "in": true,
+	"is": true, "it": true, "of": true, "on": true, "that": true, "the": true,
+	"to": true, "was": true, "will": true, "with": true,
+}
+
+// extractQueryKeywords lowercases and tokenizes a user message, dropping stop words
+// and tokens shorter than three characters.
+func extractQueryKeywords(userMessage string) []string {
+	keywords := strings.FieldsFunc(strings.ToLower(userMessage), func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';' || r == '-' || r == '_'
+	})
+
+	filtered := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		if !queryStopWords[kw] && len(kw) > 2 {
+			filtered = append(filtered, kw)
+		}
+	}
+	return filtered
 }
 
 // containsAny checks if string contains any of the keywords.
@@ -16,14 +16,21 @@ package patterns
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	_ "github.com/mutecomm/go-sqlcipher/v4"
 	"github.com/teradata-labs/loom/pkg/metaagent/learning"
 	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/types"
 )
 
 func TestNewOrchestrator(t *testing.T) {
@@ -469,9 +476,10 @@ func TestMatchesIntent(t *testing.T) {
 		{"no match", "etl", IntentAnalytics, false},
 	}
 
+	orch := NewOrchestrator(NewLibrary(nil, ""))
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := matchesIntent(tt.category, tt.intent)
+			result := orch.matchesIntent(tt.category, tt.intent)
 			if result != tt.expected {
 				t.Errorf("matchesIntent(%q, %s) = %v, expected %v", tt.category, tt.intent, result, tt.expected)
 			}
@@ -846,3 +854,820 @@ func TestOrchestrator_RecordPatternUsage_SuccessAndFailure(t *testing.T) {
 		t.Errorf("Expected success_rate ~%.2f, got %.2f", expectedRate, successRate)
 	}
 }
+
+func TestOrchestrator_WarmCache(t *testing.T) {
+	lib := NewLibrary(nil, "")
+	orch := NewOrchestrator(lib)
+
+	var mu sync.Mutex
+	classified := map[string]int{}
+	orch.SetIntentClassifier(func(msg string, ctx map[string]interface{}) (IntentCategory, float64) {
+		mu.Lock()
+		classified[msg]++
+		mu.Unlock()
+		return IntentAnalytics, 0.9
+	})
+
+	queries := []string{"top revenue by region", "find duplicates", "top revenue by region", "  ", "list tables"}
+	if err := orch.WarmCache(context.Background(), queries); err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+
+	if len(classified) != 3 {
+		t.Errorf("expected 3 distinct queries warmed, got %d", len(classified))
+	}
+	for q, n := range classified {
+		if n != 1 {
+			t.Errorf("query %q warmed %d times, expected once", q, n)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	many := make([]string, 50)
+	for i := range many {
+		many[i] = fmt.Sprintf("query %d", i)
+	}
+	if err := orch.WarmCache(ctx, many); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestOrchestrator_RequireIntentMatch(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"revenue_report": `name: revenue_report
+title: Revenue Report
+description: Aggregate revenue totals
+category: analytics
+use_cases:
+  - revenue totals
+`,
+		"revenue_validation": `name: revenue_validation
+title: Revenue Validation
+description: Validate revenue totals for duplicates and nulls
+category: data_quality
+use_cases:
+  - revenue totals validation
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	lib := NewLibrary(nil, tmpDir)
+	orch := NewOrchestrator(lib)
+	orch.SetRequireIntentMatch(true)
+
+	var candidates []string
+	orch.SetReRanker(NewFunctionReRanker(func(msg string, summary PatternSummary, keywordScore float64) float64 {
+		candidates = append(candidates, summary.Name)
+		return keywordScore
+	}))
+
+	// Analytics intent: the data_quality pattern must not be a candidate
+	if name, _ := orch.RecommendPattern("validate revenue totals", IntentAnalytics); name != "revenue_report" {
+		t.Errorf("expected revenue_report, got %q", name)
+	}
+	for _, c := range candidates {
+		if c == "revenue_validation" {
+			t.Errorf("revenue_validation should be filtered out for analytics intent")
+		}
+	}
+
+	// Without the option, both patterns compete for the analytics intent
+	orch.SetRequireIntentMatch(false)
+	ranked, err := orch.RecommendTopN("validate revenue totals", IntentAnalytics, 5)
+	if err != nil {
+		t.Fatalf("RecommendTopN: %v", err)
+	}
+	if len(ranked) != 2 {
+		t.Errorf("expected both patterns as candidates without filtering, got %v", ranked)
+	}
+
+	// Unknown intent bypasses the filter
+	orch.SetRequireIntentMatch(true)
+	candidates = nil
+	orch.RecommendPattern("validate revenue totals", IntentUnknown)
+	if len(candidates) != 2 {
+		t.Errorf("expected both patterns as candidates for unknown intent, got %v", candidates)
+	}
+}
+
+func TestOrchestrator_ClassifyMultiAndRecommendMulti(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"revenue_report": `name: revenue_report
+title: Revenue Report
+description: Aggregate revenue totals
+category: analytics
+`,
+		"revenue_validation": `name: revenue_validation
+title: Revenue Validation
+description: Validate revenue totals for duplicates and nulls
+category: data_quality
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	orch.SetRequireIntentMatch(true)
+
+	// Without a multi-intent classifier, the single-intent classifier is wrapped
+	intents, err := orch.ClassifyMulti("show tables in database")
+	if err != nil {
+		t.Fatalf("ClassifyMulti: %v", err)
+	}
+	if len(intents) != 1 || intents[0].Intent != IntentSchemaDiscovery {
+		t.Errorf("expected single schema_discovery intent, got %v", intents)
+	}
+
+	if _, err := orch.ClassifyMulti("  "); err == nil {
+		t.Error("expected error for empty message")
+	}
+
+	mock := &mockLLMProvider{defaultResponse: `{"intents": [
+		{"intent": "analytics", "confidence": 0.5},
+		{"intent": "data_quality", "confidence": 0.49}
+	]}`}
+	orch.SetMultiIntentClassifier(NewLLMMultiIntentClassifier(DefaultLLMClassifierConfig(mock)))
+
+	intents, err = orch.ClassifyMulti("validate revenue totals")
+	if err != nil {
+		t.Fatalf("ClassifyMulti: %v", err)
+	}
+	if len(intents) != 2 {
+		t.Fatalf("expected both near-tied intents, got %v", intents)
+	}
+
+	var candidates []string
+	orch.SetReRanker(NewFunctionReRanker(func(msg string, summary PatternSummary, keywordScore float64) float64 {
+		candidates = append(candidates, summary.Name)
+		return keywordScore
+	}))
+
+	// Patterns matching either intent pass the intent filter
+	rec := orch.RecommendMulti("validate revenue totals", intents)
+	if rec.PatternName != "revenue_validation" {
+		t.Errorf("expected revenue_validation, got %q", rec.PatternName)
+	}
+	if len(candidates) != 2 {
+		t.Errorf("expected both patterns as candidates, got %v", candidates)
+	}
+
+	// The single-intent path still filters to the primary intent
+	if name, _ := orch.RecommendPattern("validate revenue totals", intents[0].Intent); name != "revenue_report" {
+		t.Errorf("expected revenue_report for the single intent, got %q", name)
+	}
+}
+
+func TestOrchestrator_RecommendPatternForBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"npath_funnel": `name: npath_funnel
+title: Funnel Analysis
+description: Funnel analysis with nPath
+category: analytics
+backend: teradata
+`,
+		"window_funnel": `name: window_funnel
+title: Funnel Analysis
+description: Funnel analysis with window functions
+category: analytics
+backend: postgres
+`,
+		"funnel_checklist": `name: funnel_checklist
+title: Funnel Checklist
+description: Backend-agnostic funnel review
+category: analytics
+`,
+		"teradata_sessionize": `name: teradata_sessionize
+title: Sessionize
+description: Sessionize clickstream events
+category: analytics
+backend: teradata
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	var candidates []string
+	orch.SetReRanker(NewFunctionReRanker(func(msg string, summary PatternSummary, keywordScore float64) float64 {
+		candidates = append(candidates, summary.Name)
+		return keywordScore
+	}))
+
+	// Postgres sees its own pattern and backend-agnostic ones, never Teradata's
+	name, _, err := orch.RecommendPatternForBackend("funnel analysis", IntentUnknown, "Postgres")
+	if err != nil {
+		t.Fatalf("RecommendPatternForBackend: %v", err)
+	}
+	if name != "window_funnel" && name != "funnel_checklist" {
+		t.Errorf("expected a postgres or agnostic pattern, got %q", name)
+	}
+	for _, c := range candidates {
+		if c == "npath_funnel" {
+			t.Errorf("teradata pattern should be filtered out for postgres")
+		}
+	}
+
+	// Only Teradata patterns match: typed error
+	_, _, err = orch.RecommendPatternForBackend("sessionize clickstream", IntentAnalytics, "postgres")
+	var backendErr *NoPatternForBackendError
+	if !errors.As(err, &backendErr) {
+		t.Fatalf("expected NoPatternForBackendError, got %v", err)
+	}
+	if backendErr.Backend != "postgres" || backendErr.Filtered != 1 {
+		t.Errorf("unexpected error fields: %+v", backendErr)
+	}
+
+	// Nothing matches at all: no error
+	name, _, err = orch.RecommendPatternForBackend("zzzz", IntentAnalytics, "postgres")
+	if err != nil || name != "" {
+		t.Errorf("expected empty result without error, got %q, %v", name, err)
+	}
+
+	// Empty backend disables the filter
+	if name, _, err = orch.RecommendPatternForBackend("sessionize clickstream", IntentAnalytics, ""); err != nil || name != "teradata_sessionize" {
+		t.Errorf("expected teradata_sessionize without filter, got %q, %v", name, err)
+	}
+}
+
+// slowLLMProvider simulates a slow re-ranking model and counts calls concurrently.
+type slowLLMProvider struct {
+	mu       sync.Mutex
+	calls    int
+	delay    time.Duration
+	response string
+}
+
+func (p *slowLLMProvider) Chat(ctx context.Context, _ []types.Message, _ []shuttle.Tool) (*types.LLMResponse, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &types.LLMResponse{Content: p.response}, nil
+}
+
+func (p *slowLLMProvider) Name() string  { return "slow" }
+func (p *slowLLMProvider) Model() string { return "" }
+
+func (p *slowLLMProvider) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+func TestOrchestrator_RecommendBatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	patterns := map[string]string{
+		"revenue_report": `name: revenue_report
+title: Revenue Report
+description: Aggregate revenue totals by region
+category: analytics
+`,
+		"revenue_forecast": `name: revenue_forecast
+title: Revenue Forecast
+description: Forecast revenue by region
+category: timeseries
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	// Unknown intent always triggers re-ranking
+	orch.SetIntentClassifier(func(string, map[string]interface{}) (IntentCategory, float64) {
+		return IntentUnknown, 0.3
+	})
+	llm := &slowLLMProvider{
+		delay:    100 * time.Millisecond,
+		response: `{"selected_pattern": "revenue_report", "confidence": 0.85, "reasoning": "totals"}`,
+	}
+	orch.SetLLMProvider(llm)
+
+	queries := []string{
+		"revenue by region",
+		"Revenue  by REGION", // same normalized query: shares the re-rank call
+		"revenue by region",  // exact duplicate: processed once
+		"",
+		"forecast revenue",
+	}
+	results, err := orch.RecommendBatch(context.Background(), queries, 4)
+	if err != nil {
+		t.Fatalf("RecommendBatch returned error: %v", err)
+	}
+	if len(results) != len(queries) {
+		t.Fatalf("expected %d results, got %d", len(queries), len(results))
+	}
+
+	for i, r := range results {
+		if r.Query != queries[i] {
+			t.Errorf("result %d: query %q, expected %q", i, r.Query, queries[i])
+		}
+	}
+	for _, i := range []int{0, 1, 2, 4} {
+		if results[i].Err != nil {
+			t.Errorf("result %d: unexpected error %v", i, results[i].Err)
+		}
+		if results[i].Recommendation.PatternName != "revenue_report" {
+			t.Errorf("result %d: expected revenue_report, got %q", i, results[i].Recommendation.PatternName)
+		}
+	}
+	if results[3].Err == nil {
+		t.Errorf("expected error for empty query")
+	}
+	if n := llm.callCount(); n != 2 {
+		t.Errorf("expected 2 LLM calls (one per distinct query and candidate set), got %d", n)
+	}
+
+	// Cancelled batch: partial results, per-query errors, and the context error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = orch.RecommendBatch(ctx, []string{"revenue totals", "revenue forecast"}, 1)
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Err != context.Canceled {
+			t.Errorf("result %d: expected context.Canceled, got %v", i, r.Err)
+		}
+	}
+}
+
+func TestScoringWeights(t *testing.T) {
+	titleMatch := PatternSummary{Name: "churn_report", Title: "Churn Report", Description: "Monthly summary", Category: "analytics"}
+	useCaseMatch := PatternSummary{Name: "retention_model", Title: "Retention Model", Description: "Model accounts", Category: "ml",
+		UseCases: []string{"churn risk"}}
+	keywords := extractQueryKeywords("churn")
+
+	defaults := DefaultScoringWeights().normalized()
+	if got := defaults.score(titleMatch, IntentAnalytics, true, keywords, "churn"); got != 1.0 {
+		t.Errorf("default score = %v, expected 1.0 (0.5 intent + 0.5 keywords + 0.2 name + 0.1 title, clamped)", got)
+	}
+	if got := defaults.score(useCaseMatch, IntentUnknown, false, keywords, "churn"); got < 0.899 || got > 0.901 {
+		t.Errorf("default score = %v, expected 0.9 (0.4 unknown-intent ml + 0.5 keywords)", got)
+	}
+
+	// Scaling all weights together changes nothing
+	scaled := ScoringWeights{Title: 2, Description: 2, UseCases: 2, Category: 2}.normalized()
+	if scaled != defaults {
+		t.Errorf("scaled weights normalized to %+v, expected %+v", scaled, defaults)
+	}
+
+	// Boosting use cases ranks the use-case match above the title match
+	boosted := ScoringWeights{Title: 0.5, Description: 1, UseCases: 2, Category: 0}.normalized()
+	titleScore := boosted.score(titleMatch, IntentUnknown, false, keywords, "churn")
+	useCaseScore := boosted.score(useCaseMatch, IntentUnknown, false, keywords, "churn")
+	if useCaseScore <= titleScore {
+		t.Errorf("use-case match %.3f should outrank title match %.3f", useCaseScore, titleScore)
+	}
+	if useCaseScore > 1.0 {
+		t.Errorf("score %.3f should stay within [0, 1]", useCaseScore)
+	}
+
+	// Zero weight disables a field
+	noTitle := ScoringWeights{Description: 1, UseCases: 1}.normalized()
+	if got := noTitle.score(titleMatch, IntentAnalytics, true, keywords, "churn"); got != 0 {
+		t.Errorf("title-only match with zero title and category weights = %v, expected 0", got)
+	}
+
+	// All-zero weights fall back to defaults
+	orch := NewOrchestrator(NewLibrary(nil, ""))
+	orch.SetScoringWeights(ScoringWeights{})
+	if orch.ScoringWeights() != DefaultScoringWeights() {
+		t.Errorf("expected default weights, got %+v", orch.ScoringWeights())
+	}
+}
+
+func TestOrchestrator_ExcludeKeywords(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"sales_forecasting": `name: sales_forecasting
+title: Sales Forecasting
+description: Forecast sales from historical batches
+category: analytics
+use_cases:
+  - sales forecasting
+exclude_keywords:
+  - real-time
+`,
+		"streaming_sales": `name: streaming_sales
+title: Streaming Sales Monitor
+description: Monitor sales events from a stream
+category: analytics
+use_cases:
+  - sales monitoring
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+
+	// Without the excluded term, forecasting wins
+	if name, _ := orch.RecommendPattern("forecast sales", IntentAnalytics); name != "sales_forecasting" {
+		t.Errorf("expected sales_forecasting, got %q", name)
+	}
+
+	// The query matches "forecast" (positive) and "real time" (excluded)
+	query := "forecast sales in real time"
+	ranked, err := orch.RecommendTopN(query, IntentAnalytics, 2)
+	if err != nil {
+		t.Fatalf("RecommendTopN: %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].Name != "streaming_sales" {
+		t.Fatalf("expected streaming_sales ahead of the excluded pattern, got %v", ranked)
+	}
+	suppressed := ranked[1].KeywordScore
+
+	// Disabling exclusions restores the unpenalized score
+	orch.SetExcludeKeywords(false)
+	ranked, err = orch.RecommendTopN(query, IntentAnalytics, 2)
+	if err != nil {
+		t.Fatalf("RecommendTopN: %v", err)
+	}
+	if ranked[0].Name != "sales_forecasting" {
+		t.Errorf("expected sales_forecasting with exclusions disabled, got %v", ranked)
+	}
+	if got := ranked[0].KeywordScore * excludeKeywordPenalty; got < suppressed-1e-9 || got > suppressed+1e-9 {
+		t.Errorf("expected suppressed score %.3f, got %.3f", got, suppressed)
+	}
+}
+
+func TestContainsExcludedKeyword(t *testing.T) {
+	tests := []struct {
+		query    string
+		excluded []string
+		want     bool
+	}{
+		{"forecast sales in real-time", []string{"real-time"}, true},
+		{"forecast sales in real time", []string{"real-time"}, true},
+		{"Real-Time dashboards", []string{"real time"}, true},
+		{"forecast sales", []string{"real-time"}, false},
+		{"surreal timelines", []string{"real time"}, false},
+		{"streaming data", []string{"stream"}, false},
+		{"anything", []string{"", "--"}, false},
+	}
+	for _, tt := range tests {
+		queryWords := " " + strings.Join(normalizedWords(tt.query), " ") + " "
+		if got := containsExcludedKeyword(queryWords, tt.excluded); got != tt.want {
+			t.Errorf("containsExcludedKeyword(%q, %v) = %v, want %v", tt.query, tt.excluded, got, tt.want)
+		}
+	}
+}
+
+// syntheticSummaries returns n summaries spread across categories and vocabulary.
+func syntheticSummaries(n int) []PatternSummary {
+	categories := []string{"analytics", "data_quality", "etl", "schema", "ml"}
+	words := []string{"revenue", "churn", "duplicates", "forecast", "cohort", "funnel", "schema", "migration"}
+	summaries := make([]PatternSummary, n)
+	for i := range summaries {
+		w1, w2 := words[i%len(words)], words[(i/len(words))%len(words)]
+		summaries[i] = PatternSummary{
+			Name:        fmt.Sprintf("pattern_%04d_%s", i, w1),
+			Title:       fmt.Sprintf("%s %s analysis", w1, w2),
+			Description: fmt.Sprintf("Analyze %s with %s breakdowns over time", w1, w2),
+			Category:    categories[i%len(categories)],
+			UseCases:    []string{w1 + " report", w2 + " trends"},
+		}
+	}
+	return summaries
+}
+
+func TestScorePatterns_ParallelMatchesSequential(t *testing.T) {
+	orch := NewOrchestrator(NewLibrary(nil, ""))
+	summaries := syntheticSummaries(500)
+	query := "forecast revenue churn trends"
+	keywords := extractQueryKeywords(query)
+	intents := []IntentCategory{IntentAnalytics}
+
+	sequential := orch.scorePatterns(summaries, intents, keywords, query, nil, 1)
+	for i := 0; i < 5; i++ {
+		parallel := orch.scorePatterns(summaries, intents, keywords, query, nil, 8)
+		if len(parallel) != len(sequential) {
+			t.Fatalf("expected %d scored patterns, got %d", len(sequential), len(parallel))
+		}
+		for j := range parallel {
+			if parallel[j] != sequential[j] {
+				t.Fatalf("order differs at %d: %v vs %v", j, parallel[j], sequential[j])
+			}
+		}
+	}
+
+	for j := 1; j < len(sequential); j++ {
+		prev, cur := sequential[j-1], sequential[j]
+		if prev.score < cur.score || (prev.score == cur.score && prev.name > cur.name) {
+			t.Fatalf("not sorted by score then name at %d: %v, %v", j, prev, cur)
+		}
+	}
+}
+
+func TestOrchestrator_RecommendPattern_DeterministicTies(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Identical metadata under different names, so every keyword score ties
+	for _, name := range []string{"customer_report_c", "customer_report_a", "customer_report_b"} {
+		content := fmt.Sprintf(`name: %s
+title: Customer Report
+description: Summarize customer accounts
+category: analytics
+use_cases:
+  - customer summaries
+`, name)
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	query := "I need a customer summary"
+	keywordOnly := NewOrchestrator(NewLibrary(nil, tmpDir))
+	reRanked := NewOrchestrator(NewLibrary(nil, tmpDir))
+	reRanked.SetReRanker(NewFunctionReRanker(func(string, PatternSummary, float64) float64 { return 0.5 }))
+
+	for i := 0; i < 100; i++ {
+		if name, _ := keywordOnly.RecommendPattern(query, IntentAnalytics); name != "customer_report_a" {
+			t.Fatalf("run %d: expected customer_report_a by name tiebreak, got %q", i, name)
+		}
+		if name, _ := reRanked.RecommendPattern(query, IntentAnalytics); name != "customer_report_a" {
+			t.Fatalf("run %d: expected customer_report_a from the re-ranker, got %q", i, name)
+		}
+	}
+
+	ranked, err := keywordOnly.RecommendTopN(query, IntentAnalytics, 3)
+	if err != nil {
+		t.Fatalf("RecommendTopN: %v", err)
+	}
+	var names []string
+	for _, r := range ranked {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "customer_report_a,customer_report_b,customer_report_c" {
+		t.Errorf("expected tied patterns in name order, got %v", names)
+	}
+}
+
+func BenchmarkScoreAllPatterns(b *testing.B) {
+	orch := NewOrchestrator(NewLibrary(nil, ""))
+	query := "forecast revenue churn trends by cohort"
+	keywords := extractQueryKeywords(query)
+	intents := []IntentCategory{IntentAnalytics}
+
+	for _, n := range []int{100, 500, 1000} {
+		summaries := syntheticSummaries(n)
+		for _, mode := range []struct {
+			name    string
+			workers int
+		}{
+			{"sequential", 1},
+			{"parallel", runtime.GOMAXPROCS(0)},
+		} {
+			b.Run(fmt.Sprintf("patterns=%d/%s", n, mode.name), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					orch.scorePatterns(summaries, intents, keywords, query, nil, mode.workers)
+				}
+			})
+		}
+	}
+}
+
+func TestOrchestrator_RecommendPatternContext_Cancel(t *testing.T) {
+	tmpDir := t.TempDir()
+	patterns := map[string]string{
+		"revenue_report": `name: revenue_report
+title: Revenue Report
+description: Aggregate revenue totals by region
+category: analytics
+`,
+		"revenue_forecast": `name: revenue_forecast
+title: Revenue Forecast
+description: Forecast revenue by region
+category: timeseries
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	llm := &slowLLMProvider{
+		delay:    10 * time.Second,
+		response: `{"selected_pattern": "revenue_forecast", "confidence": 0.85, "reasoning": "forecast"}`,
+	}
+	orch.SetLLMProvider(llm)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	rec := orch.RecommendContext(ctx, "revenue by region", IntentUnknown)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the re-rank call to be cut short by the caller deadline, took %v", elapsed)
+	}
+	if llm.callCount() != 1 {
+		t.Errorf("expected 1 LLM call, got %d", llm.callCount())
+	}
+	if rec.Reason != ReasonKeywordFallbackAfterLLMError {
+		t.Errorf("expected keyword fallback after cancellation, got %s", rec.Reason)
+	}
+	if rec.PatternName == "" {
+		t.Errorf("expected the keyword winner to be returned")
+	}
+}
+
+func TestOrchestrator_MinConfidence(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `name: funnel_analysis
+title: Funnel Analysis
+description: Measure drop-off between conversion steps
+category: analytics
+use_cases:
+  - conversion funnel
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "funnel_analysis.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("write pattern: %v", err)
+	}
+
+	orch := NewOrchestrator(NewLibrary(nil, tmpDir))
+	sink := &memorySink{}
+	orch.SetRecommendationSink(sink)
+
+	// A weak match that is recommended without a threshold
+	query := "funnel weather forecast sonnet"
+	baseline := orch.Recommend(query, IntentUnknown)
+	if baseline.PatternName != "funnel_analysis" || baseline.Err() != nil {
+		t.Fatalf("baseline = %+v, want funnel_analysis", baseline)
+	}
+
+	orch.SetMinConfidence(baseline.Confidence + 0.01)
+	rec := orch.Recommend(query, IntentUnknown)
+	if rec.PatternName != "" {
+		t.Errorf("PatternName = %q, want empty below the minimum confidence", rec.PatternName)
+	}
+	if rec.Reason != ReasonBelowMinConfidence || !errors.Is(rec.Err(), ErrNoConfidentMatch) {
+		t.Errorf("Reason = %s, Err = %v", rec.Reason, rec.Err())
+	}
+	if rec.Confidence != baseline.Confidence {
+		t.Errorf("Confidence = %v, want the best candidate's %v", rec.Confidence, baseline.Confidence)
+	}
+
+	name, conf, err := orch.RecommendPatternForBackend(query, IntentUnknown, "")
+	if name != "" || conf != baseline.Confidence || !errors.Is(err, ErrNoConfidentMatch) {
+		t.Errorf("RecommendPatternForBackend = %q, %v, %v; want ErrNoConfidentMatch", name, conf, err)
+	}
+
+	// Near misses are still available to callers that want to offer them
+	ranked, err := orch.RecommendTopN(query, IntentUnknown, 3)
+	if err != nil || len(ranked) != 1 || ranked[0].Name != "funnel_analysis" {
+		t.Errorf("RecommendTopN = %v, %v", ranked, err)
+	}
+
+	sink.mu.Lock()
+	last := sink.events[len(sink.events)-1]
+	sink.mu.Unlock()
+	if last.FinalWinner != "" || last.KeywordWinner != "funnel_analysis" || last.Reason != ReasonBelowMinConfidence {
+		t.Errorf("event = %+v, want no final winner", last)
+	}
+
+	// Nothing matched is still a plain empty result
+	if rec := orch.Recommend("zzzz qqqq", IntentUnknown); rec.Err() != nil || rec.PatternName != "" {
+		t.Errorf("no match = %+v, %v", rec, rec.Err())
+	}
+
+	orch.SetMinConfidence(0)
+	if name, _ := orch.RecommendPattern(query, IntentUnknown); name != "funnel_analysis" {
+		t.Errorf("RecommendPattern after clearing the threshold = %q", name)
+	}
+}
+
+func TestOrchestrator_RecommendTopN(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patterns := map[string]string{
+		"sales_report": `name: sales_report
+title: Sales Report
+description: Summarize sales totals
+category: analytics
+use_cases:
+  - sales totals
+`,
+		"sales_forecast": `name: sales_forecast
+title: Sales Forecast
+description: Forecast future sales from history
+category: analytics
+use_cases:
+  - forecast future sales growth
+`,
+	}
+	for name, content := range patterns {
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("write pattern: %v", err)
+		}
+	}
+
+	lib := NewLibrary(nil, tmpDir)
+	orch := NewOrchestrator(lib)
+
+	if _, err := orch.RecommendTopN("sales", IntentAnalytics, 0); err == nil {
+		t.Error("expected an error for n = 0")
+	}
+
+	t.Run("keyword order without re-ranker", func(t *testing.T) {
+		ranked, err := orch.RecommendTopN("sales totals", IntentAnalytics, 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(ranked) != 2 {
+			t.Fatalf("Expected 2 candidates, got %d", len(ranked))
+		}
+		if ranked[0].Name != "sales_report" {
+			t.Errorf("Expected sales_report first, got %s", ranked[0].Name)
+		}
+		if ranked[0].KeywordScore < ranked[1].KeywordScore {
+			t.Errorf("Candidates not in keyword order: %.2f < %.2f", ranked[0].KeywordScore, ranked[1].KeywordScore)
+		}
+		if ranked[0].ReRanked {
+			t.Error("Expected no re-ranking without a re-ranker")
+		}
+
+		rec := orch.Recommend("sales totals", IntentAnalytics)
+		if rec.PatternName != ranked[0].Name || rec.Confidence != ranked[0].Confidence {
+			t.Errorf("Recommend = %s (%.2f), head of RecommendTopN = %s (%.2f)",
+				rec.PatternName, rec.Confidence, ranked[0].Name, ranked[0].Confidence)
+		}
+	})
+
+	t.Run("re-ranked pick moves to head", func(t *testing.T) {
+		orch.SetReRanker(NewFunctionReRanker(func(msg string, summary PatternSummary, keywordScore float64) float64 {
+			if summary.Name == "sales_forecast" {
+				return 0.95
+			}
+			return 0.1
+		}))
+		defer orch.SetReRanker(nil)
+
+		ranked, err := orch.RecommendTopN("sales", IntentUnknown, 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(ranked) != 2 {
+			t.Fatalf("Expected 2 candidates, got %d", len(ranked))
+		}
+		if ranked[0].Name != "sales_forecast" || !ranked[0].ReRanked || ranked[0].Confidence != 0.95 {
+			t.Errorf("Expected re-ranked sales_forecast (0.95) first, got %+v", ranked[0])
+		}
+		if ranked[1].ReRanked {
+			t.Error("Expected only the head to be re-ranked")
+		}
+	})
+
+	t.Run("truncates to n", func(t *testing.T) {
+		ranked, err := orch.RecommendTopN("sales", IntentAnalytics, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(ranked) != 1 {
+			t.Errorf("Expected 1 candidate, got %d", len(ranked))
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		ranked, err := orch.RecommendTopN("xyz random words", IntentUnknown, 3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(ranked) != 0 {
+			t.Errorf("Expected no candidates, got %d", len(ranked))
+		}
+	})
+}
@@ -0,0 +1,368 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PatternSource supplies pattern YAML files to a Library (see NewLibraryFromSource).
+// Names are slash-separated paths relative to the source root, such as
+// "analytics/funnel_analysis.yaml"; a pattern's name is its file name without ".yaml".
+// Implementations must be safe for concurrent use.
+type PatternSource interface {
+	// List returns the names of all pattern files in the source.
+	List() ([]string, error)
+	// Open returns the contents of a file returned by List. Missing files are reported
+	// with an error matching fs.ErrNotExist.
+	Open(name string) (io.ReadCloser, error)
+}
+
+const (
+	maxPatternFileSize    = 1 << 20 // Largest pattern file read from a source
+	maxSourceListingSize  = 8 << 20 // Largest manifest or S3 listing page read
+	defaultHTTPSourceTTL  = 5 * time.Minute
+	defaultHTTPSourceWait = 30 * time.Second
+)
+
+// FSSource reads patterns from an fs.FS, such as an embed.FS compiled into the binary.
+type FSSource struct {
+	fsys fs.FS
+}
+
+// NewFSSource returns a source for the .yaml files in fsys. For an embed.FS whose
+// patterns live under a directory, pass fs.Sub(embedded, "patterns") so names are
+// relative to that directory.
+func NewFSSource(fsys fs.FS) *FSSource {
+	return &FSSource{fsys: fsys}
+}
+
+// NewDirSource returns a source for the .yaml files under dir. Names cannot escape dir.
+func NewDirSource(dir string) *FSSource {
+	return NewFSSource(os.DirFS(dir))
+}
+
+// List returns every .yaml file in the filesystem, sorted. Unreadable subdirectories
+// are skipped; only a failure to read the root is reported.
+func (s *FSSource) List() ([]string, error) {
+	var names []string
+	err := fs.WalkDir(s.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == "." {
+				return err
+			}
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.HasSuffix(name, ".yaml") {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list patterns: %w", err)
+	}
+	return names, nil
+}
+
+// Open opens a pattern file.
+func (s *FSSource) Open(name string) (io.ReadCloser, error) {
+	return s.fsys.Open(name)
+}
+
+// HTTPSourceConfig configures an HTTPSource.
+type HTTPSourceConfig struct {
+	// BaseURL is the catalog root; pattern names are resolved against it. For S3, use
+	// the bucket URL, e.g. "https://my-bucket.s3.us-east-1.amazonaws.com".
+	BaseURL string
+
+	// Manifest is the path, relative to BaseURL, of a JSON array of pattern file names
+	// (default: "index.json"). Ignored when S3Listing is set.
+	Manifest string
+
+	// S3Listing lists the catalog with the S3 ListObjectsV2 API instead of a manifest.
+	// The bucket must allow anonymous listing, or Header must carry credentials.
+	S3Listing bool
+
+	// Prefix is the path under BaseURL holding the pattern files, e.g. "patterns/".
+	// Names are relative to it, and an S3 listing only returns keys under it.
+	Prefix string
+
+	// TTL is how long the listing and fetched files are reused before they are
+	// revalidated with the server (default: 5 minutes).
+	TTL time.Duration
+
+	// Header is added to every request, e.g. an Authorization header.
+	Header http.Header
+
+	// Client makes the requests (default: a client with a 30 second timeout).
+	Client *http.Client
+}
+
+// HTTPSource reads patterns from a remote catalog over HTTP(S), including S3 buckets.
+// Responses are cached in memory: entries younger than the TTL are served without a
+// request, older ones are revalidated with their ETag, and a cached copy is served when
+// the server cannot be reached, so a catalog outage does not take patterns away.
+// Call Library.ClearCache to pick up catalog changes before the library's next ListAll.
+type HTTPSource struct {
+	base     *url.URL
+	manifest string
+	s3       bool
+	prefix   string
+	ttl      time.Duration
+	header   http.Header
+	client   *http.Client
+
+	mu           sync.Mutex
+	names        []string        // Last successful listing
+	listedAt     time.Time       // When names was fetched
+	manifestResp *httpCacheEntry // Manifest response, for revalidation
+	files        map[string]*httpCacheEntry
+}
+
+// httpCacheEntry is a cached response body.
+type httpCacheEntry struct {
+	data      []byte
+	etag      string
+	fetchedAt time.Time
+}
+
+// NewHTTPSource returns a source for the catalog at cfg.BaseURL.
+func NewHTTPSource(cfg HTTPSourceConfig) (*HTTPSource, error) {
+	base, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern catalog URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid pattern catalog URL %q: scheme must be http or https", cfg.BaseURL)
+	}
+	s := &HTTPSource{
+		base:     base,
+		manifest: cfg.Manifest,
+		s3:       cfg.S3Listing,
+		prefix:   cfg.Prefix,
+		ttl:      cfg.TTL,
+		header:   cfg.Header,
+		client:   cfg.Client,
+		files:    make(map[string]*httpCacheEntry),
+	}
+	if s.manifest == "" {
+		s.manifest = "index.json"
+	}
+	if s.ttl <= 0 {
+		s.ttl = defaultHTTPSourceTTL
+	}
+	if s.client == nil {
+		s.client = &http.Client{Timeout: defaultHTTPSourceWait}
+	}
+	return s, nil
+}
+
+// List returns the pattern files in the catalog, sorted.
+func (s *HTTPSource) List() ([]string, error) {
+	s.mu.Lock()
+	names, listedAt, manifestResp := s.names, s.listedAt, s.manifestResp
+	s.mu.Unlock()
+	listed := !listedAt.IsZero()
+	if listed && time.Since(listedAt) < s.ttl {
+		return slices.Clone(names), nil
+	}
+
+	var fresh []string
+	var err error
+	if s.s3 {
+		fresh, err = s.listS3()
+	} else {
+		fresh, manifestResp, err = s.listManifest(manifestResp)
+	}
+	if err != nil {
+		if listed {
+			return slices.Clone(names), nil // Serve the last listing while the catalog is down
+		}
+		return nil, err
+	}
+	sort.Strings(fresh)
+
+	s.mu.Lock()
+	s.names, s.listedAt, s.manifestResp = fresh, time.Now(), manifestResp
+	s.mu.Unlock()
+	return slices.Clone(fresh), nil
+}
+
+// Open returns a pattern file from the catalog.
+func (s *HTTPSource) Open(name string) (io.ReadCloser, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	s.mu.Lock()
+	cached := s.files[name]
+	s.mu.Unlock()
+	if cached != nil && time.Since(cached.fetchedAt) < s.ttl {
+		return io.NopCloser(bytes.NewReader(cached.data)), nil
+	}
+
+	entry, err := s.fetch(s.base.JoinPath(s.prefix+name), cached, maxPatternFileSize)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			s.mu.Lock()
+			delete(s.files, name)
+			s.mu.Unlock()
+		} else if cached != nil {
+			return io.NopCloser(bytes.NewReader(cached.data)), nil
+		}
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	s.mu.Lock()
+	s.files[name] = entry
+	s.mu.Unlock()
+	return io.NopCloser(bytes.NewReader(entry.data)), nil
+}
+
+// listManifest fetches the manifest, a JSON array of pattern file names, revalidating
+// the cached response. Returns the names and the response to cache.
+func (s *HTTPSource) listManifest(cached *httpCacheEntry) ([]string, *httpCacheEntry, error) {
+	entry, err := s.fetch(s.base.JoinPath(s.manifest), cached, maxSourceListingSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch pattern manifest: %w", err)
+	}
+	var names []string
+	if err := json.Unmarshal(entry.data, &names); err != nil {
+		return nil, nil, fmt.Errorf("invalid pattern manifest: %w", err)
+	}
+	valid := names[:0]
+	for _, name := range names {
+		if fs.ValidPath(name) && strings.HasSuffix(name, ".yaml") {
+			valid = append(valid, name)
+		}
+	}
+	return valid, entry, nil
+}
+
+// s3ListResult is the part of an S3 ListObjectsV2 response the source reads.
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// listS3 lists the .yaml keys under the prefix, following continuation tokens.
+func (s *HTTPSource) listS3() ([]string, error) {
+	var names []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if s.prefix != "" {
+			query.Set("prefix", s.prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		target := *s.base
+		target.RawQuery = query.Encode()
+		entry, err := s.fetch(&target, nil, maxSourceListingSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list S3 pattern catalog: %w", err)
+		}
+		var result s3ListResult
+		if err := xml.Unmarshal(entry.data, &result); err != nil {
+			return nil, fmt.Errorf("invalid S3 listing: %w", err)
+		}
+		for _, obj := range result.Contents {
+			name := strings.TrimPrefix(obj.Key, s.prefix)
+			if fs.ValidPath(name) && strings.HasSuffix(name, ".yaml") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// fetch GETs target, revalidating cached with its ETag. A 404 is reported as
+// fs.ErrNotExist.
+func (s *HTTPSource) fetch(target *url.URL, cached *httpCacheEntry, limit int64) (*httpCacheEntry, error) {
+	req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range s.header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	if cached != nil && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return &httpCacheEntry{data: cached.data, etag: cached.etag, fetchedAt: time.Now()}, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fs.ErrNotExist
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s: %s", target.Redacted(), resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", target.Redacted(), limit)
+	}
+	return &httpCacheEntry{data: data, etag: resp.Header.Get("ETag"), fetchedAt: time.Now()}, nil
+}
+
+// readSourceFile reads a pattern file from a source.
+func readSourceFile(source PatternSource, name string) ([]byte, error) {
+	rc, err := source.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxPatternFileSize))
+}
+
+// sourcePatternName returns the pattern name for a source file name.
+func sourcePatternName(name string) string {
+	return strings.TrimSuffix(path.Base(name), ".yaml")
+}
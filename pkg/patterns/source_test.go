@@ -0,0 +1,268 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func sourcePatternYAML(name, category string) []byte {
+	return []byte(fmt.Sprintf("name: %s\ntitle: %s\ndescription: From a pattern source\ncategory: %s\n", name, strings.ToUpper(name), category))
+}
+
+func TestLibraryFromSource_FS(t *testing.T) {
+	lib := NewLibraryFromSource(NewFSSource(fstest.MapFS{
+		"analytics/funnel.yaml": {Data: sourcePatternYAML("funnel", "analytics")},
+		"ml/churn.yaml":         {Data: sourcePatternYAML("churn", "ml")},
+		"README.md":             {Data: []byte("not a pattern")},
+	}))
+
+	all := lib.ListAll()
+	if len(all) != 2 {
+		t.Fatalf("ListAll returned %d patterns, want 2", len(all))
+	}
+
+	pattern, err := lib.Load("churn")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if pattern.Category != "ml" {
+		t.Errorf("Category = %q, want ml", pattern.Category)
+	}
+
+	// Patterns in search paths load without indexing first
+	lib = NewLibraryFromSource(NewFSSource(fstest.MapFS{
+		"analytics/funnel.yaml": {Data: sourcePatternYAML("funnel", "analytics")},
+	}))
+	if _, err := lib.Load("funnel"); err != nil {
+		t.Errorf("Load before ListAll failed: %v", err)
+	}
+	if _, err := lib.Load("missing"); !errors.Is(err, errPatternNotFound) {
+		t.Errorf("Load(missing) error = %v, want errPatternNotFound", err)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "text"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "text", "summarize.yaml"), sourcePatternYAML("summarize", "text"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := NewDirSource(dir)
+	names, err := src.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"text/summarize.yaml"}) {
+		t.Errorf("List = %v", names)
+	}
+	if _, err := src.Open("../outside.yaml"); err == nil {
+		t.Error("Open outside the directory succeeded")
+	}
+
+	viaSource := NewLibraryFromSource(src).ListAll()
+	viaDir := NewLibrary(nil, dir).ListAll()
+	if !reflect.DeepEqual(viaSource, viaDir) {
+		t.Errorf("NewLibraryFromSource(NewDirSource(dir)) = %v, NewLibrary(nil, dir) = %v", viaSource, viaDir)
+	}
+}
+
+func TestLibrary_WarnsOnUnknownCategory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "funnel.yaml"), sourcePatternYAML("funnel", "analytics"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "typo.yaml"), sourcePatternYAML("typo", "analytcs"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		lib  func() *Library
+	}{
+		{"directory", func() *Library { return NewLibrary(nil, dir) }},
+		{"source", func() *Library { return NewLibraryFromSource(NewDirSource(dir)) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			summaries := tc.lib().WithLogger(zap.New(core)).ListAll()
+			if len(summaries) != 1 || summaries[0].Name != "funnel" {
+				t.Errorf("ListAll = %v, want only funnel", summaries)
+			}
+			warnings := logs.FilterMessage("Skipping pattern with unknown category").All()
+			if len(warnings) != 1 {
+				t.Fatalf("got %d unknown category warnings, want 1", len(warnings))
+			}
+			if file := warnings[0].ContextMap()["file"]; file != "typo.yaml" {
+				t.Errorf("warning file = %v, want typo.yaml", file)
+			}
+		})
+	}
+}
+
+// catalogServer serves a manifest-based pattern catalog with ETags and counts requests.
+type catalogServer struct {
+	mu       sync.Mutex
+	files    map[string][]byte
+	requests atomic.Int32
+	notMod   atomic.Int32
+	down     atomic.Bool
+}
+
+func (c *catalogServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.requests.Add(1)
+	if c.down.Load() {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	c.mu.Lock()
+	data, ok := c.files[strings.TrimPrefix(r.URL.Path, "/catalog/")]
+	c.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	etag := fmt.Sprintf(`"%x"`, len(data))
+	if r.Header.Get("If-None-Match") == etag {
+		c.notMod.Add(1)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_, _ = w.Write(data)
+}
+
+func TestHTTPSource(t *testing.T) {
+	catalog := &catalogServer{files: map[string][]byte{
+		"index.json":              []byte(`["sql/top_n.yaml", "../escape.yaml", "notes.txt"]`),
+		"patterns/sql/top_n.yaml": sourcePatternYAML("top_n", "analytics"),
+	}}
+	srv := httptest.NewServer(catalog)
+	defer srv.Close()
+
+	src, err := NewHTTPSource(HTTPSourceConfig{BaseURL: srv.URL + "/catalog", Prefix: "patterns/", TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := src.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"sql/top_n.yaml"}) {
+		t.Errorf("List = %v, want only valid .yaml names", names)
+	}
+
+	lib := NewLibraryFromSource(src)
+	if all := lib.ListAll(); len(all) != 1 || all[0].Name != "top_n" {
+		t.Fatalf("ListAll = %v", all)
+	}
+	before := catalog.requests.Load()
+	lib.ClearCache()
+	lib.ListAll()
+	if catalog.requests.Load() != before {
+		t.Error("fresh listing and files were refetched within the TTL")
+	}
+
+	if _, err := src.Open("sql/missing.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open(missing) error = %v, want fs.ErrNotExist", err)
+	}
+	if _, err := NewHTTPSource(HTTPSourceConfig{BaseURL: "ftp://example.com"}); err == nil {
+		t.Error("NewHTTPSource accepted a non-HTTP URL")
+	}
+}
+
+func TestHTTPSource_RevalidatesAndServesStale(t *testing.T) {
+	catalog := &catalogServer{files: map[string][]byte{
+		"index.json": []byte(`["top_n.yaml"]`),
+		"top_n.yaml": sourcePatternYAML("top_n", "analytics"),
+	}}
+	srv := httptest.NewServer(catalog)
+	defer srv.Close()
+
+	src, err := NewHTTPSource(HTTPSourceConfig{BaseURL: srv.URL + "/catalog/", TTL: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readSourceFile(src, "top_n.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readSourceFile(src, "top_n.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if catalog.notMod.Load() != 1 {
+		t.Errorf("stale file revalidated %d times with 304, want 1", catalog.notMod.Load())
+	}
+
+	if _, err := src.List(); err != nil {
+		t.Fatal(err)
+	}
+	catalog.down.Store(true)
+	names, err := src.List()
+	if err != nil || len(names) != 1 {
+		t.Errorf("List while catalog is down = %v, %v; want the cached listing", names, err)
+	}
+	if data, err := readSourceFile(src, "top_n.yaml"); err != nil || len(data) == 0 {
+		t.Errorf("Open while catalog is down failed: %v", err)
+	}
+}
+
+func TestHTTPSource_S3Listing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("list-type") == "2" && q.Get("continuation-token") == "":
+			if q.Get("prefix") != "patterns/" {
+				t.Errorf("prefix = %q", q.Get("prefix"))
+			}
+			fmt.Fprint(w, `<ListBucketResult><Contents><Key>patterns/a.yaml</Key></Contents><IsTruncated>true</IsTruncated><NextContinuationToken>next</NextContinuationToken></ListBucketResult>`)
+		case q.Get("list-type") == "2":
+			fmt.Fprint(w, `<ListBucketResult><Contents><Key>patterns/ml/b.yaml</Key></Contents><Contents><Key>patterns/README.md</Key></Contents><IsTruncated>false</IsTruncated></ListBucketResult>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := NewHTTPSource(HTTPSourceConfig{BaseURL: srv.URL, S3Listing: true, Prefix: "patterns/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := src.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"a.yaml", "ml/b.yaml"}) {
+		t.Errorf("List = %v", names)
+	}
+}
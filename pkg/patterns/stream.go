@@ -0,0 +1,134 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"time"
+)
+
+// RecommendStage identifies a step of the recommendation pipeline reported by
+// RecommendPatternStream.
+type RecommendStage string
+
+const (
+	// StageScoringDone is sent once keyword scoring completes
+	StageScoringDone RecommendStage = "scoring_done"
+
+	// StageLLMInvoked is sent before the re-ranker is called (ambiguous results only)
+	StageLLMInvoked RecommendStage = "llm_invoked"
+
+	// StageLLMResponded is sent when the re-ranker returns, successfully or not
+	StageLLMResponded RecommendStage = "llm_responded"
+
+	// StageFinal is always the last event and carries the Recommendation
+	StageFinal RecommendStage = "final"
+)
+
+// streamBufferSize holds every event a single recommendation can emit, so the
+// pipeline never blocks on a slow consumer.
+const streamBufferSize = 4
+
+// RecommendProgress is one progress event from RecommendPatternStream.
+type RecommendProgress struct {
+	Stage RecommendStage
+
+	// Leader is the current best candidate and Confidence its score: the keyword
+	// winner until the re-ranker responds, then the re-ranker's pick (or the keyword
+	// fallback if it failed). Leader is empty when nothing matched.
+	Leader     string
+	Confidence float64
+
+	// Candidates is the number of scored candidates (re-ranked candidates for LLM stages)
+	Candidates int
+
+	// Elapsed is the time since RecommendPatternStream was called
+	Elapsed time.Duration
+
+	// Err is the re-ranker error on StageLLMResponded when keyword fallback was used
+	Err error
+
+	// Recommendation is set on StageFinal only
+	Recommendation *Recommendation
+}
+
+// progressFunc receives pipeline stages from selectPattern.
+type progressFunc func(RecommendProgress)
+
+func (f progressFunc) emit(stage RecommendStage, leader string, confidence float64, candidates int, err error) {
+	if f == nil {
+		return
+	}
+	f(RecommendProgress{
+		Stage:      stage,
+		Leader:     leader,
+		Confidence: confidence,
+		Candidates: candidates,
+		Err:        err,
+	})
+}
+
+// RecommendPatternStream is RecommendPattern reporting progress as it goes, so
+// interactive clients can show the current best match while the re-ranker runs.
+// Events arrive in order: StageScoringDone, then StageLLMInvoked and StageLLMResponded
+// if re-ranking was triggered, then StageFinal. The channel is closed after
+// StageFinal, or as soon as ctx is cancelled, in which case remaining events are
+// discarded. ctx is also passed to the re-ranker (see RecommendPatternContext).
+// Returns ctx.Err() if ctx is already done.
+func (o *Orchestrator) RecommendPatternStream(ctx context.Context, userMessage string, intent IntentCategory) (<-chan RecommendProgress, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	events := make(chan RecommendProgress, streamBufferSize)
+	out := make(chan RecommendProgress)
+
+	go func() {
+		defer close(events)
+		progress := progressFunc(func(p RecommendProgress) {
+			p.Elapsed = time.Since(startTime)
+			events <- p
+		})
+		rec, _ := o.recommend(ctx, userMessage, []IntentCategory{intent}, "", "patterns.orchestrator.recommend_stream", progress)
+		events <- RecommendProgress{
+			Stage:          StageFinal,
+			Leader:         rec.PatternName,
+			Confidence:     rec.Confidence,
+			Elapsed:        time.Since(startTime),
+			Recommendation: &rec,
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case p, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case out <- p:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
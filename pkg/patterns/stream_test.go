@@ -0,0 +1,161 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package patterns
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	tmpDir := t.TempDir()
+	patterns := map[string]string{
+		"churn_analysis": `name: churn_analysis
+title: Churn Analysis
+description: Analyze customer churn
+category: analytics
+`,
+		"churn_report": `name: churn_report
+title: Churn Report
+description: Customer report
+category: reporting
+`,
+	}
+	for name, content := range patterns {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644))
+	}
+	return NewOrchestrator(NewLibrary(nil, tmpDir))
+}
+
+func collectProgress(t *testing.T, events <-chan RecommendProgress) []RecommendProgress {
+	t.Helper()
+	var collected []RecommendProgress
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case p, ok := <-events:
+			if !ok {
+				return collected
+			}
+			collected = append(collected, p)
+		case <-timeout:
+			t.Fatal("stream did not close")
+		}
+	}
+}
+
+func stages(events []RecommendProgress) []RecommendStage {
+	result := make([]RecommendStage, len(events))
+	for i, p := range events {
+		result[i] = p.Stage
+	}
+	return result
+}
+
+func TestOrchestrator_RecommendPatternStream_FastPath(t *testing.T) {
+	orch := newStreamTestOrchestrator(t)
+
+	events, err := orch.RecommendPatternStream(context.Background(), "analyze customer churn", IntentAnalytics)
+	require.NoError(t, err)
+	collected := collectProgress(t, events)
+
+	require.Equal(t, []RecommendStage{StageScoringDone, StageFinal}, stages(collected))
+	assert.Equal(t, "churn_analysis", collected[0].Leader)
+	assert.Equal(t, 2, collected[0].Candidates)
+
+	final := collected[1]
+	require.NotNil(t, final.Recommendation)
+	name, confidence := orch.RecommendPattern("analyze customer churn", IntentAnalytics)
+	assert.Equal(t, name, final.Recommendation.PatternName)
+	assert.Equal(t, confidence, final.Confidence)
+	assert.GreaterOrEqual(t, final.Elapsed, collected[0].Elapsed)
+}
+
+func TestOrchestrator_RecommendPatternStream_ReRank(t *testing.T) {
+	orch := newStreamTestOrchestrator(t)
+	orch.SetReRanker(NewFunctionReRanker(func(_ string, summary PatternSummary, _ float64) float64 {
+		if summary.Name == "churn_report" {
+			return 1
+		}
+		return 0
+	}))
+
+	events, err := orch.RecommendPatternStream(context.Background(), "analyze customer churn", IntentUnknown)
+	require.NoError(t, err)
+	collected := collectProgress(t, events)
+
+	require.Equal(t, []RecommendStage{StageScoringDone, StageLLMInvoked, StageLLMResponded, StageFinal}, stages(collected))
+	assert.Equal(t, "churn_analysis", collected[0].Leader)
+	assert.Equal(t, "churn_analysis", collected[1].Leader)
+	assert.Equal(t, "churn_report", collected[2].Leader)
+	assert.NoError(t, collected[2].Err)
+	assert.Equal(t, "churn_report", collected[3].Recommendation.PatternName)
+}
+
+func TestOrchestrator_RecommendPatternStream_ReRankError(t *testing.T) {
+	orch := newStreamTestOrchestrator(t)
+	orch.SetReRanker(&failingReRanker{})
+
+	events, err := orch.RecommendPatternStream(context.Background(), "analyze customer churn", IntentUnknown)
+	require.NoError(t, err)
+	collected := collectProgress(t, events)
+
+	require.Len(t, collected, 4)
+	assert.Error(t, collected[2].Err)
+	assert.Equal(t, "churn_analysis", collected[2].Leader)
+	assert.Equal(t, ReasonKeywordFallbackAfterLLMError, collected[3].Recommendation.Reason)
+}
+
+func TestOrchestrator_RecommendPatternStream_NoMatch(t *testing.T) {
+	orch := newStreamTestOrchestrator(t)
+
+	events, err := orch.RecommendPatternStream(context.Background(), "zzzz", IntentUnknown)
+	require.NoError(t, err)
+	collected := collectProgress(t, events)
+
+	require.Equal(t, []RecommendStage{StageScoringDone, StageFinal}, stages(collected))
+	assert.Empty(t, collected[1].Leader)
+	assert.Empty(t, collected[1].Recommendation.PatternName)
+}
+
+func TestOrchestrator_RecommendPatternStream_Cancel(t *testing.T) {
+	orch := newStreamTestOrchestrator(t)
+	release := make(chan struct{})
+	defer close(release)
+	orch.SetReRanker(NewFunctionReRanker(func(_ string, _ PatternSummary, _ float64) float64 {
+		<-release
+		return 0.5
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := orch.RecommendPatternStream(ctx, "analyze customer churn", IntentUnknown)
+	require.NoError(t, err)
+
+	assert.Equal(t, StageScoringDone, (<-events).Stage)
+	assert.Equal(t, StageLLMInvoked, (<-events).Stage)
+
+	// The re-ranker is still blocked; cancelling must close the stream anyway
+	cancel()
+	assert.Empty(t, collectProgress(t, events))
+
+	_, err = orch.RecommendPatternStream(ctx, "analyze customer churn", IntentUnknown)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -14,6 +14,7 @@
 package patterns
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -30,8 +31,16 @@ type Pattern struct {
 	Difficulty  string `yaml:"difficulty" json:"difficulty"`     // "beginner", "intermediate", "advanced"
 	BackendType string `yaml:"backend_type" json:"backend_type"` // "sql", "rest", "document", etc.
 
+	// Specific backend the pattern runs on (e.g., "teradata", "postgres").
+	// Empty means backend-agnostic, matching shuttle.Tool.Backend().
+	Backend string `yaml:"backend,omitempty" json:"backend,omitempty"`
+
 	// Use cases and related patterns
-	UseCases        []string `yaml:"use_cases" json:"use_cases"`
+	UseCases []string `yaml:"use_cases" json:"use_cases"`
+
+	// Query terms that rule this pattern out (e.g., "real-time" for a batch forecasting
+	// pattern). Matching queries have their score suppressed, see Orchestrator.SetExcludeKeywords.
+	ExcludeKeywords []string `yaml:"exclude_keywords,omitempty" json:"exclude_keywords,omitempty"`
 	RelatedPatterns []string `yaml:"related_patterns,omitempty" json:"related_patterns,omitempty"`
 
 	// Pattern definition
@@ -211,7 +220,9 @@ type PatternSummary struct {
 	Category        string   `json:"category"`
 	Difficulty      string   `json:"difficulty"`
 	BackendType     string   `json:"backend_type"`
+	Backend         string   `json:"backend,omitempty"`
 	UseCases        []string `json:"use_cases"`
+	ExcludeKeywords []string `json:"exclude_keywords,omitempty"`
 	BackendFunction string   `json:"backend_function,omitempty"`
 }
 
@@ -238,6 +249,53 @@ const (
 	IntentUnknown           IntentCategory = "unknown"
 )
 
+// ConfidenceReason is a machine-actionable code explaining how a recommendation's
+// confidence was reached. UIs and policies can branch on it instead of parsing free text.
+type ConfidenceReason string
+
+const (
+	// ReasonClearKeywordWinner means keyword scoring produced an unambiguous winner.
+	ReasonClearKeywordWinner ConfidenceReason = "CLEAR_KEYWORD_WINNER"
+	// ReasonLLMResolvedCloseRace means the re-ranker chose among closely scored candidates.
+	ReasonLLMResolvedCloseRace ConfidenceReason = "LLM_RESOLVED_CLOSE_RACE"
+	// ReasonLLMResolvedUnknownIntent means the re-ranker chose because the intent was unknown.
+	ReasonLLMResolvedUnknownIntent ConfidenceReason = "LLM_RESOLVED_UNKNOWN_INTENT"
+	// ReasonLowConfidenceBestGuess means all candidates scored low, or the result was
+	// ambiguous and no re-ranker was available to resolve it.
+	ReasonLowConfidenceBestGuess ConfidenceReason = "LOW_CONFIDENCE_BEST_GUESS"
+	// ReasonKeywordFallbackAfterLLMError means re-ranking failed and the keyword winner was used.
+	ReasonKeywordFallbackAfterLLMError ConfidenceReason = "KEYWORD_FALLBACK_AFTER_LLM_ERROR"
+	// ReasonBelowMinConfidence means the best candidate was below the orchestrator's
+	// minimum confidence, so no pattern was recommended (see Orchestrator.SetMinConfidence).
+	ReasonBelowMinConfidence ConfidenceReason = "BELOW_MIN_CONFIDENCE"
+)
+
+// ErrNoConfidentMatch is returned by Recommendation.Err and RecommendPatternForBackend
+// when patterns matched but none reached the orchestrator's minimum confidence.
+var ErrNoConfidentMatch = errors.New("no pattern matched with enough confidence")
+
+// Recommendation is the result of a pattern recommendation.
+// PatternName is empty (and Reason unset) when no pattern matched, and empty with Reason
+// ReasonBelowMinConfidence when the best match was below the minimum confidence.
+type Recommendation struct {
+	PatternName string           `json:"pattern_name"`
+	Confidence  float64          `json:"confidence"`
+	Reason      ConfidenceReason `json:"reason,omitempty"`
+
+	// Explanation says why the pattern was chosen: the re-ranker's reasoning when
+	// re-ranking ran, otherwise a short summary of the keyword match.
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// Err returns ErrNoConfidentMatch if no pattern reached the minimum confidence, and nil
+// otherwise (including when nothing matched at all).
+func (r Recommendation) Err() error {
+	if r.Reason == ReasonBelowMinConfidence {
+		return ErrNoConfidentMatch
+	}
+	return nil
+}
+
 // ExecutionPlan represents a planned sequence of operations.
 // The orchestrator creates this plan based on classified intent.
 type ExecutionPlan struct {
@@ -260,6 +318,17 @@ type PlannedStep struct {
 // Backends can provide custom classifiers for domain-specific intent detection.
 type IntentClassifierFunc func(userMessage string, context map[string]interface{}) (IntentCategory, float64)
 
+// ScoredIntent is one intent returned by multi-intent classification.
+type ScoredIntent struct {
+	Intent     IntentCategory `json:"intent"`
+	Confidence float64        `json:"confidence"`
+}
+
+// MultiIntentClassifierFunc is a pluggable function for multi-intent classification.
+// It returns every plausible intent for the message, best first, so queries that span
+// two intents (e.g. "validate and then aggregate sales") are not forced into one.
+type MultiIntentClassifierFunc func(userMessage string, context map[string]interface{}) ([]ScoredIntent, error)
+
 // ExecutionPlannerFunc is a pluggable function for execution planning.
 // Backends can provide custom planners for domain-specific execution strategies.
 type ExecutionPlannerFunc func(intent IntentCategory, userMessage string, context map[string]interface{}) (*ExecutionPlan, error)
@@ -71,6 +71,8 @@ func validatePatternFile(t *testing.T, path string) {
 	}
 	if pattern.Category == "" {
 		t.Error("Pattern missing 'category' field")
+	} else if _, err := ParseCategory(pattern.Category); err != nil {
+		t.Errorf("Pattern has unregistered category: %v", err)
 	}
 	if pattern.Difficulty == "" {
 		t.Error("Pattern missing 'difficulty' field")
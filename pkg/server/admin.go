@@ -0,0 +1,75 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AdminServer is an optional HTTP listener for operational endpoints such as
+// Prometheus /metrics. It is separate from HTTPServer so it can bind to an internal
+// address and never carries the public API or CORS handling.
+type AdminServer struct {
+	mux        *http.ServeMux
+	httpServer *http.Server
+	logger     *zap.Logger
+}
+
+// NewAdminServer creates an admin server listening on addr (e.g. "127.0.0.1:9090").
+// Register endpoints with Handle before calling Start.
+func NewAdminServer(addr string, logger *zap.Logger) *AdminServer {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	mux := http.NewServeMux()
+	return &AdminServer{
+		mux:    mux,
+		logger: logger,
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+		},
+	}
+}
+
+// Handle registers a handler for the given pattern (see http.ServeMux).
+// Must be called before Start; not safe for concurrent use.
+func (a *AdminServer) Handle(pattern string, handler http.Handler) {
+	a.mux.Handle(pattern, handler)
+}
+
+// Handler returns the admin endpoints, for serving them on an existing listener.
+func (a *AdminServer) Handler() http.Handler {
+	return a.mux
+}
+
+// Addr returns the configured listen address.
+func (a *AdminServer) Addr() string {
+	return a.httpServer.Addr
+}
+
+// Start serves the admin endpoints until Stop is called.
+func (a *AdminServer) Start() error {
+	a.logger.Info("Starting admin HTTP server", zap.String("addr", a.httpServer.Addr))
+	if err := a.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("admin HTTP server failed: %w", err)
+	}
+	return nil
+}
+
+// Stop gracefully stops the admin server.
+func (a *AdminServer) Stop(ctx context.Context) error {
+	a.logger.Info("Stopping admin HTTP server")
+	return a.httpServer.Shutdown(ctx)
+}
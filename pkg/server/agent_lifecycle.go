@@ -209,6 +209,9 @@ func (s *MultiAgentServer) CreateAgentFromConfig(ctx context.Context, req *loomv
 	if agentConfig.SystemPrompt != "" {
 		opts = append(opts, agent.WithSystemPrompt(agentConfig.SystemPrompt))
 	}
+	if canSpawn := agent.SpawnAllowlist(agentConfig); len(canSpawn) > 0 {
+		opts = append(opts, agent.WithCanSpawn(canSpawn))
+	}
 
 	ag := agent.NewAgent(nil, nil, opts...)
 	ag.SetID(agentID)
@@ -526,6 +529,9 @@ func (s *MultiAgentServer) ReloadAgent(ctx context.Context, req *loomv1.ReloadAg
 			if newConfig.SystemPrompt != "" {
 				opts = append(opts, agent.WithSystemPrompt(newConfig.SystemPrompt))
 			}
+			if canSpawn := agent.SpawnAllowlist(newConfig); len(canSpawn) > 0 {
+				opts = append(opts, agent.WithCanSpawn(canSpawn))
+			}
 
 			newAgent := agent.NewAgent(nil, nil, opts...)
 			newAgent.SetID(resolvedID)
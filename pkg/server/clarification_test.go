@@ -508,7 +508,7 @@ func TestShutdown_GracefulCleanup(t *testing.T) {
 	server.pendingQuestionsMu.RUnlock()
 
 	// Call Shutdown
-	err := server.Shutdown(context.Background())
+	_, err := server.Shutdown(context.Background())
 	require.NoError(t, err)
 
 	// Verify all questions removed
@@ -545,7 +545,7 @@ func TestShutdown_NilChannels(t *testing.T) {
 	}
 
 	// Shutdown should not panic with nil channels
-	err := server.Shutdown(context.Background())
+	_, err := server.Shutdown(context.Background())
 	require.NoError(t, err)
 
 	// All questions should be removed
@@ -7,6 +7,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
 	"github.com/teradata-labs/loom/pkg/communication"
@@ -34,6 +35,13 @@ func (s *MultiAgentServer) ConfigureCommunication(
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// Lifecycle events come only from the server; agents and clients may not forge them
+	if bus != nil {
+		if err := bus.ReserveTopic(SpawnLifecycleTopic, spawnLifecycleSource); err != nil {
+			return fmt.Errorf("failed to reserve %s: %w", SpawnLifecycleTopic, err)
+		}
+	}
+
 	s.messageBus = bus
 	s.messageQueue = queue
 	s.sharedMemoryComm = sharedMem
@@ -107,6 +115,10 @@ func (s *MultiAgentServer) Publish(ctx context.Context, req *loomv1.PublishReque
 		return nil, status.Error(codes.InvalidArgument, "message.from_agent cannot be empty")
 	}
 
+	if s.messageBus.IsReservedTopic(req.Topic) {
+		return nil, status.Errorf(codes.PermissionDenied, "topic %s is reserved for server events", req.Topic)
+	}
+
 	// Set topic and timestamp if not set
 	if req.Message.Topic == "" {
 		req.Message.Topic = req.Topic
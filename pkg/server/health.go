@@ -0,0 +1,257 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/agent"
+)
+
+// Dependencies reported by Health.
+const (
+	HealthRegistry     = "registry"
+	HealthSessionStore = "session_store"
+	HealthMessageBus   = "message_bus"
+	HealthLLM          = "llm"
+)
+
+// Dependency statuses reported by Health.
+const (
+	HealthStatusOK            = "ok"
+	HealthStatusDown          = "down"
+	HealthStatusNotConfigured = "not_configured"
+)
+
+const (
+	defaultHealthLLMTTL = time.Minute      // How long an LLM ping result is reused
+	healthCheckTimeout  = 5 * time.Second  // Per-dependency check timeout
+	healthLLMTimeout    = 15 * time.Second // LLM ping timeout
+)
+
+// DependencyHealth is the result of checking one dependency.
+type DependencyHealth struct {
+	Status    string    `json:"status"`          // HealthStatusOK, HealthStatusDown, or HealthStatusNotConfigured
+	Critical  bool      `json:"critical"`        // Whether the server is unhealthy while this is down
+	Error     string    `json:"error,omitempty"` // Why the check failed
+	LatencyMs int64     `json:"latency_ms"`      // How long the check took
+	CheckedAt time.Time `json:"checked_at"`      // When the check ran (earlier than the report for cached results)
+}
+
+// HealthReport describes the server's dependencies and load.
+type HealthReport struct {
+	Healthy          bool                        `json:"healthy"`                      // No critical dependency is down
+	Ready            bool                        `json:"ready"`                        // Healthy, not shutting down, and below the global spawn limit
+	Dependencies     map[string]DependencyHealth `json:"dependencies"`                 // Keyed by HealthRegistry, HealthSessionStore, ...
+	ActiveAgents     int                         `json:"active_agents"`                // Agents served by the server
+	SpawnedAgents    int                         `json:"spawned_agents"`               // Spawned sub-agents currently tracked
+	MaxSpawnedAgents int                         `json:"max_spawned_agents,omitempty"` // Global spawn limit (0 = unlimited)
+	ShuttingDown     bool                        `json:"shutting_down,omitempty"`      // Shutdown has started
+	CheckedAt        time.Time                   `json:"checked_at"`
+}
+
+// LLMPinger is implemented by LLM providers that have a cheaper reachability check
+// than a Chat call (e.g. listing models). Health uses it when available.
+type LLMPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// llmHealthCheck caches the result of pinging the health-check LLM provider.
+type llmHealthCheck struct {
+	provider agent.LLMProvider
+	ttl      time.Duration
+
+	pingMu sync.Mutex // Serializes pings
+
+	mu         sync.Mutex // Guards last and refreshing
+	last       *DependencyHealth
+	refreshing bool
+}
+
+// SetHealthCheckLLM sets the LLM provider whose reachability Health reports. The provider
+// is pinged at most once per ttl (default: 1 minute): in between the last result is
+// reused, and once it is stale it is still returned while a background ping refreshes it,
+// so probes neither wait on nor turn into LLM traffic. Only the first Health call waits
+// for a ping. Providers implementing LLMPinger are pinged with it; others get a one-word
+// Chat with no tools. Passing nil removes the check.
+func (s *MultiAgentServer) SetHealthCheckLLM(provider agent.LLMProvider, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultHealthLLMTTL
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if provider == nil {
+		s.healthLLM = nil
+		return
+	}
+	s.healthLLM = &llmHealthCheck{provider: provider, ttl: ttl}
+}
+
+// Health checks the agent registry, session store, message bus, and health-check LLM
+// provider (see SetHealthCheckLLM) and reports agent counts. Dependencies that are not
+// configured are reported as such and do not make the server unhealthy.
+func (s *MultiAgentServer) Health(ctx context.Context) HealthReport {
+	s.mu.RLock()
+	registry := s.registry
+	bus := s.messageBus
+	llmCheck := s.healthLLM
+	activeAgents := len(s.agents)
+	maxSpawned := s.maxSpawnedAgents
+	s.mu.RUnlock()
+
+	report := HealthReport{
+		Dependencies:     make(map[string]DependencyHealth, 4),
+		ActiveAgents:     activeAgents,
+		SpawnedAgents:    s.spawnedAgentCount(),
+		MaxSpawnedAgents: maxSpawned,
+		ShuttingDown:     s.ShuttingDown(),
+		CheckedAt:        time.Now(),
+	}
+
+	if registry == nil {
+		report.Dependencies[HealthRegistry] = notConfigured()
+	} else {
+		report.Dependencies[HealthRegistry] = checkDependency(ctx, healthCheckTimeout, registry.Ping)
+	}
+
+	if s.sessionStore == nil {
+		report.Dependencies[HealthSessionStore] = notConfigured()
+	} else {
+		report.Dependencies[HealthSessionStore] = checkDependency(ctx, healthCheckTimeout, s.sessionStore.Ping)
+	}
+
+	if bus == nil {
+		report.Dependencies[HealthMessageBus] = notConfigured()
+	} else {
+		report.Dependencies[HealthMessageBus] = checkDependency(ctx, healthCheckTimeout, func(context.Context) error {
+			if bus.IsClosed() {
+				return errMessageBusClosed
+			}
+			return nil
+		})
+	}
+
+	if llmCheck == nil {
+		report.Dependencies[HealthLLM] = notConfigured()
+	} else {
+		report.Dependencies[HealthLLM] = llmCheck.check(ctx)
+	}
+
+	report.Healthy = true
+	for _, dep := range report.Dependencies {
+		if dep.Critical && dep.Status == HealthStatusDown {
+			report.Healthy = false
+		}
+	}
+	report.Ready = report.Healthy && !report.ShuttingDown && (maxSpawned == 0 || report.SpawnedAgents < maxSpawned)
+	return report
+}
+
+// errMessageBusClosed is reported when the message bus has been shut down.
+var errMessageBusClosed = errors.New("message bus is closed")
+
+func notConfigured() DependencyHealth {
+	return DependencyHealth{Status: HealthStatusNotConfigured, CheckedAt: time.Now()}
+}
+
+// checkDependency runs a critical dependency check with a timeout.
+func checkDependency(ctx context.Context, timeout time.Duration, check func(context.Context) error) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dep := DependencyHealth{
+		Status:    HealthStatusOK,
+		Critical:  true,
+		LatencyMs: time.Since(start).Milliseconds(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		dep.Status = HealthStatusDown
+		dep.Error = err.Error()
+	}
+	return dep
+}
+
+// check returns the cached ping result, starting a background refresh when it is stale.
+// The ping is detached from ctx so a probe that gives up does not cache a failure.
+func (c *llmHealthCheck) check(ctx context.Context) DependencyHealth {
+	ctx = context.WithoutCancel(ctx)
+
+	c.mu.Lock()
+	last := c.last
+	if last != nil {
+		if time.Since(last.CheckedAt) >= c.ttl && !c.refreshing {
+			c.refreshing = true
+			go c.refresh(ctx)
+		}
+		c.mu.Unlock()
+		return *last
+	}
+	c.mu.Unlock()
+	return c.refresh(ctx)
+}
+
+// refresh pings the provider unless another caller refreshed the result meanwhile.
+func (c *llmHealthCheck) refresh(ctx context.Context) DependencyHealth {
+	c.pingMu.Lock()
+	defer c.pingMu.Unlock()
+
+	c.mu.Lock()
+	if c.last != nil && time.Since(c.last.CheckedAt) < c.ttl {
+		dep := *c.last
+		c.refreshing = false
+		c.mu.Unlock()
+		return dep
+	}
+	c.mu.Unlock()
+
+	dep := checkDependency(ctx, healthLLMTimeout, func(ctx context.Context) error {
+		if pinger, ok := c.provider.(LLMPinger); ok {
+			return pinger.Ping(ctx)
+		}
+		_, err := c.provider.Chat(ctx, []agent.Message{{Role: "user", Content: "ping"}}, nil)
+		return err
+	})
+
+	c.mu.Lock()
+	c.last = &dep
+	c.refreshing = false
+	c.mu.Unlock()
+	return dep
+}
+
+// LivenessHandler serves /healthz: 200 whenever the process is serving requests. It does
+// not check dependencies, so an outage elsewhere does not get the server restarted.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+}
+
+// ReadinessHandler serves /readyz: the Health report as JSON, with 200 when the server
+// is ready and 503 when a critical dependency is down, the global spawn limit is reached,
+// or the server is shutting down.
+func (s *MultiAgentServer) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := s.Health(r.Context())
+		code := http.StatusOK
+		if !report.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
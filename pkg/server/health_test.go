@@ -0,0 +1,194 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/agent"
+	"github.com/teradata-labs/loom/pkg/communication"
+	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+	"go.uber.org/zap"
+)
+
+// countingLLM counts Chat calls and fails them while err is set.
+type countingLLM struct {
+	mockLLMForMultiAgent
+	calls atomic.Int32
+	err   error
+}
+
+func (m *countingLLM) Chat(ctx context.Context, messages []llmtypes.Message, tools []shuttle.Tool) (*llmtypes.LLMResponse, error) {
+	m.calls.Add(1)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.mockLLMForMultiAgent.Chat(ctx, messages, tools)
+}
+
+// pingingLLM implements LLMPinger.
+type pingingLLM struct {
+	countingLLM
+	pings atomic.Int32
+}
+
+func (m *pingingLLM) Ping(ctx context.Context) error {
+	m.pings.Add(1)
+	return nil
+}
+
+func TestHealth_Dependencies(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nothing configured", func(t *testing.T) {
+		report := NewMultiAgentServer(nil, nil).Health(ctx)
+		assert.True(t, report.Healthy)
+		assert.True(t, report.Ready)
+		for _, name := range []string{HealthRegistry, HealthSessionStore, HealthMessageBus, HealthLLM} {
+			assert.Equal(t, HealthStatusNotConfigured, report.Dependencies[name].Status, name)
+		}
+	})
+
+	t.Run("session store and bus", func(t *testing.T) {
+		store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+		require.NoError(t, err)
+		s := NewMultiAgentServer(nil, store)
+		bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+		s.messageBus = bus
+
+		report := s.Health(ctx)
+		assert.True(t, report.Healthy)
+		assert.Equal(t, HealthStatusOK, report.Dependencies[HealthSessionStore].Status)
+		assert.Equal(t, HealthStatusOK, report.Dependencies[HealthMessageBus].Status)
+
+		require.NoError(t, bus.Close())
+		require.NoError(t, store.Close())
+		report = s.Health(ctx)
+		assert.False(t, report.Healthy)
+		assert.False(t, report.Ready)
+		assert.Equal(t, HealthStatusDown, report.Dependencies[HealthSessionStore].Status)
+		assert.NotEmpty(t, report.Dependencies[HealthSessionStore].Error)
+		assert.Equal(t, HealthStatusDown, report.Dependencies[HealthMessageBus].Status)
+	})
+}
+
+func TestHealth_SpawnLimit(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	s.SetMaxSpawnedAgents(1)
+	trackTestSpawn(s, "parent-1", "wf:analyst", "sess-a")
+
+	report := s.Health(context.Background())
+	assert.True(t, report.Healthy)
+	assert.False(t, report.Ready, "not ready at the global spawn limit")
+	assert.Equal(t, 1, report.SpawnedAgents)
+	assert.Equal(t, 1, report.MaxSpawnedAgents)
+
+	store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+	s.sessionStore = store
+	withEmptyRegistry(t, s)
+	_, err = s.SpawnSubAgent(context.Background(), &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-2", AgentID: "analyst"})
+	assert.ErrorIs(t, err, builtin.ErrSpawnLimitReached)
+	assert.ErrorContains(t, err, "global max: 1")
+
+	s.SetMaxSpawnedAgents(0)
+	assert.True(t, s.Health(context.Background()).Ready)
+}
+
+// withEmptyRegistry gives s an empty agent registry so spawns reach the limit checks.
+func withEmptyRegistry(t *testing.T, s *MultiAgentServer) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	registry, err := agent.NewRegistry(agent.RegistryConfig{
+		ConfigDir:   tmpDir,
+		DBPath:      tmpDir + "/registry.db",
+		LLMProvider: &mockLLMForMultiAgent{},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = registry.Close() })
+	s.SetAgentRegistry(registry)
+}
+
+func TestHealth_LLMCheckIsCached(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("chat ping", func(t *testing.T) {
+		s := NewMultiAgentServer(nil, nil)
+		llm := &countingLLM{}
+		s.SetHealthCheckLLM(llm, time.Hour)
+
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, HealthStatusOK, s.Health(ctx).Dependencies[HealthLLM].Status)
+		}
+		assert.Equal(t, int32(1), llm.calls.Load(), "LLM pinged once per TTL")
+	})
+
+	t.Run("pinger preferred", func(t *testing.T) {
+		s := NewMultiAgentServer(nil, nil)
+		llm := &pingingLLM{}
+		s.SetHealthCheckLLM(llm, time.Hour)
+
+		assert.Equal(t, HealthStatusOK, s.Health(ctx).Dependencies[HealthLLM].Status)
+		assert.Equal(t, int32(1), llm.pings.Load())
+		assert.Zero(t, llm.calls.Load())
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		s := NewMultiAgentServer(nil, nil)
+		s.SetHealthCheckLLM(&countingLLM{err: errors.New("connection refused")}, time.Hour)
+
+		report := s.Health(ctx)
+		assert.False(t, report.Healthy)
+		assert.Equal(t, HealthStatusDown, report.Dependencies[HealthLLM].Status)
+		assert.Equal(t, "connection refused", report.Dependencies[HealthLLM].Error)
+	})
+
+	t.Run("stale result refreshed in background", func(t *testing.T) {
+		s := NewMultiAgentServer(nil, nil)
+		llm := &countingLLM{}
+		s.SetHealthCheckLLM(llm, time.Millisecond)
+
+		s.Health(ctx)
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, HealthStatusOK, s.Health(ctx).Dependencies[HealthLLM].Status, "stale result is served")
+		assert.Eventually(t, func() bool { return llm.calls.Load() == 2 }, time.Second, time.Millisecond)
+	})
+}
+
+func TestHealthHandlers(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+
+	rec := httptest.NewRecorder()
+	LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	s.SetHealthCheckLLM(&countingLLM{err: errors.New("unauthorized")}, time.Hour)
+	rec = httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var report HealthReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.False(t, report.Ready)
+	assert.Equal(t, HealthStatusDown, report.Dependencies[HealthLLM].Status)
+}
@@ -119,6 +119,12 @@ func (h *HTTPServer) Start(ctx context.Context) error {
 		_, _ = w.Write([]byte(`{"status":"healthy"}`))
 	})
 
+	// Kubernetes liveness and readiness probes
+	rootMux.Handle("/healthz", LivenessHandler())
+	if h.grpcServer != nil {
+		rootMux.Handle("/readyz", h.grpcServer.ReadinessHandler())
+	}
+
 	// Swagger UI endpoint
 	rootMux.HandleFunc("/swagger-ui", h.handleSwaggerUI)
 	rootMux.HandleFunc("/swagger-ui/", h.handleSwaggerUI)
@@ -0,0 +1,143 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"errors"
+
+	"github.com/teradata-labs/loom/pkg/agent"
+	"github.com/teradata-labs/loom/pkg/metrics"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+// Reasons passed to cleanupSpawnedAgent by the server's own cleanup paths.
+// DespawnSubAgent passes the caller's reason, which defaults to cleanupReasonDespawned.
+const (
+	cleanupReasonSessionExpired = "session expired"
+	cleanupReasonParentCanceled = "parent context canceled"
+	cleanupReasonIdleTimeout    = "auto-despawn: inactivity timeout"
+	cleanupReasonDespawned      = "despawned by parent"
+	cleanupReasonParentEnded    = "parent session ended"
+	cleanupReasonShutdown       = "server shutting down"
+)
+
+// cleanupReasonLabels maps cleanup reasons to metric labels. Any other reason is a
+// caller-supplied despawn reason and is counted as "despawned".
+var cleanupReasonLabels = map[string]string{
+	cleanupReasonSessionExpired: "session_expired",
+	cleanupReasonParentCanceled: "parent_canceled",
+	cleanupReasonIdleTimeout:    "idle_timeout",
+	cleanupReasonDespawned:      "despawned",
+	cleanupReasonParentEnded:    "parent_ended",
+	cleanupReasonShutdown:       "server_shutdown",
+}
+
+func cleanupReasonLabel(reason string) string {
+	if label, ok := cleanupReasonLabels[reason]; ok {
+		return label
+	}
+	return "despawned"
+}
+
+// spawnErrorStatuses maps spawn errors to metric labels, checked in order.
+var spawnErrorStatuses = []struct {
+	err    error
+	status string
+}{
+	{builtin.ErrInvalidSpawnRequest, "invalid_request"},
+	{builtin.ErrServerShuttingDown, "shutting_down"},
+	{builtin.ErrRegistryUnavailable, "registry_unavailable"},
+	{builtin.ErrSpawnNotPermitted, "not_permitted"},
+	{builtin.ErrSpawnDepthExceeded, "depth_exceeded"},
+	{builtin.ErrSpawnLimitReached, "limit_reached"},
+	{builtin.ErrSpawnRateLimited, "rate_limited"},
+	{builtin.ErrAgentNotFound, "agent_not_found"},
+	{builtin.ErrAgentLoadFailed, "agent_load_failed"},
+	{builtin.ErrSessionStoreFailed, "session_store_failed"},
+	{builtin.ErrSessionIDConflict, "session_conflict"},
+}
+
+// spawnStatus returns the metric label for a SpawnSubAgent result: the response
+// status on success, otherwise the kind of error.
+func spawnStatus(resp *builtin.SpawnSubAgentResponse, err error) string {
+	if err == nil {
+		if resp == nil || resp.Status == "" {
+			return "spawned"
+		}
+		return resp.Status
+	}
+	for _, s := range spawnErrorStatuses {
+		if errors.Is(err, s.err) {
+			return s.status
+		}
+	}
+	return "error"
+}
+
+// SetMetrics registers the server with Prometheus collectors: spawns and spawned-agent
+// cleanups are counted as they happen, and spawned agents, sessions, message bus
+// throughput, and re-ranker cache hits are read on each scrape. LLM call latency is
+// recorded by the tracer returned from m.Tracer, which should wrap the tracer used by
+// the agent registry. Passing nil disables recording; metrics are off by default.
+func (s *MultiAgentServer) SetMetrics(m *metrics.Metrics) {
+	s.mu.Lock()
+	previous := s.metrics
+	s.metrics = m
+	s.mu.Unlock()
+
+	if previous != nil && previous != m {
+		previous.SetSource(nil)
+	}
+	m.SetSource(s.metricsSnapshot)
+}
+
+// serverMetrics returns the configured metrics, or nil (a no-op recorder).
+func (s *MultiAgentServer) serverMetrics() *metrics.Metrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.metrics
+}
+
+// metricsSnapshot reports the server state exported on each scrape.
+func (s *MultiAgentServer) metricsSnapshot() metrics.Snapshot {
+	var snap metrics.Snapshot
+
+	s.mu.RLock()
+	agents := make([]*agent.Agent, 0, len(s.agents))
+	for _, ag := range s.agents {
+		agents = append(agents, ag)
+	}
+	bus := s.messageBus
+	s.mu.RUnlock()
+
+	s.spawnedAgentsMu.RLock()
+	snap.SpawnedAgents = len(s.spawnedAgents)
+	for _, spawned := range s.spawnedAgents {
+		if spawned.agent != nil {
+			agents = append(agents, spawned.agent)
+		}
+	}
+	s.spawnedAgentsMu.RUnlock()
+
+	for _, ag := range agents {
+		if ag == nil {
+			continue
+		}
+		snap.Sessions += len(ag.ListSessions())
+		if orch := ag.GetOrchestrator(); orch != nil {
+			stats := orch.ReRankCacheStats()
+			snap.ReRankCache.Hits += stats.Hits
+			snap.ReRankCache.Misses += stats.Misses
+			snap.ReRankCache.Size += stats.Size
+		}
+	}
+
+	if bus != nil {
+		stats := bus.Stats()
+		snap.Bus = &stats
+	}
+	return snap
+}
@@ -0,0 +1,67 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/metrics"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+func TestSpawnStatus(t *testing.T) {
+	assert.Equal(t, "spawned", spawnStatus(&builtin.SpawnSubAgentResponse{Status: "spawned"}, nil))
+	assert.Equal(t, "spawned", spawnStatus(nil, nil))
+	assert.Equal(t, "limit_reached", spawnStatus(nil, fmt.Errorf("wrapped: %w", builtin.ErrSpawnLimitReached)))
+	assert.Equal(t, "agent_not_found", spawnStatus(nil, builtin.ErrAgentNotFound))
+	assert.Equal(t, "error", spawnStatus(nil, fmt.Errorf("something else")))
+}
+
+func TestCleanupReasonLabel(t *testing.T) {
+	assert.Equal(t, "idle_timeout", cleanupReasonLabel(cleanupReasonIdleTimeout))
+	assert.Equal(t, "session_expired", cleanupReasonLabel(cleanupReasonSessionExpired))
+	assert.Equal(t, "despawned", cleanupReasonLabel("task complete"), "caller-supplied reasons are bounded")
+}
+
+func TestMultiAgentServer_Metrics(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	m := metrics.New()
+	s.SetMetrics(m)
+
+	admin := NewAdminServer("127.0.0.1:0", nil)
+	admin.Handle("/metrics", m.Handler())
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		admin.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		require.Equal(t, 200, rec.Code)
+		return rec.Body.String()
+	}
+
+	ctx := context.Background()
+	_, err := s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1"})
+	require.ErrorIs(t, err, builtin.ErrInvalidSpawnRequest)
+
+	trackTestSpawn(s, "parent-1", "wf:analyst", "sess-a")
+	trackTestSpawn(s, "parent-1", "wf:writer", "sess-b")
+	out := scrape()
+	assert.Contains(t, out, `loom_spawns_total{status="invalid_request"} 1`)
+	assert.Contains(t, out, "loom_spawned_agents 2")
+	assert.Contains(t, out, "loom_active_sessions 0")
+
+	_, err = s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SessionID: "sess-a"})
+	require.NoError(t, err)
+	out = scrape()
+	assert.Contains(t, out, `loom_spawn_cleanups_total{reason="despawned"} 1`)
+	assert.Contains(t, out, "loom_spawned_agents 1")
+
+	s.SetMetrics(nil)
+	assert.NotContains(t, scrape(), "loom_spawned_agents", "detached server no longer reports state")
+}
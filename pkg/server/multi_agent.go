@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
@@ -22,6 +23,7 @@ import (
 	"github.com/teradata-labs/loom/pkg/llm/factory"
 	"github.com/teradata-labs/loom/pkg/mcp/manager"
 	"github.com/teradata-labs/loom/pkg/metaagent"
+	"github.com/teradata-labs/loom/pkg/metrics"
 	"github.com/teradata-labs/loom/pkg/observability"
 	"github.com/teradata-labs/loom/pkg/orchestration"
 	"github.com/teradata-labs/loom/pkg/patterns"
@@ -44,7 +46,7 @@ type MultiAgentServer struct {
 	loomv1.UnimplementedLoomServiceServer
 
 	agents       map[string]*agent.Agent
-	sessionStore *agent.SessionStore
+	sessionStore agent.SessionBackend
 	mu           sync.RWMutex
 
 	defaultAgentID     string                           // Agent to use when no agent_id specified
@@ -108,8 +110,47 @@ type MultiAgentServer struct {
 
 	// Spawned sub-agent tracking for lifecycle management
 	spawnedAgents   map[string]*spawnedAgentContext // sessionID → spawned agent context
+	pendingSpawns   map[string]*spawnedAgentContext // sessionID → spawn reserved but still being built (see reserveSpawn)
 	spawnedAgentsMu sync.RWMutex
 
+	// Spawned sub-agent auto-despawn defaults (see SetSpawnIdleTimeout)
+	spawnIdleTimeout  time.Duration // Inactivity before auto-despawn (0 = never)
+	spawnPollInterval time.Duration // How often spawned sessions are checked for inactivity
+
+	// Called once per spawned agent cleanup (see SetOnSpawnedAgentExit)
+	onSpawnedAgentExit func(SpawnedAgentExit)
+
+	// Pass spawn metadata and parent agent ID to spawned agents (see SetSpawnContextInjection)
+	spawnContextInjection bool
+
+	// Deepest allowed spawn chain (see SetMaxSpawnDepth)
+	maxSpawnDepth int
+
+	// Most spawned agents tracked at once across all parents (0 = unlimited; see SetMaxSpawnedAgents)
+	maxSpawnedAgents int
+
+	// Most spawned agents tracked at once per WorkflowID, and per-workflow overrides
+	// (0 = unlimited; see SetMaxSpawnsPerWorkflow and SetWorkflowSpawnLimit)
+	maxSpawnsPerWorkflow int
+	workflowSpawnLimits  map[string]int
+
+	// How long cleanup waits for a spawned agent's current turn (see SetSpawnDrainTimeout)
+	spawnDrainTimeout time.Duration
+
+	// Per-parent spawn token buckets (nil = unlimited; see SetSpawnRateLimit)
+	spawnRateLimiter *spawnRateLimiter
+
+	// Deny spawns by agents without a can_spawn allowlist (see SetRequireSpawnAllowlist)
+	requireSpawnAllowlist bool
+
+	// Prefix of generated session IDs ("" = DefaultSessionIDPrefix; see SetSessionIDPrefix)
+	sessionIDPrefix string
+
+	// Set once Shutdown starts; spawnGate is held for reading by in-flight spawns so
+	// Shutdown can wait for them before draining
+	shuttingDown atomic.Bool
+	spawnGate    sync.RWMutex
+
 	// LLM concurrency control to prevent rate limiting
 	llmSemaphore        chan struct{} // Semaphore to limit concurrent LLM calls
 	llmConcurrencyLimit int           // Max concurrent LLM calls (configurable)
@@ -122,6 +163,15 @@ type MultiAgentServer struct {
 
 	// UI App compiler for CreateUIApp/UpdateUIApp RPCs
 	appCompiler AppCompiler
+
+	// Custom per-session tool factories invoked when building an agent's tool set
+	toolFactories *builtin.ToolFactoryRegistry
+
+	// Prometheus collectors (nil disables recording; see SetMetrics)
+	metrics *metrics.Metrics
+
+	// LLM reachability check reported by Health (nil = not checked; see SetHealthCheckLLM)
+	healthLLM *llmHealthCheck
 }
 
 // workflowSubAgentContext tracks a running workflow sub-agent for message notifications
@@ -144,20 +194,41 @@ type workflowSubAgentContext struct {
 
 // spawnedAgentContext tracks a spawned sub-agent for lifecycle management
 type spawnedAgentContext struct {
-	parentSessionID    string             // Parent agent's session ID
-	parentAgentID      string             // Parent agent's ID
-	subAgentID         string             // Spawned agent's ID (may include workflow prefix)
-	subSessionID       string             // Spawned agent's session ID
-	workflowID         string             // Optional workflow namespace
-	agent              *agent.Agent       // Agent instance
-	spawnedAt          time.Time          // When the agent was spawned
-	subscriptions      []string           // Topics subscribed to (topic names)
-	subscriptionIDs    []string           // Subscription IDs for cleanup
-	notifyChannels     []chan struct{}    // Notification channels for event-driven processing
-	metadata           map[string]string  // Custom metadata
-	cancelFunc         context.CancelFunc // Cancel function for session cleanup
-	loopCancelFunc     context.CancelFunc // Cancel function for background loop
-	autoDespawnTimeout time.Duration      // Inactivity timeout before auto-despawn
+	parentSessionID     string             // Parent agent's session ID
+	parentAgentID       string             // Parent agent's ID
+	subAgentID          string             // Spawned agent's ID (may include workflow prefix)
+	subSessionID        string             // Spawned agent's session ID
+	workflowID          string             // Optional workflow namespace
+	agent               *agent.Agent       // Agent instance
+	spawnedAt           time.Time          // When the agent was spawned
+	subscriptions       []string           // Topics subscribed to (topic names)
+	subscriptionIDs     []string           // Subscription IDs for cleanup
+	notifyChannels      []chan struct{}    // Notification channels for event-driven processing
+	metadata            map[string]string  // Custom metadata
+	cancelFunc          context.CancelFunc // Cancel function for session cleanup
+	loopCancelFunc      context.CancelFunc // Cancel function for background loop
+	autoDespawnTimeout  time.Duration      // Inactivity timeout before auto-despawn (0 = never)
+	pollInterval        time.Duration      // How often the monitor checks for inactivity
+	lifecycleSuppressed bool               // Publishes no lifecycle events (spawned in reaction to one)
+
+	// Graceful shutdown: turnMu is held while the agent handles a message, and
+	// draining stops it from starting new ones
+	turnMu   sync.Mutex
+	draining atomic.Bool
+
+	// Tracing: span covers the agent's lifetime from spawn to cleanup and parents the
+	// spans of messages without their own trace (nil when tracing is off); spanMu guards
+	// annotating and ending it
+	tracer observability.Tracer
+	spanMu sync.Mutex
+	span   *observability.Span
+
+	// Direct messages from the parent (SendToSpawnedAgent), handled in order by a single
+	// goroutine on loopCtx while inboxActive is set
+	loopCtx     context.Context
+	inboxMu     sync.Mutex
+	inbox       []*loomv1.BusMessage
+	inboxActive bool
 }
 
 // NewMultiAgentServer creates a new multi-agent LoomService server.
@@ -191,9 +262,8 @@ func NewMultiAgentServer(agents map[string]*agent.Agent, store *agent.SessionSto
 	// Can be configured via SetLLMConcurrencyLimit()
 	defaultLLMConcurrency := 5
 
-	return &MultiAgentServer{
+	s := &MultiAgentServer{
 		agents:                            guidAgents,
-		sessionStore:                      store,
 		defaultAgentID:                    defaultID,
 		patternBroadcaster:                NewPatternEventBroadcaster(),
 		hotReloaders:                      make(map[string]*patterns.HotReloader),
@@ -209,11 +279,51 @@ func NewMultiAgentServer(agents map[string]*agent.Agent, store *agent.SessionSto
 		registry:                          nil,                                       // Set via SetAgentRegistry()
 		workflowSubAgents:                 make(map[string]*workflowSubAgentContext), // Initialize workflow sub-agent tracking
 		spawnedAgents:                     make(map[string]*spawnedAgentContext),     // Initialize spawned sub-agent tracking
+		pendingSpawns:                     make(map[string]*spawnedAgentContext),
+		spawnIdleTimeout:                  defaultSpawnIdleTimeout,
+		spawnPollInterval:                 defaultSpawnPollInterval,
+		maxSpawnDepth:                     defaultMaxSpawnDepth,
+		spawnDrainTimeout:                 defaultSpawnDrainTimeout,
 		llmConcurrencyLimit:               defaultLLMConcurrency,
 		llmSemaphore:                      make(chan struct{}, defaultLLMConcurrency),
 		agentStates:                       make(map[string]*agentState),
 		traceStoreLocal:                   newTraceStore(1 * time.Hour), // Eagerly initialize trace store for GetTrace RPC
 	}
+	if store != nil {
+		// Assigned only when set so a nil store stays a nil interface
+		s.sessionStore = store
+		store.RegisterExpiryHook(s.releaseExpiredSession)
+	}
+	return s
+}
+
+// sessionExpiryNotifier is a SessionBackend whose TTL expiry runs hooks, like
+// agent.SessionStore. Backends that expire sessions natively (Redis key TTLs) do not.
+type sessionExpiryNotifier interface {
+	RegisterExpiryHook(hook agent.SessionCleanupHook)
+}
+
+// SetSessionBackend replaces the store used for spawned-agent sessions, session lookup,
+// and deletion, e.g. with a Redis or Postgres backend shared by several server instances
+// so a spawned agent's session can be resumed on any of them. Agents keep persisting
+// their conversation memory to their own store.
+//
+// Only sessions are shared. Running spawned agents, and the spawn limits and
+// already_spawned checks on them, stay per instance: two instances can each run an
+// agent on the same session ID. An instance cleans up a spawned agent whose session was
+// deleted elsewhere or expired in the backend at its next inactivity poll.
+//
+// The server's expiry hook is registered on backends that support one, as for the store
+// passed to NewMultiAgentServer.
+func (s *MultiAgentServer) SetSessionBackend(backend agent.SessionBackend) {
+	s.mu.Lock()
+	previous := s.sessionStore
+	s.sessionStore = backend
+	s.mu.Unlock()
+
+	if notifier, ok := backend.(sessionExpiryNotifier); ok && backend != previous {
+		notifier.RegisterExpiryHook(s.releaseExpiredSession)
+	}
 }
 
 // ConfigureSharedMemory initializes shared memory for all agents with the given configuration.
@@ -267,6 +377,16 @@ func (s *MultiAgentServer) SetToolRegistry(registry *toolregistry.Registry) {
 	s.toolRegistry = registry
 }
 
+// SetToolFactoryRegistry injects custom tool factories.
+// Each registered factory is invoked when an agent session is set up (Weave, StreamWeave,
+// and spawned sub-agents), and its tool is registered unless the agent already has one
+// with that name.
+func (s *MultiAgentServer) SetToolFactoryRegistry(registry *builtin.ToolFactoryRegistry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toolFactories = registry
+}
+
 // SetAgentRegistry injects the agent registry for workflow execution.
 // This should be called after NewMultiAgentServer() to enable ExecuteWorkflow RPC.
 func (s *MultiAgentServer) SetAgentRegistry(registry *agent.Registry) {
@@ -351,6 +471,28 @@ func (s *MultiAgentServer) ConfigureTLS(manager *tls.Manager, config *loomv1.Ser
 	}
 }
 
+// SetSpawnIdleTimeout sets the default inactivity timeout after which spawned sub-agents
+// are despawned automatically. 0 disables auto-despawn. Spawn requests can override it
+// with SpawnSubAgentRequest.IdleTimeout. Applies to agents spawned after the call.
+func (s *MultiAgentServer) SetSpawnIdleTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if timeout < 0 {
+		timeout = 0
+	}
+	s.spawnIdleTimeout = timeout
+}
+
+// SetSpawnPollInterval sets how often spawned sub-agents are checked for inactivity.
+// Non-positive values are ignored.
+func (s *MultiAgentServer) SetSpawnPollInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if interval > 0 {
+		s.spawnPollInterval = interval
+	}
+}
+
 // SetClarificationConfig sets the clarification question timeout configuration.
 func (s *MultiAgentServer) SetClarificationConfig(channelSendTimeoutMs int) {
 	s.mu.Lock()
@@ -653,7 +795,9 @@ func (s *MultiAgentServer) Weave(ctx context.Context, req *loomv1.WeaveRequest)
 	// Get or create session
 	sessionID := req.SessionId
 	if sessionID == "" {
-		sessionID = GenerateSessionID()
+		if sessionID, err = s.newSessionID(ctx); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
 
 	// Add progress multiplexer to context if available for this agent
@@ -663,8 +807,8 @@ func (s *MultiAgentServer) Weave(ctx context.Context, req *loomv1.WeaveRequest)
 	}
 	s.mu.RUnlock()
 
-	// Register manage_ephemeral_agents tool if not already registered
-	// This allows agents to spawn and despawn sub-agents dynamically
+	// Register manage_ephemeral_agents and message_spawned_agent tools if not already registered
+	// This allows agents to spawn, message, and despawn sub-agents dynamically
 	toolNames := ag.ListTools()
 	hasManageTool := false
 	for _, name := range toolNames {
@@ -676,12 +820,14 @@ func (s *MultiAgentServer) Weave(ctx context.Context, req *loomv1.WeaveRequest)
 	if !hasManageTool {
 		manageTool := builtin.NewManageEphemeralAgentsTool(s, sessionID, agentID)
 		ag.RegisterTool(manageTool)
+		ag.RegisterTool(builtin.NewMessageSpawnedAgentTool(s, sessionID, agentID))
 		if s.logger != nil {
 			s.logger.Debug("Registered manage_ephemeral_agents tool for session",
 				zap.String("session_id", sessionID),
 				zap.String("agent_id", agentID))
 		}
 	}
+	s.registerFactoryTools(ag, agentID, sessionID)
 
 	// Spawn workflow sub-agents if this is a workflow coordinator
 	if err := s.spawnWorkflowSubAgents(ctx, ag, agentID, sessionID); err != nil {
@@ -775,11 +921,13 @@ func (s *MultiAgentServer) StreamWeave(req *loomv1.WeaveRequest, stream loomv1.L
 	// Generate session ID if not provided
 	sessionID := req.SessionId
 	if sessionID == "" {
-		sessionID = GenerateSessionID()
+		if sessionID, err = s.newSessionID(stream.Context()); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
 	}
 
-	// Register manage_ephemeral_agents tool if not already registered
-	// This allows agents to spawn and despawn sub-agents dynamically
+	// Register manage_ephemeral_agents and message_spawned_agent tools if not already registered
+	// This allows agents to spawn, message, and despawn sub-agents dynamically
 	toolNames := ag.ListTools()
 	hasManageTool := false
 	for _, name := range toolNames {
@@ -791,12 +939,14 @@ func (s *MultiAgentServer) StreamWeave(req *loomv1.WeaveRequest, stream loomv1.L
 	if !hasManageTool {
 		manageTool := builtin.NewManageEphemeralAgentsTool(s, sessionID, resolvedAgentID)
 		ag.RegisterTool(manageTool)
+		ag.RegisterTool(builtin.NewMessageSpawnedAgentTool(s, sessionID, resolvedAgentID))
 		if s.logger != nil {
 			s.logger.Debug("Registered manage_ephemeral_agents tool for streaming session",
 				zap.String("session_id", sessionID),
 				zap.String("agent_id", resolvedAgentID))
 		}
 	}
+	s.registerFactoryTools(ag, resolvedAgentID, sessionID)
 
 	// Spawn workflow sub-agents if this is a workflow coordinator
 	if err := s.spawnWorkflowSubAgents(stream.Context(), ag, resolvedAgentID, sessionID); err != nil {
@@ -1749,6 +1899,62 @@ done:
 	}
 }
 
+// stopWorkflowSubAgents cancels the goroutines of every workflow coordinator and
+// sub-agent, removes their bus subscriptions and notification channels, and stops
+// tracking them. Returns the number stopped and the number of subscriptions removed.
+func (s *MultiAgentServer) stopWorkflowSubAgents() (stopped, unsubscribed int) {
+	logger := s.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	s.workflowSubAgentsMu.Lock()
+	defer s.workflowSubAgentsMu.Unlock()
+
+	for agentID, subAgentCtx := range s.workflowSubAgents {
+		// Cancel MessageQueue goroutine
+		if subAgentCtx.cancelFunc != nil {
+			subAgentCtx.cancelFunc()
+		}
+
+		// Cancel Broadcast goroutine
+		if subAgentCtx.broadcastCancelFunc != nil {
+			subAgentCtx.broadcastCancelFunc()
+		}
+
+		// Unregister broadcast notification channels and drop the subscriptions
+		if s.messageBus != nil && len(subAgentCtx.subscriptionIDs) > 0 {
+			for _, subID := range subAgentCtx.subscriptionIDs {
+				s.messageBus.UnregisterNotificationChannel(subID)
+				if s.messageBus.IsClosed() {
+					continue
+				}
+				if err := s.messageBus.Unsubscribe(context.Background(), subID); err != nil {
+					logger.Debug("Workflow agent subscription already removed",
+						zap.String("agent", agentID),
+						zap.String("subscription_id", subID),
+						zap.Error(err))
+					continue
+				}
+				unsubscribed++
+			}
+		}
+
+		// Unregister from message queue
+		if s.messageQueue != nil {
+			s.messageQueue.UnregisterNotificationChannel(agentID)
+		}
+
+		logger.Debug("Cleaned up workflow agent on shutdown",
+			zap.String("agent", agentID))
+		stopped++
+	}
+
+	// Clear the map
+	s.workflowSubAgents = make(map[string]*workflowSubAgentContext)
+	return stopped, unsubscribed
+}
+
 // StartMessageQueueMonitor starts a background goroutine that monitors the message queue
 // and notifies workflow sub-agents when they have pending messages (event-driven, not polling).
 func (s *MultiAgentServer) StartMessageQueueMonitor(ctx context.Context) {
@@ -1764,40 +1970,7 @@ func (s *MultiAgentServer) StartMessageQueueMonitor(ctx context.Context) {
 		defer ticker.Stop()
 
 		// Cleanup all coordinators and sub-agents when monitor stops
-		defer func() {
-			s.workflowSubAgentsMu.Lock()
-			defer s.workflowSubAgentsMu.Unlock()
-
-			for agentID, subAgentCtx := range s.workflowSubAgents {
-				// Cancel MessageQueue goroutine
-				if subAgentCtx.cancelFunc != nil {
-					subAgentCtx.cancelFunc()
-				}
-
-				// Cancel Broadcast goroutine
-				if subAgentCtx.broadcastCancelFunc != nil {
-					subAgentCtx.broadcastCancelFunc()
-				}
-
-				// Unregister broadcast notification channels
-				if s.messageBus != nil && len(subAgentCtx.subscriptionIDs) > 0 {
-					for _, subID := range subAgentCtx.subscriptionIDs {
-						s.messageBus.UnregisterNotificationChannel(subID)
-					}
-				}
-
-				// Unregister from message queue
-				if s.messageQueue != nil {
-					s.messageQueue.UnregisterNotificationChannel(agentID)
-				}
-
-				s.logger.Debug("Cleaned up workflow agent on shutdown",
-					zap.String("agent", agentID))
-			}
-
-			// Clear the map
-			s.workflowSubAgents = make(map[string]*workflowSubAgentContext)
-		}()
+		defer s.stopWorkflowSubAgents()
 
 		for {
 			select {
@@ -2486,7 +2659,10 @@ func (s *MultiAgentServer) CreateSession(ctx context.Context, req *loomv1.Create
 		return nil, err
 	}
 
-	sessionID := GenerateSessionID()
+	sessionID, err := s.newSessionID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
 	// Create session without sending a message to the LLM
 	session := ag.CreateSession(sessionID)
@@ -2540,8 +2716,6 @@ func (s *MultiAgentServer) DeleteSession(ctx context.Context, req *loomv1.Delete
 
 	// Try to delete the session from any agent that has it
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	found := false
 	for _, ag := range s.agents {
 		if _, ok := ag.GetSession(req.SessionId); ok {
@@ -2550,9 +2724,12 @@ func (s *MultiAgentServer) DeleteSession(ctx context.Context, req *loomv1.Delete
 			break
 		}
 	}
+	s.mu.Unlock()
 
 	// Cleanup any spawned sub-agents before deleting parent session
+	// (outside s.mu: cleanup reads the drain timeout under it)
 	s.cleanupSpawnedAgentsByParent(req.SessionId)
+	s.unbindFactoryTools(req.SessionId)
 
 	// Also delete from persistent store
 	if s.sessionStore != nil {
@@ -2573,6 +2750,24 @@ func (s *MultiAgentServer) DeleteSession(ctx context.Context, req *loomv1.Delete
 	}, nil
 }
 
+// releaseExpiredSession is the session store expiry hook: before an expired session is
+// deleted it is dropped from the agents' in-memory sessions, its spawned children are
+// cleaned up, and so is the session itself if it belongs to a spawned agent.
+func (s *MultiAgentServer) releaseExpiredSession(_ context.Context, sessionID string) {
+	s.mu.RLock()
+	for _, ag := range s.agents {
+		if _, ok := ag.GetSession(sessionID); ok {
+			ag.DeleteSession(sessionID)
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	s.cleanupSpawnedAgentsByParent(sessionID)
+	s.cleanupSpawnedAgent(sessionID, cleanupReasonSessionExpired)
+	s.unbindFactoryTools(sessionID)
+}
+
 // GetConversationHistory retrieves conversation history.
 func (s *MultiAgentServer) GetConversationHistory(ctx context.Context, req *loomv1.GetConversationHistoryRequest) (*loomv1.ConversationHistory, error) {
 	if req.SessionId == "" {
@@ -2930,33 +3125,6 @@ func (s *MultiAgentServer) RegisterServerProgressListener(agentName string, logg
 	}
 }
 
-// Shutdown gracefully shuts down the server, closing all pending question channels.
-// This should be called during server shutdown to notify waiting agents that no more
-// answers will be received.
-func (s *MultiAgentServer) Shutdown(ctx context.Context) error {
-	s.pendingQuestionsMu.Lock()
-	defer s.pendingQuestionsMu.Unlock()
-
-	if s.logger != nil {
-		s.logger.Info("Shutting down server, closing pending clarification questions",
-			zap.Int("pending_count", len(s.pendingQuestions)))
-	}
-
-	// Close all pending question channels to notify waiting agents
-	for id, question := range s.pendingQuestions {
-		if question.AnswerChan != nil {
-			close(question.AnswerChan)
-			if s.logger != nil {
-				s.logger.Debug("Closed pending question channel",
-					zap.String("question_id", id))
-			}
-		}
-		delete(s.pendingQuestions, id)
-	}
-
-	return nil
-}
-
 // ExecuteWorkflow executes a workflow pattern loaded from YAML or programmatically defined.
 // This RPC enables automatic execution of multi-agent workflows.
 func (s *MultiAgentServer) ExecuteWorkflow(ctx context.Context, req *loomv1.ExecuteWorkflowRequest) (*loomv1.ExecuteWorkflowResponse, error) {
@@ -3795,3 +3963,85 @@ func (s *MultiAgentServer) SubscribeToSession(req *loomv1.SubscribeToSessionRequ
 		}
 	}
 }
+
+// registerFactoryTools builds tools from the registered tool factories for an agent
+// session and binds them to that session. Each factory tool name is registered on the
+// agent once as a sessionFactoryTool, which routes calls to the calling session's
+// instance; tools the agent already has from elsewhere are left alone.
+// Factory errors are logged and do not block the session.
+func (s *MultiAgentServer) registerFactoryTools(ag *agent.Agent, agentID, sessionID string) {
+	s.mu.RLock()
+	factories := s.toolFactories
+	logger := s.logger
+	s.mu.RUnlock()
+	if factories == nil {
+		return
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	skip := make(map[string]bool)
+	for _, name := range ag.ListTools() {
+		if _, ok := sessionFactoryToolOf(ag, name); !ok {
+			skip[name] = true
+		}
+	}
+
+	tools, errs := factories.Build(builtin.ToolFactoryContext{
+		AgentID:   agentID,
+		SessionID: sessionID,
+		Handler:   s,
+	}, skip)
+	for _, err := range errs {
+		logger.Warn("Failed to build tool from factory",
+			zap.String("session_id", sessionID),
+			zap.String("agent_id", agentID),
+			zap.Error(err))
+	}
+	for _, tool := range tools {
+		if skip[tool.Name()] {
+			continue
+		}
+		dispatcher, ok := sessionFactoryToolOf(ag, tool.Name())
+		if !ok {
+			dispatcher = newSessionFactoryTool(tool)
+			ag.RegisterTool(dispatcher)
+		}
+		dispatcher.bind(sessionID, tool)
+		skip[tool.Name()] = true
+		logger.Debug("Bound factory tool to session",
+			zap.String("tool", tool.Name()),
+			zap.String("session_id", sessionID),
+			zap.String("agent_id", agentID))
+	}
+}
+
+// unbindFactoryTools drops the factory tool instances bound to sessionID on every agent.
+func (s *MultiAgentServer) unbindFactoryTools(sessionID string) {
+	s.mu.RLock()
+	agents := make([]*agent.Agent, 0, len(s.agents))
+	for _, ag := range s.agents {
+		agents = append(agents, ag)
+	}
+	s.mu.RUnlock()
+
+	for _, ag := range agents {
+		for _, name := range ag.ListTools() {
+			if dispatcher, ok := sessionFactoryToolOf(ag, name); ok {
+				dispatcher.unbind(sessionID)
+			}
+		}
+	}
+}
+
+// sessionFactoryToolOf returns the agent's tool with the given name if it is a
+// session-dispatching factory tool.
+func sessionFactoryToolOf(ag *agent.Agent, name string) (*sessionFactoryTool, bool) {
+	tool, ok := ag.GetTool(name)
+	if !ok {
+		return nil, false
+	}
+	dispatcher, ok := tool.(*sessionFactoryTool)
+	return dispatcher, ok
+}
@@ -18,7 +18,9 @@ import (
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
 	"github.com/teradata-labs/loom/pkg/agent"
 	llmtypes "github.com/teradata-labs/loom/pkg/llm/types"
+	"github.com/teradata-labs/loom/pkg/session"
 	"github.com/teradata-labs/loom/pkg/shuttle"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
 	"github.com/teradata-labs/loom/pkg/storage"
 )
 
@@ -738,3 +740,60 @@ func TestUpdateAgent_ThreadSafety(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestMultiAgentServer_RegisterFactoryTools(t *testing.T) {
+	ag := agent.NewAgent(&mockBackend{}, &mockLLMForMultiAgent{})
+	server := NewMultiAgentServer(map[string]*agent.Agent{"agent1": ag}, nil)
+
+	factories := builtin.NewToolFactoryRegistry()
+	var sessions []string
+	require.NoError(t, factories.Register("ticket_lookup", func(fctx builtin.ToolFactoryContext) (shuttle.Tool, error) {
+		sessions = append(sessions, fctx.SessionID)
+		assert.NotNil(t, fctx.Handler)
+		boundSession := fctx.SessionID
+		return &shuttle.MockTool{
+			MockName: "ticket_lookup",
+			MockExecute: func(ctx context.Context, params map[string]interface{}) (*shuttle.Result, error) {
+				return &shuttle.Result{Success: true, Data: boundSession}, nil
+			},
+		}, nil
+	}))
+	server.SetToolFactoryRegistry(factories)
+
+	server.registerFactoryTools(ag, "agent1", "sess-1")
+	assert.Contains(t, ag.ListTools(), "ticket_lookup")
+
+	// A second session for the same agent gets its own binding
+	server.registerFactoryTools(ag, "agent1", "sess-2")
+	assert.Equal(t, []string{"sess-1", "sess-2"}, sessions)
+	assert.Equal(t, 1, countTools(ag.ListTools(), "ticket_lookup"))
+
+	tool, ok := ag.GetTool("ticket_lookup")
+	require.True(t, ok)
+	for _, sessionID := range []string{"sess-1", "sess-2"} {
+		result, err := tool.Execute(session.WithSessionID(context.Background(), sessionID), nil)
+		require.NoError(t, err)
+		require.True(t, result.Success)
+		assert.Equal(t, sessionID, result.Data)
+	}
+
+	// Unknown sessions and deleted sessions have no binding
+	result, err := tool.Execute(session.WithSessionID(context.Background(), "sess-3"), nil)
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+
+	server.unbindFactoryTools("sess-1")
+	result, err = tool.Execute(session.WithSessionID(context.Background(), "sess-1"), nil)
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+}
+
+func countTools(names []string, name string) int {
+	n := 0
+	for _, candidate := range names {
+		if candidate == name {
+			n++
+		}
+	}
+	return n
+}
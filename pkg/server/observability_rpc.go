@@ -407,7 +407,7 @@ func (s *MultiAgentServer) RequestToolPermission(ctx context.Context, req *loomv
 			s.logger.Info("RequestToolPermission received answer",
 				zap.String("permission_id", permID),
 				zap.Bool("granted", answer.Granted),
-				zap.String("message", answer.Message))
+				zap.String("message", observability.Redact(answer.Message)))
 		}
 
 		return &loomv1.ToolPermissionResponse{
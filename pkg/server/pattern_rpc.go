@@ -221,7 +221,15 @@ func (s *MultiAgentServer) ListPatterns(ctx context.Context, req *loomv1.ListPat
 		// Apply filtering strategy based on request fields
 		switch {
 		case req.Search != "":
-			summaries = lib.Search(req.Search)
+			var err error
+			summaries, err = lib.FullTextSearch(req.Search, 0)
+			if err != nil {
+				if s.logger != nil {
+					s.logger.Warn("Full-text pattern search failed, using keyword search",
+						zap.Error(err))
+				}
+				summaries = lib.Search(req.Search)
+			}
 		case req.Category != "":
 			summaries = lib.FilterByCategory(req.Category)
 		default:
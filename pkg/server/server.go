@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -911,7 +912,40 @@ func convertRateLimit(rateLimit *metadata.RateLimit) *loomv1.RateLimitInfo {
 	}
 }
 
-// GenerateSessionID generates a new session ID.
+// DefaultSessionIDPrefix is the prefix of IDs from GenerateSessionID.
+const DefaultSessionIDPrefix = "sess"
+
+// GenerateSessionID generates a new session ID of the form "sess_1a2b3c4d".
 func GenerateSessionID() string {
-	return fmt.Sprintf("sess_%s", uuid.New().String()[:8])
+	return GenerateSessionIDWithPrefix(DefaultSessionIDPrefix)
+}
+
+// GenerateSessionIDWithPrefix generates a session ID of the form "<prefix>_1a2b3c4d", so
+// IDs can carry an environment or agent name for grepping logs. Characters other than
+// letters, digits, '-', '_', and '.' in prefix are replaced with '-'; an empty prefix uses
+// DefaultSessionIDPrefix.
+//
+// The random part is 8 hex characters (32 bits) of a random UUID, so IDs are only
+// unique in practice: among n IDs with the same prefix, the chance of any collision is
+// about n²/2³³ (1% at roughly 9,000 IDs). Servers check new IDs against their session
+// store and regenerate on collision; other callers that persist IDs should do the same.
+func GenerateSessionIDWithPrefix(prefix string) string {
+	prefix = sanitizeSessionIDPrefix(prefix)
+	if prefix == "" {
+		prefix = DefaultSessionIDPrefix
+	}
+	return fmt.Sprintf("%s_%s", prefix, uuid.New().String()[:8])
+}
+
+// sanitizeSessionIDPrefix replaces characters that are unsafe in IDs, paths, and log
+// fields with '-'.
+func sanitizeSessionIDPrefix(prefix string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, strings.TrimSpace(prefix))
 }
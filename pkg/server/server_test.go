@@ -15,6 +15,7 @@ package server
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -224,6 +225,25 @@ func TestGenerateSessionID(t *testing.T) {
 	}
 }
 
+func TestGenerateSessionIDWithPrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"prod", "prod_"},
+		{"prod-sql_analyst.v2", "prod-sql_analyst.v2_"},
+		{"prod/sql analyst", "prod-sql-analyst_"},
+		{"  ", "sess_"},
+		{"", "sess_"},
+	}
+	for _, tt := range tests {
+		id := GenerateSessionIDWithPrefix(tt.prefix)
+		if !strings.HasPrefix(id, tt.want) || len(id) != len(tt.want)+8 {
+			t.Errorf("GenerateSessionIDWithPrefix(%q) = %q, want %s + 8 characters", tt.prefix, id, tt.want)
+		}
+	}
+}
+
 func TestConvertSessionWithNilFields(t *testing.T) {
 	// Test with zero values
 	session := &agent.Session{
@@ -0,0 +1,79 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/teradata-labs/loom/pkg/agent"
+	"go.uber.org/zap"
+)
+
+// maxSessionIDAttempts is how many IDs newSessionID generates before giving up.
+const maxSessionIDAttempts = 5
+
+// SetSessionIDPrefix sets the prefix of session IDs the server generates for new
+// sessions and spawned agents (see GenerateSessionIDWithPrefix), e.g. "prod" or
+// "prod-analyst" for IDs like "prod_1a2b3c4d". An empty prefix restores
+// DefaultSessionIDPrefix. IDs supplied by clients are used as given.
+func (s *MultiAgentServer) SetSessionIDPrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessionIDPrefix = sanitizeSessionIDPrefix(prefix)
+}
+
+// newSessionID generates a session ID with the configured prefix that no stored or
+// spawned session uses, regenerating on collision. Callers must not hold s.mu.
+func (s *MultiAgentServer) newSessionID(ctx context.Context) (string, error) {
+	s.mu.RLock()
+	prefix := s.sessionIDPrefix
+	store := s.sessionStore
+	logger := s.logger
+	s.mu.RUnlock()
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	for attempt := 0; attempt < maxSessionIDAttempts; attempt++ {
+		id := GenerateSessionIDWithPrefix(prefix)
+		inUse, err := s.sessionIDInUse(ctx, store, id)
+		if err != nil {
+			return "", err
+		}
+		if !inUse {
+			return id, nil
+		}
+		logger.Warn("Generated session ID is already in use, regenerating",
+			zap.String("session_id", id),
+			zap.Int("attempt", attempt+1))
+	}
+	return "", fmt.Errorf("no unused session ID after %d attempts", maxSessionIDAttempts)
+}
+
+// sessionIDInUse reports whether a spawned agent, a spawn in progress, or the session
+// store (if any) has id.
+func (s *MultiAgentServer) sessionIDInUse(ctx context.Context, store agent.SessionBackend, id string) (bool, error) {
+	s.spawnedAgentsMu.RLock()
+	_, tracked := s.spawnedAgents[id]
+	_, pending := s.pendingSpawns[id]
+	s.spawnedAgentsMu.RUnlock()
+	spawned := tracked || pending
+	if spawned || store == nil {
+		return spawned, nil
+	}
+
+	_, err := store.LoadSession(ctx, id)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, agent.ErrSessionNotFound):
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to check session ID %s: %w", id, err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/agent"
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+func TestNewSessionID(t *testing.T) {
+	store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := NewMultiAgentServer(nil, store)
+	ctx := context.Background()
+
+	id, err := s.newSessionID(ctx)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(id, "sess_"), id)
+
+	s.SetSessionIDPrefix("prod analyst")
+	id, err = s.newSessionID(ctx)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(id, "prod-analyst_"), id)
+
+	// IDs held by stored sessions and spawned agents are in use
+	now := time.Now()
+	require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "prod_stored01", AgentID: "a", CreatedAt: now, UpdatedAt: now}))
+	trackTestSpawn(s, "parent-1", "wf:a", "prod_spawned1")
+	for _, id := range []string{"prod_stored01", "prod_spawned1"} {
+		inUse, err := s.sessionIDInUse(ctx, s.sessionStore, id)
+		require.NoError(t, err)
+		assert.True(t, inUse, id)
+	}
+	inUse, err := s.sessionIDInUse(ctx, s.sessionStore, "prod_unused01")
+	require.NoError(t, err)
+	assert.False(t, inUse)
+
+	// Without a store only spawned agents are checked
+	inUse, err = s.sessionIDInUse(ctx, nil, "prod_stored01")
+	require.NoError(t, err)
+	assert.False(t, inUse)
+
+	require.NoError(t, store.Close())
+	_, err = s.newSessionID(ctx)
+	assert.ErrorContains(t, err, "failed to check session ID")
+}
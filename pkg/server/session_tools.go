@@ -0,0 +1,98 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/session"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// sessionFactoryTool is registered once per agent for each factory-built tool name and
+// routes every call to the instance the factory built for the calling session.
+// An agent serves many sessions from one tool registry, so registering the first
+// session's instance directly would bind every later session to it.
+type sessionFactoryTool struct {
+	name string
+
+	mu        sync.RWMutex
+	prototype shuttle.Tool
+	bySession map[string]shuttle.Tool
+}
+
+func newSessionFactoryTool(prototype shuttle.Tool) *sessionFactoryTool {
+	return &sessionFactoryTool{
+		name:      prototype.Name(),
+		prototype: prototype,
+		bySession: make(map[string]shuttle.Tool),
+	}
+}
+
+// bind sets the instance used for calls from sessionID.
+func (t *sessionFactoryTool) bind(sessionID string, tool shuttle.Tool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bySession[sessionID] = tool
+}
+
+// unbind drops the instance for sessionID.
+func (t *sessionFactoryTool) unbind(sessionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.bySession, sessionID)
+}
+
+func (t *sessionFactoryTool) forSession(sessionID string) (shuttle.Tool, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	tool, ok := t.bySession[sessionID]
+	return tool, ok
+}
+
+func (t *sessionFactoryTool) Name() string {
+	return t.name
+}
+
+func (t *sessionFactoryTool) Description() string {
+	return t.prototype.Description()
+}
+
+func (t *sessionFactoryTool) InputSchema() *shuttle.JSONSchema {
+	return t.prototype.InputSchema()
+}
+
+func (t *sessionFactoryTool) Backend() string {
+	return t.prototype.Backend()
+}
+
+// Timeout forwards the prototype's per-call limit so the executor applies it.
+func (t *sessionFactoryTool) Timeout() time.Duration {
+	if tt, ok := t.prototype.(shuttle.TimeoutTool); ok {
+		return tt.Timeout()
+	}
+	return 0
+}
+
+func (t *sessionFactoryTool) Execute(ctx context.Context, params map[string]interface{}) (*shuttle.Result, error) {
+	sessionID := session.SessionIDFromContext(ctx)
+	tool, ok := t.forSession(sessionID)
+	if !ok {
+		return &shuttle.Result{
+			Success: false,
+			Error: &shuttle.Error{
+				Code:    "SESSION_NOT_BOUND",
+				Message: fmt.Sprintf("tool %s is not available in session %q", t.name, sessionID),
+			},
+		}, nil
+	}
+	return tool.Execute(ctx, params)
+}
+
+var _ shuttle.TimeoutTool = (*sessionFactoryTool)(nil)
@@ -0,0 +1,213 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/patterns"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+	"go.uber.org/zap"
+)
+
+// ShutdownSummary reports what Shutdown stopped.
+type ShutdownSummary struct {
+	Drained                []string      // Session IDs of spawned agents that finished their turn
+	ForceCancelled         []string      // Session IDs of spawned agents cancelled mid-turn
+	WorkflowAgentsStopped  int           // Workflow coordinators and sub-agents stopped
+	Unsubscribed           int           // Workflow agent bus subscriptions removed (spawned agents' are included in cleanup)
+	PendingQuestionsClosed int           // Clarification questions that will not be answered
+	SinksClosed            int           // Pattern recommendation sinks flushed and closed
+	Duration               time.Duration // How long Shutdown took
+}
+
+// Shutdown stops the server in order: new spawns are rejected with
+// builtin.ErrServerShuttingDown (and Health reports not ready), in-flight spawns finish,
+// every spawned agent is drained and cleaned up (spawn trees in parallel, children before
+// the agent that spawned them), workflow agents are stopped
+// and unsubscribed from the message bus, pending clarification questions are closed,
+// recommendation sinks that implement io.Closer are closed (once per agent using them),
+// and the tracer is flushed.
+//
+// Spawned agents get the spawn drain timeout (see SetSpawnDrainTimeout) to finish their
+// turn, cut short by ctx's deadline; agents still mid-turn are then cancelled and listed
+// in ForceCancelled. Sessions are already persisted in the session store; spawned-agent
+// tracking is in memory only, so the exit hook (see SetOnSpawnedAgentExit) fires for each
+// agent with reason "server shutting down" for callers that need to record them.
+//
+// The message bus, session store, and registry are left open for their owners to close.
+// Errors flushing the tracer or closing sinks are joined in the returned error; the
+// summary is complete either way. Calling Shutdown again cleans up anything left.
+func (s *MultiAgentServer) Shutdown(ctx context.Context) (ShutdownSummary, error) {
+	start := time.Now()
+	logger := s.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	var summary ShutdownSummary
+
+	// Stop accepting spawns and wait for the ones already past the check
+	s.shuttingDown.Store(true)
+	s.spawnGate.Lock()
+	s.spawnGate.Unlock() //nolint:staticcheck // empty critical section waits for in-flight spawns
+
+	// Clean up from the roots of the spawn trees: each one takes its descendants with it
+	s.spawnedAgentsMu.RLock()
+	total := len(s.spawnedAgents)
+	var sessionIDs []string
+	for sessionID, spawned := range s.spawnedAgents {
+		if _, nested := s.spawnedAgents[spawned.parentSessionID]; !nested {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	s.spawnedAgentsMu.RUnlock()
+
+	drainTimeout := s.shutdownDrainTimeout(ctx)
+	logger.Info("Shutting down server",
+		zap.Int("spawned_agents", total),
+		zap.Duration("drain_timeout", drainTimeout))
+
+	// Drain in parallel so the slowest agent bounds the wait
+	// (agents cleaned up concurrently by another path are not reported)
+	var mu sync.Mutex
+	report := func(id string, drained bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if drained {
+			summary.Drained = append(summary.Drained, id)
+		} else {
+			summary.ForceCancelled = append(summary.ForceCancelled, id)
+		}
+	}
+	deadline := time.Now().Add(drainTimeout)
+	var wg sync.WaitGroup
+	for _, sessionID := range sessionIDs {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			s.cleanupSpawnTree(id, cleanupReasonShutdown, drainTimeout, deadline, report)
+		}(sessionID)
+	}
+	wg.Wait()
+	sort.Strings(summary.Drained)
+	sort.Strings(summary.ForceCancelled)
+
+	summary.WorkflowAgentsStopped, summary.Unsubscribed = s.stopWorkflowSubAgents()
+	summary.PendingQuestionsClosed = s.closePendingQuestions()
+
+	var errs []error
+	closed, err := s.closeRecommendationSinks()
+	summary.SinksClosed = closed
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if tracer := s.serverTracer(); tracer != nil {
+		if err := tracer.Flush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush tracer: %w", err))
+		}
+	}
+
+	summary.Duration = time.Since(start)
+	logger.Info("Server shutdown complete",
+		zap.Int("drained", len(summary.Drained)),
+		zap.Int("force_cancelled", len(summary.ForceCancelled)),
+		zap.Int("workflow_agents_stopped", summary.WorkflowAgentsStopped),
+		zap.Int("pending_questions_closed", summary.PendingQuestionsClosed),
+		zap.Duration("duration", summary.Duration))
+	return summary, errors.Join(errs...)
+}
+
+// ShuttingDown reports whether Shutdown has been called.
+func (s *MultiAgentServer) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// checkAcceptingSpawns rejects spawns once Shutdown has started. On success the caller
+// holds spawnGate for reading and must call the returned release when the spawn ends.
+func (s *MultiAgentServer) checkAcceptingSpawns() (release func(), err error) {
+	s.spawnGate.RLock()
+	if s.shuttingDown.Load() {
+		s.spawnGate.RUnlock()
+		return nil, builtin.ErrServerShuttingDown
+	}
+	return s.spawnGate.RUnlock, nil
+}
+
+// shutdownDrainTimeout is the spawn drain timeout, shortened to what is left before
+// ctx's deadline (0 when ctx is already done).
+func (s *MultiAgentServer) shutdownDrainTimeout(ctx context.Context) time.Duration {
+	s.mu.RLock()
+	timeout := s.spawnDrainTimeout
+	s.mu.RUnlock()
+
+	if ctx.Err() != nil {
+		return 0
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = max(remaining, 0)
+		}
+	}
+	return timeout
+}
+
+// closePendingQuestions closes every pending clarification question's answer channel so
+// waiting agents stop waiting. Returns the number closed.
+func (s *MultiAgentServer) closePendingQuestions() int {
+	s.pendingQuestionsMu.Lock()
+	defer s.pendingQuestionsMu.Unlock()
+
+	closed := 0
+	for id, question := range s.pendingQuestions {
+		if question.AnswerChan != nil {
+			close(question.AnswerChan)
+			closed++
+			if s.logger != nil {
+				s.logger.Debug("Closed pending question channel",
+					zap.String("question_id", id))
+			}
+		}
+		delete(s.pendingQuestions, id)
+	}
+	return closed
+}
+
+// closeRecommendationSinks closes the recommendation sink of each served agent's pattern
+// orchestrator that implements io.Closer, writing out buffered analytics events.
+func (s *MultiAgentServer) closeRecommendationSinks() (int, error) {
+	s.mu.RLock()
+	var orchestrators []*patterns.Orchestrator
+	for _, ag := range s.agents {
+		if ag == nil {
+			continue
+		}
+		if orch := ag.GetOrchestrator(); orch != nil {
+			orchestrators = append(orchestrators, orch)
+		}
+	}
+	s.mu.RUnlock()
+
+	closed := 0
+	var errs []error
+	for _, orch := range orchestrators {
+		closer, ok := orch.RecommendationSink().(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close recommendation sink: %w", err))
+			continue
+		}
+		closed++
+	}
+	return closed, errors.Join(errs...)
+}
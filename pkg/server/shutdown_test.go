@@ -0,0 +1,118 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/communication"
+	"github.com/teradata-labs/loom/pkg/metaagent"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+	"go.uber.org/zap"
+)
+
+func TestShutdown_DrainsSpawnedAgents(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	var mu sync.Mutex
+	reasons := make(map[string]string)
+	s.SetOnSpawnedAgentExit(func(exit SpawnedAgentExit) {
+		mu.Lock()
+		defer mu.Unlock()
+		reasons[exit.SessionID] = exit.Reason
+	})
+
+	idleCtx := trackTestSpawn(s, "parent-1", "wf:idle", "sess-idle")
+	busyCtx := trackTestSpawn(s, "parent-1", "wf:busy", "sess-busy")
+	finishingCtx := trackTestSpawn(s, "parent-2", "wf:finishing", "sess-finishing")
+
+	// sess-busy never finishes its turn; sess-finishing does shortly
+	s.spawnedAgents["sess-busy"].turnMu.Lock()
+	defer s.spawnedAgents["sess-busy"].turnMu.Unlock()
+	finishing := s.spawnedAgents["sess-finishing"]
+	finishing.turnMu.Lock()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		finishing.turnMu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	summary, err := s.Shutdown(ctx)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "ctx deadline bounds the drain, not the 30s spawn drain timeout")
+
+	assert.Equal(t, []string{"sess-finishing", "sess-idle"}, summary.Drained)
+	assert.Equal(t, []string{"sess-busy"}, summary.ForceCancelled)
+	assert.Zero(t, s.spawnedAgentCount())
+	for _, loopCtx := range []context.Context{idleCtx, busyCtx, finishingCtx} {
+		assert.Error(t, loopCtx.Err())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "server shutting down (drained)", reasons["sess-idle"])
+	assert.True(t, strings.HasPrefix(reasons["sess-busy"], "server shutting down (drain timed out"), reasons["sess-busy"])
+}
+
+func TestShutdown_RejectsSpawns(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	withEmptyRegistry(t, s)
+	assert.True(t, s.Health(context.Background()).Ready)
+
+	_, err := s.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.True(t, s.ShuttingDown())
+
+	_, err = s.SpawnSubAgent(context.Background(), &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst"})
+	assert.ErrorIs(t, err, builtin.ErrServerShuttingDown)
+	assert.Equal(t, "shutting_down", spawnStatus(nil, err))
+
+	report := s.Health(context.Background())
+	assert.True(t, report.ShuttingDown)
+	assert.False(t, report.Ready)
+}
+
+func TestShutdown_StopsWorkflowAgentsAndQuestions(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	s.logger = zap.NewNop()
+	bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+	defer bus.Close()
+	s.messageBus = bus
+
+	sub, err := bus.Subscribe(context.Background(), "coordinator", "workflow.events", nil, 10)
+	require.NoError(t, err)
+	workflowCtx, workflowCancel := context.WithCancel(context.Background())
+	s.workflowSubAgents["coord-session:coordinator"] = &workflowSubAgentContext{
+		cancelFunc:      workflowCancel,
+		subscriptionIDs: []string{sub.ID},
+	}
+
+	answers := make(chan string, 1)
+	s.pendingQuestions["q1"] = &metaagent.Question{ID: "q1", AnswerChan: answers}
+
+	// An expired ctx still stops everything; it only skips draining
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Zero(t, s.shutdownDrainTimeout(ctx))
+
+	summary, err := s.Shutdown(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.WorkflowAgentsStopped)
+	assert.Equal(t, 1, summary.Unsubscribed)
+	assert.Equal(t, 1, summary.PendingQuestionsClosed)
+
+	assert.Error(t, workflowCtx.Err())
+	assert.Empty(t, bus.GetSubscriptionsByAgent("coordinator"))
+	_, open := <-answers
+	assert.False(t, open)
+}
@@ -6,102 +6,215 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
 	"github.com/teradata-labs/loom/pkg/agent"
 	"github.com/teradata-labs/loom/pkg/metaagent"
+	"github.com/teradata-labs/loom/pkg/observability"
 	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
 	"go.uber.org/zap"
 )
 
+const (
+	defaultSpawnIdleTimeout  = 15 * time.Minute
+	defaultSpawnPollInterval = 5 * time.Second
+	defaultMaxSpawnDepth     = 3
+	defaultSpawnDrainTimeout = 30 * time.Second
+)
+
 // SpawnSubAgent spawns a new agent as a child of the current session.
 // This implements the builtin.SpawnHandler interface.
+// When a tracer is set, the spawned agent's lifetime is traced as a child span of ctx's
+// span; its message handling and LLM calls join that trace.
 func (s *MultiAgentServer) SpawnSubAgent(ctx context.Context, req *builtin.SpawnSubAgentRequest) (*builtin.SpawnSubAgentResponse, error) {
+	ctx, tracer, span := s.startSpawnSpan(ctx, req)
+	resp, err := s.spawnSubAgent(ctx, req, tracer, span)
+	s.finishSpawnSpan(tracer, span, resp, err)
+	s.serverMetrics().RecordSpawn(spawnStatus(resp, err))
+	return resp, err
+}
+
+// spawnSubAgent implements SpawnSubAgent. The spawned agent takes ownership of span
+// (which may be nil); it is left open for the caller otherwise.
+func (s *MultiAgentServer) spawnSubAgent(ctx context.Context, req *builtin.SpawnSubAgentRequest, tracer observability.Tracer, span *observability.Span) (*builtin.SpawnSubAgentResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("spawn request cannot be nil")
+		return nil, fmt.Errorf("%w: request cannot be nil", builtin.ErrInvalidSpawnRequest)
 	}
 
 	// Validate required fields
 	if req.ParentSessionID == "" {
-		return nil, fmt.Errorf("parent session ID is required")
+		return nil, fmt.Errorf("%w: parent session ID is required", builtin.ErrInvalidSpawnRequest)
 	}
 	if req.AgentID == "" {
-		return nil, fmt.Errorf("agent ID is required")
+		return nil, fmt.Errorf("%w: agent ID is required", builtin.ErrInvalidSpawnRequest)
+	}
+
+	release, err := s.checkAcceptingSpawns()
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
 	// Check registry is available
 	s.mu.RLock()
 	registry := s.registry
 	logger := s.logger
 	messageBus := s.messageBus
+	idleTimeout := s.spawnIdleTimeout
+	pollInterval := s.spawnPollInterval
+	injectSpawnContext := s.spawnContextInjection
+	maxDepth := s.maxSpawnDepth
+	maxSpawned := s.maxSpawnedAgents
+	maxPerWorkflow := s.workflowSpawnLimit(req.WorkflowID)
+	rateLimiter := s.spawnRateLimiter
+	requireAllowlist := s.requireSpawnAllowlist
 	s.mu.RUnlock()
 
 	if registry == nil {
-		return nil, fmt.Errorf("agent registry not configured")
+		return nil, builtin.ErrRegistryUnavailable
 	}
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 
+	// Check the parent may spawn this agent (prevent privilege escalation)
+	if err := s.checkSpawnPermitted(req, registry, requireAllowlist); err != nil {
+		return nil, err
+	}
+
+	// A repeated spawn with the same explicit session ID returns the running agent
+	var existingSession *agent.Session
+	if req.SessionID != "" {
+		resp, stored, err := s.resolveRequestedSession(ctx, req)
+		if err != nil || resp != nil {
+			return resp, err
+		}
+		existingSession = stored
+	}
+
+	// Depth of the new agent: 1 for a child of a top-level session
+	depth := s.spawnDepth(ctx, req.ParentSessionID, maxDepth)
+
 	logger.Info("Spawning sub-agent",
 		zap.String("parent_session", req.ParentSessionID),
 		zap.String("parent_agent", req.ParentAgentID),
 		zap.String("agent_id", req.AgentID),
-		zap.String("workflow_id", req.WorkflowID))
+		zap.String("workflow_id", req.WorkflowID),
+		zap.Int("spawn_depth", depth))
 
-	// Check spawn limits (prevent spawn bombs)
-	existingSpawns := s.countSpawnedAgentsByParent(req.ParentSessionID)
-	maxSpawnsPerParent := 10 // TODO: Make configurable
-	if existingSpawns >= maxSpawnsPerParent {
-		return nil, fmt.Errorf("spawn limit reached: parent has %d spawned agents (max: %d)", existingSpawns, maxSpawnsPerParent)
+	// Check spawn depth (prevent recursive delegation chains)
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: new agent would be at depth %d (max: %d)", builtin.ErrSpawnDepthExceeded, depth, maxDepth)
 	}
 
-	// Build full sub-agent ID with namespace (ALWAYS namespaced)
-	// If workflow_id provided, use it; otherwise auto-generate namespace from parent
-	namespace := req.WorkflowID
-	if namespace == "" {
-		// Auto-generate namespace: parent-agent-id + session-based suffix
-		namespace = fmt.Sprintf("%s-spawn", req.ParentAgentID)
+	// Check spawn rate (prevent spawn-despawn storms)
+	if rateLimiter != nil {
+		if ok, retryAfter := rateLimiter.allow(req.ParentSessionID); !ok {
+			return nil, &builtin.SpawnRateLimitError{ParentSessionID: req.ParentSessionID, RetryAfter: retryAfter}
+		}
 	}
-	subAgentID := fmt.Sprintf("%s:%s", namespace, req.AgentID)
+
+	// Build full sub-agent ID with namespace (ALWAYS namespaced)
+	namespace, subAgentID := spawnSubAgentID(req)
 
 	logger.Info("Building namespaced sub-agent ID",
 		zap.String("namespace", namespace),
 		zap.String("agent_id", req.AgentID),
 		zap.String("sub_agent_id", subAgentID))
 
+	sessionID := req.SessionID
+	if sessionID == "" {
+		if sessionID, err = s.newSessionID(ctx); err != nil {
+			return nil, fmt.Errorf("%w: %w", builtin.ErrSessionStoreFailed, err)
+		}
+	}
+
+	// Check spawn limits (prevent spawn bombs), reserving the spawn in the same step so
+	// concurrent spawns cannot all pass them
+	limits := spawnLimits{
+		perParent:   10, // TODO: Make configurable
+		perWorkflow: maxPerWorkflow,
+		global:      maxSpawned,
+	}
+	if resp, err := s.reserveSpawn(&spawnedAgentContext{
+		parentSessionID: req.ParentSessionID,
+		parentAgentID:   req.ParentAgentID,
+		subAgentID:      subAgentID,
+		subSessionID:    sessionID,
+		workflowID:      req.WorkflowID,
+	}, limits); err != nil || resp != nil {
+		return resp, err
+	}
+	reserved := true
+	defer func() {
+		if reserved {
+			s.releaseSpawnReservation(sessionID)
+		}
+	}()
+
 	// IMPORTANT: Load fresh agent instance for spawned agent (not from cache)
 	// This prevents concurrent Chat() calls on the same agent instance which can cause
 	// issues with shared state (memory, sessions, etc.)
-	if registry == nil {
-		return nil, fmt.Errorf("agent registry not configured")
-	}
-
 	ag, err := registry.GetAgent(ctx, req.AgentID)
+	if errors.Is(err, agent.ErrAgentNotFound) {
+		return nil, fmt.Errorf("%w: %s", builtin.ErrAgentNotFound, req.AgentID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to load agent %s: %w", req.AgentID, err)
+		return nil, fmt.Errorf("%w %s: %w", builtin.ErrAgentLoadFailed, req.AgentID, err)
 	}
 
 	logger.Debug("Loaded fresh agent instance for spawned agent",
 		zap.String("agent_id", req.AgentID),
 		zap.String("sub_agent_id", subAgentID))
 
-	// Create new session for sub-agent
-	sessionID := GenerateSessionID()
-	session := &agent.Session{
-		ID:              sessionID,
-		AgentID:         req.AgentID,
-		ParentSessionID: req.ParentSessionID, // Link to parent
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+	// Create new session for sub-agent, or reuse the stored one for a restarted spawn
+	session := existingSession
+	if session == nil {
+		session = &agent.Session{
+			ID:              sessionID,
+			AgentID:         req.AgentID,
+			ParentSessionID: req.ParentSessionID, // Link to parent
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+	} else {
+		session.UpdatedAt = time.Now()
+	}
+
+	// Keep the structured task on the session so it survives restarts and tools can read it
+	if req.InitialTask != nil {
+		if err := session.SetContext(initialTaskContextKey, req.InitialTask); err != nil {
+			return nil, fmt.Errorf("%w: invalid initial task: %w", builtin.ErrInvalidSpawnRequest, err)
+		}
+	}
+
+	// Let the spawned agent (and its tools) see who spawned it and why
+	var spawnMetadata map[string]string
+	if injectSpawnContext {
+		spawnMetadata = spawnContextMetadata(req.Metadata)
+		if err := session.SetContext(spawnParentAgentContextKey, req.ParentAgentID); err != nil {
+			return nil, fmt.Errorf("%w: invalid parent agent ID: %w", builtin.ErrInvalidSpawnRequest, err)
+		}
+		if len(spawnMetadata) > 0 {
+			if err := session.SetContext(spawnMetadataContextKey, spawnMetadata); err != nil {
+				return nil, fmt.Errorf("%w: invalid spawn metadata: %w", builtin.ErrInvalidSpawnRequest, err)
+			}
+		}
 	}
 
 	// Store session
 	if err := s.sessionStore.SaveSession(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+		return nil, fmt.Errorf("%w: %w", builtin.ErrSessionStoreFailed, err)
 	}
 
 	logger.Info("Created sub-agent session",
@@ -152,11 +265,17 @@ func (s *MultiAgentServer) SpawnSubAgent(ctx context.Context, req *builtin.Spawn
 		spawnCommCtx.WorkflowName = namespace
 	}
 
+	if injectSpawnContext {
+		spawnCommCtx.ParentAgentID = req.ParentAgentID
+		spawnCommCtx.SpawnMetadata = spawnMetadata
+	}
+
 	// For spawned agents, we don't know available agents upfront
 	// They'll discover via workflow or parent communication
 	// TODO: Could potentially query parent's workflow context here
 
 	ag.SetWorkflowCommunicationContext(spawnCommCtx)
+	s.registerFactoryTools(ag, subAgentID, sessionID)
 
 	logger.Info("Injected workflow communication context into spawned agent",
 		zap.String("sub_agent_id", subAgentID),
@@ -166,61 +285,90 @@ func (s *MultiAgentServer) SpawnSubAgent(ctx context.Context, req *builtin.Spawn
 	// Create contexts for lifecycle management
 	subCtx, cancel := context.WithCancel(context.Background())      // For session monitoring
 	loopCtx, loopCancel := context.WithCancel(context.Background()) // For background message loop
+	if span != nil {
+		span.SetAttribute(observability.AttrSessionID, sessionID)
+		span.SetAttribute(observability.AttrAgentID, subAgentID)
+		span.SetAttribute("spawn.depth", depth)
+		loopCtx = observability.ContextWithSpan(loopCtx, span)
+	}
 
-	// Determine auto-despawn timeout (default: 15 minutes of inactivity)
-	autoDespawnTimeout := 15 * time.Minute
-	if timeoutStr, ok := req.Metadata["auto_despawn_minutes"]; ok {
-		if minutes, err := time.ParseDuration(timeoutStr + "m"); err == nil {
-			autoDespawnTimeout = minutes
-		}
+	autoDespawnTimeout, pollInterval := spawnTimeouts(req, idleTimeout, pollInterval)
+	lifecycleSuppressed := s.suppressSpawnLifecycle(ctx, req.ParentSessionID)
+	if lifecycleSuppressed {
+		loopCtx = withSpawnLifecycleSuppressed(loopCtx)
 	}
 
 	// Track spawned agent
 	spawnedAgent := &spawnedAgentContext{
-		parentSessionID:    req.ParentSessionID,
-		parentAgentID:      req.ParentAgentID,
-		subAgentID:         subAgentID,
-		subSessionID:       sessionID,
-		workflowID:         req.WorkflowID,
-		agent:              ag,
-		spawnedAt:          time.Now(),
-		subscriptions:      subscribedTopics,
-		subscriptionIDs:    subscriptionIDs,
-		notifyChannels:     notifyChannels,
-		metadata:           req.Metadata,
-		cancelFunc:         cancel,
-		loopCancelFunc:     loopCancel,
-		autoDespawnTimeout: autoDespawnTimeout,
+		parentSessionID:     req.ParentSessionID,
+		parentAgentID:       req.ParentAgentID,
+		subAgentID:          subAgentID,
+		subSessionID:        sessionID,
+		workflowID:          req.WorkflowID,
+		agent:               ag,
+		spawnedAt:           time.Now(),
+		subscriptions:       subscribedTopics,
+		subscriptionIDs:     subscriptionIDs,
+		notifyChannels:      notifyChannels,
+		metadata:            req.Metadata,
+		cancelFunc:          cancel,
+		loopCancelFunc:      loopCancel,
+		loopCtx:             loopCtx,
+		autoDespawnTimeout:  autoDespawnTimeout,
+		pollInterval:        pollInterval,
+		lifecycleSuppressed: lifecycleSuppressed,
+		tracer:              tracer,
+		span:                span,
 	}
 
 	s.spawnedAgentsMu.Lock()
+	delete(s.pendingSpawns, sessionID)
 	s.spawnedAgents[sessionID] = spawnedAgent
 	s.spawnedAgentsMu.Unlock()
+	reserved = false
 
 	logger.Info("Spawned sub-agent tracked",
 		zap.String("session_id", sessionID),
 		zap.String("sub_agent_id", subAgentID),
 		zap.Int("subscribed_topics", len(subscribedTopics)))
-
-	// TODO: Send initial message if provided
-	// For now, parent must send initial message via send_message or publish
-	// The initial_message parameter is stored in metadata for future use
+	s.publishSpawnLifecycle(ctx, spawnedAgent, SpawnLifecycleSpawned, "")
+
+	// Deliver the initial message/task as the spawned agent's first message.
+	// It is handled on the same goroutine as the message loop so the agent never
+	// runs two Chat() calls concurrently on its session.
+	initialMsg, err := buildInitialSpawnMessage(req, subscribedTopics)
+	if initialMsg != nil {
+		initialMsg.Metadata = observability.InjectTraceContext(loopCtx, initialMsg.Metadata)
+	}
+	if err != nil {
+		logger.Warn("Failed to build initial message for spawned agent",
+			zap.String("sub_agent_id", subAgentID),
+			zap.Error(err))
+	}
 	if req.InitialMessage != "" {
 		if spawnedAgent.metadata == nil {
 			spawnedAgent.metadata = make(map[string]string)
 		}
 		spawnedAgent.metadata["initial_message"] = req.InitialMessage
-		logger.Info("Initial message stored in metadata (parent should send via send_message/publish)",
-			zap.String("session_id", sessionID),
-			zap.String("message_preview", truncateString(req.InitialMessage, 50)))
 	}
 
 	// Start background monitoring for sub-agent lifecycle
 	go s.monitorSpawnedAgent(subCtx, sessionID)
 
 	// Start background message processing loop (active agent)
-	if len(subscriptionIDs) > 0 {
-		go s.runSpawnedAgentLoop(loopCtx, spawnedAgent)
+	if initialMsg != nil || len(subscriptionIDs) > 0 {
+		go func() {
+			if initialMsg != nil {
+				logger.Info("Delivering initial message to spawned agent",
+					zap.String("sub_agent_id", subAgentID),
+					zap.Bool("structured_task", req.InitialTask != nil),
+					zap.String("message_preview", truncateString(observability.Redact(req.InitialMessage), 50)))
+				s.handleSpawnedAgentMessage(loopCtx, spawnedAgent, initialMsg)
+			}
+			if len(subscriptionIDs) > 0 {
+				s.runSpawnedAgentLoop(loopCtx, spawnedAgent)
+			}
+		}()
 		logger.Info("Started background message processing loop for spawned agent",
 			zap.String("sub_agent_id", subAgentID),
 			zap.Int("subscriptions", len(subscriptionIDs)))
@@ -242,6 +390,263 @@ func (s *MultiAgentServer) SpawnSubAgent(ctx context.Context, req *builtin.Spawn
 	return resp, nil
 }
 
+// spawnSubAgentID returns the namespace and namespaced sub-agent ID for a spawn request.
+// If workflow_id is provided it is the namespace; otherwise one is derived from the parent.
+func spawnSubAgentID(req *builtin.SpawnSubAgentRequest) (namespace, subAgentID string) {
+	namespace = req.WorkflowID
+	if namespace == "" {
+		// Auto-generate namespace: parent-agent-id + session-based suffix
+		namespace = fmt.Sprintf("%s-spawn", req.ParentAgentID)
+	}
+	return namespace, fmt.Sprintf("%s:%s", namespace, req.AgentID)
+}
+
+// resolveRequestedSession checks an explicit spawn session ID. It returns a response when
+// the same sub-agent is already running on the session, the stored session when it exists
+// for the same parent and agent but nothing is running on it (a restarted workflow), and
+// builtin.ErrSessionIDConflict when the ID belongs to another agent or parent.
+func (s *MultiAgentServer) resolveRequestedSession(ctx context.Context, req *builtin.SpawnSubAgentRequest) (*builtin.SpawnSubAgentResponse, *agent.Session, error) {
+	_, subAgentID := spawnSubAgentID(req)
+
+	s.spawnedAgentsMu.RLock()
+	resp, err := s.spawnedOnSessionLocked(req.SessionID, req.ParentSessionID, subAgentID)
+	s.spawnedAgentsMu.RUnlock()
+	if err != nil || resp != nil {
+		return resp, nil, err
+	}
+
+	if s.sessionStore == nil {
+		return nil, nil, nil
+	}
+	stored, err := s.sessionStore.LoadSession(ctx, req.SessionID)
+	if errors.Is(err, agent.ErrSessionNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", builtin.ErrSessionStoreFailed, err)
+	}
+	if stored.AgentID != req.AgentID || stored.ParentSessionID != req.ParentSessionID {
+		return nil, nil, fmt.Errorf("%w: %s is used by agent %s", builtin.ErrSessionIDConflict, req.SessionID, stored.AgentID)
+	}
+	return nil, stored, nil
+}
+
+// spawnedOnSessionLocked checks sessionID against the tracked and pending spawns. It
+// returns an already_spawned response when the same sub-agent of the same parent is
+// running on it, and builtin.ErrSessionIDConflict when another spawn holds it or it is
+// still being spawned. Callers must hold spawnedAgentsMu.
+func (s *MultiAgentServer) spawnedOnSessionLocked(sessionID, parentSessionID, subAgentID string) (*builtin.SpawnSubAgentResponse, error) {
+	if spawned, tracked := s.spawnedAgents[sessionID]; tracked {
+		if spawned.parentSessionID != parentSessionID || spawned.subAgentID != subAgentID {
+			return nil, fmt.Errorf("%w: %s is used by %s", builtin.ErrSessionIDConflict, sessionID, spawned.subAgentID)
+		}
+		return &builtin.SpawnSubAgentResponse{
+			SubAgentID:       spawned.subAgentID,
+			SessionID:        spawned.subSessionID,
+			Status:           "already_spawned",
+			SubscribedTopics: spawned.subscriptions,
+		}, nil
+	}
+	if pending, ok := s.pendingSpawns[sessionID]; ok {
+		return nil, fmt.Errorf("%w: %s is being spawned for %s", builtin.ErrSessionIDConflict, sessionID, pending.subAgentID)
+	}
+	return nil, nil
+}
+
+// spawnTimeouts resolves a spawn's inactivity timeout (request, then the legacy
+// auto_despawn_minutes metadata, then the server default) and poll interval.
+func spawnTimeouts(req *builtin.SpawnSubAgentRequest, defaultIdle, defaultPoll time.Duration) (idle, poll time.Duration) {
+	idle = defaultIdle
+	if req.IdleTimeout != nil {
+		idle = max(*req.IdleTimeout, 0)
+	} else if timeoutStr, ok := req.Metadata["auto_despawn_minutes"]; ok {
+		if minutes, err := time.ParseDuration(timeoutStr + "m"); err == nil {
+			idle = minutes
+		}
+	}
+
+	poll = defaultPoll
+	if req.PollInterval > 0 {
+		poll = req.PollInterval
+	}
+	if poll <= 0 {
+		poll = defaultSpawnPollInterval
+	}
+	return idle, poll
+}
+
+// SetMaxSpawnDepth sets how deep spawn chains may go: 1 allows only top-level sessions
+// to spawn, 2 also lets their children spawn, and so on. Values below 1 are treated as 1.
+// Default: 3.
+func (s *MultiAgentServer) SetMaxSpawnDepth(depth int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if depth < 1 {
+		depth = 1
+	}
+	s.maxSpawnDepth = depth
+}
+
+// SetMaxSpawnedAgents caps how many spawned agents the server tracks at once, across all
+// parents. Spawns beyond the cap fail with builtin.ErrSpawnLimitReached, and the server
+// reports itself not ready (see Health) while at the cap. 0 or less means unlimited.
+// Default: unlimited.
+func (s *MultiAgentServer) SetMaxSpawnedAgents(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit < 0 {
+		limit = 0
+	}
+	s.maxSpawnedAgents = limit
+}
+
+// SetMaxSpawnsPerWorkflow caps how many spawned agents the server tracks at once for each
+// WorkflowID, across all of the workflow's parent sessions; it applies alongside the
+// per-parent and global limits. Spawns beyond the cap fail with
+// builtin.ErrSpawnLimitReached naming the workflow. Spawns without a WorkflowID are not
+// counted. SetWorkflowSpawnLimit overrides the cap for individual workflows. 0 or less
+// means unlimited. Default: unlimited.
+func (s *MultiAgentServer) SetMaxSpawnsPerWorkflow(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxSpawnsPerWorkflow = max(limit, 0)
+}
+
+// SetWorkflowSpawnLimit sets the spawn cap for one workflow, overriding
+// SetMaxSpawnsPerWorkflow. A limit of 0 makes the workflow unlimited; a negative limit
+// removes the override.
+func (s *MultiAgentServer) SetWorkflowSpawnLimit(workflowID string, limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit < 0 {
+		delete(s.workflowSpawnLimits, workflowID)
+		return
+	}
+	if s.workflowSpawnLimits == nil {
+		s.workflowSpawnLimits = make(map[string]int)
+	}
+	s.workflowSpawnLimits[workflowID] = limit
+}
+
+// workflowSpawnLimit returns the spawn cap for a workflow (0 = unlimited). Callers hold s.mu.
+func (s *MultiAgentServer) workflowSpawnLimit(workflowID string) int {
+	if workflowID == "" {
+		return 0
+	}
+	if limit, ok := s.workflowSpawnLimits[workflowID]; ok {
+		return limit
+	}
+	return s.maxSpawnsPerWorkflow
+}
+
+// SetSpawnRateLimit limits how fast each parent session may spawn agents, so a parent
+// spawning and despawning in a loop cannot thrash sessions and the message bus. Each
+// parent may spawn burst agents at once and rate more per second after that; spawns
+// beyond the limit fail with a *builtin.SpawnRateLimitError saying how long to back off.
+// Burst values below 1 are treated as 1. A rate of 0 or less removes the limit.
+// Default: unlimited.
+func (s *MultiAgentServer) SetSpawnRateLimit(rate float64, burst int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rate <= 0 {
+		s.spawnRateLimiter = nil
+		return
+	}
+	s.spawnRateLimiter = newSpawnRateLimiter(rate, max(burst, 1))
+}
+
+// spawnDepth returns the depth an agent spawned by parentSessionID would have, walking
+// parent links through tracked spawned agents and, for untracked sessions, the session
+// store. The walk stops once the depth exceeds limit or a session repeats.
+func (s *MultiAgentServer) spawnDepth(ctx context.Context, parentSessionID string, limit int) int {
+	depth := 1
+	seen := make(map[string]bool)
+	for current := parentSessionID; current != "" && !seen[current] && depth <= limit; depth++ {
+		seen[current] = true
+
+		s.spawnedAgentsMu.RLock()
+		spawned, tracked := s.spawnedAgents[current]
+		s.spawnedAgentsMu.RUnlock()
+
+		var next string
+		if tracked {
+			next = spawned.parentSessionID
+		} else if s.sessionStore != nil {
+			if session, err := s.sessionStore.LoadSession(ctx, current); err == nil && session != nil {
+				next = session.ParentSessionID
+			}
+		}
+		if next == "" {
+			break
+		}
+		current = next
+	}
+	return depth
+}
+
+// spawnedAgentCount returns how many spawned agents are tracked.
+func (s *MultiAgentServer) spawnedAgentCount() int {
+	s.spawnedAgentsMu.RLock()
+	defer s.spawnedAgentsMu.RUnlock()
+	return len(s.spawnedAgents)
+}
+
+// spawnLimits are the quotas reserveSpawn enforces; zero disables a limit.
+type spawnLimits struct {
+	perParent   int // Spawned agents per parent session
+	perWorkflow int // Spawned agents per workflow, across parents
+	global      int // Spawned agents on the server
+}
+
+// reserveSpawn checks limits against the tracked spawned agents and the spawns still
+// being built and, if they allow it, reserves reservation.subSessionID as a pending
+// spawn, all under one lock. The spawn must then be tracked (moving it out of
+// pendingSpawns) or released with releaseSpawnReservation.
+//
+// A session ID that is already tracked or reserved is never reserved again: the same
+// sub-agent gets an already_spawned response, and anything else
+// builtin.ErrSessionIDConflict, so of concurrent spawns on one ID exactly one builds
+// an agent.
+func (s *MultiAgentServer) reserveSpawn(reservation *spawnedAgentContext, limits spawnLimits) (*builtin.SpawnSubAgentResponse, error) {
+	s.spawnedAgentsMu.Lock()
+	defer s.spawnedAgentsMu.Unlock()
+
+	if resp, err := s.spawnedOnSessionLocked(reservation.subSessionID, reservation.parentSessionID, reservation.subAgentID); err != nil || resp != nil {
+		return resp, err
+	}
+
+	var byParent, byWorkflow int
+	for _, agents := range []map[string]*spawnedAgentContext{s.spawnedAgents, s.pendingSpawns} {
+		for _, spawned := range agents {
+			if spawned.parentSessionID == reservation.parentSessionID {
+				byParent++
+			}
+			if spawned.workflowID == reservation.workflowID {
+				byWorkflow++
+			}
+		}
+	}
+	if limits.perParent > 0 && byParent >= limits.perParent {
+		return nil, fmt.Errorf("%w: parent has %d spawned agents (max: %d)", builtin.ErrSpawnLimitReached, byParent, limits.perParent)
+	}
+	if limits.perWorkflow > 0 && byWorkflow >= limits.perWorkflow {
+		return nil, fmt.Errorf("%w: workflow %s has %d spawned agents (max: %d)", builtin.ErrSpawnLimitReached, reservation.workflowID, byWorkflow, limits.perWorkflow)
+	}
+	if total := len(s.spawnedAgents) + len(s.pendingSpawns); limits.global > 0 && total >= limits.global {
+		return nil, fmt.Errorf("%w: server has %d spawned agents (global max: %d)", builtin.ErrSpawnLimitReached, total, limits.global)
+	}
+
+	s.pendingSpawns[reservation.subSessionID] = reservation
+	return nil, nil
+}
+
+// releaseSpawnReservation drops a pending spawn that failed before it was tracked.
+func (s *MultiAgentServer) releaseSpawnReservation(sessionID string) {
+	s.spawnedAgentsMu.Lock()
+	defer s.spawnedAgentsMu.Unlock()
+	delete(s.pendingSpawns, sessionID)
+}
+
 // countSpawnedAgentsByParent counts how many agents a parent has spawned
 func (s *MultiAgentServer) countSpawnedAgentsByParent(parentSessionID string) int {
 	s.spawnedAgentsMu.RLock()
@@ -256,28 +661,88 @@ func (s *MultiAgentServer) countSpawnedAgentsByParent(parentSessionID string) in
 	return count
 }
 
-// monitorSpawnedAgent monitors a spawned agent's lifecycle and cleans up when done
+// ListSpawnedAgents returns the sub-agents spawned by a parent session, oldest first.
+// The result is a copy; modifying it does not affect the server.
+// This implements the builtin.EphemeralAgentHandler interface.
+func (s *MultiAgentServer) ListSpawnedAgents(parentSessionID string) []builtin.SpawnedAgentInfo {
+	s.spawnedAgentsMu.RLock()
+	infos := make([]builtin.SpawnedAgentInfo, 0)
+	for _, spawned := range s.spawnedAgents {
+		if spawned.parentSessionID != parentSessionID {
+			continue
+		}
+		infos = append(infos, builtin.SpawnedAgentInfo{
+			SubAgentID:       spawned.subAgentID,
+			SessionID:        spawned.subSessionID,
+			WorkflowID:       spawned.workflowID,
+			SpawnedAt:        spawned.spawnedAt,
+			SubscribedTopics: append([]string(nil), spawned.subscriptions...),
+		})
+	}
+	s.spawnedAgentsMu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		if !infos[i].SpawnedAt.Equal(infos[j].SpawnedAt) {
+			return infos[i].SpawnedAt.Before(infos[j].SpawnedAt)
+		}
+		return infos[i].SessionID < infos[j].SessionID
+	})
+
+	// Session activity is read outside the lock since it may hit the database
+	if s.sessionStore != nil {
+		for i := range infos {
+			if session, err := s.sessionStore.LoadSession(context.Background(), infos[i].SessionID); err == nil && session != nil {
+				infos[i].LastActivity = session.UpdatedAt
+			}
+		}
+	}
+	return infos
+}
+
+// monitorSpawnedAgent monitors a spawned agent's lifecycle and cleans up when done.
+// The inactivity timeout and poll interval are read from the tracked spawnedAgentContext;
+// a zero timeout means the agent only ends when despawned or its parent ends.
 func (s *MultiAgentServer) monitorSpawnedAgent(ctx context.Context, sessionID string) {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+	s.spawnedAgentsMu.RLock()
+	spawned, exists := s.spawnedAgents[sessionID]
+	s.spawnedAgentsMu.RUnlock()
+	if !exists {
+		return
+	}
 
 	logger := s.logger
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 
+	// Never-expiring agents have nothing to poll for
+	var tick <-chan time.Time
+	if spawned.autoDespawnTimeout > 0 {
+		ticker := time.NewTicker(spawned.pollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			// Context canceled (parent shutdown)
 			logger.Info("Spawned agent monitor canceled",
 				zap.String("session_id", sessionID))
-			s.cleanupSpawnedAgent(sessionID, "parent context canceled")
+			spawned.annotateSpan("spawn.monitor_canceled", nil)
+			s.cleanupSpawnedAgent(sessionID, cleanupReasonParentCanceled)
 			return
 
-		case <-ticker.C:
+		case <-tick:
 			// Check if session is still active
 			session, err := s.sessionStore.LoadSession(ctx, sessionID)
+			if errors.Is(err, agent.ErrSessionNotFound) {
+				// Deleted by another instance sharing the backend, or expired by the backend
+				logger.Info("Spawned agent session no longer exists",
+					zap.String("session_id", sessionID))
+				s.cleanupSpawnedAgent(sessionID, cleanupReasonSessionExpired)
+				return
+			}
 			if err != nil {
 				logger.Warn("Failed to get spawned agent session",
 					zap.String("session_id", sessionID),
@@ -303,21 +768,29 @@ func (s *MultiAgentServer) monitorSpawnedAgent(ctx context.Context, sessionID st
 					zap.String("sub_agent_id", spawned.subAgentID),
 					zap.Duration("idle_time", time.Since(session.UpdatedAt)),
 					zap.Duration("timeout", timeout))
-				s.cleanupSpawnedAgent(sessionID, "auto-despawn: inactivity timeout")
+				spawned.annotateSpan("spawn.idle_timeout", map[string]interface{}{
+					"idle_ms":    time.Since(session.UpdatedAt).Milliseconds(),
+					"timeout_ms": timeout.Milliseconds(),
+				})
+				s.publishSpawnLifecycle(ctx, spawned, SpawnLifecycleExpired, cleanupReasonIdleTimeout)
+				s.cleanupSpawnedAgent(sessionID, cleanupReasonIdleTimeout)
 				return
 			}
 		}
 	}
 }
 
-// DespawnSubAgent terminates a spawned sub-agent.
-// This implements the builtin.DespawnHandler interface.
+// DespawnSubAgent terminates a spawned sub-agent before its inactivity timeout: it cancels
+// the sub-agent's contexts, unsubscribes its topics, and stops tracking it.
+// The sub-agent is identified by req.SessionID or req.SubAgentID and must have been
+// spawned by req.ParentSessionID; otherwise an error is returned.
+// This implements the builtin.EphemeralAgentHandler interface.
 func (s *MultiAgentServer) DespawnSubAgent(ctx context.Context, req *builtin.DespawnSubAgentRequest) (*builtin.DespawnSubAgentResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("despawn request cannot be nil")
 	}
-	if req.SubAgentID == "" {
-		return nil, fmt.Errorf("sub_agent_id is required")
+	if req.SubAgentID == "" && req.SessionID == "" {
+		return nil, fmt.Errorf("sub_agent_id or session_id is required")
 	}
 
 	logger := s.logger
@@ -328,57 +801,275 @@ func (s *MultiAgentServer) DespawnSubAgent(ctx context.Context, req *builtin.Des
 	logger.Info("Despawning sub-agent",
 		zap.String("parent_session", req.ParentSessionID),
 		zap.String("sub_agent_id", req.SubAgentID),
+		zap.String("session_id", req.SessionID),
 		zap.String("reason", req.Reason))
 
-	// Find the spawned agent by sub-agent ID
-	s.spawnedAgentsMu.Lock()
-	var targetSessionID string
-	for sessionID, spawned := range s.spawnedAgents {
-		if spawned.subAgentID == req.SubAgentID && spawned.parentSessionID == req.ParentSessionID {
-			targetSessionID = sessionID
-			break
-		}
-	}
-	s.spawnedAgentsMu.Unlock()
-
-	if targetSessionID == "" {
+	target, err := s.findSpawnedAgent(req.ParentSessionID, req.SessionID, req.SubAgentID)
+	if err != nil {
 		logger.Warn("Sub-agent not found for despawn",
 			zap.String("sub_agent_id", req.SubAgentID),
-			zap.String("parent_session", req.ParentSessionID))
-		return &builtin.DespawnSubAgentResponse{
-			SubAgentID: req.SubAgentID,
-			SessionID:  "",
-			Status:     "not_found",
-		}, nil
+			zap.String("session_id", req.SessionID),
+			zap.String("parent_session", req.ParentSessionID),
+			zap.Error(err))
+		return nil, err
 	}
+	targetSessionID := target.subSessionID
 
 	// Clean up the spawned agent
 	reason := req.Reason
 	if reason == "" {
-		reason = "despawned by parent"
+		reason = cleanupReasonDespawned
 	}
 	s.cleanupSpawnedAgent(targetSessionID, reason)
 
 	logger.Info("Sub-agent despawned successfully",
-		zap.String("sub_agent_id", req.SubAgentID),
+		zap.String("sub_agent_id", target.subAgentID),
 		zap.String("session_id", targetSessionID))
 
 	return &builtin.DespawnSubAgentResponse{
-		SubAgentID: req.SubAgentID,
+		SubAgentID: target.subAgentID,
 		SessionID:  targetSessionID,
 		Status:     "despawned",
 	}, nil
 }
 
-// cleanupSpawnedAgent removes a spawned agent from tracking and cleans up resources
+// SendToSpawnedAgent queues a direct message for a sub-agent spawned by the request's
+// parent session. Messages are handled one at a time in the order queued, between
+// the agent's other turns; when ReplyTopic is set the response is published there.
+// Returns ErrNotSpawnParent for agents spawned by another session, so one workflow
+// cannot inject messages into another's agents.
+func (s *MultiAgentServer) SendToSpawnedAgent(ctx context.Context, req *builtin.SendToSpawnedAgentRequest) (*builtin.SendToSpawnedAgentResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("message request cannot be nil")
+	}
+	if req.SubAgentID == "" && req.SessionID == "" {
+		return nil, fmt.Errorf("sub_agent_id or session_id is required")
+	}
+	if req.Message == "" {
+		return nil, fmt.Errorf("message is required")
+	}
+
+	logger := s.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	target, err := s.findSpawnedAgent(req.ParentSessionID, req.SessionID, req.SubAgentID)
+	if err != nil {
+		logger.Warn("Sub-agent not found for direct message",
+			zap.String("sub_agent_id", req.SubAgentID),
+			zap.String("session_id", req.SessionID),
+			zap.String("parent_session", req.ParentSessionID),
+			zap.Error(err))
+		return nil, err
+	}
+	if target.draining.Load() || target.loopCtx == nil || target.loopCtx.Err() != nil {
+		return nil, fmt.Errorf("%w: %s", builtin.ErrSpawnedAgentStopping, target.subSessionID)
+	}
+
+	msg := &loomv1.BusMessage{
+		Id:        fmt.Sprintf("direct-%s-%d", target.subSessionID, time.Now().UnixNano()),
+		Topic:     req.ReplyTopic,
+		FromAgent: req.ParentAgentID,
+		Payload: &loomv1.MessagePayload{
+			Data: &loomv1.MessagePayload_Value{
+				Value: []byte(req.Message),
+			},
+		},
+		Metadata:  observability.InjectTraceContext(ctx, maps.Clone(req.Metadata)),
+		Timestamp: time.Now().UnixMilli(),
+	}
+	s.enqueueSpawnedAgentMessage(target, msg)
+
+	logger.Info("Queued direct message for spawned agent",
+		zap.String("sub_agent_id", target.subAgentID),
+		zap.String("session_id", target.subSessionID),
+		zap.String("message_id", msg.Id),
+		zap.String("reply_topic", req.ReplyTopic))
+
+	return &builtin.SendToSpawnedAgentResponse{
+		MessageID:  msg.Id,
+		SubAgentID: target.subAgentID,
+		SessionID:  target.subSessionID,
+		Status:     "queued",
+	}, nil
+}
+
+// enqueueSpawnedAgentMessage adds a message to the spawned agent's inbox and starts
+// a goroutine to drain it if one is not already running.
+func (s *MultiAgentServer) enqueueSpawnedAgentMessage(spawned *spawnedAgentContext, msg *loomv1.BusMessage) {
+	spawned.inboxMu.Lock()
+	spawned.inbox = append(spawned.inbox, msg)
+	if spawned.inboxActive {
+		spawned.inboxMu.Unlock()
+		return
+	}
+	spawned.inboxActive = true
+	spawned.inboxMu.Unlock()
+
+	go s.drainSpawnedAgentInbox(spawned)
+}
+
+// drainSpawnedAgentInbox handles queued direct messages until the inbox is empty.
+// Messages still queued when the agent's loop is cancelled are dropped.
+func (s *MultiAgentServer) drainSpawnedAgentInbox(spawned *spawnedAgentContext) {
+	for {
+		spawned.inboxMu.Lock()
+		if len(spawned.inbox) == 0 || spawned.loopCtx.Err() != nil {
+			spawned.inbox = nil
+			spawned.inboxActive = false
+			spawned.inboxMu.Unlock()
+			return
+		}
+		msg := spawned.inbox[0]
+		spawned.inbox = spawned.inbox[1:]
+		spawned.inboxMu.Unlock()
+
+		s.handleSpawnedAgentMessage(spawned.loopCtx, spawned, msg)
+	}
+}
+
+// findSpawnedAgent returns the agent spawned by parentSessionID with the given session ID,
+// or with the given sub-agent ID when sessionID is empty. It returns ErrNotSpawnParent if
+// the only match belongs to another parent, and ErrSpawnedAgentNotFound if nothing matches.
+func (s *MultiAgentServer) findSpawnedAgent(parentSessionID, sessionID, subAgentID string) (*spawnedAgentContext, error) {
+	s.spawnedAgentsMu.RLock()
+	defer s.spawnedAgentsMu.RUnlock()
+
+	foreignParent := false
+	if sessionID != "" {
+		if spawned, ok := s.spawnedAgents[sessionID]; ok {
+			if spawned.parentSessionID == parentSessionID {
+				return spawned, nil
+			}
+			foreignParent = true
+		}
+	} else {
+		for _, spawned := range s.spawnedAgents {
+			if spawned.subAgentID != subAgentID {
+				continue
+			}
+			if spawned.parentSessionID == parentSessionID {
+				return spawned, nil
+			}
+			foreignParent = true
+		}
+	}
+
+	identifier := sessionID
+	if identifier == "" {
+		identifier = subAgentID
+	}
+	if foreignParent {
+		return nil, fmt.Errorf("%w: %s", builtin.ErrNotSpawnParent, identifier)
+	}
+	return nil, fmt.Errorf("%w: %s", builtin.ErrSpawnedAgentNotFound, identifier)
+}
+
+// SpawnedAgentExit describes a spawned sub-agent that has been cleaned up.
+type SpawnedAgentExit struct {
+	ParentSessionID string    // Session that spawned the sub-agent
+	ParentAgentID   string    // Agent that spawned the sub-agent
+	SubAgentID      string    // Full sub-agent ID (with namespace prefix)
+	SessionID       string    // Sub-agent's session ID
+	Reason          string    // Why it exited (e.g. "auto-despawn: inactivity timeout", "parent session ended", or the despawn reason)
+	SpawnedAt       time.Time // When the sub-agent was spawned
+	ExitedAt        time.Time // When cleanup completed
+}
+
+// SetOnSpawnedAgentExit sets a hook called after a spawned sub-agent is cleaned up,
+// whether it expired, was despawned, or its parent ended, so the parent can respawn,
+// reassign work, or notify the user. The hook fires exactly once per sub-agent and is
+// called on the goroutine performing the cleanup, so it should return quickly.
+// Passing nil removes the hook.
+func (s *MultiAgentServer) SetOnSpawnedAgentExit(hook func(SpawnedAgentExit)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onSpawnedAgentExit = hook
+}
+
+// SetSpawnDrainTimeout sets how long cleanup of a spawned agent (despawn, expiry, or
+// parent shutdown) waits for the agent's current turn to finish before cancelling it,
+// so a turn doing writes is not interrupted halfway. The agent stops taking new messages
+// as soon as cleanup starts. 0 cancels immediately. Default: 30 seconds.
+func (s *MultiAgentServer) SetSpawnDrainTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if timeout < 0 {
+		timeout = 0
+	}
+	s.spawnDrainTimeout = timeout
+}
+
+// drainSpawnedAgent stops the agent from taking new messages and waits up to timeout
+// for its current turn to end. Returns false if the turn was still running.
+func drainSpawnedAgent(spawned *spawnedAgentContext, timeout time.Duration) bool {
+	spawned.draining.Store(true)
+
+	// No turn in progress
+	if spawned.turnMu.TryLock() {
+		spawned.turnMu.Unlock()
+		return true
+	}
+	if timeout <= 0 {
+		return false
+	}
+
+	idle := make(chan struct{})
+	go func() {
+		spawned.turnMu.Lock()
+		spawned.turnMu.Unlock() //nolint:staticcheck // empty critical section waits for the current turn
+		close(idle)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-idle:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// cleanupSpawnedAgent removes a spawned agent from tracking and cleans up resources.
+// Only the first call for a session does anything, so concurrent cleanup paths
+// (despawn, expiry, parent shutdown) release resources and notify the exit hook once.
+// The agent is drained first (see SetSpawnDrainTimeout) and the reason passed to the
+// exit hook records whether the drain completed or timed out.
 func (s *MultiAgentServer) cleanupSpawnedAgent(sessionID string, reason string) {
+	s.mu.RLock()
+	drainTimeout := s.spawnDrainTimeout
+	s.mu.RUnlock()
+	s.cleanupSpawnedAgentWithin(sessionID, reason, drainTimeout)
+}
+
+// cleanupSpawnedAgentWithin is cleanupSpawnedAgent with an explicit drain timeout.
+// Returns whether this call cleaned the agent up and, if so, whether its turn finished
+// before the timeout (false means it was cancelled mid-turn).
+func (s *MultiAgentServer) cleanupSpawnedAgentWithin(sessionID string, reason string, drainTimeout time.Duration) (cleaned, drained bool) {
+	return s.cleanupSpawnTree(sessionID, reason, drainTimeout, time.Now().Add(drainTimeout), nil)
+}
+
+// cleanupSpawnTree cleans up a spawned agent and everything it spawned, depth-first:
+// each child's subtree is cleaned up (children in parallel) before the agent itself,
+// and every agent in the tree drains against the deadline drainTimeout set at the root.
+// report, if set, is called for each agent this call cleaned up. Returns the result for
+// sessionID itself.
+func (s *MultiAgentServer) cleanupSpawnTree(sessionID string, reason string, drainTimeout time.Duration, deadline time.Time, report func(sessionID string, drained bool)) (cleaned, drained bool) {
 	s.spawnedAgentsMu.Lock()
 	spawned, exists := s.spawnedAgents[sessionID]
 	if !exists {
 		s.spawnedAgentsMu.Unlock()
-		return
+		return false, false
 	}
 	delete(s.spawnedAgents, sessionID)
+	var children []string
+	for childID, child := range s.spawnedAgents {
+		if child.parentSessionID == sessionID {
+			children = append(children, childID)
+		}
+	}
 	s.spawnedAgentsMu.Unlock()
 
 	logger := s.logger
@@ -389,7 +1080,40 @@ func (s *MultiAgentServer) cleanupSpawnedAgent(sessionID string, reason string)
 	logger.Info("Cleaning up spawned agent",
 		zap.String("session_id", sessionID),
 		zap.String("sub_agent_id", spawned.subAgentID),
-		zap.String("reason", reason))
+		zap.String("reason", reason),
+		zap.Int("children", len(children)))
+	s.serverMetrics().RecordCleanup(cleanupReasonLabel(reason))
+	spawned.annotateSpan("spawn.cleanup_started", map[string]interface{}{"reason": reason})
+
+	// Children go first so none outlives the agent that spawned it
+	childReason := cleanupReasonParentEnded
+	if reason == cleanupReasonShutdown {
+		childReason = cleanupReasonShutdown
+	}
+	var wg sync.WaitGroup
+	for _, childID := range children {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			s.cleanupSpawnTree(id, childReason, drainTimeout, deadline, report)
+		}(childID)
+	}
+	wg.Wait()
+
+	// Let the current turn finish before cancelling anything
+	drained = drainSpawnedAgent(spawned, max(time.Until(deadline), 0))
+	switch {
+	case drained:
+		reason += " (drained)"
+	case drainTimeout == 0:
+		reason += " (drain disabled, turn cancelled)"
+	default:
+		reason += fmt.Sprintf(" (drain timed out after %s, turn cancelled)", drainTimeout)
+		logger.Warn("Spawned agent did not finish its turn before drain timeout",
+			zap.String("session_id", sessionID),
+			zap.String("sub_agent_id", spawned.subAgentID),
+			zap.Duration("drain_timeout", drainTimeout))
+	}
 
 	// Cancel background message processing loop
 	if spawned.loopCancelFunc != nil {
@@ -406,25 +1130,67 @@ func (s *MultiAgentServer) cleanupSpawnedAgent(sessionID string, reason string)
 		close(notifyChan)
 	}
 
-	// Unsubscribe from topics using subscription IDs
+	// Remove every subscription the agent holds, including ones it made itself, unless
+	// another spawn of the same sub-agent is still running and shares them
 	if s.messageBus != nil {
-		for _, subID := range spawned.subscriptionIDs {
-			err := s.messageBus.Unsubscribe(context.Background(), subID)
-			if err != nil {
-				logger.Warn("Failed to unsubscribe spawned agent",
-					zap.String("subscription_id", subID),
-					zap.Error(err))
-			} else {
-				logger.Debug("Unsubscribed spawned agent",
-					zap.String("sub_agent_id", spawned.subAgentID),
-					zap.String("subscription_id", subID))
+		if s.spawnedAgentRunning(spawned.subAgentID) {
+			for _, subID := range spawned.subscriptionIDs {
+				if err := s.messageBus.Unsubscribe(context.Background(), subID); err != nil {
+					logger.Warn("Failed to unsubscribe spawned agent",
+						zap.String("subscription_id", subID),
+						zap.Error(err))
+				}
 			}
+		} else if removed, err := s.messageBus.UnsubscribeAll(context.Background(), spawned.subAgentID); err != nil {
+			logger.Warn("Failed to unsubscribe spawned agent",
+				zap.String("sub_agent_id", spawned.subAgentID),
+				zap.Error(err))
+		} else {
+			logger.Debug("Unsubscribed spawned agent",
+				zap.String("sub_agent_id", spawned.subAgentID),
+				zap.Int("subscriptions", removed))
 		}
 	}
 
 	logger.Info("Spawned agent cleanup complete",
 		zap.String("session_id", sessionID),
-		zap.String("sub_agent_id", spawned.subAgentID))
+		zap.String("sub_agent_id", spawned.subAgentID),
+		zap.String("reason", reason))
+	if span := spawned.endSpan(reason); span != nil {
+		s.RecordTraceSpan(span)
+	}
+	s.publishSpawnLifecycle(context.Background(), spawned, SpawnLifecycleCleanedUp, reason)
+
+	s.mu.RLock()
+	hook := s.onSpawnedAgentExit
+	s.mu.RUnlock()
+	if hook != nil {
+		hook(SpawnedAgentExit{
+			ParentSessionID: spawned.parentSessionID,
+			ParentAgentID:   spawned.parentAgentID,
+			SubAgentID:      spawned.subAgentID,
+			SessionID:       sessionID,
+			Reason:          reason,
+			SpawnedAt:       spawned.spawnedAt,
+			ExitedAt:        time.Now(),
+		})
+	}
+	if report != nil {
+		report(sessionID, drained)
+	}
+	return true, drained
+}
+
+// spawnedAgentRunning reports whether a tracked spawn uses the given sub-agent ID.
+func (s *MultiAgentServer) spawnedAgentRunning(subAgentID string) bool {
+	s.spawnedAgentsMu.RLock()
+	defer s.spawnedAgentsMu.RUnlock()
+	for _, spawned := range s.spawnedAgents {
+		if spawned.subAgentID == subAgentID {
+			return true
+		}
+	}
+	return false
 }
 
 // cleanupSpawnedAgentsByParent cleans up all spawned agents for a parent session
@@ -448,9 +1214,16 @@ func (s *MultiAgentServer) cleanupSpawnedAgentsByParent(parentSessionID string)
 			zap.String("parent_session", parentSessionID),
 			zap.Int("spawned_count", len(toCleanup)))
 
+		// Drain children in parallel so one busy agent does not delay the others
+		var wg sync.WaitGroup
 		for _, sessionID := range toCleanup {
-			s.cleanupSpawnedAgent(sessionID, "parent session ended")
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				s.cleanupSpawnedAgent(id, cleanupReasonParentEnded)
+			}(sessionID)
 		}
+		wg.Wait()
 	}
 }
 
@@ -529,8 +1302,9 @@ func (s *MultiAgentServer) processSpawnedAgentMessages(ctx context.Context, spaw
 			select {
 			case msg := <-sub.Channel:
 				messages = append(messages, &BusMessage{
-					msg:   msg,
-					topic: sub.Topic,
+					msg:            msg,
+					topic:          sub.Topic,
+					subscriptionID: sub.ID,
 				})
 			default:
 				goto nextSubscription
@@ -547,102 +1321,269 @@ func (s *MultiAgentServer) processSpawnedAgentMessages(ctx context.Context, spaw
 		zap.String("agent", spawned.subAgentID),
 		zap.Int("message_count", len(messages)))
 
-	// Process each message
+	// Process each message, acknowledging the ones handled so reliable publishes are not redelivered
 	for _, busMsg := range messages {
-		msg := busMsg.msg
-
-		// Skip messages from self
-		if msg.FromAgent == spawned.subAgentID {
+		if !s.handleSpawnedAgentMessage(ctx, spawned, busMsg.msg) {
 			continue
 		}
-
-		// Extract message content
-		var content string
-		if msg.Payload != nil {
-			if value := msg.Payload.GetValue(); value != nil {
-				content = string(value)
-			} else if ref := msg.Payload.GetReference(); ref != nil {
-				content = fmt.Sprintf("[Reference: %s]", ref.Id)
-			}
+		if err := s.messageBus.Ack(busMsg.subscriptionID, busMsg.msg.Id); err != nil {
+			logger.Debug("Failed to acknowledge message",
+				zap.String("agent", spawned.subAgentID),
+				zap.String("message_id", busMsg.msg.Id),
+				zap.Error(err))
 		}
+	}
+}
 
-		if content == "" {
-			continue
-		}
+// handleSpawnedAgentMessage runs one message through the spawned agent and publishes
+// the response back to the message's topic (if it has one). It returns false if the
+// message was not processed (the agent is shutting down or Chat failed) and should
+// be left unacknowledged.
+func (s *MultiAgentServer) handleSpawnedAgentMessage(ctx context.Context, spawned *spawnedAgentContext, msg *loomv1.BusMessage) bool {
+	logger := s.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	// Skip messages from self
+	if msg.FromAgent == spawned.subAgentID {
+		return true
+	}
 
-		logger.Info("Spawned agent received message",
+	content := spawnMessageContent(msg)
+	if content == "" {
+		return true
+	}
+
+	// Hold the turn lock so cleanup can wait for this turn to finish
+	spawned.turnMu.Lock()
+	defer spawned.turnMu.Unlock()
+	if spawned.draining.Load() {
+		logger.Debug("Spawned agent is shutting down, dropping message",
+			zap.String("agent", spawned.subAgentID),
+			zap.String("from", msg.FromAgent))
+		return false
+	}
+
+	logger.Info("Spawned agent received message",
+		zap.String("agent", spawned.subAgentID),
+		zap.String("from", msg.FromAgent),
+		zap.String("topic", msg.Topic),
+		zap.String("message_preview", truncateString(observability.Redact(content), 50)))
+
+	// Call agent.Chat() to process the message with timeout
+	logger.Info("Calling agent.Chat() for spawned agent",
+		zap.String("agent", spawned.subAgentID),
+		zap.String("session", spawned.subSessionID))
+
+	// Agents spawned while handling a lifecycle event publish none of their own
+	if msg.Topic == SpawnLifecycleTopic {
+		ctx = withSpawnLifecycleSuppressed(ctx)
+	}
+
+	ctx, span := s.startMessageSpan(ctx, spawned, msg)
+	chatCtx, chatCancel := context.WithTimeout(ctx, 2*time.Minute)
+	resp, err := spawned.agent.Chat(chatCtx, spawned.subSessionID, content)
+	chatCancel()
+	defer s.endMessageSpan(spawned, span, err)
+
+	if err != nil {
+		logger.Warn("Spawned agent failed to process message",
 			zap.String("agent", spawned.subAgentID),
 			zap.String("from", msg.FromAgent),
-			zap.String("topic", msg.Topic),
-			zap.String("message_preview", truncateString(content, 50)))
+			zap.Error(err))
+		return false
+	}
+
+	logger.Info("agent.Chat() returned successfully",
+		zap.String("agent", spawned.subAgentID),
+		zap.Int("response_len", len(resp.Content)))
+
+	// Messages without a topic (e.g. the initial task) have nowhere to reply;
+	// the response stays in the sub-agent's session history.
+	if msg.Topic == "" || s.messageBus == nil {
+		return true
+	}
 
-		// Call agent.Chat() to process the message with timeout
-		logger.Info("Calling agent.Chat() for spawned agent",
+	// Publish response back to the same topic
+	responseMsg := &loomv1.BusMessage{
+		Id:        fmt.Sprintf("%s-response-%d", msg.Id, time.Now().UnixNano()),
+		Topic:     msg.Topic,
+		FromAgent: spawned.subAgentID,
+		Payload: &loomv1.MessagePayload{
+			Data: &loomv1.MessagePayload_Value{
+				Value: []byte(resp.Content),
+			},
+		},
+		Metadata:  map[string]string{"in_reply_to": msg.Id},
+		Timestamp: time.Now().UnixMilli(),
+	}
+
+	delivered, dropped, err := s.messageBus.Publish(ctx, msg.Topic, responseMsg)
+	if err != nil {
+		logger.Warn("Spawned agent failed to publish response",
 			zap.String("agent", spawned.subAgentID),
-			zap.String("session", spawned.subSessionID))
+			zap.String("topic", msg.Topic),
+			zap.Error(err))
+		return true
+	}
 
-		chatCtx, chatCancel := context.WithTimeout(ctx, 2*time.Minute)
-		resp, err := spawned.agent.Chat(chatCtx, spawned.subSessionID, content)
-		chatCancel()
+	logger.Info("Spawned agent published response",
+		zap.String("agent", spawned.subAgentID),
+		zap.String("topic", msg.Topic),
+		zap.Int("delivered", delivered),
+		zap.Int("dropped", dropped),
+		zap.String("response_preview", truncateString(observability.Redact(resp.Content), 50)))
+
+	// Emit SSE event for real-time visibility (if parent session has progress multiplexer)
+	s.emitPubSubEvent(spawned.parentSessionID, &PubSubEvent{
+		Type:      "agent_message",
+		Topic:     msg.Topic,
+		FromAgent: spawned.subAgentID,
+		ToAgents:  delivered,
+		Content:   resp.Content,
+		Timestamp: time.Now(),
+	})
+	return true
+}
 
-		if err != nil {
-			logger.Warn("Spawned agent failed to process message",
-				zap.String("agent", spawned.subAgentID),
-				zap.String("from", msg.FromAgent),
-				zap.Error(err))
+// initialTaskContextKey is the session context key holding a spawned agent's InitialTask.
+const initialTaskContextKey = "initial_task"
+
+// Session context keys set on spawned agents when spawn context injection is enabled.
+const (
+	spawnParentAgentContextKey = "spawn_parent_agent_id" // string: the spawning agent's ID
+	spawnMetadataContextKey    = "spawn_metadata"        // map[string]string: spawn request metadata
+)
+
+// spawnControlMetadataKeys are spawn metadata keys that configure the server rather
+// than describe the agent, so they are not shown to the spawned agent.
+var spawnControlMetadataKeys = map[string]bool{
+	"auto_despawn_minutes": true,
+	"initial_message":      true,
+}
+
+// SetSpawnContextInjection controls whether spawned agents are told about their spawn.
+// When enabled, each spawned agent's system prompt gains a SPAWN CONTEXT section listing
+// the parent agent ID and the spawn request metadata (e.g. "role: tank"), and its session
+// context gets two keys readable by tools:
+//
+//   - spawn_parent_agent_id: the spawning agent's ID (string)
+//   - spawn_metadata: the spawn metadata (map of string to string), if any
+//
+// Server control keys (auto_despawn_minutes, initial_message) are left out.
+// Disabled by default; applies to agents spawned after the call.
+func (s *MultiAgentServer) SetSpawnContextInjection(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spawnContextInjection = enabled
+}
+
+// spawnContextMetadata returns a copy of spawn metadata without server control keys,
+// or nil if nothing remains.
+func spawnContextMetadata(metadata map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range metadata {
+		if spawnControlMetadataKeys[key] {
 			continue
 		}
-
-		logger.Info("agent.Chat() returned successfully",
-			zap.String("agent", spawned.subAgentID),
-			zap.Int("response_len", len(resp.Content)))
-
-		// Publish response back to the same topic
-		responseMsg := &loomv1.BusMessage{
-			Id:        fmt.Sprintf("%s-response-%d", msg.Id, time.Now().UnixNano()),
-			Topic:     msg.Topic,
-			FromAgent: spawned.subAgentID,
-			Payload: &loomv1.MessagePayload{
-				Data: &loomv1.MessagePayload_Value{
-					Value: []byte(resp.Content),
-				},
-			},
-			Metadata:  map[string]string{"in_reply_to": msg.Id},
-			Timestamp: time.Now().UnixMilli(),
+		if result == nil {
+			result = make(map[string]string)
 		}
+		result[key] = value
+	}
+	return result
+}
+
+// buildInitialSpawnMessage converts a spawn request's InitialMessage/InitialTask into the
+// sub-agent's first bus message. A structured task is carried as an application/json
+// payload with the accompanying text in metadata; text alone is sent as text/plain.
+// Returns nil if the request has neither. The reply topic is the first subscribed topic.
+func buildInitialSpawnMessage(req *builtin.SpawnSubAgentRequest, subscribedTopics []string) (*loomv1.BusMessage, error) {
+	if req.InitialMessage == "" && req.InitialTask == nil {
+		return nil, nil
+	}
+
+	var topic string
+	if len(subscribedTopics) > 0 {
+		topic = subscribedTopics[0]
+	}
+
+	value := []byte(req.InitialMessage)
+	contentType := "text/plain"
+	metadata := map[string]string{"message_type": "initial_message"}
 
-		delivered, dropped, err := s.messageBus.Publish(ctx, msg.Topic, responseMsg)
+	if req.InitialTask != nil {
+		taskJSON, err := json.Marshal(req.InitialTask)
 		if err != nil {
-			logger.Warn("Spawned agent failed to publish response",
-				zap.String("agent", spawned.subAgentID),
-				zap.String("topic", msg.Topic),
-				zap.Error(err))
-			continue
+			return nil, fmt.Errorf("failed to marshal initial task: %w", err)
 		}
+		value = taskJSON
+		contentType = "application/json"
+		metadata["message_type"] = "initial_task"
+		if req.InitialMessage != "" {
+			metadata["message"] = req.InitialMessage
+		}
+	}
 
-		logger.Info("Spawned agent published response",
-			zap.String("agent", spawned.subAgentID),
-			zap.String("topic", msg.Topic),
-			zap.Int("delivered", delivered),
-			zap.Int("dropped", dropped),
-			zap.String("response_preview", truncateString(resp.Content, 50)))
-
-		// Emit SSE event for real-time visibility (if parent session has progress multiplexer)
-		s.emitPubSubEvent(spawned.parentSessionID, &PubSubEvent{
-			Type:      "agent_message",
-			Topic:     msg.Topic,
-			FromAgent: spawned.subAgentID,
-			ToAgents:  delivered,
-			Content:   resp.Content,
-			Timestamp: time.Now(),
-		})
+	return &loomv1.BusMessage{
+		Id:        fmt.Sprintf("initial-%d", time.Now().UnixNano()),
+		Topic:     topic,
+		FromAgent: req.ParentAgentID,
+		Payload: &loomv1.MessagePayload{
+			Data: &loomv1.MessagePayload_Value{Value: value},
+			Metadata: &loomv1.PayloadMetadata{
+				SizeBytes:   int64(len(value)),
+				ContentType: contentType,
+			},
+		},
+		Metadata:  metadata,
+		Timestamp: time.Now().UnixMilli(),
+	}, nil
+}
+
+// spawnMessageContent renders a bus message as chat input for a spawned agent.
+// JSON payloads are presented as a fenced block after any accompanying text so the
+// agent can parse the parameters reliably.
+func spawnMessageContent(msg *loomv1.BusMessage) string {
+	if msg.Payload == nil {
+		return ""
+	}
+
+	if ref := msg.Payload.GetReference(); ref != nil {
+		return fmt.Sprintf("[Reference: %s]", ref.Id)
+	}
+
+	value := msg.Payload.GetValue()
+	if value == nil {
+		return ""
+	}
+
+	if msg.Payload.GetMetadata().GetContentType() != "application/json" {
+		return string(value)
+	}
+
+	var sb strings.Builder
+	if text := msg.Metadata["message"]; text != "" {
+		sb.WriteString(text)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("Structured task parameters (JSON):\n```json\n")
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, value, "", "  "); err == nil {
+		sb.Write(pretty.Bytes())
+	} else {
+		sb.Write(value)
 	}
+	sb.WriteString("\n```")
+	return sb.String()
 }
 
 // BusMessage wraps a bus message with its topic for processing
 type BusMessage struct {
-	msg   *loomv1.BusMessage
-	topic string
+	msg            *loomv1.BusMessage
+	topic          string
+	subscriptionID string
 }
 
 // PubSubEvent represents a pub/sub message event for SSE streaming
@@ -0,0 +1,962 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/agent"
+	"github.com/teradata-labs/loom/pkg/communication"
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+func TestBuildInitialSpawnMessage(t *testing.T) {
+	t.Run("nothing to deliver", func(t *testing.T) {
+		msg, err := buildInitialSpawnMessage(&builtin.SpawnSubAgentRequest{}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		msg, err := buildInitialSpawnMessage(&builtin.SpawnSubAgentRequest{
+			ParentAgentID:  "coordinator",
+			InitialMessage: "Say hello to the party",
+		}, []string{"party-chat"})
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+
+		assert.Equal(t, "party-chat", msg.Topic)
+		assert.Equal(t, "coordinator", msg.FromAgent)
+		assert.Equal(t, "text/plain", msg.Payload.GetMetadata().GetContentType())
+		assert.Equal(t, "Say hello to the party", spawnMessageContent(msg))
+	})
+
+	t.Run("structured task", func(t *testing.T) {
+		msg, err := buildInitialSpawnMessage(&builtin.SpawnSubAgentRequest{
+			InitialMessage: "Profile this table",
+			InitialTask: map[string]interface{}{
+				"dataset": "sales",
+				"columns": []interface{}{"region", "revenue"},
+			},
+		}, nil)
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+
+		assert.Empty(t, msg.Topic, "no subscriptions means no reply topic")
+		assert.Equal(t, "application/json", msg.Payload.GetMetadata().GetContentType())
+		assert.JSONEq(t, `{"dataset":"sales","columns":["region","revenue"]}`, string(msg.Payload.GetValue()))
+		assert.Equal(t, "initial_task", msg.Metadata["message_type"])
+
+		content := spawnMessageContent(msg)
+		assert.Contains(t, content, "Profile this table")
+		assert.Contains(t, content, "```json")
+		assert.Contains(t, content, `"dataset": "sales"`)
+	})
+}
+
+// trackTestSpawn registers a spawned agent directly, without loading an agent or session.
+func trackTestSpawn(s *MultiAgentServer, parentSessionID, subAgentID, sessionID string) (loopCtx context.Context) {
+	loopCtx, loopCancel := context.WithCancel(context.Background())
+	_, cancel := context.WithCancel(context.Background())
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents[sessionID] = &spawnedAgentContext{
+		parentSessionID: parentSessionID,
+		subAgentID:      subAgentID,
+		subSessionID:    sessionID,
+		spawnedAt:       time.Now(),
+		cancelFunc:      cancel,
+		loopCancelFunc:  loopCancel,
+		loopCtx:         loopCtx,
+	}
+	s.spawnedAgentsMu.Unlock()
+	return loopCtx
+}
+
+func TestDespawnSubAgent(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	ctx := context.Background()
+
+	t.Run("by session ID", func(t *testing.T) {
+		loopCtx := trackTestSpawn(s, "parent-1", "wf:analyst", "sess-a")
+
+		resp, err := s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SessionID: "sess-a"})
+		require.NoError(t, err)
+		assert.Equal(t, "despawned", resp.Status)
+		assert.Equal(t, "wf:analyst", resp.SubAgentID)
+		assert.Equal(t, "sess-a", resp.SessionID)
+		assert.Error(t, loopCtx.Err(), "child context is cancelled")
+		assert.Equal(t, 0, s.countSpawnedAgentsByParent("parent-1"))
+	})
+
+	t.Run("by sub-agent ID", func(t *testing.T) {
+		trackTestSpawn(s, "parent-2", "wf:analyst", "sess-other")
+		trackTestSpawn(s, "parent-1", "wf:analyst", "sess-b")
+
+		resp, err := s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SubAgentID: "wf:analyst"})
+		require.NoError(t, err)
+		assert.Equal(t, "sess-b", resp.SessionID)
+		assert.Equal(t, 1, s.countSpawnedAgentsByParent("parent-2"), "other parent's child is untouched")
+	})
+
+	t.Run("different parent", func(t *testing.T) {
+		_, err := s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SessionID: "sess-other"})
+		assert.ErrorIs(t, err, builtin.ErrNotSpawnParent)
+		_, err = s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SubAgentID: "wf:analyst"})
+		assert.ErrorIs(t, err, builtin.ErrNotSpawnParent)
+		assert.Equal(t, 1, s.countSpawnedAgentsByParent("parent-2"))
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		_, err := s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SessionID: "missing"})
+		assert.ErrorIs(t, err, builtin.ErrSpawnedAgentNotFound)
+		_, err = s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSendToSpawnedAgent(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	s.messageBus = communication.NewMessageBus(nil, nil, nil, nil)
+	defer s.messageBus.Close()
+	ctx := context.Background()
+
+	trackTestSpawn(s, "parent-1", "wf:analyst", "sess-a")
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents["sess-a"].agent = agent.NewAgent(&mockBackend{}, &mockLLMForMultiAgent{})
+	s.spawnedAgentsMu.Unlock()
+
+	replies, err := s.messageBus.Subscribe(ctx, "coordinator", "replies", nil, 10)
+	require.NoError(t, err)
+
+	t.Run("reply is published to the reply topic", func(t *testing.T) {
+		resp, err := s.SendToSpawnedAgent(ctx, &builtin.SendToSpawnedAgentRequest{
+			ParentSessionID: "parent-1",
+			ParentAgentID:   "coordinator",
+			SubAgentID:      "wf:analyst",
+			Message:         "profile the sales table",
+			ReplyTopic:      "replies",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "queued", resp.Status)
+		assert.Equal(t, "sess-a", resp.SessionID)
+		assert.NotEmpty(t, resp.MessageID)
+
+		select {
+		case reply := <-replies.Channel:
+			assert.Equal(t, "wf:analyst", reply.FromAgent)
+			assert.Equal(t, resp.MessageID, reply.Metadata["in_reply_to"])
+			assert.Contains(t, string(reply.Payload.GetValue()), "profile the sales table")
+		case <-time.After(5 * time.Second):
+			t.Fatal("no reply published")
+		}
+	})
+
+	t.Run("other parents are refused", func(t *testing.T) {
+		_, err := s.SendToSpawnedAgent(ctx, &builtin.SendToSpawnedAgentRequest{ParentSessionID: "parent-2", SessionID: "sess-a", Message: "hi"})
+		assert.ErrorIs(t, err, builtin.ErrNotSpawnParent)
+		_, err = s.SendToSpawnedAgent(ctx, &builtin.SendToSpawnedAgentRequest{ParentSessionID: "parent-2", SubAgentID: "wf:analyst", Message: "hi"})
+		assert.ErrorIs(t, err, builtin.ErrNotSpawnParent)
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		_, err := s.SendToSpawnedAgent(ctx, &builtin.SendToSpawnedAgentRequest{ParentSessionID: "parent-1", SessionID: "sess-missing", Message: "hi"})
+		assert.ErrorIs(t, err, builtin.ErrSpawnedAgentNotFound)
+	})
+
+	t.Run("draining agent", func(t *testing.T) {
+		trackTestSpawn(s, "parent-1", "wf:writer", "sess-b")
+		s.spawnedAgentsMu.RLock()
+		s.spawnedAgents["sess-b"].draining.Store(true)
+		s.spawnedAgentsMu.RUnlock()
+
+		_, err := s.SendToSpawnedAgent(ctx, &builtin.SendToSpawnedAgentRequest{ParentSessionID: "parent-1", SessionID: "sess-b", Message: "hi"})
+		assert.ErrorIs(t, err, builtin.ErrSpawnedAgentStopping)
+	})
+}
+
+func TestListSpawnedAgents(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	trackTestSpawn(s, "parent-1", "wf:second", "sess-2")
+	trackTestSpawn(s, "parent-2", "wf:other", "sess-3")
+	trackTestSpawn(s, "parent-1", "wf:first", "sess-1")
+
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents["sess-1"].spawnedAt = time.Now().Add(-time.Minute)
+	s.spawnedAgents["sess-1"].workflowID = "wf"
+	s.spawnedAgents["sess-1"].subscriptions = []string{"party-chat"}
+	s.spawnedAgentsMu.Unlock()
+
+	infos := s.ListSpawnedAgents("parent-1")
+	require.Len(t, infos, 2)
+	assert.Equal(t, "wf:first", infos[0].SubAgentID, "oldest first")
+	assert.Equal(t, "sess-1", infos[0].SessionID)
+	assert.Equal(t, "wf", infos[0].WorkflowID)
+	assert.Equal(t, []string{"party-chat"}, infos[0].SubscribedTopics)
+	assert.Equal(t, "wf:second", infos[1].SubAgentID)
+
+	// Mutating the result does not touch server state
+	infos[0].SubscribedTopics[0] = "changed"
+	assert.Equal(t, []string{"party-chat"}, s.ListSpawnedAgents("parent-1")[0].SubscribedTopics)
+
+	assert.Empty(t, s.ListSpawnedAgents("unknown"))
+}
+
+func TestSpawnTimeouts(t *testing.T) {
+	custom := 90 * time.Second
+	never := time.Duration(0)
+
+	idle, poll := spawnTimeouts(&builtin.SpawnSubAgentRequest{}, 15*time.Minute, 5*time.Second)
+	assert.Equal(t, 15*time.Minute, idle)
+	assert.Equal(t, 5*time.Second, poll)
+
+	idle, poll = spawnTimeouts(&builtin.SpawnSubAgentRequest{IdleTimeout: &custom, PollInterval: time.Second}, 15*time.Minute, 5*time.Second)
+	assert.Equal(t, custom, idle)
+	assert.Equal(t, time.Second, poll)
+
+	idle, _ = spawnTimeouts(&builtin.SpawnSubAgentRequest{IdleTimeout: &never}, 15*time.Minute, 5*time.Second)
+	assert.Equal(t, time.Duration(0), idle, "explicit zero never expires")
+
+	idle, _ = spawnTimeouts(&builtin.SpawnSubAgentRequest{Metadata: map[string]string{"auto_despawn_minutes": "3"}}, 15*time.Minute, 5*time.Second)
+	assert.Equal(t, 3*time.Minute, idle, "legacy metadata still honored")
+
+	idle, poll = spawnTimeouts(&builtin.SpawnSubAgentRequest{}, 0, 0)
+	assert.Equal(t, time.Duration(0), idle, "server default of zero never expires")
+	assert.Equal(t, defaultSpawnPollInterval, poll)
+}
+
+func TestMonitorSpawnedAgent_IdleTimeout(t *testing.T) {
+	store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := NewMultiAgentServer(nil, store)
+	ctx := context.Background()
+	for _, id := range []string{"sess-expiring", "sess-supervised"} {
+		require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: id, AgentID: "analyst", CreatedAt: time.Now(), UpdatedAt: time.Now()}))
+	}
+
+	trackTestSpawn(s, "parent", "wf:expiring", "sess-expiring")
+	trackTestSpawn(s, "parent", "wf:supervised", "sess-supervised")
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents["sess-expiring"].autoDespawnTimeout = time.Second
+	s.spawnedAgents["sess-expiring"].pollInterval = 50 * time.Millisecond
+	s.spawnedAgents["sess-supervised"].autoDespawnTimeout = 0
+	s.spawnedAgents["sess-supervised"].pollInterval = 50 * time.Millisecond
+	s.spawnedAgentsMu.Unlock()
+
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.monitorSpawnedAgent(monitorCtx, "sess-expiring")
+	go s.monitorSpawnedAgent(monitorCtx, "sess-supervised")
+
+	assert.Eventually(t, func() bool {
+		s.spawnedAgentsMu.RLock()
+		defer s.spawnedAgentsMu.RUnlock()
+		_, tracked := s.spawnedAgents["sess-expiring"]
+		return !tracked
+	}, 5*time.Second, 50*time.Millisecond, "1s idle timeout is cleaned up")
+
+	time.Sleep(500 * time.Millisecond)
+	s.spawnedAgentsMu.RLock()
+	_, tracked := s.spawnedAgents["sess-supervised"]
+	s.spawnedAgentsMu.RUnlock()
+	assert.True(t, tracked, "zero idle timeout never expires")
+}
+
+func TestOnSpawnedAgentExit(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+
+	var mu sync.Mutex
+	var exits []SpawnedAgentExit
+	s.SetOnSpawnedAgentExit(func(exit SpawnedAgentExit) {
+		mu.Lock()
+		defer mu.Unlock()
+		exits = append(exits, exit)
+	})
+
+	trackTestSpawn(s, "parent", "wf:analyst", "sess-1")
+
+	// Concurrent cleanup paths race for the same agent
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.cleanupSpawnedAgent("sess-1", "auto-despawn: inactivity timeout")
+			s.cleanupSpawnedAgentsByParent("parent")
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, exits, 1)
+	assert.Equal(t, "wf:analyst", exits[0].SubAgentID)
+	assert.Equal(t, "sess-1", exits[0].SessionID)
+	assert.Equal(t, "parent", exits[0].ParentSessionID)
+	assert.NotEmpty(t, exits[0].Reason)
+	assert.False(t, exits[0].ExitedAt.Before(exits[0].SpawnedAt))
+
+	// Explicit despawn reports the caller's reason
+	trackTestSpawn(s, "parent", "wf:fighter", "sess-2")
+	_, err := s.DespawnSubAgent(context.Background(), &builtin.DespawnSubAgentRequest{ParentSessionID: "parent", SessionID: "sess-2", Reason: "quest complete"})
+	require.NoError(t, err)
+	require.Len(t, exits, 2)
+	assert.Equal(t, "quest complete (drained)", exits[1].Reason)
+}
+
+func TestSpawnContextMetadata(t *testing.T) {
+	assert.Nil(t, spawnContextMetadata(nil))
+	assert.Nil(t, spawnContextMetadata(map[string]string{"auto_despawn_minutes": "5"}))
+
+	metadata := map[string]string{"role": "tank", "auto_despawn_minutes": "5", "initial_message": "hi"}
+	result := spawnContextMetadata(metadata)
+	assert.Equal(t, map[string]string{"role": "tank"}, result)
+
+	// The result is a copy
+	result["role"] = "healer"
+	assert.Equal(t, "tank", metadata["role"])
+}
+
+func TestSpawnDepth(t *testing.T) {
+	store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := NewMultiAgentServer(nil, store)
+	ctx := context.Background()
+
+	// root -> sess-1 -> sess-2 -> sess-3, tracked as spawned agents
+	trackTestSpawn(s, "root", "wf:a", "sess-1")
+	trackTestSpawn(s, "sess-1", "wf:b", "sess-2")
+	trackTestSpawn(s, "sess-2", "wf:c", "sess-3")
+
+	assert.Equal(t, 1, s.spawnDepth(ctx, "root", 3))
+	assert.Equal(t, 2, s.spawnDepth(ctx, "sess-1", 3))
+	assert.Equal(t, 3, s.spawnDepth(ctx, "sess-2", 3))
+	assert.Equal(t, 4, s.spawnDepth(ctx, "sess-3", 3), "exceeds the default limit")
+	assert.Equal(t, 2, s.spawnDepth(ctx, "sess-3", 1), "walk stops past the limit")
+
+	// Untracked sessions are followed through the session store
+	now := time.Now()
+	require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "stored-root", AgentID: "a", CreatedAt: now, UpdatedAt: now}))
+	require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "stored-child", AgentID: "a", ParentSessionID: "stored-root", CreatedAt: now, UpdatedAt: now}))
+	assert.Equal(t, 2, s.spawnDepth(ctx, "stored-child", 3))
+
+	// Cycles terminate
+	trackTestSpawn(s, "loop-b", "wf:x", "loop-a")
+	trackTestSpawn(s, "loop-a", "wf:y", "loop-b")
+	assert.LessOrEqual(t, s.spawnDepth(ctx, "loop-a", 10), 3)
+
+	s.SetMaxSpawnDepth(0)
+	assert.Equal(t, 1, s.maxSpawnDepth)
+}
+
+func TestSpawnSubAgent_Errors(t *testing.T) {
+	store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := NewMultiAgentServer(nil, store)
+	ctx := context.Background()
+
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1"})
+	assert.ErrorIs(t, err, builtin.ErrInvalidSpawnRequest)
+
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst"})
+	assert.ErrorIs(t, err, builtin.ErrRegistryUnavailable)
+
+	tmpDir := t.TempDir()
+	registry, err := agent.NewRegistry(agent.RegistryConfig{
+		ConfigDir:   tmpDir,
+		DBPath:      filepath.Join(tmpDir, "test.db"),
+		LLMProvider: &mockLLMForMultiAgent{},
+	})
+	require.NoError(t, err)
+	defer registry.Close()
+	s.SetAgentRegistry(registry)
+
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "missing"})
+	assert.ErrorIs(t, err, builtin.ErrAgentNotFound)
+
+	for i := 0; i < 10; i++ {
+		trackTestSpawn(s, "parent-1", fmt.Sprintf("wf:agent-%d", i), fmt.Sprintf("sess-%d", i))
+	}
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst"})
+	assert.ErrorIs(t, err, builtin.ErrSpawnLimitReached)
+	assert.ErrorContains(t, err, "max: 10")
+
+	s.SetMaxSpawnDepth(1)
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "sess-0", AgentID: "analyst"})
+	assert.ErrorIs(t, err, builtin.ErrSpawnDepthExceeded)
+}
+
+func TestSpawnSubAgent_WorkflowLimit(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	withEmptyRegistry(t, s)
+	s.SetMaxSpawnsPerWorkflow(2)
+	ctx := context.Background()
+
+	// Two spawns of the same workflow under different parents
+	trackTestSpawn(s, "parent-1", "dungeon-crawl-workflow:fighter", "sess-a")
+	trackTestSpawn(s, "parent-2", "dungeon-crawl-workflow:wizard", "sess-b")
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents["sess-a"].workflowID = "dungeon-crawl-workflow"
+	s.spawnedAgents["sess-b"].workflowID = "dungeon-crawl-workflow"
+	s.spawnedAgentsMu.Unlock()
+
+	req := &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-3", AgentID: "rogue", WorkflowID: "dungeon-crawl-workflow"}
+	_, err := s.SpawnSubAgent(ctx, req)
+	require.ErrorIs(t, err, builtin.ErrSpawnLimitReached)
+	assert.ErrorContains(t, err, "workflow dungeon-crawl-workflow has 2 spawned agents (max: 2)")
+
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-3", AgentID: "rogue", WorkflowID: "other-workflow"})
+	assert.ErrorIs(t, err, builtin.ErrAgentNotFound, "other workflows have their own quota")
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-3", AgentID: "rogue"})
+	assert.ErrorIs(t, err, builtin.ErrAgentNotFound, "spawns without a workflow are not counted")
+
+	s.SetWorkflowSpawnLimit("dungeon-crawl-workflow", 3)
+	_, err = s.SpawnSubAgent(ctx, req)
+	assert.ErrorIs(t, err, builtin.ErrAgentNotFound, "the override raises the quota")
+
+	s.SetWorkflowSpawnLimit("dungeon-crawl-workflow", 1)
+	_, err = s.SpawnSubAgent(ctx, req)
+	assert.ErrorContains(t, err, "(max: 1)")
+
+	s.SetWorkflowSpawnLimit("dungeon-crawl-workflow", -1)
+	_, err = s.SpawnSubAgent(ctx, req)
+	assert.ErrorContains(t, err, "(max: 2)", "removing the override restores the default")
+}
+
+// memorySessionBackend is a map-backed agent.SessionBackend for spawn tests.
+type memorySessionBackend struct {
+	mu       sync.Mutex
+	sessions map[string]*agent.Session
+}
+
+func newMemorySessionBackend() *memorySessionBackend {
+	return &memorySessionBackend{sessions: make(map[string]*agent.Session)}
+}
+
+func (b *memorySessionBackend) SaveSession(_ context.Context, session *agent.Session) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sessions[session.ID] = session
+	return nil
+}
+
+func (b *memorySessionBackend) LoadSession(_ context.Context, sessionID string) (*agent.Session, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	session, ok := b.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", agent.ErrSessionNotFound, sessionID)
+	}
+	return session, nil
+}
+
+func (b *memorySessionBackend) DeleteSession(_ context.Context, sessionID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.sessions, sessionID)
+	return nil
+}
+
+func (b *memorySessionBackend) ListSessions(context.Context, agent.SessionFilter) ([]*agent.Session, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sessions := make([]*agent.Session, 0, len(b.sessions))
+	for _, session := range b.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (b *memorySessionBackend) SaveMessage(context.Context, string, agent.Message) error { return nil }
+
+func (b *memorySessionBackend) LoadMessages(context.Context, string) ([]agent.Message, error) {
+	return nil, nil
+}
+
+func (b *memorySessionBackend) ExportSession(context.Context, string) ([]byte, error) {
+	return nil, fmt.Errorf("export not supported")
+}
+
+func (b *memorySessionBackend) ImportSession(context.Context, []byte) (string, error) {
+	return "", fmt.Errorf("import not supported")
+}
+
+func (b *memorySessionBackend) Ping(context.Context) error { return nil }
+func (b *memorySessionBackend) Close() error               { return nil }
+
+// newSpawnTestServer returns a server whose spawns of agentName succeed, with an
+// in-memory session backend. Spawned agents are cleaned up with the test.
+func newSpawnTestServer(t *testing.T, agentName string) *MultiAgentServer {
+	t.Helper()
+	s := NewMultiAgentServer(nil, nil)
+	s.SetSessionBackend(newMemorySessionBackend())
+
+	tmpDir := t.TempDir()
+	registry, err := agent.NewRegistry(agent.RegistryConfig{
+		ConfigDir:   tmpDir,
+		DBPath:      filepath.Join(tmpDir, "test.db"),
+		LLMProvider: &mockLLMForMultiAgent{},
+	})
+	require.NoError(t, err)
+	registry.RegisterConfig(&loomv1.AgentConfig{Name: agentName, Llm: &loomv1.LLMConfig{}})
+	s.SetAgentRegistry(registry)
+	t.Cleanup(func() {
+		s.spawnedAgentsMu.RLock()
+		sessionIDs := make([]string, 0, len(s.spawnedAgents))
+		for sessionID := range s.spawnedAgents {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+		s.spawnedAgentsMu.RUnlock()
+		for _, sessionID := range sessionIDs {
+			s.cleanupSpawnedAgentWithin(sessionID, cleanupReasonDespawned, 0)
+		}
+		_ = registry.Close()
+	})
+	return s
+}
+
+// saveTestParent stores a top-level session for spawns to use as their parent.
+func saveTestParent(t *testing.T, s *MultiAgentServer, sessionID string) {
+	t.Helper()
+	now := time.Now()
+	require.NoError(t, s.sessionStore.SaveSession(context.Background(), &agent.Session{ID: sessionID, AgentID: "coordinator", CreatedAt: now, UpdatedAt: now}))
+}
+
+// spawnConcurrently runs the requests at once, returning their responses and errors
+// in request order.
+func spawnConcurrently(s *MultiAgentServer, reqs []*builtin.SpawnSubAgentRequest) ([]*builtin.SpawnSubAgentResponse, []error) {
+	resps := make([]*builtin.SpawnSubAgentResponse, len(reqs))
+	errs := make([]error, len(reqs))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			resps[i], errs[i] = s.SpawnSubAgent(context.Background(), req)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return resps, errs
+}
+
+func TestSpawnSubAgent_WorkflowLimitConcurrent(t *testing.T) {
+	s := newSpawnTestServer(t, "rogue")
+	s.SetMaxSpawnsPerWorkflow(3)
+
+	// Different parents, so only the workflow quota applies
+	reqs := make([]*builtin.SpawnSubAgentRequest, 12)
+	for i := range reqs {
+		parent := fmt.Sprintf("parent-%d", i)
+		saveTestParent(t, s, parent)
+		reqs[i] = &builtin.SpawnSubAgentRequest{ParentSessionID: parent, AgentID: "rogue", WorkflowID: "dungeon-crawl"}
+	}
+	_, errs := spawnConcurrently(s, reqs)
+
+	spawned := 0
+	for _, err := range errs {
+		if err == nil {
+			spawned++
+			continue
+		}
+		assert.ErrorIs(t, err, builtin.ErrSpawnLimitReached)
+	}
+	assert.Equal(t, 3, spawned)
+	assert.Equal(t, 3, s.spawnedAgentCount())
+}
+
+func TestSpawnSubAgent_GlobalLimitConcurrent(t *testing.T) {
+	s := newSpawnTestServer(t, "rogue")
+	s.SetMaxSpawnedAgents(4)
+
+	// Different parents and workflows, so only the global cap applies
+	reqs := make([]*builtin.SpawnSubAgentRequest, 12)
+	for i := range reqs {
+		parent := fmt.Sprintf("parent-%d", i)
+		saveTestParent(t, s, parent)
+		reqs[i] = &builtin.SpawnSubAgentRequest{ParentSessionID: parent, AgentID: "rogue", WorkflowID: fmt.Sprintf("wf-%d", i)}
+	}
+	_, errs := spawnConcurrently(s, reqs)
+
+	spawned := 0
+	for _, err := range errs {
+		if err == nil {
+			spawned++
+			continue
+		}
+		assert.ErrorIs(t, err, builtin.ErrSpawnLimitReached)
+		assert.ErrorContains(t, err, "global max: 4")
+	}
+	assert.Equal(t, 4, spawned)
+	assert.Equal(t, 4, s.spawnedAgentCount())
+}
+
+func TestSpawnSubAgent_SessionID(t *testing.T) {
+	store, err := agent.NewSessionStore(":memory:", observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := NewMultiAgentServer(nil, store)
+	ctx := context.Background()
+
+	tmpDir := t.TempDir()
+	registry, err := agent.NewRegistry(agent.RegistryConfig{
+		ConfigDir:   tmpDir,
+		DBPath:      filepath.Join(tmpDir, "test.db"),
+		LLMProvider: &mockLLMForMultiAgent{},
+	})
+	require.NoError(t, err)
+	defer registry.Close()
+	s.SetAgentRegistry(registry)
+
+	now := time.Now()
+	require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "parent-1", AgentID: "coordinator", CreatedAt: now, UpdatedAt: now}))
+
+	t.Run("running agent is returned", func(t *testing.T) {
+		trackTestSpawn(s, "parent-1", "wf:analyst", "sess-fixed")
+
+		resp, err := s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst", WorkflowID: "wf", SessionID: "sess-fixed"})
+		require.NoError(t, err)
+		assert.Equal(t, "already_spawned", resp.Status)
+		assert.Equal(t, "wf:analyst", resp.SubAgentID)
+		assert.Equal(t, "sess-fixed", resp.SessionID)
+		assert.Equal(t, 1, s.countSpawnedAgentsByParent("parent-1"))
+	})
+
+	t.Run("running agent conflicts", func(t *testing.T) {
+		_, err := s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "writer", WorkflowID: "wf", SessionID: "sess-fixed"})
+		assert.ErrorIs(t, err, builtin.ErrSessionIDConflict)
+		_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-2", AgentID: "analyst", WorkflowID: "wf", SessionID: "sess-fixed"})
+		assert.ErrorIs(t, err, builtin.ErrSessionIDConflict)
+	})
+
+	t.Run("stored session", func(t *testing.T) {
+		require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "sess-stored", AgentID: "analyst", ParentSessionID: "parent-1", CreatedAt: now, UpdatedAt: now}))
+
+		resp, stored, err := s.resolveRequestedSession(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst", SessionID: "sess-stored"})
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+		require.NotNil(t, stored, "restarted spawn reuses the stored session")
+		assert.Equal(t, "sess-stored", stored.ID)
+
+		_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "writer", SessionID: "sess-stored"})
+		assert.ErrorIs(t, err, builtin.ErrSessionIDConflict)
+	})
+
+	t.Run("unused ID", func(t *testing.T) {
+		resp, stored, err := s.resolveRequestedSession(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst", SessionID: "sess-new"})
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+		assert.Nil(t, stored)
+	})
+}
+
+func TestSpawnSubAgent_SessionIDConcurrent(t *testing.T) {
+	s := newSpawnTestServer(t, "analyst")
+	saveTestParent(t, s, "parent-1")
+
+	reqs := make([]*builtin.SpawnSubAgentRequest, 8)
+	for i := range reqs {
+		reqs[i] = &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst", WorkflowID: "wf", SessionID: "sess-fixed"}
+	}
+	resps, errs := spawnConcurrently(s, reqs)
+
+	spawned := 0
+	for i, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, builtin.ErrSessionIDConflict)
+			continue
+		}
+		if resps[i].Status == "spawned" {
+			spawned++
+		} else {
+			assert.Equal(t, "already_spawned", resps[i].Status)
+		}
+	}
+	assert.Equal(t, 1, spawned, "exactly one spawn builds an agent")
+
+	s.spawnedAgentsMu.RLock()
+	defer s.spawnedAgentsMu.RUnlock()
+	assert.Len(t, s.spawnedAgents, 1)
+	assert.Empty(t, s.pendingSpawns)
+	require.Contains(t, s.spawnedAgents, "sess-fixed")
+	assert.NotNil(t, s.spawnedAgents["sess-fixed"].agent)
+}
+
+func TestCleanupSpawnedAgent_Drain(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	var reasons []string
+	s.SetOnSpawnedAgentExit(func(exit SpawnedAgentExit) { reasons = append(reasons, exit.Reason) })
+
+	busy := func(sessionID string) (context.Context, *spawnedAgentContext) {
+		loopCtx := trackTestSpawn(s, "parent", "wf:writer", sessionID)
+		s.spawnedAgentsMu.RLock()
+		spawned := s.spawnedAgents[sessionID]
+		s.spawnedAgentsMu.RUnlock()
+		spawned.turnMu.Lock() // a turn is in progress
+		return loopCtx, spawned
+	}
+
+	t.Run("turn finishes within timeout", func(t *testing.T) {
+		s.SetSpawnDrainTimeout(5 * time.Second)
+		loopCtx, spawned := busy("sess-drain")
+
+		done := make(chan struct{})
+		go func() {
+			s.cleanupSpawnedAgent("sess-drain", "despawned by parent")
+			close(done)
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		assert.True(t, spawned.draining.Load(), "new work is refused during drain")
+		assert.NoError(t, loopCtx.Err(), "current turn is not cancelled while draining")
+
+		spawned.turnMu.Unlock()
+		<-done
+		assert.Error(t, loopCtx.Err())
+		assert.Equal(t, "despawned by parent (drained)", reasons[len(reasons)-1])
+	})
+
+	t.Run("turn overruns timeout", func(t *testing.T) {
+		s.SetSpawnDrainTimeout(100 * time.Millisecond)
+		loopCtx, spawned := busy("sess-overrun")
+		defer spawned.turnMu.Unlock()
+
+		s.cleanupSpawnedAgent("sess-overrun", "auto-despawn: inactivity timeout")
+		assert.Error(t, loopCtx.Err(), "overrunning turn is cancelled")
+		assert.Contains(t, reasons[len(reasons)-1], "drain timed out")
+	})
+
+	t.Run("zero timeout cancels immediately", func(t *testing.T) {
+		s.SetSpawnDrainTimeout(0)
+		_, spawned := busy("sess-immediate")
+		defer spawned.turnMu.Unlock()
+
+		s.cleanupSpawnedAgent("sess-immediate", "parent session ended")
+		assert.Equal(t, "parent session ended (drain disabled, turn cancelled)", reasons[len(reasons)-1])
+	})
+}
+
+func TestCleanupSpawnedAgent_SpawnTree(t *testing.T) {
+	tree := func(s *MultiAgentServer) []context.Context {
+		return []context.Context{
+			trackTestSpawn(s, "root", "wf:planner", "sess-parent"),
+			trackTestSpawn(s, "sess-parent", "wf:analyst", "sess-child"),
+			trackTestSpawn(s, "sess-child", "wf:writer", "sess-grandchild"),
+		}
+	}
+
+	t.Run("despawn", func(t *testing.T) {
+		s := NewMultiAgentServer(nil, nil)
+		var mu sync.Mutex
+		var exited []string
+		s.SetOnSpawnedAgentExit(func(exit SpawnedAgentExit) {
+			mu.Lock()
+			defer mu.Unlock()
+			exited = append(exited, exit.SessionID)
+		})
+		loops := tree(s)
+
+		s.cleanupSpawnedAgent("sess-parent", cleanupReasonDespawned)
+
+		for i, loopCtx := range loops {
+			assert.Error(t, loopCtx.Err(), "agent %d of the tree is cancelled", i)
+		}
+		s.spawnedAgentsMu.RLock()
+		assert.Empty(t, s.spawnedAgents)
+		s.spawnedAgentsMu.RUnlock()
+		assert.Equal(t, []string{"sess-grandchild", "sess-child", "sess-parent"}, exited, "children are cleaned up first")
+	})
+
+	t.Run("shutdown reports every agent in the tree", func(t *testing.T) {
+		s := NewMultiAgentServer(nil, nil)
+		loops := tree(s)
+
+		summary, err := s.Shutdown(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sess-child", "sess-grandchild", "sess-parent"}, summary.Drained)
+		for _, loopCtx := range loops {
+			assert.Error(t, loopCtx.Err())
+		}
+	})
+}
+
+func TestCleanupSpawnedAgent_Unsubscribes(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	s.messageBus = communication.NewMessageBus(nil, nil, nil, nil)
+	defer s.messageBus.Close()
+	ctx := context.Background()
+
+	trackTestSpawn(s, "parent", "wf:writer", "sess-1")
+	trackTestSpawn(s, "parent", "wf:writer", "sess-2")
+	auto, err := s.messageBus.Subscribe(ctx, "wf:writer", "party-chat", nil, 10)
+	require.NoError(t, err)
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents["sess-1"].subscriptionIDs = []string{auto.ID}
+	s.spawnedAgentsMu.Unlock()
+	// Subscribed by the agent itself, so not in subscriptionIDs
+	_, err = s.messageBus.Subscribe(ctx, "wf:writer", "loot.*", nil, 10)
+	require.NoError(t, err)
+
+	// Another spawn of the same sub-agent keeps the subscriptions it shares
+	s.cleanupSpawnedAgent("sess-1", "despawned by parent")
+	assert.Len(t, s.messageBus.GetSubscriptionsByAgent("wf:writer"), 1)
+
+	s.cleanupSpawnedAgent("sess-2", "despawned by parent")
+	assert.Empty(t, s.messageBus.GetSubscriptionsByAgent("wf:writer"))
+}
+
+func TestDeleteSession_CleansUpSpawnedChildren(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	loopCtx := trackTestSpawn(s, "parent", "wf:writer", "sess-child")
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = s.DeleteSession(context.Background(), &loomv1.DeleteSessionRequest{SessionId: "parent"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("DeleteSession did not return")
+	}
+	assert.Error(t, loopCtx.Err())
+	assert.Equal(t, 0, s.countSpawnedAgentsByParent("parent"))
+}
+
+func TestSessionExpiry_CleansUpSpawnedChildren(t *testing.T) {
+	store, err := agent.NewSessionStore(filepath.Join(t.TempDir(), "sessions.db"), observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+	s := NewMultiAgentServer(nil, store)
+
+	ctx := context.Background()
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "parent", CreatedAt: old, UpdatedAt: old, Context: map[string]interface{}{}}))
+	loopCtx := trackTestSpawn(s, "parent", "wf:writer", "sess-child")
+
+	store.SetSessionTTL(time.Hour, time.Hour)
+	n, err := store.ExpireSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Error(t, loopCtx.Err(), "spawned child is cleaned up")
+	assert.Equal(t, 0, s.countSpawnedAgentsByParent("parent"))
+}
+
+func TestSetSessionBackend_RegistersExpiryHook(t *testing.T) {
+	store, err := agent.NewSessionStore(filepath.Join(t.TempDir(), "sessions.db"), observability.NewNoOpTracer())
+	require.NoError(t, err)
+	defer store.Close()
+	s := NewMultiAgentServer(nil, nil)
+	s.SetSessionBackend(store)
+
+	ctx := context.Background()
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, store.SaveSession(ctx, &agent.Session{ID: "parent", CreatedAt: old, UpdatedAt: old, Context: map[string]interface{}{}}))
+	loopCtx := trackTestSpawn(s, "parent", "wf:writer", "sess-child")
+
+	store.SetSessionTTL(time.Hour, time.Hour)
+	_, err = store.ExpireSessions(ctx)
+	require.NoError(t, err)
+	assert.Error(t, loopCtx.Err(), "spawned child is cleaned up")
+	assert.Equal(t, 0, s.countSpawnedAgentsByParent("parent"))
+}
+
+func TestMonitorSpawnedAgent_SessionGone(t *testing.T) {
+	backend := newMemorySessionBackend()
+	s := NewMultiAgentServer(nil, nil)
+	s.SetSessionBackend(backend)
+	ctx := context.Background()
+	require.NoError(t, backend.SaveSession(ctx, &agent.Session{ID: "sess-a", AgentID: "analyst", UpdatedAt: time.Now()}))
+
+	loopCtx := trackTestSpawn(s, "parent", "wf:analyst", "sess-a")
+	s.spawnedAgentsMu.Lock()
+	s.spawnedAgents["sess-a"].autoDespawnTimeout = time.Hour
+	s.spawnedAgents["sess-a"].pollInterval = 20 * time.Millisecond
+	s.spawnedAgentsMu.Unlock()
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.monitorSpawnedAgent(monitorCtx, "sess-a")
+
+	// Another instance sharing the backend deletes the session
+	require.NoError(t, backend.DeleteSession(ctx, "sess-a"))
+	assert.Eventually(t, func() bool { return loopCtx.Err() != nil }, 5*time.Second, 20*time.Millisecond)
+	assert.Zero(t, s.spawnedAgentCount())
+}
+
+func TestDrainSpawnedAgent_Idle(t *testing.T) {
+	assert.True(t, drainSpawnedAgent(&spawnedAgentContext{}, 0), "idle agent drains even without a timeout")
+}
+
+func TestSpawnTracing(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	tracer := observability.NewMockTracer()
+	s.SetTracer(tracer)
+	ctx := context.Background()
+
+	t.Run("failed spawn ends its span", func(t *testing.T) {
+		_, err := s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1"})
+		require.ErrorIs(t, err, builtin.ErrInvalidSpawnRequest)
+
+		span := tracer.GetSpanByName(observability.SpanAgentSpawn)
+		require.NotNil(t, span)
+		assert.Equal(t, "invalid_request", span.Attributes[attrSpawnStatus])
+		assert.Equal(t, observability.StatusError, span.Status.Code)
+	})
+
+	t.Run("spawned agent span ends on cleanup", func(t *testing.T) {
+		tracer.Reset()
+		parentCtx, parent := tracer.StartSpan(ctx, "parent.tool")
+		_, spawnTracer, span := s.startSpawnSpan(parentCtx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst"})
+		require.NotNil(t, span)
+		trackTestSpawn(s, "parent-1", "wf:analyst", "sess-traced")
+		s.spawnedAgentsMu.Lock()
+		spawned := s.spawnedAgents["sess-traced"]
+		spawned.tracer, spawned.span = spawnTracer, span
+		s.spawnedAgentsMu.Unlock()
+
+		// A message without trace context is handled under the spawn span
+		loopCtx := observability.ContextWithSpan(spawned.loopCtx, span) // as spawnSubAgent sets it up
+		msgCtx, msgSpan := s.startMessageSpan(loopCtx, spawned, &loomv1.BusMessage{Id: "m1"})
+		assert.Equal(t, "sess-traced", msgSpan.Attributes[observability.AttrSessionID])
+		assert.Equal(t, "wf:analyst", msgSpan.Attributes[observability.AttrAgentID])
+		assert.Equal(t, parent.TraceID, msgSpan.TraceID)
+
+		// One carrying a publisher's trace continues that trace
+		pubCtx, publisher := tracer.StartSpan(ctx, "bus.publish")
+		_, remote := s.startMessageSpan(msgCtx, spawned, &loomv1.BusMessage{
+			Id:       "m2",
+			Metadata: observability.InjectTraceContext(pubCtx, nil),
+		})
+		assert.Equal(t, publisher.TraceID, remote.TraceID)
+		assert.Equal(t, publisher.SpanID, remote.ParentID)
+
+		_, err := s.DespawnSubAgent(ctx, &builtin.DespawnSubAgentRequest{ParentSessionID: "parent-1", SessionID: "sess-traced"})
+		require.NoError(t, err)
+
+		ended := tracer.GetSpanByName(observability.SpanAgentSpawn)
+		require.NotNil(t, ended, "spawn span has ended")
+		assert.Equal(t, parent.SpanID, ended.ParentID)
+		assert.Contains(t, ended.Attributes[attrSpawnExitReason], cleanupReasonDespawned)
+		require.NotEmpty(t, ended.Events)
+		assert.Equal(t, "spawn.exited", ended.Events[len(ended.Events)-1].Name)
+		assert.Nil(t, spawned.endSpan("again"), "span ends once")
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/teradata-labs/loom/pkg/agent"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+// SetRequireSpawnAllowlist sets the policy for agents without a can_spawn allowlist.
+// When require is false (the default), they may spawn any agent in the registry; when
+// true, they may not spawn at all, so every spawning agent must list what it may spawn.
+// Agents with an allowlist may only spawn the agents it matches either way.
+func (s *MultiAgentServer) SetRequireSpawnAllowlist(require bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requireSpawnAllowlist = require
+}
+
+// checkSpawnPermitted returns an error wrapping builtin.ErrSpawnNotPermitted if the
+// spawning agent's allowlist does not include req.AgentID. The requested agent matches
+// by the ID given or, if the registry resolves it, by its config name.
+func (s *MultiAgentServer) checkSpawnPermitted(req *builtin.SpawnSubAgentRequest, registry *agent.Registry, requireAllowlist bool) error {
+	parent, allowlist, err := s.spawnAllowlist(req)
+	if err != nil {
+		return err
+	}
+	if len(allowlist) == 0 {
+		if requireAllowlist {
+			return fmt.Errorf("%w %s: %s has no can_spawn allowlist and the server requires one", builtin.ErrSpawnNotPermitted, req.AgentID, parent)
+		}
+		return nil
+	}
+
+	candidates := []string{req.AgentID}
+	if info, err := registry.GetAgentInfo(req.AgentID); err == nil && info.Name != req.AgentID {
+		candidates = append(candidates, info.Name)
+	}
+	for _, pattern := range allowlist {
+		for _, id := range candidates {
+			if matched, err := path.Match(pattern, id); err == nil && matched {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w %s: not in the can_spawn allowlist of %s %v", builtin.ErrSpawnNotPermitted, req.AgentID, parent, allowlist)
+}
+
+// spawnAllowlist returns the spawning agent and its can_spawn patterns: the spawned agent
+// owning the parent session if there is one, otherwise the parent agent. The allowlist
+// is nil when no parent agent is given or it has none. A parent agent ID that does not
+// resolve is an error, so a stale or forged ID cannot bypass the allowlist.
+func (s *MultiAgentServer) spawnAllowlist(req *builtin.SpawnSubAgentRequest) (string, []string, error) {
+	s.spawnedAgentsMu.RLock()
+	spawned, ok := s.spawnedAgents[req.ParentSessionID]
+	s.spawnedAgentsMu.RUnlock()
+	if ok && spawned.agent != nil {
+		return fmt.Sprintf("spawned agent %s", spawned.subAgentID), spawned.agent.GetConfig().CanSpawn, nil
+	}
+
+	parent := fmt.Sprintf("agent %s", req.ParentAgentID)
+	if req.ParentAgentID == "" {
+		return "unknown parent agent", nil, nil
+	}
+	ag, _, err := s.getAgent(req.ParentAgentID)
+	if err != nil {
+		return parent, nil, fmt.Errorf("%w %s: cannot resolve parent %s: %v", builtin.ErrSpawnNotPermitted, req.AgentID, parent, err)
+	}
+	return parent, ag.GetConfig().CanSpawn, nil
+}
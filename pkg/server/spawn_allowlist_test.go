@@ -0,0 +1,58 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teradata-labs/loom/pkg/agent"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+func TestSpawnSubAgent_Allowlist(t *testing.T) {
+	triage := agent.NewAgent(nil, nil, agent.WithName("triage"), agent.WithCanSpawn([]string{"sql-analyst", "report-*"}))
+	open := agent.NewAgent(nil, nil, agent.WithName("open"))
+	s := NewMultiAgentServer(map[string]*agent.Agent{"triage": triage, "open": open}, nil)
+	withEmptyRegistry(t, s)
+	ctx := context.Background()
+
+	spawn := func(parentAgentID, agentID string) error {
+		_, err := s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", ParentAgentID: parentAgentID, AgentID: agentID})
+		return err
+	}
+
+	// Permitted spawns get past the check and fail on the empty registry
+	assert.ErrorIs(t, spawn("triage", "sql-analyst"), builtin.ErrAgentNotFound)
+	assert.ErrorIs(t, spawn("triage", "report-weekly"), builtin.ErrAgentNotFound)
+
+	err := spawn("triage", "admin")
+	assert.ErrorIs(t, err, builtin.ErrSpawnNotPermitted)
+	assert.ErrorContains(t, err, "can_spawn allowlist of agent triage")
+	assert.Equal(t, "not_permitted", spawnStatus(nil, err))
+
+	// A parent agent that does not resolve is denied rather than treated as unrestricted
+	err = spawn("ghost", "admin")
+	assert.ErrorIs(t, err, builtin.ErrSpawnNotPermitted)
+	assert.ErrorContains(t, err, "cannot resolve parent agent ghost")
+	assert.Equal(t, "not_permitted", spawnStatus(nil, err))
+
+	// Without an allowlist the server policy decides
+	assert.ErrorIs(t, spawn("open", "admin"), builtin.ErrAgentNotFound)
+	assert.ErrorIs(t, spawn("", "admin"), builtin.ErrAgentNotFound)
+	s.SetRequireSpawnAllowlist(true)
+	assert.ErrorIs(t, spawn("open", "admin"), builtin.ErrSpawnNotPermitted)
+	assert.ErrorIs(t, spawn("", "admin"), builtin.ErrSpawnNotPermitted)
+	assert.ErrorIs(t, spawn("triage", "sql-analyst"), builtin.ErrAgentNotFound)
+
+	// A spawned agent spawning further is held to its own allowlist
+	trackTestSpawn(s, "parent-1", "triage-spawn:sql-analyst", "sess-analyst")
+	s.spawnedAgents["sess-analyst"].agent = agent.NewAgent(nil, nil, agent.WithName("sql-analyst"), agent.WithCanSpawn([]string{"report-*"}))
+	_, err = s.SpawnSubAgent(ctx, &builtin.SpawnSubAgentRequest{ParentSessionID: "sess-analyst", ParentAgentID: "triage", AgentID: "sql-analyst"})
+	assert.ErrorIs(t, err, builtin.ErrSpawnNotPermitted)
+	assert.ErrorContains(t, err, "spawned agent triage-spawn:sql-analyst")
+}
@@ -0,0 +1,139 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"go.uber.org/zap"
+)
+
+// SpawnLifecycleTopic is the reserved bus topic spawn lifecycle events are published to.
+// Supervising agents subscribe to it (e.g. with auto_subscribe) to observe the fleet.
+// ConfigureCommunication reserves it on the bus, so only the server publishes to it.
+const SpawnLifecycleTopic = "loom.agents.lifecycle"
+
+// Spawn lifecycle events, in SpawnLifecycleEvent.Event.
+const (
+	SpawnLifecycleSpawned   = "spawned"    // The agent was spawned and is running
+	SpawnLifecycleExpired   = "expired"    // The agent hit its inactivity timeout; cleaned_up follows
+	SpawnLifecycleCleanedUp = "cleaned_up" // The agent was stopped and is no longer tracked
+)
+
+// spawnLifecycleSource is the FromAgent of lifecycle events.
+const spawnLifecycleSource = "loom-server"
+
+// spawnLifecyclePublishTimeout bounds how long publishing an event may block on
+// subscribers with a blocking backpressure policy.
+const spawnLifecyclePublishTimeout = 5 * time.Second
+
+// SpawnLifecycleEvent is the JSON payload of a message on SpawnLifecycleTopic. The event,
+// session ID, sub-agent ID, and workflow ID are also set as message metadata so
+// subscriptions can filter on them.
+type SpawnLifecycleEvent struct {
+	Event           string    `json:"event"` // SpawnLifecycleSpawned, SpawnLifecycleExpired, ...
+	SessionID       string    `json:"session_id"`
+	SubAgentID      string    `json:"sub_agent_id"`
+	ParentSessionID string    `json:"parent_session_id"`
+	ParentAgentID   string    `json:"parent_agent_id,omitempty"`
+	WorkflowID      string    `json:"workflow_id,omitempty"`
+	Reason          string    `json:"reason,omitempty"` // Why the agent expired or was cleaned up
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+type spawnLifecycleContextKey struct{}
+
+// withSpawnLifecycleSuppressed marks ctx as handling a lifecycle event. Agents spawned
+// under it publish no lifecycle events, so a subscriber that spawns agents in reaction
+// to events cannot trigger itself.
+func withSpawnLifecycleSuppressed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, spawnLifecycleContextKey{}, true)
+}
+
+// spawnLifecycleSuppressed reports whether ctx is handling a lifecycle event.
+func spawnLifecycleSuppressed(ctx context.Context) bool {
+	suppressed, _ := ctx.Value(spawnLifecycleContextKey{}).(bool)
+	return suppressed
+}
+
+// suppressSpawnLifecycle reports whether an agent spawned under ctx for parentSessionID
+// should publish no lifecycle events: the spawn happens while handling a lifecycle event,
+// or the parent is itself such an agent, so a chain of spawns started by an event stays
+// silent.
+func (s *MultiAgentServer) suppressSpawnLifecycle(ctx context.Context, parentSessionID string) bool {
+	if spawnLifecycleSuppressed(ctx) {
+		return true
+	}
+	s.spawnedAgentsMu.RLock()
+	defer s.spawnedAgentsMu.RUnlock()
+	parent, ok := s.spawnedAgents[parentSessionID]
+	return ok && parent.lifecycleSuppressed
+}
+
+// publishSpawnLifecycle publishes a lifecycle event for a spawned agent to
+// SpawnLifecycleTopic. It does nothing without a message bus or for agents whose events
+// are suppressed; publish failures are logged and otherwise ignored.
+func (s *MultiAgentServer) publishSpawnLifecycle(ctx context.Context, spawned *spawnedAgentContext, event, reason string) {
+	s.mu.RLock()
+	bus := s.messageBus
+	logger := s.logger
+	s.mu.RUnlock()
+	if bus == nil || spawned.lifecycleSuppressed {
+		return
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(SpawnLifecycleEvent{
+		Event:           event,
+		SessionID:       spawned.subSessionID,
+		SubAgentID:      spawned.subAgentID,
+		ParentSessionID: spawned.parentSessionID,
+		ParentAgentID:   spawned.parentAgentID,
+		WorkflowID:      spawned.workflowID,
+		Reason:          reason,
+		Timestamp:       now,
+	})
+	if err != nil {
+		logger.Warn("Failed to marshal spawn lifecycle event", zap.Error(err))
+		return
+	}
+
+	msg := &loomv1.BusMessage{
+		Id:        fmt.Sprintf("lifecycle-%s-%s-%d", event, spawned.subSessionID, now.UnixNano()),
+		Topic:     SpawnLifecycleTopic,
+		FromAgent: spawnLifecycleSource,
+		Payload: &loomv1.MessagePayload{
+			Data: &loomv1.MessagePayload_Value{Value: payload},
+			Metadata: &loomv1.PayloadMetadata{
+				SizeBytes:   int64(len(payload)),
+				ContentType: "application/json",
+			},
+		},
+		Metadata: map[string]string{
+			"event":        event,
+			"session_id":   spawned.subSessionID,
+			"sub_agent_id": spawned.subAgentID,
+			"workflow_id":  spawned.workflowID,
+		},
+		Timestamp: now.UnixMilli(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), spawnLifecyclePublishTimeout)
+	defer cancel()
+	if _, _, err := bus.Publish(ctx, SpawnLifecycleTopic, msg); err != nil {
+		logger.Debug("Failed to publish spawn lifecycle event",
+			zap.String("event", event),
+			zap.String("session_id", spawned.subSessionID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/communication"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func receiveLifecycleEvent(t *testing.T, sub *communication.Subscription) (*loomv1.BusMessage, SpawnLifecycleEvent) {
+	t.Helper()
+	select {
+	case msg := <-sub.Channel:
+		var event SpawnLifecycleEvent
+		require.NoError(t, json.Unmarshal(msg.GetPayload().GetValue(), &event))
+		return msg, event
+	case <-time.After(time.Second):
+		t.Fatal("no lifecycle event published")
+		return nil, SpawnLifecycleEvent{}
+	}
+}
+
+func TestSpawnLifecycleEvents(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+	defer bus.Close()
+	s.messageBus = bus
+	sub, err := bus.Subscribe(context.Background(), "supervisor", SpawnLifecycleTopic, nil, 10)
+	require.NoError(t, err)
+
+	trackTestSpawn(s, "parent-1", "dungeon-crawl:rogue", "sess-a")
+	s.spawnedAgentsMu.Lock()
+	spawned := s.spawnedAgents["sess-a"]
+	spawned.workflowID = "dungeon-crawl"
+	s.spawnedAgentsMu.Unlock()
+
+	s.publishSpawnLifecycle(context.Background(), spawned, SpawnLifecycleSpawned, "")
+	msg, event := receiveLifecycleEvent(t, sub)
+	assert.Equal(t, SpawnLifecycleTopic, msg.Topic)
+	assert.Equal(t, SpawnLifecycleSpawned, msg.Metadata["event"])
+	assert.Equal(t, "dungeon-crawl", msg.Metadata["workflow_id"])
+	assert.Equal(t, SpawnLifecycleSpawned, event.Event)
+	assert.Equal(t, "sess-a", event.SessionID)
+	assert.Equal(t, "dungeon-crawl:rogue", event.SubAgentID)
+	assert.Equal(t, "parent-1", event.ParentSessionID)
+	assert.Equal(t, "dungeon-crawl", event.WorkflowID)
+
+	s.cleanupSpawnedAgentWithin("sess-a", cleanupReasonDespawned, 0)
+	_, event = receiveLifecycleEvent(t, sub)
+	assert.Equal(t, SpawnLifecycleCleanedUp, event.Event)
+	assert.Contains(t, event.Reason, cleanupReasonDespawned)
+}
+
+func TestSpawnLifecycleEvents_Suppressed(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+	defer bus.Close()
+	s.messageBus = bus
+	sub, err := bus.Subscribe(context.Background(), "supervisor", SpawnLifecycleTopic, nil, 10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.False(t, s.suppressSpawnLifecycle(ctx, "parent-1"))
+	assert.True(t, s.suppressSpawnLifecycle(withSpawnLifecycleSuppressed(ctx), "parent-1"),
+		"spawned while handling a lifecycle event")
+
+	// Agents spawned in reaction to an event, and their descendants, publish nothing
+	trackTestSpawn(s, "parent-1", "wf:reactor", "sess-reactor")
+	s.spawnedAgentsMu.Lock()
+	reactor := s.spawnedAgents["sess-reactor"]
+	reactor.lifecycleSuppressed = true
+	s.spawnedAgentsMu.Unlock()
+	assert.True(t, s.suppressSpawnLifecycle(ctx, "sess-reactor"))
+
+	s.publishSpawnLifecycle(ctx, reactor, SpawnLifecycleSpawned, "")
+	s.cleanupSpawnedAgentWithin("sess-reactor", cleanupReasonDespawned, 0)
+	select {
+	case msg := <-sub.Channel:
+		t.Fatalf("suppressed agent published %s", msg.Metadata["event"])
+	default:
+	}
+}
+
+func TestSpawnLifecycleTopic_Reserved(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+	defer bus.Close()
+	require.NoError(t, s.ConfigureCommunication(bus, nil, nil, nil, nil, zap.NewNop()))
+	ctx := context.Background()
+	sub, err := bus.Subscribe(ctx, "supervisor", SpawnLifecycleTopic, nil, 10)
+	require.NoError(t, err)
+
+	// Clients cannot publish to the topic, even claiming to be the server
+	_, err = s.Publish(ctx, &loomv1.PublishRequest{
+		Topic:   SpawnLifecycleTopic,
+		Message: &loomv1.BusMessage{Id: "forged", FromAgent: spawnLifecycleSource},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Neither can agents publishing on the bus directly
+	_, _, err = bus.Publish(ctx, SpawnLifecycleTopic, &loomv1.BusMessage{Id: "forged", FromAgent: "rogue"})
+	assert.ErrorIs(t, err, communication.ErrReservedTopic)
+	assert.Empty(t, sub.Channel)
+
+	// The server's own events still go out
+	trackTestSpawn(s, "parent-1", "wf:scout", "sess-scout")
+	s.publishSpawnLifecycle(ctx, s.spawnedAgents["sess-scout"], SpawnLifecycleSpawned, "")
+	msg, event := receiveLifecycleEvent(t, sub)
+	assert.Equal(t, spawnLifecycleSource, msg.FromAgent)
+	assert.Equal(t, "sess-scout", event.SessionID)
+}
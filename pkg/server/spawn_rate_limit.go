@@ -0,0 +1,83 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// spawnRateSweepInterval is how often buckets that have refilled are dropped, so parents
+// that stopped spawning do not keep a bucket forever.
+const spawnRateSweepInterval = time.Minute
+
+// spawnRateLimiter is a token bucket per parent session. Each spawn takes a token;
+// tokens refill at rate per second up to burst.
+type spawnRateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*spawnBucket
+	lastSweep time.Time
+}
+
+type spawnBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newSpawnRateLimiter(rate float64, burst int) *spawnRateLimiter {
+	return &spawnRateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		now:       time.Now,
+		buckets:   make(map[string]*spawnBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow takes a token from the parent's bucket. When the bucket is empty it returns false
+// and how long until a token is available.
+func (l *spawnRateLimiter) allow(parentSessionID string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= spawnRateSweepInterval {
+		for id, b := range l.buckets {
+			if l.refill(b, now) >= l.burst {
+				delete(l.buckets, id)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[parentSessionID]
+	if !ok {
+		b = &spawnBucket{tokens: l.burst, last: now}
+		l.buckets[parentSessionID] = b
+	}
+	if l.refill(b, now) >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	// Round up to the millisecond so the hint is readable and never too short
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, time.Duration(math.Ceil(float64(wait)/float64(time.Millisecond))) * time.Millisecond
+}
+
+// refill adds the tokens earned since the bucket was last updated and returns the total.
+func (l *spawnRateLimiter) refill(b *spawnBucket, now time.Time) float64 {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+		b.last = now
+	}
+	return b.tokens
+}
@@ -0,0 +1,75 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+func TestSpawnRateLimiter(t *testing.T) {
+	l := newSpawnRateLimiter(2, 3) // 2 per second, burst 3
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.allow("parent-1")
+		assert.True(t, ok, "burst spawn %d", i)
+	}
+	ok, retryAfter := l.allow("parent-1")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	ok, _ = l.allow("parent-2")
+	assert.True(t, ok, "parents have separate buckets")
+
+	now = now.Add(250 * time.Millisecond)
+	ok, retryAfter = l.allow("parent-1")
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+
+	now = now.Add(250 * time.Millisecond)
+	ok, _ = l.allow("parent-1")
+	assert.True(t, ok, "a token refilled")
+
+	// Refilled buckets are dropped on the next sweep
+	now = now.Add(spawnRateSweepInterval)
+	ok, _ = l.allow("parent-3")
+	assert.True(t, ok)
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestSpawnSubAgent_RateLimit(t *testing.T) {
+	s := NewMultiAgentServer(nil, nil)
+	withEmptyRegistry(t, s)
+	s.SetSpawnRateLimit(0.001, 2)
+	ctx := context.Background()
+	req := &builtin.SpawnSubAgentRequest{ParentSessionID: "parent-1", AgentID: "analyst"}
+
+	for i := 0; i < 2; i++ {
+		_, err := s.SpawnSubAgent(ctx, req)
+		assert.ErrorIs(t, err, builtin.ErrAgentNotFound, "within the burst the spawn proceeds")
+	}
+
+	_, err := s.SpawnSubAgent(ctx, req)
+	require.ErrorIs(t, err, builtin.ErrSpawnRateLimited)
+	var rateErr *builtin.SpawnRateLimitError
+	require.True(t, errors.As(err, &rateErr))
+	assert.Equal(t, "parent-1", rateErr.ParentSessionID)
+	assert.Greater(t, rateErr.RetryAfter, 15*time.Minute)
+	assert.Equal(t, "rate_limited", spawnStatus(nil, err))
+
+	s.SetSpawnRateLimit(0, 0)
+	_, err = s.SpawnSubAgent(ctx, req)
+	assert.ErrorIs(t, err, builtin.ErrAgentNotFound, "limit removed")
+}
@@ -0,0 +1,121 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	loomv1 "github.com/teradata-labs/loom/gen/go/loom/v1"
+	"github.com/teradata-labs/loom/pkg/observability"
+	"github.com/teradata-labs/loom/pkg/session"
+	"github.com/teradata-labs/loom/pkg/shuttle/builtin"
+)
+
+// Span attributes set on spawn spans.
+const (
+	attrSpawnParentSessionID = "spawn.parent_session_id"
+	attrSpawnParentAgentID   = "spawn.parent_agent_id"
+	attrSpawnWorkflowID      = "spawn.workflow_id"
+	attrSpawnStatus          = "spawn.status"
+	attrSpawnExitReason      = "spawn.exit_reason"
+	attrSpawnLifetime        = "spawn.lifetime_ms"
+)
+
+// serverTracer returns the tracer set by SetTracer, or nil when tracing is off.
+func (s *MultiAgentServer) serverTracer() observability.Tracer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tracer
+}
+
+// startSpawnSpan starts the span covering a spawned agent's lifetime, as a child of the
+// spawning call's span. Returns a nil span when tracing is off.
+func (s *MultiAgentServer) startSpawnSpan(ctx context.Context, req *builtin.SpawnSubAgentRequest) (context.Context, observability.Tracer, *observability.Span) {
+	tracer := s.serverTracer()
+	if tracer == nil || req == nil {
+		return ctx, nil, nil
+	}
+	ctx, span := tracer.StartSpan(ctx, observability.SpanAgentSpawn,
+		observability.WithSpanKind("agent"),
+		observability.WithAttribute(attrSpawnParentSessionID, req.ParentSessionID),
+		observability.WithAttribute(attrSpawnParentAgentID, req.ParentAgentID),
+		observability.WithAttribute(attrSpawnWorkflowID, req.WorkflowID),
+	)
+	return ctx, tracer, span
+}
+
+// finishSpawnSpan ends the spawn span of a SpawnSubAgent call that did not start a new
+// agent. A spawned agent owns its span, which ends when the agent is cleaned up.
+func (s *MultiAgentServer) finishSpawnSpan(tracer observability.Tracer, span *observability.Span, resp *builtin.SpawnSubAgentResponse, err error) {
+	if span == nil {
+		return
+	}
+	status := spawnStatus(resp, err)
+	if err == nil && status == "spawned" {
+		return
+	}
+	span.SetAttribute(attrSpawnStatus, status)
+	span.RecordError(err)
+	tracer.EndSpan(span)
+	s.RecordTraceSpan(span)
+}
+
+// annotateSpan adds an event to the spawned agent's span, if it is still open.
+func (sp *spawnedAgentContext) annotateSpan(name string, attrs map[string]interface{}) {
+	sp.spanMu.Lock()
+	defer sp.spanMu.Unlock()
+	if sp.span != nil {
+		sp.span.AddEvent(name, attrs)
+	}
+}
+
+// endSpan records why the spawned agent exited and ends its span. Returns the ended
+// span, or nil when tracing is off or the span was already ended.
+func (sp *spawnedAgentContext) endSpan(reason string) *observability.Span {
+	sp.spanMu.Lock()
+	defer sp.spanMu.Unlock()
+	span := sp.span
+	if span == nil {
+		return nil
+	}
+	sp.span = nil
+	span.SetAttribute(attrSpawnExitReason, reason)
+	span.SetAttribute(attrSpawnLifetime, time.Since(sp.spawnedAt).Milliseconds())
+	span.AddEvent("spawn.exited", map[string]interface{}{"reason": reason})
+	sp.tracer.EndSpan(span)
+	return span
+}
+
+// startMessageSpan starts the span for one message handled by a spawned agent. The
+// span continues the trace carried in the message metadata (the publisher's trace),
+// falling back to the agent's spawn span. The returned context also carries the
+// agent's session and agent IDs for the LLM spans started under it.
+func (s *MultiAgentServer) startMessageSpan(ctx context.Context, spawned *spawnedAgentContext, msg *loomv1.BusMessage) (context.Context, *observability.Span) {
+	ctx = observability.ExtractTraceContext(ctx, msg.Metadata)
+	ctx = session.WithAgentID(session.WithSessionID(ctx, spawned.subSessionID), spawned.subAgentID)
+	if spawned.tracer == nil {
+		return ctx, nil
+	}
+	return spawned.tracer.StartSpan(ctx, observability.SpanAgentSpawnMessage,
+		observability.WithSpanKind("agent"),
+		observability.WithAttribute(observability.AttrSessionID, spawned.subSessionID),
+		observability.WithAttribute(observability.AttrAgentID, spawned.subAgentID),
+		observability.WithAttribute("message.id", msg.Id),
+		observability.WithAttribute("message.topic", msg.Topic),
+		observability.WithAttribute("message.from_agent", msg.FromAgent),
+	)
+}
+
+// endMessageSpan ends a span started by startMessageSpan.
+func (s *MultiAgentServer) endMessageSpan(spawned *spawnedAgentContext, span *observability.Span, err error) {
+	if span == nil {
+		return
+	}
+	span.RecordError(err)
+	spawned.tracer.EndSpan(span)
+	s.RecordTraceSpan(span)
+}
@@ -24,6 +24,8 @@ func TestDNDBugPrevention(t *testing.T) {
 	tmpDir := t.TempDir()
 	oldLoomData := os.Getenv("LOOM_DATA_DIR")
 	os.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	defer config.ResetDataDirCache()
 	defer os.Setenv("LOOM_DATA_DIR", oldLoomData)
 
 	// Create weaver context
@@ -122,6 +124,8 @@ func TestCreateAgentStructured(t *testing.T) {
 	tmpDir := t.TempDir()
 	oldLoomData := os.Getenv("LOOM_DATA_DIR")
 	os.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	defer config.ResetDataDirCache()
 	defer os.Setenv("LOOM_DATA_DIR", oldLoomData)
 
 	// Create weaver context
@@ -202,6 +206,8 @@ func TestAgentReferenceValidation(t *testing.T) {
 	tmpDir := t.TempDir()
 	oldLoomData := os.Getenv("LOOM_DATA_DIR")
 	os.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	defer config.ResetDataDirCache()
 	defer os.Setenv("LOOM_DATA_DIR", oldLoomData)
 
 	// Create agents directory
@@ -291,6 +297,8 @@ func TestUpdateWorkflowValidation(t *testing.T) {
 	tmpDir := t.TempDir()
 	oldLoomData := os.Getenv("LOOM_DATA_DIR")
 	os.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	defer config.ResetDataDirCache()
 	defer os.Setenv("LOOM_DATA_DIR", oldLoomData)
 
 	// Create directories
@@ -78,6 +78,8 @@ func TestAgentManagementTool_CreateAgent(t *testing.T) {
 	// Use temp directory for testing
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	tests := []struct {
 		name        string
@@ -188,6 +190,8 @@ func TestAgentManagementTool_UpdateAgent(t *testing.T) {
 
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	// Create initial agent using new API
 	createParams := map[string]interface{}{
@@ -246,6 +250,8 @@ func TestAgentManagementTool_ReadAgent(t *testing.T) {
 
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	// Create test agent
 	agentsDir := config.GetLoomSubDir("agents")
@@ -285,6 +291,8 @@ func TestAgentManagementTool_ListAgents(t *testing.T) {
 
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	// Create multiple agents
 	agentsDir := config.GetLoomSubDir("agents")
@@ -379,6 +387,8 @@ func TestAgentManagementTool_DeleteAgent(t *testing.T) {
 
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	// Create test agent
 	agentsDir := config.GetLoomSubDir("agents")
@@ -411,6 +421,8 @@ func TestAgentManagementTool_WorkflowOperations(t *testing.T) {
 
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	// Create test agents first (workflows need to reference them)
 	agentsDir := config.GetLoomSubDir("agents")
@@ -486,6 +498,8 @@ func TestAgentManagementTool_ConcurrentOperations(t *testing.T) {
 
 	tmpDir := t.TempDir()
 	t.Setenv("LOOM_DATA_DIR", tmpDir)
+	config.ResetDataDirCache()
+	t.Cleanup(config.ResetDataDirCache)
 
 	// Create agent first using new API
 	params := map[string]interface{}{
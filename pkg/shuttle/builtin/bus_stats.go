@@ -0,0 +1,143 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package builtin
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/communication"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// BusStatsTool reports message bus statistics so operators and supervising agents can
+// diagnose why messages are not flowing.
+type BusStatsTool struct {
+	bus *communication.MessageBus
+}
+
+// NewBusStatsTool creates a new bus_stats tool.
+func NewBusStatsTool(bus *communication.MessageBus) *BusStatsTool {
+	return &BusStatsTool{bus: bus}
+}
+
+func (t *BusStatsTool) Name() string {
+	return "bus_stats"
+}
+
+// Description returns the tool description.
+func (t *BusStatsTool) Description() string {
+	return `Show message bus statistics: topics, subscribers, message counts, and buffer usage.
+
+Use this tool to:
+- Check whether anyone is subscribed to a topic before publishing
+- Find slow consumers (full buffers, dropped messages, unacknowledged messages)
+- Diagnose why agents are not receiving broadcasts
+
+Examples:
+- bus_stats()
+- bus_stats(topic="party")
+- bus_stats(agent_id="dungeon-crawl:fighter")`
+}
+
+func (t *BusStatsTool) InputSchema() *shuttle.JSONSchema {
+	return shuttle.NewObjectSchema(
+		"Parameters for bus statistics",
+		map[string]*shuttle.JSONSchema{
+			"topic":    shuttle.NewStringSchema("Optional: only include topics and subscriptions whose topic starts with this prefix"),
+			"agent_id": shuttle.NewStringSchema("Optional: only include subscriptions of this agent"),
+		},
+		nil,
+	)
+}
+
+func (t *BusStatsTool) Execute(ctx context.Context, params map[string]interface{}) (*shuttle.Result, error) {
+	start := time.Now()
+
+	if t.bus == nil {
+		return &shuttle.Result{
+			Success: false,
+			Error: &shuttle.Error{
+				Code:       "BUS_NOT_AVAILABLE",
+				Message:    "Message bus not configured for this agent",
+				Suggestion: "Pub-sub communication requires MessageBus configured in server",
+			},
+			ExecutionTimeMs: time.Since(start).Milliseconds(),
+		}, nil
+	}
+
+	topicPrefix, _ := params["topic"].(string)
+	agentID, _ := params["agent_id"].(string)
+
+	stats := t.bus.Stats()
+	now := time.Now()
+
+	topics := make([]map[string]interface{}, 0, len(stats.Topics))
+	for _, ts := range stats.Topics {
+		if !strings.HasPrefix(ts.Topic, topicPrefix) {
+			continue
+		}
+		topic := map[string]interface{}{
+			"topic":              ts.Topic,
+			"subscribers":        ts.ActiveSubscribers,
+			"published":          ts.TotalPublished,
+			"delivered":          ts.TotalDelivered,
+			"dropped":            ts.TotalDropped,
+			"published_per_hour": perHour(ts.TotalPublished, time.UnixMilli(ts.CreatedAt), now),
+		}
+		if ts.LastPublishAt > 0 {
+			topic["last_publish_at"] = time.UnixMilli(ts.LastPublishAt).Format(time.RFC3339)
+		}
+		topics = append(topics, topic)
+	}
+
+	subscriptions := make([]map[string]interface{}, 0, len(stats.Subscriptions))
+	for _, ss := range stats.Subscriptions {
+		if !strings.HasPrefix(ss.Topic, topicPrefix) || (agentID != "" && ss.AgentID != agentID) {
+			continue
+		}
+		subscriptions = append(subscriptions, map[string]interface{}{
+			"subscription_id": ss.ID,
+			"agent_id":        ss.AgentID,
+			"topic":           ss.Topic,
+			"buffered":        ss.Buffered,
+			"capacity":        ss.Capacity,
+			"dropped":         ss.Dropped,
+			"pending_acks":    ss.PendingAcks,
+			"backpressure":    ss.Backpressure.String(),
+		})
+	}
+
+	return &shuttle.Result{
+		Success: true,
+		Data: map[string]interface{}{
+			"total_published":     stats.TotalPublished,
+			"total_delivered":     stats.TotalDelivered,
+			"total_dropped":       stats.TotalDropped,
+			"total_redelivered":   stats.TotalRedelivered,
+			"total_dead_lettered": stats.TotalDeadLettered,
+			"topic_count":         len(topics),
+			"topics":              topics,
+			"subscription_count":  len(subscriptions),
+			"subscriptions":       subscriptions,
+		},
+		ExecutionTimeMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+func (t *BusStatsTool) Backend() string {
+	return "" // Backend-agnostic
+}
+
+// perHour returns how many events per hour count represents since the given time.
+func perHour(count int64, since, now time.Time) float64 {
+	elapsed := now.Sub(since).Hours()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(count) / elapsed
+}
@@ -495,16 +495,113 @@ func TestCommunicationToolsWithNilInfrastructure(t *testing.T) {
 	})
 }
 
+func TestPublishTool(t *testing.T) {
+	bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+	defer bus.Close()
+	ctx := context.Background()
+
+	sub, err := bus.Subscribe(ctx, "fighter", "party.chat", nil, 4)
+	require.NoError(t, err)
+	_, err = bus.Subscribe(ctx, "bard", "party.*", nil, 4)
+	require.NoError(t, err)
+
+	tool := NewPublishTool(bus, "dungeon-master")
+	result, err := tool.Execute(ctx, map[string]interface{}{
+		"topic":    "party.chat",
+		"message":  "A door creaks open",
+		"metadata": map[string]interface{}{"scene": "crypt", "round": float64(3)},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, 2, data["delivered"])
+	assert.Equal(t, 0, data["dropped"])
+
+	msg := <-sub.Channel
+	assert.Equal(t, "dungeon-master", msg.FromAgent)
+	assert.Equal(t, "A door creaks open", string(msg.Payload.GetValue()))
+	assert.Equal(t, map[string]string{"scene": "crypt", "round": "3"}, msg.Metadata)
+
+	result, err = tool.Execute(ctx, map[string]interface{}{"topic": "", "message": "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "INVALID_TOPIC", result.Error.Code)
+
+	result, err = NewPublishTool(nil, "dungeon-master").Execute(ctx, map[string]interface{}{"topic": "party.chat", "message": "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "BUS_NOT_AVAILABLE", result.Error.Code)
+
+	// Dry run validates and describes the publish without delivering it
+	result, err = tool.DryRun(ctx, map[string]interface{}{"topic": "party.chat", "message": "hi"})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Equal(t, "would publish a 2-byte message to topic 'party.chat'", result.Data.(map[string]interface{})["plan"])
+	select {
+	case msg := <-sub.Channel:
+		t.Fatalf("dry run delivered a message: %v", msg)
+	default:
+	}
+
+	result, err = tool.DryRun(ctx, map[string]interface{}{"topic": "party.chat"})
+	require.NoError(t, err)
+	assert.Equal(t, "INVALID_MESSAGE", result.Error.Code)
+
+	// Reserved topics are rejected even for an agent named after their publisher
+	require.NoError(t, bus.ReserveTopic("loom.agents.lifecycle", "loom-server"))
+	lifecycle, err := bus.Subscribe(ctx, "supervisor", "loom.agents.lifecycle", nil, 4)
+	require.NoError(t, err)
+	for _, agentID := range []string{"dungeon-master", "loom-server"} {
+		result, err = NewPublishTool(bus, agentID).Execute(ctx, map[string]interface{}{"topic": "loom.agents.lifecycle", "message": `{"event":"spawned"}`})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Equal(t, "RESERVED_TOPIC", result.Error.Code)
+	}
+	assert.Empty(t, lifecycle.Channel)
+}
+
+func TestBusStatsTool(t *testing.T) {
+	bus := communication.NewMessageBus(nil, nil, nil, zap.NewNop())
+	defer bus.Close()
+	ctx := context.Background()
+
+	_, err := bus.Subscribe(ctx, "fighter", "party.chat", nil, 4)
+	require.NoError(t, err)
+	_, err = bus.Subscribe(ctx, "monitor", "workflow.>", nil, 4)
+	require.NoError(t, err)
+	_, _, err = bus.Publish(ctx, "party.chat", &loomv1.BusMessage{Id: "m1", FromAgent: "bard"})
+	require.NoError(t, err)
+
+	tool := NewBusStatsTool(bus)
+	result, err := tool.Execute(ctx, map[string]interface{}{"topic": "party"})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, int64(1), data["total_published"])
+	assert.Equal(t, 1, data["subscription_count"])
+	subs := data["subscriptions"].([]map[string]interface{})
+	assert.Equal(t, "fighter", subs[0]["agent_id"])
+	assert.Equal(t, 1, subs[0]["buffered"])
+	assert.Equal(t, 4, subs[0]["capacity"])
+	topics := data["topics"].([]map[string]interface{})
+	require.Len(t, topics, 1)
+	assert.Equal(t, int64(1), topics[0]["delivered"])
+
+	result, err = NewBusStatsTool(nil).Execute(ctx, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "BUS_NOT_AVAILABLE", result.Error.Code)
+}
+
 // TestCommunicationToolsRegistry tests the registry functions
 func TestCommunicationToolsRegistry(t *testing.T) {
 	t.Run("CommunicationToolNames", func(t *testing.T) {
 		names := CommunicationToolNames()
 		// Visualization tools are NOT included by default (metaagent assigns them)
-		// Point-to-point (1) + pub-sub (1) + shared memory (2) + query (2) = 6 tools
+		// Point-to-point (1) + pub-sub (2) + shared memory (2) + query (2) = 7 tools
 		// Note: receive_message, subscribe, receive_broadcast removed (event-driven auto-injection)
-		assert.Len(t, names, 6)
+		assert.Len(t, names, 7)
 		assert.Contains(t, names, "send_message")
 		assert.Contains(t, names, "publish")
+		assert.Contains(t, names, "bus_stats")
 		assert.Contains(t, names, "shared_memory_write")
 		assert.Contains(t, names, "shared_memory_read")
 		assert.Contains(t, names, "top_n_query")
@@ -7,7 +7,9 @@ package builtin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/teradata-labs/loom/pkg/shuttle"
@@ -19,31 +21,95 @@ type EphemeralAgentHandler interface {
 	SpawnSubAgent(ctx context.Context, req *SpawnSubAgentRequest) (*SpawnSubAgentResponse, error)
 	// DespawnSubAgent terminates a spawned sub-agent
 	DespawnSubAgent(ctx context.Context, req *DespawnSubAgentRequest) (*DespawnSubAgentResponse, error)
+	// ListSpawnedAgents returns the sub-agents spawned by a parent session
+	ListSpawnedAgents(parentSessionID string) []SpawnedAgentInfo
+}
+
+// Errors returned by EphemeralAgentHandler implementations, wrapped with details.
+// Use errors.Is to tell failure modes apart.
+var (
+	// ErrInvalidSpawnRequest means the request is missing required fields or has invalid values.
+	ErrInvalidSpawnRequest = errors.New("invalid spawn request")
+	// ErrRegistryUnavailable means the server has no agent registry to load agents from.
+	ErrRegistryUnavailable = errors.New("agent registry not configured")
+	// ErrSpawnLimitReached means the parent already has the maximum number of spawned agents.
+	ErrSpawnLimitReached = errors.New("spawn limit reached")
+	// ErrSpawnDepthExceeded means the new agent would exceed the maximum spawn depth.
+	ErrSpawnDepthExceeded = errors.New("spawn depth limit reached")
+	// ErrAgentNotFound means no agent config matches the requested agent ID.
+	ErrAgentNotFound = errors.New("agent not found")
+	// ErrAgentLoadFailed means the agent config exists but the agent could not be built.
+	ErrAgentLoadFailed = errors.New("failed to load agent")
+	// ErrSessionStoreFailed means the sub-agent's session could not be saved.
+	ErrSessionStoreFailed = errors.New("failed to create session")
+	// ErrSessionIDConflict means the requested session ID is used by a different agent or
+	// parent, or another spawn on it is still in progress.
+	ErrSessionIDConflict = errors.New("session ID is already in use")
+	// ErrSpawnedAgentNotFound means no spawned agent matches a despawn request.
+	ErrSpawnedAgentNotFound = errors.New("spawned agent not found")
+	// ErrNotSpawnParent means the spawned agent belongs to a different parent session.
+	ErrNotSpawnParent = errors.New("spawned agent belongs to a different parent session")
+	// ErrServerShuttingDown means the server is shutting down and no longer spawns agents.
+	ErrServerShuttingDown = errors.New("server is shutting down")
+	// ErrSpawnNotPermitted means the parent agent's can_spawn allowlist does not include
+	// the requested agent.
+	ErrSpawnNotPermitted = errors.New("not permitted to spawn agent")
+	// ErrSpawnRateLimited means the parent is spawning faster than the server allows.
+	// Returned as a *SpawnRateLimitError carrying the suggested backoff.
+	ErrSpawnRateLimited = errors.New("spawn rate limit exceeded")
+)
+
+// SpawnRateLimitError is returned when a parent session spawns faster than the server's
+// spawn rate limit. It matches ErrSpawnRateLimited with errors.Is.
+type SpawnRateLimitError struct {
+	ParentSessionID string
+	RetryAfter      time.Duration // How long until the parent may spawn again
+}
+
+func (e *SpawnRateLimitError) Error() string {
+	return fmt.Sprintf("%v: parent %s may spawn again in %s", ErrSpawnRateLimited, e.ParentSessionID, e.RetryAfter)
+}
+
+func (e *SpawnRateLimitError) Unwrap() error {
+	return ErrSpawnRateLimited
 }
 
 // SpawnSubAgentRequest contains parameters for spawning a new sub-agent.
 type SpawnSubAgentRequest struct {
-	ParentSessionID string            // Session ID of the parent agent
-	ParentAgentID   string            // Agent ID of the parent
-	AgentID         string            // Agent config to spawn (e.g., "fighter-spawnable")
-	WorkflowID      string            // Optional: workflow namespace (auto-generated if empty)
-	InitialMessage  string            // Optional: first message to send to spawned agent
-	AutoSubscribe   []string          // Optional: topics to auto-subscribe
-	Metadata        map[string]string // Optional: metadata for tracking
+	ParentSessionID string                 // Session ID of the parent agent
+	ParentAgentID   string                 // Agent ID of the parent
+	AgentID         string                 // Agent config to spawn (e.g., "fighter-spawnable")
+	WorkflowID      string                 // Optional: workflow namespace (auto-generated if empty)
+	InitialMessage  string                 // Optional: first message to send to spawned agent
+	InitialTask     map[string]interface{} // Optional: structured task parameters delivered as a JSON payload
+	AutoSubscribe   []string               // Optional: topics to auto-subscribe
+	Metadata        map[string]string      // Optional: metadata for tracking
+
+	// Optional: session ID for the sub-agent (generated if empty). Spawning again with the
+	// same ID, parent, and agent returns the existing sub-agent instead of a new one.
+	SessionID string
+
+	// Optional: inactivity before the sub-agent is despawned automatically.
+	// nil uses the server default; 0 never expires (for supervised workflows).
+	IdleTimeout *time.Duration
+	// Optional: how often the sub-agent's session is checked for inactivity (0: server default)
+	PollInterval time.Duration
 }
 
 // SpawnSubAgentResponse contains the result of spawning a sub-agent.
 type SpawnSubAgentResponse struct {
 	SubAgentID       string   // Full agent ID (with namespace prefix)
 	SessionID        string   // New session ID for the sub-agent
-	Status           string   // Status: "spawned"
+	Status           string   // Status: "spawned", or "already_spawned" for a repeated spawn with the same SessionID
 	SubscribedTopics []string // Topics the agent auto-subscribed to
 }
 
 // DespawnSubAgentRequest contains parameters for despawning a sub-agent.
+// The sub-agent is identified by SessionID or SubAgentID; SessionID wins when both are set.
 type DespawnSubAgentRequest struct {
 	ParentSessionID string // Session ID of the parent agent
 	SubAgentID      string // Full ID of sub-agent to despawn (e.g., "workflow:agent")
+	SessionID       string // Session ID of sub-agent to despawn (from the spawn response)
 	Reason          string // Optional: reason for despawn (for logging)
 }
 
@@ -51,7 +117,17 @@ type DespawnSubAgentRequest struct {
 type DespawnSubAgentResponse struct {
 	SubAgentID string // The sub-agent that was despawned
 	SessionID  string // The session that was terminated
-	Status     string // "despawned" or "not_found"
+	Status     string // "despawned"
+}
+
+// SpawnedAgentInfo describes a sub-agent spawned by a parent session.
+type SpawnedAgentInfo struct {
+	SubAgentID       string    // Full agent ID (with namespace prefix)
+	SessionID        string    // Sub-agent's session ID
+	WorkflowID       string    // Workflow namespace, if one was given at spawn
+	SpawnedAt        time.Time // When the sub-agent was spawned
+	SubscribedTopics []string  // Topics the sub-agent is subscribed to
+	LastActivity     time.Time // Last update to the sub-agent's session (zero if unavailable)
 }
 
 // ManageEphemeralAgentsTool enables agents to spawn and despawn sub-agents dynamically.
@@ -75,10 +151,11 @@ func (t *ManageEphemeralAgentsTool) Name() string {
 }
 
 func (t *ManageEphemeralAgentsTool) Description() string {
-	return `Manage ephemeral sub-agents - spawn and despawn agents dynamically.
+	return `Manage ephemeral sub-agents - spawn, list, and despawn agents dynamically.
 
 COMMANDS:
 - spawn: Create a new agent instance to run in the background
+- list: Show the agents you have spawned, their topics, and when they were last active
 - despawn: Terminate a spawned agent and clean up resources
 
 SPAWN use cases:
@@ -91,6 +168,8 @@ Spawned agents:
 - Run independently in background with own sessions
 - Auto-subscribe to pub/sub topics for group communication
 - Process messages and respond automatically
+- Can be messaged directly with message_spawned_agent
+- Receive initial_message (text) and initial_task (structured JSON) as their first message
 - Clean up when parent ends or when explicitly despawned
 
 DESPAWN use cases:
@@ -100,31 +179,62 @@ DESPAWN use cases:
 
 Examples:
   spawn: {"command": "spawn", "agent_id": "fighter-spawnable", "workflow_id": "dungeon-crawl", "auto_subscribe": ["party-chat"]}
-  despawn: {"command": "despawn", "sub_agent_id": "dungeon-crawl:fighter-spawnable", "reason": "adventure complete"}`
+  spawn with wildcard topics: {"command": "spawn", "agent_id": "dungeon-master", "workflow_id": "dungeon-crawl", "auto_subscribe": ["party.*", "workflow.dungeon-crawl.>"]}
+  spawn with task: {"command": "spawn", "agent_id": "analyst", "initial_message": "Profile this table", "initial_task": {"dataset": "sales", "columns": ["region", "revenue"]}}
+  despawn: {"command": "despawn", "sub_agent_id": "dungeon-crawl:fighter-spawnable", "reason": "adventure complete"}
+  despawn by session: {"command": "despawn", "session_id": "sess_abc123"}
+  list: {"command": "list"}`
 }
 
 func (t *ManageEphemeralAgentsTool) InputSchema() *shuttle.JSONSchema {
 	return shuttle.NewObjectSchema(
 		"Parameters for managing ephemeral agents",
 		map[string]*shuttle.JSONSchema{
-			"command": shuttle.NewStringSchema("Command: 'spawn' or 'despawn'").
-				WithEnum("spawn", "despawn"),
+			"command": shuttle.NewEnumSchema("Command: 'spawn', 'list', or 'despawn'", []string{"spawn", "list", "despawn"}),
 			// Spawn parameters
 			"agent_id":        shuttle.NewStringSchema("(spawn) Agent config to spawn (e.g., 'fighter-spawnable')"),
 			"workflow_id":     shuttle.NewStringSchema("(spawn) Optional: workflow namespace (auto-generated if not provided)"),
 			"initial_message": shuttle.NewStringSchema("(spawn) Optional: first message to send to spawned agent"),
-			"auto_subscribe":  shuttle.NewArraySchema("(spawn) Optional: topics to auto-subscribe", shuttle.NewStringSchema("Topic name")),
+			"initial_task": shuttle.NewObjectSchema(
+				"(spawn) Optional: structured task parameters (e.g., dataset, columns, constraints) delivered to the spawned agent as JSON",
+				map[string]*shuttle.JSONSchema{},
+				nil,
+			),
+			"auto_subscribe": shuttle.NewArraySchema("(spawn) Optional: topics to auto-subscribe; 'party.*' matches one level and 'workflow.x.>' any depth", shuttle.NewStringSchema("Topic name or wildcard pattern")),
+
+			"idle_timeout_seconds": shuttle.NewIntegerSchema("(spawn) Optional: despawn the agent after this many seconds without activity (0 = never; default: server setting)").WithMinimum(0),
 			// Despawn parameters
 			"sub_agent_id": shuttle.NewStringSchema("(despawn) Full ID of sub-agent to despawn (e.g., 'workflow:agent-name')"),
+			"session_id":   shuttle.NewStringSchema("(spawn) Optional: session ID for the new agent, so retried spawns return the same agent. (despawn) Session ID of sub-agent to despawn, as returned by spawn (alternative to sub_agent_id)"),
 			"reason":       shuttle.NewStringSchema("(despawn) Optional: reason for despawn"),
 		},
 		[]string{"command"}, // Only command is required
 	)
 }
 
+// Execute runs the command and sets ExecutionTimeMs on the result.
 func (t *ManageEphemeralAgentsTool) Execute(ctx context.Context, params map[string]any) (*shuttle.Result, error) {
 	start := time.Now()
+	result, err := t.execute(ctx, params, false)
+	if result != nil {
+		result.ExecutionTimeMs = time.Since(start).Milliseconds()
+	}
+	return result, err
+}
+
+// DryRun validates a spawn or despawn and describes it without spawning or despawning
+// anything. Despawn targets are checked against the agents this session has spawned.
+// The list command has no side effects and runs normally.
+func (t *ManageEphemeralAgentsTool) DryRun(ctx context.Context, params map[string]any) (*shuttle.Result, error) {
+	start := time.Now()
+	result, err := t.execute(ctx, params, true)
+	if result != nil {
+		result.ExecutionTimeMs = time.Since(start).Milliseconds()
+	}
+	return result, err
+}
 
+func (t *ManageEphemeralAgentsTool) execute(ctx context.Context, params map[string]any, dryRun bool) (*shuttle.Result, error) {
 	// Extract command
 	command, ok := params["command"].(string)
 	if !ok || command == "" {
@@ -133,31 +243,35 @@ func (t *ManageEphemeralAgentsTool) Execute(ctx context.Context, params map[stri
 			Error: &shuttle.Error{
 				Code:       "MISSING_COMMAND",
 				Message:    "command parameter is required",
-				Suggestion: "Specify 'spawn' or 'despawn' as the command",
+				Suggestion: "Specify 'spawn', 'list', or 'despawn' as the command",
 			},
-			ExecutionTimeMs: time.Since(start).Milliseconds(),
 		}, nil
 	}
 
+	if err := shuttle.ValidateParams(t.InputSchema(), params); err != nil {
+		return shuttle.NewValidationErrorResult(err), nil
+	}
+
 	switch command {
 	case "spawn":
-		return t.executeSpawn(ctx, params, start)
+		return t.executeSpawn(ctx, params, dryRun)
+	case "list":
+		return t.executeList()
 	case "despawn":
-		return t.executeDespawn(ctx, params, start)
+		return t.executeDespawn(ctx, params, dryRun)
 	default:
 		return &shuttle.Result{
 			Success: false,
 			Error: &shuttle.Error{
 				Code:       "INVALID_COMMAND",
 				Message:    fmt.Sprintf("Unknown command: %s", command),
-				Suggestion: "Use 'spawn' or 'despawn'",
+				Suggestion: "Use 'spawn', 'list', or 'despawn'",
 			},
-			ExecutionTimeMs: time.Since(start).Milliseconds(),
 		}, nil
 	}
 }
 
-func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map[string]any, start time.Time) (*shuttle.Result, error) {
+func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map[string]any, dryRun bool) (*shuttle.Result, error) {
 	// Extract agent_id (required for spawn)
 	agentID, ok := params["agent_id"].(string)
 	if !ok || agentID == "" {
@@ -168,13 +282,14 @@ func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map
 				Message:    "agent_id parameter is required for spawn command",
 				Suggestion: "Specify which agent config to spawn (e.g., 'fighter-spawnable')",
 			},
-			ExecutionTimeMs: time.Since(start).Milliseconds(),
 		}, nil
 	}
 
 	// Extract optional parameters
 	workflowID, _ := params["workflow_id"].(string)
+	sessionID, _ := params["session_id"].(string)
 	initialMessage, _ := params["initial_message"].(string)
+	initialTask, _ := params["initial_task"].(map[string]any)
 
 	var autoSubscribe []string
 	if topicsRaw, ok := params["auto_subscribe"].([]any); ok {
@@ -185,6 +300,12 @@ func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map
 		}
 	}
 
+	var idleTimeout *time.Duration
+	if seconds, ok := params["idle_timeout_seconds"].(float64); ok && seconds >= 0 {
+		d := time.Duration(seconds * float64(time.Second))
+		idleTimeout = &d
+	}
+
 	var metadata map[string]string
 	if metaRaw, ok := params["metadata"].(map[string]any); ok {
 		metadata = make(map[string]string)
@@ -202,8 +323,14 @@ func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map
 		AgentID:         agentID,
 		WorkflowID:      workflowID,
 		InitialMessage:  initialMessage,
+		InitialTask:     initialTask,
 		AutoSubscribe:   autoSubscribe,
 		Metadata:        metadata,
+		IdleTimeout:     idleTimeout,
+		SessionID:       sessionID,
+	}
+	if dryRun {
+		return spawnPlan(req), nil
 	}
 
 	// Call server handler
@@ -211,12 +338,7 @@ func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map
 	if err != nil {
 		return &shuttle.Result{
 			Success: false,
-			Error: &shuttle.Error{
-				Code:       "SPAWN_FAILED",
-				Message:    fmt.Sprintf("Failed to spawn agent: %v", err),
-				Suggestion: "Verify the agent_id exists in $LOOM_DATA_DIR/agents/",
-			},
-			ExecutionTimeMs: time.Since(start).Milliseconds(),
+			Error:   spawnError(err),
 		}, nil
 	}
 
@@ -230,22 +352,50 @@ func (t *ManageEphemeralAgentsTool) executeSpawn(ctx context.Context, params map
 			"status":            resp.Status,
 			"subscribed_topics": resp.SubscribedTopics,
 		},
-		ExecutionTimeMs: time.Since(start).Milliseconds(),
 	}, nil
 }
 
-func (t *ManageEphemeralAgentsTool) executeDespawn(ctx context.Context, params map[string]any, start time.Time) (*shuttle.Result, error) {
-	// Extract sub_agent_id (required for despawn)
-	subAgentID, ok := params["sub_agent_id"].(string)
-	if !ok || subAgentID == "" {
+func (t *ManageEphemeralAgentsTool) executeList() (*shuttle.Result, error) {
+	spawned := t.handler.ListSpawnedAgents(t.parentSession)
+
+	agents := make([]map[string]any, 0, len(spawned))
+	for _, info := range spawned {
+		agent := map[string]any{
+			"sub_agent_id":      info.SubAgentID,
+			"session_id":        info.SessionID,
+			"workflow_id":       info.WorkflowID,
+			"spawned_at":        info.SpawnedAt.Format(time.RFC3339),
+			"subscribed_topics": info.SubscribedTopics,
+		}
+		if !info.LastActivity.IsZero() {
+			agent["last_activity"] = info.LastActivity.Format(time.RFC3339)
+			agent["idle_seconds"] = int64(time.Since(info.LastActivity).Seconds())
+		}
+		agents = append(agents, agent)
+	}
+
+	return &shuttle.Result{
+		Success: true,
+		Data: map[string]any{
+			"command": "list",
+			"count":   len(agents),
+			"agents":  agents,
+		},
+	}, nil
+}
+
+func (t *ManageEphemeralAgentsTool) executeDespawn(ctx context.Context, params map[string]any, dryRun bool) (*shuttle.Result, error) {
+	// Extract sub_agent_id or session_id (one is required for despawn)
+	subAgentID, _ := params["sub_agent_id"].(string)
+	sessionID, _ := params["session_id"].(string)
+	if subAgentID == "" && sessionID == "" {
 		return &shuttle.Result{
 			Success: false,
 			Error: &shuttle.Error{
 				Code:       "MISSING_SUB_AGENT_ID",
-				Message:    "sub_agent_id parameter is required for despawn command",
-				Suggestion: "Provide the full ID of the sub-agent to despawn (e.g., 'workflow:agent-name')",
+				Message:    "sub_agent_id or session_id parameter is required for despawn command",
+				Suggestion: "Provide the full ID of the sub-agent to despawn (e.g., 'workflow:agent-name') or its session_id",
 			},
-			ExecutionTimeMs: time.Since(start).Milliseconds(),
 		}, nil
 	}
 
@@ -259,20 +409,19 @@ func (t *ManageEphemeralAgentsTool) executeDespawn(ctx context.Context, params m
 	req := &DespawnSubAgentRequest{
 		ParentSessionID: t.parentSession,
 		SubAgentID:      subAgentID,
+		SessionID:       sessionID,
 		Reason:          reason,
 	}
+	if dryRun {
+		return t.despawnPlan(req), nil
+	}
 
 	// Call server handler
 	resp, err := t.handler.DespawnSubAgent(ctx, req)
 	if err != nil {
 		return &shuttle.Result{
 			Success: false,
-			Error: &shuttle.Error{
-				Code:       "DESPAWN_FAILED",
-				Message:    fmt.Sprintf("Failed to despawn agent: %v", err),
-				Suggestion: "Verify the sub_agent_id is correct and the agent exists",
-			},
-			ExecutionTimeMs: time.Since(start).Milliseconds(),
+			Error:   despawnError(err),
 		}, nil
 	}
 
@@ -286,10 +435,144 @@ func (t *ManageEphemeralAgentsTool) executeDespawn(ctx context.Context, params m
 			"status":       resp.Status,
 			"reason":       reason,
 		},
-		ExecutionTimeMs: time.Since(start).Milliseconds(),
 	}, nil
 }
 
+// spawnPlan describes the spawn a request would perform.
+func spawnPlan(req *SpawnSubAgentRequest) *shuttle.Result {
+	var plan strings.Builder
+	fmt.Fprintf(&plan, "would spawn agent '%s'", req.AgentID)
+	if req.WorkflowID != "" {
+		fmt.Fprintf(&plan, " in workflow '%s'", req.WorkflowID)
+	}
+	if req.SessionID != "" {
+		fmt.Fprintf(&plan, " with session '%s'", req.SessionID)
+	}
+	if len(req.AutoSubscribe) > 0 {
+		fmt.Fprintf(&plan, ", subscribe to %s", strings.Join(req.AutoSubscribe, ", "))
+	}
+	if req.InitialMessage != "" || req.InitialTask != nil {
+		plan.WriteString(", send it an initial task")
+	}
+	if req.IdleTimeout != nil {
+		if *req.IdleTimeout == 0 {
+			plan.WriteString(", never despawn it automatically")
+		} else {
+			fmt.Fprintf(&plan, ", despawn it after %s idle", *req.IdleTimeout)
+		}
+	}
+
+	return shuttle.DryRunResult(plan.String(), map[string]any{
+		"command":     "spawn",
+		"agent_id":    req.AgentID,
+		"workflow_id": req.WorkflowID,
+		"session_id":  req.SessionID,
+		"topics":      req.AutoSubscribe,
+	})
+}
+
+// despawnPlan describes the despawn a request would perform, or returns the error
+// DespawnSubAgent would if the target is not among this session's spawned agents.
+func (t *ManageEphemeralAgentsTool) despawnPlan(req *DespawnSubAgentRequest) *shuttle.Result {
+	for _, info := range t.handler.ListSpawnedAgents(t.parentSession) {
+		matches := info.SessionID == req.SessionID
+		if req.SessionID == "" {
+			matches = info.SubAgentID == req.SubAgentID
+		}
+		if !matches {
+			continue
+		}
+		return shuttle.DryRunResult(
+			fmt.Sprintf("would despawn agent '%s' (session '%s'): %s", info.SubAgentID, info.SessionID, req.Reason),
+			map[string]any{
+				"command":      "despawn",
+				"sub_agent_id": info.SubAgentID,
+				"session_id":   info.SessionID,
+				"reason":       req.Reason,
+			},
+		)
+	}
+
+	identifier := req.SessionID
+	if identifier == "" {
+		identifier = req.SubAgentID
+	}
+	return &shuttle.Result{
+		Success: false,
+		Error:   despawnError(fmt.Errorf("%w: %s", ErrSpawnedAgentNotFound, identifier)),
+	}
+}
+
+// spawnError maps a SpawnSubAgent failure to a tool error. Only failures that may
+// succeed on a later attempt are marked retryable.
+func spawnError(err error) *shuttle.Error {
+	e := &shuttle.Error{
+		Code:       "SPAWN_FAILED",
+		Message:    fmt.Sprintf("Failed to spawn agent: %v", err),
+		Suggestion: "Verify the agent_id exists in $LOOM_DATA_DIR/agents/",
+	}
+	switch {
+	case errors.Is(err, ErrInvalidSpawnRequest):
+		e.Code = "INVALID_SPAWN_REQUEST"
+		e.Suggestion = "Check the spawn parameters"
+	case errors.Is(err, ErrRegistryUnavailable):
+		e.Code = "REGISTRY_UNAVAILABLE"
+		e.Suggestion = "Agent spawning is not available on this server"
+	case errors.Is(err, ErrSpawnNotPermitted):
+		e.Code = "SPAWN_NOT_PERMITTED"
+		e.Suggestion = "Spawn only agents allowed by this agent's can_spawn config, or handle the task yourself"
+	case errors.Is(err, ErrSpawnLimitReached):
+		e.Code = "SPAWN_LIMIT_REACHED"
+		e.Suggestion = "Despawn agents you no longer need before spawning more"
+	case errors.Is(err, ErrSpawnRateLimited):
+		e.Code = "SPAWN_RATE_LIMITED"
+		e.Suggestion = "Wait before spawning again, or reuse agents you already spawned"
+		var rateErr *SpawnRateLimitError
+		if errors.As(err, &rateErr) && rateErr.RetryAfter > 0 {
+			e.Suggestion = fmt.Sprintf("Wait at least %s before spawning again, or reuse agents you already spawned", rateErr.RetryAfter)
+		}
+		e.Retryable = true
+	case errors.Is(err, ErrSpawnDepthExceeded):
+		e.Code = "SPAWN_DEPTH_EXCEEDED"
+		e.Suggestion = "Handle the task in this agent instead of delegating further"
+	case errors.Is(err, ErrServerShuttingDown):
+		e.Code = "SERVER_SHUTTING_DOWN"
+		e.Suggestion = "Finish the task in this agent; no new agents can be spawned"
+	case errors.Is(err, ErrSessionIDConflict):
+		e.Code = "SESSION_ID_CONFLICT"
+		e.Suggestion = "Use a different session_id, or omit it to generate one"
+	case errors.Is(err, ErrAgentNotFound):
+		e.Code = "AGENT_NOT_FOUND"
+	case errors.Is(err, ErrAgentLoadFailed):
+		e.Code = "AGENT_LOAD_FAILED"
+		e.Suggestion = "The agent config exists but failed to load; retry or check its configuration"
+		e.Retryable = true
+	case errors.Is(err, ErrSessionStoreFailed):
+		e.Code = "SESSION_STORE_FAILED"
+		e.Suggestion = "Retry the spawn"
+		e.Retryable = true
+	}
+	return e
+}
+
+// despawnError maps a DespawnSubAgent failure to a tool error.
+func despawnError(err error) *shuttle.Error {
+	e := &shuttle.Error{
+		Code:       "DESPAWN_FAILED",
+		Message:    fmt.Sprintf("Failed to despawn agent: %v", err),
+		Suggestion: "Verify the sub_agent_id or session_id is correct and the agent was spawned by this session",
+	}
+	switch {
+	case errors.Is(err, ErrSpawnedAgentNotFound):
+		e.Code = "SPAWNED_AGENT_NOT_FOUND"
+		e.Suggestion = "Use the list command to see the agents spawned by this session"
+	case errors.Is(err, ErrNotSpawnParent):
+		e.Code = "NOT_SPAWN_PARENT"
+		e.Suggestion = "Only the session that spawned an agent can despawn it"
+	}
+	return e
+}
+
 func (t *ManageEphemeralAgentsTool) Backend() string {
 	return "" // Backend-agnostic
 }
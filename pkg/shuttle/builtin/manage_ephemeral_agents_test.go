@@ -0,0 +1,141 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingEphemeralHandler returns fixed errors from spawn and despawn and counts the calls.
+type failingEphemeralHandler struct {
+	spawnErr   error
+	despawnErr error
+	spawned    []SpawnedAgentInfo
+	calls      int
+}
+
+func (h *failingEphemeralHandler) SpawnSubAgent(context.Context, *SpawnSubAgentRequest) (*SpawnSubAgentResponse, error) {
+	h.calls++
+	return nil, h.spawnErr
+}
+
+func (h *failingEphemeralHandler) DespawnSubAgent(context.Context, *DespawnSubAgentRequest) (*DespawnSubAgentResponse, error) {
+	h.calls++
+	return nil, h.despawnErr
+}
+
+func (h *failingEphemeralHandler) ListSpawnedAgents(string) []SpawnedAgentInfo {
+	return h.spawned
+}
+
+func TestManageEphemeralAgentsTool_SpawnErrors(t *testing.T) {
+	tests := []struct {
+		err       error
+		code      string
+		retryable bool
+	}{
+		{fmt.Errorf("%w: parent has 10 spawned agents (max: 10)", ErrSpawnLimitReached), "SPAWN_LIMIT_REACHED", false},
+		{fmt.Errorf("%w: new agent would be at depth 4 (max: 3)", ErrSpawnDepthExceeded), "SPAWN_DEPTH_EXCEEDED", false},
+		{&SpawnRateLimitError{ParentSessionID: "parent", RetryAfter: 1500 * time.Millisecond}, "SPAWN_RATE_LIMITED", true},
+		{ErrServerShuttingDown, "SERVER_SHUTTING_DOWN", false},
+		{fmt.Errorf("%w admin: not in the can_spawn allowlist of agent triage [analyst-*]", ErrSpawnNotPermitted), "SPAWN_NOT_PERMITTED", false},
+		{ErrRegistryUnavailable, "REGISTRY_UNAVAILABLE", false},
+		{fmt.Errorf("%w: sess-1 is used by wf:writer", ErrSessionIDConflict), "SESSION_ID_CONFLICT", false},
+		{fmt.Errorf("%w: analyst", ErrAgentNotFound), "AGENT_NOT_FOUND", false},
+		{fmt.Errorf("%w: %w", ErrSessionStoreFailed, errors.New("database is locked")), "SESSION_STORE_FAILED", true},
+		{errors.New("something else"), "SPAWN_FAILED", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			tool := NewManageEphemeralAgentsTool(&failingEphemeralHandler{spawnErr: tt.err}, "parent", "coordinator")
+			result, err := tool.Execute(context.Background(), map[string]any{"command": "spawn", "agent_id": "analyst"})
+			require.NoError(t, err)
+			require.NotNil(t, result.Error)
+			assert.False(t, result.Success)
+			assert.Equal(t, tt.code, result.Error.Code)
+			assert.Equal(t, tt.retryable, result.Error.Retryable)
+			assert.Contains(t, result.Error.Message, tt.err.Error())
+		})
+	}
+
+	t.Run("rate limit backoff hint", func(t *testing.T) {
+		err := fmt.Errorf("spawn failed: %w", &SpawnRateLimitError{ParentSessionID: "parent", RetryAfter: 1500 * time.Millisecond})
+		assert.ErrorIs(t, err, ErrSpawnRateLimited)
+		assert.Contains(t, spawnError(err).Suggestion, "Wait at least 1.5s")
+	})
+}
+
+func TestManageEphemeralAgentsTool_DespawnErrors(t *testing.T) {
+	tool := NewManageEphemeralAgentsTool(&failingEphemeralHandler{despawnErr: fmt.Errorf("%w: sess-1", ErrNotSpawnParent)}, "parent", "coordinator")
+	result, err := tool.Execute(context.Background(), map[string]any{"command": "despawn", "session_id": "sess-1"})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "NOT_SPAWN_PARENT", result.Error.Code)
+
+	tool = NewManageEphemeralAgentsTool(&failingEphemeralHandler{despawnErr: fmt.Errorf("%w: sess-1", ErrSpawnedAgentNotFound)}, "parent", "coordinator")
+	result, err = tool.Execute(context.Background(), map[string]any{"command": "despawn", "session_id": "sess-1"})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "SPAWNED_AGENT_NOT_FOUND", result.Error.Code)
+}
+
+func TestManageEphemeralAgentsTool_ValidatesParams(t *testing.T) {
+	tool := NewManageEphemeralAgentsTool(&failingEphemeralHandler{}, "parent", "coordinator")
+	result, err := tool.Execute(context.Background(), map[string]any{
+		"command":        "spawn",
+		"agent_id":       "analyst",
+		"auto_subscribe": "party-chat",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "VALIDATION_ERROR", result.Error.Code)
+	assert.Contains(t, result.Error.Message, "auto_subscribe: expected array, got string")
+}
+
+func TestManageEphemeralAgentsTool_DryRun(t *testing.T) {
+	handler := &failingEphemeralHandler{
+		spawned: []SpawnedAgentInfo{{SubAgentID: "dungeon-crawl:fighter", SessionID: "sess-1"}},
+	}
+	tool := NewManageEphemeralAgentsTool(handler, "parent", "coordinator")
+	ctx := context.Background()
+
+	result, err := tool.DryRun(ctx, map[string]any{
+		"command":        "spawn",
+		"agent_id":       "fighter",
+		"workflow_id":    "dungeon-crawl",
+		"auto_subscribe": []any{"party-chat"},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	data := result.Data.(map[string]any)
+	assert.Equal(t, true, data["dry_run"])
+	assert.Equal(t, "would spawn agent 'fighter' in workflow 'dungeon-crawl', subscribe to party-chat", data["plan"])
+
+	result, err = tool.DryRun(ctx, map[string]any{"command": "despawn", "sub_agent_id": "dungeon-crawl:fighter", "reason": "quest complete"})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Equal(t, "would despawn agent 'dungeon-crawl:fighter' (session 'sess-1'): quest complete", result.Data.(map[string]any)["plan"])
+
+	result, err = tool.DryRun(ctx, map[string]any{"command": "despawn", "session_id": "sess-other"})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "SPAWNED_AGENT_NOT_FOUND", result.Error.Code)
+
+	result, err = tool.DryRun(ctx, map[string]any{"command": "spawn"})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "MISSING_AGENT_ID", result.Error.Code)
+
+	assert.Zero(t, handler.calls, "dry run must not spawn or despawn")
+}
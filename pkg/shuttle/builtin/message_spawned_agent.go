@@ -0,0 +1,217 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// SpawnedAgentMessenger is implemented by MultiAgentServer to deliver direct messages
+// to sub-agents spawned with manage_ephemeral_agents.
+type SpawnedAgentMessenger interface {
+	// SendToSpawnedAgent queues a message for a sub-agent spawned by the request's parent session
+	SendToSpawnedAgent(ctx context.Context, req *SendToSpawnedAgentRequest) (*SendToSpawnedAgentResponse, error)
+}
+
+// ErrSpawnedAgentStopping means the spawned agent is shutting down and no longer takes messages.
+// SendToSpawnedAgent also returns ErrSpawnedAgentNotFound and ErrNotSpawnParent.
+var ErrSpawnedAgentStopping = errors.New("spawned agent is shutting down")
+
+// SendToSpawnedAgentRequest contains parameters for messaging a spawned sub-agent.
+// The sub-agent is identified by SessionID or SubAgentID; SessionID wins when both are set.
+type SendToSpawnedAgentRequest struct {
+	ParentSessionID string            // Session ID of the parent agent
+	ParentAgentID   string            // Agent ID of the parent (recorded as the sender)
+	SubAgentID      string            // Full ID of the sub-agent (e.g., "workflow:agent")
+	SessionID       string            // Session ID of the sub-agent (from the spawn response)
+	Message         string            // Message text
+	Metadata        map[string]string // Optional: metadata attached to the message
+	ReplyTopic      string            // Optional: topic the sub-agent's response is published to
+}
+
+// SendToSpawnedAgentResponse contains the result of queueing a message.
+type SendToSpawnedAgentResponse struct {
+	MessageID  string // ID assigned to the message
+	SubAgentID string // The sub-agent the message was queued for
+	SessionID  string // The sub-agent's session
+	Status     string // "queued"
+}
+
+// MessageSpawnedAgentTool sends a direct message to a sub-agent spawned by the calling session.
+// Unlike send_message, it reaches spawned agents without a shared message queue, and it
+// refuses targets spawned by other sessions.
+type MessageSpawnedAgentTool struct {
+	handler       SpawnedAgentMessenger
+	parentSession string
+	parentAgentID string
+}
+
+// NewMessageSpawnedAgentTool creates a new message_spawned_agent tool.
+func NewMessageSpawnedAgentTool(handler SpawnedAgentMessenger, parentSessionID, parentAgentID string) *MessageSpawnedAgentTool {
+	return &MessageSpawnedAgentTool{
+		handler:       handler,
+		parentSession: parentSessionID,
+		parentAgentID: parentAgentID,
+	}
+}
+
+func (t *MessageSpawnedAgentTool) Name() string {
+	return "message_spawned_agent"
+}
+
+func (t *MessageSpawnedAgentTool) Description() string {
+	return `Send a direct message to a sub-agent you spawned with manage_ephemeral_agents.
+
+The message is queued and handled by the sub-agent in its own session, in the order sent.
+Only agents spawned by this session can be messaged.
+
+Use reply_topic to receive the sub-agent's response on a pub/sub topic; without it the
+response stays in the sub-agent's session history.
+
+Examples:
+  by session: {"session_id": "sess_abc123", "message": "Summarize what you found so far"}
+  by agent: {"sub_agent_id": "dungeon-crawl:fighter-spawnable", "message": "Guard the door", "reply_topic": "party-chat"}`
+}
+
+func (t *MessageSpawnedAgentTool) InputSchema() *shuttle.JSONSchema {
+	return shuttle.NewObjectSchema(
+		"Parameters for messaging a spawned agent",
+		map[string]*shuttle.JSONSchema{
+			"session_id":   shuttle.NewStringSchema("Session ID of the sub-agent, as returned by spawn"),
+			"sub_agent_id": shuttle.NewStringSchema("Full ID of the sub-agent (e.g., 'workflow:agent-name'; alternative to session_id)"),
+			"message":      shuttle.NewStringSchema("Message to send"),
+			"reply_topic":  shuttle.NewStringSchema("Optional: topic to publish the sub-agent's response to"),
+			"metadata": shuttle.NewObjectSchema(
+				"Optional: metadata attached to the message",
+				map[string]*shuttle.JSONSchema{},
+				nil,
+			),
+		},
+		[]string{"message"},
+	)
+}
+
+// Execute queues the message and sets ExecutionTimeMs on the result.
+func (t *MessageSpawnedAgentTool) Execute(ctx context.Context, params map[string]any) (*shuttle.Result, error) {
+	start := time.Now()
+	result, err := t.execute(ctx, params)
+	if result != nil {
+		result.ExecutionTimeMs = time.Since(start).Milliseconds()
+	}
+	return result, err
+}
+
+func (t *MessageSpawnedAgentTool) execute(ctx context.Context, params map[string]any) (*shuttle.Result, error) {
+	if err := shuttle.ValidateParams(t.InputSchema(), params); err != nil {
+		return shuttle.NewValidationErrorResult(err), nil
+	}
+
+	message, _ := params["message"].(string)
+	if message == "" {
+		return &shuttle.Result{
+			Success: false,
+			Error: &shuttle.Error{
+				Code:       "MISSING_MESSAGE",
+				Message:    "message parameter cannot be empty",
+				Suggestion: "Provide the text to send to the sub-agent",
+			},
+		}, nil
+	}
+
+	subAgentID, _ := params["sub_agent_id"].(string)
+	sessionID, _ := params["session_id"].(string)
+	if subAgentID == "" && sessionID == "" {
+		return &shuttle.Result{
+			Success: false,
+			Error: &shuttle.Error{
+				Code:       "MISSING_SUB_AGENT_ID",
+				Message:    "sub_agent_id or session_id parameter is required",
+				Suggestion: "Use manage_ephemeral_agents with the list command to find your spawned agents",
+			},
+		}, nil
+	}
+
+	if t.handler == nil {
+		return &shuttle.Result{
+			Success: false,
+			Error: &shuttle.Error{
+				Code:       "MESSENGER_UNAVAILABLE",
+				Message:    "spawned agent messaging is not available",
+				Suggestion: "This tool requires a multi-agent server",
+			},
+		}, nil
+	}
+
+	replyTopic, _ := params["reply_topic"].(string)
+
+	var metadata map[string]string
+	if metaRaw, ok := params["metadata"].(map[string]any); ok {
+		metadata = make(map[string]string, len(metaRaw))
+		for k, v := range metaRaw {
+			metadata[k] = fmt.Sprint(v)
+		}
+	}
+
+	resp, err := t.handler.SendToSpawnedAgent(ctx, &SendToSpawnedAgentRequest{
+		ParentSessionID: t.parentSession,
+		ParentAgentID:   t.parentAgentID,
+		SubAgentID:      subAgentID,
+		SessionID:       sessionID,
+		Message:         message,
+		Metadata:        metadata,
+		ReplyTopic:      replyTopic,
+	})
+	if err != nil {
+		return &shuttle.Result{
+			Success: false,
+			Error:   messageSpawnedAgentError(err),
+		}, nil
+	}
+
+	data := map[string]any{
+		"message_id":   resp.MessageID,
+		"sub_agent_id": resp.SubAgentID,
+		"session_id":   resp.SessionID,
+		"status":       resp.Status,
+	}
+	if replyTopic != "" {
+		data["reply_topic"] = replyTopic
+	}
+	return &shuttle.Result{
+		Success: true,
+		Data:    data,
+	}, nil
+}
+
+// messageSpawnedAgentError maps a SendToSpawnedAgent failure to a tool error.
+func messageSpawnedAgentError(err error) *shuttle.Error {
+	e := &shuttle.Error{
+		Code:       "MESSAGE_FAILED",
+		Message:    fmt.Sprintf("Failed to message agent: %v", err),
+		Suggestion: "Verify the sub_agent_id or session_id is correct and the agent was spawned by this session",
+	}
+	switch {
+	case errors.Is(err, ErrSpawnedAgentNotFound):
+		e.Code = "SPAWNED_AGENT_NOT_FOUND"
+		e.Suggestion = "Use manage_ephemeral_agents with the list command to see the agents spawned by this session"
+	case errors.Is(err, ErrNotSpawnParent):
+		e.Code = "NOT_SPAWN_PARENT"
+		e.Suggestion = "Only the session that spawned an agent can message it directly"
+	case errors.Is(err, ErrSpawnedAgentStopping):
+		e.Code = "SPAWNED_AGENT_STOPPING"
+		e.Suggestion = "Spawn the agent again if it is still needed"
+	}
+	return e
+}
+
+func (t *MessageSpawnedAgentTool) Backend() string {
+	return "" // Backend-agnostic
+}
@@ -0,0 +1,84 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMessenger records requests and returns a fixed error.
+type recordingMessenger struct {
+	requests []*SendToSpawnedAgentRequest
+	err      error
+}
+
+func (m *recordingMessenger) SendToSpawnedAgent(_ context.Context, req *SendToSpawnedAgentRequest) (*SendToSpawnedAgentResponse, error) {
+	m.requests = append(m.requests, req)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &SendToSpawnedAgentResponse{MessageID: "msg-1", SubAgentID: "wf:analyst", SessionID: "sess-1", Status: "queued"}, nil
+}
+
+func TestMessageSpawnedAgentTool_Execute(t *testing.T) {
+	messenger := &recordingMessenger{}
+	tool := NewMessageSpawnedAgentTool(messenger, "parent", "coordinator")
+	assert.Equal(t, "message_spawned_agent", tool.Name())
+
+	result, err := tool.Execute(context.Background(), map[string]any{
+		"sub_agent_id": "wf:analyst",
+		"message":      "profile the sales table",
+		"reply_topic":  "replies",
+		"metadata":     map[string]any{"priority": "high", "attempt": float64(2)},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+
+	data := result.Data.(map[string]any)
+	assert.Equal(t, "queued", data["status"])
+	assert.Equal(t, "msg-1", data["message_id"])
+	assert.Equal(t, "replies", data["reply_topic"])
+
+	require.Len(t, messenger.requests, 1)
+	req := messenger.requests[0]
+	assert.Equal(t, "parent", req.ParentSessionID)
+	assert.Equal(t, "coordinator", req.ParentAgentID)
+	assert.Equal(t, "wf:analyst", req.SubAgentID)
+	assert.Equal(t, "profile the sales table", req.Message)
+	assert.Equal(t, map[string]string{"priority": "high", "attempt": "2"}, req.Metadata)
+}
+
+func TestMessageSpawnedAgentTool_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		params map[string]any
+		code   string
+	}{
+		{"missing message", nil, map[string]any{"session_id": "sess-1"}, "VALIDATION_ERROR"},
+		{"empty message", nil, map[string]any{"session_id": "sess-1", "message": ""}, "MISSING_MESSAGE"},
+		{"missing target", nil, map[string]any{"message": "hi"}, "MISSING_SUB_AGENT_ID"},
+		{"not found", fmt.Errorf("%w: sess-1", ErrSpawnedAgentNotFound), map[string]any{"session_id": "sess-1", "message": "hi"}, "SPAWNED_AGENT_NOT_FOUND"},
+		{"foreign parent", fmt.Errorf("%w: sess-1", ErrNotSpawnParent), map[string]any{"session_id": "sess-1", "message": "hi"}, "NOT_SPAWN_PARENT"},
+		{"stopping", fmt.Errorf("%w: sess-1", ErrSpawnedAgentStopping), map[string]any{"session_id": "sess-1", "message": "hi"}, "SPAWNED_AGENT_STOPPING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := NewMessageSpawnedAgentTool(&recordingMessenger{err: tt.err}, "parent", "coordinator")
+			result, err := tool.Execute(context.Background(), tt.params)
+			require.NoError(t, err)
+			require.NotNil(t, result.Error)
+			assert.False(t, result.Success)
+			assert.Equal(t, tt.code, result.Error.Code)
+		})
+	}
+}
@@ -67,6 +67,15 @@ func (t *PublishTool) InputSchema() *shuttle.JSONSchema {
 }
 
 func (t *PublishTool) Execute(ctx context.Context, params map[string]interface{}) (*shuttle.Result, error) {
+	return t.publish(ctx, params, false)
+}
+
+// DryRun validates a publish and describes it without sending the message.
+func (t *PublishTool) DryRun(ctx context.Context, params map[string]interface{}) (*shuttle.Result, error) {
+	return t.publish(ctx, params, true)
+}
+
+func (t *PublishTool) publish(ctx context.Context, params map[string]interface{}, dryRun bool) (*shuttle.Result, error) {
 	start := time.Now()
 
 	// Validate bus availability
@@ -96,6 +105,19 @@ func (t *PublishTool) Execute(ctx context.Context, params map[string]interface{}
 		}, nil
 	}
 
+	// Reserved topics carry server events; agents may read but never publish them
+	if t.bus.IsReservedTopic(topic) {
+		return &shuttle.Result{
+			Success: false,
+			Error: &shuttle.Error{
+				Code:       "RESERVED_TOPIC",
+				Message:    fmt.Sprintf("Topic '%s' is reserved for server events", topic),
+				Suggestion: "Subscribe to the topic to observe it, or publish to another topic",
+			},
+			ExecutionTimeMs: time.Since(start).Milliseconds(),
+		}, nil
+	}
+
 	// Extract message
 	message, ok := params["message"].(string)
 	if !ok || message == "" {
@@ -110,16 +132,32 @@ func (t *PublishTool) Execute(ctx context.Context, params map[string]interface{}
 		}, nil
 	}
 
-	// Optional metadata
+	// Optional metadata; bus metadata is string-valued, so other values are formatted
 	metadata := make(map[string]string)
 	if md, ok := params["metadata"].(map[string]interface{}); ok {
 		for k, v := range md {
-			if strVal, ok := v.(string); ok {
-				metadata[k] = strVal
+			switch val := v.(type) {
+			case nil:
+			case string:
+				metadata[k] = val
+			default:
+				metadata[k] = fmt.Sprint(val)
 			}
 		}
 	}
 
+	if dryRun {
+		result := shuttle.DryRunResult(
+			fmt.Sprintf("would publish a %d-byte message to topic '%s'", len(message), topic),
+			map[string]interface{}{
+				"topic":    topic,
+				"metadata": metadata,
+			},
+		)
+		result.ExecutionTimeMs = time.Since(start).Milliseconds()
+		return result, nil
+	}
+
 	// Create bus message
 	messageID := uuid.New().String()
 	busMessage := &loomv1.BusMessage{
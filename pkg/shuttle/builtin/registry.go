@@ -119,6 +119,7 @@ func RegisterByNames(registry *shuttle.Registry, names []string) {
 // Includes:
 // - send_message (point-to-point messaging)
 // - publish (pub-sub broadcast messaging)
+// - bus_stats (message bus diagnostics)
 // - shared_memory_write, shared_memory_read (zero-copy data sharing)
 // - top_n_query, group_by_query (presentation strategies)
 //
@@ -139,6 +140,7 @@ func CommunicationTools(queue *communication.MessageQueue, bus *communication.Me
 	if bus != nil {
 		tools = append(tools,
 			NewPublishTool(bus, agentID),
+			NewBusStatsTool(bus),
 		)
 	}
 
@@ -163,6 +165,7 @@ func CommunicationToolNames() []string {
 	return []string{
 		"send_message",
 		"publish",
+		"bus_stats",
 		"shared_memory_write",
 		"shared_memory_read",
 		"top_n_query",
@@ -190,3 +193,25 @@ func LoadMetadata(toolName string) (*metadata.ToolMetadata, error) {
 func LoadAllMetadata() (map[string]*metadata.ToolMetadata, error) {
 	return metadataLoader.LoadAll()
 }
+
+// ApplyConcurrencyLimits configures limiter with the concurrency limits declared in
+// builtin tool metadata (the `concurrency` section). Tools without a declared
+// limit are left unrestricted.
+func ApplyConcurrencyLimits(limiter *shuttle.ConcurrencyLimiter) error {
+	all, err := LoadAllMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to load tool metadata: %w", err)
+	}
+
+	for name, meta := range all {
+		if meta.Concurrency == nil {
+			continue
+		}
+		policy := shuttle.ConcurrencyPolicy(meta.Concurrency.OnLimit)
+		if err := limiter.SetLimit(name, meta.Concurrency.MaxConcurrent, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -594,6 +594,12 @@ func (t *ShellExecuteTool) Backend() string {
 	return "" // Backend-agnostic
 }
 
+// Timeout leaves room past MaxShellTimeout so the tool's own timeout, which kills the
+// command and returns its partial output, fires before the executor's.
+func (t *ShellExecuteTool) Timeout() time.Duration {
+	return (MaxShellTimeout + 30) * time.Second
+}
+
 // detectShell determines which shell to use based on OS and user preference.
 func detectShell(shellType, command string) (binary string, args []string, actualType string, err error) {
 	switch shellType {
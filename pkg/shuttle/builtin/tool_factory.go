@@ -0,0 +1,131 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package builtin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+// ToolFactoryContext carries the per-agent, per-session state a factory needs to
+// build a stateful tool (the same wiring manage_ephemeral_agents receives).
+type ToolFactoryContext struct {
+	AgentID   string                // Agent the tool is being built for
+	SessionID string                // Session the tool is bound to
+	Handler   EphemeralAgentHandler // Server handler for spawn/despawn (may be nil)
+}
+
+// ToolFactory builds a tool instance for a single agent session.
+// Returning a nil tool with a nil error skips registration for that agent.
+type ToolFactory func(fctx ToolFactoryContext) (shuttle.Tool, error)
+
+// ToolFactoryRegistry holds named tool factories that the server invokes when
+// constructing an agent's tool set. This is the extension point for third parties
+// to add session-aware builtin tools without modifying core packages.
+//
+// Example usage:
+//
+//	factories := builtin.NewToolFactoryRegistry()
+//	_ = factories.Register("ticket_lookup", func(fctx builtin.ToolFactoryContext) (shuttle.Tool, error) {
+//	    return NewTicketLookupTool(fctx.SessionID), nil
+//	})
+//	server.SetToolFactoryRegistry(factories)
+type ToolFactoryRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]ToolFactory
+}
+
+// defaultToolFactories is the process-wide registry wired into looms serve.
+var defaultToolFactories = NewToolFactoryRegistry()
+
+// DefaultToolFactories returns the process-wide tool factory registry.
+// Custom looms builds can register factories here (e.g. from an init function)
+// and the server will pick them up at startup.
+func DefaultToolFactories() *ToolFactoryRegistry {
+	return defaultToolFactories
+}
+
+// NewToolFactoryRegistry creates an empty tool factory registry.
+func NewToolFactoryRegistry() *ToolFactoryRegistry {
+	return &ToolFactoryRegistry{
+		factories: make(map[string]ToolFactory),
+	}
+}
+
+// Register adds a factory under the given tool name.
+// Returns an error if the name is empty, the factory is nil, or the name is taken.
+func (r *ToolFactoryRegistry) Register(name string, factory ToolFactory) error {
+	if name == "" {
+		return fmt.Errorf("tool factory name is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("tool factory %s is nil", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("tool factory already registered: %s", name)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+// Unregister removes the factory with the given name, if present.
+func (r *ToolFactoryRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.factories, name)
+}
+
+// Names returns the registered factory names in sorted order.
+func (r *ToolFactoryRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build invokes every registered factory except those named in skip (typically
+// tools the agent already has) and returns the tools produced. Factory errors are
+// collected and returned alongside the tools that were built successfully.
+func (r *ToolFactoryRegistry) Build(fctx ToolFactoryContext, skip map[string]bool) ([]shuttle.Tool, []error) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.factories))
+	factories := make(map[string]ToolFactory, len(r.factories))
+	for name, factory := range r.factories {
+		if skip[name] {
+			continue
+		}
+		names = append(names, name)
+		factories[name] = factory
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+
+	var tools []shuttle.Tool
+	var errs []error
+	for _, name := range names {
+		tool, err := factories[name](fctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tool factory %s: %w", name, err))
+			continue
+		}
+		if tool != nil {
+			tools = append(tools, tool)
+		}
+	}
+	return tools, errs
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package builtin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teradata-labs/loom/pkg/shuttle"
+)
+
+func TestToolFactoryRegistry_Register(t *testing.T) {
+	registry := NewToolFactoryRegistry()
+	factory := func(ToolFactoryContext) (shuttle.Tool, error) {
+		return &shuttle.MockTool{MockName: "custom"}, nil
+	}
+
+	require.NoError(t, registry.Register("custom", factory))
+	assert.Error(t, registry.Register("custom", factory), "duplicate names are rejected")
+	assert.Error(t, registry.Register("", factory))
+	assert.Error(t, registry.Register("nil_factory", nil))
+	assert.Equal(t, []string{"custom"}, registry.Names())
+
+	registry.Unregister("custom")
+	assert.Empty(t, registry.Names())
+}
+
+func TestToolFactoryRegistry_Build(t *testing.T) {
+	registry := NewToolFactoryRegistry()
+
+	var seen ToolFactoryContext
+	require.NoError(t, registry.Register("session_tool", func(fctx ToolFactoryContext) (shuttle.Tool, error) {
+		seen = fctx
+		return &shuttle.MockTool{MockName: "session_tool"}, nil
+	}))
+	require.NoError(t, registry.Register("broken", func(ToolFactoryContext) (shuttle.Tool, error) {
+		return nil, fmt.Errorf("missing credentials")
+	}))
+	require.NoError(t, registry.Register("opt_out", func(ToolFactoryContext) (shuttle.Tool, error) {
+		return nil, nil
+	}))
+	require.NoError(t, registry.Register("existing", func(ToolFactoryContext) (shuttle.Tool, error) {
+		t.Fatal("factory for an existing tool should not be invoked")
+		return nil, nil
+	}))
+
+	tools, errs := registry.Build(ToolFactoryContext{AgentID: "agent-1", SessionID: "sess-1"}, map[string]bool{"existing": true})
+
+	require.Len(t, tools, 1)
+	assert.Equal(t, "session_tool", tools[0].Name())
+	assert.Equal(t, "agent-1", seen.AgentID)
+	assert.Equal(t, "sess-1", seen.SessionID)
+
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "broken")
+}
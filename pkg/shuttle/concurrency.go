@@ -0,0 +1,146 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrConcurrencyLimitReached is returned when a tool is at its concurrency limit
+// and its policy is ConcurrencyPolicyFail.
+var ErrConcurrencyLimitReached = errors.New("tool concurrency limit reached")
+
+// ConcurrencyPolicy controls what happens to tool calls beyond a concurrency limit.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyPolicyQueue waits for a free slot (or context cancellation). This is the default.
+	ConcurrencyPolicyQueue ConcurrencyPolicy = "queue"
+
+	// ConcurrencyPolicyFail rejects the call immediately with a retryable error.
+	ConcurrencyPolicyFail ConcurrencyPolicy = "fail"
+)
+
+// ConcurrencyLimiter bounds how many executions of each tool run at once.
+// Limits are keyed by tool name, so a limiter shared by many executors (the default)
+// enforces the limit across every agent in the process, e.g. a whole spawn tree.
+// Tools without a configured limit run unrestricted.
+type ConcurrencyLimiter struct {
+	mu     sync.RWMutex
+	limits map[string]*toolSemaphore
+}
+
+// toolSemaphore is a counting semaphore for a single tool.
+type toolSemaphore struct {
+	slots  chan struct{}
+	policy ConcurrencyPolicy
+}
+
+// defaultConcurrencyLimiter is shared by all executors unless overridden.
+var defaultConcurrencyLimiter = NewConcurrencyLimiter()
+
+// NewConcurrencyLimiter creates an empty limiter with no tool limits.
+func NewConcurrencyLimiter() *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		limits: make(map[string]*toolSemaphore),
+	}
+}
+
+// DefaultConcurrencyLimiter returns the process-wide limiter used by executors by default.
+func DefaultConcurrencyLimiter() *ConcurrencyLimiter {
+	return defaultConcurrencyLimiter
+}
+
+// SetLimit configures the maximum number of concurrent executions for a tool.
+// A maxConcurrent of zero or less removes the limit. An empty policy defaults to
+// ConcurrencyPolicyQueue. Calls already holding a slot are unaffected by changes.
+func (l *ConcurrencyLimiter) SetLimit(toolName string, maxConcurrent int, policy ConcurrencyPolicy) error {
+	switch policy {
+	case "":
+		policy = ConcurrencyPolicyQueue
+	case ConcurrencyPolicyQueue, ConcurrencyPolicyFail:
+	default:
+		return fmt.Errorf("unknown concurrency policy for tool %s: %q", toolName, policy)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if maxConcurrent <= 0 {
+		delete(l.limits, toolName)
+		return nil
+	}
+
+	l.limits[toolName] = &toolSemaphore{
+		slots:  make(chan struct{}, maxConcurrent),
+		policy: policy,
+	}
+	return nil
+}
+
+// Limit returns the configured limit for a tool, or 0 if the tool is unrestricted.
+func (l *ConcurrencyLimiter) Limit(toolName string) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if sem, ok := l.limits[toolName]; ok {
+		return cap(sem.slots)
+	}
+	return 0
+}
+
+// InFlight returns the number of executions currently holding a slot for a tool.
+func (l *ConcurrencyLimiter) InFlight(toolName string) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if sem, ok := l.limits[toolName]; ok {
+		return len(sem.slots)
+	}
+	return 0
+}
+
+// Acquire reserves an execution slot for a tool. The returned release function must
+// be called when execution finishes. Returns ErrConcurrencyLimitReached when the tool
+// is saturated under ConcurrencyPolicyFail, or the context error if cancelled while queued.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, toolName string) (func(), error) {
+	l.mu.RLock()
+	sem, ok := l.limits[toolName]
+	l.mu.RUnlock()
+
+	if !ok {
+		return func() {}, nil
+	}
+
+	release := func() { <-sem.slots }
+
+	if sem.policy == ConcurrencyPolicyFail {
+		select {
+		case sem.slots <- struct{}{}:
+			return release, nil
+		default:
+			return nil, fmt.Errorf("%w: %s allows %d concurrent executions", ErrConcurrencyLimitReached, toolName, cap(sem.slots))
+		}
+	}
+
+	select {
+	case sem.slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for %s concurrency slot: %w", toolName, ctx.Err())
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimiter_Unlimited(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+
+	release, err := limiter.Acquire(context.Background(), "any_tool")
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 0, limiter.Limit("any_tool"))
+}
+
+func TestConcurrencyLimiter_FailPolicy(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	require.NoError(t, limiter.SetLimit("sql", 1, ConcurrencyPolicyFail))
+
+	release, err := limiter.Acquire(context.Background(), "sql")
+	require.NoError(t, err)
+	assert.Equal(t, 1, limiter.InFlight("sql"))
+
+	_, err = limiter.Acquire(context.Background(), "sql")
+	assert.True(t, errors.Is(err, ErrConcurrencyLimitReached))
+
+	release()
+	assert.Equal(t, 0, limiter.InFlight("sql"))
+
+	release, err = limiter.Acquire(context.Background(), "sql")
+	require.NoError(t, err)
+	release()
+}
+
+func TestConcurrencyLimiter_QueuePolicyRespectsContext(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	require.NoError(t, limiter.SetLimit("http", 1, ""))
+
+	release, err := limiter.Acquire(context.Background(), "http")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.Acquire(ctx, "http")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestConcurrencyLimiter_InvalidPolicy(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	assert.Error(t, limiter.SetLimit("tool", 2, ConcurrencyPolicy("drop")))
+}
+
+func TestConcurrencyLimiter_RemoveLimit(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	require.NoError(t, limiter.SetLimit("tool", 2, ConcurrencyPolicyQueue))
+	assert.Equal(t, 2, limiter.Limit("tool"))
+
+	require.NoError(t, limiter.SetLimit("tool", 0, ConcurrencyPolicyQueue))
+	assert.Equal(t, 0, limiter.Limit("tool"))
+}
+
+func TestExecutor_ConcurrencyLimitSharedAcrossExecutors(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	require.NoError(t, limiter.SetLimit("bounded", 2, ConcurrencyPolicyQueue))
+
+	var current, peak atomic.Int32
+	tool := &MockTool{
+		MockName: "bounded",
+		MockExecute: func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			n := current.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			current.Add(-1)
+			return &Result{Success: true}, nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		reg := NewRegistry()
+		reg.Register(tool)
+		exec := NewExecutor(reg)
+		exec.SetConcurrencyLimiter(limiter)
+
+		for j := 0; j < 3; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := exec.Execute(context.Background(), "bounded", map[string]interface{}{})
+				assert.NoError(t, err)
+				assert.True(t, result.Success)
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Equal(t, 0, limiter.InFlight("bounded"))
+}
+
+func TestExecutor_ConcurrencyLimitFailIsRetryable(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	require.NoError(t, limiter.SetLimit("bounded", 1, ConcurrencyPolicyFail))
+
+	hold, err := limiter.Acquire(context.Background(), "bounded")
+	require.NoError(t, err)
+	defer hold()
+
+	reg := NewRegistry()
+	reg.Register(&MockTool{MockName: "bounded"})
+	exec := NewExecutor(reg)
+	exec.SetConcurrencyLimiter(limiter)
+
+	result, err := exec.Execute(context.Background(), "bounded", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "concurrency_limit", result.Error.Code)
+	assert.True(t, result.Error.Retryable)
+}
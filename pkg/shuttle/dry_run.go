@@ -0,0 +1,87 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DryRunnable is a Tool with side effects that can preview a call without making it.
+// Tools that do not implement it have no preview and are executed directly.
+type DryRunnable interface {
+	Tool
+
+	// DryRun validates params like Execute and returns a result describing what
+	// Execute would do, without changing any state. Invalid params produce the same
+	// error results Execute would return.
+	DryRun(ctx context.Context, params map[string]interface{}) (*Result, error)
+}
+
+// ErrDryRunNotSupported is returned by Executor.DryRun for tools that do not implement DryRunnable.
+var ErrDryRunNotSupported = errors.New("tool does not support dry run")
+
+// Unwrapper is implemented by tools that wrap another tool, such as those returned by
+// RetryTool, NewPromptAwareTool, and middlewares built with WrapExecute.
+type Unwrapper interface {
+	Unwrap() Tool
+}
+
+// AsDryRunnable reports whether tool, or a tool it wraps, implements DryRunnable.
+func AsDryRunnable(tool Tool) (DryRunnable, bool) {
+	for tool != nil {
+		if d, ok := tool.(DryRunnable); ok {
+			return d, true
+		}
+		u, ok := tool.(Unwrapper)
+		if !ok {
+			return nil, false
+		}
+		tool = u.Unwrap()
+	}
+	return nil, false
+}
+
+// DryRun previews a call to a registered tool, so a caller can ask for confirmation
+// before running it with Execute. Returns ErrDryRunNotSupported if the tool has no
+// preview. Parameters are normalized as for Execute; permission checks are not
+// applied because nothing is executed.
+func (e *Executor) DryRun(ctx context.Context, toolName string, params map[string]interface{}) (*Result, error) {
+	tool, ok := e.registry.Get(toolName)
+	if !ok {
+		return nil, fmt.Errorf("tool not found: %s", toolName)
+	}
+	d, ok := AsDryRunnable(tool)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDryRunNotSupported, toolName)
+	}
+	return d.DryRun(ctx, normalizeParametersToSchema(tool, params))
+}
+
+// DryRunResult returns a successful dry-run result. plan is a one-line description of
+// what would happen; details are added to Data alongside "dry_run" and "plan".
+func DryRunResult(plan string, details map[string]interface{}) *Result {
+	data := make(map[string]interface{}, len(details)+2)
+	for k, v := range details {
+		data[k] = v
+	}
+	data["dry_run"] = true
+	data["plan"] = plan
+	return &Result{
+		Success:  true,
+		Data:     data,
+		Metadata: map[string]interface{}{"dry_run": true},
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dryRunMockTool previews calls instead of executing them.
+type dryRunMockTool struct {
+	MockTool
+	dryRuns int
+}
+
+func (m *dryRunMockTool) DryRun(ctx context.Context, params map[string]interface{}) (*Result, error) {
+	m.dryRuns++
+	return DryRunResult("would drop table "+params["table"].(string), map[string]interface{}{"table": params["table"]}), nil
+}
+
+func TestExecutor_DryRun(t *testing.T) {
+	tool := &dryRunMockTool{MockTool: MockTool{MockName: "drop_table"}}
+	reg := NewRegistry()
+	reg.Register(Chain(RetryTool(tool, RetryPolicy{}), TimingMiddleware()))
+	reg.Register(&MockTool{MockName: "read_table"})
+	exec := NewExecutor(reg)
+	ctx := context.Background()
+
+	result, err := exec.DryRun(ctx, "drop_table", map[string]interface{}{"table": "sales"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, true, result.Metadata["dry_run"])
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, "would drop table sales", data["plan"])
+	assert.Equal(t, "sales", data["table"])
+	assert.Equal(t, 1, tool.dryRuns)
+	assert.Equal(t, 0, tool.ExecuteCount)
+
+	_, err = exec.DryRun(ctx, "read_table", nil)
+	assert.ErrorIs(t, err, ErrDryRunNotSupported)
+
+	_, err = exec.DryRun(ctx, "missing", nil)
+	assert.ErrorContains(t, err, "tool not found")
+}
+
+func TestAsDryRunnable(t *testing.T) {
+	tool := &dryRunMockTool{}
+	d, ok := AsDryRunnable(Chain(tool, LoggingMiddleware(nil)))
+	require.True(t, ok)
+	assert.Same(t, tool, d)
+
+	_, ok = AsDryRunnable(RetryTool(&MockTool{}, RetryPolicy{}))
+	assert.False(t, ok)
+}
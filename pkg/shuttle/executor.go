@@ -54,6 +54,8 @@ type Executor struct {
 	toolRegistry        ToolRegistry        // Tool registry for dynamic tool discovery
 	mcpManager          MCPManager          // MCP manager for dynamic MCP tool registration
 	builtinToolProvider BuiltinToolProvider // Builtin tool provider for dynamic builtin tool registration
+	concurrencyLimiter  *ConcurrencyLimiter // Per-tool concurrency limits (shared process-wide by default)
+	defaultToolTimeout  atomic.Int64        // Tool timeout in ns (0 = DefaultToolTimeout, negative = none)
 
 	// Metrics for large parameter optimization
 	largeParamStores      atomic.Int64 // Count of parameters stored
@@ -65,8 +67,9 @@ type Executor struct {
 // NewExecutor creates a new tool executor.
 func NewExecutor(registry *Registry) *Executor {
 	return &Executor{
-		registry:  registry,
-		threshold: storage.DefaultSharedMemoryThreshold,
+		registry:           registry,
+		threshold:          storage.DefaultSharedMemoryThreshold,
+		concurrencyLimiter: defaultConcurrencyLimiter,
 	}
 }
 
@@ -105,7 +108,38 @@ func (e *Executor) SetBuiltinToolProvider(provider BuiltinToolProvider) {
 	e.builtinToolProvider = provider
 }
 
+// SetConcurrencyLimiter overrides the per-tool concurrency limiter.
+// By default executors share DefaultConcurrencyLimiter so limits apply across all agents.
+// Passing nil disables concurrency limiting for this executor.
+func (e *Executor) SetConcurrencyLimiter(limiter *ConcurrencyLimiter) {
+	e.concurrencyLimiter = limiter
+}
+
+// acquireToolSlot reserves a concurrency slot for the tool.
+// Returns a release function, or a failed Result if no slot could be obtained.
+func (e *Executor) acquireToolSlot(ctx context.Context, toolName string) (func(), *Result) {
+	if e.concurrencyLimiter == nil {
+		return func() {}, nil
+	}
+
+	release, err := e.concurrencyLimiter.Acquire(ctx, toolName)
+	if err != nil {
+		return nil, &Result{
+			Success: false,
+			Error: &Error{
+				Code:       "concurrency_limit",
+				Message:    err.Error(),
+				Retryable:  true,
+				Suggestion: "Wait for in-flight calls to finish and retry",
+			},
+		}
+	}
+	return release, nil
+}
+
 // Execute executes a tool by name with the given parameters.
+// StreamingTools run through ExecuteStream; see ContextWithToolEventHandler.
+// Each call is bounded by the tool's timeout; see TimeoutTool.
 func (e *Executor) Execute(ctx context.Context, toolName string, params map[string]interface{}) (*Result, error) {
 	tool, ok := e.registry.Get(toolName)
 	if !ok {
@@ -160,8 +194,13 @@ func (e *Executor) Execute(ctx context.Context, toolName string, params map[stri
 		}, nil
 	}
 
+	release, limitResult := e.acquireToolSlot(ctx, tool.Name())
+	if limitResult != nil {
+		return limitResult, nil
+	}
+
 	start := time.Now()
-	result, err := tool.Execute(ctx, finalParams)
+	result, err := e.invokeToolWithTimeout(ctx, tool, finalParams, release)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -240,8 +279,13 @@ func (e *Executor) ExecuteWithTool(ctx context.Context, tool Tool, params map[st
 		}, nil
 	}
 
+	release, limitResult := e.acquireToolSlot(ctx, tool.Name())
+	if limitResult != nil {
+		return limitResult, nil
+	}
+
 	start := time.Now()
-	result, err := tool.Execute(ctx, finalParams)
+	result, err := e.invokeToolWithTimeout(ctx, tool, finalParams, release)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -362,6 +362,12 @@ func (t *ContactHumanTool) Backend() string {
 	return "" // Backend-agnostic
 }
 
+// Timeout disables the executor's timeout: requests expire on their own timeout_seconds,
+// which may exceed the default.
+func (t *ContactHumanTool) Timeout() time.Duration {
+	return NoToolTimeout
+}
+
 // waitForResponse polls the store until a response is received or timeout occurs.
 func (t *ContactHumanTool) waitForResponse(ctx context.Context, requestID string, timeout time.Duration) (*HumanRequest, bool) {
 	deadline := t.now().Add(timeout)
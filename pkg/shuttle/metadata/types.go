@@ -35,6 +35,7 @@ type ToolMetadata struct {
 	// Prerequisites and requirements
 	Prerequisites []Prerequisite `yaml:"prerequisites,omitempty" json:"prerequisites,omitempty"`
 	RateLimit     *RateLimit     `yaml:"rate_limit,omitempty" json:"rate_limit,omitempty"`
+	Concurrency   *Concurrency   `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
 
 	// Backend/provider information
 	Providers []string `yaml:"providers,omitempty" json:"providers,omitempty"` // For multi-provider tools
@@ -102,3 +103,10 @@ type RateLimit struct {
 	Limits map[string]int `yaml:"limits,omitempty" json:"limits,omitempty"` // provider -> requests_per_month
 	Notes  string         `yaml:"notes,omitempty" json:"notes,omitempty"`
 }
+
+// Concurrency caps how many instances of the tool may run at once across all agents.
+// This protects shared backends from wide fan-outs of agents calling the same tool.
+type Concurrency struct {
+	MaxConcurrent int    `yaml:"max_concurrent" json:"max_concurrent"`         // 0 = unlimited
+	OnLimit       string `yaml:"on_limit,omitempty" json:"on_limit,omitempty"` // "queue" (default) or "fail" (retryable error)
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"time"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+	"go.uber.org/zap"
+)
+
+// ToolMiddleware wraps a tool with behavior that runs around every Execute call,
+// such as logging or metrics. Compose middlewares with Chain.
+type ToolMiddleware func(next Tool) Tool
+
+// ToolExecuteFunc has the signature of Tool.Execute.
+type ToolExecuteFunc func(ctx context.Context, params map[string]interface{}) (*Result, error)
+
+// Chain wraps tool with the middlewares. The first middleware is outermost, so
+// Chain(tool, a, b) runs a, then b, then the tool.
+//
+// Example:
+//
+//	tool = shuttle.Chain(tool, shuttle.LoggingMiddleware(logger), shuttle.MetricsMiddleware(tracer))
+func Chain(tool Tool, middlewares ...ToolMiddleware) Tool {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		tool = middlewares[i](tool)
+	}
+	return tool
+}
+
+// WrapExecute returns a tool that behaves like next except that calls go through
+// execute, which receives a function invoking next. Streaming tools still report
+// progress through the context's ToolEventHandler, and next's TimeoutTool limit is
+// kept. It is the building block for ToolMiddleware implementations.
+func WrapExecute(next Tool, execute func(ctx context.Context, params map[string]interface{}, next ToolExecuteFunc) (*Result, error)) Tool {
+	return &middlewareTool{Tool: next, execute: execute}
+}
+
+// middlewareTool delegates everything but Execute to the wrapped tool.
+type middlewareTool struct {
+	Tool
+	execute func(ctx context.Context, params map[string]interface{}, next ToolExecuteFunc) (*Result, error)
+}
+
+// Execute runs the middleware around the wrapped tool.
+func (m *middlewareTool) Execute(ctx context.Context, params map[string]interface{}) (*Result, error) {
+	return m.execute(ctx, params, func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		return invokeTool(ctx, m.Tool, params)
+	})
+}
+
+// Unwrap returns the wrapped tool.
+func (m *middlewareTool) Unwrap() Tool {
+	return m.Tool
+}
+
+// Timeout returns the wrapped tool's timeout, or 0 (the executor default) if it declares none.
+func (m *middlewareTool) Timeout() time.Duration {
+	if t, ok := m.Tool.(TimeoutTool); ok {
+		return t.Timeout()
+	}
+	return 0
+}
+
+// TimingMiddleware sets each result's ExecutionTimeMs to the wall-clock time of the
+// call, so tools do not need to time themselves.
+func TimingMiddleware() ToolMiddleware {
+	return func(next Tool) Tool {
+		return WrapExecute(next, func(ctx context.Context, params map[string]interface{}, next ToolExecuteFunc) (*Result, error) {
+			start := time.Now()
+			result, err := next(ctx, params)
+			if result != nil {
+				result.ExecutionTimeMs = time.Since(start).Milliseconds()
+			}
+			return result, err
+		})
+	}
+}
+
+// LoggingMiddleware logs every call with the tool name, outcome, and duration.
+// Successful calls are logged at debug level, failed results and errors at warn.
+// A nil logger disables logging.
+func LoggingMiddleware(logger *zap.Logger) ToolMiddleware {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return func(next Tool) Tool {
+		name := next.Name()
+		return WrapExecute(next, func(ctx context.Context, params map[string]interface{}, next ToolExecuteFunc) (*Result, error) {
+			start := time.Now()
+			result, err := next(ctx, params)
+			duration := time.Since(start)
+
+			switch {
+			case err != nil:
+				logger.Warn("Tool call failed",
+					zap.String("tool", name),
+					zap.Duration("duration", duration),
+					zap.Error(err))
+			case result == nil:
+				logger.Warn("Tool returned no result",
+					zap.String("tool", name),
+					zap.Duration("duration", duration))
+			case !result.Success:
+				fields := []zap.Field{
+					zap.String("tool", name),
+					zap.Duration("duration", duration),
+				}
+				if result.Error != nil {
+					fields = append(fields,
+						zap.String("error_code", result.Error.Code),
+						zap.String("error", result.Error.Message),
+						zap.Bool("retryable", result.Error.Retryable))
+				}
+				logger.Warn("Tool returned an error", fields...)
+			default:
+				logger.Debug("Tool call succeeded",
+					zap.String("tool", name),
+					zap.Duration("duration", duration),
+					zap.Int64("execution_time_ms", result.ExecutionTimeMs))
+			}
+			return result, err
+		})
+	}
+}
+
+// MetricsMiddleware records every call on the tracer: a MetricToolExecutions count
+// labelled with the tool name and status ("success", "failure" for error results, or
+// "error" for Go errors), and a MetricToolDuration sample of the result's
+// ExecutionTimeMs (or the measured time if the tool left it unset).
+func MetricsMiddleware(tracer observability.Tracer) ToolMiddleware {
+	if tracer == nil {
+		tracer = observability.NewNoOpTracer()
+	}
+	return func(next Tool) Tool {
+		name := next.Name()
+		return WrapExecute(next, func(ctx context.Context, params map[string]interface{}, next ToolExecuteFunc) (*Result, error) {
+			start := time.Now()
+			result, err := next(ctx, params)
+
+			status := "success"
+			durationMs := time.Since(start).Milliseconds()
+			switch {
+			case err != nil || result == nil:
+				status = "error"
+			case !result.Success:
+				status = "failure"
+			}
+			if result != nil && result.ExecutionTimeMs > 0 {
+				durationMs = result.ExecutionTimeMs
+			}
+
+			tracer.RecordMetric(observability.MetricToolExecutions, 1, map[string]string{
+				observability.AttrToolName: name,
+				"status":                   status,
+			})
+			tracer.RecordMetric(observability.MetricToolDuration, float64(durationMs), map[string]string{
+				observability.AttrToolName: name,
+				"status":                   status,
+			})
+			return result, err
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/teradata-labs/loom/pkg/observability"
+)
+
+// recordingMiddleware appends its name to calls before and after running the tool.
+func recordingMiddleware(name string, calls *[]string) ToolMiddleware {
+	return func(next Tool) Tool {
+		return WrapExecute(next, func(ctx context.Context, params map[string]interface{}, next ToolExecuteFunc) (*Result, error) {
+			*calls = append(*calls, name+" before")
+			result, err := next(ctx, params)
+			*calls = append(*calls, name+" after")
+			return result, err
+		})
+	}
+}
+
+func TestChain_Order(t *testing.T) {
+	var calls []string
+	mock := &MockTool{MockName: "query"}
+	mock.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		calls = append(calls, "tool")
+		return &Result{Success: true}, nil
+	}
+
+	tool := Chain(mock, recordingMiddleware("a", &calls), recordingMiddleware("b", &calls))
+	result, err := tool.Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"a before", "b before", "tool", "b after", "a after"}, calls)
+
+	assert.Equal(t, "query", tool.Name())
+	assert.Same(t, mock, Chain(mock), "no middlewares returns the tool unchanged")
+}
+
+func TestWrapExecute_KeepsTimeoutAndStreaming(t *testing.T) {
+	wrapped := Chain(&timeoutMockTool{timeout: NoToolTimeout}, TimingMiddleware())
+	timeoutTool, ok := wrapped.(TimeoutTool)
+	require.True(t, ok)
+	assert.Equal(t, NoToolTimeout, timeoutTool.Timeout())
+	assert.Equal(t, time.Duration(0), Chain(&MockTool{}, TimingMiddleware()).(TimeoutTool).Timeout())
+
+	streaming := &streamingMockTool{
+		events: []ToolEvent{
+			{Type: ToolEventProgress, Message: "step 1"},
+			{Type: ToolEventProgress, Message: "step 2"},
+			{Type: ToolEventResult, Result: &Result{Success: true}},
+		},
+	}
+	var progress []string
+	ctx := ContextWithToolEventHandler(context.Background(), func(_ string, event ToolEvent) {
+		progress = append(progress, event.Message)
+	})
+	result, err := Chain(streaming, TimingMiddleware()).Execute(ctx, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"step 1", "step 2"}, progress)
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	mock := &MockTool{}
+	mock.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		time.Sleep(20 * time.Millisecond)
+		return &Result{Success: true}, nil
+	}
+
+	result, err := Chain(mock, TimingMiddleware()).Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.ExecutionTimeMs, int64(20))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logging := LoggingMiddleware(zap.New(core))
+
+	ok := &MockTool{MockName: "ok"}
+	_, err := Chain(ok, logging).Execute(context.Background(), nil)
+	require.NoError(t, err)
+
+	failing := &MockTool{MockName: "failing"}
+	failing.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		return &Result{Error: &Error{Code: "NOT_FOUND", Message: "no such table"}}, nil
+	}
+	_, err = Chain(failing, logging).Execute(context.Background(), nil)
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "ok", entries[0].ContextMap()["tool"])
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, "NOT_FOUND", entries[1].ContextMap()["error_code"])
+
+	// A nil logger is allowed
+	_, err = Chain(ok, LoggingMiddleware(nil)).Execute(context.Background(), nil)
+	require.NoError(t, err)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	tracer := newMockTracer()
+	metrics := MetricsMiddleware(tracer)
+
+	ok := &MockTool{MockName: "ok"}
+	ok.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		return &Result{Success: true, ExecutionTimeMs: 42}, nil
+	}
+	broken := &MockTool{MockName: "broken"}
+	broken.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	_, err := Chain(ok, metrics).Execute(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = Chain(broken, metrics).Execute(context.Background(), nil)
+	require.Error(t, err)
+
+	require.Len(t, tracer.metrics, 4)
+	assert.Equal(t, observability.MetricToolExecutions, tracer.metrics[0].name)
+	assert.Equal(t, map[string]string{observability.AttrToolName: "ok", "status": "success"}, tracer.metrics[0].labels)
+	assert.Equal(t, observability.MetricToolDuration, tracer.metrics[1].name)
+	assert.Equal(t, float64(42), tracer.metrics[1].value)
+	assert.Equal(t, "error", tracer.metrics[2].labels["status"])
+	assert.Equal(t, "broken", tracer.metrics[3].labels[observability.AttrToolName])
+}
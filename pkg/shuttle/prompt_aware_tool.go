@@ -88,3 +88,8 @@ func (p *PromptAwareTool) Execute(ctx context.Context, params map[string]interfa
 func (p *PromptAwareTool) Backend() string {
 	return p.tool.Backend()
 }
+
+// Unwrap returns the wrapped tool.
+func (p *PromptAwareTool) Unwrap() Tool {
+	return p.tool
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy controls how RetryTool retries retryable tool errors.
+// Zero fields use the defaults from DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of Execute calls, including the first
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+
+	// Multiplier grows the backoff after each retry
+	Multiplier float64
+}
+
+// DefaultRetryPolicy returns 3 attempts with backoff starting at 500ms, doubling up to 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2.0,
+	}
+}
+
+// withDefaults fills zero fields from DefaultRetryPolicy.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	defaults := DefaultRetryPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaults.Multiplier
+	}
+	return p
+}
+
+// RetryingTool wraps a tool and retries results whose Error is Retryable.
+type RetryingTool struct {
+	tool   Tool
+	policy RetryPolicy
+}
+
+// RetryTool wraps a tool so results with a retryable Error are retried with exponential
+// backoff, up to policy.MaxAttempts. Successes, non-retryable errors, and Go errors from
+// Execute are returned immediately. Retries stop when ctx is done, returning the last
+// result. The returned result's ExecutionTimeMs is the total across attempts, and
+// Metadata["retry_attempts"] records the attempt count when more than one was made.
+//
+// Example:
+//
+//	tool := shuttle.RetryTool(builtin.NewManageEphemeralAgentsTool(handler, sessionID, agentID), shuttle.RetryPolicy{MaxAttempts: 4})
+func RetryTool(tool Tool, policy RetryPolicy) Tool {
+	return &RetryingTool{tool: tool, policy: policy.withDefaults()}
+}
+
+// Name returns the tool's unique identifier (delegates to wrapped tool).
+func (r *RetryingTool) Name() string {
+	return r.tool.Name()
+}
+
+// Description returns the tool description (delegates to wrapped tool).
+func (r *RetryingTool) Description() string {
+	return r.tool.Description()
+}
+
+// InputSchema returns the JSON Schema for tool parameters (delegates to wrapped tool).
+func (r *RetryingTool) InputSchema() *JSONSchema {
+	return r.tool.InputSchema()
+}
+
+// Backend returns the backend type this tool requires (delegates to wrapped tool).
+func (r *RetryingTool) Backend() string {
+	return r.tool.Backend()
+}
+
+// Unwrap returns the wrapped tool.
+func (r *RetryingTool) Unwrap() Tool {
+	return r.tool
+}
+
+// Timeout returns the wrapped tool's timeout, or 0 (the executor default) if it declares none.
+// The executor applies it to the whole call, retries included.
+func (r *RetryingTool) Timeout() time.Duration {
+	if t, ok := r.tool.(TimeoutTool); ok {
+		return t.Timeout()
+	}
+	return 0
+}
+
+// Execute runs the wrapped tool, retrying retryable errors according to the policy.
+// Streaming tools run through ExecuteStream, so each attempt still reports progress
+// through the context's ToolEventHandler.
+func (r *RetryingTool) Execute(ctx context.Context, params map[string]interface{}) (*Result, error) {
+	backoff := r.policy.InitialBackoff
+	var totalMs int64
+
+	for attempt := 1; ; attempt++ {
+		result, err := invokeTool(ctx, r.tool, params)
+		if err != nil || result == nil {
+			return result, err
+		}
+		totalMs += result.ExecutionTimeMs
+
+		retryable := result.Error != nil && result.Error.Retryable && !result.Success
+		if !retryable || attempt >= r.policy.MaxAttempts || !sleepContext(ctx, backoff) {
+			result.ExecutionTimeMs = totalMs
+			if attempt > 1 {
+				if result.Metadata == nil {
+					result.Metadata = make(map[string]interface{})
+				}
+				result.Metadata["retry_attempts"] = attempt
+			}
+			return result, nil
+		}
+
+		backoff = min(time.Duration(float64(backoff)*r.policy.Multiplier), r.policy.MaxBackoff)
+	}
+}
+
+// sleepContext waits for d and reports whether it finished before ctx was done.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingResults returns a MockTool whose first n calls fail with the given retryability.
+func failingResults(n int, retryable bool) *MockTool {
+	mock := &MockTool{}
+	calls := 0
+	mock.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		calls++
+		if calls <= n {
+			return &Result{Error: &Error{Code: "BUSY", Retryable: retryable}, ExecutionTimeMs: 10}, nil
+		}
+		return &Result{Success: true, Data: "ok", ExecutionTimeMs: 5}, nil
+	}
+	return mock
+}
+
+func fastPolicy(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+func TestRetryTool_RetriesUntilSuccess(t *testing.T) {
+	mock := failingResults(2, true)
+	tool := RetryTool(mock, fastPolicy(3))
+
+	result, err := tool.Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, 3, mock.ExecuteCount)
+	assert.Equal(t, int64(25), result.ExecutionTimeMs)
+	assert.Equal(t, 3, result.Metadata["retry_attempts"])
+	assert.Equal(t, mock.Name(), tool.Name())
+}
+
+func TestRetryTool_StopsAtMaxAttempts(t *testing.T) {
+	mock := failingResults(5, true)
+	result, err := RetryTool(mock, fastPolicy(2)).Execute(context.Background(), nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "BUSY", result.Error.Code)
+	assert.Equal(t, 2, mock.ExecuteCount)
+	assert.Equal(t, int64(20), result.ExecutionTimeMs)
+}
+
+func TestRetryTool_PassesThrough(t *testing.T) {
+	// Non-retryable errors are not retried
+	mock := failingResults(1, false)
+	result, err := RetryTool(mock, fastPolicy(3)).Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 1, mock.ExecuteCount)
+	assert.NotContains(t, result.Metadata, "retry_attempts")
+
+	// Nor are Go errors
+	mock = &MockTool{MockExecute: func(context.Context, map[string]interface{}) (*Result, error) {
+		return nil, errors.New("boom")
+	}}
+	_, err = RetryTool(mock, fastPolicy(3)).Execute(context.Background(), nil)
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 1, mock.ExecuteCount)
+}
+
+func TestRetryTool_RespectsContext(t *testing.T) {
+	mock := failingResults(5, true)
+	tool := RetryTool(mock, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := tool.Execute(ctx, nil)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, "BUSY", result.Error.Code)
+	assert.Equal(t, 1, mock.ExecuteCount)
+}
+
+func TestRetryTool_KeepsTimeoutAndStreaming(t *testing.T) {
+	wrapped := RetryTool(&timeoutMockTool{timeout: 7 * time.Second}, fastPolicy(2))
+	timeoutTool, ok := wrapped.(TimeoutTool)
+	require.True(t, ok)
+	assert.Equal(t, 7*time.Second, timeoutTool.Timeout())
+	assert.Equal(t, time.Duration(0), RetryTool(&MockTool{}, fastPolicy(2)).(TimeoutTool).Timeout())
+
+	streaming := &streamingMockTool{
+		events: []ToolEvent{
+			{Type: ToolEventProgress, Message: "step 1"},
+			{Type: ToolEventResult, Result: &Result{Success: true}},
+		},
+	}
+	var progress []string
+	ctx := ContextWithToolEventHandler(context.Background(), func(_ string, event ToolEvent) {
+		progress = append(progress, event.Message)
+	})
+	result, err := RetryTool(streaming, fastPolicy(2)).Execute(ctx, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"step 1"}, progress)
+	assert.Equal(t, 0, streaming.ExecuteCount, "streaming tools run through ExecuteStream")
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ToolEventType identifies the kind of event a StreamingTool emits.
+type ToolEventType string
+
+const (
+	// ToolEventProgress reports how far along the tool is
+	ToolEventProgress ToolEventType = "progress"
+
+	// ToolEventPartialOutput carries output produced before the tool finishes
+	ToolEventPartialOutput ToolEventType = "partial_output"
+
+	// ToolEventResult carries the final result and must be the last event sent
+	ToolEventResult ToolEventType = "result"
+)
+
+// ToolEvent is one update from a StreamingTool.
+type ToolEvent struct {
+	Type ToolEventType
+
+	// Message is a human-readable description of current activity
+	Message string
+
+	// Progress is the completion percentage (0-100), or 0 if unknown
+	Progress int32
+
+	// Data holds partial output for ToolEventPartialOutput events
+	Data interface{}
+
+	// Result is the final result for ToolEventResult events
+	Result *Result
+
+	// Timestamp when the event occurred (set by the executor if zero)
+	Timestamp time.Time
+}
+
+// StreamingTool is a Tool that can report progress while it runs. The Executor calls
+// ExecuteStream instead of Execute for tools implementing it.
+//
+// ExecuteStream returns a channel of events that ends with exactly one ToolEventResult
+// event, after which the tool closes the channel. The tool should stop and close the
+// channel when ctx is done. Execute must still work for callers that bypass the Executor.
+type StreamingTool interface {
+	Tool
+	ExecuteStream(ctx context.Context, params map[string]interface{}) (<-chan ToolEvent, error)
+}
+
+// ToolEventHandler receives the non-result events of a streaming tool run.
+type ToolEventHandler func(toolName string, event ToolEvent)
+
+type toolEventHandlerKey struct{}
+
+// ContextWithToolEventHandler stores a handler that the Executor calls with the progress
+// and partial-output events of streaming tools executed with the returned context.
+func ContextWithToolEventHandler(ctx context.Context, handler ToolEventHandler) context.Context {
+	return context.WithValue(ctx, toolEventHandlerKey{}, handler)
+}
+
+// ToolEventHandlerFromContext returns the handler stored by ContextWithToolEventHandler, or nil.
+func ToolEventHandlerFromContext(ctx context.Context) ToolEventHandler {
+	if handler, ok := ctx.Value(toolEventHandlerKey{}).(ToolEventHandler); ok {
+		return handler
+	}
+	return nil
+}
+
+// errStreamWithoutResult is returned when a streaming tool closes its channel without
+// sending a ToolEventResult.
+var errStreamWithoutResult = errors.New("tool stream ended without a result")
+
+// invokeTool runs a tool, preferring ExecuteStream for streaming tools. Intermediate
+// events go to the context's ToolEventHandler and the final result is returned.
+func invokeTool(ctx context.Context, tool Tool, params map[string]interface{}) (*Result, error) {
+	streaming, ok := tool.(StreamingTool)
+	if !ok {
+		return tool.Execute(ctx, params)
+	}
+
+	events, err := streaming.ExecuteStream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	handler := ToolEventHandlerFromContext(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil, errStreamWithoutResult
+			}
+			if event.Type == ToolEventResult {
+				return event.Result, nil
+			}
+			if handler != nil {
+				if event.Timestamp.IsZero() {
+					event.Timestamp = time.Now()
+				}
+				handler(tool.Name(), event)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingMockTool emits the given events from ExecuteStream.
+type streamingMockTool struct {
+	MockTool
+	events []ToolEvent
+}
+
+func (m *streamingMockTool) ExecuteStream(ctx context.Context, params map[string]interface{}) (<-chan ToolEvent, error) {
+	ch := make(chan ToolEvent)
+	go func() {
+		defer close(ch)
+		for _, event := range m.events {
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func TestExecutor_StreamingTool(t *testing.T) {
+	tool := &streamingMockTool{
+		MockTool: MockTool{MockName: "batch"},
+		events: []ToolEvent{
+			{Type: ToolEventProgress, Message: "profiling", Progress: 40},
+			{Type: ToolEventPartialOutput, Data: "row 1"},
+			{Type: ToolEventResult, Result: &Result{Success: true, Data: "done"}},
+		},
+	}
+	reg := NewRegistry()
+	reg.Register(tool)
+	exec := NewExecutor(reg)
+
+	var received []ToolEvent
+	ctx := ContextWithToolEventHandler(context.Background(), func(name string, event ToolEvent) {
+		assert.Equal(t, "batch", name)
+		received = append(received, event)
+	})
+
+	result, err := exec.Execute(ctx, "batch", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "done", result.Data)
+	assert.Equal(t, 0, tool.ExecuteCount, "Execute should not be called for streaming tools")
+
+	require.Len(t, received, 2)
+	assert.Equal(t, ToolEventProgress, received[0].Type)
+	assert.Equal(t, int32(40), received[0].Progress)
+	assert.False(t, received[0].Timestamp.IsZero())
+	assert.Equal(t, "row 1", received[1].Data)
+
+	// Without a handler the events are dropped
+	result, err = exec.ExecuteWithTool(context.Background(), tool, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestExecutor_StreamingToolWithoutResult(t *testing.T) {
+	tool := &streamingMockTool{
+		MockTool: MockTool{MockName: "broken"},
+		events:   []ToolEvent{{Type: ToolEventProgress, Message: "starting"}},
+	}
+	result, err := NewExecutor(NewRegistry()).ExecuteWithTool(context.Background(), tool, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "execution_failed", result.Error.Code)
+	assert.Contains(t, result.Error.Message, "without a result")
+}
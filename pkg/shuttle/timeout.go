@@ -0,0 +1,118 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultToolTimeout bounds each tool call made through an Executor, unless the tool
+// declares its own limit with TimeoutTool or the default is changed with
+// Executor.SetDefaultToolTimeout.
+const DefaultToolTimeout = 10 * time.Minute
+
+// NoToolTimeout, returned from TimeoutTool.Timeout, runs the tool without a deadline.
+const NoToolTimeout time.Duration = -1
+
+// ToolTimeoutCode is the Error.Code of results for tool calls that exceeded their timeout.
+const ToolTimeoutCode = "TOOL_TIMEOUT"
+
+// TimeoutTool is a Tool that declares its own execution time limit.
+type TimeoutTool interface {
+	Tool
+
+	// Timeout returns the limit for one call. Zero uses the executor default, and
+	// NoToolTimeout (or any negative value) disables the limit.
+	Timeout() time.Duration
+}
+
+// SetDefaultToolTimeout sets the limit for tools that do not declare their own.
+// Zero or negative disables it.
+func (e *Executor) SetDefaultToolTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = NoToolTimeout
+	}
+	e.defaultToolTimeout.Store(int64(timeout))
+}
+
+// toolTimeout returns the limit for a tool call, or a negative duration for none.
+func (e *Executor) toolTimeout(tool Tool) time.Duration {
+	if t, ok := tool.(TimeoutTool); ok {
+		if timeout := t.Timeout(); timeout != 0 {
+			return timeout
+		}
+	}
+	if timeout := time.Duration(e.defaultToolTimeout.Load()); timeout != 0 {
+		return timeout
+	}
+	return DefaultToolTimeout
+}
+
+// invokeToolWithTimeout runs a tool under its timeout. The tool's context is cancelled
+// at the deadline, and if the tool has not returned by then its eventual result is
+// abandoned and a TOOL_TIMEOUT result is returned instead. A tool that ignores ctx keeps
+// running in the background until it returns.
+//
+// release is called once the tool returns, not when the call is abandoned, so a tool
+// that ignores ctx keeps holding its concurrency slot for as long as it actually runs.
+func (e *Executor) invokeToolWithTimeout(ctx context.Context, tool Tool, params map[string]interface{}, release func()) (*Result, error) {
+	timeout := e.toolTimeout(tool)
+	if timeout < 0 {
+		defer release()
+		return invokeTool(ctx, tool, params)
+	}
+
+	toolCtx, cancel := context.WithTimeout(ctx, timeout)
+
+	type outcome struct {
+		result *Result
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer cancel()
+		result, err := invokeTool(toolCtx, tool, params)
+		release() // Before reporting, so the slot is free again when the call returns
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-done:
+		// A tool that stopped because of our deadline is reported as a timeout too
+		if ctx.Err() == nil && errors.Is(toolCtx.Err(), context.DeadlineExceeded) && (o.err != nil || o.result == nil || !o.result.Success) {
+			return toolTimeoutResult(tool.Name(), timeout), nil
+		}
+		return o.result, o.err
+	case <-toolCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return toolTimeoutResult(tool.Name(), timeout), nil
+	}
+}
+
+func toolTimeoutResult(toolName string, timeout time.Duration) *Result {
+	return &Result{
+		Success: false,
+		Error: &Error{
+			Code:       ToolTimeoutCode,
+			Message:    fmt.Sprintf("tool %s did not finish within %s", toolName, timeout),
+			Details:    map[string]interface{}{"timeout_ms": timeout.Milliseconds()},
+			Suggestion: "Narrow the request so the tool does less work, or try a different approach",
+		},
+	}
+}
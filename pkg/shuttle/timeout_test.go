@@ -0,0 +1,136 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timeoutMockTool declares a timeout.
+type timeoutMockTool struct {
+	MockTool
+	timeout time.Duration
+}
+
+func (m *timeoutMockTool) Timeout() time.Duration { return m.timeout }
+
+func TestExecutor_ToolTimeout(t *testing.T) {
+	exec := NewExecutor(NewRegistry())
+
+	// A tool that respects ctx stops at its deadline
+	stopped := make(chan struct{})
+	respectful := &timeoutMockTool{timeout: 20 * time.Millisecond}
+	respectful.MockExecute = func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		<-ctx.Done()
+		close(stopped)
+		return nil, ctx.Err()
+	}
+	result, err := exec.ExecuteWithTool(context.Background(), respectful, map[string]interface{}{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, ToolTimeoutCode, result.Error.Code)
+	assert.Contains(t, result.Error.Message, "20ms")
+	<-stopped
+
+	// A tool that ignores ctx is abandoned
+	release := make(chan struct{})
+	defer close(release)
+	stuck := &timeoutMockTool{timeout: 20 * time.Millisecond}
+	stuck.MockExecute = func(context.Context, map[string]interface{}) (*Result, error) {
+		<-release
+		return &Result{Success: true}, nil
+	}
+	start := time.Now()
+	result, err = exec.ExecuteWithTool(context.Background(), stuck, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, ToolTimeoutCode, result.Error.Code)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestExecutor_ToolTimeoutDefaults(t *testing.T) {
+	exec := NewExecutor(NewRegistry())
+	assert.Equal(t, DefaultToolTimeout, exec.toolTimeout(&MockTool{}))
+	assert.Equal(t, DefaultToolTimeout, exec.toolTimeout(&timeoutMockTool{}))
+	assert.Equal(t, time.Second, exec.toolTimeout(&timeoutMockTool{timeout: time.Second}))
+	assert.Equal(t, NoToolTimeout, exec.toolTimeout(&timeoutMockTool{timeout: NoToolTimeout}))
+
+	exec.SetDefaultToolTimeout(30 * time.Millisecond)
+	slow := &MockTool{MockExecute: func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+	result, err := exec.ExecuteWithTool(context.Background(), slow, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, ToolTimeoutCode, result.Error.Code)
+
+	exec.SetDefaultToolTimeout(0)
+	assert.Equal(t, NoToolTimeout, exec.toolTimeout(&MockTool{}))
+
+	// Fast tools are unaffected
+	result, err = exec.ExecuteWithTool(context.Background(), &MockTool{}, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestExecutor_ToolTimeoutParentCancel(t *testing.T) {
+	tool := &MockTool{MockExecute: func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	result, err := NewExecutor(NewRegistry()).ExecuteWithTool(ctx, tool, map[string]interface{}{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "execution_failed", result.Error.Code, "cancellation is not a timeout")
+}
+
+func TestExecutor_ToolTimeoutHoldsConcurrencySlot(t *testing.T) {
+	limiter := NewConcurrencyLimiter()
+	require.NoError(t, limiter.SetLimit("stuck", 1, ConcurrencyPolicyFail))
+	exec := NewExecutor(NewRegistry())
+	exec.SetConcurrencyLimiter(limiter)
+
+	// A tool that ignores ctx keeps its slot after its call is abandoned
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	stuck := &timeoutMockTool{MockTool: MockTool{MockName: "stuck"}, timeout: 20 * time.Millisecond}
+	stuck.MockExecute = func(context.Context, map[string]interface{}) (*Result, error) {
+		defer close(returned)
+		<-release
+		return &Result{Success: true}, nil
+	}
+	result, err := exec.ExecuteWithTool(context.Background(), stuck, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, ToolTimeoutCode, result.Error.Code)
+	assert.Equal(t, 1, limiter.InFlight("stuck"))
+
+	result, err = exec.ExecuteWithTool(context.Background(), stuck, map[string]interface{}{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "concurrency_limit", result.Error.Code)
+
+	// The slot is freed once the tool actually returns
+	close(release)
+	<-returned
+	assert.Eventually(t, func() bool { return limiter.InFlight("stuck") == 0 }, time.Second, 5*time.Millisecond)
+}
@@ -208,6 +208,14 @@ func NewNumberSchema(description string) *JSONSchema {
 	}
 }
 
+// NewIntegerSchema creates a new integer schema. Use WithRange to bound it.
+func NewIntegerSchema(description string) *JSONSchema {
+	return &JSONSchema{
+		Type:        "integer",
+		Description: description,
+	}
+}
+
 // NewBooleanSchema creates a new boolean schema.
 func NewBooleanSchema(description string) *JSONSchema {
 	return &JSONSchema{
@@ -216,6 +224,19 @@ func NewBooleanSchema(description string) *JSONSchema {
 	}
 }
 
+// NewEnumSchema creates a string schema that only accepts the given values.
+func NewEnumSchema(description string, values []string) *JSONSchema {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return &JSONSchema{
+		Type:        "string",
+		Description: description,
+		Enum:        enum,
+	}
+}
+
 // NewArraySchema creates a new array schema.
 func NewArraySchema(description string, items *JSONSchema) *JSONSchema {
 	return &JSONSchema{
@@ -256,6 +277,18 @@ func (s *JSONSchema) WithRange(min, max *float64) *JSONSchema {
 	return s
 }
 
+// WithMinimum sets the inclusive lower bound of a number or integer schema.
+func (s *JSONSchema) WithMinimum(min float64) *JSONSchema {
+	s.Minimum = &min
+	return s
+}
+
+// WithMaximum sets the inclusive upper bound of a number or integer schema.
+func (s *JSONSchema) WithMaximum(max float64) *JSONSchema {
+	s.Maximum = &max
+	return s
+}
+
 // WithLength adds length constraints to the schema.
 func (s *JSONSchema) WithLength(minLen, maxLen *int) *JSONSchema {
 	s.MinLength = minLen
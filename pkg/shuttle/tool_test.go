@@ -77,6 +77,46 @@ func TestNewNumberSchema(t *testing.T) {
 	}
 }
 
+func TestNewIntegerSchema(t *testing.T) {
+	schema := NewIntegerSchema("test integer").WithMinimum(1).WithMaximum(10)
+
+	if schema.Type != "integer" {
+		t.Errorf("Expected type 'integer', got %s", schema.Type)
+	}
+	data, err := schema.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if string(data) != `{"type":"integer","description":"test integer","minimum":1,"maximum":10}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+	if err := ValidateParams(NewObjectSchema("test", map[string]*JSONSchema{"n": schema}, nil), map[string]interface{}{"n": 11.0}); err == nil {
+		t.Error("Expected value above maximum to fail validation")
+	}
+}
+
+func TestNewEnumSchema(t *testing.T) {
+	schema := NewEnumSchema("priority", []string{"low", "high"})
+
+	if schema.Type != "string" {
+		t.Errorf("Expected type 'string', got %s", schema.Type)
+	}
+	data, err := schema.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if string(data) != `{"type":"string","description":"priority","enum":["low","high"]}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+	object := NewObjectSchema("test", map[string]*JSONSchema{"priority": schema}, nil)
+	if err := ValidateParams(object, map[string]interface{}{"priority": "high"}); err != nil {
+		t.Errorf("Expected enum value to pass validation, got %v", err)
+	}
+	if err := ValidateParams(object, map[string]interface{}{"priority": "urgent"}); err == nil {
+		t.Error("Expected value outside the enum to fail validation")
+	}
+}
+
 func TestNewBooleanSchema(t *testing.T) {
 	schema := NewBooleanSchema("test boolean")
 
@@ -0,0 +1,257 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationErrorCode is the Error.Code of results built by NewValidationErrorResult.
+const ValidationErrorCode = "VALIDATION_ERROR"
+
+// ValidationError lists every way params failed to match a tool's input schema.
+type ValidationError struct {
+	// Problems holds one message per failed check, e.g. `auto_subscribe[1]: expected string, got number`
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid parameters: " + strings.Join(e.Problems, "; ")
+}
+
+// ValidateParams checks params against a tool's input schema: required fields, types
+// (recursing into objects and array items), enums, numeric ranges, and string lengths.
+// Parameters not declared in the schema are allowed, as are properties with no type.
+// Returns a *ValidationError, or nil when params match.
+//
+// Example:
+//
+//	if err := shuttle.ValidateParams(t.InputSchema(), params); err != nil {
+//		return shuttle.NewValidationErrorResult(err), nil
+//	}
+func ValidateParams(schema *JSONSchema, params map[string]interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	v := &paramValidator{}
+	v.object("", schema, params)
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// NewValidationErrorResult converts an error from ValidateParams into a failed Result
+// with code VALIDATION_ERROR, listing the problems in Error.Details["problems"].
+func NewValidationErrorResult(err error) *Result {
+	details := map[string]interface{}{}
+	if verr, ok := err.(*ValidationError); ok {
+		details["problems"] = verr.Problems
+	}
+	return &Result{
+		Success: false,
+		Error: &Error{
+			Code:       ValidationErrorCode,
+			Message:    err.Error(),
+			Details:    details,
+			Suggestion: "Check the parameter names and types against the tool's input schema",
+		},
+	}
+}
+
+type paramValidator struct {
+	problems []string
+}
+
+func (v *paramValidator) fail(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "params"
+	}
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// object checks required fields and each declared property, in name order.
+func (v *paramValidator) object(path string, schema *JSONSchema, obj map[string]interface{}) {
+	for _, name := range schema.Required {
+		if value, ok := obj[name]; !ok || value == nil {
+			v.fail(joinParamPath(path, name), "required parameter is missing")
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if value, ok := obj[name]; ok && value != nil {
+			v.value(joinParamPath(path, name), schema.Properties[name], value)
+		}
+	}
+}
+
+// value checks one value against its schema.
+func (v *paramValidator) value(path string, schema *JSONSchema, value interface{}) {
+	if schema == nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			v.fail(path, "expected string, got %s", paramTypeName(value))
+			return
+		}
+		n := utf8.RuneCountInString(s)
+		if schema.MinLength != nil && n < *schema.MinLength {
+			v.fail(path, "must be at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && n > *schema.MaxLength {
+			v.fail(path, "must be at most %d characters", *schema.MaxLength)
+		}
+	case "number", "integer":
+		n, ok := paramNumber(value)
+		if !ok {
+			v.fail(path, "expected %s, got %s", schema.Type, paramTypeName(value))
+			return
+		}
+		if schema.Type == "integer" && n != math.Trunc(n) {
+			v.fail(path, "expected integer, got %v", n)
+			return
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			v.fail(path, "must be >= %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			v.fail(path, "must be <= %v", *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(path, "expected boolean, got %s", paramTypeName(value))
+			return
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			v.fail(path, "expected object, got %s", paramTypeName(value))
+			return
+		}
+		v.object(path, schema, obj)
+	case "array":
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			v.fail(path, "expected array, got %s", paramTypeName(value))
+			return
+		}
+		if schema.Items != nil {
+			for i := 0; i < rv.Len(); i++ {
+				if item := rv.Index(i).Interface(); item != nil {
+					v.value(fmt.Sprintf("%s[%d]", path, i), schema.Items, item)
+				}
+			}
+		}
+	}
+
+	if len(schema.Enum) > 0 && !paramInEnum(value, schema.Enum) {
+		allowed := make([]string, len(schema.Enum))
+		for i, e := range schema.Enum {
+			allowed[i] = fmt.Sprintf("%v", e)
+		}
+		v.fail(path, "must be one of [%s], got %v", strings.Join(allowed, ", "), value)
+	}
+}
+
+func joinParamPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// paramNumber converts JSON-decoded and Go numeric values to float64.
+func paramNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// paramInEnum compares numbers by value so an int enum matches a JSON float64.
+func paramInEnum(value interface{}, enum []interface{}) bool {
+	n, isNumber := paramNumber(value)
+	for _, e := range enum {
+		if isNumber {
+			if en, ok := paramNumber(e); ok && en == n {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(value, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// paramTypeName names a value's JSON type for error messages.
+func paramTypeName(value interface{}) string {
+	if _, ok := paramNumber(value); ok {
+		return "number"
+	}
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	}
+	if kind := reflect.ValueOf(value).Kind(); kind == reflect.Slice || kind == reflect.Array {
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
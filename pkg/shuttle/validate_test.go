@@ -0,0 +1,102 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package shuttle
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidationSchema() *JSONSchema {
+	maxRetries := 5.0
+	minName := 2
+	return NewObjectSchema("test", map[string]*JSONSchema{
+		"name":    NewStringSchema("name").WithLength(&minName, nil),
+		"mode":    NewStringSchema("mode").WithEnum("fast", "safe"),
+		"retries": &JSONSchema{Type: "integer", Maximum: &maxRetries},
+		"ratio":   NewNumberSchema("ratio"),
+		"dry_run": NewBooleanSchema("dry run"),
+		"topics":  NewArraySchema("topics", NewStringSchema("topic")),
+		"options": NewObjectSchema("options", map[string]*JSONSchema{
+			"depth": NewNumberSchema("depth"),
+		}, []string{"depth"}),
+		"anything": {},
+	}, []string{"name"})
+}
+
+func TestValidateParams_Valid(t *testing.T) {
+	var params map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "analyst",
+		"mode": "safe",
+		"retries": 3,
+		"ratio": 0.5,
+		"dry_run": true,
+		"topics": ["a", "b"],
+		"options": {"depth": 2},
+		"anything": [1, "x"],
+		"undeclared": "ignored"
+	}`), &params))
+	assert.NoError(t, ValidateParams(testValidationSchema(), params))
+
+	// Go-typed values from direct callers are accepted too
+	assert.NoError(t, ValidateParams(testValidationSchema(), map[string]interface{}{
+		"name":    "analyst",
+		"retries": 3,
+		"topics":  []string{"a"},
+	}))
+
+	assert.NoError(t, ValidateParams(nil, nil))
+}
+
+func TestValidateParams_Problems(t *testing.T) {
+	err := ValidateParams(testValidationSchema(), map[string]interface{}{
+		"mode":    "slow",
+		"retries": 2.5,
+		"ratio":   "high",
+		"dry_run": "yes",
+		"topics":  []interface{}{"a", 7},
+		"options": map[string]interface{}{},
+	})
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []string{
+		"name: required parameter is missing",
+		"dry_run: expected boolean, got string",
+		"mode: must be one of [fast, safe], got slow",
+		"options.depth: required parameter is missing",
+		"ratio: expected number, got string",
+		"retries: expected integer, got 2.5",
+		"topics[1]: expected string, got number",
+	}, verr.Problems)
+
+	err = ValidateParams(testValidationSchema(), map[string]interface{}{"name": "a", "retries": 9})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name: must be at least 2 characters")
+	assert.Contains(t, err.Error(), "retries: must be <= 5")
+}
+
+func TestNewValidationErrorResult(t *testing.T) {
+	err := ValidateParams(testValidationSchema(), map[string]interface{}{})
+	result := NewValidationErrorResult(err)
+	assert.False(t, result.Success)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, ValidationErrorCode, result.Error.Code)
+	assert.Equal(t, []string{"name: required parameter is missing"}, result.Error.Details["problems"])
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026 Teradata Corporation - All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+package types
+
+import "encoding/json"
+
+const (
+	// messageOverheadTokens approximates the role and formatting tokens of each message
+	messageOverheadTokens = 10
+	// imageTokenEstimate approximates the tokens of one image content block
+	imageTokenEstimate = 1600
+)
+
+// TokenCounterFunc returns the number of tokens a message occupies in the context window.
+type TokenCounterFunc func(msg Message) int
+
+// EstimateMessageTokens is the default TokenCounterFunc. It approximates one token per
+// four characters of text, tool call names, and tool call input, plus a fixed overhead
+// per message. Plug in a tokenizer-backed counter with TrimMessagesWithCounter when
+// accuracy matters.
+func EstimateMessageTokens(msg Message) int {
+	chars := len(msg.Content)
+	tokens := messageOverheadTokens
+	for _, block := range msg.ContentBlocks {
+		chars += len(block.Text)
+		if block.Image != nil {
+			tokens += imageTokenEstimate
+		}
+	}
+	for _, call := range msg.ToolCalls {
+		chars += len(call.Name)
+		if input, err := json.Marshal(call.Input); err == nil {
+			chars += len(input)
+		}
+	}
+	return tokens + chars/4
+}
+
+// TrimMessages drops the oldest conversation turns until messages fit in maxTokens, as
+// estimated by EstimateMessageTokens. See TrimMessagesWithCounter.
+func TrimMessages(messages []Message, maxTokens int, keepSystem bool) []Message {
+	return TrimMessagesWithCounter(messages, maxTokens, keepSystem, EstimateMessageTokens)
+}
+
+// TrimMessagesWithCounter drops the oldest conversation turns until messages fit in
+// maxTokens, as measured by counter (nil uses EstimateMessageTokens).
+//
+// A turn starts at a user message and runs to the next one, so an assistant's tool
+// calls are always dropped together with their tool results and the history never
+// starts mid-exchange. The most recent user turn (and everything after it) is always
+// kept, and with keepSystem so are system messages. If those alone exceed maxTokens
+// they are returned anyway; the budget is best effort, not a guarantee.
+//
+// The input slice is not modified. It is returned unchanged when it already fits or
+// when maxTokens <= 0.
+func TrimMessagesWithCounter(messages []Message, maxTokens int, keepSystem bool, counter TokenCounterFunc) []Message {
+	if maxTokens <= 0 || len(messages) == 0 {
+		return messages
+	}
+	if counter == nil {
+		counter = EstimateMessageTokens
+	}
+
+	costs := make([]int, len(messages))
+	total := 0
+	for i, msg := range messages {
+		costs[i] = counter(msg)
+		total += costs[i]
+	}
+	if total <= maxTokens {
+		return messages
+	}
+
+	// The most recent user turn is never dropped
+	lastTurn := len(messages) - 1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			lastTurn = i
+			break
+		}
+	}
+
+	dropped := make([]bool, len(messages))
+	for start := 0; start < lastTurn && total > maxTokens; {
+		end := start + 1
+		for end < lastTurn && messages[end].Role != "user" {
+			end++
+		}
+		for i := start; i < end; i++ {
+			if keepSystem && messages[i].Role == "system" {
+				continue
+			}
+			dropped[i] = true
+			total -= costs[i]
+		}
+		start = end
+	}
+
+	trimmed := make([]Message, 0, len(messages))
+	for i, msg := range messages {
+		if !dropped[i] {
+			trimmed = append(trimmed, msg)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Teradata
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countChars counts one token per character, which keeps budgets easy to reason about
+func countChars(msg Message) int {
+	return len(msg.Content)
+}
+
+func roles(messages []Message) string {
+	parts := make([]string, len(messages))
+	for i, msg := range messages {
+		parts[i] = msg.Role + ":" + msg.Content
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestTrimMessages_FitsUnchanged(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "sys"},
+		{Role: "user", Content: "hi"},
+	}
+	assert.Equal(t, messages, TrimMessagesWithCounter(messages, 100, true, countChars))
+	assert.Equal(t, messages, TrimMessagesWithCounter(messages, 0, true, countChars), "non-positive budget disables trimming")
+}
+
+func TestTrimMessages_DropsOldestTurns(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "sys"},
+		{Role: "user", Content: "u1"},
+		{Role: "assistant", Content: "a1"},
+		{Role: "user", Content: "u2"},
+		{Role: "assistant", Content: "a2"},
+		{Role: "user", Content: "u3"},
+	}
+	original := append([]Message(nil), messages...)
+
+	trimmed := TrimMessagesWithCounter(messages, 9, true, countChars)
+	assert.Equal(t, "system:sys user:u2 assistant:a2 user:u3", roles(trimmed))
+	assert.Equal(t, original, messages, "input is not modified")
+
+	trimmed = TrimMessagesWithCounter(messages, 9, false, countChars)
+	assert.Equal(t, "user:u2 assistant:a2 user:u3", roles(trimmed))
+}
+
+func TestTrimMessages_KeepsToolExchangesTogether(t *testing.T) {
+	messages := []Message{
+		{Role: "user", Content: "u1"},
+		{Role: "assistant", Content: "a1", ToolCalls: []ToolCall{{ID: "t1", Name: "query"}}},
+		{Role: "tool", Content: "lots of rows", ToolUseID: "t1"},
+		{Role: "assistant", Content: "a2"},
+		{Role: "user", Content: "u2"},
+	}
+
+	trimmed := TrimMessagesWithCounter(messages, 5, true, countChars)
+	require.Len(t, trimmed, 1)
+	assert.Equal(t, "user:u2", roles(trimmed))
+}
+
+func TestTrimMessages_AlwaysKeepsLatestUserTurn(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "system prompt"},
+		{Role: "user", Content: "old"},
+		{Role: "user", Content: "a very long latest question"},
+		{Role: "assistant", Content: "partial", ToolCalls: []ToolCall{{ID: "t1", Name: "query"}}},
+		{Role: "tool", Content: "result", ToolUseID: "t1"},
+	}
+
+	// Over budget even after trimming: system and the latest turn survive
+	trimmed := TrimMessagesWithCounter(messages, 1, true, countChars)
+	assert.Equal(t, "system:system prompt user:a very long latest question assistant:partial tool:result", roles(trimmed))
+}
+
+func TestEstimateMessageTokens(t *testing.T) {
+	assert.Equal(t, messageOverheadTokens, EstimateMessageTokens(Message{Role: "user"}))
+
+	text := EstimateMessageTokens(Message{Role: "user", Content: strings.Repeat("a", 400)})
+	assert.Equal(t, messageOverheadTokens+100, text)
+
+	withTool := EstimateMessageTokens(Message{
+		Role:      "assistant",
+		ToolCalls: []ToolCall{{Name: "query", Input: map[string]interface{}{"sql": strings.Repeat("x", 100)}}},
+	})
+	assert.Greater(t, withTool, messageOverheadTokens+25)
+
+	withImage := EstimateMessageTokens(Message{
+		Role:          "user",
+		ContentBlocks: []ContentBlock{{Type: "image", Image: &ImageContent{}}},
+	})
+	assert.Equal(t, messageOverheadTokens+imageTokenEstimate, withImage)
+
+	// The default counter is used when none is given
+	messages := []Message{{Role: "user", Content: strings.Repeat("a", 400)}, {Role: "user", Content: "b"}}
+	assert.Len(t, TrimMessagesWithCounter(messages, 20, true, nil), 1)
+	assert.Len(t, TrimMessages(messages, 20, true), 1)
+}
@@ -10,6 +10,8 @@ package types
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
@@ -180,6 +182,16 @@ type LLMProvider interface {
 // Implementations should be lightweight and non-blocking.
 type TokenCallback func(token string)
 
+// ChatChunk is one event from a channel-based chat stream.
+// Content chunks carry a Delta; the last chunk has Done set and carries either the
+// complete Response (including stop reason and token usage) or Err.
+type ChatChunk struct {
+	Delta    string       // Incremental text content
+	Done     bool         // True on the final chunk
+	Response *LLMResponse // Complete response (final chunk only, nil if Err is set)
+	Err      error        // Stream failure (final chunk only)
+}
+
 // StreamingLLMProvider extends LLMProvider with token streaming support.
 // Providers implement this interface if they support real-time token streaming.
 // Use the SupportsStreaming helper to check if a provider implements this interface.
@@ -315,6 +327,77 @@ func (s *Session) MessageCount() int32 {
 	return int32(count)
 }
 
+// Usage returns the session's accumulated cost and token usage.
+// Thread-safe via RLock.
+func (s *Session) Usage() (costUSD float64, tokens int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.TotalCostUSD, s.TotalTokens
+}
+
+// SetContext stores a typed value in the session context under key.
+// The value must be JSON-marshalable because Context is persisted as JSON in the
+// session record; an error is returned (and nothing is stored) otherwise.
+// Thread-safe via Lock.
+func (s *Session) SetContext(key string, value interface{}) error {
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Errorf("context value for %q is not JSON-marshalable: %w", key, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Context == nil {
+		s.Context = make(map[string]interface{})
+	}
+	s.Context[key] = value
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetContext decodes the session context value stored under key into out,
+// which must be a non-nil pointer. Returns false if the key is not present.
+//
+// Values are decoded through a JSON round-trip, so this works both for values set
+// in this process and for values restored from the session store (which come back
+// as generic maps and slices).
+// Thread-safe via RLock.
+func (s *Session) GetContext(key string, out interface{}) (bool, error) {
+	s.mu.RLock()
+	value, ok := s.Context[key]
+	s.mu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return true, fmt.Errorf("failed to marshal context value for %q: %w", key, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return true, fmt.Errorf("failed to decode context value for %q: %w", key, err)
+	}
+	return true, nil
+}
+
+// DeleteContext removes the session context value stored under key.
+// Thread-safe via Lock.
+func (s *Session) DeleteContext(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.Context, key)
+	s.UpdatedAt = time.Now()
+}
+
+// MarshalContext serializes the session context to JSON for persistence.
+// Thread-safe via RLock.
+func (s *Session) MarshalContext() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return json.Marshal(s.Context)
+}
+
 // ExecutionStage represents the current stage of agent execution.
 type ExecutionStage string
 
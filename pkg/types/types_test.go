@@ -15,6 +15,7 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -122,3 +123,59 @@ func TestSession_MessageCount_ThreadSafe(t *testing.T) {
 		t.Errorf("Final MessageCount() = %d, want 100", finalCount)
 	}
 }
+
+func TestSession_TypedContext(t *testing.T) {
+	type taskSpec struct {
+		Dataset string   `json:"dataset"`
+		Columns []string `json:"columns"`
+		Limit   int      `json:"limit"`
+	}
+
+	session := &Session{ID: "sess-1"}
+	spec := taskSpec{Dataset: "sales", Columns: []string{"region", "revenue"}, Limit: 10}
+
+	if err := session.SetContext("task", spec); err != nil {
+		t.Fatalf("SetContext failed: %v", err)
+	}
+
+	var got taskSpec
+	ok, err := session.GetContext("task", &got)
+	if err != nil || !ok {
+		t.Fatalf("GetContext() = %v, %v; want true, nil", ok, err)
+	}
+	if got.Dataset != "sales" || len(got.Columns) != 2 || got.Limit != 10 {
+		t.Errorf("GetContext() decoded %+v, want %+v", got, spec)
+	}
+
+	// Simulate a persistence round-trip: values come back as generic JSON types
+	data, err := session.MarshalContext()
+	if err != nil {
+		t.Fatalf("MarshalContext failed: %v", err)
+	}
+	restored := &Session{ID: "sess-1"}
+	if err := json.Unmarshal(data, &restored.Context); err != nil {
+		t.Fatalf("unmarshal context: %v", err)
+	}
+	var restoredSpec taskSpec
+	if ok, err := restored.GetContext("task", &restoredSpec); err != nil || !ok {
+		t.Fatalf("GetContext() after restore = %v, %v; want true, nil", ok, err)
+	}
+	if restoredSpec.Dataset != "sales" || restoredSpec.Columns[1] != "revenue" {
+		t.Errorf("restored context = %+v, want %+v", restoredSpec, spec)
+	}
+
+	// Missing keys report false without error
+	if ok, err := session.GetContext("missing", &got); ok || err != nil {
+		t.Errorf("GetContext(missing) = %v, %v; want false, nil", ok, err)
+	}
+
+	// Non-marshalable values are rejected
+	if err := session.SetContext("bad", make(chan int)); err == nil {
+		t.Error("SetContext should reject non-JSON-marshalable values")
+	}
+
+	session.DeleteContext("task")
+	if ok, _ := session.GetContext("task", &got); ok {
+		t.Error("expected task context to be deleted")
+	}
+}
@@ -361,6 +361,7 @@ func validateTools(tools []interface{}) ([]ValidationError, []ValidationWarning)
 	communicationTools := []string{
 		"send_message",
 		"publish",
+		"bus_stats",
 		"shared_memory_read",
 		"shared_memory_write",
 		"top_n_query",